@@ -395,3 +395,68 @@ func TestNEP17ImportToken(t *testing.T) {
 		})
 	})
 }
+
+func TestNEP17Approvals(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+
+	h := testcli.DeployContract(t, e, "testdata/approvable/token.go", "testdata/approvable/token.yml",
+		testcli.ValidatorWallet, testcli.ValidatorAddr, testcli.ValidatorPass)
+
+	allowanceArgs := []string{
+		"neo-go", "wallet", "nep17", "allowance",
+		"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--address", testcli.ValidatorAddr,
+		"--token", h.StringLE(),
+	}
+	approveArgs := []string{
+		"neo-go", "wallet", "nep17", "approve", "--force",
+		"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--from", testcli.ValidatorAddr,
+		"--token", h.StringLE(),
+		"--spender", testcli.TestWalletMultiAccount1,
+	}
+	revokeArgs := slices.Clone(approveArgs)
+	revokeArgs[3] = "revoke"
+
+	checkAllowance := func(t *testing.T, amount string) {
+		e.Run(t, append(allowanceArgs, testcli.TestWalletMultiAccount1)...)
+		e.CheckNextLine(t, "^Account "+testcli.ValidatorAddr)
+		e.CheckNextLine(t, "^\\s*Spender\\s*:\\s*"+testcli.TestWalletMultiAccount1+" \\("+testcli.TestWalletMultiAccount1Hash.StringLE()+"\\)$")
+		e.CheckNextLine(t, "^\\s*Allowance\\s*:\\s*"+amount+" APPR$")
+		e.CheckEOF(t)
+	}
+
+	t.Run("no spenders", func(t *testing.T) {
+		e.RunWithError(t, allowanceArgs...)
+	})
+	t.Run("missing amount", func(t *testing.T) {
+		e.RunWithErrorCheck(t, `Required flag "amount" not set`, approveArgs...)
+	})
+	t.Run("invalid amount", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.RunWithError(t, append(approveArgs, "--amount", "-1")...)
+	})
+
+	checkAllowance(t, "0")
+
+	e.In.WriteString(testcli.ValidatorPass + "\r")
+	e.Run(t, append(approveArgs, "--amount", "12.5")...)
+	e.CheckTxPersisted(t)
+	checkAllowance(t, "12.5")
+
+	t.Run("contract spender", func(t *testing.T) {
+		e.Run(t, append(allowanceArgs, h.StringLE())...)
+		e.CheckNextLine(t, "^Account "+testcli.ValidatorAddr)
+		e.CheckNextLine(t, "^\\s*Spender\\s*:\\s*"+address.Uint160ToString(h))
+		e.CheckNextLine(t, "^\\s*Contract\\s*:\\s*Approvable token$")
+		e.CheckNextLine(t, "^\\s*Allowance\\s*:\\s*0 APPR$")
+		e.CheckEOF(t)
+	})
+
+	e.In.WriteString(testcli.ValidatorPass + "\r")
+	e.Run(t, revokeArgs...)
+	e.CheckTxPersisted(t)
+	checkAllowance(t, "0")
+}
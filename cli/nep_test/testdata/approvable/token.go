@@ -0,0 +1,129 @@
+// Package approvable contains a simple NEP-17 token implementing non-standard
+// approve/allowance/transferFrom extension for CLI tests.
+package approvable
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+const (
+	totalSupply     = 1000_00000000
+	balancePrefix   = 0x01
+	allowancePrefix = 0x02
+)
+
+func _deploy(_ any, isUpdate bool) {
+	if isUpdate {
+		return
+	}
+	owner := runtime.GetScriptContainer().Sender
+	storage.Put(storage.GetContext(), balanceKey(owner), totalSupply)
+	runtime.Notify("Transfer", nil, owner, totalSupply)
+}
+
+func balanceKey(holder interop.Hash160) []byte {
+	return append([]byte{balancePrefix}, holder...)
+}
+
+func allowanceKey(owner, spender interop.Hash160) []byte {
+	return append(append([]byte{allowancePrefix}, owner...), spender...)
+}
+
+func getInt(ctx storage.Context, key []byte) int {
+	val := storage.Get(ctx, key)
+	if val == nil {
+		return 0
+	}
+	return val.(int)
+}
+
+// Symbol returns the token symbol.
+func Symbol() string {
+	return "APPR"
+}
+
+// Decimals returns the token decimals.
+func Decimals() int {
+	return 8
+}
+
+// TotalSupply returns the token total supply value.
+func TotalSupply() int {
+	return totalSupply
+}
+
+// BalanceOf returns the amount of token on the specified address.
+func BalanceOf(holder interop.Hash160) int {
+	return getInt(storage.GetReadOnlyContext(), balanceKey(holder))
+}
+
+// Transfer transfers tokens from one account to another.
+func Transfer(from, to interop.Hash160, amount int, data any) bool {
+	if !runtime.CheckWitness(from) {
+		return false
+	}
+	return move(storage.GetContext(), from, to, amount, data)
+}
+
+// Approve allows spender to transfer up to amount of owner's tokens.
+func Approve(owner, spender interop.Hash160, amount int) bool {
+	if len(spender) != interop.Hash160Len || amount < 0 || !runtime.CheckWitness(owner) {
+		return false
+	}
+	ctx := storage.GetContext()
+	if amount == 0 {
+		storage.Delete(ctx, allowanceKey(owner, spender))
+	} else {
+		storage.Put(ctx, allowanceKey(owner, spender), amount)
+	}
+	runtime.Notify("Approval", owner, spender, amount)
+	return true
+}
+
+// Allowance returns the amount of owner's tokens spender can transfer.
+func Allowance(owner, spender interop.Hash160) int {
+	return getInt(storage.GetReadOnlyContext(), allowanceKey(owner, spender))
+}
+
+// TransferFrom transfers approved tokens of from account.
+func TransferFrom(spender, from, to interop.Hash160, amount int, data any) bool {
+	if !runtime.CheckWitness(spender) {
+		return false
+	}
+	ctx := storage.GetContext()
+	allowed := getInt(ctx, allowanceKey(from, spender))
+	if allowed < amount {
+		return false
+	}
+	if allowed == amount {
+		storage.Delete(ctx, allowanceKey(from, spender))
+	} else {
+		storage.Put(ctx, allowanceKey(from, spender), allowed-amount)
+	}
+	return move(ctx, from, to, amount, data)
+}
+
+func move(ctx storage.Context, from, to interop.Hash160, amount int, data any) bool {
+	if len(to) != interop.Hash160Len || amount < 0 {
+		return false
+	}
+	balance := getInt(ctx, balanceKey(from))
+	if balance < amount {
+		return false
+	}
+	if balance == amount {
+		storage.Delete(ctx, balanceKey(from))
+	} else {
+		storage.Put(ctx, balanceKey(from), balance-amount)
+	}
+	storage.Put(ctx, balanceKey(to), getInt(ctx, balanceKey(to))+amount)
+	runtime.Notify("Transfer", from, to, amount)
+	if management.GetContract(to) != nil {
+		contract.Call(to, "onNEP17Payment", contract.All, from, amount, data)
+	}
+	return true
+}
@@ -4,12 +4,14 @@ Package options contains a set of common CLI options and helper functions to use
 package options
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -26,7 +28,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/ledger"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -60,6 +64,18 @@ var Wallet = []cli.Flag{
 	},
 }
 
+// Ledger is a set of flags used to sign with a Ledger hardware wallet.
+var Ledger = []cli.Flag{
+	&cli.BoolFlag{
+		Name:  "ledger",
+		Usage: "Use Ledger device (Neo N3 application) to sign with the wallet account key",
+	},
+	&cli.UintFlag{
+		Name:  "ledger-index",
+		Usage: "Index of the Ledger key in the m/44'/888'/0'/0 derivation path",
+	},
+}
+
 // Network is a set of flags for choosing the network to operate on
 // (privnet/mainnet/testnet).
 var Network = []cli.Flag{
@@ -340,10 +356,10 @@ func GetRPCWithActor(gctx context.Context, ctx *cli.Context, signers []actor.Sig
 	return c, a, nil
 }
 
-// GetAccFromContext returns account and wallet from context. If address is not set, default address is used.
-func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error) {
-	var addr util.Uint160
-
+// GetWalletFromContext opens the wallet specified by the --wallet or
+// --wallet-config flag. Password from the wallet config is returned as well if
+// it's used (nil is returned otherwise).
+func GetWalletFromContext(ctx *cli.Context) (*wallet.Wallet, *string, error) {
 	wPath := ctx.String("wallet")
 	walletConfigPath := ctx.String("wallet-config")
 	if len(wPath) != 0 && len(walletConfigPath) != 0 {
@@ -366,6 +382,17 @@ func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error
 	if err != nil {
 		return nil, nil, err
 	}
+	return wall, pass, nil
+}
+
+// GetAccFromContext returns account and wallet from context. If address is not set, default address is used.
+func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error) {
+	var addr util.Uint160
+
+	wall, pass, err := GetWalletFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 	addrFlag := ctx.Generic("address").(*flags.Address)
 	if addrFlag.IsSet {
 		addr = addrFlag.Uint160()
@@ -376,10 +403,53 @@ func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error
 		}
 	}
 
-	acc, err := GetUnlockedAccount(wall, addr, pass)
+	acc, err := GetSigningAccount(ctx, wall, addr, pass)
 	return acc, wall, err
 }
 
+// GetSigningAccount returns account from wallet ready to sign transactions. If
+// --ledger flag is set, the key from Ledger device is attached to the account
+// (it must be the account key or one of its multisignature keys, standard
+// account for the Ledger key is created if it's missing in the wallet),
+// otherwise the account is unlocked with GetUnlockedAccount.
+func GetSigningAccount(ctx *cli.Context, wall *wallet.Wallet, addr util.Uint160, pass *string) (*wallet.Account, error) {
+	if !ctx.Bool("ledger") {
+		return GetUnlockedAccount(wall, addr, pass)
+	}
+	dev, err := ledger.Open()
+	if err != nil {
+		return nil, fmt.Errorf("can't open Ledger device: %w", err)
+	}
+	s, err := ledger.NewSigner(dev, ledger.AccountPath(uint32(ctx.Uint("ledger-index"))))
+	if err != nil {
+		_ = dev.Close()
+		return nil, fmt.Errorf("can't get Ledger key: %w", err)
+	}
+	acc := wall.GetAccount(addr)
+	if acc == nil {
+		if s.PublicKey().GetScriptHash() == addr {
+			return wallet.NewAccountFromSigner(s), nil
+		}
+		_ = dev.Close()
+		return nil, fmt.Errorf("wallet contains no account for '%s'", address.Uint160ToString(addr))
+	}
+	var pubs [][]byte
+	if acc.Contract != nil {
+		if pub, ok := vm.ParseSignatureContract(acc.Contract.Script); ok {
+			pubs = [][]byte{pub}
+		} else if _, multi, ok := vm.ParseMultiSigContract(acc.Contract.Script); ok {
+			pubs = multi
+		}
+	}
+	if !slices.ContainsFunc(pubs, func(pub []byte) bool { return bytes.Equal(pub, s.PublicKey().Bytes()) }) {
+		_ = dev.Close()
+		return nil, fmt.Errorf("key %s (%s) from Ledger doesn't belong to account %s",
+			s.PublicKey().StringCompressed(), s.Path(), acc.Address)
+	}
+	acc.SetSigner(s)
+	return acc, nil
+}
+
 // GetUnlockedAccount returns account from wallet, address and uses pass to unlock specified account if given.
 // If the password is not given, then it is requested from user.
 func GetUnlockedAccount(wall *wallet.Wallet, addr util.Uint160, pass *string) (*wallet.Account, error) {
@@ -2,6 +2,7 @@ package paramcontext
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 
@@ -18,6 +19,9 @@ func InitAndSave(net netmode.Magic, tx *transaction.Transaction, acc *wallet.Acc
 	scCtx := context.NewParameterContext(context.TransactionType, net, tx)
 	if acc != nil && acc.CanSign() {
 		sign := acc.SignHashable(net, tx)
+		if sign == nil {
+			return errors.New("can't sign the transaction")
+		}
 		if err := scCtx.AddSignature(acc.ScriptHash(), acc.Contract, acc.PublicKey(), sign); err != nil {
 			return fmt.Errorf("can't add signature: %w", err)
 		}
@@ -50,8 +50,11 @@ func TestDBRestoreDump(t *testing.T) {
 	// Big count.
 	e.RunWithError(t, append(baseArgs, "--count", "1000")...)
 
+	t.Run("zero workers", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "number of workers must be positive", append(baseArgs, "--workers", "0")...)
+	})
 	// Continue 15..25
-	e.Run(t, append(baseArgs, "--count", "10")...)
+	e.Run(t, append(baseArgs, "--count", "10", "--workers", "3")...)
 
 	// Continue till end.
 	e.Run(t, baseArgs...)
@@ -156,3 +159,130 @@ func TestDBDumpRestoreIncremental(t *testing.T) {
 	// Restore second 15 blocks from incremental dump.
 	e.Run(t, append(restoreBaseArgs, "--in", incDump, "-n", "--count", "15")...)
 }
+
+func TestDBVerify(t *testing.T) {
+	tmpDir := t.TempDir()
+	chainPath := filepath.Join(tmpDir, "neogotestchain")
+
+	cfg, err := config.LoadFile(filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.LevelDB
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = chainPath
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+
+	cfgPath := filepath.Join(tmpDir, "protocol.unit_testnet.yml")
+	require.NoError(t, os.WriteFile(cfgPath, out, os.ModePerm))
+
+	e := testcli.NewExecutor(t, false)
+	e.Run(t, "neo-go", "db", "restore", "--unittest", "--config-path", tmpDir, "--in", inDump)
+
+	baseArgs := []string{"neo-go", "db", "verify", "--unittest", "--config-path", tmpDir}
+
+	t.Run("excessive parameters", func(t *testing.T) {
+		e.RunWithError(t, append(baseArgs, "something")...)
+	})
+	t.Run("zero workers", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "number of workers must be positive", append(baseArgs, "--workers", "0")...)
+	})
+	t.Run("too many blocks", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "chain is not that high", append(baseArgs, "--start", "10", "--count", "100")...)
+	})
+	t.Run("database", func(t *testing.T) {
+		e.Run(t, baseArgs...)
+		e.CheckNextLine(t, `^Verified blocks: 51 \(0-50\)$`)
+		e.CheckNextLine(t, `^Errors: 0$`)
+		e.CheckNextLine(t, `^Time: `)
+		e.CheckEOF(t)
+	})
+	t.Run("database range with MPT", func(t *testing.T) {
+		e.Run(t, append(baseArgs, "--start", "10", "--count", "5", "--workers", "2", "--mpt")...)
+		e.CheckNextLine(t, `^Verified blocks: 5 \(10-14\)$`)
+		e.CheckNextLine(t, `^Errors: 0$`)
+	})
+	t.Run("dump", func(t *testing.T) {
+		t.Run("with start", func(t *testing.T) {
+			e.RunWithErrorCheckExit(t, "can't be used with --in", append(baseArgs, "--in", inDump, "--start", "1")...)
+		})
+		t.Run("missing file", func(t *testing.T) {
+			e.RunWithError(t, append(baseArgs, "--in", filepath.Join(tmpDir, "nonexistent.acc"))...)
+		})
+		t.Run("too many blocks", func(t *testing.T) {
+			e.RunWithErrorCheckExit(t, "input file has only", append(baseArgs, "--in", inDump, "--count", "100")...)
+		})
+		t.Run("good", func(t *testing.T) {
+			e.Run(t, append(baseArgs, "--in", inDump, "--count", "20")...)
+			e.CheckNextLine(t, `^Verified blocks: 20 \(0-19\)$`)
+			e.CheckNextLine(t, `^Errors: 0$`)
+		})
+		t.Run("incremental", func(t *testing.T) {
+			incDump := filepath.Join(tmpDir, "incDump.acc")
+			e.Run(t, "neo-go", "db", "dump", "--unittest", "--config-path", tmpDir,
+				"--out", incDump, "--start", "15", "--count", "15")
+			e.Run(t, append(baseArgs, "--in", incDump, "-n")...)
+			e.CheckNextLine(t, `^Verified blocks: 15 \(15-29\)$`)
+			e.CheckNextLine(t, `^Errors: 0$`)
+		})
+		t.Run("corrupted", func(t *testing.T) {
+			d, err := os.ReadFile(inDump)
+			require.NoError(t, err)
+			// Block count, block size, version and previous hash precede
+			// genesis merkle root.
+			d[4+4+4+32] ^= 0xff
+			badDump := filepath.Join(tmpDir, "bad.acc")
+			require.NoError(t, os.WriteFile(badDump, d, os.ModePerm))
+
+			e.RunWithErrorCheckExit(t, "verification failed with 2 errors", append(baseArgs, "--in", badDump)...)
+			e.CheckNextLine(t, `^block 0: merkle root mismatch`)
+			e.CheckNextLine(t, `^block 1: previous block hash mismatch`)
+			e.CheckNextLine(t, `^Verified blocks: 51 \(0-50\)$`)
+			e.CheckNextLine(t, `^Errors: 2$`)
+		})
+	})
+}
+
+func TestDBCompact(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg, err := config.LoadFile(filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.BoltDB
+	cfg.ApplicationConfiguration.DBConfiguration.BoltDBOptions.FilePath = filepath.Join(tmpDir, "chain.bolt")
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+
+	cfgPath := filepath.Join(tmpDir, "protocol.unit_testnet.yml")
+	require.NoError(t, os.WriteFile(cfgPath, out, os.ModePerm))
+
+	e := testcli.NewExecutor(t, false)
+	e.Run(t, "neo-go", "db", "restore", "--unittest", "--config-path", tmpDir, "--in", inDump)
+
+	baseArgs := []string{"neo-go", "db", "compact", "--unittest", "--config-path", tmpDir}
+	t.Run("excessive parameters", func(t *testing.T) {
+		e.RunWithError(t, append(baseArgs, "something")...)
+	})
+	t.Run("good", func(t *testing.T) {
+		e.Run(t, baseArgs...)
+		e.CheckNextLine(t, `^Before compaction:$`)
+		e.CheckNextLine(t, `^  File size: +\d+ bytes$`)
+		e.CheckNextLine(t, `^  Free pages: +\d+ \(\d+ pending, \d+ bytes\)$`)
+		e.CheckNextLine(t, `^  Fragmentation: +\d+\.\d+%$`)
+		e.CheckNextLine(t, `^After compaction:$`)
+		e.CheckNextLine(t, `^  File size: `)
+		e.CheckNextLine(t, `^  Free pages: `)
+		e.CheckNextLine(t, `^  Fragmentation: `)
+		e.CheckEOF(t)
+
+		// Chain is still usable.
+		e.Run(t, "neo-go", "db", "verify", "--unittest", "--config-path", tmpDir)
+		e.CheckNextLine(t, `^Verified blocks: 51 \(0-50\)$`)
+	})
+	t.Run("not a BoltDB", func(t *testing.T) {
+		cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.LevelDB
+		cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = filepath.Join(tmpDir, "leveldb")
+		out, err := yaml.Marshal(cfg)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(cfgPath, out, os.ModePerm))
+		e.RunWithErrorCheckExit(t, "compaction is only supported for boltdb", baseArgs...)
+	})
+}
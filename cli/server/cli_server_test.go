@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -131,3 +132,47 @@ func TestServerStart(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigDump(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfgPath := filepath.Join(tmpDir, "protocol.yml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(`Preset: testnet
+ApplicationConfiguration:
+  DBConfiguration:
+    Type: inmemory
+`), os.ModePerm))
+
+	e := testcli.NewExecutor(t, false)
+	t.Run("excessive parameters", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "config", "dump", "--config-file", cfgPath, "something")
+	})
+	t.Run("unknown preset", func(t *testing.T) {
+		badPath := filepath.Join(tmpDir, "bad.yml")
+		require.NoError(t, os.WriteFile(badPath, []byte(`Preset: unknown`), os.ModePerm))
+		e.RunWithErrorCheckExit(t, `unknown preset "unknown"`, "neo-go", "config", "dump", "--config-file", badPath)
+	})
+	t.Run("good", func(t *testing.T) {
+		e.Run(t, "neo-go", "config", "dump", "--config-file", cfgPath, "--relative-path", "/base")
+		dump := slices.Clone(e.Out.Bytes())
+		e.Out.Reset()
+
+		expected, err := config.LoadFile(cfgPath, "/base")
+		require.NoError(t, err)
+		expected.Preset = ""
+		out, err := yaml.Marshal(expected)
+		require.NoError(t, err)
+		require.Equal(t, string(out), string(dump))
+
+		var actual config.Config
+		require.NoError(t, yaml.Unmarshal(dump, &actual))
+		require.Equal(t, "inmemory", actual.ApplicationConfiguration.DBConfiguration.Type)
+		require.Equal(t, filepath.Join("/base", "chains", "testnet"), actual.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath)
+
+		// The dump is a complete configuration that can be used as is.
+		dumpPath := filepath.Join(tmpDir, "dump.yml")
+		require.NoError(t, os.WriteFile(dumpPath, dump, os.ModePerm))
+		e.Run(t, "neo-go", "config", "dump", "--config-file", dumpPath)
+		require.Equal(t, string(dump), e.Out.String())
+		e.Out.Reset()
+	})
+}
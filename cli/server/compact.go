@@ -0,0 +1,54 @@
+package server
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+	"github.com/urfave/cli/v2"
+)
+
+func compactDB(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	store, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("could not initialize storage: %w", err), 1)
+	}
+	defer store.Close()
+
+	bolt, ok := store.(*storage.BoltDBStore)
+	if !ok {
+		return cli.Exit(fmt.Errorf("compaction is only supported for %s", dbconfig.BoltDB), 1)
+	}
+	st, err := bolt.Stats()
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to get DB stats: %w", err), 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, "Before compaction:")
+	printBoltDBStats(ctx.App.Writer, st)
+	if err = bolt.Compact(); err != nil {
+		return cli.Exit(err, 1)
+	}
+	st, err = bolt.Stats()
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to get DB stats: %w", err), 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, "After compaction:")
+	printBoltDBStats(ctx.App.Writer, st)
+	return nil
+}
+
+func printBoltDBStats(w io.Writer, st storage.BoltDBStats) {
+	fmt.Fprintf(w, "  File size:     %d bytes\n", st.FileSize)
+	fmt.Fprintf(w, "  Free pages:    %d (%d pending, %d bytes)\n", st.FreePages+st.PendingPages, st.PendingPages, st.FreeBytes)
+	fmt.Fprintf(w, "  Fragmentation: %.2f%%\n", st.FragmentationRatio()*100)
+}
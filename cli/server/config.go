@@ -0,0 +1,30 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// dumpConfig prints the effective node configuration with the preset (if any)
+// applied and all relative paths updated.
+func dumpConfig(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	// The result is a complete configuration on its own.
+	cfg.Preset = ""
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to marshal config: %w", err), 1)
+	}
+	_, err = ctx.App.Writer.Write(data)
+	return err
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"runtime"
 	"slices"
+	"strings"
 	"syscall"
 	"time"
 
@@ -28,6 +30,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv"
 	"github.com/nspcc-dev/neo-go/pkg/services/stateroot"
+	"github.com/nspcc-dev/neo-go/pkg/services/webhook"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -77,6 +80,41 @@ func NewCommands() []*cli.Command {
 			Usage:   "Use if dump is incremental",
 		},
 	)
+	var cfgRestoreFlags = slices.Clone(cfgCountInFlags)
+	cfgRestoreFlags = append(cfgRestoreFlags,
+		&cli.UintFlag{
+			Name:  "workers",
+			Usage: "Number of blocks to be decoded and preverified in parallel",
+			Value: uint(runtime.NumCPU()),
+		},
+	)
+	var cfgVerifyFlags = slices.Clone(cfgWithCountFlags)
+	cfgVerifyFlags = append(cfgVerifyFlags, options.Debug,
+		&cli.UintFlag{
+			Name:    "start",
+			Aliases: []string{"s"},
+			Usage:   "Block number to start from (can't be used with --in)",
+		},
+		&cli.StringFlag{
+			Name:    "in",
+			Aliases: []string{"i"},
+			Usage:   "Dump file to verify instead of the database",
+		},
+		&cli.BoolFlag{
+			Name:    "incremental",
+			Aliases: []string{"n"},
+			Usage:   "Use if dump is incremental",
+		},
+		&cli.UintFlag{
+			Name:  "workers",
+			Usage: "Number of blocks to be checked in parallel",
+			Value: uint(runtime.NumCPU()),
+		},
+		&cli.BoolFlag{
+			Name:  "mpt",
+			Usage: "Also rebuild MPT from the current contract storage and compare it with the local state root (slow, requires a lot of memory, can't be used with --in)",
+		},
+	)
 	var cfgHeightFlags = slices.Clone(cfgFlags)
 	cfgHeightFlags = append(cfgHeightFlags, &cli.UintFlag{
 		Name:     "height",
@@ -112,9 +150,23 @@ func NewCommands() []*cli.Command {
 				{
 					Name:      "restore",
 					Usage:     "Restore blocks from the file",
-					UsageText: "neo-go db restore [-i file] [--dump] [-n] [-c count] [--config-path path] [-p/-m/-t] [--config-file file]",
+					UsageText: "neo-go db restore [-i file] [--dump] [-n] [-c count] [--workers num] [--config-path path] [-p/-m/-t] [--config-file file]",
 					Action:    restoreDB,
-					Flags:     cfgCountInFlags,
+					Flags:     cfgRestoreFlags,
+				},
+				{
+					Name:      "verify",
+					Usage:     "Verify integrity of the database or dump",
+					UsageText: "neo-go db verify [-i file] [-n] [-s start] [-c count] [--workers num] [--mpt] [--config-path path] [-p/-m/-t] [--config-file file]",
+					Description: `Checks block hashes, previous block links, merkle roots and state root
+   linkage for the given range of blocks in the database (or the whole chain by
+   default). If dump file is given via --in flag, blocks from it are checked
+   instead (hashes, links and merkle roots only), the database is not used in
+   this case. All problems found are printed along with a summary, the command
+   fails if there are any.
+`,
+					Action: verifyDB,
+					Flags:  cfgVerifyFlags,
 				},
 				{
 					Name:      "reset",
@@ -123,6 +175,43 @@ func NewCommands() []*cli.Command {
 					Action:    resetDB,
 					Flags:     cfgHeightFlags,
 				},
+				{
+					Name:      "rebuild-transfers",
+					Usage:     "Rebuild NEP-17/NEP-11 transfers index from the stored blocks",
+					UsageText: "neo-go db rebuild-transfers [--config-path path] [-p/-m/-t] [--config-file file]",
+					Action:    rebuildTransfers,
+					Flags:     cfgFlags,
+				},
+				{
+					Name:      "compact",
+					Usage:     "Compact BoltDB database file",
+					UsageText: "neo-go db compact [--config-path path] [-p/-m/-t] [--config-file file]",
+					Description: `Rewrites BoltDB database file without free pages reclaiming the disk
+   space left after deleted data (BoltDB files never shrink otherwise). File
+   usage statistics are printed before and after compaction. The node should be
+   stopped, compaction requires free disk space for a copy of the live data.
+`,
+					Action: compactDB,
+					Flags:  cfgFlags,
+				},
+			},
+		},
+		{
+			Name:  "config",
+			Usage: "Node configuration helpers",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "dump",
+					Usage:     "Print effective node configuration",
+					UsageText: "neo-go config dump [--config-path path] [-p/-m/-t] [--config-file file] [--relative-path path]",
+					Description: `Loads node configuration the same way the node does and prints it in YAML
+   format. If the configuration file specifies Preset, it's merged with the
+   built-in network configuration, so the output shows all the settings that
+   are used by the node. Available presets: ` + strings.Join(config.Presets(), ", ") + `.
+`,
+					Action: dumpConfig,
+					Flags:  cfgFlags,
+				},
 			},
 		},
 	}
@@ -233,6 +322,10 @@ func restoreDB(ctx *cli.Context) error {
 		defer func() { _ = logCloser() }()
 	}
 	count := uint32(ctx.Uint("count"))
+	workers := int(ctx.Uint("workers"))
+	if workers == 0 {
+		return cli.Exit("number of workers must be positive", 1)
+	}
 
 	var inStream = os.Stdin
 	if in := ctx.String("in"); in != "" {
@@ -327,7 +420,7 @@ func restoreDB(ctx *cli.Context) error {
 		}
 	}
 
-	err = chaindump.Restore(chain, reader, skip, count, f)
+	err = chaindump.RestoreParallel(chain, reader, skip, count, workers, f)
 	if err != nil {
 		return cli.Exit(fmt.Errorf("wrong dump file or settings mismatch: %w", err), 1)
 	}
@@ -367,6 +460,38 @@ func resetDB(ctx *cli.Context) error {
 	return nil
 }
 
+func rebuildTransfers(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+	chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		pprof.ShutDown()
+		prometheus.ShutDown()
+		chain.Close()
+	}()
+
+	err = chain.RebuildTransfers()
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to rebuild transfers: %w", err), 1)
+	}
+	return nil
+}
+
 // oracleService is an interface representing Oracle service with network.Service
 // capabilities and ability to submit oracle responses.
 type oracleService interface {
@@ -444,6 +569,18 @@ func mkP2PNotary(config config.P2PNotary, chain *core.Blockchain, serv *network.
 	return n, nil
 }
 
+func mkWebhook(config config.Webhook, chain *core.Blockchain, serv *network.Server, log *zap.Logger) (*webhook.Service, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+	wh, err := webhook.New(config, chain, log)
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize Webhook service: %w", err)
+	}
+	serv.AddService(wh)
+	return wh, nil
+}
+
 func startServer(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
@@ -484,6 +621,14 @@ func startServer(ctx *cli.Context) error {
 	if err != nil {
 		return cli.Exit(fmt.Errorf("failed to create network server: %w", err), 1)
 	}
+	health := metrics.NewHealthService(cfg.ApplicationConfiguration.Health, serv, log)
+	err = health.Start()
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to start Health service: %w", err), 1)
+	}
+	defer func() {
+		health.ShutDown()
+	}()
 	srMod := chain.GetStateModule().(*corestate.Module) // Take full responsibility here.
 	sr, err := stateroot.New(serverConfig.StateRootCfg, srMod, log, chain, serv.BroadcastExtensible)
 	if err != nil {
@@ -503,8 +648,13 @@ func startServer(ctx *cli.Context) error {
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
+	webhookSrv, err := mkWebhook(cfg.ApplicationConfiguration.Webhook, chain, serv, log)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
 	errChan := make(chan error)
 	rpcServer := rpcsrv.New(chain, cfg.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+	rpcServer.SetConsensusHandler(dbftSrv)
 	serv.AddService(&rpcServer)
 
 	serv.Start()
@@ -564,6 +714,7 @@ Main:
 				serv.DelService(&rpcServer)
 				rpcServer.Shutdown()
 				rpcServer = rpcsrv.New(chain, cfgnew.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+				rpcServer.SetConsensusHandler(dbftSrv)
 				serv.AddService(&rpcServer)
 				if !cfgnew.ApplicationConfiguration.RPC.StartWhenSynchronized || serv.IsInSync() {
 					// Here similar to the initial run (see above for-loop), so async.
@@ -583,6 +734,25 @@ Main:
 					shutdownErr = fmt.Errorf("failed to start Prometheus service: %w", err)
 					cancel() // Fatal error, like for RPC server.
 				}
+				health.ShutDown()
+				health = metrics.NewHealthService(cfgnew.ApplicationConfiguration.Health, serv, log)
+				err = health.Start()
+				if err != nil {
+					shutdownErr = fmt.Errorf("failed to start Health service: %w", err)
+					cancel() // Fatal error, like for RPC server.
+				}
+				if webhookSrv != nil {
+					serv.DelService(webhookSrv)
+					webhookSrv.Shutdown()
+				}
+				webhookSrv, err = mkWebhook(cfgnew.ApplicationConfiguration.Webhook, chain, serv, log)
+				if err != nil {
+					log.Error("failed to create webhook service", zap.Error(err))
+					break // Keep going.
+				}
+				if webhookSrv != nil && serv.IsInSync() {
+					webhookSrv.Start()
+				}
 			case sigusr1:
 				if oracleSrv != nil {
 					serv.DelService(oracleSrv)
@@ -629,6 +799,7 @@ Main:
 			case sigusr2:
 				if dbftSrv != nil {
 					serv.DelConsensusService(dbftSrv)
+					rpcServer.SetConsensusHandler(nil)
 					dbftSrv.Shutdown()
 				}
 				dbftSrv, err = mkConsensus(cfgnew.ApplicationConfiguration.Consensus, serverConfig.TimePerBlock, chain, serv, log)
@@ -636,8 +807,11 @@ Main:
 					log.Error("failed to create consensus service", zap.Error(err))
 					break // Whatever happens, I'll leave it all to chance.
 				}
-				if dbftSrv != nil && serv.IsInSync() {
-					dbftSrv.Start()
+				if dbftSrv != nil {
+					rpcServer.SetConsensusHandler(dbftSrv)
+					if serv.IsInSync() {
+						dbftSrv.Start()
+					}
 				}
 			}
 			cfg = cfgnew
@@ -300,6 +300,7 @@ func TestRestoreDB(t *testing.T) {
 
 	in := set.String("in", testDump, "")
 	incremental := set.Bool("incremental", false, "")
+	set.Uint("workers", 2, "")
 	t.Run("invalid in", func(t *testing.T) {
 		*in = "unknown-file"
 		require.Error(t, restoreDB(ctx))
@@ -390,3 +391,17 @@ func TestResetDB(t *testing.T) {
 	err = resetDB(ctx)
 	require.NoError(t, err)
 }
+
+func TestRebuildTransfers(t *testing.T) {
+	d := t.TempDir()
+	err := os.Chdir(d)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.Chdir(serverTestWD)) })
+	set := flag.NewFlagSet("flagSet", flag.ExitOnError)
+	set.String("config-path", filepath.Join(serverTestWD, "..", "..", "config"), "")
+	set.Bool("privnet", true, "")
+	set.Bool("debug", true, "")
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+	err = rebuildTransfers(ctx)
+	require.NoError(t, err)
+}
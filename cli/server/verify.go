@@ -0,0 +1,288 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/urfave/cli/v2"
+)
+
+// verifyError is a problem found for some block during verification.
+type verifyError struct {
+	index uint32
+	err   error
+}
+
+// verifyReport accumulates verification results, it's safe for concurrent use.
+type verifyReport struct {
+	lock    sync.Mutex
+	checked uint32
+	errs    []verifyError
+}
+
+func (r *verifyReport) add(index uint32, errs ...error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.checked++
+	for _, err := range errs {
+		r.errs = append(r.errs, verifyError{index: index, err: err})
+	}
+}
+
+func verifyDB(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	workers := int(ctx.Uint("workers"))
+	if workers == 0 {
+		return cli.Exit("number of workers must be positive", 1)
+	}
+	var (
+		count  = uint32(ctx.Uint("count"))
+		start  = uint32(ctx.Uint("start"))
+		report = new(verifyReport)
+		gctx   = newGraceContext()
+		begin  = time.Now()
+	)
+	if in := ctx.String("in"); in != "" {
+		if ctx.IsSet("start") || ctx.Bool("mpt") {
+			return cli.Exit("--start and --mpt can't be used with --in", 1)
+		}
+		inStream, err := os.Open(in)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+		defer inStream.Close()
+		start, err = verifyDump(gctx, io.NewBinReaderFromIO(inStream), cfg.ProtocolConfiguration.StateRootInHeader,
+			ctx.Bool("incremental"), count, workers, report)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+	} else {
+		log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), cfg.ApplicationConfiguration)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+		if logCloser != nil {
+			defer func() { _ = logCloser() }()
+		}
+		chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			pprof.ShutDown()
+			prometheus.ShutDown()
+			chain.Close()
+		}()
+
+		chainCount := chain.BlockHeight() + 1
+		if start+count > chainCount {
+			return cli.Exit(fmt.Errorf("chain is not that high (%d) to verify %d blocks starting from %d", chainCount-1, count, start), 1)
+		}
+		if count == 0 {
+			count = chainCount - start
+		}
+		verifyChain(gctx, chain, start, count, workers, report)
+		if ctx.Bool("mpt") && gctx.Err() == nil {
+			h := chain.BlockHeight()
+			root, err := chain.ComputeStorageRoot()
+			if err == nil && root != chain.GetStateModule().CurrentLocalStateRoot() {
+				err = fmt.Errorf("storage MPT root mismatch: state root is %s, storage gives %s",
+					chain.GetStateModule().CurrentLocalStateRoot().StringLE(), root.StringLE())
+			}
+			if err != nil {
+				report.errs = append(report.errs, verifyError{index: h, err: err})
+			}
+		}
+	}
+
+	slices.SortStableFunc(report.errs, func(a, b verifyError) int {
+		return cmp.Compare(a.index, b.index)
+	})
+	w := ctx.App.Writer
+	for _, e := range report.errs {
+		fmt.Fprintf(w, "block %d: %s\n", e.index, e.err)
+	}
+	if report.checked != 0 {
+		fmt.Fprintf(w, "Verified blocks: %d (%d-%d)\n", report.checked, start, start+report.checked-1)
+	} else {
+		fmt.Fprintln(w, "Verified blocks: 0")
+	}
+	fmt.Fprintf(w, "Errors: %d\n", len(report.errs))
+	fmt.Fprintf(w, "Time: %s\n", time.Since(begin).Round(time.Millisecond))
+	if gctx.Err() != nil {
+		return cli.Exit("verification interrupted", 1)
+	}
+	if len(report.errs) != 0 {
+		return cli.Exit(fmt.Errorf("verification failed with %d errors", len(report.errs)), 1)
+	}
+	return nil
+}
+
+// verifyChain checks count blocks starting from start in the chain's database
+// using the given number of parallel workers.
+func verifyChain(ctx context.Context, chain *core.Blockchain, start, count uint32, workers int, report *verifyReport) {
+	var (
+		heights = make(chan uint32)
+		wg      sync.WaitGroup
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for h := range heights {
+				report.add(h, verifyChainBlock(chain, h)...)
+			}
+		}()
+	}
+loop:
+	for h := start; h < start+count; h++ {
+		select {
+		case <-ctx.Done():
+			break loop
+		case heights <- h:
+		}
+	}
+	close(heights)
+	wg.Wait()
+}
+
+// verifyChainBlock checks the block at the given height against the data
+// stored in the database: block hash, its linkage with the previous block,
+// merkle root and state roots.
+func verifyChainBlock(chain *core.Blockchain, h uint32) []error {
+	var (
+		errs []error
+		hash = chain.GetHeaderHash(h)
+	)
+	b, err := chain.GetBlock(hash)
+	if err != nil {
+		return []error{fmt.Errorf("failed to get block %s: %w", hash.StringLE(), err)}
+	}
+	errs = append(errs, verifyBlock(b)...)
+	if b.Index != h {
+		errs = append(errs, fmt.Errorf("unexpected block index %d", b.Index))
+	}
+	if b.Hash() != hash {
+		errs = append(errs, fmt.Errorf("hash mismatch: expected %s, got %s", hash.StringLE(), b.Hash().StringLE()))
+	}
+	if h != 0 {
+		if prev := chain.GetHeaderHash(h - 1); b.PrevHash != prev {
+			errs = append(errs, fmt.Errorf("previous block hash mismatch: expected %s, got %s", prev.StringLE(), b.PrevHash.StringLE()))
+		}
+	}
+	sm := chain.GetStateModule()
+	sr, err := sm.GetStateRoot(h)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to get state root: %w", err))
+	} else if sr.Index != h {
+		errs = append(errs, fmt.Errorf("state root index mismatch: %d", sr.Index))
+	}
+	if chain.GetConfig().StateRootInHeader && h != 0 {
+		prev, err := sm.GetStateRoot(h - 1)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get previous state root: %w", err))
+		} else if b.PrevStateRoot != prev.Root {
+			errs = append(errs, fmt.Errorf("previous state root mismatch: expected %s, got %s", prev.Root.StringLE(), b.PrevStateRoot.StringLE()))
+		}
+	}
+	return errs
+}
+
+// verifyDump checks blocks from the dump (in the format produced by 'db dump')
+// using the given number of parallel workers. It returns the index of the
+// first block in the dump.
+func verifyDump(ctx context.Context, r *io.BinReader, stateRootInHeader bool, incremental bool, count uint32, workers int, report *verifyReport) (uint32, error) {
+	var start uint32
+	if incremental {
+		start = r.ReadU32LE()
+	}
+	allBlocks := r.ReadU32LE()
+	if r.Err != nil {
+		return 0, fmt.Errorf("failed to read dump header: %w", r.Err)
+	}
+	if count > allBlocks {
+		return 0, fmt.Errorf("input file has only %d blocks, can't verify %d", allBlocks, count)
+	}
+	if count == 0 {
+		count = allBlocks
+	}
+
+	var (
+		blocks = make(chan *block.Block)
+		wg     sync.WaitGroup
+		prev   util.Uint256
+		buf    []byte
+		err    error
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range blocks {
+				report.add(b.Index, verifyBlock(b)...)
+			}
+		}()
+	}
+	for i := start; i < start+count; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		size := r.ReadU32LE()
+		buf = slices.Grow(buf[:0], int(size))[:size]
+		r.ReadBytes(buf)
+		if r.Err != nil {
+			err = fmt.Errorf("failed to read block %d: %w", i, r.Err)
+			break
+		}
+		b := block.New(stateRootInHeader)
+		br := io.NewBinReaderFromBuf(buf)
+		b.DecodeBinary(br)
+		if br.Err != nil {
+			err = fmt.Errorf("failed to decode block %d: %w", i, br.Err)
+			break
+		}
+		var linkErrs []error
+		if b.Index != i {
+			linkErrs = append(linkErrs, fmt.Errorf("unexpected block index %d", b.Index))
+		}
+		// Previous block is unknown for the first block of incremental dump.
+		if (i != start || i == 0) && b.PrevHash != prev {
+			linkErrs = append(linkErrs, fmt.Errorf("previous block hash mismatch: expected %s, got %s", prev.StringLE(), b.PrevHash.StringLE()))
+		}
+		for _, e := range linkErrs {
+			report.lock.Lock()
+			report.errs = append(report.errs, verifyError{index: i, err: e})
+			report.lock.Unlock()
+		}
+		prev = b.Hash()
+		blocks <- b
+	}
+	close(blocks)
+	wg.Wait()
+	return start, err
+}
+
+// verifyBlock performs self-contained block checks.
+func verifyBlock(b *block.Block) []error {
+	if root := b.ComputeMerkleRoot(); root != b.MerkleRoot {
+		return []error{fmt.Errorf("merkle root mismatch: expected %s, got %s", b.MerkleRoot.StringLE(), root.StringLE())}
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -387,6 +388,62 @@ func TestDeployBigContract(t *testing.T) {
 		"--in", nefName, "--manifest", manifestName)
 }
 
+func TestContractDeployChecks(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	cmd := []string{"neo-go", "contract", "deploy",
+		"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+		"--in", "testdata/verify.nef",
+	}
+	checkWarnings := func(t *testing.T) {
+		e.CheckNextLine(t, "WARNING: onNEP17Payment method is present, but NEP-27 standard is not declared as supported")
+		e.CheckNextLine(t, "WARNING: permission allows calling any method of any contract")
+		e.CheckNextLine(t, "WARNING: permission is not used: any contract")
+		e.CheckNextLine(t, "WARNING: verify method is not marked as safe")
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		mBytes, err := os.ReadFile("testdata/verify.manifest.json")
+		require.NoError(t, err)
+		m := new(manifest.Manifest)
+		require.NoError(t, json.Unmarshal(mBytes, m))
+		m.SupportedStandards = []string{manifest.NEP17StandardName}
+		m.ABI.Methods[0].ReturnType = m.ABI.Methods[1].ReturnType // Void.
+		mBytes, err = json.Marshal(m)
+		require.NoError(t, err)
+		manifestName := filepath.Join(t.TempDir(), "verify.manifest.json")
+		require.NoError(t, os.WriteFile(manifestName, mBytes, 0644))
+
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.RunWithErrorCheckExit(t, "pre-deployment checks failed", append(cmd, "--manifest", manifestName, "--yes")...)
+		e.CheckNextLine(t, "Pre-deployment checks:")
+		e.CheckNextLine(t, "ERROR: manifest is not compliant with 'NEP-17': method missing")
+		e.CheckNextLine(t, "ERROR: verify method returns Void instead of Boolean")
+		checkWarnings(t)
+		e.CheckEOF(t)
+	})
+	cmd = append(cmd, "--manifest", "testdata/verify.manifest.json")
+	t.Run("cancelled", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.In.WriteString("n\r")
+		e.RunWithErrorCheckExit(t, "cancelled", cmd...)
+		e.CheckNextLine(t, "Pre-deployment checks:")
+		checkWarnings(t)
+		e.CheckEOF(t)
+	})
+	t.Run("confirmed", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.In.WriteString("y\r")
+		e.Run(t, append(cmd, "--yes")...)
+		e.CheckNextLine(t, "Pre-deployment checks:")
+		checkWarnings(t)
+		e.CheckNextLine(t, "Network fee:")
+		e.CheckNextLine(t, "System fee:")
+		e.CheckNextLine(t, "Total fee:")
+		e.CheckTxPersisted(t, "Sent invocation transaction ")
+	})
+}
+
 func TestContractDeployWithData(t *testing.T) {
 	eCompile := testcli.NewExecutor(t, false)
 	tmpDir := t.TempDir()
@@ -472,6 +529,93 @@ func TestContractDeployWithData(t *testing.T) {
 	deployContract(t, true, "", true)
 }
 
+func TestContractUpgrade(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	tmpDir := t.TempDir()
+
+	nefName := filepath.Join(tmpDir, "deploy.nef")
+	manifestName := filepath.Join(tmpDir, "deploy.manifest.json")
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", "testdata/deploy/main.go", // compile single file
+		"--config", "testdata/deploy/neo-go.yml",
+		"--out", nefName, "--manifest", manifestName)
+	newNefName := filepath.Join(tmpDir, "updated.nef")
+	newManifestName := filepath.Join(tmpDir, "updated.manifest.json")
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", "testdata/deploy", // compile the whole package with updated.go
+		"--config", "testdata/deploy/neo-go.yml",
+		"--out", newNefName, "--manifest", newManifestName)
+
+	e.In.WriteString(testcli.ValidatorPass + "\r")
+	e.Run(t, "neo-go", "contract", "deploy",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+		"--in", nefName, "--manifest", manifestName, "--force")
+	e.CheckTxPersisted(t)
+	line := strings.TrimPrefix(e.GetNextLine(t), "Contract: ")
+	h, err := util.Uint160DecodeStringLE(line)
+	require.NoError(t, err)
+
+	cmd := []string{"neo-go", "contract", "upgrade",
+		"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+		"--contract", h.StringLE(),
+	}
+	checkDryRun := func(t *testing.T) {
+		e.CheckNextLine(t, "^Dry-run: HALT, GAS consumed: ")
+		e.CheckNextLine(t, "^Storage changes:$")
+		changes := make([]string, 3)
+		for i := range changes {
+			changes[i] = strings.TrimSpace(e.GetNextLine(t))
+		}
+		require.ElementsMatch(t, []string{
+			"Changed " + hex.EncodeToString([]byte("key")) + " = " + hex.EncodeToString([]byte("on update")),
+			"Changed " + hex.EncodeToString([]byte("sub")) + " = " + hex.EncodeToString([]byte("sub update")),
+			"Added " + hex.EncodeToString([]byte("migrated")) + " = " + hex.EncodeToString([]byte("yes")),
+		}, changes)
+		e.CheckNextLine(t, `\(\d+ changes of other contracts\)$`)
+	}
+
+	t.Run("missing contract", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		args := slices.Clone(cmd)
+		args[len(args)-1] = util.Uint160{1, 2, 3}.StringLE()
+		e.RunWithErrorCheckExit(t, "can't fetch contract info", append(args, "--in", newNefName, "--manifest", newManifestName)...)
+	})
+	t.Run("dry-run", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.Run(t, append(cmd, "--in", newNefName, "--manifest", newManifestName,
+			"--migrate", "migrate", "--dry-run", "--force")...)
+		checkDryRun(t)
+		e.CheckEOF(t)
+
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.RunWithErrorCheckExit(t, "update dry-run failed", append(cmd, "--in", newNefName, "--manifest", newManifestName,
+			"--migrate", "unknown", "--dry-run", "--force")...)
+	})
+	t.Run("update", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.Run(t, append(cmd, "--in", newNefName, "--manifest", newManifestName,
+			"--migrate", "migrate", "--force")...)
+		checkDryRun(t)
+		e.CheckTxPersisted(t)
+
+		e.Run(t, "neo-go", "contract", "testinvokefunction",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			h.StringLE(), "getValueWithKey", "migrated")
+		res := new(result.Invoke)
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), res))
+		require.Equal(t, vmstate.Halt.String(), res.State, res.FaultException)
+		require.Equal(t, []byte("yes"), res.Stack[0].Value())
+	})
+	t.Run("incompatible", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.Run(t, append(cmd, "--in", nefName, "--manifest", manifestName, "--dry-run")...)
+		require.Contains(t, e.Out.String(), "WARNING: incompatible ABI change: ")
+		require.Contains(t, e.Out.String(), "'newMethod' with 0 parameters")
+	})
+}
+
 func TestDeployWithSigners(t *testing.T) {
 	e := testcli.NewExecutor(t, true)
 	tmpDir := t.TempDir()
@@ -615,6 +759,108 @@ func TestContractManifestGroups(t *testing.T) {
 		"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr)
 }
 
+func TestContractManifestCheckCompat(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	tmpDir := t.TempDir()
+	h := deployVerifyContract(t, e)
+	cs := e.Chain.GetContractState(h)
+	require.NotNil(t, cs)
+
+	writeManifest := func(t *testing.T, name string, m *manifest.Manifest) string {
+		raw, err := json.Marshal(m)
+		require.NoError(t, err)
+		p := filepath.Join(tmpDir, name)
+		require.NoError(t, os.WriteFile(p, raw, os.ModePerm))
+		return p
+	}
+	oldPath := writeManifest(t, "old.manifest.json", &cs.Manifest)
+	newM := cs.Manifest
+	newM.ABI.Methods = slices.DeleteFunc(slices.Clone(newM.ABI.Methods), func(m manifest.Method) bool {
+		return m.Name == manifest.MethodVerify
+	})
+	newPath := writeManifest(t, "new.manifest.json", &newM)
+
+	cmd := []string{"neo-go", "contract", "manifest", "check-compat"}
+	t.Run("missing manifest", func(t *testing.T) {
+		e.RunWithErrorCheck(t, `Required flag "manifest" not set`, cmd...)
+	})
+	t.Run("missing old", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "either --old or --contract must be specified", append(cmd, "--manifest", newPath)...)
+	})
+	t.Run("both old and contract", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "--old and --contract can't be used together",
+			append(cmd, "--manifest", newPath, "--old", oldPath, "--contract", h.StringLE())...)
+	})
+	t.Run("invalid manifest", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--manifest", tmpDir, "--old", oldPath)...)
+		e.RunWithError(t, append(cmd, "--manifest", newPath, "--old", tmpDir)...)
+	})
+	t.Run("compatible", func(t *testing.T) {
+		e.Run(t, append(cmd, "--manifest", oldPath, "--old", oldPath)...)
+		e.CheckNextLine(t, "No breaking changes found.")
+		e.CheckEOF(t)
+	})
+	t.Run("file", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "1 breaking changes found", append(cmd, "--manifest", newPath, "--old", oldPath)...)
+		e.CheckNextLine(t, "method removed: 'verify' with 0 parameters")
+	})
+	t.Run("contract", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "1 breaking changes found", append(cmd, "--manifest", newPath,
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0], "--contract", h.StringLE())...)
+		e.CheckNextLine(t, "method removed: 'verify' with 0 parameters")
+	})
+	t.Run("unknown contract", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--manifest", newPath,
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0], "--contract", util.Uint160{1, 2, 3}.StringLE())...)
+	})
+}
+
+func TestContractManifestCheckPermissions(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmpDir := t.TempDir()
+
+	nefName := filepath.Join(tmpDir, "deploy.nef")
+	manifestName := filepath.Join(tmpDir, "deploy.manifest.json")
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", "testdata/deploy/main.go", // compile single file
+		"--config", "testdata/deploy/neo-go.yml",
+		"--out", nefName, "--manifest", manifestName)
+
+	cmd := []string{"neo-go", "contract", "manifest", "check-permissions"}
+	t.Run("missing flags", func(t *testing.T) {
+		e.RunWithErrorCheck(t, `Required flags "in, manifest" not set`, cmd...)
+	})
+	t.Run("invalid files", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--in", tmpDir, "--manifest", manifestName)...)
+		e.RunWithError(t, append(cmd, "--in", nefName, "--manifest", tmpDir)...)
+	})
+	t.Run("match", func(t *testing.T) {
+		e.Run(t, append(cmd, "--in", nefName, "--manifest", manifestName)...)
+		e.CheckNextLine(t, "Permissions match contract calls.")
+		e.CheckEOF(t)
+	})
+	t.Run("wildcard", func(t *testing.T) {
+		raw, err := os.ReadFile(manifestName)
+		require.NoError(t, err)
+		m := new(manifest.Manifest)
+		require.NoError(t, json.Unmarshal(raw, m))
+		m.Permissions = []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)}
+		raw, err = json.Marshal(m)
+		require.NoError(t, err)
+		wildName := filepath.Join(tmpDir, "wildcard.manifest.json")
+		require.NoError(t, os.WriteFile(wildName, raw, os.ModePerm))
+
+		// Contract hash is taken from the storage, so it can't be restricted.
+		e.RunWithErrorCheckExit(t, "1 permission problems found", append(cmd, "--in", nefName, "--manifest", wildName)...)
+		e.CheckNextLine(t, "permission allows any method: any contract, only update are called")
+		e.CheckNextLine(t, "Suggested permissions:")
+		e.CheckNextLine(t, "permissions:")
+		e.CheckNextLine(t, "- methods:")
+		e.CheckNextLine(t, "- update")
+		e.CheckEOF(t)
+	})
+}
+
 func deployVerifyContract(t *testing.T, e *testcli.Executor) util.Uint160 {
 	return testcli.DeployContract(t, e, "testdata/verify.go", "testdata/verify.yml", testcli.ValidatorWallet, testcli.ValidatorAddr, testcli.ValidatorPass)
 }
@@ -0,0 +1,148 @@
+package smartcontract
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/input"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/permcheck"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/util/bitfield"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/urfave/cli/v2"
+)
+
+// deployReport contains problems found by pre-deployment (or pre-update)
+// contract checks. Errors make the deployment fail or the contract unusable,
+// warnings are likely mistakes that need to be confirmed by the user.
+type deployReport struct {
+	errors   []string
+	warnings []string
+	update   bool
+}
+
+// checkDeployment checks the contract to be deployed with the given hash for
+// standard compliance, excessive permissions and broken special methods.
+func checkDeployment(nefFile *nef.File, m *manifest.Manifest, hash util.Uint160) *deployReport {
+	r := new(deployReport)
+
+	if err := m.IsValid(hash, true); err != nil {
+		r.errorf("manifest is invalid: %s", err)
+	}
+	offsets := bitfield.New(len(nefFile.Script))
+	offsetsOK := true
+	for _, md := range m.ABI.Methods {
+		if md.Offset < 0 || md.Offset >= len(nefFile.Script) {
+			r.errorf("method %s/%d: offset is out of the script range", md.Name, len(md.Parameters))
+			offsetsOK = false
+			continue
+		}
+		offsets.Set(md.Offset)
+	}
+	if offsetsOK {
+		if err := vm.IsScriptCorrect(nefFile.Script, offsets); err != nil {
+			r.errorf("invalid contract script: %s", err)
+		}
+	}
+	if err := standard.Check(m, m.SupportedStandards...); err != nil {
+		r.errorf("%s", err)
+	}
+	r.checkPayable(m, manifest.MethodOnNEP17Payment, manifest.NEP27StandardName)
+	r.checkPayable(m, manifest.MethodOnNEP11Payment, manifest.NEP26StandardName)
+
+	for _, p := range m.Permissions {
+		switch {
+		case p.Contract.Type == manifest.PermissionWildcard && p.Methods.IsWildcard():
+			r.warnf("permission allows calling any method of any contract")
+		case !p.Methods.IsWildcard() && len(p.Methods.Value) == 0:
+			r.warnf("permission for %s doesn't allow calling any method", p.Contract)
+		}
+	}
+	if calls, err := permcheck.FindCalls(nefFile); err == nil {
+		for _, err := range permcheck.Check(calls, m.Permissions) {
+			r.warnf("%s", err)
+		}
+	}
+	if m.Trusts.IsWildcard() {
+		r.warnf("contract trusts any other contract to call it")
+	}
+
+	if md := m.ABI.GetMethod(manifest.MethodVerify, -1); md != nil {
+		if md.ReturnType != smartcontract.BoolType {
+			r.errorf("%s method returns %s instead of Boolean, contract witnesses will always fail", md.Name, md.ReturnType)
+		}
+		if !md.Safe {
+			r.warnf("%s method is not marked as safe", md.Name)
+		}
+	}
+	if m.ABI.GetMethod(manifest.MethodDeploy, -1) != nil && m.ABI.GetMethod(manifest.MethodDeploy, 2) == nil {
+		r.warnf("%s method doesn't have 2 parameters and won't be called on deployment", manifest.MethodDeploy)
+	}
+	return r
+}
+
+// checkPayable warns if the contract has payment callback, but doesn't declare
+// the corresponding standard support (neither with the current nor with the
+// legacy name).
+func (r *deployReport) checkPayable(m *manifest.Manifest, method string, std string) {
+	if m.ABI.GetMethod(method, -1) != nil && !m.IsPayable(method) {
+		r.warnf("%s method is present, but %s standard is not declared as supported", method, std)
+	}
+}
+
+func (r *deployReport) errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *deployReport) warnf(format string, args ...any) {
+	r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+// print writes the report if there are any problems found.
+func (r *deployReport) print(w io.Writer) {
+	if len(r.errors) == 0 && len(r.warnings) == 0 {
+		return
+	}
+	if r.update {
+		fmt.Fprintln(w, "Pre-update checks:")
+	} else {
+		fmt.Fprintln(w, "Pre-deployment checks:")
+	}
+	for _, e := range r.errors {
+		fmt.Fprintf(w, "  ERROR: %s\n", e)
+	}
+	for _, e := range r.warnings {
+		fmt.Fprintf(w, "  WARNING: %s\n", e)
+	}
+}
+
+// confirm prints the report and either fails if there are errors or asks the
+// user to confirm the deployment if there are warnings (unless skipConfirm is
+// set).
+func (r *deployReport) confirm(w io.Writer, skipConfirm bool) error {
+	var stage, action = "deployment", "deploy"
+	if r.update {
+		stage, action = "update", "update"
+	}
+	r.print(w)
+	if len(r.errors) != 0 {
+		return cli.Exit(fmt.Sprintf("pre-%s checks failed.\nUse --force flag to %s the contract anyway.", stage, action), 1)
+	}
+	if len(r.warnings) == 0 || skipConfirm {
+		return nil
+	}
+	ln, err := input.ReadLine(fmt.Sprintf("%s the contract anyway? [y/N]: ", strings.ToUpper(action[:1])+action[1:]))
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	ln = strings.ToLower(strings.TrimSpace(ln))
+	if ln != "y" && ln != "yes" {
+		return cli.Exit("cancelled", 1)
+	}
+	return nil
+}
@@ -422,6 +422,118 @@ func (c *ContractReader) Get() (*big.Int, error) {
 `, string(data))
 }
 
+func TestGenerateExtendedTypes(t *testing.T) {
+	m := manifest.NewManifest("Dependency")
+	m.ABI.Methods = append(m.ABI.Methods,
+		manifest.Method{
+			Name: "balances",
+			Parameters: []manifest.Parameter{
+				manifest.NewParameter("accounts", smartcontract.ArrayType),
+			},
+			ReturnType: smartcontract.MapType,
+			Safe:       true,
+		},
+		manifest.Method{
+			Name:       "tokens",
+			Parameters: []manifest.Parameter{},
+			ReturnType: smartcontract.InteropInterfaceType,
+			Safe:       true,
+		},
+		manifest.Method{
+			Name: "setOwners",
+			Parameters: []manifest.Parameter{
+				manifest.NewParameter("owners", smartcontract.MapType),
+				manifest.NewParameter("info", smartcontract.ArrayType),
+			},
+			ReturnType: smartcontract.ArrayType,
+		},
+	)
+
+	manifestFile := filepath.Join(t.TempDir(), "manifest.json")
+	outFile := filepath.Join(t.TempDir(), "out.go")
+
+	rawManifest, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(manifestFile, rawManifest, os.ModePerm))
+
+	rawCfg := `types:
+    balances.accounts:
+        base: Array
+        value:
+            base: Hash160
+    balances:
+        base: Map
+        key: Hash160
+        value:
+            base: Integer
+    tokens:
+        base: InteropInterface
+        interface: iterator
+    setOwners.owners:
+        base: Map
+        key: Integer
+        value:
+            base: Array
+            value:
+                base: PublicKey
+    setOwners.info:
+        base: Array
+        name: dependency.Info
+    setOwners:
+        base: Array
+        value:
+            base: Bool
+`
+	cfgPath := filepath.Join(t.TempDir(), "binding.yml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(rawCfg), os.ModePerm))
+
+	e := testcli.NewExecutor(t, false)
+	e.Run(t, []string{"", "contract", "generate-wrapper",
+		"--manifest", manifestFile,
+		"--config", cfgPath,
+		"--out", outFile,
+	}...)
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	require.Equal(t, `// Code generated by neo-go contract generate-wrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>]; DO NOT EDIT.
+
+// Package dependency contains wrappers for Dependency contract.
+package dependency
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+)
+
+// Contract represents the Dependency smart contract.
+type Contract struct {
+	Hash interop.Hash160
+}
+
+// NewContract returns a new Contract instance with the specified hash.
+func NewContract(hash interop.Hash160) Contract {
+	return Contract{Hash: hash}
+}
+
+// Balances invokes `+"`balances`"+` method of contract.
+func (c Contract) Balances(accounts []interop.Hash160) map[string]int {
+	return contract.Call(c.Hash, "balances", contract.ReadOnly, accounts).(map[string]int)
+}
+
+// Tokens invokes `+"`tokens`"+` method of contract.
+func (c Contract) Tokens() iterator.Iterator {
+	return contract.Call(c.Hash, "tokens", contract.ReadOnly).(iterator.Iterator)
+}
+
+// SetOwners invokes `+"`setOwners`"+` method of contract.
+func (c Contract) SetOwners(owners map[int][]interop.PublicKey, info []any) []bool {
+	return contract.Call(c.Hash, "setOwners", contract.All, owners, info).([]bool)
+}
+`, string(data))
+}
+
 // rewriteExpectedOutputs denotes whether expected output files should be rewritten
 // for TestGenerateRPCBindings and TestAssistedRPCBindings.
 const rewriteExpectedOutputs = false
@@ -430,14 +542,18 @@ func TestGenerateRPCBindings(t *testing.T) {
 	tmpDir := t.TempDir()
 	e := testcli.NewExecutor(t, false)
 
-	var checkBinding = func(manifest string, hash string, good string) {
+	var checkBinding = func(manifest string, hash string, good string, config ...string) {
 		t.Run(manifest, func(t *testing.T) {
 			outFile := filepath.Join(tmpDir, "out.go")
-			e.Run(t, []string{"", "contract", "generate-rpcwrapper",
+			cmd := []string{"", "contract", "generate-rpcwrapper",
 				"--manifest", manifest,
 				"--out", outFile,
 				"--hash", hash,
-			}...)
+			}
+			if len(config) != 0 {
+				cmd = append(cmd, "--config", config[0])
+			}
+			e.Run(t, cmd...)
 
 			data, err := os.ReadFile(outFile)
 			require.NoError(t, err)
@@ -468,6 +584,10 @@ func TestGenerateRPCBindings(t *testing.T) {
 	checkBinding(filepath.Join("testdata", "nonepiter", "iter.manifest.json"),
 		"0x00112233445566778899aabbccddeeff00112233",
 		filepath.Join("testdata", "nonepiter", "iter.go"))
+	checkBinding(filepath.Join("testdata", "nep11d", "nft.manifest.json"),
+		"0x00112233445566778899aabbccddeeff00112233",
+		filepath.Join("testdata", "nep11d", "nft.go"),
+		filepath.Join("testdata", "nep11d", "nft.bindings.yml"))
 
 	require.False(t, rewriteExpectedOutputs)
 }
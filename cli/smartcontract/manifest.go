@@ -10,9 +10,11 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/permcheck"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
 )
 
 func manifestAddGroup(ctx *cli.Context) error {
@@ -69,6 +71,91 @@ func manifestAddGroup(ctx *cli.Context) error {
 	return nil
 }
 
+func manifestCheckCompat(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	newM, _, err := readManifest(ctx.String("manifest"), util.Uint160{})
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't read new contract manifest: %w", err), 1)
+	}
+	var (
+		oldM     *manifest.Manifest
+		oldPath  = ctx.String("old")
+		contract = ctx.Generic("contract").(*flags.Address)
+	)
+	switch {
+	case oldPath != "" && contract.IsSet:
+		return cli.Exit("--old and --contract can't be used together", 1)
+	case oldPath != "":
+		oldM, _, err = readManifest(oldPath, util.Uint160{})
+		if err != nil {
+			return cli.Exit(fmt.Errorf("can't read old contract manifest: %w", err), 1)
+		}
+	case contract.IsSet:
+		gctx, cancel := options.GetTimeoutContext(ctx)
+		defer cancel()
+
+		c, exitErr := options.GetRPCClient(gctx, ctx)
+		if exitErr != nil {
+			return exitErr
+		}
+		cs, err := c.GetContractStateByHash(contract.Uint160())
+		if err != nil {
+			return cli.Exit(fmt.Errorf("can't fetch contract info: %w", err), 1)
+		}
+		oldM = &cs.Manifest
+	default:
+		return cli.Exit("either --old or --contract must be specified", 1)
+	}
+	errs := manifest.CheckCompatibility(oldM, newM)
+	if len(errs) == 0 {
+		fmt.Fprintln(ctx.App.Writer, "No breaking changes found.")
+		return nil
+	}
+	for _, err := range errs {
+		fmt.Fprintln(ctx.App.Writer, err)
+	}
+	return cli.Exit(fmt.Errorf("%d breaking changes found", len(errs)), 1)
+}
+
+func manifestCheckPermissions(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	nf, _, err := readNEFFile(ctx.String("in"))
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't read NEF file: %w", err), 1)
+	}
+	m, _, err := readManifest(ctx.String("manifest"), util.Uint160{})
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't read contract manifest: %w", err), 1)
+	}
+	calls, err := permcheck.FindCalls(nf)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't analyze contract script: %w", err), 1)
+	}
+	errs := permcheck.Check(calls, m.Permissions)
+	for _, err := range errs {
+		fmt.Fprintln(ctx.App.Writer, err)
+	}
+	if len(errs) == 0 {
+		fmt.Fprintln(ctx.App.Writer, "Permissions match contract calls.")
+		return nil
+	}
+	suggested := permcheck.Suggest(calls)
+	conf := struct{ Permissions []permission }{Permissions: make([]permission, len(suggested))}
+	for i := range suggested {
+		conf.Permissions[i] = permission(suggested[i])
+	}
+	b, err := yaml.Marshal(conf)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't marshal suggested permissions: %w", err), 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Suggested permissions:\n%s", b)
+	return cli.Exit(fmt.Errorf("%d permission problems found", len(errs)), 1)
+}
+
 func readNEFFile(filename string) (*nef.File, []byte, error) {
 	f, err := os.ReadFile(filename)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
@@ -99,6 +100,7 @@ func NewCommands() []*cli.Command {
 		txctx.AwaitFlag,
 	}
 	invokeFunctionFlags = append(invokeFunctionFlags, options.Wallet...)
+	invokeFunctionFlags = append(invokeFunctionFlags, options.Ledger...)
 	invokeFunctionFlags = append(invokeFunctionFlags, options.RPC...)
 	deployFlags := append(invokeFunctionFlags, []cli.Flag{
 		&cli.StringFlag{
@@ -115,6 +117,31 @@ func NewCommands() []*cli.Command {
 			Usage:    "Manifest input file (*.manifest.json)",
 			Action:   cmdargs.EnsureNotEmpty("manifest"),
 		},
+		&cli.BoolFlag{
+			Name:  "yes",
+			Usage: "Do not ask for a confirmation of pre-deployment check warnings",
+		},
+	}...)
+	upgradeFlags := append(slices.Clone(deployFlags), []cli.Flag{
+		&flags.AddressFlag{
+			Name:     "contract",
+			Aliases:  []string{"c"},
+			Required: true,
+			Usage:    "Hash or address of the contract to update",
+		},
+		&cli.StringFlag{
+			Name:  "method",
+			Value: "update",
+			Usage: "Contract method performing the update",
+		},
+		&cli.StringFlag{
+			Name:  "migrate",
+			Usage: "Contract method (without parameters) to call right after the update in the same transaction",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Only perform the update (and migration) test invocation and checks, don't send anything",
+		},
 	}...)
 	manifestAddGroupFlags := append([]cli.Flag{
 		&flags.AddressFlag{
@@ -144,6 +171,29 @@ func NewCommands() []*cli.Command {
 			Action:   cmdargs.EnsureNotEmpty("manifest"),
 		},
 	}, options.Wallet...)
+	// RPC endpoint is only needed when the old manifest is fetched from the chain.
+	rpcFlagOriginal, _ := options.RPC[0].(*cli.StringFlag)
+	rpcFlag := *rpcFlagOriginal
+	rpcFlag.Required = false
+	manifestCheckCompatFlags := append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "manifest",
+			Aliases:  []string{"m"},
+			Required: true,
+			Usage:    "Path to the new manifest",
+			Action:   cmdargs.EnsureNotEmpty("manifest"),
+		},
+		&cli.StringFlag{
+			Name:  "old",
+			Usage: "Path to the old manifest (can't be used with --contract)",
+		},
+		&flags.AddressFlag{
+			Name:    "contract",
+			Aliases: []string{"c"},
+			Usage:   "Hash or address of the deployed contract to fetch the old manifest from via RPC",
+		},
+		&rpcFlag,
+	}, options.RPC[1:]...)
 	return []*cli.Command{{
 		Name:  "contract",
 		Usage: "Compile - debug - deploy smart contracts",
@@ -151,7 +201,7 @@ func NewCommands() []*cli.Command {
 			{
 				Name:      "compile",
 				Usage:     "Compile a smart contract to a .nef file",
-				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes]",
+				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes] [--optimize level]",
 				Description: `Compiles given smart contract to a .nef file and emits other associated
    information (manifest, bindings configuration, debug information files) if
    asked to. If none of --out, --manifest, --config, --bindings flags are specified,
@@ -214,27 +264,62 @@ func NewCommands() []*cli.Command {
 						Name:  "bindings",
 						Usage: "Output file for smart-contract bindings configuration",
 					},
+					&cli.IntFlag{
+						Name:  "optimize",
+						Usage: "Optimization level: 0 (none), 1 (dead branches elimination), 2 (plus constant propagation across calls), 3 (plus binary search dispatch for large switches)",
+					},
 				},
 			},
 			{
 				Name:      "deploy",
 				Usage:     "Deploy a smart contract (.nef with description)",
-				UsageText: "neo-go contract deploy -r endpoint -w wallet [-a address] [-g gas] [-e sysgas] --in contract.nef --manifest contract.manifest.json [--out file] [--force] [--await] [data]",
+				UsageText: "neo-go contract deploy -r endpoint -w wallet [-a address] [--ledger [--ledger-index <index>]] [-g gas] [-e sysgas] --in contract.nef --manifest contract.manifest.json [--out file] [--force] [--yes] [--await] [data]",
 				Description: `Deploys given contract into the chain. The gas parameter is for additional
    gas to be added as a network fee to prioritize the transaction. The data 
    parameter is an optional parameter to be passed to '_deploy' method. When
    --await flag is specified, it waits for the transaction to be included 
    in a block.
+
+   Before deployment the contract is checked for compliance with declared
+   standards, excessive permissions and broken 'verify' and '_deploy' methods.
+   Errors found abort the deployment, warnings need to be confirmed unless
+   --yes flag is given. --force flag skips these checks completely.
 `,
 				Action: contractDeploy,
 				Flags:  deployFlags,
 			},
+			{
+				Name:      "upgrade",
+				Usage:     "Update the deployed smart contract with the new version",
+				UsageText: "neo-go contract upgrade -r endpoint -w wallet [-a address] [--ledger [--ledger-index <index>]] [-g gas] [-e sysgas] -c contract --in contract.nef --manifest contract.manifest.json [--method update] [--migrate method] [--dry-run] [--out file] [--force] [--yes] [--await] [data] [-- signers]",
+				Description: `Updates the deployed contract with the given NEF and manifest by calling its
+   update method (` + "`update`" + ` by default, it can be changed with --method)
+   with NEF, manifest and (optional) data parameters. If --migrate method is
+   given, it's called right after the update in the same transaction (the new
+   contract version is used for this call). When --await flag is specified,
+   it waits for the transaction to be included in a block.
+
+   Before sending anything the update is tested against the current chain
+   state and its result with all storage changes made to the contract is
+   printed. The new contract is also checked the same way deploy command does
+   and its ABI is compared with the deployed one, changes breaking
+   compatibility are reported as warnings. Errors abort the update, warnings
+   need to be confirmed unless --yes flag is given. --force flag skips these
+   checks and allows to send the transaction even if the test invocation
+   fails. --dry-run flag makes the command stop after the test invocation.
+
+   Signers (sender with CalledByEntry scope by default) can be specified after
+   the data parameter, see testinvokefunction documentation for the details.
+`,
+				Action: contractUpgrade,
+				Flags:  upgradeFlags,
+			},
 			generateWrapperCmd,
 			generateRPCWrapperCmd,
 			{
 				Name:      "invokefunction",
 				Usage:     "Invoke deployed contract on the blockchain",
-				UsageText: "neo-go contract invokefunction -r endpoint -w wallet [-a address] [-g gas] [-e sysgas] [--out file] [--force] [--await] scripthash [method] [arguments...] [--] [signers...]",
+				UsageText: "neo-go contract invokefunction -r endpoint -w wallet [-a address] [--ledger [--ledger-index <index>]] [-g gas] [-e sysgas] [--out file] [--force] [--await] scripthash [method] [arguments...] [--] [signers...]",
 				Description: `Executes given (as a script hash) deployed script with the given method,
    arguments and signers. Sender is included in the list of signers by default
    with None witness scope. If you'd like to change default sender's scope, 
@@ -355,6 +440,53 @@ func NewCommands() []*cli.Command {
 						Action:    manifestAddGroup,
 						Flags:     manifestAddGroupFlags,
 					},
+					{
+						Name:      "check-compat",
+						Usage:     "Check the new manifest ABI for changes breaking compatibility with the old one",
+						UsageText: "neo-go contract manifest check-compat -m manifest {--old manifest | -c contract -r endpoint [-s timeout]}",
+						Description: `Compares ABI of the new contract manifest with the old one (taken from the
+   file or from the deployed contract) and prints all changes that break
+   existing contract users: removed methods and events, changed method
+   signatures or event parameters, methods that are no longer safe. The
+   command fails if there are any, so it can be used before contract update
+   or in CI.
+`,
+						Action: manifestCheckCompat,
+						Flags:  manifestCheckCompatFlags,
+					},
+					{
+						Name:      "check-permissions",
+						Usage:     "Check manifest permissions against contract calls made by the script",
+						UsageText: "neo-go contract manifest check-permissions -i nef -m manifest",
+						Description: `Finds contract calls made by the NEF file script (CALLT instructions
+   and System.Contract.Call syscalls) and compares them with permissions
+   declared in the manifest. It reports calls that are not allowed, unused
+   permissions, wildcards that can be replaced with specific contracts or
+   methods and unused methods. If there are any problems, the minimal set of
+   permissions allowing all the calls found is printed in the contract
+   configuration file format and the command fails. Calls with contract or
+   method computed at runtime are only allowed by wildcards, while group
+   permissions can't be checked without the called contract manifest and
+   are assumed to allow any contract.
+`,
+						Action: manifestCheckPermissions,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "in",
+								Aliases:  []string{"i"},
+								Required: true,
+								Usage:    "Input file for the smart contract (*.nef)",
+								Action:   cmdargs.EnsureNotEmpty("in"),
+							},
+							&cli.StringFlag{
+								Name:     "manifest",
+								Aliases:  []string{"m"},
+								Required: true,
+								Usage:    "Manifest input file (*.manifest.json)",
+								Action:   cmdargs.EnsureNotEmpty("manifest"),
+							},
+						},
+					},
 				},
 			},
 		},
@@ -486,6 +618,8 @@ func contractCompile(ctx *cli.Context) error {
 		NoPermissionsCheck: ctx.Bool("no-permissions"),
 
 		GuessEventTypes: ctx.Bool("guess-eventtypes"),
+
+		Optimize: ctx.Int("optimize"),
 	}
 
 	if len(confFile) != 0 {
@@ -798,6 +932,14 @@ func contractDeploy(ctx *cli.Context) error {
 	}
 	defer w.Close()
 	sender := acc.ScriptHash()
+	hash := state.CreateContractHash(sender, nefFile.Checksum, m.Name)
+
+	if !ctx.Bool("force") {
+		err = checkDeployment(nefFile, m, hash).confirm(ctx.App.Writer, ctx.Bool("yes"))
+		if err != nil {
+			return err
+		}
+	}
 
 	cosigners, sgnErr := cmdargs.GetSignersFromContext(ctx, signOffset)
 	if sgnErr != nil {
@@ -814,7 +956,6 @@ func contractDeploy(ctx *cli.Context) error {
 		return extErr
 	}
 
-	hash := state.CreateContractHash(sender, nefFile.Checksum, m.Name)
 	fmt.Fprintf(ctx.App.Writer, "Contract: %s\n", hash.StringLE())
 	return nil
 }
@@ -1,6 +1,13 @@
 package deploy
 
+import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+
 // NewMethod in updated contract.
 func NewMethod() int {
 	return 42
 }
+
+// Migrate is called after the contract update.
+func Migrate() {
+	storage.Put(storage.GetContext(), "migrated", "yes")
+}
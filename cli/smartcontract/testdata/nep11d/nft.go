@@ -0,0 +1,406 @@
+// Code generated by neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>]; DO NOT EDIT.
+
+// Package nft contains RPC wrappers for NeoFS Object NFT contract.
+package nft
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep11"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"math/big"
+)
+
+// Hash contains contract hash.
+var Hash = util.Uint160{0x33, 0x22, 0x11, 0x0, 0xff, 0xee, 0xdd, 0xcc, 0xbb, 0xaa, 0x99, 0x88, 0x77, 0x66, 0x55, 0x44, 0x33, 0x22, 0x11, 0x0}
+
+// NftRecord is a contract-specific nft.Record type used by its methods.
+type NftRecord struct {
+	Owner  util.Uint160
+	Amount *big.Int
+}
+
+// MintedEvent represents "Minted" event emitted by the contract.
+type MintedEvent struct {
+	Owner   util.Uint160
+	TokenId []byte
+	Amount  *big.Int
+}
+
+// Invoker is used by ContractReader to call various safe methods.
+type Invoker interface {
+	nep11.Invoker
+}
+
+// Actor is used by Contract to call state-changing methods.
+type Actor interface {
+	Invoker
+
+	nep11.Actor
+
+	MakeCall(contract util.Uint160, method string, params ...any) (*transaction.Transaction, error)
+	MakeRun(script []byte) (*transaction.Transaction, error)
+	MakeUnsignedCall(contract util.Uint160, method string, attrs []transaction.Attribute, params ...any) (*transaction.Transaction, error)
+	MakeUnsignedRun(script []byte, attrs []transaction.Attribute) (*transaction.Transaction, error)
+	SendCall(contract util.Uint160, method string, params ...any) (util.Uint256, uint32, error)
+	SendRun(script []byte) (util.Uint256, uint32, error)
+}
+
+// ContractReader implements safe contract methods.
+type ContractReader struct {
+	nep11.DivisibleReader
+	invoker Invoker
+	hash    util.Uint160
+}
+
+// Contract implements all contract methods.
+type Contract struct {
+	ContractReader
+	nep11.DivisibleWriter
+	actor Actor
+	hash  util.Uint160
+}
+
+// NewReader creates an instance of ContractReader using Hash and the given Invoker.
+func NewReader(invoker Invoker) *ContractReader {
+	var hash = Hash
+	return &ContractReader{*nep11.NewDivisibleReader(invoker, hash), invoker, hash}
+}
+
+// New creates an instance of Contract using Hash and the given Actor.
+func New(actor Actor) *Contract {
+	var hash = Hash
+	var nep11dt = nep11.NewDivisible(actor, hash)
+	return &Contract{ContractReader{nep11dt.DivisibleReader, actor, hash}, nep11dt.DivisibleWriter, actor, hash}
+}
+
+// Holders invokes `holders` method of contract.
+func (c *ContractReader) Holders() (uuid.UUID, result.Iterator, error) {
+	return unwrap.SessionIterator(c.invoker.Call(c.hash, "holders"))
+}
+
+// HoldersExpanded is similar to Holders (uses the same contract
+// method), but can be useful if the server used doesn't support sessions and
+// doesn't expand iterators. It creates a script that will get the specified
+// number of result items from the iterator right in the VM and return them to
+// you. It's only limited by VM stack and GAS available for RPC invocations.
+func (c *ContractReader) HoldersExpanded(_numOfIteratorItems int) ([]util.Uint160, error) {
+	items, err := unwrap.Array(c.invoker.CallAndExpandIterator(c.hash, "holders", _numOfIteratorItems))
+	if err != nil {
+		return nil, err
+	}
+	return itemsToHoldersValues(items)
+}
+
+// HoldersTraverse returns the next num items of the iterator returned by
+// Holders converted to util.Uint160. The session isn't terminated
+// by this method, use TerminateSession of the Invoker when it's no longer needed.
+func (c *ContractReader) HoldersTraverse(sessionID uuid.UUID, iter *result.Iterator, num int) ([]util.Uint160, error) {
+	items, err := c.invoker.TraverseIterator(sessionID, iter, num)
+	if err != nil {
+		return nil, err
+	}
+	return itemsToHoldersValues(items)
+}
+
+// itemsToHoldersValues converts items of the iterator returned by Holders
+// to util.Uint160.
+func itemsToHoldersValues(items []stackitem.Item) ([]util.Uint160, error) {
+	var (
+		err error
+		res = make([]util.Uint160, len(items))
+	)
+	for i := range items {
+		res[i], err = func(item stackitem.Item) (util.Uint160, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			u, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			return u, nil
+		}(items[i])
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// TokenRecords invokes `tokenRecords` method of contract.
+func (c *ContractReader) TokenRecords(tokenId []byte) (uuid.UUID, result.Iterator, error) {
+	return unwrap.SessionIterator(c.invoker.Call(c.hash, "tokenRecords", tokenId))
+}
+
+// TokenRecordsExpanded is similar to TokenRecords (uses the same contract
+// method), but can be useful if the server used doesn't support sessions and
+// doesn't expand iterators. It creates a script that will get the specified
+// number of result items from the iterator right in the VM and return them to
+// you. It's only limited by VM stack and GAS available for RPC invocations.
+func (c *ContractReader) TokenRecordsExpanded(tokenId []byte, _numOfIteratorItems int) ([]*NftRecord, error) {
+	items, err := unwrap.Array(c.invoker.CallAndExpandIterator(c.hash, "tokenRecords", _numOfIteratorItems, tokenId))
+	if err != nil {
+		return nil, err
+	}
+	return itemsToTokenRecordsValues(items)
+}
+
+// TokenRecordsTraverse returns the next num items of the iterator returned by
+// TokenRecords converted to *NftRecord. The session isn't terminated
+// by this method, use TerminateSession of the Invoker when it's no longer needed.
+func (c *ContractReader) TokenRecordsTraverse(sessionID uuid.UUID, iter *result.Iterator, num int) ([]*NftRecord, error) {
+	items, err := c.invoker.TraverseIterator(sessionID, iter, num)
+	if err != nil {
+		return nil, err
+	}
+	return itemsToTokenRecordsValues(items)
+}
+
+// itemsToTokenRecordsValues converts items of the iterator returned by TokenRecords
+// to *NftRecord.
+func itemsToTokenRecordsValues(items []stackitem.Item) ([]*NftRecord, error) {
+	var (
+		err error
+		res = make([]*NftRecord, len(items))
+	)
+	for i := range items {
+		res[i], err = itemToNftRecord(items[i], nil)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// RawTokens invokes `rawTokens` method of contract.
+func (c *ContractReader) RawTokens() (uuid.UUID, result.Iterator, error) {
+	return unwrap.SessionIterator(c.invoker.Call(c.hash, "rawTokens"))
+}
+
+// RawTokensExpanded is similar to RawTokens (uses the same contract
+// method), but can be useful if the server used doesn't support sessions and
+// doesn't expand iterators. It creates a script that will get the specified
+// number of result items from the iterator right in the VM and return them to
+// you. It's only limited by VM stack and GAS available for RPC invocations.
+func (c *ContractReader) RawTokensExpanded(_numOfIteratorItems int) ([]stackitem.Item, error) {
+	return unwrap.Array(c.invoker.CallAndExpandIterator(c.hash, "rawTokens", _numOfIteratorItems))
+}
+
+// Destroy creates a transaction invoking `destroy` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) Destroy() (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "destroy")
+}
+
+// DestroyTransaction creates a transaction invoking `destroy` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) DestroyTransaction() (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "destroy")
+}
+
+// DestroyUnsigned creates a transaction invoking `destroy` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) DestroyUnsigned() (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "destroy", nil)
+}
+
+// Update creates a transaction invoking `update` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) Update(nef []byte, manifest []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "update", nef, manifest)
+}
+
+// UpdateTransaction creates a transaction invoking `update` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) UpdateTransaction(nef []byte, manifest []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "update", nef, manifest)
+}
+
+// UpdateUnsigned creates a transaction invoking `update` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) UpdateUnsigned(nef []byte, manifest []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "update", nil, nef, manifest)
+}
+
+func (c *Contract) scriptForVerify() ([]byte, error) {
+	return smartcontract.CreateCallWithAssertScript(c.hash, "verify")
+}
+
+// Verify creates a transaction invoking `verify` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) Verify() (util.Uint256, uint32, error) {
+	script, err := c.scriptForVerify()
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	return c.actor.SendRun(script)
+}
+
+// VerifyTransaction creates a transaction invoking `verify` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) VerifyTransaction() (*transaction.Transaction, error) {
+	script, err := c.scriptForVerify()
+	if err != nil {
+		return nil, err
+	}
+	return c.actor.MakeRun(script)
+}
+
+// VerifyUnsigned creates a transaction invoking `verify` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) VerifyUnsigned() (*transaction.Transaction, error) {
+	script, err := c.scriptForVerify()
+	if err != nil {
+		return nil, err
+	}
+	return c.actor.MakeUnsignedRun(script, nil)
+}
+
+// itemToNftRecord converts stack item into *NftRecord.
+// NULL item is returned as nil pointer without error.
+func itemToNftRecord(item stackitem.Item, err error) (*NftRecord, error) {
+	if err != nil {
+		return nil, err
+	}
+	_, null := item.(stackitem.Null)
+	if null {
+		return nil, nil
+	}
+	var res = new(NftRecord)
+	err = res.FromStackItem(item)
+	return res, err
+}
+
+// FromStackItem retrieves fields of NftRecord from the given
+// [stackitem.Item] or returns an error if it's not possible to do to so.
+func (res *NftRecord) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var (
+		index = -1
+		err   error
+	)
+	index++
+	res.Owner, err = func(item stackitem.Item) (util.Uint160, error) {
+		b, err := item.TryBytes()
+		if err != nil {
+			return util.Uint160{}, err
+		}
+		u, err := util.Uint160DecodeBytesBE(b)
+		if err != nil {
+			return util.Uint160{}, err
+		}
+		return u, nil
+	}(arr[index])
+	if err != nil {
+		return fmt.Errorf("field Owner: %w", err)
+	}
+
+	index++
+	res.Amount, err = arr[index].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Amount: %w", err)
+	}
+
+	return nil
+}
+
+// MintedEventsFromApplicationLog retrieves a set of all emitted events
+// with "Minted" name from the provided [result.ApplicationLog].
+func MintedEventsFromApplicationLog(log *result.ApplicationLog) ([]*MintedEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*MintedEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "Minted" {
+				continue
+			}
+			event := new(MintedEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize MintedEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided [stackitem.Array] to MintedEvent or
+// returns an error if it's not possible to do to so.
+func (e *MintedEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 3 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var (
+		index = -1
+		err   error
+	)
+	index++
+	e.Owner, err = func(item stackitem.Item) (util.Uint160, error) {
+		b, err := item.TryBytes()
+		if err != nil {
+			return util.Uint160{}, err
+		}
+		u, err := util.Uint160DecodeBytesBE(b)
+		if err != nil {
+			return util.Uint160{}, err
+		}
+		return u, nil
+	}(arr[index])
+	if err != nil {
+		return fmt.Errorf("field Owner: %w", err)
+	}
+
+	index++
+	e.TokenId, err = arr[index].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field TokenId: %w", err)
+	}
+
+	index++
+	e.Amount, err = arr[index].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Amount: %w", err)
+	}
+
+	return nil
+}
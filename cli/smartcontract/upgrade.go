@@ -0,0 +1,168 @@
+package smartcontract
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/txctx"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/urfave/cli/v2"
+)
+
+// contractUpgrade updates the deployed contract via its update method and
+// (optionally) calls the migration method in the same transaction.
+func contractUpgrade(ctx *cli.Context) error {
+	var (
+		contract = ctx.Generic("contract").(*flags.Address)
+		migrate  = ctx.String("migrate")
+		dryRun   = ctx.Bool("dry-run")
+	)
+	if !contract.IsSet {
+		return cli.Exit("contract hash or address is required", 1)
+	}
+	h := contract.Uint160()
+	nefFile, f, err := readNEFFile(ctx.String("in"))
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	m, manifestBytes, err := readManifest(ctx.String("manifest"), util.Uint160{})
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to read manifest file: %w", err), 1)
+	}
+
+	var appCallParams = []any{f, manifestBytes}
+
+	signOffset, data, err := cmdargs.ParseParams(ctx.Args().Slice(), true)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("unable to parse 'data' parameter: %w", err), 1)
+	}
+	if len(data) > 1 {
+		return cli.Exit("'data' should be represented as a single parameter", 1)
+	}
+	if len(data) != 0 {
+		appCallParams = append(appCallParams, data[0])
+	}
+
+	acc, w, err := options.GetAccFromContext(ctx)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't get sender address: %w", err), 1)
+	}
+	defer w.Close()
+
+	cosigners, exitErr := cmdargs.GetSignersFromContext(ctx, signOffset)
+	if exitErr != nil {
+		return exitErr
+	} else if len(cosigners) == 0 {
+		cosigners = []transaction.Signer{{
+			Account: acc.Contract.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		}}
+	}
+	signersAccounts, err := cmdargs.GetSignersAccounts(acc, w, cosigners, transaction.None)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("invalid signers: %w", err), 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, act, exitErr := options.GetRPCWithActor(gctx, ctx, signersAccounts)
+	if exitErr != nil {
+		return exitErr
+	}
+	cs, err := c.GetContractStateByHash(h)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't fetch contract info: %w", err), 1)
+	}
+
+	var r *deployReport
+	if !ctx.Bool("force") {
+		r = checkDeployment(nefFile, m, h)
+		r.update = true
+		if m.Name != cs.Manifest.Name {
+			r.errorf("contract name can't be changed on update (%q -> %q)", cs.Manifest.Name, m.Name)
+		}
+		for _, err := range manifest.CheckCompatibility(&cs.Manifest, m) {
+			r.warnf("incompatible ABI change: %s", err)
+		}
+	}
+
+	b := smartcontract.NewBuilder()
+	b.InvokeMethod(h, ctx.String("method"), appCallParams...)
+	if migrate != "" {
+		b.InvokeMethod(h, migrate)
+	}
+	script, err := b.Script()
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to create update script: %w", err), 1)
+	}
+	resp, err := c.InvokeScriptWithDiagnostics(script, act.Signers())
+	if err != nil {
+		return cli.Exit(fmt.Errorf("dry-run failed: %w", err), 1)
+	}
+	printUpgradeDryRun(ctx.App.Writer, resp, cs.ID)
+
+	if dryRun {
+		if r != nil {
+			r.print(ctx.App.Writer)
+		}
+		if resp.State != vmstate.Halt.String() {
+			return cli.Exit("update dry-run failed", 1)
+		}
+		return nil
+	}
+	if r != nil {
+		err = r.confirm(ctx.App.Writer, ctx.Bool("yes"))
+		if err != nil {
+			return err
+		}
+	}
+	if resp.State != vmstate.Halt.String() && !ctx.Bool("force") {
+		return cli.Exit("update dry-run failed.\nUse --force flag to send the transaction anyway.", 1)
+	}
+	tx, err := act.MakeUnsignedUncheckedRun(script, resp.GasConsumed, nil)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to create tx: %w", err), 1)
+	}
+	return txctx.SignAndSend(ctx, act, acc, tx)
+}
+
+// printUpgradeDryRun prints the update invocation result along with the storage
+// changes made to the contract with the given ID, changes made to other
+// contracts are only counted.
+func printUpgradeDryRun(w io.Writer, resp *result.Invoke, id int32) {
+	fmt.Fprintf(w, "Dry-run: %s, GAS consumed: %s\n", resp.State, fixedn.Fixed8(resp.GasConsumed))
+	if resp.FaultException != "" {
+		fmt.Fprintf(w, "Exception: %s\n", resp.FaultException)
+	}
+	if resp.Diagnostics == nil {
+		return
+	}
+	var other int
+	fmt.Fprintln(w, "Storage changes:")
+	for _, op := range resp.Diagnostics.Changes {
+		if len(op.Key) < 4 || int32(binary.LittleEndian.Uint32(op.Key)) != id {
+			other++
+			continue
+		}
+		fmt.Fprintf(w, "  %s %s", op.State, hex.EncodeToString(op.Key[4:]))
+		if op.Value != nil {
+			fmt.Fprintf(w, " = %s", hex.EncodeToString(op.Value))
+		}
+		fmt.Fprintln(w)
+	}
+	if other != 0 {
+		fmt.Fprintf(w, "  (%d changes of other contracts)\n", other)
+	}
+}
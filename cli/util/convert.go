@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
 	"github.com/nspcc-dev/neo-go/cli/flags"
@@ -22,7 +24,21 @@ func NewCommands() []*cli.Command {
 	rpcFlag := *rpcFlagOriginal
 	rpcFlag.Required = false
 	txDumpFlags := append([]cli.Flag{&rpcFlag}, options.RPC[1:]...)
-	txSendFlags := append(txDumpFlags, txctx.AwaitFlag)
+	txSendFlags := append(slices.Clone(txDumpFlags), txctx.AwaitFlag)
+	txDumpFlags = append(txDumpFlags,
+		&cli.BoolFlag{
+			Name:  "raw",
+			Usage: "Treat the argument as base64- or hex-encoded transaction (base64 is checked first)",
+		},
+		&cli.BoolFlag{
+			Name:  "hex",
+			Usage: "Use hex encoding and do not check base64 for raw transaction",
+		},
+		&cli.BoolFlag{
+			Name:  "full",
+			Usage: "Print all transaction details (always enabled for raw transactions)",
+		},
+	)
 	txCancelFlags := append([]cli.Flag{
 		&flags.AddressFlag{
 			Name:    "address",
@@ -92,6 +108,13 @@ func NewCommands() []*cli.Command {
 		},
 	}, options.RPC...)
 	uploadBinFlags = append(uploadBinFlags, options.Wallet...)
+	txBuilderFlags := append([]cli.Flag{}, options.RPC...)
+	txBuilderFlags = append(txBuilderFlags, options.Wallet...)
+	txBuilderFlags = append(txBuilderFlags, &cli.DurationFlag{
+		Name:  "unlock-ttl",
+		Value: 5 * time.Minute,
+		Usage: "Time to keep accounts unlocked after entering the password, 0 keeps them unlocked until exit",
+	})
 	return []*cli.Command{
 		{
 			Name:  "util",
@@ -141,15 +164,19 @@ func NewCommands() []*cli.Command {
 				},
 				{
 					Name:      "txdump",
-					Usage:     "Dump transaction stored in file",
-					UsageText: "txdump [-r <endpoint>] <file.in>",
+					Usage:     "Dump transaction stored in file or given as raw base64/hex data",
+					UsageText: "txdump [-r <endpoint>] [--full] <file.in> | txdump [-r <endpoint>] --raw [--hex] <tx>",
 					Action:    txDump,
 					Flags:     txDumpFlags,
 					Description: `Dumps the transaction from the given parameter context file to 
-   the output. This command expects a ContractParametersContext JSON file for input, it can't handle
-   binary (or hex- or base64-encoded) transactions. If --rpc-endpoint flag is specified the result 
-   of the given script after running it true the VM will be printed. Otherwise only transaction will
-   be printed.
+   the output. By default this command expects a ContractParametersContext JSON file for input,
+   binary transaction encoded in base64 or hex can be given directly as an argument with --raw flag.
+   With --full flag (implied for raw transactions) all transaction details are printed including
+   signer scopes, allowed contracts/groups and witness rules, attributes and witnesses with
+   disassembled invocation and verification scripts. No network connection is needed for that,
+   so it can be used to review transactions on offline machines before signing. If --rpc-endpoint
+   flag is specified the result of the given script after running it true the VM will be printed.
+   Otherwise only transaction will be printed.
 `,
 				},
 				{
@@ -169,6 +196,23 @@ func NewCommands() []*cli.Command {
 						},
 					},
 				},
+				{
+					Name:      "txbuilder",
+					Usage:     "Interactively build, sign and send a transaction",
+					UsageText: "txbuilder -r <endpoint> --wallet <wallet> [--wallet-config <path>] [--unlock-ttl <duration>]",
+					Description: `Starts an interactive prompt that allows to compose an invocation transaction
+   step by step (contract, method, parameters, signers and attributes), preview
+   its script and fees, sign it with the wallet keys, save and load signing
+   context for other parties and send the transaction to the RPC node. Type
+   'help' in the prompt to get the list of available commands.
+
+   Accounts unlocked for signing are locked again (and their keys are wiped
+   from memory) after --unlock-ttl, so the password needs to be entered again
+   for subsequent signing after that.
+`,
+					Action: runTxBuilder,
+					Flags:  txBuilderFlags,
+				},
 				{
 					Name:      "upload-bin",
 					Usage:     "Fetch blocks from RPC node and upload them to the NeoFS container",
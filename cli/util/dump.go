@@ -1,13 +1,23 @@
 package util
 
 import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/cli/query"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/urfave/cli/v2"
 )
 
@@ -19,20 +29,40 @@ func txDump(ctx *cli.Context) error {
 		return cli.Exit("only one input file is accepted", 1)
 	}
 
-	c, err := paramcontext.Read(args[0])
-	if err != nil {
-		return cli.Exit(err, 1)
-	}
+	var (
+		tx   *transaction.Transaction
+		full = ctx.Bool("full")
+	)
+	if ctx.Bool("raw") {
+		var err error
+		tx, err = decodeRawTx(args[0], ctx.Bool("hex"))
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+		full = true
+	} else {
+		c, err := paramcontext.Read(args[0])
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
 
-	tx, ok := c.Verifiable.(*transaction.Transaction)
-	if !ok {
-		return cli.Exit("verifiable item is not a transaction", 1)
+		var ok bool
+		tx, ok = c.Verifiable.(*transaction.Transaction)
+		if !ok {
+			return cli.Exit("verifiable item is not a transaction", 1)
+		}
 	}
 
-	err = query.DumpApplicationLog(ctx, nil, tx, nil, true)
+	err := query.DumpApplicationLog(ctx, nil, tx, nil, true)
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
+	if full {
+		err = dumpTxDetails(ctx, tx)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+	}
 
 	if ctx.String(options.RPCEndpointFlag) != "" {
 		gctx, cancel := options.GetTimeoutContext(ctx)
@@ -55,3 +85,104 @@ func txDump(ctx *cli.Context) error {
 	}
 	return nil
 }
+
+// decodeRawTx decodes base64- or hex-encoded (base64 is checked first unless
+// hexOnly is set) transaction.
+func decodeRawTx(s string, hexOnly bool) (*transaction.Transaction, error) {
+	var (
+		b   []byte
+		err error
+	)
+	s = strings.TrimSpace(s)
+	if !hexOnly {
+		b, err = base64.StdEncoding.DecodeString(s)
+	}
+	if hexOnly || err != nil {
+		b, err = hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	}
+	if err != nil {
+		return nil, errors.New("unknown encoding: base64 or hex are supported")
+	}
+	tx, err := transaction.NewTransactionFromBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// dumpTxDetails prints all transaction fields not printed by
+// query.DumpApplicationLog: signer scopes and rules, attributes and witnesses
+// (with disassembled scripts). It doesn't need any network connection.
+func dumpTxDetails(ctx *cli.Context, tx *transaction.Transaction) error {
+	var buf []byte
+
+	buf = fmt.Appendf(buf, "Version:\t%d\n", tx.Version)
+	buf = fmt.Appendf(buf, "Nonce:\t%d\n", tx.Nonce)
+	buf = fmt.Appendf(buf, "Size:\t%d bytes\n", tx.Size())
+	for i, s := range tx.Signers {
+		buf = fmt.Appendf(buf, "Signer #%d:\t%s (%s)\n", i, address.Uint160ToString(s.Account), s.Account.StringLE())
+		buf = fmt.Appendf(buf, "  Scopes:\t%s\n", s.Scopes)
+		for _, h := range s.AllowedContracts {
+			buf = fmt.Appendf(buf, "  AllowedContract:\t%s\n", h.StringLE())
+		}
+		for _, g := range s.AllowedGroups {
+			buf = fmt.Appendf(buf, "  AllowedGroup:\t%s\n", g.StringCompressed())
+		}
+		for _, r := range s.Rules {
+			cond, err := json.Marshal(r.Condition)
+			if err != nil {
+				return fmt.Errorf("signer #%d: %w", i, err)
+			}
+			buf = fmt.Appendf(buf, "  Rule:\t%s %s\n", r.Action, cond)
+		}
+	}
+	for i, a := range tx.Attributes {
+		attr, err := json.Marshal(&a)
+		if err != nil {
+			return fmt.Errorf("attribute #%d: %w", i, err)
+		}
+		buf = fmt.Appendf(buf, "Attribute #%d:\t%s %s\n", i, a.Type, attr)
+	}
+	for i, w := range tx.Scripts {
+		buf = fmt.Appendf(buf, "Witness #%d:\t%s\n", i, describeVerification(w.VerificationScript))
+		buf = fmt.Appendf(buf, "  Invocation:\t%s\n", base64.StdEncoding.EncodeToString(w.InvocationScript))
+		buf = appendOps(buf, w.InvocationScript)
+		buf = fmt.Appendf(buf, "  Verification:\t%s\n", base64.StdEncoding.EncodeToString(w.VerificationScript))
+		buf = appendOps(buf, w.VerificationScript)
+	}
+	tw := tabwriter.NewWriter(ctx.App.Writer, 0, 4, 4, '\t', 0)
+	_, err := tw.Write(buf)
+	if err != nil {
+		return err
+	}
+	return tw.Flush()
+}
+
+// describeVerification returns a short description of the given verification
+// script.
+func describeVerification(script []byte) string {
+	if len(script) == 0 {
+		return "deployed contract verification"
+	}
+	if pub, ok := vm.ParseSignatureContract(script); ok {
+		k, err := keys.NewPublicKeyFromBytes(pub, elliptic.P256())
+		if err == nil {
+			return fmt.Sprintf("signature of %s", k.StringCompressed())
+		}
+	}
+	if m, pubs, ok := vm.ParseMultiSigContract(script); ok {
+		return fmt.Sprintf("%d out of %d multisignature", m, len(pubs))
+	}
+	return "custom verification script"
+}
+
+func appendOps(buf []byte, script []byte) []byte {
+	if len(script) == 0 {
+		return buf
+	}
+	v := vm.New()
+	v.LoadScript(script)
+	ops := bytes.NewBuffer(nil)
+	v.PrintOps(ops)
+	return append(buf, ops.Bytes()...)
+}
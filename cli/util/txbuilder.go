@@ -0,0 +1,626 @@
+package util
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/kballard/go-shellquote"
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/cli/txctx"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli/v2"
+)
+
+const txBuilderKey = "txbuilder"
+
+// txBuilder is the state of the interactive transaction builder.
+type txBuilder struct {
+	rl       *readline.Instance
+	client   *rpcclient.Client
+	wallet   *wallet.Wallet
+	session  *wallet.Session
+	pass     *string
+	contract util.Uint160
+	method   string
+	params   []smartcontract.Parameter
+	signers  []transaction.Signer
+	attrs    []transaction.Attribute
+	// tx is the transaction created by "sign" or "load", it's dropped on
+	// any settings change.
+	tx *transaction.Transaction
+	// scCtx is the signing context of tx if it's not signed completely.
+	scCtx *context.ParameterContext
+}
+
+var txBuilderCommands = []*cli.Command{
+	{
+		Name:      "contract",
+		Usage:     "Set the contract to invoke",
+		UsageText: "contract <hash>",
+		Description: `<hash> is a contract hash in LE form or its address.
+
+Example:
+> contract 0xd2a4cff31913016155e38e474a2c06d08be276cf`,
+		Action: handleTxBuilderContract,
+	},
+	{
+		Name:      "method",
+		Usage:     "Set the method to invoke and its parameters",
+		UsageText: "method <name> [<parameters>...]",
+		Description: `<name> is a method name, <parameters> are specified in the same way as for
+'contract invokefunction' command (see 'neo-go contract testinvokefunction --help').
+
+Example:
+> method transfer NbrUYaZgyhSkNoRo9ugRyEMdUZxrhkNaWB NTh9TnZTstvAePEYWDGLLxidBikJE24uTo 100 any:null`,
+		Action: handleTxBuilderMethod,
+	},
+	{
+		Name:      "signers",
+		Usage:     "Set transaction signers",
+		UsageText: "signers <signer> [<signer>...]",
+		Description: `Signers are specified in the same way as for 'contract invokefunction'
+command, the first one is a sender paying fees. Signers need to have accounts
+in the wallet, but these can be watch-only (not having private keys). If no
+signers are set, the default wallet account with CalledByEntry scope is used.
+
+Example:
+> signers NbrUYaZgyhSkNoRo9ugRyEMdUZxrhkNaWB:CalledByEntry NTh9TnZTstvAePEYWDGLLxidBikJE24uTo:Global`,
+		Action: handleTxBuilderSigners,
+	},
+	{
+		Name:      "attr",
+		Usage:     "Add transaction attribute",
+		UsageText: "attr <type> [<value>]",
+		Description: `<type> is one of HighPriority (no value), NotValidBefore (block height value)
+or Conflicts (transaction hash value).
+
+Example:
+> attr NotValidBefore 1000`,
+		Action: handleTxBuilderAttr,
+	},
+	{
+		Name:        "show",
+		Usage:       "Show the transaction being built and its script",
+		UsageText:   "show",
+		Description: "Show the contract, method, parameters, signers, attributes and disassembled script of the transaction being built.",
+		Action:      handleTxBuilderShow,
+	},
+	{
+		Name:        "fees",
+		Usage:       "Test-invoke the script and estimate fees",
+		UsageText:   "fees",
+		Description: "Test-invoke the script via RPC node and show invocation results along with system and network fees required for the transaction.",
+		Action:      handleTxBuilderFees,
+	},
+	{
+		Name:      "sign",
+		Usage:     "Create the transaction and sign it with available keys",
+		UsageText: "sign [<file>]",
+		Description: `Creates the transaction and adds signatures of all signers having keys in the
+wallet (account password is asked for if needed). If some signatures are
+missing, the signing context can be saved into <file> to be signed by other
+parties (with 'wallet sign' command) and then loaded back with 'load'.`,
+		Action: handleTxBuilderSign,
+	},
+	{
+		Name:        "load",
+		Usage:       "Load signing context from the file",
+		UsageText:   "load <file>",
+		Description: "Load signing context (with signatures added by other parties) from the file, it replaces the current transaction.",
+		Action:      handleTxBuilderLoad,
+	},
+	{
+		Name:        "send",
+		Usage:       "Send the signed transaction",
+		UsageText:   "send",
+		Description: "Send the completely signed transaction to the RPC node.",
+		Action:      handleTxBuilderSend,
+	},
+	{
+		Name:        "reset",
+		Usage:       "Reset the transaction being built",
+		UsageText:   "reset",
+		Description: "Drop all transaction settings and signatures.",
+		Action:      handleTxBuilderReset,
+	},
+	{
+		Name:        "exit",
+		Usage:       "Exit the transaction builder",
+		UsageText:   "exit",
+		Description: "Exit the transaction builder.",
+		Action:      handleTxBuilderExit,
+	},
+}
+
+var errTxBuilderExit = errors.New("exit")
+
+func runTxBuilder(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	w, pass, err := options.GetWalletFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer w.Close()
+	session := wallet.NewSession(w, ctx.Duration("unlock-ttl"))
+	defer session.Close()
+	// The client is used for the whole session, so no timeout is applied.
+	c, exitErr := options.GetRPCClient(gocontext.Background(), ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+	defer c.Close()
+
+	var pcItems []readline.PrefixCompleterInterface
+	for _, cmd := range txBuilderCommands {
+		pcItems = append(pcItems, readline.PcItem(cmd.Name))
+	}
+	rlCfg := &readline.Config{
+		Prompt:       "txbuilder> ",
+		AutoComplete: readline.NewPrefixCompleter(pcItems...),
+	}
+	if ctx.App.Reader != os.Stdin {
+		rlCfg.Stdin = io.NopCloser(ctx.App.Reader)
+		rlCfg.Stdout = ctx.App.Writer
+		rlCfg.Stderr = ctx.App.ErrWriter
+		rlCfg.FuncIsTerminal = func() bool { return false }
+	}
+	rl, err := readline.NewEx(rlCfg)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to create readline instance: %w", err), 1)
+	}
+	defer rl.Close()
+
+	shell := cli.NewApp()
+	shell.Name = "txbuilder"
+	shell.HelpName = ""
+	shell.UsageText = ""
+	shell.Usage = "Interactive transaction builder"
+	shell.Writer = rl.Stdout()
+	shell.ErrWriter = rl.Stderr()
+	// Override default error handler in order not to exit on error.
+	shell.ExitErrHandler = func(*cli.Context, error) {}
+	shell.Commands = txBuilderCommands
+	shell.Metadata = map[string]any{
+		txBuilderKey: &txBuilder{
+			rl:      rl,
+			client:  c,
+			wallet:  w,
+			session: session,
+			pass:    pass,
+		},
+	}
+	for {
+		line, err := rl.Readline()
+		if errors.Is(err, io.EOF) || errors.Is(err, readline.ErrInterrupt) {
+			return nil
+		}
+		if err != nil {
+			return cli.Exit(fmt.Errorf("failed to read input: %w", err), 1)
+		}
+		args, err := shellquote.Split(line)
+		if err != nil {
+			fmt.Fprintf(shell.ErrWriter, "Error: failed to parse arguments: %s\n", err)
+			continue
+		}
+		if len(args) == 0 {
+			continue
+		}
+		err = shell.Run(append([]string{"txbuilder"}, args...))
+		if errors.Is(err, errTxBuilderExit) {
+			return nil
+		}
+		if err != nil {
+			fmt.Fprintf(shell.ErrWriter, "Error: %s\n", err)
+		}
+	}
+}
+
+func getTxBuilder(ctx *cli.Context) *txBuilder {
+	return ctx.App.Metadata[txBuilderKey].(*txBuilder)
+}
+
+func handleTxBuilderContract(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("contract hash is expected")
+	}
+	h, err := flags.ParseAddress(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("invalid contract hash: %w", err)
+	}
+	b := getTxBuilder(ctx)
+	cs, err := b.client.GetContractStateByHash(h)
+	if err != nil {
+		return fmt.Errorf("failed to get contract state: %w", err)
+	}
+	b.contract = h
+	b.drop()
+	fmt.Fprintf(ctx.App.Writer, "Contract: %s (%s)\n", cs.Manifest.Name, h.StringLE())
+	return nil
+}
+
+func handleTxBuilderMethod(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return errors.New("method name is expected")
+	}
+	args := ctx.Args().Slice()
+	offset, scParams, err := cmdargs.ParseParams(args[1:], true)
+	if err != nil {
+		return fmt.Errorf("failed to parse parameters: %w", err)
+	}
+	if offset != len(args)-1 {
+		return errors.New("signers can't be specified here, use 'signers' command")
+	}
+	b := getTxBuilder(ctx)
+	b.method = args[0]
+	b.params = scParams
+	b.drop()
+	return nil
+}
+
+func handleTxBuilderSigners(ctx *cli.Context) error {
+	signers, err := cmdargs.ParseSigners(ctx.Args().Slice())
+	if err != nil {
+		return err
+	}
+	b := getTxBuilder(ctx)
+	b.signers = signers
+	b.drop()
+	return nil
+}
+
+func handleTxBuilderAttr(ctx *cli.Context) error {
+	var (
+		args = ctx.Args().Slice()
+		attr transaction.Attribute
+	)
+	if len(args) == 0 {
+		return errors.New("attribute type is expected")
+	}
+	switch strings.ToLower(args[0]) {
+	case "highpriority":
+		if len(args) != 1 {
+			return errors.New("HighPriority attribute has no value")
+		}
+		attr.Type = transaction.HighPriority
+	case "notvalidbefore":
+		if len(args) != 2 {
+			return errors.New("NotValidBefore attribute requires block height")
+		}
+		height, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid height: %w", err)
+		}
+		attr.Type = transaction.NotValidBeforeT
+		attr.Value = &transaction.NotValidBefore{Height: uint32(height)}
+	case "conflicts":
+		if len(args) != 2 {
+			return errors.New("Conflicts attribute requires transaction hash")
+		}
+		h, err := util.Uint256DecodeStringLE(strings.TrimPrefix(args[1], "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid transaction hash: %w", err)
+		}
+		attr.Type = transaction.ConflictsT
+		attr.Value = &transaction.Conflicts{Hash: h}
+	default:
+		return fmt.Errorf("unsupported attribute type: %s", args[0])
+	}
+	b := getTxBuilder(ctx)
+	b.attrs = append(b.attrs, attr)
+	b.drop()
+	return nil
+}
+
+func handleTxBuilderShow(ctx *cli.Context) error {
+	var (
+		b = getTxBuilder(ctx)
+		w = ctx.App.Writer
+	)
+	script, err := b.script()
+	if err != nil {
+		return err
+	}
+	signers, err := b.getSigners()
+	if err != nil {
+		return err
+	}
+	if b.tx != nil {
+		fmt.Fprintf(w, "Transaction:\t%s\n", b.tx.Hash().StringLE())
+		fmt.Fprintf(w, "Missing signatures:\t%d\n", len(b.missingSigners()))
+	}
+	fmt.Fprintf(w, "Contract:\t%s\n", b.contract.StringLE())
+	fmt.Fprintf(w, "Method:\t%s\n", b.method)
+	for i, p := range b.params {
+		fmt.Fprintf(w, "Parameter #%d:\t%s\n", i, p)
+	}
+	for i, s := range signers {
+		fmt.Fprintf(w, "Signer #%d:\t%s (%s)\n", i, address.Uint160ToString(s.Signer.Account), s.Signer.Scopes)
+	}
+	for i, a := range b.attrs {
+		fmt.Fprintf(w, "Attribute #%d:\t%s\n", i, a.Type)
+	}
+	v := vm.New()
+	v.LoadScript(script)
+	v.PrintOps(w)
+	return nil
+}
+
+func handleTxBuilderFees(ctx *cli.Context) error {
+	b := getTxBuilder(ctx)
+	script, err := b.script()
+	if err != nil {
+		return err
+	}
+	a, err := b.actor()
+	if err != nil {
+		return err
+	}
+	res, err := a.Run(script)
+	if err != nil {
+		return fmt.Errorf("failed to test-invoke the script: %w", err)
+	}
+	w := ctx.App.Writer
+	fmt.Fprintf(w, "VMState:\t%s\n", res.State)
+	if res.FaultException != "" {
+		fmt.Fprintf(w, "FaultException:\t%s\n", res.FaultException)
+	}
+	tx, err := a.MakeUnsignedUncheckedRun(script, res.GasConsumed, b.attrs)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	fmt.Fprintf(w, "SystemFee:\t%s GAS\n", fixedn.Fixed8(tx.SystemFee))
+	fmt.Fprintf(w, "NetworkFee:\t%s GAS\n", fixedn.Fixed8(tx.NetworkFee))
+	fmt.Fprintf(w, "Total fee:\t%s GAS\n", fixedn.Fixed8(tx.SystemFee+tx.NetworkFee))
+	return nil
+}
+
+func handleTxBuilderSign(ctx *cli.Context) error {
+	if ctx.NArg() > 1 {
+		return errors.New("only one output file is accepted")
+	}
+	b := getTxBuilder(ctx)
+	// Accounts can't be relocked while signing.
+	if err := b.session.Run(b.sign); err != nil {
+		return err
+	}
+	w := ctx.App.Writer
+	if b.scCtx == nil {
+		fmt.Fprintf(w, "Transaction %s is signed and can be sent\n", b.tx.Hash().StringLE())
+		return nil
+	}
+	fmt.Fprintf(w, "Transaction %s is signed partially, missing signatures:\n", b.tx.Hash().StringLE())
+	for _, h := range b.missingSigners() {
+		fmt.Fprintf(w, "\t%s\n", address.Uint160ToString(h))
+	}
+	if ctx.NArg() == 1 {
+		if err := paramcontext.Save(b.scCtx, ctx.Args().First()); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Signing context is saved to %s\n", ctx.Args().First())
+	}
+	return nil
+}
+
+// sign creates the transaction (if needed) and adds signatures of all signers
+// having keys in the wallet unlocking them if needed.
+func (b *txBuilder) sign() error {
+	script, err := b.script()
+	if err != nil {
+		return err
+	}
+	signers, err := b.getSigners()
+	if err != nil {
+		return err
+	}
+	for _, s := range signers {
+		if err := b.unlock(s.Account); err != nil {
+			return err
+		}
+	}
+	a, err := actor.New(b.client, signers)
+	if err != nil {
+		return fmt.Errorf("failed to create actor: %w", err)
+	}
+	if b.tx == nil {
+		tx, err := a.MakeUnsignedRun(script, b.attrs)
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+		b.scCtx, err = a.SignPartially(tx)
+		if err != nil {
+			return err
+		}
+		b.tx = tx
+	} else if b.scCtx != nil {
+		// Loaded or previously signed context, add what's available now.
+		for i, s := range signers {
+			if !s.Account.CanSign() || !slices.Contains(b.missingSigners(), s.Signer.Account) {
+				continue
+			}
+			sig := s.Account.SignHashable(b.scCtx.Network, b.tx)
+			if err := b.scCtx.AddSignature(s.Signer.Account, s.Account.Contract, s.Account.PublicKey(), sig); err != nil {
+				return fmt.Errorf("failed to add signature for signer #%d (%s): %w", i, s.Account.Address, err)
+			}
+		}
+		if len(b.missingSigners()) == 0 {
+			if b.tx, err = b.scCtx.GetCompleteTransaction(); err != nil {
+				return fmt.Errorf("failed to complete transaction: %w", err)
+			}
+			b.scCtx = nil
+		}
+	}
+	return nil
+}
+
+func handleTxBuilderLoad(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("input file is expected")
+	}
+	scCtx, err := paramcontext.Read(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	tx, ok := scCtx.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return errors.New("not a transaction signing context")
+	}
+	b := getTxBuilder(ctx)
+	b.tx = tx
+	b.scCtx = scCtx
+	b.signers = tx.Signers
+	b.attrs = tx.Attributes
+	fmt.Fprintf(ctx.App.Writer, "Loaded transaction %s, missing signatures: %d\n", tx.Hash().StringLE(), len(b.missingSigners()))
+	return nil
+}
+
+func handleTxBuilderSend(ctx *cli.Context) error {
+	b := getTxBuilder(ctx)
+	if b.tx == nil {
+		return errors.New("transaction is not signed, use 'sign' command")
+	}
+	tx := b.tx
+	if b.scCtx != nil {
+		var err error
+		tx, err = b.scCtx.GetCompleteTransaction()
+		if err != nil {
+			return fmt.Errorf("failed to complete transaction: %w", err)
+		}
+	}
+	h, err := b.client.SendRawTransaction(tx)
+	if err != nil {
+		return fmt.Errorf("failed to submit transaction to RPC node: %w", err)
+	}
+	txctx.DumpTransactionInfo(ctx.App.Writer, h, nil)
+	return nil
+}
+
+func handleTxBuilderReset(ctx *cli.Context) error {
+	b := getTxBuilder(ctx)
+	*b = txBuilder{
+		rl:     b.rl,
+		client: b.client,
+		wallet: b.wallet,
+		pass:   b.pass,
+	}
+	return nil
+}
+
+func handleTxBuilderExit(ctx *cli.Context) error {
+	fmt.Fprintln(ctx.App.Writer, "Bye!")
+	return errTxBuilderExit
+}
+
+// script returns the script of the transaction being built.
+func (b *txBuilder) script() ([]byte, error) {
+	if b.tx != nil {
+		return b.tx.Script, nil
+	}
+	if b.contract.Equals(util.Uint160{}) {
+		return nil, errors.New("contract is not set, use 'contract' command")
+	}
+	if b.method == "" {
+		return nil, errors.New("method is not set, use 'method' command")
+	}
+	params := make([]any, len(b.params))
+	for i := range b.params {
+		var err error
+		params[i], err = smartcontract.ExpandParameterToEmitable(b.params[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert parameter #%d: %w", i, err)
+		}
+	}
+	return smartcontract.CreateCallScript(b.contract, b.method, params...)
+}
+
+// getSigners returns the signer accounts of the transaction being built.
+func (b *txBuilder) getSigners() ([]actor.SignerAccount, error) {
+	signers := b.signers
+	if len(signers) == 0 {
+		addr := b.wallet.GetChangeAddress()
+		if addr.Equals(util.Uint160{}) {
+			return nil, errors.New("no signers are set and wallet has no default account")
+		}
+		signers = []transaction.Signer{{Account: addr, Scopes: transaction.CalledByEntry}}
+	}
+	return actor.ResolveSigners(b.wallet, signers)
+}
+
+// actor returns an Actor for the current signers.
+func (b *txBuilder) actor() (*actor.Actor, error) {
+	signers, err := b.getSigners()
+	if err != nil {
+		return nil, err
+	}
+	a, err := actor.New(b.client, signers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create actor: %w", err)
+	}
+	return a, nil
+}
+
+// unlock decrypts the account key if it's encrypted, the password is taken
+// from the wallet config or requested from the user. The account is relocked
+// by the session after --unlock-ttl.
+func (b *txBuilder) unlock(acc *wallet.Account) error {
+	if b.session.IsUnlocked(acc) || acc.EncryptedWIF == "" {
+		return nil
+	}
+	var pass string
+	if b.pass != nil {
+		pass = *b.pass
+	} else {
+		raw, err := b.rl.ReadPassword(fmt.Sprintf("Enter account %s password > ", acc.Address))
+		if err != nil {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		pass = strings.TrimRight(string(raw), "\n")
+	}
+	if err := b.session.Unlock(acc, pass); err != nil {
+		return fmt.Errorf("failed to decrypt account %s: %w", acc.Address, err)
+	}
+	return nil
+}
+
+// drop drops the transaction created previously, it's to be done on any
+// settings change.
+func (b *txBuilder) drop() {
+	b.tx = nil
+	b.scCtx = nil
+}
+
+// missingSigners returns the list of signers having no signatures in the
+// context.
+func (b *txBuilder) missingSigners() []util.Uint160 {
+	if b.scCtx == nil {
+		return nil
+	}
+	var res []util.Uint160
+	for _, s := range b.tx.Signers {
+		item, ok := b.scCtx.Items[s.Account]
+		if !ok || len(item.Signatures) == 0 && len(item.Parameters) != 0 {
+			res = append(res, s.Account)
+		}
+	}
+	return res
+}
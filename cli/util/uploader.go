@@ -217,7 +217,7 @@ func fetchLatestMissingBlockIndex(ctx context.Context, p *pool.Pool, containerID
 					err       error
 				)
 				err = retry(func() error {
-					objectIDs, err = neofs.ObjectSearch(ctx, p, priv, containerID.String(), prm)
+					objectIDs, err = neofs.ObjectSearch(ctx, p, priv, nil, containerID.String(), prm)
 					return err
 				})
 				results[i] = searchResult{startIndex: startIndex, endIndex: endIndex, numOIDs: len(objectIDs), err: err}
@@ -339,7 +339,7 @@ func uploadIndexFiles(ctx *cli.Context, p *pool.Pool, containerID cid.ID, accoun
 	var objectIDs []oid.ID
 	errSearch := retry(func() error {
 		var errSearchIndex error
-		objectIDs, errSearchIndex = neofs.ObjectSearch(ctx.Context, p, account.PrivateKey(), containerID.String(), prm)
+		objectIDs, errSearchIndex = neofs.ObjectSearch(ctx.Context, p, account.PrivateKey(), nil, containerID.String(), prm)
 		return errSearchIndex
 	})
 	if errSearch != nil {
@@ -518,7 +518,7 @@ func searchObjects(ctx context.Context, p *pool.Pool, containerID cid.ID, accoun
 					var objIDs []oid.ID
 					err := retry(func() error {
 						var errBlockSearch error
-						objIDs, errBlockSearch = neofs.ObjectSearch(ctx, p, account.PrivateKey(), containerID.String(), prm)
+						objIDs, errBlockSearch = neofs.ObjectSearch(ctx, p, account.PrivateKey(), nil, containerID.String(), prm)
 						return errBlockSearch
 					})
 					if err != nil {
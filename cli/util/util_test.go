@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
@@ -210,3 +212,144 @@ func TestUploadBin(t *testing.T) {
 	e.In.WriteString("one\r")
 	e.RunWithErrorCheckExit(t, "failed to dial NeoFS pool", append(args, "--cid", "9iVfUg8aDHKjPC4LhQXEkVUM4HDkR7UCXYLs8NQwYfSG", "--wallet", testcli.ValidatorWallet, "--rpc-endpoint", "http://"+e.RPC.Addresses()[0])...)
 }
+
+func TestUtilTxBuilder(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	w, err := wallet.NewWalletFromFile("../testdata/testwallet.json")
+	require.NoError(t, err)
+
+	gasHash, err := e.Chain.GetNativeContractScriptHash(nativenames.Gas)
+	require.NoError(t, err)
+	args := []string{"neo-go", "util", "txbuilder",
+		"-r", "http://" + e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet}
+	run := func(t *testing.T, commands ...string) string {
+		e.CLI.Reader = strings.NewReader(strings.Join(commands, "\n") + "\n")
+		e.Run(t, args...)
+		return e.Out.String() + e.Err.String()
+	}
+
+	t.Run("missing wallet", func(t *testing.T) {
+		e.RunWithError(t, args[:5]...)
+	})
+	t.Run("incomplete", func(t *testing.T) {
+		out := run(t, "show", "contract "+gasHash.StringLE(), "sign", "send", "exit")
+		require.Contains(t, out, "Error: contract is not set")
+		require.Contains(t, out, "Error: method is not set")
+		require.Contains(t, out, "Contract: GasToken")
+		require.Contains(t, out, "Error: transaction is not signed")
+		require.Contains(t, out, "Bye!")
+	})
+	t.Run("unknown signer", func(t *testing.T) {
+		out := run(t, "contract "+gasHash.StringLE(), "method symbol", "signers "+w.Accounts[0].Address, "fees")
+		require.Contains(t, out, "Error: no account was found for signer #0")
+	})
+
+	out := run(t,
+		"contract "+gasHash.StringLE(),
+		"method transfer "+testcli.ValidatorAddr+" "+w.Accounts[0].Address+" 100 any:null",
+		"signers "+testcli.ValidatorAddr,
+		"attr HighPriority",
+		"attr Unknown",
+		"show",
+		"fees",
+		"sign",
+		testcli.ValidatorPass,
+		"send",
+		"exit")
+	require.NotContains(t, out, "Error: contract")
+	require.Contains(t, out, "Error: unsupported attribute type: Unknown")
+	require.Contains(t, out, "Method:\ttransfer")
+	require.Contains(t, out, "Signer #0:\t"+testcli.ValidatorAddr)
+	require.Contains(t, out, "Attribute #0:\tHighPriority")
+	require.Contains(t, out, "SYSCALL")
+	require.Contains(t, out, "VMState:\tHALT")
+	require.Contains(t, out, "NetworkFee:")
+	require.Regexp(t, "Transaction [0-9a-f]{64} is signed and can be sent", out)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	var txHash util.Uint256
+	for _, l := range lines {
+		if h, err := util.Uint256DecodeStringLE(strings.TrimSpace(l)); err == nil {
+			txHash = h
+		}
+	}
+	require.NotEqual(t, util.Uint256{}, txHash)
+	tx, _ := e.GetTransaction(t, txHash)
+	require.True(t, tx.HasAttribute(transaction.HighPriority))
+	aer, err := e.Chain.GetAppExecResults(txHash, trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, vmstate.Halt, aer[0].VMState)
+}
+
+func TestUtilTxBuilderPartial(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	other, err := wallet.NewAccount()
+	require.NoError(t, err)
+
+	w, err := wallet.NewWalletFromFile(testcli.ValidatorWallet)
+	require.NoError(t, err)
+	walletPath := filepath.Join(t.TempDir(), "wallet.json")
+	w.SetPath(walletPath)
+	w.AddAccount(&wallet.Account{Address: other.Address, Contract: other.Contract}) // Watch-only.
+	require.NoError(t, w.Save())
+	w.Close()
+
+	ctxPath := filepath.Join(t.TempDir(), "ctx.json")
+	args := []string{"neo-go", "util", "txbuilder",
+		"-r", "http://" + e.RPC.Addresses()[0],
+		"--wallet", walletPath}
+	gasHash, err := e.Chain.GetNativeContractScriptHash(nativenames.Gas)
+	require.NoError(t, err)
+
+	e.CLI.Reader = strings.NewReader(strings.Join([]string{
+		"contract " + gasHash.StringLE(),
+		"method symbol",
+		"signers " + testcli.ValidatorAddr + " " + other.Address,
+		"sign " + ctxPath,
+		testcli.ValidatorPass,
+		"send",
+	}, "\n") + "\n")
+	e.Run(t, args...)
+	require.Contains(t, e.Out.String(), "is signed partially, missing signatures:\n\t"+other.Address)
+	require.Contains(t, e.Err.String(), "Error: failed to complete transaction")
+
+	e.CLI.Reader = strings.NewReader("load " + ctxPath + "\nshow\n")
+	e.Run(t, args...)
+	require.Contains(t, e.Out.String(), "missing signatures: 1")
+	require.Contains(t, e.Out.String(), "Signer #1:\t"+other.Address)
+}
+
+func TestUtilTxBuilderUnlockTTL(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	gasHash, err := e.Chain.GetNativeContractScriptHash(nativenames.Gas)
+	require.NoError(t, err)
+	args := []string{"neo-go", "util", "txbuilder",
+		"-r", "http://" + e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet}
+	// The second "sign" needs a password only if the account is relocked,
+	// "exit" is used as a password in this case.
+	commands := strings.Join([]string{
+		"contract " + gasHash.StringLE(),
+		"method symbol",
+		"signers " + testcli.ValidatorAddr,
+		"sign",
+		testcli.ValidatorPass,
+		"method decimals",
+		"sign",
+		"exit",
+	}, "\n") + "\n"
+
+	t.Run("default", func(t *testing.T) {
+		e.CLI.Reader = strings.NewReader(commands)
+		e.Run(t, args...)
+		require.Contains(t, e.Out.String(), "Bye!")
+		require.NotContains(t, e.Err.String(), "Error")
+	})
+	t.Run("expired", func(t *testing.T) {
+		e.CLI.Reader = strings.NewReader(commands)
+		e.Run(t, append(args, "--unlock-ttl", "1ns")...)
+		require.NotContains(t, e.Out.String(), "Bye!")
+		require.Contains(t, e.Err.String(), "Error: failed to decrypt account "+testcli.ValidatorAddr)
+	})
+}
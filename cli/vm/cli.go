@@ -26,6 +26,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/runtime"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
@@ -36,6 +37,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -43,6 +45,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
@@ -57,6 +60,8 @@ const (
 	exitFuncKey         = "exitFunc"
 	readlineInstanceKey = "readlineKey"
 	printLogoKey        = "printLogoKey"
+	debuggerKey         = "debugger"
+	debugInfoKey        = "debugInfo"
 )
 
 // Various flag names.
@@ -67,6 +72,8 @@ const (
 	backwardsFlagFullName = "backwards"
 	diffFlagFullName      = "diff"
 	hashFlagFullName      = "hash"
+	opcodeFlagFullName    = "opcode"
+	syscallFlagFullName   = "syscall"
 )
 
 var (
@@ -103,13 +110,59 @@ var commands = []*cli.Command{
 	{
 		Name:      "break",
 		Usage:     "Place a breakpoint",
-		UsageText: `break <ip>`,
-		Description: `<ip> is mandatory parameter.
+		UsageText: `break [--opcode <opcode> | --syscall <name>] [<ip>]`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  opcodeFlagFullName,
+				Usage: "Stop before any instruction with the specified opcode",
+			},
+			&cli.StringFlag{
+				Name:  syscallFlagFullName,
+				Usage: "Stop before any call of the specified syscall",
+			},
+		},
+		Description: `<ip> is mandatory parameter unless --opcode or --syscall flag is
+given. Breakpoints with an instruction pointer are bound to the current
+program, while opcode and syscall breakpoints are checked in any executed
+contract and can be listed and removed with 'breakpoints' and 'delete'
+commands.
 
 Example:
-> break 12`,
+> break 12
+> break --opcode ASSERT
+> break --syscall System.Storage.Put`,
 		Action: handleBreak,
 	},
+	{
+		Name:        "breakpoints",
+		Usage:       "Show breakpoints and watchpoints",
+		UsageText:   "breakpoints",
+		Description: "Show breakpoints of the current program as well as opcode, syscall breakpoints and storage watchpoints with their IDs.",
+		Action:      handleBreakpoints,
+	},
+	{
+		Name:      "delete",
+		Usage:     "Delete opcode, syscall breakpoint or storage watchpoint",
+		UsageText: `delete <id>`,
+		Description: `<id> is mandatory parameter, it's an ID of the breakpoint or watchpoint
+shown by 'breakpoints' command.
+
+Example:
+> delete 1`,
+		Action: handleDelete,
+	},
+	{
+		Name:      "watch",
+		Usage:     "Place a storage watchpoint",
+		UsageText: `watch <hash-or-address-or-id> [<prefix>]`,
+		Description: `Stop execution after any change of the contract storage items with
+the specified prefix (all items if not given). Hash, address or ID of the
+contract is mandatory parameter, prefix is a hex-encoded string.
+
+Example:
+> watch 0x56ce2a8ec9a74232c4e1ebb9cbc6ba41f6d9d4d5 0a`,
+		Action: handleWatch,
+	},
 	{
 		Name:      "jump",
 		Usage:     "Jump to the specified instruction (absolute IP value)",
@@ -162,7 +215,8 @@ Example:
 		Flags:     []cli.Flag{historicFlag, gasFlag, hashFlag},
 		Description: `<file> parameter is mandatory, <manifest> parameter (if omitted) will
    be guessed from the <file> parameter by replacing '.nef' suffix with '.manifest.json'
-   suffix.
+   suffix. If there is a file with '.debug.json' suffix next to the NEF, it's used
+   as the contract debug information to resolve FAULT locations in the source code.
 
 ` + cmdargs.SignersParsingDoc + `
 
@@ -320,6 +374,30 @@ Example:
 > stepover`,
 		Action: handleStepOver,
 	},
+	{
+		Name:      "stepback",
+		Usage:     "Revert (n) executed instructions",
+		UsageText: `stepback [<n>]`,
+		Description: `Restore the state the program had before the last <n> (1 by default)
+instructions were executed. The state is restored by replaying the recorded
+execution history from the program start, so breakpoints are not triggered
+and all the storage changes made by reverted instructions are discarded.
+
+Example:
+> stepback 3`,
+		Action: handleStepBack,
+	},
+	{
+		Name:      "trace",
+		Usage:     "Show (n) last executed instructions",
+		UsageText: `trace [<n>]`,
+		Description: fmt.Sprintf(`Show <n> (10 by default) last executed instructions along with the hash of
+the executed script, up to %d instructions are kept in the trace.
+
+Example:
+> trace 20`, maxTraceLength),
+		Action: handleTrace,
+	},
 	{
 		Name:        "ops",
 		Usage:       "Dump opcodes of the current loaded program",
@@ -327,6 +405,19 @@ Example:
 		Description: "Dump opcodes of the current loaded program",
 		Action:      handleOps,
 	},
+	{
+		Name:      "estimate",
+		Usage:     "Estimate execution fee of the current loaded program",
+		UsageText: "estimate",
+		Description: `Print static execution fee estimation of the current loaded program
+calculated as the sum of opcode and syscall base prices (every instruction is
+counted once) using the current execution fee factor. Instructions that can
+add an unknown amount of GAS to the real execution fee (contract calls, loops,
+storage writes, etc.) are listed after it. If the program was loaded with
+debug information (via loadgo or loadnef with debug file), the same estimation
+is also printed for every contract method.`,
+		Action: handleEstimate,
+	},
 	{
 		Name:        "events",
 		Usage:       "Dump events emitted by the current loaded program",
@@ -504,6 +595,9 @@ func NewWithConfig(printLogotype bool, onExit func(int), c *readline.Config, cfg
 		readlineInstanceKey: l,
 		printLogoKey:        printLogotype,
 	}
+	dbg := newDebugger(vmcli.shell)
+	dbg.attach(ic.VM)
+	vmcli.shell.Metadata[debuggerKey] = dbg
 	changePrompt(vmcli.shell)
 	return &vmcli, nil
 }
@@ -548,6 +642,36 @@ func setContractStateInContext(app *cli.App, cs *state.ContractBase) {
 	app.Metadata[contractStateKey] = cs
 }
 
+func getDebugInfoFromContext(app *cli.App) *compiler.DebugInfo {
+	di, _ := app.Metadata[debugInfoKey].(*compiler.DebugInfo)
+	return di
+}
+
+func setDebugInfoInContext(app *cli.App, di *compiler.DebugInfo) {
+	app.Metadata[debugInfoKey] = di
+}
+
+// describeFault extends the given VM execution error with the source location
+// of the failing instruction using the debug information of the loaded program
+// or the one configured for the chain.
+func describeFault(app *cli.App, err error) error {
+	v := getVMFromContext(app)
+	if !v.HasFailed() {
+		return err
+	}
+	msg := err.Error()
+	if di := getDebugInfoFromContext(app); di != nil {
+		msg = compiler.DescribeFault(v, err, map[util.Uint160]*compiler.DebugInfo{di.Hash: di})
+	}
+	if msg == err.Error() {
+		msg = getChainFromContext(app).DescribeFault(v, err)
+	}
+	if msg == err.Error() {
+		return err
+	}
+	return errors.New(msg)
+}
+
 func checkVMIsReady(app *cli.App) bool {
 	v := getVMFromContext(app)
 	if v == nil || !v.Ready() {
@@ -583,6 +707,34 @@ func handleIP(c *cli.Context) error {
 }
 
 func handleBreak(c *cli.Context) error {
+	var (
+		cond *condition
+		err  error
+	)
+	switch {
+	case c.IsSet(opcodeFlagFullName) && c.IsSet(syscallFlagFullName):
+		return fmt.Errorf("%w: --%s and --%s flags can't be used together", ErrInvalidParameter, opcodeFlagFullName, syscallFlagFullName)
+	case c.IsSet(opcodeFlagFullName):
+		op, err := opcode.FromString(c.String(opcodeFlagFullName))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidParameter, err)
+		}
+		cond = &condition{op: op}
+	case c.IsSet(syscallFlagFullName):
+		cond, err = newSyscallCondition(c.String(syscallFlagFullName))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidParameter, err)
+		}
+	}
+	if cond != nil {
+		if c.Args().Present() {
+			return fmt.Errorf("%w: <ip> can't be used with --%s or --%s flags", ErrInvalidParameter, opcodeFlagFullName, syscallFlagFullName)
+		}
+		id := getDebuggerFromContext(c.App).addCondition(cond)
+		fmt.Fprintf(c.App.Writer, "breakpoint %d added: %s\n", id, cond)
+		return nil
+	}
+
 	if !checkVMIsReady(c.App) {
 		return nil
 	}
@@ -591,12 +743,54 @@ func handleBreak(c *cli.Context) error {
 		return err
 	}
 
-	v := getVMFromContext(c.App)
-	v.AddBreakPoint(n)
+	_ = getDebuggerFromContext(c.App).record(func() error {
+		getVMFromContext(c.App).AddBreakPoint(n)
+		return nil
+	})
 	fmt.Fprintf(c.App.Writer, "breakpoint added at instruction %d\n", n)
 	return nil
 }
 
+func handleBreakpoints(c *cli.Context) error {
+	v := getVMFromContext(c.App)
+	if v.Ready() {
+		for _, n := range v.Context().BreakPoints() {
+			fmt.Fprintf(c.App.Writer, "instruction %d\n", n)
+		}
+	}
+	for _, cond := range getDebuggerFromContext(c.App).conditions {
+		fmt.Fprintf(c.App.Writer, "%d: %s\n", cond.id, cond)
+	}
+	return nil
+}
+
+func handleDelete(c *cli.Context) error {
+	args := c.Args().Slice()
+	if len(args) != 1 {
+		return fmt.Errorf("%w: <id>", ErrMissingParameter)
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidParameter, err)
+	}
+	if !getDebuggerFromContext(c.App).removeCondition(id) {
+		return fmt.Errorf("%w: breakpoint %d not found", ErrInvalidParameter, id)
+	}
+	fmt.Fprintf(c.App.Writer, "breakpoint %d deleted\n", id)
+	return nil
+}
+
+func handleWatch(c *cli.Context) error {
+	id, prefix, err := getDumpArgs(c)
+	if err != nil {
+		return err
+	}
+	cond := &condition{watch: &watchpoint{contractID: id, prefix: prefix}}
+	n := getDebuggerFromContext(c.App).addCondition(cond)
+	fmt.Fprintf(c.App.Writer, "watchpoint %d added: %s\n", n, cond)
+	return nil
+}
+
 func handleJump(c *cli.Context) error {
 	if !checkVMIsReady(c.App) {
 		return nil
@@ -606,8 +800,10 @@ func handleJump(c *cli.Context) error {
 		return err
 	}
 
-	v := getVMFromContext(c.App)
-	v.Context().Jump(n)
+	_ = getDebuggerFromContext(c.App).record(func() error {
+		getVMFromContext(c.App).Context().Jump(n)
+		return nil
+	})
 	fmt.Fprintf(c.App.Writer, "jumped to instruction %d\n", n)
 	return nil
 }
@@ -681,6 +877,14 @@ func prepareVM(c *cli.Context, tx *transaction.Transaction) error {
 	return nil
 }
 
+// startHistory starts a new execution history of the program loaded by the
+// command, so that it can be replayed for reverse stepping.
+func startHistory(c *cli.Context) {
+	getDebuggerFromContext(c.App).reset(func() error {
+		return c.Command.Action(c)
+	})
+}
+
 func getHashFlag(c *cli.Context) (util.Uint160, error) {
 	if !c.IsSet(hashFlagFullName) {
 		return util.Uint160{}, nil
@@ -720,6 +924,7 @@ func handleLoadNEF(c *cli.Context) error {
 	if len(manifestFile) == 0 {
 		manifestFile = strings.TrimSuffix(nefFile, ".nef") + ".manifest.json"
 	}
+	debugFile := strings.TrimSuffix(nefFile, ".nef") + ".debug.json"
 	b, err := os.ReadFile(nefFile)
 	if err != nil {
 		return err
@@ -753,9 +958,13 @@ func handleLoadNEF(c *cli.Context) error {
 		Manifest: *m,
 	}
 	setContractStateInContext(c.App, cs)
+	if di, err := compiler.ReadDebugInfo(debugFile); err == nil {
+		setDebugInfoInContext(c.App, di)
+	}
 
 	v := getVMFromContext(c.App)
 	fmt.Fprintf(c.App.Writer, "READY: loaded %d instructions\n", v.Context().LenInstr())
+	startHistory(c)
 	changePrompt(c.App)
 	return nil
 }
@@ -788,6 +997,7 @@ func handleLoadBase64(c *cli.Context) error {
 	}
 	v := getVMFromContext(c.App)
 	fmt.Fprintf(c.App.Writer, "READY: loaded %d instructions\n", v.Context().LenInstr())
+	startHistory(c)
 	changePrompt(c.App)
 	return nil
 }
@@ -828,6 +1038,7 @@ func handleLoadHex(c *cli.Context) error {
 	}
 	v := getVMFromContext(c.App)
 	fmt.Fprintf(c.App.Writer, "READY: loaded %d instructions\n", v.Context().LenInstr())
+	startHistory(c)
 	changePrompt(c.App)
 	return nil
 }
@@ -877,9 +1088,11 @@ func handleLoadGo(c *cli.Context) error {
 		Manifest: *m,
 	}
 	setContractStateInContext(c.App, cs)
+	setDebugInfoInContext(c.App, di)
 
 	v := getVMFromContext(c.App)
 	fmt.Fprintf(c.App.Writer, "READY: loaded %d instructions\n", v.Context().LenInstr())
+	startHistory(c)
 	changePrompt(c.App)
 	return nil
 }
@@ -921,6 +1134,7 @@ func handleLoadTx(c *cli.Context) error {
 		v.GasLimit = tx.SystemFee
 	}
 	fmt.Fprintf(c.App.Writer, "READY: loaded %d instructions\n", v.Context().LenInstr())
+	startHistory(c)
 	changePrompt(c.App)
 	return nil
 }
@@ -976,6 +1190,7 @@ func handleLoadDeployed(c *cli.Context) error {
 	ic.VM.LoadScriptWithHash(cs.NEF.Script, cs.Hash, callflag.All)
 	fmt.Fprintf(c.App.Writer, "READY: loaded %d instructions\n", ic.VM.Context().LenInstr())
 	setContractStateInContext(c.App, &cs.ContractBase)
+	startHistory(c)
 	changePrompt(c.App)
 	return nil
 }
@@ -985,6 +1200,7 @@ func handleReset(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	startHistory(c)
 	changePrompt(c.App)
 	return nil
 }
@@ -1022,6 +1238,7 @@ func resetInteropContext(app *cli.App, tx *transaction.Transaction, height ...ui
 			return fmt.Errorf("failed to create VM: %w", err)
 		}
 	}
+	getDebuggerFromContext(app).attach(newIc.VM)
 	if tx != nil {
 		newIc.VM.LoadWithFlags(tx.Script, callflag.All)
 	}
@@ -1033,6 +1250,7 @@ func resetInteropContext(app *cli.App, tx *transaction.Transaction, height ...ui
 // resetContractState removes loaded contract state from app context.
 func resetContractState(app *cli.App) {
 	setContractStateInContext(app, nil)
+	setDebugInfoInContext(app, nil)
 }
 
 // resetState resets state of the app (clear interop context and manifest) so that it's ready
@@ -1060,14 +1278,12 @@ func getManifestFromFile(name string) (*manifest.Manifest, error) {
 }
 
 func handleRun(c *cli.Context) error {
-	v := getVMFromContext(c.App)
 	cs := getContractStateFromContext(c.App)
 	args := c.Args().Slice()
 	if len(args) != 0 {
 		var (
-			params     []stackitem.Item
 			offset     int
-			err        error
+			initOff    = -1
 			runCurrent = args[0] != "_"
 			hasRet     bool
 		)
@@ -1076,42 +1292,55 @@ func handleRun(c *cli.Context) error {
 		if err != nil {
 			return fmt.Errorf("%w: %w", ErrInvalidParameter, err)
 		}
-		params = make([]stackitem.Item, len(scParams))
-		for i := range scParams {
-			params[i], err = scParams[i].ToStackItem()
-			if err != nil {
-				return fmt.Errorf("failed to convert parameter #%d to stackitem: %w", i, err)
-			}
-		}
 		if runCurrent {
 			if cs == nil {
 				return fmt.Errorf("manifest is not loaded; either use 'run' command to run loaded script from the start or use 'loadgo', 'loadnef' or 'loaddeployed' commands to provide manifest")
 			}
-			md := cs.Manifest.ABI.GetMethod(args[0], len(params))
+			md := cs.Manifest.ABI.GetMethod(args[0], len(scParams))
 			if md == nil {
 				return fmt.Errorf("%w: method not found", ErrInvalidParameter)
 			}
 			hasRet = md.ReturnType != smartcontract.VoidType
 			offset = md.Offset
-			var initOff = -1
 			if initMD := cs.Manifest.ABI.GetMethod(manifest.MethodInit, 0); initMD != nil {
 				initOff = initMD.Offset
 			}
-
-			// Clear context loaded by 'loadgo', 'loadnef' or 'loaddeployed' to properly handle LoadNEFMethod.
-			// At the same time, preserve previously set gas limit and the set of breakpoints.
-			ic := getInteropContextFromContext(c.App)
-			gasLimit := v.GasLimit
-			breaks := v.Context().BreakPoints() // We ensure that there's a context loaded.
-			ic.ReuseVM(v)
-			v.GasLimit = gasLimit
-			v.LoadNEFMethod(&cs.NEF, &cs.Manifest, util.Uint160{}, cs.Hash, callflag.All, hasRet, offset, initOff, nil)
-			for _, bp := range breaks {
-				v.AddBreakPoint(bp)
-			}
 		}
-		for i := len(params) - 1; i >= 0; i-- {
-			v.Estack().PushVal(params[i])
+		// Parameters are converted every time the method is loaded, because
+		// stack items can be modified by the program and the loading can be
+		// replayed by 'stepback'.
+		err = getDebuggerFromContext(c.App).record(func() error {
+			var (
+				params = make([]stackitem.Item, len(scParams))
+				err    error
+			)
+			for i := range scParams {
+				params[i], err = scParams[i].ToStackItem()
+				if err != nil {
+					return fmt.Errorf("failed to convert parameter #%d to stackitem: %w", i, err)
+				}
+			}
+			v := getVMFromContext(c.App)
+			if runCurrent {
+				// Clear context loaded by 'loadgo', 'loadnef' or 'loaddeployed' to properly handle LoadNEFMethod.
+				// At the same time, preserve previously set gas limit and the set of breakpoints.
+				ic := getInteropContextFromContext(c.App)
+				gasLimit := v.GasLimit
+				breaks := v.Context().BreakPoints() // We ensure that there's a context loaded.
+				ic.ReuseVM(v)
+				v.GasLimit = gasLimit
+				v.LoadNEFMethod(&cs.NEF, &cs.Manifest, util.Uint160{}, cs.Hash, callflag.All, hasRet, offset, initOff, nil)
+				for _, bp := range breaks {
+					v.AddBreakPoint(bp)
+				}
+			}
+			for i := len(params) - 1; i >= 0; i-- {
+				v.Estack().PushVal(params[i])
+			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 	}
 	runVMWithHandling(c)
@@ -1124,7 +1353,7 @@ func runVMWithHandling(c *cli.Context) {
 	v := getVMFromContext(c.App)
 	err := v.Run()
 	if err != nil {
-		writeErr(c.App.ErrWriter, err)
+		writeErr(c.App.ErrWriter, describeFault(c.App, err))
 	}
 
 	var (
@@ -1140,11 +1369,14 @@ func runVMWithHandling(c *cli.Context) {
 		dumpNtf = true
 	case v.AtBreakpoint():
 		ctx := v.Context()
+		if hits := getDebuggerFromContext(c.App).popHits(); hits != "" {
+			message = hits + "\n"
+		}
 		if ctx.NextIP() < ctx.LenInstr() {
 			i, op := ctx.NextInstr()
-			message = fmt.Sprintf("at breakpoint %d (%s)", i, op)
+			message += fmt.Sprintf("at breakpoint %d (%s)", i, op)
 		} else {
-			message = "execution has finished"
+			message += "execution has finished"
 		}
 	}
 	if dumpNtf {
@@ -1180,7 +1412,6 @@ func handleStep(c *cli.Context) error {
 	if !checkVMIsReady(c.App) {
 		return nil
 	}
-	v := getVMFromContext(c.App)
 	args := c.Args().Slice()
 	if len(args) > 0 {
 		n, err = strconv.Atoi(args[0])
@@ -1188,7 +1419,10 @@ func handleStep(c *cli.Context) error {
 			return fmt.Errorf("%w: %w", ErrInvalidParameter, err)
 		}
 	}
-	v.AddBreakPointRel(n)
+	_ = getDebuggerFromContext(c.App).record(func() error {
+		getVMFromContext(c.App).AddBreakPointRel(n)
+		return nil
+	})
 	runVMWithHandling(c)
 	changePrompt(c.App)
 	return nil
@@ -1220,6 +1454,34 @@ func handleStepType(c *cli.Context, stepType string) error {
 	case "over":
 		err = v.StepOver()
 	}
+	if err != nil {
+		return describeFault(c.App, err)
+	}
+	if hits := getDebuggerFromContext(c.App).popHits(); hits != "" {
+		fmt.Fprintln(c.App.Writer, hits)
+	}
+	_ = handleIP(c)
+	changePrompt(c.App)
+	return nil
+}
+
+func handleStepBack(c *cli.Context) error {
+	var (
+		n   = 1
+		err error
+	)
+
+	args := c.Args().Slice()
+	if len(args) > 0 {
+		n, err = strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidParameter, err)
+		}
+		if n < 1 {
+			return fmt.Errorf("%w: positive number of instructions expected", ErrInvalidParameter)
+		}
+	}
+	err = getDebuggerFromContext(c.App).stepBack(n)
 	if err != nil {
 		return err
 	}
@@ -1228,6 +1490,27 @@ func handleStepType(c *cli.Context, stepType string) error {
 	return nil
 }
 
+func handleTrace(c *cli.Context) error {
+	var (
+		n   = 10
+		err error
+	)
+	args := c.Args().Slice()
+	if len(args) > 0 {
+		n, err = strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidParameter, err)
+		}
+	}
+	trace := getDebuggerFromContext(c.App).trace
+	trace = trace[len(trace)-min(max(n, 0), len(trace)):]
+	w := tabwriter.NewWriter(c.App.Writer, 0, 0, 4, ' ', 0)
+	for _, e := range trace {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", e.scriptHash.StringLE(), e.ip, e.op)
+	}
+	return w.Flush()
+}
+
 func handleOps(c *cli.Context) error {
 	if !checkVMIsReady(c.App) {
 		return nil
@@ -1239,6 +1522,39 @@ func handleOps(c *cli.Context) error {
 	return nil
 }
 
+func handleEstimate(c *cli.Context) error {
+	if !checkVMIsReady(c.App) {
+		return nil
+	}
+	ic := getInteropContextFromContext(c.App)
+	est, err := fee.Estimate(ic.BaseExecFee(), ic.VM.Context().Program(), core.SyscallPrice)
+	if err != nil {
+		writeErr(c.App.ErrWriter, err)
+		return nil
+	}
+	w := tabwriter.NewWriter(c.App.Writer, 0, 0, 4, ' ', 0)
+	fmt.Fprintf(w, "Static execution fee:\t%s GAS\n", fixedn.Fixed8(est.Fee))
+	if len(est.Dynamic) != 0 {
+		fmt.Fprintln(w, "INDEX\tOPCODE\tDYNAMIC PART")
+		for _, d := range est.Dynamic {
+			fmt.Fprintf(w, "%d\t%s\t%s\n", d.Offset, d.Op, d.Reason)
+		}
+	}
+	cs := getContractStateFromContext(c.App)
+	if di := getDebugInfoFromContext(c.App); di != nil && cs != nil {
+		ests, err := compiler.EstimateMethodFees(cs.NEF.Script, di, ic.BaseExecFee(), core.SyscallPrice)
+		if err != nil {
+			writeErr(c.App.ErrWriter, err)
+			return nil
+		}
+		fmt.Fprintln(w, "METHOD\tSTATIC FEE\t")
+		for i, m := range di.Methods {
+			fmt.Fprintf(w, "%s\t%s GAS\t\n", m.ID, fixedn.Fixed8(ests[i].Fee))
+		}
+	}
+	return w.Flush()
+}
+
 func changePrompt(app *cli.App) {
 	v := getVMFromContext(app)
 	l := getReadlineInstanceFromContext(app)
@@ -796,6 +796,50 @@ func TestPrintOps(t *testing.T) {
 	e.checkNextLine(t, "10.*PUSHDATA1.*010203")
 }
 
+func TestEstimate(t *testing.T) {
+	w := io.NewBufBinWriter()
+	emit.String(w.BinWriter, "log")
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeLog)
+	emit.Syscall(w.BinWriter, interopnames.SystemStoragePut)
+	emit.Instruction(w.BinWriter, opcode.CALLT, []byte{0, 0})
+	script := w.Bytes()
+	e := newTestVMCLI(t)
+	e.runProg(t,
+		"estimate",
+		"loadhex "+hex.EncodeToString(script),
+		"estimate")
+
+	e.checkNextLine(t, ".*no program loaded")
+	e.checkNextLine(t, fmt.Sprintf("READY: loaded %d instructions", len(script)))
+	e.checkNextLine(t, "Static execution fee:.*0\\.0294936 GAS")
+	e.checkNextLine(t, "INDEX.*OPCODE.*DYNAMIC PART")
+	e.checkNextLine(t, "10.*SYSCALL.*storage fee depends on the data size")
+	e.checkNextLine(t, "15.*CALLT.*method token call")
+
+	src := `package kek
+	import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	func Main(a int) int {
+		runtime.Log("main")
+		return a + helper()
+	}
+	func helper() int {
+		return 42
+	}`
+	filename := prepareLoadgoSrc(t, t.TempDir(), src)
+	e = newTestVMCLI(t)
+	e.runProgWithTimeout(t, 10*time.Second,
+		"loadgo "+filename,
+		"estimate")
+
+	e.checkNextLine(t, "READY: loaded \\d* instructions")
+	e.checkNextLine(t, "Static execution fee:.* GAS")
+	e.checkNextLine(t, "INDEX.*OPCODE.*DYNAMIC PART")
+	e.checkNextLine(t, "\\d+.*CALL.*method call")
+	e.checkNextLine(t, "METHOD.*STATIC FEE")
+	e.checkNextLine(t, "Main.*0\\.0[0-9]+ GAS")
+	e.checkNextLine(t, "helper.*0\\.00[0-9]+ GAS")
+}
+
 func TestLoadAbort(t *testing.T) {
 	e := newTestVMCLI(t)
 	e.runProg(t,
@@ -807,6 +851,64 @@ func TestLoadAbort(t *testing.T) {
 	e.checkNextLine(t, "Error:.*at instruction 1.*ABORT")
 }
 
+func TestFaultSourceLocation(t *testing.T) {
+	src := `package kek
+	func Main(a int) int {
+		if a > 0 {
+			return a
+		}
+		panic("negative")
+	}`
+
+	t.Run("loadgo", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		filename := prepareLoadgoSrc(t, tmpDir, src)
+
+		e := newTestVMCLI(t)
+		e.runProgWithTimeout(t, 10*time.Second,
+			"loadgo "+filename,
+			"run main -1",
+		)
+
+		e.checkNextLine(t, "READY: loaded \\d* instructions")
+		e.checkNextLine(t, `Error:.*unhandled exception: "negative" \(at .*vmtestcontract\.go:6 in kek\.Main\)`)
+	})
+	t.Run("loadnef", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		nefFile, di, err := compiler.CompileWithOptions("test.go", strings.NewReader(src), nil)
+		require.NoError(t, err)
+		filename := filepath.Join(tmpDir, "vmtestcontract.nef")
+		rawNef, err := nefFile.Bytes()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filename, rawNef, os.ModePerm))
+		m, err := di.ConvertToManifest(&compiler.Options{})
+		require.NoError(t, err)
+		rawManifest, err := json.Marshal(m)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "vmtestcontract.manifest.json"), rawManifest, os.ModePerm))
+
+		e := newTestVMCLI(t)
+		e.runProgWithTimeout(t, 10*time.Second,
+			"loadnef '"+filename+"'",
+			"run main -1",
+		)
+		e.checkNextLine(t, "READY: loaded \\d* instructions")
+		e.checkNextLine(t, `Error:.*unhandled exception: "negative"\s*$`)
+
+		rawDebug, err := json.Marshal(di)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "vmtestcontract.debug.json"), rawDebug, os.ModePerm))
+
+		e = newTestVMCLI(t)
+		e.runProgWithTimeout(t, 10*time.Second,
+			"loadnef '"+filename+"'",
+			"run main -1",
+		)
+		e.checkNextLine(t, "READY: loaded \\d* instructions")
+		e.checkNextLine(t, `Error:.*unhandled exception: "negative" \(at .*test\.go:6 in kek\.Main\)`)
+	})
+}
+
 func TestBreakpoint(t *testing.T) {
 	w := io.NewBufBinWriter()
 	emit.Opcodes(w.BinWriter, opcode.PUSH1, opcode.PUSH2, opcode.ADD, opcode.PUSH6, opcode.ADD)
@@ -843,6 +945,52 @@ func TestBreakpoint(t *testing.T) {
 	e.checkStack(t, 9)
 }
 
+func TestConditionalBreakpoint(t *testing.T) {
+	w := io.NewBufBinWriter()
+	emit.Opcodes(w.BinWriter, opcode.PUSH1, opcode.PUSH2, opcode.ADD, opcode.PUSH6, opcode.ADD)
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeGetTrigger)
+	emit.Opcodes(w.BinWriter, opcode.DROP)
+	e := newTestVMCLI(t)
+	e.runProg(t,
+		"break --opcode ADD",
+		"break --opcode UNKNOWN",
+		"break --syscall System.Unknown",
+		"break --opcode ADD --syscall System.Runtime.GetTrigger",
+		"break --syscall System.Runtime.GetTrigger",
+		"loadhex "+hex.EncodeToString(w.Bytes()),
+		"break --opcode DROP 1",
+		"break 3",
+		"breakpoints",
+		"run",
+		"cont",
+		"delete 1",
+		"delete 1",
+		"cont",
+		"cont",
+	)
+
+	e.checkNextLine(t, "breakpoint 1 added: opcode ADD")
+	e.checkError(t, ErrInvalidParameter)
+	e.checkError(t, ErrInvalidParameter)
+	e.checkError(t, ErrInvalidParameter)
+	e.checkNextLine(t, "breakpoint 2 added: syscall System.Runtime.GetTrigger")
+	e.checkNextLine(t, "READY: loaded 11 instructions")
+	e.checkError(t, ErrInvalidParameter)
+	e.checkNextLine(t, "breakpoint added at instruction 3")
+	e.checkNextLineExact(t, "instruction 3\n")
+	e.checkNextLineExact(t, "1: opcode ADD\n")
+	e.checkNextLineExact(t, "2: syscall System.Runtime.GetTrigger\n")
+
+	e.checkNextLineExact(t, "breakpoint 1 hit: opcode ADD\n")
+	e.checkNextLine(t, "at breakpoint 2.*ADD")
+	e.checkNextLine(t, "at breakpoint 3.*PUSH6")
+	e.checkNextLine(t, "breakpoint 1 deleted")
+	e.checkError(t, ErrInvalidParameter)
+	e.checkNextLineExact(t, "breakpoint 2 hit: syscall System.Runtime.GetTrigger\n")
+	e.checkNextLine(t, "at breakpoint 5.*SYSCALL")
+	e.checkStack(t, 9)
+}
+
 func TestDumpSSlot(t *testing.T) {
 	w := io.NewBufBinWriter()
 	emit.Opcodes(w.BinWriter, opcode.INITSSLOT, 2, // init static slot with size=2
@@ -962,6 +1110,51 @@ func TestStepIntoOverOut(t *testing.T) {
 	e.checkStack(t, 5)
 }
 
+func TestStepBack(t *testing.T) {
+	script := hex.EncodeToString([]byte{
+		byte(opcode.PUSH0), byte(opcode.PUSH1), byte(opcode.PUSH2), byte(opcode.PUSH3),
+	})
+	e := newTestVMCLI(t)
+	e.runProg(t,
+		"stepback",
+		"loadhex "+script,
+		"stepback",
+		"stepback invalid",
+		"break 2",
+		"run", "stepback", "estack",
+		"cont", "estack",
+		"run", "stepback 3", "estack", // including the final RET.
+		"trace",
+		"jump 3",
+		"run", "stepback 2", "estack",
+	)
+
+	e.checkNextLine(t, "no program loaded")
+	e.checkNextLine(t, "READY: loaded 4 instructions")
+	e.checkError(t, errors.New("can't step back 1 instructions, only 0 were executed"))
+	e.checkError(t, ErrInvalidParameter)
+	e.checkNextLine(t, "breakpoint added at instruction 2")
+
+	e.checkNextLine(t, "at breakpoint 2.*PUSH2")
+	e.checkNextLine(t, "instruction pointer at 1.*PUSH1")
+	e.checkStack(t, 0)
+
+	e.checkNextLine(t, "at breakpoint 2.*PUSH2") // the breakpoint is restored.
+	e.checkStack(t, 0, 1)
+
+	e.checkStack(t, 0, 1, 2, 3)
+	e.checkNextLine(t, "instruction pointer at 2.*PUSH2")
+	e.checkStack(t, 0, 1)
+
+	e.checkNextLine(t, `^[0-9a-f]{40}\s+0\s+PUSH0\s*$`)
+	e.checkNextLine(t, `^[0-9a-f]{40}\s+1\s+PUSH1\s*$`)
+
+	e.checkNextLine(t, "jumped to instruction 3")
+	e.checkStack(t, 0, 1, 3)
+	e.checkNextLine(t, "instruction pointer at 3.*PUSH3") // jump is restored.
+	e.checkStack(t, 0, 1)
+}
+
 // `Parse` output is written via `tabwriter` so if any problems
 // are encountered in this test, try to replace ' ' with '\\s+'.
 func TestParse(t *testing.T) {
@@ -1284,6 +1477,38 @@ func TestDumpChanges(t *testing.T) {
 	e.checkChange(t, expected[2])
 }
 
+func TestWatch(t *testing.T) {
+	e := newTestVMClIWithState(t)
+
+	script := io.NewBufBinWriter()
+	h, err := e.cli.chain.GetContractScriptHash(1) // examples/storage/storage.go
+	require.NoError(t, err)
+	emit.AppCall(script.BinWriter, h, "put", callflag.All, 3, 3)
+	emit.AppCall(script.BinWriter, h, "put", callflag.All, 1, 3)
+
+	e.runProg(t,
+		"watch",
+		"watch 1 "+hex.EncodeToString([]byte{3}),
+		"loadhex "+hex.EncodeToString(script.Bytes()),
+		"run",
+		"stepback",
+		"storage --diff 1",
+		"cont",
+		"cont",
+		"exit",
+	)
+
+	e.checkNextLine(t, "Error: contract hash, address or ID is mandatory argument")
+	e.checkNextLine(t, "watchpoint 1 added: storage of contract 1 with prefix 03")
+	e.checkNextLine(t, "READY: loaded 74 instructions")
+	e.checkNextLineExact(t, "watchpoint 1 hit: key 03 changed from <none> to 03\n")
+	e.checkNextLine(t, "at breakpoint")
+	e.checkNextLine(t, "instruction pointer at.*SYSCALL") // no storage changes after that.
+	e.checkNextLineExact(t, "watchpoint 1 hit: key 03 changed from <none> to 03\n")
+	e.checkNextLine(t, "at breakpoint")
+	e.checkStack(t, 3, 1)
+}
+
 func TestLoadtx(t *testing.T) {
 	e := newTestVMClIWithState(t)
 
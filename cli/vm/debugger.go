@@ -0,0 +1,319 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/urfave/cli/v2"
+)
+
+// maxTraceLength is the maximum number of executed instructions kept in the
+// execution trace.
+const maxTraceLength = 1024
+
+// condition is a conditional breakpoint or a storage watchpoint.
+type condition struct {
+	id int
+	// op is the opcode of the instruction to stop at, it's only used for
+	// opcode and syscall breakpoints.
+	op opcode.Opcode
+	// syscall is the name of the syscall to stop at, syscallID is its ID.
+	syscall   string
+	syscallID uint32
+	// watch is non-nil for storage watchpoints.
+	watch *watchpoint
+}
+
+// watchpoint contains the contract storage items watched for changes.
+type watchpoint struct {
+	contractID int32
+	prefix     []byte
+	items      map[string][]byte
+}
+
+// traceEntry is a single executed instruction.
+type traceEntry struct {
+	scriptHash util.Uint160
+	ip         int
+	op         opcode.Opcode
+}
+
+// action is a VM state change made between executions (like program loading,
+// breakpoint addition or jump). Actions are recorded along with the number of
+// instructions executed before them to be able to restore VM state for reverse
+// stepping.
+type action struct {
+	at    int
+	apply func() error
+}
+
+// debugger contains conditional breakpoints, storage watchpoints and execution
+// history of the currently loaded program.
+type debugger struct {
+	app        *cli.App
+	conditions []*condition
+	lastID     int
+	// hits contains the descriptions of conditions triggered by the last
+	// instruction.
+	hits []string
+
+	steps   int
+	trace   []traceEntry
+	history []action
+	// replayTo is the number of instructions to execute while restoring
+	// VM state, it's negative when the state is not being restored.
+	replayTo int
+}
+
+func newDebugger(app *cli.App) *debugger {
+	return &debugger{
+		app:      app,
+		replayTo: -1,
+	}
+}
+
+// attach sets debugger hooks to the given VM.
+func (d *debugger) attach(v *vm.VM) {
+	v.SetOnExecHook(d.onExec)
+	v.SetBreakCondition(d.check)
+}
+
+// reset starts a new history with the given program loading action.
+func (d *debugger) reset(load func() error) {
+	if d.replaying() {
+		return
+	}
+	d.steps = 0
+	d.trace = d.trace[:0]
+	d.history = []action{{apply: load}}
+	d.refreshWatches()
+}
+
+// record applies the given VM state change and stores it in the history.
+func (d *debugger) record(apply func() error) error {
+	err := apply()
+	if err == nil && !d.replaying() && len(d.history) != 0 {
+		d.history = append(d.history, action{at: d.steps, apply: apply})
+	}
+	return err
+}
+
+func (d *debugger) replaying() bool {
+	return d.replayTo >= 0
+}
+
+func (d *debugger) onExec(scriptHash util.Uint160, offset int, op opcode.Opcode) {
+	d.steps++
+	if len(d.trace) == maxTraceLength {
+		d.trace = slices.Delete(d.trace, 0, 1)
+	}
+	d.trace = append(d.trace, traceEntry{scriptHash: scriptHash, ip: offset, op: op})
+}
+
+// check implements vm.BreakCondition, it's called after every executed
+// instruction with the context holding the next one.
+func (d *debugger) check(ctx *vm.Context) bool {
+	if d.replaying() {
+		return d.steps >= d.replayTo
+	}
+	if ctx.IP() < ctx.LenInstr() {
+		if _, op := ctx.CurrInstr(); op == opcode.SYSCALL {
+			d.checkWatches()
+		}
+	}
+	if ctx.NextIP() < ctx.LenInstr() {
+		ip, op := ctx.NextInstr()
+		for _, c := range d.conditions {
+			if c.watch != nil || c.op != op {
+				continue
+			}
+			if c.syscall != "" {
+				prog := ctx.Program()
+				if ip+5 > len(prog) || vm.GetInteropID(prog[ip+1:]) != c.syscallID {
+					continue
+				}
+			}
+			d.hits = append(d.hits, fmt.Sprintf("breakpoint %d hit: %s", c.id, c))
+		}
+	}
+	return len(d.hits) != 0
+}
+
+// checkWatches compares watched storage items with the current ones.
+func (d *debugger) checkWatches() {
+	for _, c := range d.conditions {
+		if c.watch == nil {
+			continue
+		}
+		items := d.watchedItems(c.watch)
+		for _, k := range sortedKeys(items) {
+			if old, ok := c.watch.items[k]; !ok || !bytes.Equal(old, items[k]) {
+				d.hits = append(d.hits, fmt.Sprintf("watchpoint %d hit: key %s changed from %s to %s",
+					c.id, hex.EncodeToString([]byte(k)), dumpValue(old, ok), dumpValue(items[k], true)))
+			}
+		}
+		for _, k := range sortedKeys(c.watch.items) {
+			if _, ok := items[k]; !ok {
+				d.hits = append(d.hits, fmt.Sprintf("watchpoint %d hit: key %s deleted, old value %s",
+					c.id, hex.EncodeToString([]byte(k)), dumpValue(c.watch.items[k], true)))
+			}
+		}
+		c.watch.items = items
+	}
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func dumpValue(v []byte, ok bool) string {
+	if !ok {
+		return "<none>"
+	}
+	return hex.EncodeToString(v)
+}
+
+// watchedItems returns the current values of storage items matching the
+// watchpoint.
+func (d *debugger) watchedItems(w *watchpoint) map[string][]byte {
+	var (
+		ic    = getInteropContextFromContext(d.app)
+		items = make(map[string][]byte)
+	)
+	ic.DAO.Seek(w.contractID, storage.SeekRange{Prefix: w.prefix}, func(k, v []byte) bool {
+		items[string(w.prefix)+string(k)] = bytes.Clone(v)
+		return true
+	})
+	return items
+}
+
+// refreshWatches updates the watched storage items, it's required every time
+// the storage is changed not by the program execution.
+func (d *debugger) refreshWatches() {
+	for _, c := range d.conditions {
+		if c.watch != nil {
+			c.watch.items = d.watchedItems(c.watch)
+		}
+	}
+}
+
+// popHits returns the descriptions of triggered conditions and clears them.
+func (d *debugger) popHits() string {
+	s := strings.Join(d.hits, "\n")
+	d.hits = d.hits[:0]
+	return s
+}
+
+// addCondition adds a new condition and returns its ID.
+func (d *debugger) addCondition(c *condition) int {
+	d.lastID++
+	c.id = d.lastID
+	if c.watch != nil {
+		c.watch.items = d.watchedItems(c.watch)
+	}
+	d.conditions = append(d.conditions, c)
+	return c.id
+}
+
+// removeCondition removes the condition with the given ID.
+func (d *debugger) removeCondition(id int) bool {
+	l := len(d.conditions)
+	d.conditions = slices.DeleteFunc(d.conditions, func(c *condition) bool { return c.id == id })
+	return len(d.conditions) != l
+}
+
+// stepBack restores the state of the program that was n instructions ago by
+// replaying its history.
+func (d *debugger) stepBack(n int) error {
+	if len(d.history) == 0 {
+		return fmt.Errorf("no program loaded")
+	}
+	if n > d.steps {
+		return fmt.Errorf("can't step back %d instructions, only %d were executed", n, d.steps)
+	}
+	var (
+		history = d.history
+		target  = d.steps - n
+		w       = d.app.Writer
+	)
+	d.replayTo = 0
+	d.app.Writer = io.Discard
+	defer func() {
+		d.replayTo = -1
+		d.app.Writer = w
+		d.refreshWatches()
+	}()
+	d.steps = 0
+	d.trace = d.trace[:0]
+	for _, a := range history {
+		err := d.runUntil(a.at)
+		if err == nil {
+			err = a.apply()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to restore program state: %w", err)
+		}
+	}
+	err := d.runUntil(target)
+	if err != nil {
+		return fmt.Errorf("failed to restore program state: %w", err)
+	}
+	return nil
+}
+
+// runUntil runs the program until n instructions are executed in total.
+func (d *debugger) runUntil(n int) error {
+	d.replayTo = n
+	v := getVMFromContext(d.app)
+	for d.steps < n && v.Ready() && !v.HasStopped() {
+		err := v.Run()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String implements fmt.Stringer.
+func (c *condition) String() string {
+	switch {
+	case c.watch != nil:
+		s := fmt.Sprintf("storage of contract %d", c.watch.contractID)
+		if len(c.watch.prefix) != 0 {
+			s += fmt.Sprintf(" with prefix %s", hex.EncodeToString(c.watch.prefix))
+		}
+		return s
+	case c.syscall != "":
+		return fmt.Sprintf("syscall %s", c.syscall)
+	default:
+		return fmt.Sprintf("opcode %s", c.op)
+	}
+}
+
+// newSyscallCondition returns a breakpoint condition for the given syscall.
+func newSyscallCondition(name string) (*condition, error) {
+	id := interopnames.ToID([]byte(name))
+	if _, err := interopnames.FromID(id); err != nil {
+		return nil, fmt.Errorf("unknown syscall %s", name)
+	}
+	return &condition{op: opcode.SYSCALL, syscall: name, syscallID: id}, nil
+}
+
+// getDebuggerFromContext returns the debugger bound to the app.
+func getDebuggerFromContext(app *cli.App) *debugger {
+	return app.Metadata[debuggerKey].(*debugger)
+}
@@ -50,6 +50,9 @@ func signStoredTransaction(ctx *cli.Context) error {
 
 	if acc.CanSign() {
 		sign := acc.SignHashable(pc.Network, pc.Verifiable)
+		if sign == nil {
+			return cli.Exit("can't sign the transaction", 1)
+		}
 		if err := pc.AddSignature(acc.ScriptHash(), acc.Contract, acc.PublicKey(), sign); err != nil {
 			return cli.Exit(fmt.Errorf("can't add signature: %w", err), 1)
 		}
@@ -1,6 +1,8 @@
 package wallet_test
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -8,7 +10,9 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -149,6 +153,34 @@ func TestSignMultisigTx(t *testing.T) {
 			e.CheckEOF(t)
 		})
 
+		t.Run("full", func(t *testing.T) {
+			e.Run(t, "neo-go", "util", "txdump", "--full", txPath)
+			e.CheckTxTestInvokeOutput(t, 11)
+			out := e.Out.String()
+			require.Contains(t, out, "Signer #0:")
+			require.Contains(t, out, "Scopes:")
+		})
+
+		t.Run("raw", func(t *testing.T) {
+			pc, err := paramcontext.Read(txPath)
+			require.NoError(t, err)
+			tx := pc.Verifiable.(*transaction.Transaction)
+			tx.Scripts = []transaction.Witness{{VerificationScript: script}}
+			b64 := base64.StdEncoding.EncodeToString(tx.Bytes())
+
+			e.RunWithErrorCheckExit(t, "unknown encoding", "neo-go", "util", "txdump", "--raw", "not-a-tx")
+			e.RunWithErrorCheckExit(t, "unknown encoding", "neo-go", "util", "txdump", "--raw", "--hex", b64)
+			e.RunWithErrorCheckExit(t, "failed to decode transaction", "neo-go", "util", "txdump", "--raw", "0102")
+
+			for _, raw := range []string{b64, hex.EncodeToString(tx.Bytes())} {
+				e.Run(t, "neo-go", "util", "txdump", "--raw", raw)
+				e.CheckNextLine(t, "Hash:\\s+"+tx.Hash().StringLE())
+				require.Regexp(t, `Witness #0:\s+2 out of 3 multisignature`, e.Out.String())
+			}
+			e.Run(t, "neo-go", "util", "txdump", "--raw", "--hex", "0x"+hex.EncodeToString(tx.Bytes()))
+			e.CheckNextLine(t, "Hash:\\s+"+tx.Hash().StringLE())
+		})
+
 		t.Run("excessive parameters", func(t *testing.T) {
 			e.RunWithError(t, "neo-go", "util", "txdump",
 				"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
@@ -46,6 +46,7 @@ func newNEP11Commands() []*cli.Command {
 
 	transferFlags := slices.Clone(baseTransferFlags)
 	transferFlags = append(transferFlags, tokenID)
+	transferFlags = append(transferFlags, options.Ledger...)
 	transferFlags = append(transferFlags, options.RPC...)
 	return []*cli.Command{
 		{
@@ -103,7 +104,7 @@ func newNEP11Commands() []*cli.Command {
 		{
 			Name:      "transfer",
 			Usage:     "Transfer NEP-11 tokens",
-			UsageText: "transfer -w wallet [--wallet-config path] --rpc-endpoint <node> [--timeout <time>] --from <addr> --to <addr> --token <hash-or-name> --id <token-id> [--amount string] [--await] [data] [-- <cosigner1:Scope> [<cosigner2> [...]]]",
+			UsageText: "transfer -w wallet [--wallet-config path] --rpc-endpoint <node> [--timeout <time>] --from <addr> [--ledger [--ledger-index <index>]] --to <addr> --token <hash-or-name> --id <token-id> [--amount string] [--await] [data] [-- <cosigner1:Scope> [<cosigner2> [...]]]",
 			Action:    transferNEP11,
 			Flags:     transferFlags,
 			Description: `Transfers specified NEP-11 token with optional cosigners list attached to
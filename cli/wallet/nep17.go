@@ -35,7 +35,7 @@ import (
 type transferTarget struct {
 	Token   util.Uint160
 	Address util.Uint160
-	Amount  int64
+	Amount  *big.Int
 	Data    any
 }
 
@@ -79,7 +79,7 @@ var (
 			Usage: "Amount of asset to send",
 		},
 	}
-	multiTransferFlags = append([]cli.Flag{
+	multiTransferFlags = append(append([]cli.Flag{
 		walletPathFlag,
 		walletConfigFlag,
 		txctx.OutFlag,
@@ -88,7 +88,7 @@ var (
 		txctx.SysGasFlag,
 		txctx.ForceFlag,
 		txctx.AwaitFlag,
-	}, options.RPC...)
+	}, options.Ledger...), options.RPC...)
 )
 
 func newNEP17Commands() []*cli.Command {
@@ -96,7 +96,32 @@ func newNEP17Commands() []*cli.Command {
 	balanceFlags = append(balanceFlags, options.RPC...)
 
 	transferFlags := slices.Clone(baseTransferFlags)
+	transferFlags = append(transferFlags, options.Ledger...)
 	transferFlags = append(transferFlags, options.RPC...)
+
+	allowanceFlags := slices.Clone(baseBalanceFlags)
+	allowanceFlags = append(allowanceFlags, options.RPC...)
+
+	revokeFlags := []cli.Flag{
+		walletPathFlag,
+		walletConfigFlag,
+		txctx.OutFlag,
+		fromAddrFlag,
+		spenderFlag,
+		tokenFlag,
+		txctx.GasFlag,
+		txctx.SysGasFlag,
+		txctx.ForceFlag,
+		txctx.AwaitFlag,
+	}
+	revokeFlags = append(revokeFlags, options.Ledger...)
+	revokeFlags = append(revokeFlags, options.RPC...)
+	approveFlags := slices.Clone(revokeFlags)
+	approveFlags = append(approveFlags, &cli.StringFlag{
+		Name:     "amount",
+		Usage:    "Amount of asset spender is allowed to transfer",
+		Required: true,
+	})
 	return []*cli.Command{
 		{
 			Name:      "balance",
@@ -151,7 +176,7 @@ func newNEP17Commands() []*cli.Command {
 		{
 			Name:      "transfer",
 			Usage:     "Transfer NEP-17 tokens",
-			UsageText: "transfer -w wallet [--wallet-config path] [--await] --rpc-endpoint <node> [--timeout <time>] --from <addr> --to <addr> --token <hash-or-name> --amount string [data] [-- <cosigner1:Scope> [<cosigner2> [...]]]",
+			UsageText: "transfer -w wallet [--wallet-config path] [--await] --rpc-endpoint <node> [--timeout <time>] --from <addr> [--ledger [--ledger-index <index>]] --to <addr> --token <hash-or-name> --amount string [data] [-- <cosigner1:Scope> [<cosigner2> [...]]]",
 			Action:    transferNEP17,
 			Flags:     transferFlags,
 			Description: `Transfers specified NEP-17 token amount with optional 'data' parameter and cosigners
@@ -166,11 +191,46 @@ func newNEP17Commands() []*cli.Command {
 		{
 			Name:  "multitransfer",
 			Usage: "Transfer NEP-17 tokens to multiple recipients",
-			UsageText: `multitransfer -w wallet [--wallet-config path] [--await] --rpc-endpoint <node> --timeout <time> --from <addr>` +
+			UsageText: `multitransfer -w wallet [--wallet-config path] [--await] --rpc-endpoint <node> --timeout <time> --from <addr> [--ledger [--ledger-index <index>]]` +
 				` <token1>:<addr1>:<amount1> [<token2>:<addr2>:<amount2> [...]] [-- <cosigner1:Scope> [<cosigner2> [...]]]`,
 			Action: multiTransferNEP17,
 			Flags:  multiTransferFlags,
 		},
+		{
+			Name:      "allowance",
+			Usage:     "Get allowances for the given spenders",
+			UsageText: "allowance -w wallet [--wallet-config path] --rpc-endpoint <node> [--timeout <time>] [--address <address>] --token <hash-or-name> <spender> [<spender> [...]]",
+			Description: `Prints the amount of NEP-17 tokens each spender is allowed to transfer on
+   behalf of wallet accounts (or a single account chosen with the address
+   option). Token must implement approve/transferFrom extension with
+   'allowance' method. Spenders can be specified by address or hash, for
+   deployed contracts the contract name is printed as well.
+`,
+			Action: listNEP17Allowances,
+			Flags:  allowanceFlags,
+		},
+		{
+			Name:      "approve",
+			Usage:     "Allow spender to transfer NEP-17 tokens",
+			UsageText: "approve -w wallet [--wallet-config path] [--await] --rpc-endpoint <node> [--timeout <time>] --from <addr> [--ledger [--ledger-index <index>]] --spender <addr> --token <hash-or-name> --amount string",
+			Description: `Calls 'approve' method of the token allowing spender to transfer up to the
+   specified amount of tokens from the sender account via 'transferFrom'. The
+   previous allowance is replaced, not increased. Token must implement
+   approve/transferFrom extension.
+`,
+			Action: approveNEP17,
+			Flags:  approveFlags,
+		},
+		{
+			Name:      "revoke",
+			Usage:     "Revoke NEP-17 tokens approval",
+			UsageText: "revoke -w wallet [--wallet-config path] [--await] --rpc-endpoint <node> [--timeout <time>] --from <addr> [--ledger [--ledger-index <index>]] --spender <addr> --token <hash-or-name>",
+			Description: `Calls 'approve' method of the token with zero amount revoking any allowance
+   previously given to spender by the sender account.
+`,
+			Action: revokeNEP17,
+			Flags:  revokeFlags,
+		},
 	}
 }
 
@@ -525,7 +585,7 @@ func multiTransferNEP17(ctx *cli.Context) error {
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
-	acc, err := options.GetUnlockedAccount(wall, from, pass)
+	acc, err := options.GetSigningAccount(ctx, wall, from, pass)
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
@@ -560,7 +620,10 @@ func multiTransferNEP17(ctx *cli.Context) error {
 		return exitErr
 	}
 
-	cache := make(map[string]*wallet.Token)
+	var (
+		cache   = make(map[string]*wallet.Token)
+		readers = make(map[util.Uint160]*nep17.TokenReader)
+	)
 	for i := range cosignersSepPos {
 		arg := ctx.Args().Get(i)
 		ss := strings.SplitN(arg, ":", 3)
@@ -582,14 +645,19 @@ func multiTransferNEP17(ctx *cli.Context) error {
 		if err != nil {
 			return cli.Exit(fmt.Errorf("invalid address: '%s'", ss[1]), 1)
 		}
-		amount, err := fixedn.FromString(ss[2], int(token.Decimals))
+		r, ok := readers[token.Hash]
+		if !ok {
+			r = nep17.NewReader(act, token.Hash)
+			readers[token.Hash] = r
+		}
+		amount, err := r.ParseAmount(ss[2])
 		if err != nil {
 			return cli.Exit(fmt.Errorf("invalid amount: %w", err), 1)
 		}
 		recipients = append(recipients, transferTarget{
 			Token:   token.Hash,
 			Address: addr,
-			Amount:  amount.Int64(),
+			Amount:  amount,
 			Data:    nil,
 		})
 	}
@@ -619,7 +687,7 @@ func transferNEP(ctx *cli.Context, standard string) error {
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
-	acc, err := options.GetUnlockedAccount(wall, from, pass)
+	acc, err := options.GetSigningAccount(ctx, wall, from, pass)
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
@@ -655,15 +723,16 @@ func transferNEP(ctx *cli.Context, standard string) error {
 		}
 	}
 
+	// Amounts are parsed using token decimals retrieved from the chain, it's
+	// OK for NEP-11 transfer to not have amount set.
 	amountArg := ctx.String("amount")
-	amount, err := fixedn.FromString(amountArg, int(token.Decimals))
-	// It's OK for NEP-11 transfer to not have amount set.
-	if err != nil && (standard == manifest.NEP17StandardName || amountArg != "") {
-		return cli.Exit(fmt.Errorf("invalid amount: %w", err), 1)
-	}
 	switch standard {
 	case manifest.NEP17StandardName:
 		n17 := nep17.New(act, token.Hash)
+		amount, aerr := n17.ParseAmount(amountArg)
+		if aerr != nil {
+			return cli.Exit(fmt.Errorf("invalid amount: %w", aerr), 1)
+		}
 		tx, err = n17.TransferUnsigned(act.Sender(), to, amount, data)
 	case manifest.NEP11StandardName:
 		tokenID := ctx.String("id")
@@ -679,6 +748,10 @@ func transferNEP(ctx *cli.Context, standard string) error {
 			tx, err = n11.TransferUnsigned(to, tokenIDBytes, data)
 		} else {
 			n11 := nep11.NewDivisible(act, token.Hash)
+			amount, aerr := n11.ParseAmount(amountArg)
+			if aerr != nil {
+				return cli.Exit(fmt.Errorf("invalid amount: %w", aerr), 1)
+			}
 			tx, err = n11.TransferDUnsigned(act.Sender(), to, amount, tokenIDBytes, data)
 		}
 	default:
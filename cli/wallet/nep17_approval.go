@@ -0,0 +1,197 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/txctx"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli/v2"
+)
+
+var spenderFlag = &flags.AddressFlag{
+	Name:     "spender",
+	Usage:    "Address allowed to spend owner's tokens",
+	Required: true,
+}
+
+func listNEP17Allowances(ctx *cli.Context) error {
+	var accounts []*wallet.Account
+
+	if ctx.NArg() == 0 {
+		return cli.Exit(errors.New("no spenders given"), 1)
+	}
+	spenders := make([]util.Uint160, 0, ctx.NArg())
+	for _, s := range ctx.Args().Slice() {
+		h, err := flags.ParseAddress(s)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("invalid spender %q: %w", s, err), 1)
+		}
+		spenders = append(spenders, h)
+	}
+
+	wall, _, err := readWallet(ctx)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("bad wallet: %w", err), 1)
+	}
+	defer wall.Close()
+
+	addrFlag := ctx.Generic("address").(*flags.Address)
+	if addrFlag.IsSet {
+		addrHash := addrFlag.Uint160()
+		acc := wall.GetAccount(addrHash)
+		if acc == nil {
+			return cli.Exit(fmt.Errorf("can't find account for the address: %s", address.Uint160ToString(addrHash)), 1)
+		}
+		accounts = append(accounts, acc)
+	} else {
+		if len(wall.Accounts) == 0 {
+			return cli.Exit(errors.New("no accounts in the wallet"), 1)
+		}
+		accounts = wall.Accounts
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, inv, exitErr := options.GetRPCWithInvoker(gctx, ctx, nil)
+	if exitErr != nil {
+		return exitErr
+	}
+
+	token, err := getApprovableToken(ctx, c, wall, accounts[0].ScriptHash())
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	names := make(map[util.Uint160]string)
+	for _, s := range spenders {
+		// Not being a contract is not an error, spender can be a regular account.
+		cs, err := c.GetContractStateByHash(s)
+		if err == nil {
+			names[s] = cs.Manifest.Name
+		}
+	}
+
+	tok := nep17.NewApprovableReader(inv, token.Hash)
+	for k, acc := range accounts {
+		if k != 0 {
+			fmt.Fprintln(ctx.App.Writer)
+		}
+		fmt.Fprintf(ctx.App.Writer, "Account %s\n", acc.Address)
+		for _, s := range spenders {
+			allowance, err := tok.Allowance(acc.ScriptHash(), s)
+			if err != nil {
+				return cli.Exit(fmt.Errorf("failed to get allowance for %s: %w", address.Uint160ToString(s), err), 1)
+			}
+			fmt.Fprintf(ctx.App.Writer, "\tSpender  : %s (%s)\n", address.Uint160ToString(s), s.StringLE())
+			if name, ok := names[s]; ok {
+				fmt.Fprintf(ctx.App.Writer, "\tContract : %s\n", name)
+			}
+			fmt.Fprintf(ctx.App.Writer, "\tAllowance: %s %s\n", fixedn.ToString(allowance, int(token.Decimals)), token.Symbol)
+		}
+	}
+	return nil
+}
+
+func approveNEP17(ctx *cli.Context) error {
+	return setNEP17Approval(ctx, false)
+}
+
+func revokeNEP17(ctx *cli.Context) error {
+	return setNEP17Approval(ctx, true)
+}
+
+// setNEP17Approval creates, signs and sends (or saves) approve transaction
+// for the given spender. Zero amount is used when revoking approval.
+func setNEP17Approval(ctx *cli.Context, revoke bool) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, pass, err := readWallet(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	fromFlag := ctx.Generic("from").(*flags.Address)
+	from, err := getDefaultAddress(fromFlag, wall)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	acc, err := options.GetSigningAccount(ctx, wall, from, pass)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	signersAccounts, err := cmdargs.GetSignersAccounts(acc, wall, nil, transaction.CalledByEntry)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("invalid signers: %w", err), 1)
+	}
+	c, act, exitErr := options.GetRPCWithActor(gctx, ctx, signersAccounts)
+	if exitErr != nil {
+		return exitErr
+	}
+
+	token, err := getApprovableToken(ctx, c, wall, from)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	var (
+		tok    = nep17.NewApprovable(act, token.Hash)
+		amount = new(big.Int)
+	)
+	if !revoke {
+		amount, err = tok.ParseAmount(ctx.String("amount"))
+		if err != nil {
+			return cli.Exit(fmt.Errorf("invalid amount: %w", err), 1)
+		}
+	}
+	spender := ctx.Generic("spender").(*flags.Address).Uint160()
+	tx, err := tok.ApproveUnsigned(act.Sender(), spender, amount)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't make transaction: %w", err), 1)
+	}
+	return txctx.SignAndSend(ctx, act, acc, tx)
+}
+
+// getApprovableToken returns NEP-17 token specified by the token flag looking
+// for it in the wallet first, then in the owner's balances and then treating
+// it as a contract hash or address.
+func getApprovableToken(ctx *cli.Context, c *rpcclient.Client, wall *wallet.Wallet, owner util.Uint160) (*wallet.Token, error) {
+	name := ctx.String("token")
+	if name == "" {
+		return nil, errors.New("token should be specified")
+	}
+	token, err := getMatchingToken(ctx, wall, name, manifest.NEP17StandardName)
+	if err == nil {
+		return token, nil
+	}
+	token, err = getMatchingTokenRPC(ctx, c, owner, name, manifest.NEP17StandardName)
+	if err == nil {
+		return token, nil
+	}
+	h, err := flags.ParseAddress(name)
+	if err != nil {
+		return nil, fmt.Errorf("can't find matching token: %w", err)
+	}
+	token, err = getTokenWithStandard(c, h, manifest.NEP17StandardName)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid NEP-17 token: %w", name, err)
+	}
+	return token, nil
+}
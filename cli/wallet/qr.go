@@ -0,0 +1,193 @@
+package wallet
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/cli/txctx"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	qrcode "github.com/skip2/go-qrcode"
+	"github.com/urfave/cli/v2"
+)
+
+// qrPNGSize is the size of QR frame images written to files.
+const qrPNGSize = 512
+
+func newQRCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:      "export",
+			Usage:     "Show context as animated QR code",
+			UsageText: "export --in <file.in> [--frame-size <size>] [--interval <time>] [--loops <n>] [--out <dir>]",
+			Description: `Shows the given (in file.in) parameter context (like the one produced by
+   --out option of transaction-creating commands or by 'wallet sign') as
+   a sequence of QR codes in the terminal, so that it can be scanned by
+   a camera-equipped machine without any other connection. Frames are shown
+   one by one with the given interval, the sequence is repeated the given
+   number of times (until interrupted by default), each frame contains up to
+   frame-size characters of compressed context data. If the output directory
+   is given, frames are saved there as PNG images instead.
+
+   Scanned frames can be imported with 'wallet qr import' command, so the
+   typical air-gapped signing workflow is to export an unsigned context on
+   an online machine, import it on the offline one, sign it with 'wallet sign',
+   export the result and import it back on the online machine (merging new
+   signatures into the original context if needed).
+`,
+			Action: qrExport,
+			Flags: []cli.Flag{
+				inFlag,
+				&cli.IntFlag{
+					Name:  "frame-size",
+					Value: context.DefaultQRFrameSize,
+					Usage: "Maximum number of data characters in a single frame",
+				},
+				&cli.DurationFlag{
+					Name:  "interval",
+					Value: time.Second,
+					Usage: "Time each frame is shown for",
+				},
+				&cli.IntFlag{
+					Name:  "loops",
+					Usage: "Number of times the sequence is shown, 0 means until interrupted",
+				},
+				&cli.StringFlag{
+					Name:  "out",
+					Usage: "Directory to save frames to as PNG images",
+				},
+			},
+		},
+		{
+			Name:      "import",
+			Usage:     "Import context from scanned QR frames",
+			UsageText: "import --out <file.out> [--merge <file>]",
+			Description: `Reads scanned QR code frames produced by 'wallet qr export' from the
+   standard input (one per line, in any order, duplicates and unrelated lines
+   are ignored) until the whole parameter context is restored and saves it
+   into the given file. Most barcode scanners and scanning applications
+   can "type" scanned text, so they can be used directly. If merge file
+   is given, it must contain the context for the same transaction, signatures
+   from the scanned context are then added to it and the result is saved.
+`,
+			Action: qrImport,
+			Flags: []cli.Flag{
+				txctx.OutFlag,
+				&cli.StringFlag{
+					Name:  "merge",
+					Usage: "File with the context to merge scanned signatures into",
+				},
+			},
+		},
+	}
+}
+
+func qrExport(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	pc, err := paramcontext.Read(ctx.String("in"))
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	frames, err := context.QRFrames(pc, ctx.Int("frame-size"))
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	codes := make([]*qrcode.QRCode, len(frames))
+	for i := range frames {
+		codes[i], err = qrcode.New(frames[i], qrcode.Low)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("can't encode frame %d: %w", i+1, err), 1)
+		}
+	}
+	if dir := ctx.String("out"); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return cli.Exit(fmt.Errorf("can't create output directory: %w", err), 1)
+		}
+		for i := range codes {
+			png, err := codes[i].PNG(qrPNGSize)
+			if err != nil {
+				return cli.Exit(fmt.Errorf("can't render frame %d: %w", i+1, err), 1)
+			}
+			name := filepath.Join(dir, fmt.Sprintf("frame-%03d.png", i+1))
+			if err := os.WriteFile(name, png, 0644); err != nil {
+				return cli.Exit(fmt.Errorf("can't save frame %d: %w", i+1, err), 1)
+			}
+		}
+		fmt.Fprintf(ctx.App.Writer, "%d frames saved to %s\n", len(codes), dir)
+		return nil
+	}
+	var (
+		interval = ctx.Duration("interval")
+		loops    = ctx.Int("loops")
+	)
+	for l := 0; loops == 0 || l < loops; l++ {
+		for i := range codes {
+			// Clear the screen and move cursor to the top left corner.
+			fmt.Fprint(ctx.App.Writer, "\033[H\033[2J")
+			fmt.Fprint(ctx.App.Writer, codes[i].ToSmallString(false))
+			fmt.Fprintf(ctx.App.Writer, "Frame %d/%d\n", i+1, len(codes))
+			select {
+			case <-ctx.Context.Done():
+				return nil
+			case <-time.After(interval):
+			}
+		}
+	}
+	return nil
+}
+
+func qrImport(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	out := ctx.String("out")
+	if out == "" {
+		return cli.Exit("output file is not specified", 1)
+	}
+	var (
+		d       context.QRDecoder
+		scanner = bufio.NewScanner(ctx.App.Reader)
+		done    bool
+	)
+	for !done && scanner.Scan() {
+		var err error
+		done, err = d.Add(scanner.Text())
+		if errors.Is(err, context.ErrNotQRFrame) {
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(ctx.App.ErrWriter, "skipping frame: %s\n", err)
+			continue
+		}
+		got, total := d.Progress()
+		fmt.Fprintf(ctx.App.ErrWriter, "frame received: %d/%d\n", got, total)
+	}
+	if err := scanner.Err(); err != nil {
+		return cli.Exit(fmt.Errorf("can't read frames: %w", err), 1)
+	}
+	pc, err := d.Context()
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	if merge := ctx.String("merge"); merge != "" {
+		orig, err := paramcontext.Read(merge)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+		if err := orig.Merge(pc); err != nil {
+			return cli.Exit(fmt.Errorf("can't merge contexts: %w", err), 1)
+		}
+		pc = orig
+	}
+	if err := paramcontext.Save(pc, out); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package wallet_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletQR(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmpDir := t.TempDir()
+
+	privs, pubs := testcli.GenerateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, pubs)
+	require.NoError(t, err)
+	ctr := &wallet.Contract{
+		Script: script,
+		Parameters: []wallet.ContractParam{
+			{Name: "param0", Type: smartcontract.SignatureType},
+			{Name: "param1", Type: smartcontract.SignatureType},
+		},
+	}
+	tx := transaction.New([]byte{1, 2, 3}, 100)
+	tx.Signers = []transaction.Signer{{Account: hash.Hash160(script)}}
+	tx.Scripts = []transaction.Witness{{}}
+
+	newCtx := func(signers ...*keys.PrivateKey) *context.ParameterContext {
+		c := context.NewParameterContext("Neo.Network.P2P.Payloads.Transaction", netmode.UnitTestNet, tx)
+		for _, p := range signers {
+			sig := p.SignHashable(uint32(netmode.UnitTestNet), tx)
+			require.NoError(t, c.AddSignature(tx.Signers[0].Account, ctr, p.PublicKey(), sig))
+		}
+		return c
+	}
+	scan := func(c *context.ParameterContext) string {
+		frames, err := context.QRFrames(c, 50)
+		require.NoError(t, err)
+		require.Greater(t, len(frames), 1)
+		// Scanned in reverse order with duplicates and garbage.
+		var lines = []string{"garbage", frames[0]}
+		for i := len(frames) - 1; i >= 0; i-- {
+			lines = append(lines, frames[i])
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	t.Run("export", func(t *testing.T) {
+		inPath := filepath.Join(tmpDir, "export.json")
+		require.NoError(t, paramcontext.Save(newCtx(privs[0]), inPath))
+		outDir := filepath.Join(tmpDir, "frames")
+
+		e.RunWithErrorCheck(t, `Required flag "in" not set`, "neo-go", "wallet", "qr", "export", "--out", outDir)
+		e.RunWithError(t, "neo-go", "wallet", "qr", "export", "--in", inPath, "--frame-size", "0", "--out", outDir)
+		e.RunWithError(t, "neo-go", "wallet", "qr", "export", "--in", inPath, "--out", outDir, "extra")
+
+		e.Run(t, "neo-go", "wallet", "qr", "export", "--in", inPath, "--frame-size", "100", "--out", outDir)
+		frames, err := context.QRFrames(newCtx(privs[0]), 100)
+		require.NoError(t, err)
+		e.CheckNextLine(t, "frames saved to")
+		files, err := os.ReadDir(outDir)
+		require.NoError(t, err)
+		require.Equal(t, len(frames), len(files))
+		require.Equal(t, "frame-001.png", files[0].Name())
+
+		e.Run(t, "neo-go", "wallet", "qr", "export", "--in", inPath, "--interval", "1ms", "--loops", "1")
+		require.Contains(t, e.Out.String(), "Frame 1/")
+	})
+	t.Run("import", func(t *testing.T) {
+		outPath := filepath.Join(tmpDir, "import.json")
+		orig := newCtx(privs[0])
+
+		e.RunWithError(t, "neo-go", "wallet", "qr", "import")
+		e.In.WriteString("garbage\n")
+		e.RunWithErrorCheck(t, "not all frames are collected", "neo-go", "wallet", "qr", "import", "--out", outPath)
+
+		e.In.WriteString(scan(orig))
+		e.Run(t, "neo-go", "wallet", "qr", "import", "--out", outPath)
+		actual, err := paramcontext.Read(outPath)
+		require.NoError(t, err)
+		require.Equal(t, orig.Verifiable.Hash(), actual.Verifiable.Hash())
+		require.Equal(t, 1, len(actual.Items[tx.Signers[0].Account].Signatures))
+	})
+	t.Run("merge", func(t *testing.T) {
+		mergePath := filepath.Join(tmpDir, "merge.json")
+		outPath := filepath.Join(tmpDir, "merged.json")
+		require.NoError(t, paramcontext.Save(newCtx(privs[0]), mergePath))
+
+		otherTx := transaction.New([]byte{4}, 1)
+		otherTx.Signers = tx.Signers
+		other := context.NewParameterContext("Neo.Network.P2P.Payloads.Transaction", netmode.UnitTestNet, otherTx)
+		e.In.WriteString(scan(other))
+		e.RunWithErrorCheck(t, "can't merge contexts", "neo-go", "wallet", "qr", "import", "--out", outPath, "--merge", mergePath)
+
+		e.In.WriteString(scan(newCtx(privs[1])))
+		e.Run(t, "neo-go", "wallet", "qr", "import", "--out", outPath, "--merge", mergePath)
+		actual, err := paramcontext.Read(outPath)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(actual.Items[tx.Signers[0].Account].Signatures))
+		_, err = actual.GetCompleteTransaction()
+		require.NoError(t, err)
+	})
+}
@@ -117,6 +117,7 @@ func NewCommands() []*cli.Command {
 	rpcFlagOriginal, _ := options.RPC[0].(*cli.StringFlag)
 	rpcFlag := *rpcFlagOriginal
 	rpcFlag.Required = false
+	signFlags = append(signFlags, options.Ledger...)
 	signFlags = append(signFlags, &rpcFlag)
 	signFlags = append(signFlags, options.RPC[1:]...)
 	return []*cli.Command{{
@@ -237,7 +238,7 @@ func NewCommands() []*cli.Command {
 			{
 				Name:      "import",
 				Usage:     "Import WIF of a standard signature contract",
-				UsageText: "import -w wallet [--wallet-config path] --wif <wif> [--name <account_name>]",
+				UsageText: "import -w wallet [--wallet-config path] --wif <wif> [--name <account_name>] [--secp256k1]",
 				Action:    importWallet,
 				Flags: []cli.Flag{
 					walletPathFlag,
@@ -252,6 +253,10 @@ func NewCommands() []*cli.Command {
 						Name:  "contract",
 						Usage: "Verification script for custom contracts",
 					},
+					&cli.BoolFlag{
+						Name:  "secp256k1",
+						Usage: "Treat unencrypted WIF as a Secp256k1 key (NEP-2 keys are detected automatically)",
+					},
 				},
 			},
 			{
@@ -325,7 +330,7 @@ func NewCommands() []*cli.Command {
 			{
 				Name:      "sign",
 				Usage:     "Cosign transaction with multisig/contract/additional account",
-				UsageText: "sign -w wallet [--wallet-config path] --address <address> --in <file.in> [--out <file.out>] [-r <endpoint>] [--await]",
+				UsageText: "sign -w wallet [--wallet-config path] --address <address> [--ledger [--ledger-index <index>]] --in <file.in> [--out <file.out>] [-r <endpoint>] [--await]",
 				Description: `Signs the given (in file.in) context (which must be a transaction
    signing context) for the given address using the given wallet. This command can
    output the resulting JSON (with additional signature added) right to the console
@@ -338,6 +343,11 @@ func NewCommands() []*cli.Command {
 				Action: signStoredTransaction,
 				Flags:  signFlags,
 			},
+			{
+				Name:        "qr",
+				Usage:       "Transfer contexts via QR codes for air-gapped signing",
+				Subcommands: newQRCommands(),
+			},
 			{
 				Name:      "strip-keys",
 				Usage:     "Remove private keys for all accounts",
@@ -615,7 +625,7 @@ loop:
 	if !ctx.IsSet("wif") {
 		return cli.Exit(errors.New("none of the provided public keys correspond to an existing key in the wallet or multiple matching accounts found in the wallet, and no WIF is provided"), 1)
 	}
-	acc, err = newAccountFromWIF(ctx.App.Writer, ctx.String("wif"), wall.Scrypt, label, pass)
+	acc, err = newAccountFromWIF(ctx.App.Writer, ctx.String("wif"), false, wall.Scrypt, label, pass)
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
@@ -648,7 +658,7 @@ func importDeployed(ctx *cli.Context) error {
 		l := ctx.String("name")
 		label = &l
 	}
-	acc, err := newAccountFromWIF(ctx.App.Writer, ctx.String("wif"), wall.Scrypt, label, pass)
+	acc, err := newAccountFromWIF(ctx.App.Writer, ctx.String("wif"), false, wall.Scrypt, label, pass)
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
@@ -704,7 +714,7 @@ func importWallet(ctx *cli.Context) error {
 		label = &l
 	}
 
-	acc, err := newAccountFromWIF(ctx.App.Writer, ctx.String("wif"), wall.Scrypt, label, pass)
+	acc, err := newAccountFromWIF(ctx.App.Writer, ctx.String("wif"), ctx.Bool("secp256k1"), wall.Scrypt, label, pass)
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
@@ -829,6 +839,16 @@ func dumpKeys(ctx *cli.Context) error {
 			hasPrinted = true
 			continue
 		}
+		pub, ok = vm.ParseSecp256k1SignatureContract(acc.Contract.Script)
+		if ok {
+			if hasPrinted {
+				fmt.Fprintln(ctx.App.Writer)
+			}
+			fmt.Fprintf(ctx.App.Writer, "%s (secp256k1 signature contract):\n", acc.Address)
+			fmt.Fprintln(ctx.App.Writer, hex.EncodeToString(pub))
+			hasPrinted = true
+			continue
+		}
 		n, bs, ok := vm.ParseMultiSigContract(acc.Contract.Script)
 		if ok {
 			if hasPrinted {
@@ -1021,7 +1041,7 @@ func getWalletPathAndPass(ctx *cli.Context, canUseWalletConfig bool) (string, *s
 	return path, pass, nil
 }
 
-func newAccountFromWIF(w io.Writer, wif string, scrypt keys.ScryptParams, label *string, pass *string) (*wallet.Account, error) {
+func newAccountFromWIF(w io.Writer, wif string, secp256k1 bool, scrypt keys.ScryptParams, label *string, pass *string) (*wallet.Account, error) {
 	var (
 		phrase, name string
 		err          error
@@ -1067,7 +1087,12 @@ func newAccountFromWIF(w io.Writer, wif string, scrypt keys.ScryptParams, label
 		return acc, nil
 	}
 
-	acc, err := wallet.NewAccountFromWIF(wif)
+	var acc *wallet.Account
+	if secp256k1 {
+		acc, err = wallet.NewSecp256k1AccountFromWIF(wif)
+	} else {
+		acc, err = wallet.NewAccountFromWIF(wif)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -284,6 +284,24 @@ func TestWalletInit(t *testing.T) {
 					"--wallet", walletPath, "--wif", priv.WIF())
 			})
 
+			t.Run("secp256k1", func(t *testing.T) {
+				k1, err := keys.NewSecp256k1PrivateKey()
+				require.NoError(t, err)
+				e.In.WriteString("test_account_k1\r")
+				e.In.WriteString("qwerty\r")
+				e.In.WriteString("qwerty\r")
+				e.Run(t, "neo-go", "wallet", "import", "--wallet", walletPath,
+					"--wif", k1.WIF(), "--secp256k1")
+
+				w, err := wallet.NewWalletFromFile(walletPath)
+				require.NoError(t, err)
+				acc := w.GetAccount(k1.GetScriptHash())
+				require.NotNil(t, acc)
+				require.Equal(t, k1.PublicKey().GetVerificationScript(), acc.Contract.Script)
+				require.NoError(t, acc.Decrypt("qwerty", w.Scrypt))
+				require.Equal(t, k1.Bytes(), acc.PrivateKey().Bytes())
+			})
+
 			t.Run("contract", func(t *testing.T) {
 				priv, err = keys.NewPrivateKey()
 				require.NoError(t, err)
@@ -2,4 +2,4 @@ module github.com/nspcc-dev/neo-go/examples/engine
 
 go 1.22
 
-require github.com/nspcc-dev/neo-go/pkg/interop v0.0.0-20240727093519-1a48f1ce43ec
+require github.com/nspcc-dev/neo-go/pkg/interop v0.0.0-20261016115607-ef7dc72bf19a
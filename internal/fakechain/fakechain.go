@@ -3,6 +3,7 @@ package fakechain
 import (
 	"errors"
 	"math/big"
+	"sync"
 	"sync/atomic"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
@@ -26,6 +27,7 @@ type FakeChain struct {
 	*mempool.Pool
 	blocksCh                 []chan *block.Block
 	Blockheight              atomic.Uint32
+	Headerheight             atomic.Uint32
 	PoolTxF                  func(*transaction.Transaction) error
 	poolTxWithData           func(*transaction.Transaction, any, *mempool.Pool) error
 	blocks                   map[util.Uint256]*block.Block
@@ -35,8 +37,12 @@ type FakeChain struct {
 	MaxVerificationGAS       int64
 	NotaryContractScriptHash util.Uint160
 	NotaryDepositExpiration  uint32
+	NotaryBalance            *big.Int
 	PostBlock                []func(func(*transaction.Transaction, *mempool.Pool, bool) bool, *mempool.Pool, *block.Block)
 	UtilityTokenBalance      *big.Int
+
+	featureFlagsLock sync.RWMutex
+	featureFlags     map[string]int64
 }
 
 // FakeStateSync implements the StateSync interface.
@@ -47,6 +53,8 @@ type FakeStateSync struct {
 	InitFunc          func(h uint32) error
 	TraverseFunc      func(root util.Uint256, process func(node mpt.Node, nodeBytes []byte) bool) error
 	AddMPTNodesFunc   func(nodes [][]byte) error
+	RequestMPTNodes   atomic.Bool
+	MPTNodesBatchFunc func(limit int) []util.Uint256
 }
 
 // NewFakeChain returns a new FakeChain structure.
@@ -121,6 +129,9 @@ func (chain *FakeChain) GetNotaryContractScriptHash() util.Uint160 {
 
 // GetNotaryBalance implements the Blockchainer interface.
 func (chain *FakeChain) GetNotaryBalance(acc util.Uint160) *big.Int {
+	if chain.NotaryBalance != nil {
+		return chain.NotaryBalance
+	}
 	panic("TODO")
 }
 
@@ -147,6 +158,24 @@ func (chain *FakeChain) GetMaxVerificationGAS() int64 {
 	panic("TODO")
 }
 
+// GetFeatureFlag implements the Blockchainer interface.
+func (chain *FakeChain) GetFeatureFlag(name string) (int64, bool) {
+	chain.featureFlagsLock.RLock()
+	defer chain.featureFlagsLock.RUnlock()
+	v, ok := chain.featureFlags[name]
+	return v, ok
+}
+
+// SetFeatureFlag sets the feature flag returned by GetFeatureFlag.
+func (chain *FakeChain) SetFeatureFlag(name string, value int64) {
+	chain.featureFlagsLock.Lock()
+	defer chain.featureFlagsLock.Unlock()
+	if chain.featureFlags == nil {
+		chain.featureFlags = make(map[string]int64)
+	}
+	chain.featureFlags[name] = value
+}
+
 // PoolTxWithData implements the Blockchainer interface.
 func (chain *FakeChain) PoolTxWithData(t *transaction.Transaction, data any, mp *mempool.Pool, feer mempool.Feer, verificationFunction func(t *transaction.Transaction, data any) error) error {
 	return chain.poolTxWithData(t, data, mp)
@@ -178,8 +207,14 @@ func (chain *FakeChain) P2PSigExtensionsEnabled() bool {
 }
 
 // AddHeaders implements the Blockchainer interface.
-func (chain *FakeChain) AddHeaders(...*block.Header) error {
-	panic("TODO")
+func (chain *FakeChain) AddHeaders(hdrs ...*block.Header) error {
+	for _, h := range hdrs {
+		if h.Index == chain.HeaderHeight()+1 {
+			chain.hdrHashes[h.Index] = h.Hash()
+			chain.Headerheight.Store(h.Index)
+		}
+	}
+	return nil
 }
 
 // AddBlock implements the Blockchainer interface.
@@ -197,7 +232,7 @@ func (chain *FakeChain) BlockHeight() uint32 {
 
 // HeaderHeight implements the Blockchainer interface.
 func (chain *FakeChain) HeaderHeight() uint32 {
-	return chain.Blockheight.Load()
+	return max(chain.Blockheight.Load(), chain.Headerheight.Load())
 }
 
 // GetAppExecResults implements the Blockchainer interface.
@@ -448,9 +483,7 @@ func (s *FakeStateSync) Init(currChainHeight uint32) error {
 func (s *FakeStateSync) NeedHeaders() bool { return s.RequestHeaders.Load() }
 
 // NeedMPTNodes implements the StateSync interface.
-func (s *FakeStateSync) NeedMPTNodes() bool {
-	panic("TODO")
-}
+func (s *FakeStateSync) NeedMPTNodes() bool { return s.RequestMPTNodes.Load() }
 
 // Traverse implements the StateSync interface.
 func (s *FakeStateSync) Traverse(root util.Uint256, process func(node mpt.Node, nodeBytes []byte) bool) error {
@@ -460,7 +493,10 @@ func (s *FakeStateSync) Traverse(root util.Uint256, process func(node mpt.Node,
 	panic("TODO")
 }
 
-// GetUnknownMPTNodesBatch implements the StateSync interface.
-func (s *FakeStateSync) GetUnknownMPTNodesBatch(limit int) []util.Uint256 {
+// RequestMPTNodesBatch implements the StateSync interface.
+func (s *FakeStateSync) RequestMPTNodesBatch(limit int) []util.Uint256 {
+	if s.MPTNodesBatchFunc != nil {
+		return s.MPTNodesBatchFunc(limit)
+	}
 	panic("TODO")
 }
@@ -198,6 +198,7 @@ func NewExecutorWithConfig(t *testing.T, needChain, runChain bool, f func(*confi
 	}
 	e.CLI.Writer = e.Out
 	e.CLI.ErrWriter = e.Err
+	e.CLI.Reader = e.In
 	if needChain {
 		e.Chain, e.RPC, e.NetSrv = NewTestChain(t, f, runChain)
 	}
@@ -0,0 +1,122 @@
+package testserdes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/stretchr/testify/require"
+)
+
+// Vector is a serialization test vector: the binary representation of some
+// object of the given type. Vectors produced by other implementations (like
+// C# node) allow to check that the same bytes are decoded and encoded back
+// by neo-go.
+type Vector struct {
+	// Type is the name of the object type, it's used to pick the decoder.
+	Type string `json:"type"`
+	// Name is a short description of the vector.
+	Name string `json:"name"`
+	// Source is the origin of the vector (implementation, test, network).
+	Source string `json:"source,omitempty"`
+	// Data is the hex-encoded binary representation of the object.
+	Data string `json:"data"`
+}
+
+// NewVector creates a vector of the given type from the binary
+// representation of a. It's intended to be used to generate vectors for new
+// types that can then be checked against other implementations.
+func NewVector(typ, name, source string, a io.Serializable) (Vector, error) {
+	data, err := EncodeBinary(a)
+	if err != nil {
+		return Vector{}, err
+	}
+	return Vector{
+		Type:   typ,
+		Name:   name,
+		Source: source,
+		Data:   hex.EncodeToString(data),
+	}, nil
+}
+
+// ReadVectors reads vectors from the given JSON file.
+func ReadVectors(path string) ([]Vector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var vs []Vector
+	err = json.Unmarshal(data, &vs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode vectors: %w", err)
+	}
+	return vs, nil
+}
+
+// WriteVectors writes vectors to the given JSON file.
+func WriteVectors(path string, vs []Vector) error {
+	data, err := json.MarshalIndent(vs, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// CheckVectors decodes every vector using an object returned by the
+// constructor for the vector type, encodes it back and checks that the result
+// is exactly the same as the vector data. All vector types must have a
+// constructor.
+func CheckVectors(t *testing.T, vs []Vector, types map[string]func() io.Serializable) {
+	for _, v := range vs {
+		t.Run(v.Type+"/"+v.Name, func(t *testing.T) {
+			newObj, ok := types[v.Type]
+			require.True(t, ok, "unknown vector type %s", v.Type)
+			expected, err := hex.DecodeString(v.Data)
+			require.NoError(t, err)
+
+			a := newObj()
+			require.NoError(t, DecodeBinary(expected, a))
+			actual, err := EncodeBinary(a)
+			require.NoError(t, err)
+			requireSameBytes(t, expected, actual)
+		})
+	}
+}
+
+// CheckCanonical checks that the encoding of an object decoded from data is
+// canonical, that is the object decoded from this encoding is encoded into
+// exactly the same bytes. Data that can't be decoded is ignored, so it can be
+// used with random inputs. newObj must return a new empty object on every
+// call.
+func CheckCanonical(t testing.TB, data []byte, newObj func() io.Serializable) {
+	a := newObj()
+	if DecodeBinary(data, a) != nil {
+		return
+	}
+	first, err := EncodeBinary(a)
+	require.NoError(t, err)
+
+	b := newObj()
+	require.NoError(t, DecodeBinary(first, b))
+	second, err := EncodeBinary(b)
+	require.NoError(t, err)
+	requireSameBytes(t, first, second)
+}
+
+// requireSameBytes checks that actual is exactly the same as expected
+// reporting the first differing byte offset otherwise.
+func requireSameBytes(t testing.TB, expected, actual []byte) {
+	if bytes.Equal(expected, actual) {
+		return
+	}
+	var i int
+	for i < len(expected) && i < len(actual) && expected[i] == actual[i] {
+		i++
+	}
+	t.Fatalf("encoding differs at offset %d (expected %d bytes, got %d):\nexpected: %x\nactual:   %x",
+		i, len(expected), len(actual), expected, actual)
+}
@@ -0,0 +1,82 @@
+package testserdes_test
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+const csharpVectors = "testdata/csharp_vectors.json"
+
+// serializedItem makes stackitem.Item an io.Serializable.
+type serializedItem struct {
+	stackitem.Item
+}
+
+func (s *serializedItem) EncodeBinary(w *io.BinWriter) {
+	stackitem.EncodeBinary(s.Item, w)
+}
+
+func (s *serializedItem) DecodeBinary(r *io.BinReader) {
+	s.Item = stackitem.DecodeBinary(r)
+}
+
+var vectorTypes = map[string]func() io.Serializable{
+	"block":       func() io.Serializable { return block.New(false) },
+	"transaction": func() io.Serializable { return new(transaction.Transaction) },
+	"stackitem":   func() io.Serializable { return new(serializedItem) },
+}
+
+func TestCSharpVectors(t *testing.T) {
+	vs, err := testserdes.ReadVectors(csharpVectors)
+	require.NoError(t, err)
+	testserdes.CheckVectors(t, vs, vectorTypes)
+}
+
+func TestWriteReadVectors(t *testing.T) {
+	item := &serializedItem{stackitem.NewArray([]stackitem.Item{stackitem.Make(1)})}
+	v, err := testserdes.NewVector("stackitem", "array", "neo-go", item)
+	require.NoError(t, err)
+	require.Equal(t, "4001210101", v.Data)
+
+	path := filepath.Join(t.TempDir(), "vectors.json")
+	require.NoError(t, testserdes.WriteVectors(path, []testserdes.Vector{v}))
+	vs, err := testserdes.ReadVectors(path)
+	require.NoError(t, err)
+	require.Equal(t, []testserdes.Vector{v}, vs)
+	testserdes.CheckVectors(t, vs, vectorTypes)
+}
+
+func fuzzVectors(f *testing.F, typ string) {
+	vs, err := testserdes.ReadVectors(csharpVectors)
+	require.NoError(f, err)
+	for _, v := range vs {
+		if v.Type == typ {
+			data, err := hex.DecodeString(v.Data)
+			require.NoError(f, err)
+			f.Add(data)
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		testserdes.CheckCanonical(t, data, vectorTypes[typ])
+	})
+}
+
+func FuzzBlock(f *testing.F) {
+	fuzzVectors(f, "block")
+}
+
+func FuzzTransaction(f *testing.F) {
+	fuzzVectors(f, "transaction")
+}
+
+func FuzzStackItem(f *testing.F) {
+	fuzzVectors(f, "stackitem")
+}
@@ -0,0 +1,113 @@
+package vmfuzz
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// NeoGo is an Executor using pkg/vm.
+type NeoGo struct{}
+
+// External is an Executor running an external program for every script. The
+// program gets a JSON request with base64-encoded script and GAS limit via
+// its standard input:
+//
+//	{"script":"EBGe","gaslimit":"100000"}
+//
+// and it must print a JSON result with the VM state, GAS consumed and result
+// stack items in the same format as invokescript RPC call uses:
+//
+//	{"state":"HALT","gasconsumed":"270","stack":[{"type":"Integer","value":"1"}]}
+//
+// Non-zero exit code is treated as an execution error.
+type External struct {
+	Path string
+	Args []string
+}
+
+type (
+	externalRequest struct {
+		Script   []byte `json:"script"`
+		GasLimit int64  `json:"gaslimit,string"`
+	}
+
+	externalResult struct {
+		State       string            `json:"state"`
+		GasConsumed int64             `json:"gasconsumed,string"`
+		Stack       []json.RawMessage `json:"stack"`
+	}
+)
+
+// Name implements the Executor interface.
+func (NeoGo) Name() string {
+	return "neo-go"
+}
+
+// Execute implements the Executor interface.
+func (NeoGo) Execute(script []byte, gasLimit int64) (*Result, error) {
+	v := vm.New()
+	v.GasLimit = gasLimit
+	v.SetPriceGetter(func(op opcode.Opcode, _ []byte) int64 {
+		return fee.Opcode(BaseExecFee, op)
+	})
+	v.LoadScript(script)
+	_ = v.Run() // Faults are a part of the result.
+	return &Result{
+		State:       v.State(),
+		GasConsumed: v.GasConsumed(),
+		Stack:       v.Estack().ToArray(),
+	}, nil
+}
+
+// Name implements the Executor interface.
+func (e External) Name() string {
+	return filepath.Base(e.Path)
+}
+
+// Execute implements the Executor interface.
+func (e External) Execute(script []byte, gasLimit int64) (*Result, error) {
+	req, err := json.Marshal(externalRequest{Script: script, GasLimit: gasLimit})
+	if err != nil {
+		return nil, err
+	}
+	var (
+		cmd    = exec.Command(e.Path, e.Args...)
+		stdout bytes.Buffer
+		stderr bytes.Buffer
+	)
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, stderr.String())
+	}
+
+	var aux externalResult
+	if err = json.Unmarshal(stdout.Bytes(), &aux); err != nil {
+		return nil, fmt.Errorf("invalid result: %w", err)
+	}
+	res := &Result{
+		GasConsumed: aux.GasConsumed,
+		Stack:       make([]stackitem.Item, len(aux.Stack)),
+	}
+	res.State, err = vmstate.FromString(aux.State)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state: %w", err)
+	}
+	for i := range aux.Stack {
+		res.Stack[i], err = stackitem.FromJSONWithTypes(aux.Stack[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid stack item #%d: %w", i, err)
+		}
+	}
+	return res, nil
+}
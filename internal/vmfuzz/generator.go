@@ -0,0 +1,128 @@
+package vmfuzz
+
+import (
+	"math/big"
+	"math/rand/v2"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// Generator generates random valid scripts using ReferenceOpcodes. Scripts
+// contain enough push instructions for other ones to have something to work
+// with most of the time, integers are biased towards small values and the
+// edge ones (like the minimum and the maximum 256-bit integers) and jumps
+// always point to instruction boundaries (including the end of the script).
+// Scripts can contain loops, so they should be executed with some GAS limit.
+type Generator struct {
+	rand *rand.Rand
+}
+
+// edgeInts are integers that are likely to trigger edge cases.
+var edgeInts = []*big.Int{
+	refMaxInt,
+	refMinInt,
+	new(big.Int).Sub(refMaxInt, big.NewInt(1)),
+	new(big.Int).Add(refMinInt, big.NewInt(1)),
+	big.NewInt(255),
+	big.NewInt(256),
+	big.NewInt(257),
+	big.NewInt(-256),
+	big.NewInt(1<<31 - 1),
+	big.NewInt(-1 << 31),
+	big.NewInt(1 << 31),
+	new(big.Int).Lsh(big.NewInt(1), 128),
+}
+
+// NewGenerator returns a Generator with the given seed, the same seed always
+// produces the same sequence of scripts.
+func NewGenerator(seed uint64) *Generator {
+	return &Generator{rand: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// Script returns a script of n instructions.
+func (g *Generator) Script(n int) []byte {
+	var (
+		offsets = make([]int, n+1)
+		instrs  = make([][]byte, n)
+	)
+	for i := range instrs {
+		instrs[i] = g.instruction()
+		offsets[i+1] = offsets[i] + len(instrs[i])
+	}
+	// Jump targets can only be set when all offsets are known.
+	for i, instr := range instrs {
+		op := opcode.Opcode(instr[0])
+		if op < opcode.JMP || op > opcode.JMPLE {
+			continue
+		}
+		var targets []int
+		for _, off := range offsets {
+			if d := off - offsets[i]; d >= -128 && d <= 127 {
+				targets = append(targets, d)
+			}
+		}
+		instr[1] = byte(int8(targets[g.rand.IntN(len(targets))]))
+	}
+	script := make([]byte, 0, offsets[n])
+	for _, instr := range instrs {
+		script = append(script, instr...)
+	}
+	return script
+}
+
+// instruction returns a random instruction, jumps have zero offsets.
+func (g *Generator) instruction() []byte {
+	if g.rand.IntN(5) < 2 {
+		return g.push()
+	}
+	op := ReferenceOpcodes[g.rand.IntN(len(ReferenceOpcodes))]
+	size, _ := refOperandSize(op)
+	if op <= opcode.PUSHINT256 {
+		return g.push()
+	}
+	return append([]byte{byte(op)}, make([]byte, size)...)
+}
+
+// push returns an instruction pushing a random integer or boolean.
+func (g *Generator) push() []byte {
+	switch g.rand.IntN(10) {
+	case 0:
+		return []byte{byte(opcode.PUSHT + opcode.Opcode(g.rand.IntN(2)))}
+	case 1, 2:
+		return pushInt(edgeInts[g.rand.IntN(len(edgeInts))])
+	case 3:
+		k := g.rand.IntN(6)
+		b := make([]byte, 1<<k)
+		for i := range b {
+			b[i] = byte(g.rand.Uint32())
+		}
+		return append([]byte{byte(opcode.PUSHINT8) + byte(k)}, b...)
+	default:
+		return []byte{byte(opcode.PUSHM1 + opcode.Opcode(g.rand.IntN(18)))}
+	}
+}
+
+// pushInt returns the shortest PUSHINT* instruction for the given 256-bit
+// integer.
+func pushInt(v *big.Int) []byte {
+	for k := range 6 {
+		b := toLE(v, 1<<k)
+		if decodeInt(b).Cmp(v) == 0 {
+			return append([]byte{byte(opcode.PUSHINT8) + byte(k)}, b...)
+		}
+	}
+	panic("integer is too big")
+}
+
+// toLE returns a little-endian two's complement representation of v of the
+// given size (truncating it if needed).
+func toLE(v *big.Int, size int) []byte {
+	m := new(big.Int).Lsh(big.NewInt(1), uint(8*size))
+	u := new(big.Int).Mod(v, m) // Non-negative for positive m.
+	be := u.FillBytes(make([]byte, size))
+	res := make([]byte, size)
+	for i := range be {
+		res[i] = be[size-1-i]
+	}
+	return res
+}
@@ -0,0 +1,506 @@
+package vmfuzz
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// Reference is an Executor implementing a subset of VM instructions in the
+// most straightforward way, it's intentionally kept independent from pkg/vm
+// (except for opcode prices) to be a reference for it. Only integers and
+// booleans are supported, so the list of opcodes is limited to pushing them,
+// stack manipulation, arithmetic, comparison, short jumps, ASSERT, ABORT and
+// RET (see ReferenceOpcodes).
+type Reference struct{}
+
+// refMaxStackSize is the maximum number of items on the evaluation stack.
+const refMaxStackSize = 2 * 1024
+
+var (
+	// refMaxInt and refMinInt are the limits of 256-bit signed integers.
+	refMaxInt = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+	refMinInt = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+
+	// errFault is returned by the reference instruction implementations for
+	// any fault.
+	errFault = errors.New("fault")
+)
+
+// ReferenceOpcodes is the list of opcodes supported by the Reference
+// interpreter.
+var ReferenceOpcodes = []opcode.Opcode{
+	opcode.PUSHINT8, opcode.PUSHINT16, opcode.PUSHINT32, opcode.PUSHINT64,
+	opcode.PUSHINT128, opcode.PUSHINT256, opcode.PUSHT, opcode.PUSHF,
+	opcode.PUSHM1, opcode.PUSH0, opcode.PUSH1, opcode.PUSH2, opcode.PUSH3,
+	opcode.PUSH4, opcode.PUSH5, opcode.PUSH6, opcode.PUSH7, opcode.PUSH8,
+	opcode.PUSH9, opcode.PUSH10, opcode.PUSH11, opcode.PUSH12, opcode.PUSH13,
+	opcode.PUSH14, opcode.PUSH15, opcode.PUSH16,
+
+	opcode.NOP, opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ,
+	opcode.JMPNE, opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE,
+	opcode.RET, opcode.ABORT, opcode.ASSERT,
+
+	opcode.DEPTH, opcode.DROP, opcode.NIP, opcode.XDROP, opcode.CLEAR,
+	opcode.DUP, opcode.OVER, opcode.PICK, opcode.TUCK, opcode.SWAP,
+	opcode.ROT, opcode.ROLL, opcode.REVERSE3, opcode.REVERSE4, opcode.REVERSEN,
+
+	opcode.INVERT, opcode.AND, opcode.OR, opcode.XOR,
+
+	opcode.SIGN, opcode.ABS, opcode.NEGATE, opcode.INC, opcode.DEC,
+	opcode.ADD, opcode.SUB, opcode.MUL, opcode.DIV, opcode.MOD, opcode.POW,
+	opcode.SQRT, opcode.SHL, opcode.SHR, opcode.NOT, opcode.BOOLAND,
+	opcode.BOOLOR, opcode.NZ, opcode.NUMEQUAL, opcode.NUMNOTEQUAL,
+	opcode.LT, opcode.LE, opcode.GT, opcode.GE, opcode.MIN, opcode.MAX,
+	opcode.WITHIN,
+}
+
+// refItem is a stack item of the Reference interpreter.
+type refItem struct {
+	value  *big.Int
+	isBool bool
+}
+
+// refVM is the Reference interpreter state.
+type refVM struct {
+	script []byte
+	ip     int
+	next   int
+	stack  []refItem
+}
+
+// refOperandSize returns operand size for the supported opcodes and false for
+// the unsupported ones.
+func refOperandSize(op opcode.Opcode) (int, bool) {
+	if !slices.Contains(ReferenceOpcodes, op) {
+		return 0, false
+	}
+	switch {
+	case op <= opcode.PUSHINT256:
+		return 1 << int(op-opcode.PUSHINT8), true
+	case op >= opcode.JMP && op <= opcode.JMPLE:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+// Name implements the Executor interface.
+func (Reference) Name() string {
+	return "reference"
+}
+
+// Execute implements the Executor interface.
+func (Reference) Execute(script []byte, gasLimit int64) (*Result, error) {
+	var (
+		r   = &refVM{script: script}
+		res = &Result{State: vmstate.Fault}
+	)
+	for r.ip < len(script) {
+		op := opcode.Opcode(script[r.ip])
+		size, ok := refOperandSize(op)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s at %d", ErrUnsupported, op, r.ip)
+		}
+		if r.ip+1+size > len(script) {
+			return nil, fmt.Errorf("%w: truncated %s at %d", ErrUnsupported, op, r.ip)
+		}
+		res.GasConsumed += fee.Opcode(BaseExecFee, op)
+		if res.GasConsumed > gasLimit {
+			return res, nil
+		}
+		if op == opcode.RET {
+			break
+		}
+		r.next = r.ip + 1 + size
+		if err := r.execute(op, script[r.ip+1:r.next]); err != nil {
+			if errors.Is(err, ErrUnsupported) {
+				return nil, err
+			}
+			return res, nil
+		}
+		if len(r.stack) > refMaxStackSize {
+			return res, nil
+		}
+		r.ip = r.next
+	}
+	res.State = vmstate.Halt
+	for _, it := range r.stack {
+		if it.isBool {
+			res.Stack = append(res.Stack, stackitem.NewBool(it.value.Sign() != 0))
+		} else {
+			res.Stack = append(res.Stack, stackitem.NewBigInteger(it.value))
+		}
+	}
+	return res, nil
+}
+
+func (r *refVM) pop() (refItem, error) {
+	if len(r.stack) == 0 {
+		return refItem{}, errFault
+	}
+	it := r.stack[len(r.stack)-1]
+	r.stack = r.stack[:len(r.stack)-1]
+	return it, nil
+}
+
+func (r *refVM) popInt() (*big.Int, error) {
+	it, err := r.pop()
+	return it.value, err
+}
+
+// popInt32 pops an integer that is used as an index or a count.
+func (r *refVM) popInt32() (int, error) {
+	v, err := r.popInt()
+	if err != nil || v.Cmp(big.NewInt(math.MinInt32)) < 0 || v.Cmp(big.NewInt(math.MaxInt32)) > 0 {
+		return 0, errFault
+	}
+	return int(v.Int64()), nil
+}
+
+func (r *refVM) popBool() (bool, error) {
+	v, err := r.popInt()
+	return err == nil && v.Sign() != 0, err
+}
+
+// pop2 pops two integers, the first returned one is the deeper one.
+func (r *refVM) pop2() (*big.Int, *big.Int, error) {
+	b, err := r.popInt()
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := r.popInt()
+	return a, b, err
+}
+
+func (r *refVM) pushInt(v *big.Int) error {
+	if v.Cmp(refMinInt) < 0 || v.Cmp(refMaxInt) > 0 {
+		return errFault
+	}
+	r.stack = append(r.stack, refItem{value: v})
+	return nil
+}
+
+func (r *refVM) pushBool(b bool) error {
+	var v int64
+	if b {
+		v = 1
+	}
+	r.stack = append(r.stack, refItem{value: big.NewInt(v), isBool: true})
+	return nil
+}
+
+// peek returns the index of the n-th item from the top of the stack.
+func (r *refVM) peek(n int) (int, error) {
+	if n < 0 || n >= len(r.stack) {
+		return 0, errFault
+	}
+	return len(r.stack) - 1 - n, nil
+}
+
+// decodeInt decodes a little-endian two's complement integer.
+func decodeInt(b []byte) *big.Int {
+	be := slices.Clone(b)
+	slices.Reverse(be)
+	v := new(big.Int).SetBytes(be)
+	if len(b) > 0 && b[len(b)-1]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+	}
+	return v
+}
+
+func (r *refVM) execute(op opcode.Opcode, param []byte) error {
+	switch {
+	case op <= opcode.PUSHINT256:
+		return r.pushInt(decodeInt(param))
+	case op >= opcode.PUSHM1 && op <= opcode.PUSH16:
+		return r.pushInt(big.NewInt(int64(op) - int64(opcode.PUSH0)))
+	case op >= opcode.JMP && op <= opcode.JMPLE:
+		return r.jump(op, param)
+	}
+
+	switch op {
+	case opcode.PUSHT, opcode.PUSHF:
+		return r.pushBool(op == opcode.PUSHT)
+	case opcode.NOP:
+		return nil
+	case opcode.ABORT:
+		return errFault
+	case opcode.ASSERT:
+		ok, err := r.popBool()
+		if err != nil || !ok {
+			return errFault
+		}
+		return nil
+	case opcode.DEPTH:
+		return r.pushInt(big.NewInt(int64(len(r.stack))))
+	case opcode.DROP:
+		_, err := r.pop()
+		return err
+	case opcode.NIP, opcode.XDROP:
+		n := 1
+		if op == opcode.XDROP {
+			var err error
+			if n, err = r.popInt32(); err != nil {
+				return err
+			}
+		}
+		i, err := r.peek(n)
+		if err != nil {
+			return err
+		}
+		r.stack = slices.Delete(r.stack, i, i+1)
+		return nil
+	case opcode.CLEAR:
+		r.stack = r.stack[:0]
+		return nil
+	case opcode.DUP, opcode.OVER, opcode.PICK:
+		var n int
+		switch op {
+		case opcode.OVER:
+			n = 1
+		case opcode.PICK:
+			var err error
+			if n, err = r.popInt32(); err != nil {
+				return err
+			}
+		}
+		i, err := r.peek(n)
+		if err != nil {
+			return err
+		}
+		r.stack = append(r.stack, r.stack[i])
+		return nil
+	case opcode.TUCK:
+		if len(r.stack) < 2 {
+			return errFault
+		}
+		r.stack = slices.Insert(r.stack, len(r.stack)-2, r.stack[len(r.stack)-1])
+		return nil
+	case opcode.SWAP, opcode.ROT, opcode.ROLL:
+		n := 1
+		switch op {
+		case opcode.ROT:
+			n = 2
+		case opcode.ROLL:
+			var err error
+			if n, err = r.popInt32(); err != nil {
+				return err
+			}
+		}
+		i, err := r.peek(n)
+		if err != nil {
+			return err
+		}
+		it := r.stack[i]
+		r.stack = append(slices.Delete(r.stack, i, i+1), it)
+		return nil
+	case opcode.REVERSE3, opcode.REVERSE4, opcode.REVERSEN:
+		n := 3
+		switch op {
+		case opcode.REVERSE4:
+			n = 4
+		case opcode.REVERSEN:
+			var err error
+			if n, err = r.popInt32(); err != nil {
+				return err
+			}
+		}
+		if n < 0 || n > len(r.stack) {
+			return errFault
+		}
+		slices.Reverse(r.stack[len(r.stack)-n:])
+		return nil
+	case opcode.SHL, opcode.SHR:
+		n, err := r.popInt32()
+		if err != nil || n == 0 {
+			return err
+		}
+		if n < 0 || n > 256 {
+			return errFault
+		}
+		a, err := r.popInt()
+		if err != nil {
+			return err
+		}
+		pow := new(big.Int).Lsh(big.NewInt(1), uint(n))
+		if op == opcode.SHL {
+			return r.pushInt(new(big.Int).Mul(a, pow))
+		}
+		return r.pushInt(floorDiv(a, pow))
+	case opcode.INVERT, opcode.SIGN, opcode.ABS, opcode.NEGATE, opcode.INC,
+		opcode.DEC, opcode.SQRT, opcode.NOT, opcode.NZ:
+		a, err := r.popInt()
+		if err != nil {
+			return err
+		}
+		return r.unary(op, a)
+	case opcode.WITHIN:
+		a, b, err := r.pop2()
+		if err != nil {
+			return err
+		}
+		x, err := r.popInt()
+		if err != nil {
+			return err
+		}
+		return r.pushBool(a.Cmp(x) <= 0 && x.Cmp(b) < 0)
+	default:
+		a, b, err := r.pop2()
+		if err != nil {
+			return err
+		}
+		return r.binary(op, a, b)
+	}
+}
+
+func (r *refVM) jump(op opcode.Opcode, param []byte) error {
+	var cond = true
+
+	switch op {
+	case opcode.JMP:
+	case opcode.JMPIF, opcode.JMPIFNOT:
+		b, err := r.popBool()
+		if err != nil {
+			return err
+		}
+		cond = b == (op == opcode.JMPIF)
+	default:
+		a, b, err := r.pop2()
+		if err != nil {
+			return err
+		}
+		cond = jumpCondition(op, a.Cmp(b))
+	}
+	if cond {
+		target := r.ip + int(int8(param[0]))
+		if target < 0 || target >= len(r.script) {
+			return errFault
+		}
+		r.next = target
+	}
+	return nil
+}
+
+func jumpCondition(op opcode.Opcode, cmp int) bool {
+	switch op {
+	case opcode.JMPEQ:
+		return cmp == 0
+	case opcode.JMPNE:
+		return cmp != 0
+	case opcode.JMPGT:
+		return cmp > 0
+	case opcode.JMPGE:
+		return cmp >= 0
+	case opcode.JMPLT:
+		return cmp < 0
+	default: // JMPLE
+		return cmp <= 0
+	}
+}
+
+func (r *refVM) unary(op opcode.Opcode, a *big.Int) error {
+	switch op {
+	case opcode.INVERT:
+		return r.pushInt(new(big.Int).Sub(new(big.Int).Neg(a), big.NewInt(1)))
+	case opcode.SIGN:
+		return r.pushInt(big.NewInt(int64(a.Sign())))
+	case opcode.ABS:
+		return r.pushInt(new(big.Int).Abs(a))
+	case opcode.NEGATE:
+		return r.pushInt(new(big.Int).Neg(a))
+	case opcode.INC:
+		return r.pushInt(new(big.Int).Add(a, big.NewInt(1)))
+	case opcode.DEC:
+		return r.pushInt(new(big.Int).Sub(a, big.NewInt(1)))
+	case opcode.SQRT:
+		if a.Sign() < 0 {
+			return errFault
+		}
+		return r.pushInt(new(big.Int).Sqrt(a))
+	case opcode.NOT:
+		return r.pushBool(a.Sign() == 0)
+	default: // NZ
+		return r.pushBool(a.Sign() != 0)
+	}
+}
+
+func (r *refVM) binary(op opcode.Opcode, a, b *big.Int) error {
+	cmp := a.Cmp(b)
+	switch op {
+	case opcode.AND:
+		return r.pushInt(new(big.Int).And(a, b))
+	case opcode.OR:
+		return r.pushInt(new(big.Int).Or(a, b))
+	case opcode.XOR:
+		return r.pushInt(new(big.Int).Xor(a, b))
+	case opcode.ADD:
+		return r.pushInt(new(big.Int).Add(a, b))
+	case opcode.SUB:
+		return r.pushInt(new(big.Int).Sub(a, b))
+	case opcode.MUL:
+		return r.pushInt(new(big.Int).Mul(a, b))
+	case opcode.DIV, opcode.MOD:
+		if b.Sign() == 0 {
+			return errFault
+		}
+		// Both are truncated towards zero.
+		q := floorDiv(new(big.Int).Abs(a), new(big.Int).Abs(b))
+		if a.Sign()*b.Sign() < 0 {
+			q.Neg(q)
+		}
+		if op == opcode.DIV {
+			return r.pushInt(q)
+		}
+		return r.pushInt(new(big.Int).Sub(a, new(big.Int).Mul(q, b)))
+	case opcode.POW:
+		if b.Sign() < 0 || b.Cmp(big.NewInt(256)) > 0 {
+			return errFault
+		}
+		res := big.NewInt(1)
+		for range b.Int64() {
+			res.Mul(res, a)
+		}
+		return r.pushInt(res)
+	case opcode.BOOLAND:
+		return r.pushBool(a.Sign() != 0 && b.Sign() != 0)
+	case opcode.BOOLOR:
+		return r.pushBool(a.Sign() != 0 || b.Sign() != 0)
+	case opcode.NUMEQUAL:
+		return r.pushBool(cmp == 0)
+	case opcode.NUMNOTEQUAL:
+		return r.pushBool(cmp != 0)
+	case opcode.LT:
+		return r.pushBool(cmp < 0)
+	case opcode.LE:
+		return r.pushBool(cmp <= 0)
+	case opcode.GT:
+		return r.pushBool(cmp > 0)
+	case opcode.GE:
+		return r.pushBool(cmp >= 0)
+	case opcode.MIN:
+		if cmp > 0 {
+			return r.pushInt(b)
+		}
+		return r.pushInt(a)
+	case opcode.MAX:
+		if cmp < 0 {
+			return r.pushInt(b)
+		}
+		return r.pushInt(a)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupported, op)
+	}
+}
+
+// floorDiv returns a/b rounded towards negative infinity for positive b.
+func floorDiv(a, b *big.Int) *big.Int {
+	return new(big.Int).Div(a, b) // Euclidean division is the same for b > 0.
+}
@@ -0,0 +1,131 @@
+/*
+Package vmfuzz implements differential VM testing. It generates random valid
+scripts (see Generator), executes them with several Executor implementations
+and reports any difference in the resulting VM state, GAS consumed and result
+stack as a Divergence.
+
+Available executors are NeoGo (pkg/vm itself), Reference (a simple
+independent interpreter for a subset of opcodes dealing with integers,
+booleans, the evaluation stack and control flow) and External (any program
+implementing a simple JSON protocol, like a harness around the C# VM).
+Divergences are consensus-splitting bugs in one of the implementations.
+*/
+package vmfuzz
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// BaseExecFee is the opcode price multiplier used by executors.
+const BaseExecFee = interop.DefaultBaseExecFee
+
+// ErrUnsupported is returned by executors for scripts they can't execute (like
+// the ones containing opcodes not supported by the Reference interpreter),
+// it's not a divergence.
+var ErrUnsupported = errors.New("unsupported script")
+
+// Result is a script execution result.
+type Result struct {
+	State       vmstate.State
+	GasConsumed int64
+	// Stack is the resulting evaluation stack with the top item being the
+	// last. It's only compared for the HALT state.
+	Stack []stackitem.Item
+}
+
+// String implements the fmt.Stringer interface.
+func (r *Result) String() string {
+	return fmt.Sprintf("%s %d %s", r.State, r.GasConsumed, stackString(r.Stack))
+}
+
+// Executor executes scripts.
+type Executor interface {
+	// Name returns executor name used in divergence reports.
+	Name() string
+	// Execute runs the script with the given GAS limit (in datoshi, with
+	// BaseExecFee used for opcode prices) in a single context without any
+	// arguments and returns the result. An error is returned if execution
+	// can't be performed at all, faults are reported via Result.State.
+	Execute(script []byte, gasLimit int64) (*Result, error)
+}
+
+// Divergence is a difference between the results of two executors.
+type Divergence struct {
+	Script   []byte
+	Expected string // Executor name.
+	Actual   string // Executor name.
+	Reason   string
+}
+
+// Error implements the error interface.
+func (d *Divergence) Error() string {
+	return fmt.Sprintf("%s and %s diverge on %s: %s", d.Expected, d.Actual, hex.EncodeToString(d.Script), d.Reason)
+}
+
+// Check executes the script with all executors and compares their results with
+// the result of the first one. It returns *Divergence for the first mismatch
+// found. If any of executors returns an error (including ErrUnsupported), it's
+// returned as is.
+func Check(script []byte, gasLimit int64, executors ...Executor) error {
+	var expected *Result
+
+	for i, e := range executors {
+		res, err := e.Execute(script, gasLimit)
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		if i == 0 {
+			expected = res
+			continue
+		}
+		if reason := compare(expected, res); reason != "" {
+			return &Divergence{
+				Script:   script,
+				Expected: executors[0].Name(),
+				Actual:   e.Name(),
+				Reason:   reason,
+			}
+		}
+	}
+	return nil
+}
+
+// compare returns a description of the difference between the results or an
+// empty string if they're the same.
+func compare(a, b *Result) string {
+	if a.State != b.State {
+		return fmt.Sprintf("state %s vs %s", a.State, b.State)
+	}
+	if a.GasConsumed != b.GasConsumed {
+		return fmt.Sprintf("GAS consumed %d vs %d", a.GasConsumed, b.GasConsumed)
+	}
+	if a.State != vmstate.Halt {
+		return ""
+	}
+	as, bs := stackString(a.Stack), stackString(b.Stack)
+	if as != bs {
+		return fmt.Sprintf("stack %s vs %s", as, bs)
+	}
+	return ""
+}
+
+// stackString returns a JSON-like representation of stack items.
+func stackString(items []stackitem.Item) string {
+	var parts = make([]string, len(items))
+	for i := range items {
+		data, err := stackitem.ToJSONWithTypes(items[i])
+		if err != nil {
+			parts[i] = fmt.Sprintf("<%s: %s>", items[i].Type(), err)
+			continue
+		}
+		parts[i] = string(data)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
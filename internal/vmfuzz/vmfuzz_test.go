@@ -0,0 +1,114 @@
+package vmfuzz
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+// externalEnv is an environment variable with the command line of an
+// additional External executor (like the C# VM harness) for differential
+// tests.
+const externalEnv = "NEOGO_VMFUZZ_EXTERNAL"
+
+const testGasLimit = 1 << 16 * BaseExecFee
+
+func getExecutors(t testing.TB) []Executor {
+	var res = []Executor{Reference{}, NeoGo{}}
+	if cmd := strings.Fields(os.Getenv(externalEnv)); len(cmd) != 0 {
+		t.Logf("using %q executor", cmd)
+		res = append(res, External{Path: cmd[0], Args: cmd[1:]})
+	}
+	return res
+}
+
+func TestGenerator(t *testing.T) {
+	g := NewGenerator(1)
+	for range 1000 {
+		script := g.Script(1 + g.rand.IntN(64))
+		require.NoError(t, vm.IsScriptCorrect(script, nil), "%x", script)
+	}
+	require.Equal(t, NewGenerator(42).Script(100), NewGenerator(42).Script(100))
+}
+
+func TestDifferential(t *testing.T) {
+	var (
+		g      = NewGenerator(0)
+		execs  = getExecutors(t)
+		states = make(map[vmstate.State]int)
+		n      = 5000
+	)
+	if testing.Short() {
+		n = 500
+	}
+	for range n {
+		script := g.Script(1 + g.rand.IntN(64))
+		require.NoError(t, Check(script, testGasLimit, execs...))
+
+		res, err := execs[0].Execute(script, testGasLimit)
+		require.NoError(t, err)
+		states[res.State]++
+	}
+	// Make sure scripts are meaningful enough.
+	require.Greater(t, states[vmstate.Halt], n/10)
+	require.Greater(t, states[vmstate.Fault], n/10)
+}
+
+func TestCheck(t *testing.T) {
+	script := []byte{byte(opcode.PUSH1), byte(opcode.PUSH2), byte(opcode.ADD)}
+	require.NoError(t, Check(script, testGasLimit, Reference{}, NeoGo{}))
+
+	t.Run("divergence", func(t *testing.T) {
+		err := Check(script, testGasLimit, Reference{}, brokenAdd{})
+		var d *Divergence
+		require.True(t, errors.As(err, &d))
+		require.Equal(t, "reference", d.Expected)
+		require.Equal(t, "neo-go", d.Actual)
+		require.Contains(t, d.Error(), "11129e")
+		require.Contains(t, d.Reason, "stack")
+	})
+	t.Run("gas", func(t *testing.T) {
+		res, err := NeoGo{}.Execute(script, 2*BaseExecFee)
+		require.NoError(t, err)
+		require.Equal(t, vmstate.Fault, res.State)
+		require.NoError(t, Check(script, 2*BaseExecFee, Reference{}, NeoGo{}))
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		err := Check([]byte{byte(opcode.NEWMAP)}, testGasLimit, Reference{}, NeoGo{})
+		require.ErrorIs(t, err, ErrUnsupported)
+	})
+}
+
+// brokenAdd is an executor that subtracts instead of adding.
+type brokenAdd struct {
+	NeoGo
+}
+
+func (b brokenAdd) Execute(script []byte, gasLimit int64) (*Result, error) {
+	script = []byte(strings.ReplaceAll(string(script), string([]byte{byte(opcode.ADD)}), string([]byte{byte(opcode.SUB)})))
+	return b.NeoGo.Execute(script, gasLimit)
+}
+
+func FuzzDifferential(f *testing.F) {
+	g := NewGenerator(0)
+	for range 10 {
+		f.Add(g.Script(32))
+	}
+	execs := getExecutors(f)
+	f.Fuzz(func(t *testing.T, script []byte) {
+		if vm.IsScriptCorrect(script, nil) != nil {
+			return
+		}
+		err := Check(script, testGasLimit, execs...)
+		if errors.Is(err, ErrUnsupported) {
+			return
+		}
+		require.NoError(t, err)
+	})
+}
@@ -718,7 +718,8 @@ func isSyscall(fun *funcScope) bool {
 		return false
 	}
 	return fun.pkg.Name() == "neogointernal" && (strings.HasPrefix(fun.name, "Syscall") ||
-		strings.HasPrefix(fun.name, "Opcode") || strings.HasPrefix(fun.name, "CallWithToken"))
+		strings.HasPrefix(fun.name, "Opcode") || strings.HasPrefix(fun.name, "CallWithToken") ||
+		strings.HasPrefix(fun.name, "Asm"))
 }
 
 const interopPrefix = "github.com/nspcc-dev/neo-go/pkg/interop"
@@ -0,0 +1,238 @@
+package compiler
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// parseAsm converts textual NeoVM assembly used in neogointernal.Asm intrinsic
+// into bytecode. Instructions are separated by newlines or semicolons (that
+// are a part of the instruction if used inside of a quoted string), every
+// instruction is an opcode name optionally followed by a single operand:
+//   - PUSHINT* accept a decimal or 0x-prefixed integer fitting into the
+//     instruction size;
+//   - PUSHDATA* accept a 0x-prefixed hex string or a quoted Go string, length
+//     prefix is added automatically;
+//   - SYSCALL accepts an interop name (like System.Runtime.Log);
+//   - ISTYPE, CONVERT and NEWARRAYT accept a stack item type name (like
+//     Integer) or a number;
+//   - slot instructions (LDLOC, STARG, etc.) accept an index.
+//
+// Control flow instructions (jumps, calls, exception handling, RET) and slot
+// initialization are not allowed since they break compiler invariants.
+func parseAsm(code string) ([]byte, error) {
+	var w = io.NewBufBinWriter()
+
+	lines, err := splitAsm(code)
+	if err != nil {
+		return nil, err
+	}
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		err := parseAsmInstruction(w.BinWriter, line)
+		if err != nil {
+			return nil, fmt.Errorf("instruction #%d (%s): %w", i, line, err)
+		}
+	}
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	if w.Len() == 0 {
+		return nil, errors.New("empty assembly")
+	}
+	return w.Bytes(), nil
+}
+
+// splitAsm splits assembly code into instructions. Quoted strings can only be
+// used as instruction operands, so they must be preceded by a space and
+// followed by the end of instruction, anything else is ambiguous and rejected.
+func splitAsm(code string) ([]string, error) {
+	var (
+		lines []string
+		start int
+		quote byte
+		end   = -1 // The end of the last quoted string.
+	)
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		switch {
+		case quote != 0:
+			switch {
+			case c == '\\' && quote != '`':
+				i++
+			case c == '\n' && quote != '`':
+				return nil, fmt.Errorf("instruction #%d: unterminated string", len(lines))
+			case c == quote:
+				quote, end = 0, i
+			}
+		case c == '\n' || c == ';':
+			lines = append(lines, code[start:i])
+			start = i + 1
+		case end >= start && c != ' ' && c != '\t' && c != '\r':
+			return nil, fmt.Errorf("instruction #%d: unexpected data after string", len(lines))
+		case c == '"' || c == '`' || c == '\'':
+			if i == 0 || (code[i-1] != ' ' && code[i-1] != '\t') {
+				return nil, fmt.Errorf("instruction #%d: unexpected string", len(lines))
+			}
+			quote = c
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("instruction #%d: unterminated string", len(lines))
+	}
+	return append(lines, code[start:]), nil
+}
+
+func parseAsmInstruction(w *io.BinWriter, line string) error {
+	name, arg := line, ""
+	if i := strings.IndexFunc(line, unicode.IsSpace); i >= 0 {
+		name, arg = line[:i], strings.TrimSpace(line[i:])
+	}
+	hasArg := arg != ""
+
+	op, err := opcode.FromString(name)
+	if err != nil {
+		return fmt.Errorf("unknown opcode %s", name)
+	}
+	if isForbiddenAsmOpcode(op) {
+		return fmt.Errorf("%s is not allowed in assembly", op)
+	}
+
+	var operand []byte
+	switch {
+	case op >= opcode.PUSHINT8 && op <= opcode.PUSHINT256:
+		if !hasArg {
+			return errors.New("missing integer operand")
+		}
+		n, ok := new(big.Int).SetString(arg, 0)
+		if !ok {
+			return fmt.Errorf("invalid integer %s", arg)
+		}
+		size := 1 << op
+		buf := bigint.ToPreallocatedBytes(n, make([]byte, 0, size))
+		if len(buf) > size {
+			return fmt.Errorf("%s doesn't fit into %d bytes", arg, size)
+		}
+		var pad byte
+		if n.Sign() < 0 {
+			pad = 0xff
+		}
+		for len(buf) < size {
+			buf = append(buf, pad)
+		}
+		operand = buf
+	case op == opcode.PUSHDATA1 || op == opcode.PUSHDATA2 || op == opcode.PUSHDATA4:
+		if !hasArg {
+			return errors.New("missing data operand")
+		}
+		data, err := parseAsmData(arg)
+		if err != nil {
+			return err
+		}
+		var maxLen = map[opcode.Opcode]int{
+			opcode.PUSHDATA1: 0xff,
+			opcode.PUSHDATA2: 0xffff,
+			opcode.PUSHDATA4: stackitem.MaxSize,
+		}[op]
+		if len(data) > maxLen {
+			return fmt.Errorf("data is too big for %s: %d bytes", op, len(data))
+		}
+		var prefix []byte
+		switch op {
+		case opcode.PUSHDATA1:
+			prefix = []byte{byte(len(data))}
+		case opcode.PUSHDATA2:
+			prefix = binary.LittleEndian.AppendUint16(nil, uint16(len(data)))
+		default:
+			prefix = binary.LittleEndian.AppendUint32(nil, uint32(len(data)))
+		}
+		operand = append(prefix, data...)
+	case op == opcode.SYSCALL:
+		if !hasArg {
+			return errors.New("missing interop name")
+		}
+		id := interopnames.ToID([]byte(arg))
+		if _, err := interopnames.FromID(id); err != nil {
+			return fmt.Errorf("unknown interop %s", arg)
+		}
+		operand = binary.LittleEndian.AppendUint32(nil, id)
+	case op == opcode.ISTYPE || op == opcode.CONVERT || op == opcode.NEWARRAYT:
+		if !hasArg {
+			return errors.New("missing type operand")
+		}
+		typ, err := stackitem.FromString(arg)
+		if err != nil {
+			n, nErr := strconv.ParseUint(arg, 0, 8)
+			if nErr != nil {
+				return fmt.Errorf("invalid type %s", arg)
+			}
+			typ = stackitem.Type(n)
+		}
+		if !typ.IsValid() {
+			return fmt.Errorf("invalid type %s", arg)
+		}
+		operand = []byte{byte(typ)}
+	case op == opcode.LDSFLD || op == opcode.STSFLD || op == opcode.LDLOC ||
+		op == opcode.STLOC || op == opcode.LDARG || op == opcode.STARG:
+		if !hasArg {
+			return errors.New("missing slot index")
+		}
+		n, err := strconv.ParseUint(arg, 0, 8)
+		if err != nil {
+			return fmt.Errorf("invalid slot index %s", arg)
+		}
+		operand = []byte{byte(n)}
+	default:
+		if hasArg {
+			return fmt.Errorf("%s has no operand", op)
+		}
+	}
+	emit.Instruction(w, op, operand)
+	return nil
+}
+
+func parseAsmData(arg string) ([]byte, error) {
+	if strings.HasPrefix(arg, "0x") {
+		data, err := hex.DecodeString(arg[2:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex data: %w", err)
+		}
+		return data, nil
+	}
+	s, err := strconv.Unquote(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid string data %s", arg)
+	}
+	return []byte(s), nil
+}
+
+// isForbiddenAsmOpcode returns true for opcodes that depend on code offsets,
+// change control flow or manage slots which is done by the compiler itself.
+func isForbiddenAsmOpcode(op opcode.Opcode) bool {
+	switch op {
+	case opcode.JMP, opcode.JMPL, opcode.JMPIF, opcode.JMPIFL, opcode.JMPIFNOT, opcode.JMPIFNOTL,
+		opcode.JMPEQ, opcode.JMPEQL, opcode.JMPNE, opcode.JMPNEL, opcode.JMPGT, opcode.JMPGTL,
+		opcode.JMPGE, opcode.JMPGEL, opcode.JMPLT, opcode.JMPLTL, opcode.JMPLE, opcode.JMPLEL,
+		opcode.CALL, opcode.CALLL, opcode.CALLA, opcode.CALLT, opcode.PUSHA,
+		opcode.TRY, opcode.TRYL, opcode.ENDTRY, opcode.ENDTRYL, opcode.ENDFINALLY,
+		opcode.RET, opcode.INITSLOT, opcode.INITSSLOT:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package compiler
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAsm(t *testing.T) {
+	t.Run("good", func(t *testing.T) {
+		code, err := parseAsm(`PUSHINT8 -1; PUSHINT16 0x0102
+			PUSHDATA1 0x0a0b
+			PUSHDATA2 "ab"
+			DUP ; ISTYPE Integer
+			CONVERT 0x28
+			LDARG 1
+			SYSCALL System.Runtime.Log`)
+		require.NoError(t, err)
+
+		w := io.NewBufBinWriter()
+		emit.Instruction(w.BinWriter, opcode.PUSHINT8, []byte{0xff})
+		emit.Instruction(w.BinWriter, opcode.PUSHINT16, []byte{0x02, 0x01})
+		emit.Instruction(w.BinWriter, opcode.PUSHDATA1, []byte{2, 0x0a, 0x0b})
+		emit.Instruction(w.BinWriter, opcode.PUSHDATA2, []byte{2, 0, 'a', 'b'})
+		emit.Opcodes(w.BinWriter, opcode.DUP)
+		emit.Instruction(w.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.IntegerT)})
+		emit.Instruction(w.BinWriter, opcode.CONVERT, []byte{byte(stackitem.ByteArrayT)})
+		emit.Instruction(w.BinWriter, opcode.LDARG, []byte{1})
+		emit.Syscall(w.BinWriter, interopnames.SystemRuntimeLog)
+		require.Equal(t, w.Bytes(), code)
+	})
+	t.Run("quoted separators", func(t *testing.T) {
+		code, err := parseAsm("PUSHDATA1 \"a;\\\"b\" ;DROP\nPUSHDATA1 `c\nd;` ")
+		require.NoError(t, err)
+
+		w := io.NewBufBinWriter()
+		emit.Instruction(w.BinWriter, opcode.PUSHDATA1, []byte{4, 'a', ';', '"', 'b'})
+		emit.Opcodes(w.BinWriter, opcode.DROP)
+		emit.Instruction(w.BinWriter, opcode.PUSHDATA1, []byte{4, 'c', '\n', 'd', ';'})
+		require.Equal(t, w.Bytes(), code)
+	})
+	t.Run("tab separator", func(t *testing.T) {
+		code, err := parseAsm("PUSHINT8\t3;PUSHDATA1\t\t\"a b\"")
+		require.NoError(t, err)
+
+		w := io.NewBufBinWriter()
+		emit.Instruction(w.BinWriter, opcode.PUSHINT8, []byte{3})
+		emit.Instruction(w.BinWriter, opcode.PUSHDATA1, []byte{3, 'a', ' ', 'b'})
+		require.Equal(t, w.Bytes(), code)
+	})
+	t.Run("bad", func(t *testing.T) {
+		for _, code := range []string{
+			"",
+			" ; ",
+			"UNKNOWN",
+			"JMP 2",
+			"RET",
+			"INITSLOT 0x0101",
+			"ADD 1",
+			"PUSHINT8",
+			"PUSHINT8 300",
+			"PUSHINT8 abc",
+			"PUSHDATA1",
+			"PUSHDATA1 0xzz",
+			"PUSHDATA1 unquoted",
+			"SYSCALL",
+			"SYSCALL System.Unknown",
+			"ISTYPE",
+			"ISTYPE Unknown",
+			"CONVERT 0x01",
+			"LDLOC",
+			"LDLOC 256",
+			`PUSHDATA1 "a;b`,
+			"PUSHDATA1 \"a\nb\"",
+			`PUSHDATA1 "a" "b"`,
+			`PUSHDATA1 "a"b`,
+			`PUSHDATA1 "a";"b"`,
+			`PUSHDATA1 a"b;c"`,
+		} {
+			_, err := parseAsm(code)
+			require.Error(t, err, code)
+		}
+	})
+}
@@ -74,6 +74,9 @@ type codegen struct {
 	currentFor string
 	// A label for the switch statement being visited.
 	currentSwitch string
+	// dispatchSwitches contains switch statements to be compiled into a
+	// binary search over case values.
+	dispatchSwitches map[*ast.SwitchStmt]bool
 	// A label to be used in the next statement.
 	nextLabel string
 
@@ -844,12 +847,16 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		for i := range startLabels {
 			startLabels[i] = c.newLabel()
 		}
+		dispatch := c.dispatchSwitches[n]
+		if dispatch {
+			c.emitSwitchDispatch(n, startLabels, switchEnd)
+		}
 		for i := range n.Body.List {
 			lEnd := c.newLabel()
 			lStart := startLabels[i]
 			cc := n.Body.List[i].(*ast.CaseClause)
 
-			if l := len(cc.List); l != 0 { // if not `default`
+			if l := len(cc.List); l != 0 && !dispatch { // if not `default`
 				for j := range cc.List {
 					emit.Opcodes(c.prog.BinWriter, opcode.DUP)
 					ast.Walk(c, cc.List[j])
@@ -1541,6 +1548,64 @@ func (c *codegen) rangeLoadKey() {
 		opcode.PICKITEM)
 }
 
+// switchCase is a single constant value of a switch case clause along with the
+// label of the clause body.
+type switchCase struct {
+	expr  ast.Expr
+	val   constant.Value
+	label uint16
+}
+
+// emitSwitchDispatch emits a binary search over constant integer case values
+// of the switch statement with the tag already on the stack. It jumps to the
+// label of the matching clause body, to the default clause or to the end of
+// the switch if nothing matches. The tag is left on the stack in any case.
+func (c *codegen) emitSwitchDispatch(n *ast.SwitchStmt, startLabels []uint16, switchEnd uint16) {
+	var (
+		cases      []switchCase
+		defaultLbl = switchEnd
+	)
+	for i, stmt := range n.Body.List {
+		cc := stmt.(*ast.CaseClause)
+		if cc.List == nil {
+			defaultLbl = startLabels[i]
+		}
+		for _, e := range cc.List {
+			cases = append(cases, switchCase{expr: e, val: c.typeAndValueOf(e).Value, label: startLabels[i]})
+		}
+	}
+	slices.SortFunc(cases, func(a, b switchCase) int {
+		if constant.Compare(a.val, token.LSS, b.val) {
+			return -1
+		}
+		return 1
+	})
+	c.emitCaseSearch(cases, defaultLbl)
+}
+
+// emitCaseSearch emits a binary search over sorted switch cases. Small ranges
+// are checked sequentially.
+func (c *codegen) emitCaseSearch(cases []switchCase, defaultLbl uint16) {
+	if len(cases) <= 3 {
+		for _, cs := range cases {
+			emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+			ast.Walk(c, cs.expr)
+			emit.Opcodes(c.prog.BinWriter, opcode.NUMEQUAL)
+			emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, cs.label)
+		}
+		emit.Jmp(c.prog.BinWriter, opcode.JMPL, defaultLbl)
+		return
+	}
+	mid := len(cases) / 2
+	lLess := c.newLabel()
+	emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+	ast.Walk(c, cases[mid].expr)
+	emit.Jmp(c.prog.BinWriter, opcode.JMPLTL, lLess)
+	c.emitCaseSearch(cases[mid:], defaultLbl)
+	c.setLabel(lLess)
+	c.emitCaseSearch(cases[:mid], defaultLbl)
+}
+
 func isFallthroughStmt(c ast.Node) bool {
 	s, ok := c.(*ast.BranchStmt)
 	return ok && s.Tok == token.FALLTHROUGH
@@ -1754,6 +1819,10 @@ func (c *codegen) convertSyscall(f *funcScope, expr *ast.CallExpr) {
 	if strings.HasPrefix(f.name, "CallWithToken") {
 		callArgs = expr.Args[3:]
 	}
+	if strings.HasPrefix(f.name, "Asm") && expr.Ellipsis.IsValid() {
+		c.prog.Err = errors.New("assembly arguments can't be passed as a slice")
+		return
+	}
 	for _, arg := range callArgs {
 		ast.Walk(c, arg)
 	}
@@ -1767,6 +1836,13 @@ func (c *codegen) convertSyscall(f *funcScope, expr *ast.CallExpr) {
 	if strings.HasPrefix(f.name, "Syscall") {
 		c.emitReverse(len(callArgs))
 		emit.Syscall(c.prog.BinWriter, arg0Str)
+	} else if strings.HasPrefix(f.name, "Asm") {
+		code, err := parseAsm(arg0Str)
+		if err != nil {
+			c.prog.Err = fmt.Errorf("invalid assembly: %w", err)
+			return
+		}
+		c.prog.WriteBytes(code)
 	} else if strings.HasPrefix(f.name, "CallWithToken") {
 		var hasRet = !strings.HasSuffix(f.name, "NoRet")
 
@@ -2228,6 +2304,9 @@ func (c *codegen) compile(info *buildInfo, pkg *packages.Package) error {
 	if c.prog.Err != nil {
 		return c.prog.Err
 	}
+	if info.options != nil && info.options.Optimize > OptimizeNone {
+		c.optimize(info.options.Optimize)
+	}
 	c.fillDocumentInfo()
 	funUsage := c.analyzeFuncAndGlobalVarUsage()
 	if c.prog.Err != nil {
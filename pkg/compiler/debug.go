@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/types"
+	"os"
 	"slices"
 	"strconv"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
@@ -643,3 +645,66 @@ func (di *DebugInfo) ConvertToManifest(o *Options) (*manifest.Manifest, error) {
 	}
 	return result, nil
 }
+
+// ReadDebugInfo reads and decodes contract debug information from the given
+// JSON file.
+func ReadDebugInfo(path string) (*DebugInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	di := new(DebugInfo)
+	if err := json.Unmarshal(data, di); err != nil {
+		return nil, fmt.Errorf("failed to decode debug info: %w", err)
+	}
+	return di, nil
+}
+
+// DescribeOffset returns the source location of the instruction at the given
+// offset in "file:line in namespace.method" format. An empty string is returned
+// if the offset doesn't belong to any method.
+func (di *DebugInfo) DescribeOffset(ip int) string {
+	for i := range di.Methods {
+		m := &di.Methods[i]
+		if ip < int(m.Range.Start) || ip > int(m.Range.End) {
+			continue
+		}
+		name := m.Name.Namespace + "." + m.ID
+		var sp *DebugSeqPoint
+		for j := range m.SeqPoints {
+			if m.SeqPoints[j].Opcode <= ip && (sp == nil || sp.Opcode < m.SeqPoints[j].Opcode) {
+				sp = &m.SeqPoints[j]
+			}
+		}
+		if sp == nil || sp.Document < 0 || sp.Document >= len(di.Documents) {
+			return name
+		}
+		return fmt.Sprintf("%s:%d in %s", di.Documents[sp.Document], sp.StartLine, name)
+	}
+	return ""
+}
+
+// DescribeFault returns the message of the error the given VM has failed with
+// extended by the source location of the failing instruction. Debug information
+// is looked up in infos by the script hash of the contexts starting from the
+// topmost one, so if the failing contract has no debug information, the call
+// site in the nearest caller having it is used. The message is returned
+// unchanged if no location can be resolved.
+func DescribeFault(v *vm.VM, err error, infos map[util.Uint160]*DebugInfo) string {
+	msg := err.Error()
+	if len(infos) == 0 {
+		return msg
+	}
+	istack := v.Istack()
+	for i := len(istack) - 1; i >= 0; i-- {
+		ctx := istack[i]
+		di, ok := infos[hash.Hash160(ctx.Program())]
+		if !ok {
+			continue
+		}
+		if loc := di.DescribeOffset(ctx.IP()); loc != "" {
+			return fmt.Sprintf("%s (at %s)", msg, loc)
+		}
+	}
+	return msg
+}
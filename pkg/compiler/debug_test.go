@@ -9,6 +9,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -412,3 +414,37 @@ func TestManifestOverload(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestDebugInfo_DescribeFault(t *testing.T) {
+	src := `package foo
+	func Main(a int) int {
+		if a > 0 {
+			return a
+		}
+		panic("negative")
+	}`
+
+	ne, d, err := CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+
+	t.Run("offset", func(t *testing.T) {
+		require.Equal(t, "", d.DescribeOffset(len(ne.Script)))
+		require.Regexp(t, `foo\.go:4 in foo\.Main$`, d.DescribeOffset(int(d.Methods[0].SeqPoints[0].Opcode)))
+	})
+
+	v := vm.New()
+	v.LoadScript(ne.Script)
+	v.Estack().PushVal(-1)
+	err = v.Run()
+	require.Error(t, err)
+
+	t.Run("no debug info", func(t *testing.T) {
+		require.Equal(t, err.Error(), DescribeFault(v, err, nil))
+		require.Equal(t, err.Error(), DescribeFault(v, err, map[util.Uint160]*DebugInfo{{1, 2, 3}: d}))
+	})
+	t.Run("resolved", func(t *testing.T) {
+		msg := DescribeFault(v, err, map[util.Uint160]*DebugInfo{d.Hash: d})
+		require.True(t, strings.HasPrefix(msg, err.Error()))
+		require.Regexp(t, `\(at .*foo\.go:6 in foo\.Main\)$`, msg)
+	})
+}
@@ -0,0 +1,31 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+)
+
+// EstimateMethodFees returns static execution fee estimations (see
+// [fee.Estimate]) for all methods of the compiled contract using its script
+// and debug info. Every method is estimated separately, so the resulting
+// estimation i corresponds to di.Methods[i] and calls to other methods are
+// reported as dynamic parts. Offsets of dynamic parts are script offsets.
+func EstimateMethodFees(script []byte, di *DebugInfo, base int64, syscallPrice fee.SyscallPriceGetter) ([]*fee.Estimation, error) {
+	var res = make([]*fee.Estimation, len(di.Methods))
+	for i, m := range di.Methods {
+		start, end := int(m.Range.Start), int(m.Range.End)
+		if start > end || end >= len(script) {
+			return nil, fmt.Errorf("method %s: invalid range %d-%d", m.ID, start, end)
+		}
+		est, err := fee.Estimate(base, script[start:end+1], syscallPrice)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", m.ID, err)
+		}
+		for j := range est.Dynamic {
+			est.Dynamic[j].Offset += start
+		}
+		res[i] = est
+	}
+	return res, nil
+}
@@ -0,0 +1,56 @@
+package compiler_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateMethodFees(t *testing.T) {
+	src := `package foo
+	import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	func Sum(a, b int) int {
+		return a + b
+	}
+	func Log(s string) {
+		runtime.Log(s)
+		for i := 0; i < Sum(1, 2); i++ {
+			runtime.Notify("event")
+		}
+	}`
+	b, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+
+	ests, err := compiler.EstimateMethodFees(b.Script, di, interop.DefaultBaseExecFee, core.SyscallPrice)
+	require.NoError(t, err)
+	require.Equal(t, len(di.Methods), len(ests))
+	for i, m := range di.Methods {
+		switch m.ID {
+		case "Sum":
+			require.Empty(t, ests[i].Dynamic)
+			exp, err := fee.Estimate(interop.DefaultBaseExecFee, b.Script[m.Range.Start:m.Range.End+1], core.SyscallPrice)
+			require.NoError(t, err)
+			require.Equal(t, exp.Fee, ests[i].Fee)
+		case "Log":
+			var ops []opcode.Opcode
+			for _, d := range ests[i].Dynamic {
+				require.True(t, d.Offset >= int(m.Range.Start) && d.Offset <= int(m.Range.End))
+				require.Equal(t, d.Op, opcode.Opcode(b.Script[d.Offset]))
+				ops = append(ops, d.Op)
+			}
+			require.Contains(t, ops, opcode.CALL)
+			require.Greater(t, ests[i].Fee, int64(1<<15)*interop.DefaultBaseExecFee) // System.Runtime.Log price.
+		}
+	}
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := compiler.EstimateMethodFees(b.Script[:1], di, interop.DefaultBaseExecFee, core.SyscallPrice)
+		require.Error(t, err)
+	})
+}
@@ -36,7 +36,7 @@ import (
 )
 
 func TestContractHashes(t *testing.T) {
-	cfg := config.ProtocolConfiguration{P2PSigExtensions: true}
+	cfg := config.ProtocolConfiguration{P2PSigExtensions: true, NeoGoExtensions: true}
 	cs := native.NewContracts(cfg)
 	require.Equalf(t, []byte(neo.Hash), cs.NEO.Hash.BytesBE(), "%q", string(cs.NEO.Hash.BytesBE()))
 	require.Equalf(t, []byte(gas.Hash), cs.GAS.Hash.BytesBE(), "%q", string(cs.GAS.Hash.BytesBE()))
@@ -147,7 +147,7 @@ type nativeTestCase struct {
 
 // Here we test that corresponding method does exist, is invoked and correct value is returned.
 func TestNativeHelpersCompile(t *testing.T) {
-	cfg := config.ProtocolConfiguration{P2PSigExtensions: true}
+	cfg := config.ProtocolConfiguration{P2PSigExtensions: true, NeoGoExtensions: true}
 	cs := native.NewContracts(cfg)
 	u160 := `interop.Hash160("aaaaaaaaaaaaaaaaaaaa")`
 	u256 := `interop.Hash256("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")`
@@ -176,8 +176,11 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"unclaimedGas", []string{u160, "123"}},
 		{"unregisterCandidate", []string{pub}},
 		{"getAccountState", []string{u160}},
+		{"multiTransfer", []string{"[]neo.TransferEntry{}"}},
+	}, nep17TestCases...))
+	runNativeTestCases(t, cs.GAS.ContractMD, "gas", append([]nativeTestCase{
+		{"multiTransfer", []string{"[]gas.TransferEntry{}"}},
 	}, nep17TestCases...))
-	runNativeTestCases(t, cs.GAS.ContractMD, "gas", nep17TestCases)
 	runNativeTestCases(t, cs.Oracle.ContractMD, "oracle", []nativeTestCase{
 		{"getPrice", nil},
 		{"request", []string{`"url"`, "nil", `"callback"`, "nil", "123"}},
@@ -186,6 +189,7 @@ func TestNativeHelpersCompile(t *testing.T) {
 	runNativeTestCases(t, cs.Designate.ContractMD, "roles", []nativeTestCase{
 		{"designateAsRole", []string{"1", "[]interop.PublicKey{}"}},
 		{"getDesignatedByRole", []string{"1", "1000"}},
+		{"getDesignationHeight", []string{"1", "1000"}},
 	})
 	runNativeTestCases(t, cs.Policy.ContractMD, "policy", []nativeTestCase{
 		{"blockAccount", []string{u160}},
@@ -199,6 +203,9 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"unblockAccount", []string{u160}},
 		{"getAttributeFee", []string{"1"}},
 		{"setAttributeFee", []string{"1", "123"}},
+		{"getFeatureFlag", []string{`"flag"`}},
+		{"setFeatureFlag", []string{`"flag"`, "1"}},
+		{"deleteFeatureFlag", []string{`"flag"`}},
 	})
 	runNativeTestCases(t, cs.Ledger.ContractMD, "ledger", []nativeTestCase{
 		{"currentHash", nil},
@@ -217,6 +224,8 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"expirationOf", []string{u160}},
 		{"getMaxNotValidBeforeDelta", nil},
 		{"setMaxNotValidBeforeDelta", []string{"42"}},
+		{"getNotaryServiceFeePerKey", nil},
+		{"setNotaryServiceFeePerKey", []string{"42"}},
 	})
 	runNativeTestCases(t, cs.Management.ContractMD, "management", []nativeTestCase{
 		{"deploy", []string{"nil", "nil"}},
@@ -236,6 +245,7 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"ripemd160", []string{"[]byte{1, 2, 3}"}},
 		{"murmur32", []string{"[]byte{1, 2, 3}", "123"}},
 		{"verifyWithECDsa", []string{"[]byte{1, 2, 3}", pub, sig, "crypto.Secp256k1Sha256"}},
+		{"verifyWithECDsaHasher", []string{"[]byte{1, 2, 3}", pub, sig, "crypto.Secp256k1", "crypto.HasherSha256"}},
 		{"bls12381Serialize", []string{"crypto.Bls12381Point{}"}},
 		{"bls12381Deserialize", []string{"[]byte{1, 2, 3}"}},
 		{"bls12381Equal", []string{"crypto.Bls12381Point{}", "crypto.Bls12381Point{}"}},
@@ -243,6 +253,9 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"bls12381Mul", []string{"crypto.Bls12381Point{}", "[]byte{1, 2, 3}", "true"}},
 		{"bls12381Pairing", []string{"crypto.Bls12381Point{}", "crypto.Bls12381Point{}"}},
 		{"keccak256", []string{"[]byte{1, 2, 3}"}},
+		{"bls12381IsOnCurve", []string{"[]byte{1, 2, 3}"}},
+		{"bls12381IsInSubgroup", []string{"[]byte{1, 2, 3}"}},
+		{"vrfVerify", []string{"[]byte{1, 2, 3}", pub, "[]byte{1, 2, 3}"}},
 	})
 	runNativeTestCases(t, cs.Std.ContractMD, "std", []nativeTestCase{
 		{"serialize", []string{"[]byte{1, 2, 3}"}},
@@ -265,6 +278,14 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"memorySearchLastIndex", []string{"[]byte{1}", "[]byte{2}", "3"}},
 		{"stringSplit", []string{`"a,b"`, `","`}},
 		{"stringSplitNonEmpty", []string{`"a,b"`, `","`}},
+		{"timestampToDays", []string{"123"}},
+		{"weekday", []string{"123"}},
+		{"isLeapYear", []string{"2024"}},
+		{"addDays", []string{"123", "1"}},
+		{"addMonths", []string{"123", "1"}},
+		{"addYears", []string{"123", "1"}},
+		{"cborSerialize", []string{"[]byte{1, 2, 3}"}},
+		{"cborDeserialize", []string{"[]byte{1, 2, 3}"}},
 	})
 }
 
@@ -306,6 +327,8 @@ func getMethod(t *testing.T, ctr interop.ContractMD, name string, params []strin
 			paramLen++ // true should be appended inside of an interop
 		}
 		name = "stringSplit"
+	case name == "verifyWithECDsaHasher":
+		name = "verifyWithECDsa"
 	default:
 		name = strings.TrimSuffix(name, "WithData")
 	}
@@ -330,6 +353,7 @@ func addNativeTestCase(t *testing.T, srcBuilder *bytes.Buffer, ctr interop.Contr
 	methodUpper = strings.ReplaceAll(methodUpper, "Gas", "GAS")
 	methodUpper = strings.ReplaceAll(methodUpper, "Json", "JSON")
 	methodUpper = strings.ReplaceAll(methodUpper, "Id", "ID")
+	methodUpper = strings.ReplaceAll(methodUpper, "Cbor", "CBOR")
 	srcBuilder.WriteString(name)
 	srcBuilder.WriteRune('.')
 	srcBuilder.WriteString(methodUpper)
@@ -0,0 +1,225 @@
+package compiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+// Optimization levels that can be specified in Options.Optimize. Unused
+// functions and global variables are removed at any level, higher levels
+// include all optimizations of the lower ones and allow more of them to be
+// removed.
+const (
+	// OptimizeNone disables additional AST-level optimizations.
+	OptimizeNone = iota
+	// OptimizeDeadCode removes branches of if statements that can never be
+	// executed because their condition is a compile-time constant.
+	OptimizeDeadCode
+	// OptimizeConstants replaces calls of functions that always return a
+	// compile-time constant with this constant before dead code elimination,
+	// so such functions can be removed and conditions depending on them can
+	// be evaluated at compile time.
+	OptimizeConstants
+	// OptimizeSwitches compiles switch statements with many integer constant
+	// cases into a binary search over case values instead of sequential
+	// comparisons, which makes dispatching cheaper for large switches.
+	OptimizeSwitches
+)
+
+// minDispatchSwitchCases is the minimum number of case values a switch
+// statement must have to be compiled into a binary search. Sequential
+// comparisons are cheaper for smaller switches.
+const minDispatchSwitchCases = 8
+
+// optimize rewrites AST of all packages according to the given optimization
+// level. It must be performed before function usage analysis.
+func (c *codegen) optimize(level int) {
+	if level >= OptimizeConstants {
+		consts := c.collectConstFuncs()
+		if len(consts) != 0 {
+			c.forEachSyntax(func(f *ast.File, pkg *packages.Package) {
+				astutil.Apply(f, nil, func(cur *astutil.Cursor) bool {
+					call, ok := cur.Node().(*ast.CallExpr)
+					if !ok {
+						return true
+					}
+					if lit := propagateConstCall(call, pkg.TypesInfo, consts); lit != nil {
+						cur.Replace(lit)
+					}
+					return true
+				})
+			})
+		}
+	}
+	if level >= OptimizeDeadCode {
+		c.forEachSyntax(func(f *ast.File, pkg *packages.Package) {
+			astutil.Apply(f, nil, func(cur *astutil.Cursor) bool {
+				stmt, ok := cur.Node().(*ast.IfStmt)
+				if !ok {
+					return true
+				}
+				if block := eliminateDeadBranch(stmt, pkg.TypesInfo); block != nil {
+					cur.Replace(block)
+				}
+				return true
+			})
+		})
+	}
+	if level >= OptimizeSwitches {
+		c.dispatchSwitches = make(map[*ast.SwitchStmt]bool)
+		c.forEachSyntax(func(f *ast.File, pkg *packages.Package) {
+			ast.Inspect(f, func(n ast.Node) bool {
+				if stmt, ok := n.(*ast.SwitchStmt); ok && isDispatchSwitch(stmt, pkg.TypesInfo) {
+					c.dispatchSwitches[stmt] = true
+				}
+				return true
+			})
+		})
+	}
+}
+
+// forEachSyntax executes fn for every non-interop file of the program.
+func (c *codegen) forEachSyntax(fn func(*ast.File, *packages.Package)) {
+	c.ForEachPackage(func(pkg *packages.Package) {
+		if isInteropPath(pkg.PkgPath) {
+			return
+		}
+		for _, f := range pkg.Syntax {
+			fn(f, pkg)
+		}
+	})
+}
+
+// collectConstFuncs returns all non-method functions with a single
+// non-interface result consisting of a single return statement with a
+// constant value.
+func (c *codegen) collectConstFuncs() map[*types.Func]constant.Value {
+	var res = make(map[*types.Func]constant.Value)
+	c.forEachSyntax(func(f *ast.File, pkg *packages.Package) {
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv != nil || fd.Body == nil || len(fd.Body.List) != 1 ||
+				fd.Type.Results.NumFields() != 1 || isInitFunc(fd) || isDeployFunc(fd) {
+				continue
+			}
+			ret, ok := fd.Body.List[0].(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				continue
+			}
+			tv, ok := pkg.TypesInfo.Types[ret.Results[0]]
+			if !ok || tv.Value == nil {
+				continue
+			}
+			fun, ok := pkg.TypesInfo.Defs[fd.Name].(*types.Func)
+			if !ok || types.IsInterface(fun.Type().(*types.Signature).Results().At(0).Type()) {
+				continue
+			}
+			res[fun] = tv.Value
+		}
+	})
+	return res
+}
+
+// propagateConstCall returns a constant expression that can replace the call
+// or nil if the call can't be replaced.
+func propagateConstCall(call *ast.CallExpr, info *types.Info, consts map[*types.Func]constant.Value) ast.Expr {
+	var ident *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	fun, ok := info.Uses[ident].(*types.Func)
+	if !ok {
+		return nil
+	}
+	val, ok := consts[fun]
+	if !ok {
+		return nil
+	}
+	// Arguments are not evaluated after replacement, so they must have no
+	// side effects.
+	for _, arg := range call.Args {
+		if _, ok := arg.(*ast.Ident); ok {
+			continue
+		}
+		if tv, ok := info.Types[arg]; !ok || tv.Value == nil {
+			return nil
+		}
+	}
+	var expr ast.Expr
+	if val.Kind() == constant.Bool {
+		expr = &ast.Ident{NamePos: call.Pos(), Name: val.ExactString()}
+	} else {
+		var kind = token.INT
+		switch val.Kind() {
+		case constant.String:
+			kind = token.STRING
+		case constant.Float:
+			kind = token.FLOAT
+		}
+		expr = &ast.BasicLit{ValuePos: call.Pos(), Kind: kind, Value: val.ExactString()}
+	}
+	info.Types[expr] = types.TypeAndValue{
+		Type:  info.Types[call].Type,
+		Value: val,
+	}
+	return expr
+}
+
+// eliminateDeadBranch returns a block that can replace the if statement with
+// a constant condition or nil if the condition is not constant.
+func eliminateDeadBranch(stmt *ast.IfStmt, info *types.Info) ast.Stmt {
+	tv, ok := info.Types[stmt.Cond]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Bool {
+		return nil
+	}
+	var (
+		block = &ast.BlockStmt{Lbrace: stmt.Pos(), Rbrace: stmt.End()}
+		taken ast.Stmt
+	)
+	if constant.BoolVal(tv.Value) {
+		taken = stmt.Body
+	} else if stmt.Else != nil {
+		taken = stmt.Else
+	}
+	if stmt.Init != nil {
+		block.List = append(block.List, stmt.Init)
+	}
+	if taken != nil {
+		block.List = append(block.List, taken)
+	}
+	return block
+}
+
+// isDispatchSwitch checks whether the switch statement can be compiled into a
+// binary search, that is it has an integer tag and at least
+// minDispatchSwitchCases constant case values.
+func isDispatchSwitch(stmt *ast.SwitchStmt, info *types.Info) bool {
+	if stmt.Tag == nil {
+		return false
+	}
+	typ, ok := info.TypeOf(stmt.Tag).Underlying().(*types.Basic)
+	if !ok || typ.Info()&types.IsInteger == 0 {
+		return false
+	}
+	var count int
+	for _, cl := range stmt.Body.List {
+		for _, e := range cl.(*ast.CaseClause).List {
+			tv, ok := info.Types[e]
+			if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+				return false
+			}
+			count++
+		}
+	}
+	return count >= minDispatchSwitchCases
+}
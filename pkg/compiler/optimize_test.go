@@ -0,0 +1,148 @@
+package compiler_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func evalOptimized(t *testing.T, src string, level int, result any) []byte {
+	v := vm.New()
+	v.GasLimit = -1
+	b, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), &compiler.Options{Optimize: level})
+	require.NoError(t, err)
+	invokeMethod(t, testMainIdent, b.Script, v, di)
+	runAndCheck(t, v, result)
+	return b.Script
+}
+
+func TestOptimizeDeadCode(t *testing.T) {
+	src := `package foo
+	const debug = false
+	func expensive(a int) int {
+		return a*a*a + a*a + a
+	}
+	func Main() int {
+		a := 3
+		if debug {
+			a = expensive(a)
+		} else if !debug {
+			a += 1
+		} else {
+			a = expensive(a + 1)
+		}
+		if x := a * 2; !debug {
+			a = x
+		}
+		return a
+	}`
+	eval(t, src, big.NewInt(8))
+	unoptimized := evalOptimized(t, src, compiler.OptimizeNone, big.NewInt(8))
+	optimized := evalOptimized(t, src, compiler.OptimizeDeadCode, big.NewInt(8))
+	require.Less(t, len(optimized), len(unoptimized))
+
+	_, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), &compiler.Options{Optimize: compiler.OptimizeDeadCode})
+	require.NoError(t, err)
+	for _, m := range di.Methods {
+		require.NotEqual(t, "expensive", m.Name.Name)
+	}
+}
+
+func TestOptimizeConstants(t *testing.T) {
+	src := `package foo
+	func isEnabled() bool {
+		return false
+	}
+	func base(x int) int {
+		return 40
+	}
+	func name() string {
+		return "neo"
+	}
+	func sideEffect(a []int) int {
+		a[0] = 1
+		return 0
+	}
+	func Main() int {
+		a := []int{0}
+		b := base(1) + base(sideEffect(a)) + len(name())
+		if isEnabled() {
+			b = 0
+		}
+		return b + a[0]
+	}`
+	eval(t, src, big.NewInt(84))
+	deadOnly := evalOptimized(t, src, compiler.OptimizeDeadCode, big.NewInt(84))
+	optimized := evalOptimized(t, src, compiler.OptimizeConstants, big.NewInt(84))
+	require.Less(t, len(optimized), len(deadOnly))
+}
+
+func TestOptimizeSwitches(t *testing.T) {
+	src := `package foo
+	const (
+		methodA = iota + 10
+		methodB
+		methodC
+	)
+	func Main(x int) int {
+		r := 0
+		switch x {
+		case methodA, methodB:
+			r = 1
+		case methodC:
+			r = 2
+			fallthrough
+		case 1:
+			r += 3
+		case 2, 3, 4:
+			if x == 3 {
+				break
+			}
+			r = 5
+		case -7:
+			r = 6
+		case 100, 200, 300, 1000:
+			r = x / 100
+		default:
+			r = -1
+		}
+		return r
+	}`
+	compile := func(level int) ([]byte, *compiler.DebugInfo) {
+		b, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), &compiler.Options{Optimize: level})
+		require.NoError(t, err)
+		return b.Script, di
+	}
+	run := func(t *testing.T, script []byte, di *compiler.DebugInfo, x int) (*big.Int, int64) {
+		v := vm.New()
+		v.GasLimit = -1
+		// Count executed instructions.
+		v.SetPriceGetter(func(opcode.Opcode, []byte) int64 { return 1 })
+		invokeMethod(t, testMainIdent, script, v, di)
+		v.Estack().PushVal(x)
+		require.NoError(t, v.Run())
+		require.Equal(t, 1, v.Estack().Len())
+		return v.Estack().Pop().BigInt(), v.GasConsumed()
+	}
+	expected := map[int]int64{10: 1, 11: 1, 12: 5, 1: 3, 2: 5, 3: 0, 4: 5, -7: 6, 100: 1, 200: 2, 300: 3, 1000: 10}
+	linear, linearDI := compile(compiler.OptimizeConstants)
+	dispatch, dispatchDI := compile(compiler.OptimizeSwitches)
+	for _, x := range []int{-8, -7, 0, 1, 2, 3, 4, 5, 9, 10, 11, 12, 13, 99, 100, 200, 300, 1000, 1001} {
+		exp, ok := expected[x]
+		if !ok {
+			exp = -1
+		}
+		r, linearSteps := run(t, linear, linearDI, x)
+		require.Equal(t, exp, r.Int64(), x)
+		r, dispatchSteps := run(t, dispatch, dispatchDI, x)
+		require.Equal(t, exp, r.Int64(), x)
+		if x == 1000 {
+			require.Less(t, dispatchSteps, linearSteps)
+		}
+	}
+}
@@ -239,6 +239,55 @@ func TestSyscallInGlobalInit(t *testing.T) {
 	require.Equal(t, true, v.Estack().Pop().Value())
 }
 
+func TestAsm(t *testing.T) {
+	t.Run("arguments", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() int {
+			a, b := 50, 8
+			return neogointernal.Asm("SUB; PUSHINT8 0x0a\nSUB ; ABS", b, a).(int)
+		}`
+		eval(t, src, big.NewInt(52))
+	})
+	t.Run("quoted separators", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() []byte {
+			return neogointernal.Asm(` + "`" + `PUSHDATA1 "a;b\nc"; PUSHDATA1 ";"; CAT` + "`" + `).([]byte)
+		}`
+		eval(t, src, []byte("a;b\nc;"))
+	})
+	t.Run("no return", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() int {
+			a := 42
+			neogointernal.AsmNoReturn("PUSH1; DROP; DROP", a)
+			return a
+		}`
+		eval(t, src, big.NewInt(42))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() int {
+			return neogointernal.Asm("PUSHDATA1 \"a;b").(int)
+		}`
+		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+		require.ErrorContains(t, err, "unterminated string")
+	})
+	t.Run("slice arguments", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() int {
+			args := []any{1, 2}
+			return neogointernal.Asm("ADD", args...).(int)
+		}`
+		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+		require.ErrorContains(t, err, "can't be passed as a slice")
+	})
+}
+
 func TestOpcode(t *testing.T) {
 	t.Run("1 argument", func(t *testing.T) {
 		src := `package foo
@@ -1,10 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
 )
@@ -18,8 +20,12 @@ type ApplicationConfiguration struct {
 	LogLevel string `yaml:"LogLevel"`
 	LogPath  string `yaml:"LogPath"`
 
+	// ShutdownTimeout is the time given to every node service to stop.
+	ShutdownTimeout time.Duration `yaml:"ShutdownTimeout"`
+
 	P2P P2P `yaml:"P2P"`
 
+	Health     BasicService `yaml:"Health"`
 	Pprof      BasicService `yaml:"Pprof"`
 	Prometheus BasicService `yaml:"Prometheus"`
 
@@ -30,11 +36,12 @@ type ApplicationConfiguration struct {
 	P2PNotary         P2PNotary           `yaml:"P2PNotary"`
 	StateRoot         StateRoot           `yaml:"StateRoot"`
 	NeoFSBlockFetcher NeoFSBlockFetcher   `yaml:"NeoFSBlockFetcher"`
+	Webhook           Webhook             `yaml:"Webhook"`
 }
 
 // EqualsButServices returns true when the o is the same as a except for services
-// (Oracle, P2PNotary, Pprof, Prometheus, RPC and StateRoot sections)
-// and LogLevel field.
+// (Health, Oracle, P2PNotary, Pprof, Prometheus, RPC, StateRoot and Webhook
+// sections) and LogLevel field.
 func (a *ApplicationConfiguration) EqualsButServices(o *ApplicationConfiguration) bool {
 	if len(a.P2P.Addresses) != len(o.P2P.Addresses) {
 		return false
@@ -147,8 +154,32 @@ func (a *ApplicationConfiguration) GetAddresses() ([]AnnounceableAddress, error)
 // an error if any invalid settings are found. This ensures that the application
 // configuration is valid and safe to use for further operations.
 func (a *ApplicationConfiguration) Validate() error {
+	if err := a.P2P.NeoFSSeeds.Validate(); err != nil {
+		return fmt.Errorf("invalid NeoFSSeeds config: %w", err)
+	}
 	if err := a.NeoFSBlockFetcher.Validate(); err != nil {
 		return fmt.Errorf("invalid NeoFSBlockFetcher config: %w", err)
 	}
+	if err := a.RPC.Validate(); err != nil {
+		return fmt.Errorf("invalid RPC config: %w", err)
+	}
+	if err := a.Webhook.Validate(); err != nil {
+		return fmt.Errorf("invalid Webhook config: %w", err)
+	}
+	if err := a.Oracle.UnlockWallet.Validate(); err != nil {
+		return fmt.Errorf("invalid Oracle wallet config: %w", err)
+	}
+	if err := a.P2PNotary.Validate(); err != nil {
+		return fmt.Errorf("invalid P2PNotary config: %w", err)
+	}
+	if err := a.StateRoot.UnlockWallet.Validate(); err != nil {
+		return fmt.Errorf("invalid StateRoot wallet config: %w", err)
+	}
+	if a.Consensus.UnlockWallet.Keystore != "" {
+		return errors.New("invalid Consensus wallet config: Keystore is not supported")
+	}
+	if a.NeoFSBlockFetcher.UnlockWallet.Keystore != "" {
+		return errors.New("invalid NeoFSBlockFetcher wallet config: Keystore is not supported")
+	}
 	return nil
 }
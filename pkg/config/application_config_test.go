@@ -206,6 +206,19 @@ func TestNeoFSBlockFetcherValidation(t *testing.T) {
 			shouldFail: true,
 			errMsg:     "BQueueSize (5) is lower than OIDBatchSize (10)",
 		},
+		{
+			cfg: NeoFSBlockFetcher{
+				InternalService:    InternalService{Enabled: true},
+				Timeout:            time.Second,
+				ContainerID:        validContainerID,
+				Addresses:          []string{"127.0.0.1"},
+				OIDBatchSize:       10,
+				BQueueSize:         20,
+				BQueueOverflowSize: -1,
+			},
+			shouldFail: true,
+			errMsg:     "negative BQueueOverflowSize (-1)",
+		},
 		{
 			cfg: NeoFSBlockFetcher{
 				InternalService:      InternalService{Enabled: true},
@@ -220,6 +233,49 @@ func TestNeoFSBlockFetcherValidation(t *testing.T) {
 			shouldFail: true,
 			errMsg:     "IndexFileSize is not set",
 		},
+		{
+			cfg: NeoFSBlockFetcher{
+				InternalService:      InternalService{Enabled: true},
+				Timeout:              time.Second,
+				ContainerID:          validContainerID,
+				Addresses:            []string{"127.0.0.1"},
+				OIDBatchSize:         10,
+				BQueueSize:           20,
+				SkipIndexFilesSearch: true,
+				RetryBackoff:         time.Minute,
+				RetryMaxBackoff:      time.Second,
+			},
+			shouldFail: true,
+			errMsg:     "RetryMaxBackoff (1s) is lower than RetryBackoff (1m0s)",
+		},
+		{
+			cfg: NeoFSBlockFetcher{
+				InternalService:      InternalService{Enabled: true},
+				Timeout:              time.Second,
+				ContainerID:          validContainerID,
+				Addresses:            []string{"127.0.0.1"},
+				OIDBatchSize:         10,
+				BQueueSize:           20,
+				SkipIndexFilesSearch: true,
+				MaxBytesPerSecond:    -1,
+			},
+			shouldFail: true,
+			errMsg:     "negative MaxBytesPerSecond (-1)",
+		},
+		{
+			cfg: NeoFSBlockFetcher{
+				InternalService:      InternalService{Enabled: true},
+				Timeout:              time.Second,
+				ContainerID:          validContainerID,
+				Addresses:            []string{"127.0.0.1"},
+				OIDBatchSize:         10,
+				BQueueSize:           20,
+				SkipIndexFilesSearch: true,
+				MaxObjectsPerSecond:  -1,
+			},
+			shouldFail: true,
+			errMsg:     "negative MaxObjectsPerSecond (-1)",
+		},
 	}
 
 	for _, c := range cases {
@@ -232,3 +288,86 @@ func TestNeoFSBlockFetcherValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestWallet_Validate(t *testing.T) {
+	cases := []struct {
+		cfg    Wallet
+		errMsg string
+	}{
+		{cfg: Wallet{}},
+		{cfg: Wallet{Path: "wallet.json", Password: "pass"}},
+		{cfg: Wallet{Keystore: "keys", Passwords: map[string]string{"addr": "pass"}}},
+		{
+			cfg:    Wallet{Path: "wallet.json", Keystore: "keys"},
+			errMsg: "Path and Keystore can't be specified simultaneously",
+		},
+		{
+			cfg:    Wallet{Path: "wallet.json", Passwords: map[string]string{"addr": "pass"}},
+			errMsg: "Passwords can only be used with Keystore",
+		},
+	}
+	for _, c := range cases {
+		err := c.cfg.Validate()
+		if c.errMsg != "" {
+			require.ErrorContains(t, err, c.errMsg)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestRPC_Validate(t *testing.T) {
+	require.NoError(t, (&RPC{}).Validate())
+	require.NoError(t, (&RPC{CORS: []CORSRule{
+		{Origins: []string{"https://dapp.example.com", "http://localhost:3000"}, AllowCredentials: true},
+		{Origins: []string{"*"}},
+	}}).Validate())
+	require.ErrorContains(t, (&RPC{CORS: []CORSRule{{}}}).Validate(), "no origins configured")
+	require.ErrorContains(t, (&RPC{CORS: []CORSRule{{Origins: []string{"*"}, MaxAge: -1}}}).Validate(), "negative MaxAge")
+	require.ErrorContains(t, (&RPC{CORS: []CORSRule{{Origins: []string{"*"}, AllowCredentials: true}}}).Validate(),
+		"credentials can't be allowed for any origin")
+	for _, o := range []string{"dapp.example.com", "ftp://dapp.example.com", "https://dapp.example.com/app", "https://"} {
+		require.ErrorContains(t, (&RPC{CORS: []CORSRule{{Origins: []string{o}}}}).Validate(), "invalid origin", o)
+	}
+}
+
+func TestP2PNotary_Validate(t *testing.T) {
+	require.NoError(t, (&P2PNotary{MaxRequestsPerSponsor: 10}).Validate())
+	require.ErrorContains(t, (&P2PNotary{MaxRequestsPerSponsor: -1}).Validate(), "negative MaxRequestsPerSponsor")
+	require.ErrorContains(t, (&P2PNotary{UnlockWallet: Wallet{Path: "wallet.json", Keystore: "keys"}}).Validate(),
+		"Path and Keystore can't be specified simultaneously")
+}
+
+func TestNeoFSSeeds_Validate(t *testing.T) {
+	const pub = "02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e"
+	require.NoError(t, (&NeoFSSeeds{}).Validate())
+	require.NoError(t, (&NeoFSSeeds{Object: "neofs:cid/oid", Addresses: []string{"st1"}, PublicKey: pub}).Validate())
+	require.ErrorContains(t, (&NeoFSSeeds{Object: "neofs:cid/oid", PublicKey: pub}).Validate(), "addresses are not set")
+	require.ErrorContains(t, (&NeoFSSeeds{Object: "neofs:cid/oid", Addresses: []string{"st1"}}).Validate(), "invalid public key")
+	require.ErrorContains(t, (&NeoFSSeeds{Object: "neofs:cid/oid", Addresses: []string{"st1"}, PublicKey: pub, MaxAge: -1}).Validate(), "negative MaxAge")
+}
+
+func TestWallet_GetPassword(t *testing.T) {
+	w := Wallet{
+		Keystore:  "keys",
+		Passwords: map[string]string{"first": "one"},
+	}
+	t.Setenv(WalletPasswordEnvPrefix+"first", "env")
+	t.Setenv(WalletPasswordEnvPrefix+"second", "two")
+
+	pass, ok := w.GetPassword("first")
+	require.True(t, ok)
+	require.Equal(t, "one", pass)
+
+	pass, ok = w.GetPassword("second")
+	require.True(t, ok)
+	require.Equal(t, "two", pass)
+
+	_, ok = w.GetPassword("third")
+	require.False(t, ok)
+
+	w.Password = "default"
+	pass, ok = w.GetPassword("third")
+	require.True(t, ok)
+	require.Equal(t, "default", pass)
+}
@@ -11,6 +11,7 @@ import (
 // NeoFSBlockFetcher represents the configuration for the NeoFS BlockFetcher service.
 type NeoFSBlockFetcher struct {
 	InternalService        `yaml:",inline"`
+	NeoFSTokens            `yaml:",inline"`
 	Timeout                time.Duration `yaml:"Timeout"`
 	ContainerID            string        `yaml:"ContainerID"`
 	Addresses              []string      `yaml:"Addresses"`
@@ -19,8 +20,15 @@ type NeoFSBlockFetcher struct {
 	IndexFileAttribute     string        `yaml:"IndexFileAttribute"`
 	DownloaderWorkersCount int           `yaml:"DownloaderWorkersCount"`
 	BQueueSize             int           `yaml:"BQueueSize"`
+	BQueueOverflowSize     int           `yaml:"BQueueOverflowSize"`
+	BQueueOverflowPath     string        `yaml:"BQueueOverflowPath"`
 	SkipIndexFilesSearch   bool          `yaml:"SkipIndexFilesSearch"`
 	IndexFileSize          uint32        `yaml:"IndexFileSize"`
+	RetryAttempts          int           `yaml:"RetryAttempts"`
+	RetryBackoff           time.Duration `yaml:"RetryBackoff"`
+	RetryMaxBackoff        time.Duration `yaml:"RetryMaxBackoff"`
+	MaxBytesPerSecond      int64         `yaml:"MaxBytesPerSecond"`
+	MaxObjectsPerSecond    int           `yaml:"MaxObjectsPerSecond"`
 }
 
 // Validate checks NeoFSBlockFetcher for internal consistency and ensures
@@ -41,11 +49,23 @@ func (cfg *NeoFSBlockFetcher) Validate() error {
 	if cfg.BQueueSize < cfg.OIDBatchSize {
 		return fmt.Errorf("BQueueSize (%d) is lower than OIDBatchSize (%d)", cfg.BQueueSize, cfg.OIDBatchSize)
 	}
+	if cfg.BQueueOverflowSize < 0 {
+		return fmt.Errorf("negative BQueueOverflowSize (%d)", cfg.BQueueOverflowSize)
+	}
 	if len(cfg.Addresses) == 0 {
 		return errors.New("addresses are not set")
 	}
 	if !cfg.SkipIndexFilesSearch && cfg.IndexFileSize == 0 {
 		return errors.New("IndexFileSize is not set")
 	}
+	if cfg.RetryMaxBackoff != 0 && cfg.RetryMaxBackoff < cfg.RetryBackoff {
+		return fmt.Errorf("RetryMaxBackoff (%s) is lower than RetryBackoff (%s)", cfg.RetryMaxBackoff, cfg.RetryBackoff)
+	}
+	if cfg.MaxBytesPerSecond < 0 {
+		return fmt.Errorf("negative MaxBytesPerSecond (%d)", cfg.MaxBytesPerSecond)
+	}
+	if cfg.MaxObjectsPerSecond < 0 {
+		return fmt.Errorf("negative MaxObjectsPerSecond (%d)", cfg.MaxObjectsPerSecond)
+	}
 	return nil
 }
@@ -49,6 +49,10 @@ var Version string
 // Config top level struct representing the config
 // for the node.
 type Config struct {
+	// Preset is the name of the built-in network configuration (see Presets)
+	// used as a base for this one, settings given in the file override the
+	// preset ones.
+	Preset                   string                   `yaml:"Preset,omitempty"`
 	ProtocolConfiguration    ProtocolConfiguration    `yaml:"ProtocolConfiguration"`
 	ApplicationConfiguration ApplicationConfiguration `yaml:"ApplicationConfiguration"`
 }
@@ -77,7 +81,10 @@ func Load(path string, netMode netmode.Magic, relativePath ...string) (Config, e
 
 // LoadFile loads config from the provided path. It also applies backwards compatibility
 // fixups if necessary. If relativePath is not empty, relative paths in the config will
-// be updated based on the provided relative path.
+// be updated based on the provided relative path. If the config has Preset set, it's
+// applied as an overlay over the preset: scalar settings replace preset values,
+// lists replace preset lists completely and maps (like Hardforks) are merged
+// with preset ones.
 func LoadFile(configPath string, relativePath ...string) (Config, error) {
 	var (
 		configData []byte
@@ -102,9 +109,24 @@ func LoadFile(configPath string, relativePath ...string) (Config, error) {
 			},
 		},
 	}
-	decoder := yaml.NewDecoder(bytes.NewReader(configData))
-	decoder.KnownFields(true)
-	err = decoder.Decode(&config)
+	var base struct {
+		Preset string `yaml:"Preset"`
+	}
+	err = yaml.Unmarshal(configData, &base)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to unmarshal config YAML: %w", err)
+	}
+	if base.Preset != "" {
+		presetData, err := getPreset(base.Preset)
+		if err != nil {
+			return Config{}, err
+		}
+		err = decodeYAML(presetData, &config)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to unmarshal preset %s: %w", base.Preset, err)
+		}
+	}
+	err = decodeYAML(configData, &config)
 	if err != nil {
 		return Config{}, fmt.Errorf("failed to unmarshal config YAML: %w", err)
 	}
@@ -124,6 +146,14 @@ func LoadFile(configPath string, relativePath ...string) (Config, error) {
 	return config, nil
 }
 
+// decodeYAML decodes configuration data into the given config, unknown fields
+// are not allowed and settings missing from the data are left untouched.
+func decodeYAML(data []byte, config *Config) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	return decoder.Decode(config)
+}
+
 // getEmbeddedConfig returns the embedded config based on the provided config path.
 func getEmbeddedConfig(configPath string) ([]byte, error) {
 	switch configPath {
@@ -157,4 +187,7 @@ func updateRelativePaths(relativePath string, config *Config) {
 	updatePath(&config.ApplicationConfiguration.P2PNotary.UnlockWallet.Path)
 	updatePath(&config.ApplicationConfiguration.Oracle.UnlockWallet.Path)
 	updatePath(&config.ApplicationConfiguration.StateRoot.UnlockWallet.Path)
+	updatePath(&config.ApplicationConfiguration.P2PNotary.UnlockWallet.Keystore)
+	updatePath(&config.ApplicationConfiguration.Oracle.UnlockWallet.Keystore)
+	updatePath(&config.ApplicationConfiguration.StateRoot.UnlockWallet.Keystore)
 }
@@ -54,3 +54,67 @@ func TestLoadFileWithMissingDefaultConfigPath(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "doesn't exist and no matching embedded config was found")
 }
+
+func TestLoadFileWithPreset(t *testing.T) {
+	tmp := t.TempDir()
+	cfgPath := filepath.Join(tmp, "protocol.yml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(`Preset: mainnet
+ProtocolConfiguration:
+  Hardforks:
+    Echidna: 10000000
+ApplicationConfiguration:
+  DBConfiguration:
+    Type: inmemory
+  P2P:
+    Addresses:
+      - ":20333"
+`), os.ModePerm))
+
+	cfg, err := LoadFile(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, "mainnet", cfg.Preset)
+	require.Equal(t, netmode.MainNet, cfg.ProtocolConfiguration.Magic)
+	require.Equal(t, uint32(7), cfg.ProtocolConfiguration.ValidatorsCount)
+	require.Equal(t, map[string]uint32{
+		"Aspidochelone": 1730000,
+		"Basilisk":      4120000,
+		"Cockatrice":    5450000,
+		"Domovoi":       5570000,
+		"Echidna":       10000000,
+	}, cfg.ProtocolConfiguration.Hardforks)
+	require.Equal(t, "inmemory", cfg.ApplicationConfiguration.DBConfiguration.Type)
+	require.Equal(t, "./chains/mainnet", cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath)
+	require.Equal(t, []string{":20333"}, cfg.ApplicationConfiguration.P2P.Addresses)
+	require.Equal(t, 100, cfg.ApplicationConfiguration.P2P.MaxPeers)
+
+	t.Run("preset only", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(cfgPath, []byte(`Preset: testnet`), os.ModePerm))
+		cfg, err := LoadFile(cfgPath)
+		require.NoError(t, err)
+		expected, err := LoadFile(fmt.Sprintf("%s/protocol.%s.yml", DefaultConfigPath, netmode.TestNet))
+		require.NoError(t, err)
+		expected.Preset = "testnet"
+		require.Equal(t, expected, cfg)
+	})
+	t.Run("unknown preset", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(cfgPath, []byte(`Preset: unknown`), os.ModePerm))
+		_, err := LoadFile(cfgPath)
+		require.ErrorContains(t, err, `unknown preset "unknown"`)
+	})
+	t.Run("unknown field", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(cfgPath, []byte("Preset: privnet\nUnknownConfigurationField: 123"), os.ModePerm))
+		_, err := LoadFile(cfgPath)
+		require.ErrorContains(t, err, "field UnknownConfigurationField not found")
+	})
+}
+
+func TestPresets(t *testing.T) {
+	require.Equal(t, []string{"mainnet", "neofs-mainnet", "neofs-testnet", "privnet", "testnet"}, Presets())
+	for _, name := range Presets() {
+		var cfg Config
+		data, err := getPreset(name)
+		require.NoError(t, err)
+		require.NoError(t, decodeYAML(data, &cfg), name)
+		require.Empty(t, cfg.Preset, name)
+	}
+}
@@ -4,6 +4,21 @@ package config
 // a part of the ProtocolConfiguration (which is common for every node on the
 // network).
 type Ledger struct {
+	// AppLogsMaxBlocks is the number of latest blocks to keep application
+	// execution results for, older ones are removed (transaction VM states are
+	// kept). Zero means logs are never removed.
+	AppLogsMaxBlocks uint32 `yaml:"AppLogsMaxBlocks"`
+	// CompressAppLogs enables zstd compression of the stored application
+	// execution results. This value should remain the same for the same
+	// database.
+	CompressAppLogs bool `yaml:"CompressAppLogs"`
+	// ContractStatsPeriod is the number of blocks per-contract execution
+	// statistics are aggregated for. Zero disables statistics collection.
+	ContractStatsPeriod uint32 `yaml:"ContractStatsPeriod"`
+	// DebugInfoFiles is a list of contract debug information files (as
+	// produced by the compiler) used to resolve FAULTed instruction offsets
+	// into source code locations in application logs and invocation results.
+	DebugInfoFiles []string `yaml:"DebugInfoFiles"`
 	// GarbageCollectionPeriod sets the number of blocks to wait before
 	// starting the next MPT garbage collection cycle when RemoveUntraceableBlocks
 	// option is used.
@@ -16,9 +31,27 @@ type Ledger struct {
 	RemoveUntraceableBlocks bool `yaml:"RemoveUntraceableBlocks"`
 	// SaveStorageBatch enables storage batch saving before every persist.
 	SaveStorageBatch bool `yaml:"SaveStorageBatch"`
+	// StateResetHeight is the height to reset the chain state to on node
+	// start (see Blockchain.Reset). The reset is performed once for every
+	// configured value (the value is stored in the DB), so the node can
+	// continue synchronization after it. Zero disables the reset.
+	StateResetHeight uint32 `yaml:"StateResetHeight"`
 	// SkipBlockVerification allows to disable verification of received
 	// blocks (including cryptographic checks).
 	SkipBlockVerification bool `yaml:"SkipBlockVerification"`
+	// StreamExecutions makes the node send transaction execution results
+	// (and notifications) to subscribers as soon as they're computed during
+	// block processing instead of doing it after the block is stored. If
+	// the block fails to be stored then, a rejected block event is sent.
+	StreamExecutions bool `yaml:"StreamExecutions"`
+	// ValidatorStatsWindow is the number of the latest blocks validators'
+	// block production statistics are tracked for. Zero disables statistics
+	// collection.
+	ValidatorStatsWindow uint32 `yaml:"ValidatorStatsWindow"`
+	// VerificationWorkers is the number of workers used to verify witnesses
+	// of received block transactions concurrently. Zero means the number of
+	// available CPUs, one makes verification sequential.
+	VerificationWorkers int `yaml:"VerificationWorkers"`
 }
 
 // Blockchain is a set of settings for core.Blockchain to use, it includes protocol
@@ -0,0 +1,10 @@
+package config
+
+// NeoFSTokens contains paths to files with bearer and object session tokens
+// used to access NeoFS containers with restricted access rules (extended ACL).
+// Files are reread when modified, so tokens can be renewed without the node
+// restart.
+type NeoFSTokens struct {
+	BearerTokens  []string `yaml:"BearerTokens"`
+	SessionTokens []string `yaml:"SessionTokens"`
+}
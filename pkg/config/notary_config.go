@@ -1,4 +1,21 @@
 package config
 
+import "errors"
+
 // P2PNotary stores configuration for Notary node service.
-type P2PNotary InternalService
+type P2PNotary struct {
+	Enabled      bool   `yaml:"Enabled"`
+	UnlockWallet Wallet `yaml:"UnlockWallet"`
+	// MaxRequestsPerSponsor is the maximum number of pending notary requests
+	// paid by the same sponsor (fallback transaction payer) that are
+	// processed by the service at the same time. Zero means no limit.
+	MaxRequestsPerSponsor int `yaml:"MaxRequestsPerSponsor"`
+}
+
+// Validate checks P2PNotary configuration for internal consistency.
+func (p *P2PNotary) Validate() error {
+	if p.MaxRequestsPerSponsor < 0 {
+		return errors.New("negative MaxRequestsPerSponsor")
+	}
+	return p.UnlockWallet.Validate()
+}
@@ -19,6 +19,7 @@ type OracleConfiguration struct {
 
 // NeoFSConfiguration is a config for the NeoFS service.
 type NeoFSConfiguration struct {
-	Nodes   []string      `yaml:"Nodes"`
-	Timeout time.Duration `yaml:"Timeout"`
+	NeoFSTokens `yaml:",inline"`
+	Nodes       []string      `yaml:"Nodes"`
+	Timeout     time.Duration `yaml:"Timeout"`
 }
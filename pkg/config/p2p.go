@@ -1,6 +1,12 @@
 package config
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
 
 // P2P holds P2P node settings.
 type P2P struct {
@@ -8,12 +14,65 @@ type P2P struct {
 	Addresses        []string `yaml:"Addresses"`
 	AttemptConnPeers int      `yaml:"AttemptConnPeers"`
 	// BroadcastFactor is the factor (0-100) controlling gossip fan-out number optimization.
-	BroadcastFactor    int           `yaml:"BroadcastFactor"`
-	DialTimeout        time.Duration `yaml:"DialTimeout"`
-	ExtensiblePoolSize int           `yaml:"ExtensiblePoolSize"`
-	MaxPeers           int           `yaml:"MaxPeers"`
-	MinPeers           int           `yaml:"MinPeers"`
-	PingInterval       time.Duration `yaml:"PingInterval"`
-	PingTimeout        time.Duration `yaml:"PingTimeout"`
-	ProtoTickInterval  time.Duration `yaml:"ProtoTickInterval"`
+	BroadcastFactor int `yaml:"BroadcastFactor"`
+	// CompactBlocks enables compact block relay with peers supporting it.
+	CompactBlocks bool `yaml:"CompactBlocks"`
+	// DNSSeeds is the list of domain names with TXT records containing
+	// additional seed node addresses.
+	DNSSeeds    []string      `yaml:"DNSSeeds"`
+	DialTimeout time.Duration `yaml:"DialTimeout"`
+	// ExtensibleCategories is the list of extensible payload categories
+	// requested from peers if ExtensibleFilter is enabled.
+	ExtensibleCategories []string `yaml:"ExtensibleCategories"`
+	// ExtensibleFilter makes the node announce extensible payload categories
+	// it's interested in, so that peers supporting it don't relay others.
+	ExtensibleFilter   bool `yaml:"ExtensibleFilter"`
+	ExtensiblePoolSize int  `yaml:"ExtensiblePoolSize"`
+	// HeadersPrefetch enables header-first synchronization, it's the
+	// number of headers requested ahead of the current block height.
+	HeadersPrefetch uint32 `yaml:"HeadersPrefetch"`
+	MaxPeers        int    `yaml:"MaxPeers"`
+	MinPeers        int    `yaml:"MinPeers"`
+	// NeoFSSeeds configures fetching a signed seed node list from NeoFS.
+	NeoFSSeeds        NeoFSSeeds    `yaml:"NeoFSSeeds"`
+	PingInterval      time.Duration `yaml:"PingInterval"`
+	PingTimeout       time.Duration `yaml:"PingTimeout"`
+	ProtoTickInterval time.Duration `yaml:"ProtoTickInterval"`
+	// SyncProfile enables initial block download profiling with periodic
+	// and final reports logged.
+	SyncProfile bool `yaml:"SyncProfile"`
+}
+
+// NeoFSSeeds contains settings for fetching a signed list of seed node
+// addresses stored as a NeoFS object.
+type NeoFSSeeds struct {
+	NeoFSTokens `yaml:",inline"`
+	// Object is the NeoFS URL of the list in "neofs:<container>/<object>" form,
+	// an empty value disables NeoFS seeds.
+	Object string `yaml:"Object"`
+	// Addresses is the list of NeoFS nodes to fetch the object from.
+	Addresses []string `yaml:"Addresses"`
+	// PublicKey is the hex-encoded public key the list must be signed with.
+	PublicKey string        `yaml:"PublicKey"`
+	Timeout   time.Duration `yaml:"Timeout"`
+	// MaxAge is the maximum age of the accepted list, zero means no limit.
+	MaxAge time.Duration `yaml:"MaxAge"`
+}
+
+// Validate checks NeoFSSeeds for internal consistency, it returns nil if
+// NeoFS seeds are not configured.
+func (n *NeoFSSeeds) Validate() error {
+	if n.Object == "" {
+		return nil
+	}
+	if len(n.Addresses) == 0 {
+		return errors.New("addresses are not set")
+	}
+	if _, err := keys.NewPublicKeyFromString(n.PublicKey); err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if n.MaxAge < 0 {
+		return fmt.Errorf("negative MaxAge (%s)", n.MaxAge)
+	}
+	return nil
 }
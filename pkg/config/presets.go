@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/config"
+)
+
+// presets contains built-in network configurations that can be used as a base
+// for node configuration files via Preset setting.
+var presets = map[string][]byte{
+	"mainnet":       config.MainNet,
+	"testnet":       config.TestNet,
+	"privnet":       config.PrivNet,
+	"neofs-mainnet": config.MainNetNeoFS,
+	"neofs-testnet": config.TestNetNeoFS,
+}
+
+// Presets returns sorted names of all built-in network presets.
+func Presets() []string {
+	var res = make([]string, 0, len(presets))
+	for name := range presets {
+		res = append(res, name)
+	}
+	slices.Sort(res)
+	return res
+}
+
+// getPreset returns configuration data of the preset with the given name.
+func getPreset(name string) ([]byte, error) {
+	data, ok := presets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(Presets(), ", "))
+	}
+	return data, nil
+}
@@ -37,6 +37,17 @@ type (
 		MaxBlockSize uint32 `yaml:"MaxBlockSize"`
 		// MaxBlockSystemFee is the maximum overall system fee per block.
 		MaxBlockSystemFee int64 `yaml:"MaxBlockSystemFee"`
+		// MaxIntegerSize is the maximum size (in bytes) of Integer values
+		// produced by VM arithmetic instructions. Zero value means the standard
+		// 32-byte (256-bit) limit. Any other value makes the network incompatible
+		// with the standard Neo protocol, so it's intended for private networks
+		// only.
+		MaxIntegerSize uint32 `yaml:"MaxIntegerSize"`
+		// MaxStorageFindResults is the maximum number of items a contract can
+		// traverse via a single storage iterator (created by System.Storage.Find)
+		// starting from Echidna hardfork if NeoGoExtensions are enabled. Zero
+		// value means the default limit set by the Blockchain.
+		MaxStorageFindResults uint32 `yaml:"MaxStorageFindResults"`
 		// MaxTraceableBlocks is the length of the chain accessible to smart contracts.
 		MaxTraceableBlocks uint32 `yaml:"MaxTraceableBlocks"`
 		// MaxTransactionsPerBlock is the maximum amount of transactions per block.
@@ -45,6 +56,11 @@ type (
 		// exceeding that a transaction should fail validation. It is set to estimated daily number
 		// of blocks with 15s interval.
 		MaxValidUntilBlockIncrement uint32 `yaml:"MaxValidUntilBlockIncrement"`
+		// NeoGoExtensions enables NeoGo-specific native contract methods and
+		// events along with storage iterator limits starting from Echidna
+		// hardfork. These are not supported by the C# node, so it makes the
+		// network incompatible with the standard Neo protocol.
+		NeoGoExtensions bool `yaml:"NeoGoExtensions"`
 		// P2PSigExtensions enables additional signature-related logic.
 		P2PSigExtensions bool `yaml:"P2PSigExtensions"`
 		// P2PStateExchangeExtensions enables additional P2P MPT state data exchange logic.
@@ -67,9 +83,21 @@ type (
 		ValidatorsHistory map[uint32]uint32 `yaml:"ValidatorsHistory"`
 		// Whether to verify transactions in the received blocks.
 		VerifyTransactions bool `yaml:"VerifyTransactions"`
+		// WeightedPrimarySelection enables dBFT primary (speaker) selection
+		// weighted by validators' recent liveness.
+		WeightedPrimarySelection bool `yaml:"WeightedPrimarySelection"`
 	}
 )
 
+const (
+	// minMaxIntegerSize is the standard (and minimum allowed) VM Integer size
+	// limit in bytes.
+	minMaxIntegerSize = 32
+	// maxMaxIntegerSize is the maximum allowed value of MaxIntegerSize setting,
+	// it matches stackitem.MaxExtendedIntegerSizeBits.
+	maxMaxIntegerSize = 128
+)
+
 // heightNumber is an auxiliary structure for configuration checks.
 type heightNumber struct {
 	h uint32
@@ -108,6 +136,9 @@ func (p *ProtocolConfiguration) Validate() error {
 			shouldBeDisabled = true
 		}
 	}
+	if p.MaxIntegerSize != 0 && (p.MaxIntegerSize < minMaxIntegerSize || p.MaxIntegerSize > maxMaxIntegerSize) {
+		return fmt.Errorf("MaxIntegerSize must be between %d and %d", minMaxIntegerSize, maxMaxIntegerSize)
+	}
 	if p.ValidatorsCount != 0 && len(p.ValidatorsHistory) != 0 || p.ValidatorsCount == 0 && len(p.ValidatorsHistory) == 0 {
 		return errors.New("configuration should either have one of ValidatorsCount or ValidatorsHistory, not both")
 	}
@@ -218,10 +249,13 @@ func (p *ProtocolConfiguration) Equals(o *ProtocolConfiguration) bool {
 		p.Magic != o.Magic ||
 		p.MaxBlockSize != o.MaxBlockSize ||
 		p.MaxBlockSystemFee != o.MaxBlockSystemFee ||
+		p.MaxIntegerSize != o.MaxIntegerSize ||
+		p.MaxStorageFindResults != o.MaxStorageFindResults ||
 		p.MaxTraceableBlocks != o.MaxTraceableBlocks ||
 		p.MaxTransactionsPerBlock != o.MaxTransactionsPerBlock ||
 		p.MaxValidUntilBlockIncrement != o.MaxValidUntilBlockIncrement ||
 		p.MemPoolSize != o.MemPoolSize ||
+		p.NeoGoExtensions != o.NeoGoExtensions ||
 		p.P2PNotaryRequestPayloadPoolSize != o.P2PNotaryRequestPayloadPoolSize ||
 		p.P2PSigExtensions != o.P2PSigExtensions ||
 		p.P2PStateExchangeExtensions != o.P2PStateExchangeExtensions ||
@@ -231,6 +265,7 @@ func (p *ProtocolConfiguration) Equals(o *ProtocolConfiguration) bool {
 		p.TimePerBlock != o.TimePerBlock ||
 		p.ValidatorsCount != o.ValidatorsCount ||
 		p.VerifyTransactions != o.VerifyTransactions ||
+		p.WeightedPrimarySelection != o.WeightedPrimarySelection ||
 		!maps.Equal(p.CommitteeHistory, o.CommitteeHistory) ||
 		!maps.Equal(p.Hardforks, o.Hardforks) ||
 		!slices.Equal(p.SeedList, o.SeedList) ||
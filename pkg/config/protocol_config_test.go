@@ -148,6 +148,18 @@ func TestProtocolConfigurationValidation(t *testing.T) {
 	err = p.Validate()
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "configuration should either have one of ValidatorsCount or ValidatorsHistory, not both")
+	for _, sz := range []uint32{16, 256} {
+		p = &ProtocolConfiguration{
+			StandbyCommittee: []string{"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2"},
+			ValidatorsCount:  1,
+			MaxIntegerSize:   sz,
+		}
+		err = p.Validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "MaxIntegerSize must be between")
+	}
+	p.MaxIntegerSize = 64
+	require.NoError(t, p.Validate())
 }
 
 func TestProtocolConfigurationValidation_Hardforks(t *testing.T) {
@@ -1,14 +1,23 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 )
 
 type (
 	// RPC is an RPC service configuration information.
 	RPC struct {
-		BasicService         `yaml:",inline"`
-		EnableCORSWorkaround bool `yaml:"EnableCORSWorkaround"`
+		BasicService          `yaml:",inline"`
+		ConsensusStateEnabled bool `yaml:"ConsensusStateEnabled"`
+		// CORS is a list of per-origin CORS settings, the first rule
+		// matching the request origin is used.
+		CORS                 []CORSRule `yaml:"CORS"`
+		EnableCORSWorkaround bool       `yaml:"EnableCORSWorkaround"`
 		// MaxGasInvoke is the maximum amount of GAS which
 		// can be spent during an RPC call.
 		MaxGasInvoke              fixedn.Fixed8 `yaml:"MaxGasInvoke"`
@@ -23,10 +32,32 @@ type (
 		SessionExpirationTime     int           `yaml:"SessionExpirationTime"`
 		SessionBackedByMPT        bool          `yaml:"SessionBackedByMPT"`
 		SessionPoolSize           int           `yaml:"SessionPoolSize"`
+		SSEHistorySize            int           `yaml:"SSEHistorySize"`
 		StartWhenSynchronized     bool          `yaml:"StartWhenSynchronized"`
+		StrictStackJSON           bool          `yaml:"StrictStackJSON"`
 		TLSConfig                 TLS           `yaml:"TLSConfig"`
 	}
 
+	// CORSRule describes CORS settings for a set of origins.
+	CORSRule struct {
+		// Origins is a list of allowed origins (like "https://example.com"),
+		// "*" allows any origin.
+		Origins []string `yaml:"Origins"`
+		// Methods is a list of HTTP methods allowed for the origins, GET
+		// and POST are allowed by default.
+		Methods []string `yaml:"Methods"`
+		// Headers is a list of request headers allowed for the origins,
+		// Content-Type, Authorization and X-Requested-With are allowed
+		// by default.
+		Headers []string `yaml:"Headers"`
+		// AllowCredentials allows requests with credentials (cookies or
+		// HTTP authentication), it can't be used with "*" origin.
+		AllowCredentials bool `yaml:"AllowCredentials"`
+		// MaxAge is the time preflight request results can be cached for,
+		// 6 hours by default.
+		MaxAge time.Duration `yaml:"MaxAge"`
+	}
+
 	// TLS describes SSL/TLS configuration.
 	TLS struct {
 		BasicService `yaml:",inline"`
@@ -34,3 +65,39 @@ type (
 		KeyFile      string `yaml:"KeyFile"`
 	}
 )
+
+// Validate checks RPC configuration for internal consistency.
+func (r *RPC) Validate() error {
+	for i := range r.CORS {
+		if err := r.CORS[i].validate(); err != nil {
+			return fmt.Errorf("CORS rule #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (c *CORSRule) validate() error {
+	if len(c.Origins) == 0 {
+		return errors.New("no origins configured")
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("negative MaxAge: %s", c.MaxAge)
+	}
+	for _, o := range c.Origins {
+		if o == "*" {
+			if c.AllowCredentials {
+				return errors.New("credentials can't be allowed for any origin")
+			}
+			continue
+		}
+		u, err := url.Parse(o)
+		if err != nil {
+			return fmt.Errorf("invalid origin %q: %w", o, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return fmt.Errorf("invalid origin %q: scheme and host expected", o)
+		}
+	}
+	return nil
+}
@@ -1,7 +1,50 @@
 package config
 
+import (
+	"errors"
+	"os"
+)
+
+// WalletPasswordEnvPrefix is a prefix of environment variables that can be
+// used to specify keystore account passwords. The full variable name is the
+// prefix followed by the account address, like
+// NEOGO_WALLET_PASSWORD_NbrUYaZgyhSkNoRo9ugRyEMdUZxrhkNaWB.
+const WalletPasswordEnvPrefix = "NEOGO_WALLET_PASSWORD_"
+
 // Wallet is a wallet info.
 type Wallet struct {
 	Path     string `yaml:"Path"`
 	Password string `yaml:"Password"`
+	// Keystore is a path to a directory with NEP-6 wallet files. It can be
+	// used instead of Path to provide service accounts from multiple
+	// wallets.
+	Keystore string `yaml:"Keystore"`
+	// Passwords contains keystore account passwords by account address.
+	Passwords map[string]string `yaml:"Passwords"`
+}
+
+// Validate checks Wallet for internal consistency and returns an error if any
+// invalid settings are found.
+func (w *Wallet) Validate() error {
+	if w.Path != "" && w.Keystore != "" {
+		return errors.New("Path and Keystore can't be specified simultaneously")
+	}
+	if w.Keystore == "" && len(w.Passwords) != 0 {
+		return errors.New("Passwords can only be used with Keystore")
+	}
+	return nil
+}
+
+// GetPassword returns the password for the account with the given address.
+// Passwords from the configuration have priority over ones specified via
+// WalletPasswordEnvPrefix environment variables, Password is used as
+// a fallback. The second value is false if no password is found.
+func (w *Wallet) GetPassword(addr string) (string, bool) {
+	if pass, ok := w.Passwords[addr]; ok {
+		return pass, true
+	}
+	if pass, ok := os.LookupEnv(WalletPasswordEnvPrefix + addr); ok {
+		return pass, true
+	}
+	return w.Password, w.Password != ""
 }
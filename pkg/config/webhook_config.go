@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Webhook is a configuration for the webhook notification service.
+type Webhook struct {
+	Enabled bool `yaml:"Enabled"`
+	// Timeout is a single HTTP request timeout.
+	Timeout time.Duration `yaml:"Timeout"`
+	// MaxRetries is the number of additional delivery attempts made after
+	// the first failed one.
+	MaxRetries int `yaml:"MaxRetries"`
+	// RetryInterval is the delay before the first retry, every subsequent
+	// retry doubles it.
+	RetryInterval time.Duration `yaml:"RetryInterval"`
+	// QueueSize is the number of pending events per endpoint, events are
+	// dropped if the queue is full.
+	QueueSize int               `yaml:"QueueSize"`
+	Endpoints []WebhookEndpoint `yaml:"Endpoints"`
+}
+
+// WebhookEndpoint describes a single webhook URL and the set of events sent
+// to it.
+type WebhookEndpoint struct {
+	URL string `yaml:"URL"`
+	// Secret is used as a key for HMAC-SHA256 signature of the request body,
+	// no signature is added if it's empty.
+	Secret string `yaml:"Secret"`
+	// Blocks enables new block events.
+	Blocks bool `yaml:"Blocks"`
+	// Contracts is a list of contract hashes (LE hex) to send notifications
+	// of, optionally restricted by Notifications names.
+	Contracts     []string `yaml:"Contracts"`
+	Notifications []string `yaml:"Notifications"`
+	// Addresses is a list of addresses to track accepted transactions for
+	// (any transaction signed by these accounts is reported).
+	Addresses []string `yaml:"Addresses"`
+}
+
+// Validate checks Webhook for internal consistency.
+func (cfg *Webhook) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if len(cfg.Endpoints) == 0 {
+		return errors.New("no endpoints configured")
+	}
+	if cfg.MaxRetries < 0 {
+		return fmt.Errorf("negative MaxRetries: %d", cfg.MaxRetries)
+	}
+	if cfg.QueueSize < 0 {
+		return fmt.Errorf("negative QueueSize: %d", cfg.QueueSize)
+	}
+	for i := range cfg.Endpoints {
+		if err := cfg.Endpoints[i].validate(); err != nil {
+			return fmt.Errorf("endpoint #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (e *WebhookEndpoint) validate() error {
+	u, err := url.Parse(e.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+	for _, c := range e.Contracts {
+		if _, err := util.Uint160DecodeStringLE(c); err != nil {
+			return fmt.Errorf("invalid contract hash %s: %w", c, err)
+		}
+	}
+	for _, a := range e.Addresses {
+		if _, err := address.StringToUint160(a); err != nil {
+			return fmt.Errorf("invalid address %s: %w", a, err)
+		}
+	}
+	if !e.Blocks && len(e.Contracts) == 0 && len(e.Addresses) == 0 {
+		return errors.New("no events selected")
+	}
+	if len(e.Notifications) != 0 && len(e.Contracts) == 0 {
+		return errors.New("notification names are set without contracts")
+	}
+	return nil
+}
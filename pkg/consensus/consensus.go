@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -78,6 +80,10 @@ type Service interface {
 	OnPayload(p *npayload.Extensible) error
 	// OnTransaction is a callback to notify the Service about a newly received transaction.
 	OnTransaction(tx *transaction.Transaction)
+
+	// GetState returns the snapshot of the current consensus state or nil if
+	// the Service is not running. The snapshot must not be modified.
+	GetState() *result.ConsensusState
 }
 
 type service struct {
@@ -107,6 +113,19 @@ type service struct {
 	// before the block is accepted. So, in case of change view, it will contain
 	// an updated value.
 	lastTimestamp uint64
+	// timer is the dBFT timer.
+	timer *deadlineTimer
+	// state is the snapshot of dBFT state updated after every event.
+	state atomic.Pointer[result.ConsensusState]
+	// weighted is the validators list reordered for the next block if
+	// weighted primary selection is enabled.
+	weighted atomic.Pointer[weightedOrder]
+	// futureLock protects future.
+	futureLock sync.Mutex
+	// future contains payloads for the block after the next one received
+	// with weighted primary selection enabled, they can only be validated
+	// after the next block is added.
+	future []Payload
 }
 
 // Config is a configuration for consensus services.
@@ -174,8 +193,9 @@ func NewService(cfg Config) (Service, error) {
 		}
 	}
 
+	srv.timer = &deadlineTimer{Timer: timer.New()}
 	srv.dbft, err = dbft.New[util.Uint256](
-		dbft.WithTimer[util.Uint256](timer.New()),
+		dbft.WithTimer[util.Uint256](srv.timer),
 		dbft.WithLogger[util.Uint256](srv.log),
 		dbft.WithSecondsPerBlock[util.Uint256](cfg.TimePerBlock),
 		dbft.WithGetKeyPair[util.Uint256](srv.getKeyPair),
@@ -308,10 +328,19 @@ func (s *service) Start() {
 		b, _ := s.Chain.GetBlock(s.Chain.CurrentBlockHash()) // Can't fail, we have some current block!
 		s.lastTimestamp = b.Timestamp
 		s.dbft.Start(s.lastTimestamp * nsInMs)
+		s.updateState()
 		go s.eventLoop()
 	}
 }
 
+// Health returns an error if the service is not running.
+func (s *service) Health() error {
+	if !s.started.Load() {
+		return errors.New("not started")
+	}
+	return nil
+}
+
 // Shutdown implements the Service interface.
 func (s *service) Shutdown() {
 	if s.started.CompareAndSwap(true, false) {
@@ -333,6 +362,7 @@ func (s *service) eventLoop() {
 	b, _ := s.Chain.GetBlock(s.Chain.CurrentBlockHash()) // Can't fail, we have some current block!
 	if b.Timestamp >= s.lastTimestamp {
 		s.handleChainBlock(b)
+		s.updateState()
 	}
 events:
 	for {
@@ -348,31 +378,7 @@ events:
 				zap.Uint("view", uint(v)))
 			s.dbft.OnTimeout(h, v)
 		case msg := <-s.messages:
-			fields := []zap.Field{
-				zap.Uint8("from", msg.message.ValidatorIndex),
-				zap.Stringer("type", msg.Type()),
-			}
-
-			if msg.Type() == dbft.RecoveryMessageType {
-				rec := msg.GetRecoveryMessage().(*recoveryMessage)
-				if rec.preparationHash == nil {
-					req := rec.GetPrepareRequest(&msg, s.dbft.Validators, uint16(s.dbft.PrimaryIndex))
-					if req != nil {
-						h := req.Hash()
-						rec.preparationHash = &h
-					}
-				}
-
-				fields = append(fields,
-					zap.Int("#preparation", len(rec.preparationPayloads)),
-					zap.Int("#commit", len(rec.commitPayloads)),
-					zap.Int("#changeview", len(rec.changeViewPayloads)),
-					zap.Bool("#request", rec.prepareRequest != nil),
-					zap.Bool("#hash", rec.preparationHash != nil))
-			}
-
-			s.log.Debug("received message", fields...)
-			s.dbft.OnReceive(&msg)
+			s.handleMessage(msg)
 		case tx := <-s.transactions:
 			s.dbft.OnTransaction(tx)
 		case b := <-s.blockEvents:
@@ -392,6 +398,7 @@ events:
 		if latestBlock != nil {
 			s.handleChainBlock(latestBlock)
 		}
+		s.updateState()
 	}
 drainLoop:
 	for {
@@ -409,6 +416,34 @@ drainLoop:
 	close(s.finished)
 }
 
+func (s *service) handleMessage(msg Payload) {
+	fields := []zap.Field{
+		zap.Uint8("from", msg.message.ValidatorIndex),
+		zap.Stringer("type", msg.Type()),
+	}
+
+	if msg.Type() == dbft.RecoveryMessageType {
+		rec := msg.GetRecoveryMessage().(*recoveryMessage)
+		if rec.preparationHash == nil {
+			req := rec.GetPrepareRequest(&msg, s.dbft.Validators, uint16(s.dbft.PrimaryIndex))
+			if req != nil {
+				h := req.Hash()
+				rec.preparationHash = &h
+			}
+		}
+
+		fields = append(fields,
+			zap.Int("#preparation", len(rec.preparationPayloads)),
+			zap.Int("#commit", len(rec.commitPayloads)),
+			zap.Int("#changeview", len(rec.changeViewPayloads)),
+			zap.Bool("#request", rec.prepareRequest != nil),
+			zap.Bool("#hash", rec.preparationHash != nil))
+	}
+
+	s.log.Debug("received message", fields...)
+	s.dbft.OnReceive(&msg)
+}
+
 func (s *service) handleChainBlock(b *coreb.Block) {
 	// We can get our own block here, so check for index.
 	if b.Index >= s.dbft.BlockIndex {
@@ -418,9 +453,20 @@ func (s *service) handleChainBlock(b *coreb.Block) {
 		s.postBlock(b)
 		s.dbft.Reset(b.Timestamp * nsInMs)
 	}
+	if s.ProtocolConfiguration.WeightedPrimarySelection {
+		for _, msg := range s.takeFuturePayloads() {
+			s.handleMessage(msg)
+		}
+	}
 }
 
 func (s *service) validatePayload(p *Payload) bool {
+	// Validators order depends on the previous block with weighted primary
+	// selection, so payloads for other heights can't be checked (payloads
+	// for the block after the next one are deferred, see deferPayload).
+	if s.ProtocolConfiguration.WeightedPrimarySelection && p.BlockIndex != s.Chain.BlockHeight()+1 {
+		return false
+	}
 	validators := s.getValidators()
 	if int(p.message.ValidatorIndex) >= len(validators) {
 		return false
@@ -473,6 +519,11 @@ func (s *service) OnPayload(cp *npayload.Extensible) error {
 		return nil
 	}
 
+	if s.ProtocolConfiguration.WeightedPrimarySelection && s.deferPayload(p) {
+		log.Debug("payload for the future block is deferred")
+		return nil
+	}
+
 	if !s.validatePayload(p) {
 		log.Info("can't validate payload")
 		return nil
@@ -725,6 +776,9 @@ func (s *service) getValidators(txes ...dbft.Transaction[util.Uint256]) []dbft.P
 	if err != nil {
 		s.log.Error("error while trying to get validators", zap.Error(err))
 	}
+	if s.ProtocolConfiguration.WeightedPrimarySelection && len(pKeys) > 0 {
+		pKeys = s.getWeightedValidators(pKeys)
+	}
 
 	pubs := make([]dbft.PublicKey, len(pKeys))
 	for i := range pKeys {
@@ -778,6 +832,16 @@ func (s *service) newBlockFromContext(ctx *dbft.Context[util.Uint256]) dbft.Bloc
 	block.Block.Version = coreb.VersionInitial
 
 	primaryIndex := byte(ctx.PrimaryIndex)
+	if s.ProtocolConfiguration.WeightedPrimarySelection {
+		// Block stores primary index in the standard validators list.
+		pKeys, err := s.Chain.GetNextBlockValidators()
+		if err != nil {
+			s.log.Fatal(fmt.Sprintf("failed to get validators: %s", err.Error()))
+		}
+		if i := slices.IndexFunc(pKeys, ctx.Validators[ctx.PrimaryIndex].(*keys.PublicKey).Equal); i >= 0 {
+			primaryIndex = byte(i)
+		}
+	}
 	block.Block.PrimaryIndex = primaryIndex
 
 	// it's OK to have ctx.TransactionsHashes == nil here
@@ -392,6 +392,29 @@ func TestService_OnPayload(t *testing.T) {
 	shouldReceive(t, srv.messages)
 }
 
+func TestService_GetState(t *testing.T) {
+	srv := newTestService(t)
+	require.Nil(t, srv.GetState())
+
+	srv.Start()
+	t.Cleanup(srv.Shutdown)
+	st := srv.GetState()
+	require.NotNil(t, st)
+	require.Equal(t, srv.Chain.BlockHeight()+1, st.Height)
+	require.Equal(t, byte(0), st.View)
+	require.Equal(t, srv.dbft.M(), st.M)
+	require.NotZero(t, st.TimerDeadline)
+	require.Equal(t, srv.dbft.GetPrimaryIndex(1), st.NextPrimary)
+
+	vals, err := srv.Chain.GetNextBlockValidators()
+	require.NoError(t, err)
+	require.Len(t, st.Validators, len(vals))
+	for i := range vals {
+		require.True(t, vals[i].Equal(st.Validators[i].PublicKey))
+	}
+	require.True(t, st.Index >= 0 && st.Index < len(vals))
+}
+
 func TestVerifyBlock(t *testing.T) {
 	srv := newTestService(t)
 
@@ -0,0 +1,145 @@
+package consensus
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// livenessWindow is the number of recent blocks used to estimate validators
+// liveness for weighted primary selection.
+const livenessWindow = 100
+
+// maxFuturePayloads is the maximum number of payloads for the block after the
+// next one kept until the next block is added.
+const maxFuturePayloads = 256
+
+// weightedOrder is the validators list reordered for weighted primary
+// selection at some height.
+type weightedOrder struct {
+	prevHash util.Uint256
+	pubs     keys.PublicKeys
+}
+
+// weightedValidators reorders validators (given in the standard order) for
+// the block with the given index and previous block hash. produced contains
+// the number of recent blocks produced by every validator (in the standard
+// order). Speakers for views 0, 1, ... are picked in a pseudo-random order
+// seeded by prevHash, every validator is picked with the probability
+// proportional to its weight (which is the fair share of recent blocks plus
+// the number of blocks actually produced). The result is arranged in a way
+// that makes dBFT (choosing (index - view) mod n validator as the primary)
+// follow this order.
+func weightedValidators(pubs keys.PublicKeys, index uint32, prevHash util.Uint256, produced []int) keys.PublicKeys {
+	var (
+		n       = len(pubs)
+		base    = max(livenessWindow/max(n, 1), 1)
+		weights = make([]uint64, n)
+		total   uint64
+		order   = make([]int, 0, n)
+		res     = make(keys.PublicKeys, n)
+	)
+	for i := range weights {
+		weights[i] = uint64(base)
+		if i < len(produced) {
+			weights[i] += uint64(produced[i])
+		}
+		total += weights[i]
+	}
+	for view := 0; view < n; view++ {
+		r := seededRand(prevHash, view) % total
+		for i, w := range weights {
+			if w == 0 {
+				continue
+			}
+			if r < w {
+				order = append(order, i)
+				total -= w
+				weights[i] = 0
+				break
+			}
+			r -= w
+		}
+	}
+	for view, i := range order {
+		pos := (int(index%uint32(n)) - view%n + n) % n
+		res[pos] = pubs[i]
+	}
+	return res
+}
+
+// seededRand returns a deterministic pseudo-random number for the given seed
+// and counter.
+func seededRand(seed util.Uint256, counter int) uint64 {
+	var buf [util.Uint256Size + 4]byte
+	copy(buf[:], seed[:])
+	binary.LittleEndian.PutUint32(buf[util.Uint256Size:], uint32(counter))
+	h := sha256.Sum256(buf[:])
+	return binary.LittleEndian.Uint64(h[:8])
+}
+
+// getWeightedValidators returns validators list for the next block reordered
+// according to the weighted primary selection. Reordered list is cached until
+// the next block is added.
+func (s *service) getWeightedValidators(pubs keys.PublicKeys) keys.PublicKeys {
+	var (
+		height   = s.Chain.BlockHeight()
+		prevHash = s.Chain.GetHeaderHash(height)
+	)
+	if o := s.weighted.Load(); o != nil && o.prevHash.Equals(prevHash) && len(o.pubs) == len(pubs) {
+		return o.pubs
+	}
+	var produced = make([]int, len(pubs))
+	for i := range min(height, livenessWindow) {
+		b, err := s.Chain.GetBlock(s.Chain.GetHeaderHash(height - i))
+		if err != nil {
+			break
+		}
+		if int(b.PrimaryIndex) < len(produced) {
+			produced[b.PrimaryIndex]++
+		}
+	}
+	res := weightedValidators(pubs, height+1, prevHash, produced)
+	s.weighted.Store(&weightedOrder{prevHash: prevHash, pubs: res})
+	return res
+}
+
+// deferPayload keeps the payload for the block after the next one until the
+// next block is added, since validators order for it depends on the next
+// block hash. It returns false if the payload is not for this block.
+func (s *service) deferPayload(p *Payload) bool {
+	s.futureLock.Lock()
+	defer s.futureLock.Unlock()
+	if p.BlockIndex != s.Chain.BlockHeight()+2 {
+		return false
+	}
+	if len(s.future) < maxFuturePayloads {
+		s.future = append(s.future, *p)
+	}
+	return true
+}
+
+// takeFuturePayloads returns deferred payloads for the next block that pass
+// validation now, payloads for the past blocks are dropped.
+func (s *service) takeFuturePayloads() []Payload {
+	s.futureLock.Lock()
+	defer s.futureLock.Unlock()
+	var (
+		height = s.Chain.BlockHeight()
+		res    []Payload
+		keep   = s.future[:0]
+	)
+	for i := range s.future {
+		switch {
+		case s.future[i].BlockIndex > height+1:
+			keep = append(keep, s.future[i])
+		case s.future[i].BlockIndex == height+1 && s.validatePayload(&s.future[i]):
+			res = append(res, s.future[i])
+		}
+	}
+	clear(s.future[len(keep):])
+	s.future = keep
+	return res
+}
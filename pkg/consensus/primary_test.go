@@ -0,0 +1,115 @@
+package consensus
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeightedValidators(t *testing.T) {
+	pubs := make(keys.PublicKeys, 4)
+	for i := range pubs {
+		pubs[i] = testchain.PrivateKey(i).PublicKey()
+	}
+	primary := func(res keys.PublicKeys, index uint32) int {
+		return slices.IndexFunc(pubs, res[index%uint32(len(res))].Equal)
+	}
+
+	t.Run("permutation", func(t *testing.T) {
+		for index := range uint32(8) {
+			res := weightedValidators(pubs, index, util.Uint256{byte(index)}, nil)
+			require.ElementsMatch(t, pubs, res)
+			require.Equal(t, res, weightedValidators(pubs, index, util.Uint256{byte(index)}, nil))
+		}
+	})
+	t.Run("fair", func(t *testing.T) {
+		var picks = make([]int, len(pubs))
+		for i := range 2000 {
+			res := weightedValidators(pubs, 1, util.Uint256{byte(i), byte(i >> 8)}, []int{25, 25, 25, 25})
+			picks[primary(res, 1)]++
+		}
+		for i := range picks {
+			require.InDelta(t, 500, picks[i], 100, i)
+		}
+	})
+	t.Run("flaky", func(t *testing.T) {
+		var picks = make([]int, len(pubs))
+		for i := range 2000 {
+			res := weightedValidators(pubs, 1, util.Uint256{byte(i), byte(i >> 8)}, []int{0, 33, 33, 34})
+			picks[primary(res, 1)]++
+		}
+		// 25 / 200 of picks are expected for the flaky one.
+		require.InDelta(t, 250, picks[0], 75)
+	})
+}
+
+func TestService_WeightedPrimarySelection(t *testing.T) {
+	srv := newTestService(t)
+	srv.ProtocolConfiguration.WeightedPrimarySelection = true
+
+	std, err := srv.Chain.GetNextBlockValidators()
+	require.NoError(t, err)
+	height := srv.Chain.BlockHeight()
+	expected := weightedValidators(std, height+1, srv.Chain.CurrentBlockHash(), nil)
+	actual := convertKeys(srv.getValidators())
+	require.Equal(t, []*keys.PublicKey(expected), actual)
+
+	t.Run("validate payload", func(t *testing.T) {
+		priv, pub := getTestValidator(1)
+		p := new(Payload)
+		p.Sender = priv.GetScriptHash()
+		p.payload = &prepareRequest{}
+		p.message.ValidatorIndex = byte(slices.IndexFunc(expected, pub.Equal))
+		p.BlockIndex = height + 1
+		require.NoError(t, p.Sign(priv))
+		require.True(t, srv.validatePayload(p))
+
+		p.BlockIndex = height + 2
+		require.NoError(t, p.Sign(priv))
+		require.False(t, srv.validatePayload(p))
+	})
+	t.Run("future payload", func(t *testing.T) {
+		srv.started.Store(true)
+		defer srv.started.Store(false)
+
+		// Validator index for the block after the next one is not known
+		// yet, so send payloads with all of them, only one is valid.
+		priv, _ := getTestValidator(1)
+		for i := range std {
+			p := new(Payload)
+			p.Sender = priv.GetScriptHash()
+			p.payload = &commit{}
+			p.message.ValidatorIndex = byte(i)
+			p.BlockIndex = height + 2
+			require.NoError(t, p.Sign(priv))
+			require.NoError(t, srv.OnPayload(&p.Extensible))
+		}
+		p := new(Payload)
+		p.Sender = priv.GetScriptHash()
+		p.payload = &commit{}
+		p.BlockIndex = height + 3
+		require.NoError(t, p.Sign(priv))
+		require.NoError(t, srv.OnPayload(&p.Extensible))
+		shouldNotReceive(t, srv.messages)
+		require.Len(t, srv.future, len(std))
+
+		bc := srv.Chain.(*core.Blockchain)
+		require.NoError(t, bc.AddBlock(testchain.NewBlock(t, bc, 1, 0)))
+		msgs := srv.takeFuturePayloads()
+		require.Len(t, msgs, 1)
+		require.Equal(t, height+2, msgs[0].BlockIndex)
+		require.Empty(t, srv.future)
+	})
+	t.Run("block primary index", func(t *testing.T) {
+		srv.dbft.Start(0)
+		ctx := srv.dbft.Context
+		b := srv.newBlockFromContext(&ctx)
+		primary := ctx.Validators[ctx.PrimaryIndex].(*keys.PublicKey)
+		require.Equal(t, byte(slices.IndexFunc(std, primary.Equal)), b.(*neoBlock).PrimaryIndex)
+	})
+}
@@ -0,0 +1,98 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/nspcc-dev/dbft/timer"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+)
+
+// deadlineTimer is a dBFT timer that keeps track of the time it fires at.
+// Like any other dBFT timer it's only used from the event loop goroutine.
+type deadlineTimer struct {
+	*timer.Timer
+
+	start time.Time
+	d     time.Duration
+}
+
+// Reset implements the dbft.Timer interface.
+func (t *deadlineTimer) Reset(height uint32, view byte, d time.Duration) {
+	t.Timer.Reset(height, view, d)
+	t.start, t.d = t.Now(), d
+}
+
+// Extend implements the dbft.Timer interface.
+func (t *deadlineTimer) Extend(d time.Duration) {
+	t.Timer.Extend(d)
+	t.d += d
+}
+
+// Stop implements the dbft.Timer interface.
+func (t *deadlineTimer) Stop() {
+	t.Timer.Stop()
+	t.start = time.Time{}
+}
+
+// deadline returns the time the timer fires at or zero time if it's stopped.
+func (t *deadlineTimer) deadline() time.Time {
+	if t.start.IsZero() {
+		return time.Time{}
+	}
+	return t.start.Add(t.d)
+}
+
+// updateState takes a snapshot of the current dBFT state, it must be called
+// from the event loop goroutine (or before it's started).
+func (s *service) updateState() {
+	var (
+		ctx = &s.dbft.Context
+		st  = &result.ConsensusState{
+			Height:              ctx.BlockIndex,
+			View:                ctx.ViewNumber,
+			Index:               ctx.MyIndex,
+			Primary:             ctx.PrimaryIndex,
+			M:                   ctx.M(),
+			MissingTransactions: len(ctx.MissingTransactions),
+			Validators:          make([]result.ConsensusValidator, len(ctx.Validators)),
+		}
+	)
+	if len(ctx.Validators) != 0 {
+		st.NextPrimary = ctx.GetPrimaryIndex(ctx.ViewNumber + 1)
+	}
+	if d := s.timer.deadline(); !d.IsZero() {
+		st.TimerDeadline = uint64(d.UnixMilli())
+	}
+	for i := range st.Validators {
+		v := &st.Validators[i]
+		v.PublicKey, _ = ctx.Validators[i].(*keys.PublicKey)
+		v.Prepared = i < len(ctx.PreparationPayloads) && ctx.PreparationPayloads[i] != nil
+		v.Committed = i < len(ctx.CommitPayloads) && ctx.CommitPayloads[i] != nil
+		v.ChangeView = i < len(ctx.ChangeViewPayloads) && ctx.ChangeViewPayloads[i] != nil
+		if i < len(ctx.LastSeenMessage) && ctx.LastSeenMessage[i] != nil {
+			v.LastSeen = &result.ConsensusHeightView{
+				Height: ctx.LastSeenMessage[i].Height,
+				View:   ctx.LastSeenMessage[i].View,
+			}
+		}
+		if v.Prepared {
+			st.Preparations++
+		}
+		if v.Committed {
+			st.Commits++
+		}
+		if v.ChangeView {
+			st.ChangeViews++
+		}
+	}
+	s.state.Store(st)
+}
+
+// GetState implements the Service interface.
+func (s *service) GetState() *result.ConsensusState {
+	if !s.started.Load() {
+		return nil
+	}
+	return s.state.Load()
+}
@@ -2,20 +2,21 @@ package core
 
 import (
 	"bytes"
-	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"runtime"
 	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	json "github.com/nspcc-dev/go-ordered-json"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/config"
-	"github.com/nspcc-dev/neo-go/pkg/config/limits"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/contractstats"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
@@ -27,11 +28,13 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/stateroot"
 	"github.com/nspcc-dev/neo-go/pkg/core/statesync"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dboper"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/core/transfers"
+	"github.com/nspcc-dev/neo-go/pkg/core/validatorstats"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
-	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -55,12 +58,19 @@ const (
 	defaultP2PNotaryRequestPayloadPoolSize = 1000
 	defaultMaxBlockSize                    = 262144
 	defaultMaxBlockSystemFee               = 900000000000
+	defaultMaxStorageFindResults           = 100000
 	defaultMaxTraceableBlocks              = 2102400 // 1 year of 15s blocks
 	defaultMaxTransactionsPerBlock         = 512
 	defaultTimePerBlock                    = 15 * time.Second
 	// HeaderVerificationGasLimit is the maximum amount of GAS for block header verification.
 	HeaderVerificationGasLimit = 3_00000000 // 3 GAS
 	defaultStateSyncInterval   = 40000
+	// rebuildTransfersPersistPeriod is the number of blocks processed by
+	// RebuildTransfers between cache flushes.
+	rebuildTransfersPersistPeriod = 1000
+	// maxAppLogsPruneBatch is the maximum number of blocks application logs
+	// are pruned for when a new block is stored.
+	maxAppLogsPruneBatch = 1000
 )
 
 // stateChangeStage denotes the stage of state modification process.
@@ -105,6 +115,9 @@ var (
 	// ErrInvalidBlockIndex is returned when trying to add block with index
 	// other than expected height of the blockchain.
 	ErrInvalidBlockIndex = errors.New("invalid block index")
+	// ErrBlockHeaderMismatch is returned when trying to add block that
+	// doesn't match the header already stored for its index.
+	ErrBlockHeaderMismatch = errors.New("block doesn't match stored header")
 	// ErrHasConflicts is returned when trying to add some transaction which
 	// conflicts with other transaction in the chain or pool according to
 	// Conflicts attribute.
@@ -156,6 +169,15 @@ type Blockchain struct {
 	// Current persisted block count.
 	persistedHeight uint32
 
+	// profiler receives block processing timings if set.
+	profiler atomic.Pointer[blockProfiler]
+
+	// preverifiedLock protects preverified.
+	preverifiedLock sync.Mutex
+	// preverified contains header witnesses verified by PreverifyHeader
+	// for blocks that are not yet added.
+	preverified map[util.Uint256]transaction.Witness
+
 	// Stop synchronization mechanisms.
 	stopCh      chan struct{}
 	runToExitCh chan struct{}
@@ -174,6 +196,10 @@ type Blockchain struct {
 
 	contracts native.Contracts
 
+	// debugInfos contains contract debug information indexed by script
+	// hash, it's used to add source locations to FAULT messages.
+	debugInfos map[util.Uint160]*compiler.DebugInfo
+
 	extensible atomic.Value
 
 	// knownValidatorsCount is the latest known validators count used
@@ -184,6 +210,14 @@ type Blockchain struct {
 	defaultBlockWitness atomic.Value
 
 	stateRoot *stateroot.Module
+	// transfers is the NEP-17/NEP-11 transfer index.
+	transfers *transfers.Tracker
+	// contractStats aggregates per-contract execution statistics, it's nil
+	// if ContractStatsPeriod is not set.
+	contractStats *contractstats.Collector
+	// validatorStats tracks block production statistics of validators, it's
+	// nil if ValidatorStatsWindow is not set.
+	validatorStats *validatorstats.Collector
 
 	// Notification subsystem.
 	events  chan bcEvent
@@ -198,8 +232,10 @@ type StateRoot interface {
 	CurrentValidatedHeight() uint32
 	FindStates(root util.Uint256, prefix, start []byte, maxNum int) ([]storage.KeyValue, error)
 	SeekStates(root util.Uint256, prefix []byte, f func(k, v []byte) bool)
+	SeekStatesRange(root util.Uint256, rng storage.SeekRange, f func(k, v []byte) bool)
 	GetState(root util.Uint256, key []byte) ([]byte, error)
 	GetStateProof(root util.Uint256, key []byte) ([][]byte, error)
+	GetRangeProof(root util.Uint256, prefix, from []byte, maxNum int) ([]storage.KeyValue, [][]byte, error)
 	GetStateRoot(height uint32) (*state.MPTRoot, error)
 	GetLatestStateHeight(root util.Uint256) (uint32, error)
 }
@@ -207,17 +243,16 @@ type StateRoot interface {
 // bcEvent is an internal event generated by the Blockchain and then
 // broadcasted to other parties. It joins the new block and associated
 // invocation logs, all the other events visible from outside can be produced
-// from this combination.
+// from this combination. If execution results are streamed (see
+// StreamExecutions ledger setting), every one of them is sent as a separate
+// event without block (with the transaction it belongs to, if any) and the
+// block is sent without execution results after that. If such block fails to
+// be stored, it's sent with rejected flag set instead.
 type bcEvent struct {
 	block          *block.Block
 	appExecResults []*state.AppExecResult
-}
-
-// transferData is used for transfer caching during storeBlock.
-type transferData struct {
-	Info  state.TokenTransferInfo
-	Log11 state.TokenTransferLog
-	Log17 state.TokenTransferLog
+	tx             *transaction.Transaction
+	rejected       bool
 }
 
 // NewBlockchain returns a new blockchain object the will use the
@@ -249,6 +284,10 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		cfg.MaxBlockSystemFee = defaultMaxBlockSystemFee
 		log.Info("MaxBlockSystemFee is not set or wrong, setting default value", zap.Int64("MaxBlockSystemFee", cfg.MaxBlockSystemFee))
 	}
+	if cfg.MaxStorageFindResults == 0 {
+		cfg.MaxStorageFindResults = defaultMaxStorageFindResults
+		log.Info("MaxStorageFindResults is not set or wrong, using default value", zap.Uint32("MaxStorageFindResults", cfg.MaxStorageFindResults))
+	}
 	if cfg.MaxTraceableBlocks == 0 {
 		cfg.MaxTraceableBlocks = defaultMaxTraceableBlocks
 		log.Info("MaxTraceableBlocks is not set or wrong, using default value", zap.Uint32("MaxTraceableBlocks", cfg.MaxTraceableBlocks))
@@ -306,6 +345,9 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		cfg.Ledger.GarbageCollectionPeriod = defaultGCPeriod
 		log.Info("GarbageCollectionPeriod is not set or wrong, using default value", zap.Uint32("GarbageCollectionPeriod", cfg.Ledger.GarbageCollectionPeriod))
 	}
+	if cfg.Ledger.VerificationWorkers <= 0 {
+		cfg.Ledger.VerificationWorkers = min(runtime.GOMAXPROCS(0), runtime.NumCPU())
+	}
 	bc := &Blockchain{
 		config:      cfg,
 		dao:         dao.NewSimple(s, cfg.StateRootInHeader),
@@ -319,10 +361,25 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		subCh:       make(chan any),
 		unsubCh:     make(chan any),
 		contracts:   *native.NewContracts(cfg.ProtocolConfiguration),
+		debugInfos:  make(map[util.Uint160]*compiler.DebugInfo),
+	}
+	for _, f := range cfg.Ledger.DebugInfoFiles {
+		di, err := compiler.ReadDebugInfo(f)
+		if err != nil {
+			return nil, fmt.Errorf("can't read debug info from %s: %w", f, err)
+		}
+		bc.debugInfos[di.Hash] = di
 	}
 
 	bc.stateRoot = stateroot.NewModule(cfg, bc.VerifyWitness, bc.log, bc.dao.Store)
 	bc.contracts.Designate.StateRootService = bc.stateRoot
+	bc.transfers = transfers.NewTracker(bc.getContractID)
+	if cfg.Ledger.ContractStatsPeriod != 0 {
+		bc.contractStats = contractstats.NewCollector(cfg.Ledger.ContractStatsPeriod)
+	}
+	if cfg.Ledger.ValidatorStatsWindow != 0 {
+		bc.validatorStats = validatorstats.NewCollector(cfg.Ledger.ValidatorStatsWindow, !cfg.WeightedPrimarySelection)
+	}
 
 	if err := bc.init(); err != nil {
 		return nil, err
@@ -332,6 +389,13 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 	return bc, nil
 }
 
+// DescribeFault returns the message of the error the given VM has failed with
+// extended by the source location of the failing instruction if debug
+// information for the contract is configured (see DebugInfoFiles setting).
+func (bc *Blockchain) DescribeFault(v *vm.VM, err error) string {
+	return compiler.DescribeFault(v, err, bc.debugInfos)
+}
+
 // GetDesignatedByRole returns a set of designated public keys for the given role
 // relevant for the next block.
 func (bc *Blockchain) GetDesignatedByRole(r noderoles.Role) (keys.PublicKeys, uint32, error) {
@@ -345,10 +409,12 @@ func (bc *Blockchain) GetDesignatedByRole(r noderoles.Role) (keys.PublicKeys, ui
 // getCurrentHF returns the latest currently enabled hardfork. In case if no hardforks are enabled, the
 // default config.Hardfork(0) value is returned.
 func (bc *Blockchain) getCurrentHF() config.Hardfork {
-	var (
-		height  = bc.BlockHeight()
-		current config.Hardfork
-	)
+	return bc.getHFAt(bc.BlockHeight())
+}
+
+// getHFAt returns the latest hardfork enabled at the specified height.
+func (bc *Blockchain) getHFAt(height uint32) config.Hardfork {
+	var current config.Hardfork
 	// Rely on the fact that hardforks list is continuous.
 	for _, hf := range config.Hardforks {
 		enableHeight, ok := bc.config.Hardforks[hf.String()]
@@ -415,6 +481,7 @@ func (bc *Blockchain) init() error {
 			P2PSigExtensions:           bc.config.P2PSigExtensions,
 			P2PStateExchangeExtensions: bc.config.P2PStateExchangeExtensions,
 			KeepOnlyLatestState:        bc.config.Ledger.KeepOnlyLatestState,
+			CompressAppLogs:            bc.config.Ledger.CompressAppLogs,
 			Magic:                      uint32(bc.config.Magic),
 			Value:                      version,
 		}
@@ -429,7 +496,7 @@ func (bc *Blockchain) init() error {
 		if err := bc.stateRoot.Init(0); err != nil {
 			return fmt.Errorf("can't init MPT: %w", err)
 		}
-		return bc.storeBlock(genesisBlock, nil)
+		return bc.storeBlock(genesisBlock, nil, nil)
 	}
 	if ver.Value != version {
 		return fmt.Errorf("storage version mismatch (expected=%s, actual=%s)", version, ver.Value)
@@ -450,6 +517,10 @@ func (bc *Blockchain) init() error {
 		return fmt.Errorf("KeepOnlyLatestState setting mismatch (old=%v, new=%v)",
 			ver.KeepOnlyLatestState, bc.config.Ledger.KeepOnlyLatestState)
 	}
+	if ver.CompressAppLogs != bc.config.Ledger.CompressAppLogs {
+		return fmt.Errorf("CompressAppLogs setting mismatch (old=%v, new=%v)",
+			ver.CompressAppLogs, bc.config.Ledger.CompressAppLogs)
+	}
 	if ver.Magic != uint32(bc.config.Magic) {
 		return fmt.Errorf("protocol configuration Magic mismatch (old=%v, new=%v)",
 			ver.Magic, bc.config.Magic)
@@ -488,6 +559,13 @@ func (bc *Blockchain) init() error {
 		return bc.jumpToStateInternal(stateSyncPoint, stateChangeStage(stateChStage[0]))
 	}
 
+	if h := bc.config.Ledger.StateResetHeight; h != 0 {
+		err = bc.resetToConfiguredHeight(h)
+		if err != nil {
+			return err
+		}
+	}
+
 	bHeight, err := bc.dao.GetCurrentBlockHeight()
 	if err != nil {
 		return fmt.Errorf("failed to retrieve current block height: %w", err)
@@ -607,13 +685,7 @@ func (bc *Blockchain) jumpToStateInternal(p uint32, stage stateChangeStage) erro
 			if err != nil {
 				return fmt.Errorf("failed to remove outdated state data for the genesis block: %w", err)
 			}
-			prefixes := []byte{byte(storage.STNEP11Transfers), byte(storage.STNEP17Transfers), byte(storage.STTokenTransferInfo)}
-			for i := range prefixes {
-				cache.Store.Seek(storage.SeekRange{Prefix: prefixes[i : i+1]}, func(k, v []byte) bool {
-					cache.Store.Delete(k)
-					return true
-				})
-			}
+			bc.transfers.Drop(cache)
 		}
 		// Update SYS-prefixed info.
 		block, err := bc.dao.GetBlock(bc.GetHeaderHash(p))
@@ -691,6 +763,33 @@ func (bc *Blockchain) Reset(height uint32) error {
 	return bc.resetStateInternal(height, none)
 }
 
+// resetToConfiguredHeight resets the state to the height specified in the
+// configuration unless this was already done before for the same height or
+// the chain is below it.
+func (bc *Blockchain) resetToConfiguredHeight(height uint32) error {
+	if done, err := bc.dao.GetStateResetHeight(); err == nil && done == height {
+		return nil
+	}
+	currHeight, err := bc.dao.GetCurrentBlockHeight()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve current block height: %w", err)
+	}
+	if currHeight > height {
+		bc.log.Info("resetting state to the configured height", zap.Uint32("height", height), zap.Uint32("current height", currHeight))
+		bc.dao.PutStateSyncPoint(height)
+		err = bc.resetStateInternal(height, none)
+		if err != nil {
+			return fmt.Errorf("failed to reset state to the configured height %d: %w", height, err)
+		}
+	}
+	bc.dao.PutStateResetHeight(height)
+	_, err = bc.dao.PersistSync()
+	if err != nil {
+		return fmt.Errorf("failed to persist state reset height: %w", err)
+	}
+	return nil
+}
+
 func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage) error {
 	// Cache isn't yet initialized, so retrieve block height right from DAO.
 	currHeight, err := bc.dao.GetCurrentBlockHeight()
@@ -939,6 +1038,9 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 			upperCache.PurgeHeader(bc.GetHeaderHash(i))
 		}
 		upperCache.DeleteHeaderHashes(height+1, headerBatchCount)
+		if h, err := upperCache.GetAppLogsPruneHeight(); err == nil && h > height+1 {
+			upperCache.PutAppLogsPruneHeight(height + 1)
+		}
 		upperCache.StoreAsCurrentBlock(b)
 		upperCache.PutCurrentHeader(b.Hash(), height)
 		v.StoragePrefix = statesync.TemporaryPrefix(v.StoragePrefix)
@@ -977,7 +1079,7 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 		}
 
 		// Reset transfers.
-		err = bc.resetTransfers(upperCache, height)
+		err = bc.transfers.Reset(upperCache, height)
 		if err != nil {
 			return fmt.Errorf("failed to strip transfer log / transfer info: %w", err)
 		}
@@ -1047,6 +1149,13 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 	if err != nil {
 		return fmt.Errorf("failed to update in-memory blockchain data: %w", err)
 	}
+	bc.transfers.NotifyReset(height)
+	if bc.contractStats != nil {
+		bc.contractStats.Reset()
+	}
+	if bc.validatorStats != nil {
+		bc.validatorStats.Reset()
+	}
 	return nil
 }
 
@@ -1109,6 +1218,8 @@ func (bc *Blockchain) Run() {
 			dur, err := bc.persist(nextSync)
 			if err != nil {
 				bc.log.Warn("failed to persist blockchain", zap.Error(err))
+			} else if prof := bc.getBlockProfiler(); prof != nil && dur > 0 {
+				prof.Persisted(dur)
 			}
 			if bc.config.Ledger.RemoveUntraceableBlocks {
 				gcDur = bc.tryRunGC(oldPersisted)
@@ -1149,143 +1260,6 @@ func (bc *Blockchain) tryRunGC(oldHeight uint32) time.Duration {
 	return dur
 }
 
-// resetTransfers is a helper function that strips the top newest NEP17 and NEP11 transfer logs
-// down to the given height (not including the height itself) and updates corresponding token
-// transfer info.
-func (bc *Blockchain) resetTransfers(cache *dao.Simple, height uint32) error {
-	// Completely remove transfer info, updating it takes too much effort. We'll gather new
-	// transfer info on-the-fly later.
-	cache.Store.Seek(storage.SeekRange{
-		Prefix: []byte{byte(storage.STTokenTransferInfo)},
-	}, func(k, v []byte) bool {
-		cache.Store.Delete(k)
-		return true
-	})
-
-	// Look inside each transfer batch and iterate over the batch transfers, picking those that
-	// not newer than the given height. Also, for each suitable transfer update transfer info
-	// flushing changes after complete account's transfers processing.
-	prefixes := []byte{byte(storage.STNEP11Transfers), byte(storage.STNEP17Transfers)}
-	for i := range prefixes {
-		var (
-			acc             util.Uint160
-			trInfo          *state.TokenTransferInfo
-			removeFollowing bool
-			seekErr         error
-		)
-
-		cache.Store.Seek(storage.SeekRange{
-			Prefix:    prefixes[i : i+1],
-			Backwards: false, // From oldest to newest batch.
-		}, func(k, v []byte) bool {
-			var batchAcc util.Uint160
-			copy(batchAcc[:], k[1:])
-
-			if batchAcc != acc { // Some new account we're iterating over.
-				if trInfo != nil {
-					seekErr = cache.PutTokenTransferInfo(acc, trInfo)
-					if seekErr != nil {
-						return false
-					}
-				}
-				acc = batchAcc
-				trInfo = nil
-				removeFollowing = false
-			} else if removeFollowing {
-				cache.Store.Delete(bytes.Clone(k))
-				return seekErr == nil
-			}
-
-			r := io.NewBinReaderFromBuf(v[1:])
-			l := len(v)
-			bytesRead := 1 // 1 is for batch size byte which is read by default.
-			var (
-				oldBatchSize = v[0]
-				newBatchSize byte
-			)
-			for range v[0] { // From oldest to newest transfer of the batch.
-				var t *state.NEP17Transfer
-				if k[0] == byte(storage.STNEP11Transfers) {
-					tr := new(state.NEP11Transfer)
-					tr.DecodeBinary(r)
-					t = &tr.NEP17Transfer
-				} else {
-					t = new(state.NEP17Transfer)
-					t.DecodeBinary(r)
-				}
-				if r.Err != nil {
-					seekErr = fmt.Errorf("failed to decode subsequent transfer: %w", r.Err)
-					break
-				}
-
-				if t.Block > height {
-					break
-				}
-				bytesRead = l - r.Len() // Including batch size byte.
-				newBatchSize++
-				if trInfo == nil {
-					var err error
-					trInfo, err = cache.GetTokenTransferInfo(batchAcc)
-					if err != nil {
-						seekErr = fmt.Errorf("failed to retrieve token transfer info for %s: %w", batchAcc.StringLE(), r.Err)
-						return false
-					}
-				}
-				appendTokenTransferInfo(trInfo, t.Asset, t.Block, t.Timestamp, k[0] == byte(storage.STNEP11Transfers), newBatchSize >= state.TokenTransferBatchSize)
-			}
-			if newBatchSize == oldBatchSize {
-				// The batch is already in storage and doesn't need to be changed.
-				return seekErr == nil
-			}
-			if newBatchSize > 0 {
-				v[0] = newBatchSize
-				cache.Store.Put(k, v[:bytesRead])
-			} else {
-				cache.Store.Delete(k)
-				removeFollowing = true
-			}
-			return seekErr == nil
-		})
-		if seekErr != nil {
-			return seekErr
-		}
-		if trInfo != nil {
-			// Flush the last batch of transfer info changes.
-			err := cache.PutTokenTransferInfo(acc, trInfo)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
-
-// appendTokenTransferInfo is a helper for resetTransfers that updates token transfer info
-// wrt the given transfer that was added to the subsequent transfer batch.
-func appendTokenTransferInfo(transferData *state.TokenTransferInfo,
-	token int32, bIndex uint32, bTimestamp uint64, isNEP11 bool, lastTransferInBatch bool) {
-	var (
-		newBatch      *bool
-		nextBatch     *uint32
-		currTimestamp *uint64
-	)
-	if !isNEP11 {
-		newBatch = &transferData.NewNEP17Batch
-		nextBatch = &transferData.NextNEP17Batch
-		currTimestamp = &transferData.NextNEP17NewestTimestamp
-	} else {
-		newBatch = &transferData.NewNEP11Batch
-		nextBatch = &transferData.NextNEP11Batch
-		currTimestamp = &transferData.NextNEP11NewestTimestamp
-	}
-	transferData.LastUpdated[token] = bIndex
-	*newBatch = lastTransferInBatch
-	if *newBatch {
-		*nextBatch++
-		*currTimestamp = bTimestamp
-	}
-}
-
 func (bc *Blockchain) removeOldTransfers(index uint32) time.Duration {
 	bc.log.Info("starting transfer data garbage collection", zap.Uint32("index", index))
 	start := time.Now()
@@ -1295,40 +1269,7 @@ func (bc *Blockchain) removeOldTransfers(index uint32) time.Duration {
 		bc.log.Error("failed to find block header for transfer GC", zap.Duration("time", dur), zap.Error(err))
 		return dur
 	}
-	var removed, kept int64
-	var ts = h.Timestamp
-	prefixes := []byte{byte(storage.STNEP11Transfers), byte(storage.STNEP17Transfers)}
-
-	for i := range prefixes {
-		var acc util.Uint160
-		var canDrop bool
-
-		err = bc.store.SeekGC(storage.SeekRange{
-			Prefix:    prefixes[i : i+1],
-			Backwards: true, // From new to old.
-		}, func(k, v []byte) bool {
-			// We don't look inside of the batches, it requires too much effort, instead
-			// we drop batches that are confirmed to contain outdated entries.
-			var batchAcc util.Uint160
-			var batchTs = binary.BigEndian.Uint64(k[1+util.Uint160Size:])
-			copy(batchAcc[:], k[1:])
-
-			if batchAcc != acc { // Some new account we're iterating over.
-				acc = batchAcc
-			} else if canDrop { // We've seen this account and all entries in this batch are guaranteed to be outdated.
-				removed++
-				return false
-			}
-			// We don't know what's inside, so keep the current
-			// batch anyway, but allow to drop older ones.
-			canDrop = batchTs <= ts
-			kept++
-			return true
-		})
-		if err != nil {
-			break
-		}
-	}
+	removed, kept, err := bc.transfers.GC(h.Timestamp, bc.store)
 	dur := time.Since(start)
 	if err != nil {
 		bc.log.Error("failed to flush transfer data GC changeset", zap.Duration("time", dur), zap.Error(err))
@@ -1352,7 +1293,31 @@ func (bc *Blockchain) notificationDispatcher() {
 		txFeed           = make(map[chan *transaction.Transaction]bool)
 		notificationFeed = make(map[chan *state.ContainedNotificationEvent]bool)
 		executionFeed    = make(map[chan *state.AppExecResult]bool)
+		rejectedFeed     = make(map[chan *state.RejectedBlock]bool)
 	)
+	// sendExecution sends the execution result, its notifications and the
+	// transaction (nil for OnPersist and PostPersist executions) to
+	// subscribers. Notifications of FAULTed transactions are not sent.
+	sendExecution := func(aer *state.AppExecResult, tx *transaction.Transaction) {
+		for ch := range executionFeed {
+			ch <- aer
+		}
+		if tx == nil || aer.VMState == vmstate.Halt {
+			for i := range aer.Events {
+				for ch := range notificationFeed {
+					ch <- &state.ContainedNotificationEvent{
+						Container:         aer.Container,
+						NotificationEvent: aer.Events[i],
+					}
+				}
+			}
+		}
+		if tx != nil {
+			for ch := range txFeed {
+				ch <- tx
+			}
+		}
+	}
 	for {
 		select {
 		case <-bc.stopCh:
@@ -1369,6 +1334,8 @@ func (bc *Blockchain) notificationDispatcher() {
 				notificationFeed[ch] = true
 			case chan *state.AppExecResult:
 				executionFeed[ch] = true
+			case chan *state.RejectedBlock:
+				rejectedFeed[ch] = true
 			default:
 				panic(fmt.Sprintf("bad subscription: %T", sub))
 			}
@@ -1384,28 +1351,33 @@ func (bc *Blockchain) notificationDispatcher() {
 				delete(notificationFeed, ch)
 			case chan *state.AppExecResult:
 				delete(executionFeed, ch)
+			case chan *state.RejectedBlock:
+				delete(rejectedFeed, ch)
 			default:
 				panic(fmt.Sprintf("bad unsubscription: %T", unsub))
 			}
 		case event := <-bc.events:
 			// We don't want to waste time looping through transactions when there are no
 			// subscribers.
-			if len(txFeed) != 0 || len(notificationFeed) != 0 || len(executionFeed) != 0 {
+			var hasExecSubscribers = len(txFeed) != 0 || len(notificationFeed) != 0 || len(executionFeed) != 0
+			if event.block == nil {
+				if hasExecSubscribers {
+					sendExecution(event.appExecResults[0], event.tx)
+				}
+				continue
+			}
+			if event.rejected {
+				for ch := range rejectedFeed {
+					ch <- &state.RejectedBlock{Hash: event.block.Hash(), Index: event.block.Index}
+				}
+				continue
+			}
+			if hasExecSubscribers && len(event.appExecResults) != 0 {
 				aer := event.appExecResults[0]
 				if !aer.Container.Equals(event.block.Hash()) {
 					panic("inconsistent application execution results")
 				}
-				for ch := range executionFeed {
-					ch <- aer
-				}
-				for i := range aer.Events {
-					for ch := range notificationFeed {
-						ch <- &state.ContainedNotificationEvent{
-							Container:         aer.Container,
-							NotificationEvent: aer.Events[i],
-						}
-					}
-				}
+				sendExecution(aer, nil)
 
 				aerIdx := 1
 				for _, tx := range event.block.Transactions {
@@ -1414,39 +1386,14 @@ func (bc *Blockchain) notificationDispatcher() {
 						panic("inconsistent application execution results")
 					}
 					aerIdx++
-					for ch := range executionFeed {
-						ch <- aer
-					}
-					if aer.VMState == vmstate.Halt {
-						for i := range aer.Events {
-							for ch := range notificationFeed {
-								ch <- &state.ContainedNotificationEvent{
-									Container:         aer.Container,
-									NotificationEvent: aer.Events[i],
-								}
-							}
-						}
-					}
-					for ch := range txFeed {
-						ch <- tx
-					}
+					sendExecution(aer, tx)
 				}
 
 				aer = event.appExecResults[aerIdx]
 				if !aer.Container.Equals(event.block.Hash()) {
 					panic("inconsistent application execution results")
 				}
-				for ch := range executionFeed {
-					ch <- aer
-				}
-				for i := range aer.Events {
-					for ch := range notificationFeed {
-						ch <- &state.ContainedNotificationEvent{
-							Container:         aer.Container,
-							NotificationEvent: aer.Events[i],
-						}
-					}
-				}
+				sendExecution(aer, nil)
 			}
 			for ch := range headerFeed {
 				ch <- &event.block.Header
@@ -1476,7 +1423,14 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 	bc.addLock.Lock()
 	defer bc.addLock.Unlock()
 
-	var mp *mempool.Pool
+	var (
+		mp    *mempool.Pool
+		prof  = bc.getBlockProfiler()
+		start time.Time
+	)
+	if prof != nil {
+		start = time.Now()
+	}
 	expectedHeight := bc.BlockHeight() + 1
 	if expectedHeight != block.Index {
 		return fmt.Errorf("expected %d, got %d: %w", expectedHeight, block.Index, ErrInvalidBlockIndex)
@@ -1486,11 +1440,22 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 			ErrHdrStateRootSetting, bc.config.StateRootInHeader, block.StateRootEnabled)
 	}
 
+	// Headers can be synchronized ahead of blocks, in this case the block
+	// must just match the stored (already verified) one. Otherwise the
+	// header is only stored after the whole block is verified, so that an
+	// invalid block doesn't leave it in the chain.
 	if block.Index == bc.HeaderHeight()+1 {
-		err := bc.addHeaders(!bc.config.SkipBlockVerification, &block.Header)
-		if err != nil {
-			return err
+		if !bc.config.SkipBlockVerification {
+			prev, err := bc.GetHeader(block.PrevHash)
+			if err != nil {
+				return fmt.Errorf("previous header was not found: %w", err)
+			}
+			if err = bc.verifyHeader(&block.Header, prev); err != nil {
+				return err
+			}
 		}
+	} else if err := bc.checkStoredHeader(block); err != nil {
+		return err
 	}
 	if !bc.config.SkipBlockVerification {
 		merkle := block.ComputeMerkleRoot()
@@ -1498,18 +1463,25 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 			return errors.New("invalid block: MerkleRoot mismatch")
 		}
 		mp = mempool.New(len(block.Transactions), 0, false, nil)
-		for _, tx := range block.Transactions {
+		// Transactions are verified before adding them
+		// into the pool, so there is no point in doing
+		// it again even if we're verifying in-block transactions.
+		inPool := make([]bool, len(block.Transactions))
+		for i, tx := range block.Transactions {
+			inPool[i] = bc.memPool.ContainsKey(tx.Hash())
+		}
+		witnessErrs := bc.verifyBlockTxWitnesses(block.Transactions, inPool)
+		for i, tx := range block.Transactions {
 			var err error
-			// Transactions are verified before adding them
-			// into the pool, so there is no point in doing
-			// it again even if we're verifying in-block transactions.
-			if bc.memPool.ContainsKey(tx.Hash()) {
+			if inPool[i] {
 				err = mp.Add(tx, bc)
 				if err == nil {
 					continue
 				}
 			} else {
-				err = bc.verifyAndPoolTx(tx, mp, bc)
+				err = bc.checkAndPoolTx(tx, mp, bc, func(int64, bool) error {
+					return witnessErrs[i]
+				})
 			}
 			if err != nil {
 				if bc.config.VerifyTransactions {
@@ -1519,7 +1491,38 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 			}
 		}
 	}
-	return bc.storeBlock(block, mp)
+	err := bc.addHeaders(false, &block.Header)
+	if err == nil {
+		// The header could be added concurrently.
+		err = bc.checkStoredHeader(block)
+	}
+	if err != nil {
+		return err
+	}
+	if prof == nil {
+		return bc.storeBlock(block, mp, nil)
+	}
+	var (
+		verification = time.Since(start)
+		mptTime      time.Duration
+	)
+	start = time.Now()
+	err = bc.storeBlock(block, mp, &mptTime)
+	if err != nil {
+		return err
+	}
+	prof.BlockAdded(block.Index, verification, time.Since(start)-mptTime, mptTime)
+	return nil
+}
+
+// checkStoredHeader checks that the block matches the header stored for its
+// index.
+func (bc *Blockchain) checkStoredHeader(block *block.Block) error {
+	if h := bc.GetHeaderHash(block.Index); !h.Equals(block.Hash()) {
+		return fmt.Errorf("%w: %d, expected %s, got %s", ErrBlockHeaderMismatch,
+			block.Index, h.StringLE(), block.Hash().StringLE())
+	}
+	return nil
 }
 
 // AddHeaders processes the given headers and add them to the
@@ -1587,24 +1590,72 @@ func (bc *Blockchain) GetStateSyncModule() *statesync.Module {
 	return statesync.NewModule(bc, bc.stateRoot, bc.log, bc.dao, bc.jumpToState)
 }
 
+// pruneAppLogs prunes application logs of blocks up to the given height
+// (inclusive) starting from the first block that is not pruned yet. At most
+// maxAppLogsPruneBatch blocks are processed at once, so logs of an existing
+// database are removed gradually when AppLogsMaxBlocks is enabled or lowered.
+func (bc *Blockchain) pruneAppLogs(d *dao.Simple, till uint32) {
+	start, err := d.GetAppLogsPruneHeight()
+	if err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+		bc.log.Warn("failed to get application logs prune height", zap.Error(err))
+		return
+	}
+	if start > till {
+		return
+	}
+	stop := min(till, start+maxAppLogsPruneBatch-1)
+	for index := start; index <= stop; index++ {
+		err := d.PruneAppExecResults(bc.GetHeaderHash(index))
+		// The block can be removed already (RemoveUntraceableBlocks).
+		if err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+			bc.log.Warn("error while pruning old application logs",
+				zap.Uint32("index", index),
+				zap.Error(err))
+		}
+	}
+	d.PutAppLogsPruneHeight(stop + 1)
+}
+
 // storeBlock performs chain update using the block given, it executes all
 // transactions with all appropriate side-effects and updates Blockchain state.
 // This is the only way to change Blockchain state.
-func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error {
+// storeBlock executes and stores the block. If mptTime is not nil, the time
+// spent on MPT update is stored there.
+func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool, mptTime *time.Duration) (err error) {
 	var (
 		cache          = bc.dao.GetPrivate()
 		aerCache       = bc.dao.GetPrivate()
 		appExecResults = make([]*state.AppExecResult, 0, 2+len(block.Transactions))
 		aerchan        = make(chan *state.AppExecResult, len(block.Transactions)/8) // Tested 8 and 4 with no practical difference, but feel free to test more and tune.
 		aerdone        = make(chan error)
+		trBatch        = bc.transfers.NewBatch(aerCache, block)
+		statsBatch     *contractstats.Batch
+		validators     keys.PublicKeys
+		// Genesis block is stored when Blockchain is not yet running, so there
+		// is no one to read its events.
+		stream = bc.config.Ledger.StreamExecutions && block.Index != 0
 	)
+	if bc.contractStats != nil {
+		statsBatch = bc.contractStats.NewBatch()
+	}
+	if bc.validatorStats != nil && block.Index != 0 {
+		// Validators of this block are the next ones for the previous block.
+		validators = bc.contracts.NEO.GetNextBlockValidatorsInternal(bc.dao)
+	}
+	if stream {
+		defer func() {
+			// Streamed execution results are to be discarded by subscribers.
+			if err != nil {
+				bc.events <- bcEvent{block: block, rejected: true}
+			}
+		}()
+	}
 	go func() {
 		var (
 			kvcache      = aerCache
 			err          error
 			txCnt        int
 			baer1, baer2 *state.AppExecResult
-			transCache   = make(map[util.Uint160]transferData)
 		)
 		kvcache.StoreAsCurrentBlock(block)
 		if bc.config.Ledger.RemoveUntraceableBlocks {
@@ -1630,6 +1681,9 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 				}
 			}
 		}
+		if bc.config.Ledger.AppLogsMaxBlocks != 0 && block.Index >= bc.config.Ledger.AppLogsMaxBlocks {
+			bc.pruneAppLogs(kvcache, block.Index-bc.config.Ledger.AppLogsMaxBlocks)
+		}
 		for aer := range aerchan {
 			if aer.Container == block.Hash() {
 				if baer1 == nil {
@@ -1645,11 +1699,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 				err = fmt.Errorf("failed to store exec result: %w", err)
 				break
 			}
-			if aer.Execution.VMState == vmstate.Halt {
-				for j := range aer.Execution.Events {
-					bc.handleNotification(&aer.Execution.Events[j], kvcache, transCache, block, aer.Container)
-				}
-			}
+			trBatch.AddExecution(aer)
 		}
 		if err != nil {
 			aerdone <- err
@@ -1659,18 +1709,9 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 			aerdone <- err
 			return
 		}
-		for acc, trData := range transCache {
-			err = kvcache.PutTokenTransferInfo(acc, &trData.Info)
-			if err != nil {
-				aerdone <- err
-				return
-			}
-			if !trData.Info.NewNEP11Batch {
-				kvcache.PutTokenTransferLog(acc, trData.Info.NextNEP11NewestTimestamp, trData.Info.NextNEP11Batch, true, &trData.Log11)
-			}
-			if !trData.Info.NewNEP17Batch {
-				kvcache.PutTokenTransferLog(acc, trData.Info.NextNEP17NewestTimestamp, trData.Info.NextNEP17Batch, false, &trData.Log17)
-			}
+		if err := trBatch.Flush(); err != nil {
+			aerdone <- err
+			return
 		}
 		close(aerdone)
 	}()
@@ -1684,6 +1725,9 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	}
 	appExecResults = append(appExecResults, aer)
 	aerchan <- aer
+	if stream {
+		bc.events <- bcEvent{appExecResults: []*state.AppExecResult{aer}}
+	}
 
 	for _, tx := range block.Transactions {
 		systemInterop := bc.newInteropContext(trigger.Application, cache, block, tx)
@@ -1706,7 +1750,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 				zap.String("tx", tx.Hash().StringLE()),
 				zap.Uint32("block", block.Index),
 				zap.Error(err))
-			faultException = err.Error()
+			faultException = bc.DescribeFault(v, err)
 		}
 		aer := &state.AppExecResult{
 			Container: tx.Hash(),
@@ -1719,8 +1763,14 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 				FaultException: faultException,
 			},
 		}
+		if statsBatch != nil {
+			statsBatch.AddExecution(systemInterop.Invocations, aer.GasConsumed, v.HasFailed())
+		}
 		appExecResults = append(appExecResults, aer)
 		aerchan <- aer
+		if stream {
+			bc.events <- bcEvent{appExecResults: []*state.AppExecResult{aer}, tx: tx}
+		}
 	}
 
 	aer, _, err = bc.runPersist(bc.contracts.GetPostPersistScript(), block, cache, trigger.PostPersist, v)
@@ -1732,9 +1782,16 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	}
 	appExecResults = append(appExecResults, aer)
 	aerchan <- aer
+	if stream {
+		bc.events <- bcEvent{appExecResults: []*state.AppExecResult{aer}}
+	}
 	close(aerchan)
+	mptStart := time.Now()
 	b := mpt.MapToMPTBatch(cache.Store.GetStorageChanges())
 	mpt, sr, err := bc.stateRoot.AddMPTBatch(block.Index, b, cache.Store)
+	if mptTime != nil {
+		*mptTime = time.Since(mptStart)
+	}
 	if err != nil {
 		// Release goroutines, don't care about errors, we already have one.
 		<-aerdone
@@ -1800,11 +1857,23 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	bc.lock.Unlock()
 
 	updateBlockHeightMetric(block.Index)
+	bc.transfers.Notify(trBatch)
+	if statsBatch != nil {
+		if p := bc.contractStats.AddBlock(block.Index, statsBatch); p != nil {
+			updateContractStatsMetrics(p)
+		}
+	}
+	if validators != nil {
+		bc.validatorStats.AddBlock(block.Index, block.PrimaryIndex, validators)
+		updateValidatorStatsMetrics(bc.validatorStats.Window())
+	}
 	// Genesis block is stored when Blockchain is not yet running, so there
 	// is no one to read this event. And it doesn't make much sense as event
 	// anyway.
-	if block.Index != 0 {
-		bc.events <- bcEvent{block, appExecResults}
+	if stream {
+		bc.events <- bcEvent{block: block}
+	} else if block.Index != 0 {
+		bc.events <- bcEvent{block: block, appExecResults: appExecResults}
 	}
 	return nil
 }
@@ -1881,155 +1950,156 @@ func (bc *Blockchain) runPersist(script []byte, block *block.Block, cache *dao.S
 	}, v, nil
 }
 
-func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.Simple,
-	transCache map[util.Uint160]transferData, b *block.Block, h util.Uint256) {
-	if note.Name != "Transfer" {
-		return
-	}
-	arr, ok := note.Item.Value().([]stackitem.Item)
-	if !ok || !(len(arr) == 3 || len(arr) == 4) {
-		return
-	}
-	from, err := parseUint160(arr[0])
-	if err != nil {
-		return
-	}
-	to, err := parseUint160(arr[1])
-	if err != nil {
-		return
+// getContractID returns the ID of the contract with the given hash. Native
+// contracts are resolved without DAO access since the DAO used for transfer
+// processing doesn't contain changes made by the current block.
+func (bc *Blockchain) getContractID(d *dao.Simple, h util.Uint160) (int32, error) {
+	nativeContract := bc.contracts.ByHash(h)
+	if nativeContract != nil {
+		return nativeContract.Metadata().ID, nil
 	}
-	amount, err := arr[2].TryInteger()
+	cs, err := native.GetContract(d, h)
 	if err != nil {
-		return
-	}
-	var id []byte
-	if len(arr) == 4 {
-		id, err = arr[3].TryBytes()
-		if err != nil || len(id) > limits.MaxStorageKeyLen {
-			return
-		}
+		return 0, err
 	}
-	bc.processTokenTransfer(d, transCache, h, b, note.ScriptHash, from, to, amount, id)
+	return cs.ID, nil
 }
 
-func parseUint160(itm stackitem.Item) (util.Uint160, error) {
-	_, ok := itm.(stackitem.Null) // Minting or burning.
-	if ok {
-		return util.Uint160{}, nil
-	}
-	bytes, err := itm.TryBytes()
-	if err != nil {
-		return util.Uint160{}, err
-	}
-	return util.Uint160DecodeBytesBE(bytes)
+// SubscribeForTransfers adds the given subscriber to NEP-17/NEP-11 transfer
+// index updates. Subscriber methods are called synchronously from the block
+// processing code, so they must not block.
+func (bc *Blockchain) SubscribeForTransfers(s transfers.Subscriber) {
+	bc.transfers.Subscribe(s)
 }
 
-func (bc *Blockchain) processTokenTransfer(cache *dao.Simple, transCache map[util.Uint160]transferData,
-	h util.Uint256, b *block.Block, sc util.Uint160, from util.Uint160, to util.Uint160,
-	amount *big.Int, tokenID []byte) {
-	var id int32
-	nativeContract := bc.contracts.ByHash(sc)
-	if nativeContract != nil {
-		id = nativeContract.Metadata().ID
-	} else {
-		assetContract, err := native.GetContract(cache, sc)
-		if err != nil {
-			return
+// UnsubscribeFromTransfers removes the given subscriber from NEP-17/NEP-11
+// transfer index updates. Passing non-subscribed subscriber is a no-op.
+func (bc *Blockchain) UnsubscribeFromTransfers(s transfers.Subscriber) {
+	bc.transfers.Unsubscribe(s)
+}
+
+// rebuildContractIDs resolves token contract IDs during transfer index
+// rebuild. Contracts destroyed by the current height can't be found in the
+// current state, so their IDs are tracked via ContractManagement Deploy
+// notifications of the replayed application logs, IDs are allocated
+// sequentially for every deployed contract.
+type rebuildContractIDs struct {
+	bc        *Blockchain
+	nextID    int32
+	destroyed map[util.Uint160]int32
+}
+
+// addExecution tracks contracts deployed by the given execution, it must be
+// called before execution notifications are processed since tokens can be
+// transferred by _deploy method before Deploy notification is emitted.
+func (r *rebuildContractIDs) addExecution(d *dao.Simple, aer *state.AppExecResult) {
+	if aer.VMState != vmstate.Halt {
+		return
+	}
+	for _, ev := range aer.Events {
+		if ev.ScriptHash != r.bc.contracts.Management.Hash || ev.Name != "Deploy" {
+			continue
 		}
-		id = assetContract.ID
-	}
-	var transfer io.Serializable
-	var nep17xfer *state.NEP17Transfer
-	var isNEP11 = (tokenID != nil)
-	if !isNEP11 {
-		nep17xfer = &state.NEP17Transfer{
-			Asset:        id,
-			Amount:       amount,
-			Block:        b.Index,
-			Counterparty: to,
-			Timestamp:    b.Timestamp,
-			Tx:           h,
-		}
-		transfer = nep17xfer
-	} else {
-		nep11xfer := &state.NEP11Transfer{
-			NEP17Transfer: state.NEP17Transfer{
-				Asset:        id,
-				Amount:       amount,
-				Block:        b.Index,
-				Counterparty: to,
-				Timestamp:    b.Timestamp,
-				Tx:           h,
-			},
-			ID: tokenID,
+		arr, ok := ev.Item.Value().([]stackitem.Item)
+		if !ok || len(arr) != 1 {
+			continue
 		}
-		transfer = nep11xfer
-		nep17xfer = &nep11xfer.NEP17Transfer
-	}
-	if !from.Equals(util.Uint160{}) {
-		_ = nep17xfer.Amount.Neg(nep17xfer.Amount)
-		err := appendTokenTransfer(cache, transCache, from, transfer, id, b.Index, b.Timestamp, isNEP11)
-		_ = nep17xfer.Amount.Neg(nep17xfer.Amount)
+		b, err := arr[0].TryBytes()
 		if err != nil {
-			return
+			continue
+		}
+		h, err := util.Uint160DecodeBytesBE(b)
+		if err != nil || r.bc.contracts.ByHash(h) != nil {
+			continue
 		}
+		if cs, err := native.GetContract(d, h); err == nil {
+			r.nextID = max(r.nextID, cs.ID+1)
+			continue
+		}
+		r.destroyed[h] = r.nextID
+		r.nextID++
 	}
-	if !to.Equals(util.Uint160{}) {
-		nep17xfer.Counterparty = from
-		_ = appendTokenTransfer(cache, transCache, to, transfer, id, b.Index, b.Timestamp, isNEP11) // Nothing useful we can do.
+}
+
+// getID implements transfers.ContractIDFunc.
+func (r *rebuildContractIDs) getID(d *dao.Simple, h util.Uint160) (int32, error) {
+	if id, ok := r.destroyed[h]; ok {
+		return id, nil
 	}
+	return r.bc.getContractID(d, h)
 }
 
-func appendTokenTransfer(cache *dao.Simple, transCache map[util.Uint160]transferData, addr util.Uint160, transfer io.Serializable,
-	token int32, bIndex uint32, bTimestamp uint64, isNEP11 bool) error {
-	transferData, ok := transCache[addr]
-	if !ok {
-		balances, err := cache.GetTokenTransferInfo(addr)
+// RebuildTransfers drops NEP-17/NEP-11 transfer index and rebuilds it from the
+// stored blocks and their application logs. It can't be used with
+// RemoveUntraceableBlocks or AppLogsMaxBlocks settings enabled since old
+// blocks or logs are not available then. Subscribers are not notified since
+// the rebuilt index contains the same transfers they've received already.
+func (bc *Blockchain) RebuildTransfers() error {
+	if bc.config.Ledger.RemoveUntraceableBlocks {
+		return errors.New("can't rebuild transfers with RemoveUntraceableBlocks enabled")
+	}
+	if bc.config.Ledger.AppLogsMaxBlocks != 0 {
+		return errors.New("can't rebuild transfers with AppLogsMaxBlocks enabled")
+	}
+	bc.addLock.Lock()
+	defer bc.addLock.Unlock()
+
+	var (
+		height = bc.BlockHeight()
+		cache  = bc.dao.GetPrivate()
+		ids    = &rebuildContractIDs{
+			bc:        bc,
+			nextID:    1,
+			destroyed: make(map[util.Uint160]int32),
+		}
+	)
+	bc.log.Info("rebuilding NEP transfers", zap.Uint32("height", height))
+	start := time.Now()
+	bc.transfers.Drop(cache)
+	for i := uint32(0); i <= height; i++ {
+		b, err := cache.GetBlock(bc.GetHeaderHash(i))
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to get block %d: %w", i, err)
 		}
-		if !balances.NewNEP11Batch {
-			trLog, err := cache.GetTokenTransferLog(addr, balances.NextNEP11NewestTimestamp, balances.NextNEP11Batch, true)
+		aers, err := cache.GetAppExecResults(b.Hash(), trigger.OnPersist|trigger.PostPersist)
+		if err != nil {
+			return fmt.Errorf("failed to get application logs of block %d: %w", i, err)
+		}
+		if len(aers) != 2 {
+			return fmt.Errorf("unexpected number of application logs of block %d: %d", i, len(aers))
+		}
+		batch := bc.transfers.NewBatchWithIDFunc(cache, b, ids.getID)
+		addExecution := func(aer *state.AppExecResult) {
+			ids.addExecution(cache, aer)
+			batch.AddExecution(aer)
+		}
+		addExecution(&aers[0])
+		for _, tx := range b.Transactions {
+			txAers, err := cache.GetAppExecResults(tx.Hash(), trigger.Application)
 			if err != nil {
-				return err
+				return fmt.Errorf("failed to get application log of transaction %s: %w", tx.Hash().StringLE(), err)
+			}
+			if len(txAers) != 1 {
+				return fmt.Errorf("unexpected number of application logs of transaction %s: %d", tx.Hash().StringLE(), len(txAers))
 			}
-			transferData.Log11 = *trLog
+			addExecution(&txAers[0])
 		}
-		if !balances.NewNEP17Batch {
-			trLog, err := cache.GetTokenTransferLog(addr, balances.NextNEP17NewestTimestamp, balances.NextNEP17Batch, false)
+		addExecution(&aers[1])
+		err = batch.Flush()
+		if err != nil {
+			return fmt.Errorf("failed to store transfers of block %d: %w", i, err)
+		}
+		if i%rebuildTransfersPersistPeriod == 0 || i == height {
+			bc.lock.Lock()
+			_, err = cache.Persist()
+			bc.lock.Unlock()
 			if err != nil {
-				return err
+				return fmt.Errorf("failed to persist transfers of block %d: %w", i, err)
 			}
-			transferData.Log17 = *trLog
+			cache = bc.dao.GetPrivate()
 		}
-		transferData.Info = *balances
-	}
-	var (
-		log           *state.TokenTransferLog
-		nextBatch     uint32
-		currTimestamp uint64
-	)
-	if !isNEP11 {
-		log = &transferData.Log17
-		nextBatch = transferData.Info.NextNEP17Batch
-		currTimestamp = transferData.Info.NextNEP17NewestTimestamp
-	} else {
-		log = &transferData.Log11
-		nextBatch = transferData.Info.NextNEP11Batch
-		currTimestamp = transferData.Info.NextNEP11NewestTimestamp
-	}
-	err := log.Append(transfer)
-	if err != nil {
-		return err
-	}
-	newBatch := log.Size() >= state.TokenTransferBatchSize
-	if newBatch {
-		cache.PutTokenTransferLog(addr, currTimestamp, nextBatch, isNEP11, log)
-		// Put makes a copy of it anyway.
-		log.Reset()
 	}
-	appendTokenTransferInfo(&transferData.Info, token, bIndex, bTimestamp, isNEP11, newBatch)
-	transCache[addr] = transferData
+	bc.log.Info("NEP transfers are rebuilt", zap.Duration("took", time.Since(start)))
 	return nil
 }
 
@@ -2037,14 +2107,14 @@ func appendTokenTransfer(cache *dao.Simple, transCache map[util.Uint160]transfer
 // the transfer with the newest timestamp up to the oldest transfer. It continues
 // iteration until false is returned from f. The last non-nil error is returned.
 func (bc *Blockchain) ForEachNEP17Transfer(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP17Transfer) (bool, error)) error {
-	return bc.dao.SeekNEP17TransferLog(acc, newestTimestamp, f)
+	return bc.transfers.ForEachNEP17Transfer(bc.dao, acc, newestTimestamp, f)
 }
 
 // ForEachNEP11Transfer executes f for each NEP-11 transfer in log starting from
 // the transfer with the newest timestamp up to the oldest transfer. It continues
 // iteration until false is returned from f. The last non-nil error is returned.
 func (bc *Blockchain) ForEachNEP11Transfer(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP11Transfer) (bool, error)) error {
-	return bc.dao.SeekNEP11TransferLog(acc, newestTimestamp, f)
+	return bc.transfers.ForEachNEP11Transfer(bc.dao, acc, newestTimestamp, f)
 }
 
 // GetNEP17Contracts returns the list of deployed NEP-17 contracts.
@@ -2061,23 +2131,23 @@ func (bc *Blockchain) GetNEP11Contracts() []util.Uint160 {
 // block indexes. In case of an empty account, latest stored state synchronisation point
 // is returned under Math.MinInt32 key.
 func (bc *Blockchain) GetTokenLastUpdated(acc util.Uint160) (map[int32]uint32, error) {
-	info, err := bc.dao.GetTokenTransferInfo(acc)
+	lastUpdated, err := bc.transfers.GetLastUpdated(bc.dao, acc)
 	if err != nil {
 		return nil, err
 	}
 	if bc.config.P2PStateExchangeExtensions && bc.config.Ledger.RemoveUntraceableBlocks {
-		if _, ok := info.LastUpdated[bc.contracts.NEO.ID]; !ok {
+		if _, ok := lastUpdated[bc.contracts.NEO.ID]; !ok {
 			nBalance, lub := bc.contracts.NEO.BalanceOf(bc.dao, acc)
 			if nBalance.Sign() != 0 {
-				info.LastUpdated[bc.contracts.NEO.ID] = lub
+				lastUpdated[bc.contracts.NEO.ID] = lub
 			}
 		}
 	}
 	stateSyncPoint, err := bc.dao.GetStateSyncPoint()
 	if err == nil {
-		info.LastUpdated[math.MinInt32] = stateSyncPoint
+		lastUpdated[math.MinInt32] = stateSyncPoint
 	}
-	return info.LastUpdated, nil
+	return lastUpdated, nil
 }
 
 // GetUtilityTokenBalance returns utility token (GAS) balance for the acc.
@@ -2095,6 +2165,31 @@ func (bc *Blockchain) GetGoverningTokenBalance(acc util.Uint160) (*big.Int, uint
 	return bc.contracts.NEO.BalanceOf(bc.dao, acc)
 }
 
+// GetNEOAccountState returns NEO state (balance, its update height and the
+// vote target) of the specified account, nil is returned for accounts that
+// don't have one.
+func (bc *Blockchain) GetNEOAccountState(acc util.Uint160) (*state.NEOBalance, error) {
+	return bc.contracts.NEO.GetAccountState(bc.dao, acc)
+}
+
+// ProjectClaimable returns the amount of GAS the specified account would be
+// able to claim at the given height (not lower than the next block height)
+// without claiming it. Future blocks are assumed to have the current GAS per
+// block value and voter reward includes only the part already accrued.
+func (bc *Blockchain) ProjectClaimable(acc util.Uint160, endHeight uint32) (*big.Int, error) {
+	if next := bc.BlockHeight() + 1; endHeight < next {
+		return nil, fmt.Errorf("height %d is lower than the next block height %d", endHeight, next)
+	}
+	st, err := bc.contracts.NEO.GetAccountState(bc.dao, acc)
+	if err != nil {
+		return nil, err
+	}
+	if st == nil {
+		return big.NewInt(0), nil
+	}
+	return bc.contracts.NEO.ProjectBonus(bc.dao, st, endHeight)
+}
+
 // GetNotaryBalance returns Notary deposit amount for the specified account.
 func (bc *Blockchain) GetNotaryBalance(acc util.Uint160) *big.Int {
 	return bc.contracts.Notary.BalanceOf(bc.dao, acc)
@@ -2163,6 +2258,12 @@ func (bc *Blockchain) persist(isSync bool) (time.Duration, error) {
 
 		// update monitoring metrics.
 		updatePersistedHeightMetric(bHeight)
+		if bolt, ok := bc.store.(*storage.BoltDBStore); ok {
+			st, err := bolt.Stats()
+			if err == nil {
+				updateBoltDBMetrics(st)
+			}
+		}
 	}
 
 	return duration, nil
@@ -2176,6 +2277,26 @@ func (bc *Blockchain) GetTransaction(hash util.Uint256) (*transaction.Transactio
 	return bc.dao.GetTransaction(hash)
 }
 
+// GetContractStats returns per-contract execution statistics for the last
+// completed period of ContractStatsPeriod blocks. It returns an error if
+// statistics collection is disabled and nil if no period is completed yet.
+func (bc *Blockchain) GetContractStats() (*contractstats.Period, error) {
+	if bc.contractStats == nil {
+		return nil, errors.New("contract statistics collection is disabled")
+	}
+	return bc.contractStats.Last(), nil
+}
+
+// GetValidatorStats returns block production statistics of validators for
+// the last ValidatorStatsWindow blocks. It returns an error if statistics
+// collection is disabled and nil if no blocks were added since node start.
+func (bc *Blockchain) GetValidatorStats() (*validatorstats.Window, error) {
+	if bc.validatorStats == nil {
+		return nil, errors.New("validator statistics collection is disabled")
+	}
+	return bc.validatorStats.Window(), nil
+}
+
 // GetAppExecResults returns application execution results with the specified trigger by the given
 // tx hash or block hash.
 func (bc *Blockchain) GetAppExecResults(hash util.Uint256, trig trigger.Type) ([]state.AppExecResult, error) {
@@ -2192,6 +2313,26 @@ func (bc *Blockchain) SeekStorage(id int32, prefix []byte, cont func(k, v []byte
 	bc.dao.Seek(id, storage.SeekRange{Prefix: prefix}, cont)
 }
 
+// ComputeStorageRoot builds MPT from scratch using all current contract storage
+// items and returns its root hash. It doesn't use any stored MPT data, so it
+// can be compared with the current local state root to check state
+// consistency. This operation is slow and keeps the whole trie in memory, it
+// must not be performed on a running chain.
+func (bc *Blockchain) ComputeStorageRoot() (util.Uint256, error) {
+	var (
+		err error
+		tr  = mpt.NewTrie(nil, mpt.ModeLatest, storage.NewMemCachedStore(storage.NewMemoryStore()))
+	)
+	bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(bc.dao.Version.StoragePrefix)}}, func(k, v []byte) bool {
+		err = tr.Put(slices.Clone(k[1:]), slices.Clone(v))
+		return err == nil
+	})
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to put storage item into MPT: %w", err)
+	}
+	return tr.StateRoot(), nil
+}
+
 // GetBlock returns a Block by the given hash.
 func (bc *Blockchain) GetBlock(hash util.Uint256) (*block.Block, error) {
 	topBlock := bc.topBlock.Load()
@@ -2304,6 +2445,40 @@ func (bc *Blockchain) GetNatives() []state.Contract {
 	return res
 }
 
+// GetNativesAt returns list of native contracts states active at the specified
+// height. States are built from the native contracts metadata, so the height
+// may be any (including future ones), the result is based on the hardforks
+// configuration of the chain.
+func (bc *Blockchain) GetNativesAt(height uint32) []state.Contract {
+	res := make([]state.Contract, 0, len(bc.contracts.Contracts))
+	hf := bc.getHFAt(height)
+	for _, c := range bc.contracts.Contracts {
+		activeIn := c.ActiveIn()
+		if !(activeIn == nil || activeIn.Cmp(hf) <= 0) {
+			continue
+		}
+		md := c.Metadata()
+		var deployHeight uint32
+		if activeIn != nil {
+			deployHeight = bc.config.Hardforks[activeIn.String()]
+		}
+		// Native contract is updated once per every height some of its
+		// hardforks are enabled at except the deployment one.
+		updates := make(map[uint32]struct{})
+		for activeHF := range md.ActiveHFs {
+			enableHeight, ok := bc.config.Hardforks[activeHF.String()]
+			if ok && activeHF.Cmp(hf) <= 0 && enableHeight != deployHeight {
+				updates[enableHeight] = struct{}{}
+			}
+		}
+		res = append(res, state.Contract{
+			ContractBase:  md.HFSpecificContractMD(&hf).ContractBase,
+			UpdateCounter: uint16(len(updates)),
+		})
+	}
+	return res
+}
+
 // GetConfig returns the config stored in the blockchain.
 func (bc *Blockchain) GetConfig() config.Blockchain {
 	return bc.config
@@ -2360,6 +2535,17 @@ func (bc *Blockchain) SubscribeForExecutions(ch chan *state.AppExecResult) {
 	bc.subCh <- ch
 }
 
+// SubscribeForRejectedBlocks adds given channel to rejected block event
+// broadcasting, so when a block execution results of which were streamed to
+// subscribers (see StreamExecutions ledger setting) fails to be stored you'll
+// receive its hash and index via this channel. Execution results,
+// notifications and transactions received for this block are to be discarded
+// then. Make sure it's read from regularly as not reading these events might
+// affect other Blockchain functions.
+func (bc *Blockchain) SubscribeForRejectedBlocks(ch chan *state.RejectedBlock) {
+	bc.subCh <- ch
+}
+
 // UnsubscribeFromBlocks unsubscribes given channel from new block notifications,
 // you can close it afterwards. Passing non-subscribed channel is a no-op, but
 // the method can read from this channel (discarding any read data).
@@ -2432,6 +2618,20 @@ unsubloop:
 	}
 }
 
+// UnsubscribeFromRejectedBlocks unsubscribes given channel from rejected block
+// notifications, you can close it afterwards. Passing non-subscribed channel is
+// a no-op, but the method can read from this channel (discarding any read data).
+func (bc *Blockchain) UnsubscribeFromRejectedBlocks(ch chan *state.RejectedBlock) {
+unsubloop:
+	for {
+		select {
+		case <-ch:
+		case bc.unsubCh <- ch:
+			break unsubloop
+		}
+	}
+}
+
 // CalculateClaimable calculates the amount of GAS generated by owning specified
 // amount of NEO between specified blocks.
 func (bc *Blockchain) CalculateClaimable(acc util.Uint160, endHeight uint32) (*big.Int, error) {
@@ -2443,6 +2643,14 @@ func (bc *Blockchain) CalculateClaimable(acc util.Uint160, endHeight uint32) (*b
 	return bc.contracts.NEO.CalculateBonus(ic, acc, endHeight)
 }
 
+// GetFeatureFlag returns the value of the given feature flag set by the
+// committee via Policy contract and a flag specifying whether it's set at all.
+// Feature flags are read at runtime to control non-consensus node behavior
+// (like relay policies), they're never set before the Echidna hardfork.
+func (bc *Blockchain) GetFeatureFlag(name string) (int64, bool) {
+	return bc.contracts.Policy.GetFeatureFlagInternal(bc.dao, name)
+}
+
 // FeePerByte returns transaction network fee per byte.
 func (bc *Blockchain) FeePerByte() int64 {
 	return bc.contracts.Policy.GetFeePerByteInternal(bc.dao)
@@ -2535,6 +2743,16 @@ var (
 // verifyAndPoolTx verifies whether a transaction is bonafide or not and tries
 // to add it to the mempool given.
 func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.Pool, feer mempool.Feer, data ...any) error {
+	return bc.checkAndPoolTx(t, pool, feer, func(netFee int64, isPartialTx bool) error {
+		return bc.verifyTxWitnesses(t, nil, isPartialTx, netFee)
+	}, data...)
+}
+
+// checkAndPoolTx is an internal implementation of verifyAndPoolTx that uses
+// the given function to verify transaction witnesses, it allows witnesses to
+// be verified in advance.
+func (bc *Blockchain) checkAndPoolTx(t *transaction.Transaction, pool *mempool.Pool, feer mempool.Feer,
+	verifyWitnesses func(netFee int64, isPartialTx bool) error, data ...any) error {
 	// This code can technically be moved out of here, because it doesn't
 	// really require a chain lock.
 	err := vm.IsScriptCorrect(t.Script, nil)
@@ -2575,7 +2793,7 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 			return err
 		}
 	}
-	err = bc.verifyTxWitnesses(t, nil, isPartialTx, netFee)
+	err = verifyWitnesses(netFee, isPartialTx)
 	if err != nil {
 		return err
 	}
@@ -2751,11 +2969,11 @@ func (bc *Blockchain) VerifyTx(t *transaction.Transaction) error {
 
 // PoolTx verifies and tries to add given transaction into the mempool. If not
 // given, the default mempool is used. Passing multiple pools is not supported.
+// Witnesses are verified against a snapshot of the current chain state before
+// taking the chain lock, so verification doesn't block new blocks.
 func (bc *Blockchain) PoolTx(t *transaction.Transaction, pools ...*mempool.Pool) error {
 	var pool = bc.memPool
 
-	bc.lock.RLock()
-	defer bc.lock.RUnlock()
 	// Programmer error.
 	if len(pools) > 1 {
 		panic("too many pools given")
@@ -2763,7 +2981,38 @@ func (bc *Blockchain) PoolTx(t *transaction.Transaction, pools ...*mempool.Pool)
 	if len(pools) == 1 {
 		pool = pools[0]
 	}
-	return bc.verifyAndPoolTx(t, pool, bc)
+	var verifyWitnesses = func(netFee int64, isPartialTx bool) error {
+		return bc.verifyTxWitnesses(t, nil, isPartialTx, netFee)
+	}
+	// Don't waste time on duplicates, they're rejected anyway.
+	if !pool.ContainsKey(t.Hash()) {
+		verifyWitnesses = bc.verifyTxWitnessesInAdvance(t)
+	}
+
+	bc.lock.RLock()
+	defer bc.lock.RUnlock()
+	return bc.checkAndPoolTx(t, pool, bc, verifyWitnesses)
+}
+
+// verifyTxWitnessesInAdvance verifies transaction witnesses against a snapshot
+// of the current chain state without holding the chain lock. It returns
+// a witness checker for checkAndPoolTx that reuses the result if the chain
+// height hasn't changed since then and verifies witnesses again otherwise.
+func (bc *Blockchain) verifyTxWitnessesInAdvance(t *transaction.Transaction) func(netFee int64, isPartialTx bool) error {
+	bc.lock.RLock()
+	var (
+		d      = bc.dao.GetSnapshot()
+		height = bc.BlockHeight()
+	)
+	bc.lock.RUnlock()
+	err := bc.verifyTxWitnessesWithDAO(d, t, nil, false)
+	_ = d.Store.Close()
+	return func(netFee int64, isPartialTx bool) error {
+		if bc.BlockHeight() != height {
+			return bc.verifyTxWitnesses(t, nil, isPartialTx, netFee)
+		}
+		return err
+	}
 }
 
 // PoolTxWithData verifies and tries to add given transaction with additional data into the mempool.
@@ -2814,16 +3063,26 @@ func (bc *Blockchain) GetEnrollments() ([]state.Validator, error) {
 }
 
 // GetTestVM returns an interop context with VM set up for a test run.
+// The context works with a copy-on-write snapshot of the current chain state,
+// so it's not affected by blocks added during execution, the snapshot is
+// released by the context's Finalize.
 func (bc *Blockchain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) (*interop.Context, error) {
+	bc.lock.RLock()
+	var (
+		d = bc.dao.GetSnapshot()
+		h = bc.BlockHeight() + 1
+	)
+	bc.lock.RUnlock()
 	if b == nil {
 		var err error
-		h := bc.BlockHeight() + 1
 		b, err = bc.getFakeNextBlock(h)
 		if err != nil {
+			_ = d.Store.Close()
 			return nil, fmt.Errorf("failed to create fake block for height %d: %w", h, err)
 		}
 	}
-	systemInterop := bc.newInteropContext(t, bc.dao, b, tx)
+	systemInterop := bc.newInteropContext(t, d, b, tx)
+	systemInterop.RegisterCancelFunc(func() { _ = d.Store.Close() })
 	_ = systemInterop.SpawnVM() // All the other code suppose that the VM is ready.
 	return systemInterop, nil
 }
@@ -2878,6 +3137,74 @@ func (bc *Blockchain) getFakeNextBlock(nextBlockHeight uint32) (*block.Block, er
 	return b, nil
 }
 
+// SimulateTransactions executes the given transactions in a fake next block on
+// top of the current chain state the same way storeBlock does it (including
+// OnPersist and PostPersist native calls), but without persisting anything. It
+// returns application execution results for OnPersist trigger, every
+// transaction and PostPersist trigger (in this order) and storage changes made
+// by the whole bundle. Transactions are not verified, so they can be unsigned,
+// but FAULTed transaction changes are discarded just like in a real block and
+// subsequent transactions see the changes made by the previous HALTed ones.
+func (bc *Blockchain) SimulateTransactions(txs []*transaction.Transaction) ([]*state.AppExecResult, []dboper.Operation, error) {
+	bc.lock.RLock()
+	var (
+		d = bc.dao.GetSnapshot()
+		h = bc.BlockHeight() + 1
+	)
+	bc.lock.RUnlock()
+	defer func() { _ = d.Store.Close() }()
+
+	b, err := bc.getFakeNextBlock(h)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create fake block for height %d: %w", h, err)
+	}
+	b.Transactions = txs
+
+	var (
+		cache = d.GetPrivate()
+		aers  = make([]*state.AppExecResult, 0, 2+len(txs))
+	)
+	aer, v, err := bc.runPersist(bc.contracts.GetPersistScript(), b, cache, trigger.OnPersist, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("onPersist failed: %w", err)
+	}
+	aers = append(aers, aer)
+	for _, tx := range txs {
+		systemInterop := bc.newInteropContext(trigger.Application, cache, b, tx)
+		systemInterop.ReuseVM(v)
+		v.LoadScriptWithFlags(tx.Script, callflag.All)
+		v.GasLimit = tx.SystemFee
+
+		err := systemInterop.Exec()
+		var faultException string
+		if !v.HasFailed() {
+			_, err := systemInterop.DAO.Persist()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to persist invocation results: %w", err)
+			}
+		} else {
+			faultException = bc.DescribeFault(v, err)
+		}
+		aers = append(aers, &state.AppExecResult{
+			Container: tx.Hash(),
+			Execution: state.Execution{
+				Trigger:        trigger.Application,
+				VMState:        v.State(),
+				GasConsumed:    v.GasConsumed(),
+				Stack:          v.Estack().ToArray(),
+				Events:         systemInterop.Notifications,
+				FaultException: faultException,
+			},
+		})
+	}
+	aer, _, err = bc.runPersist(bc.contracts.GetPostPersistScript(), b, cache, trigger.PostPersist, v)
+	if err != nil {
+		return nil, nil, fmt.Errorf("postPersist failed: %w", err)
+	}
+	aers = append(aers, aer)
+	return aers, storage.BatchToOperations(cache.GetBatch()), nil
+}
+
 // Various witness verification errors.
 var (
 	ErrWitnessHashMismatch         = errors.New("witness hash mismatch")
@@ -2985,7 +3312,13 @@ func (bc *Blockchain) verifyHashAgainstScript(hash util.Uint160, witness *transa
 // verification.
 // Golang implementation of VerifyWitnesses method in C# (https://github.com/neo-project/neo/blob/master/neo/SmartContract/Helper.cs#L87).
 func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block.Block, isPartialTx bool, verificationFee ...int64) error {
-	interopCtx := bc.newInteropContext(trigger.Verification, bc.dao, block, t)
+	return bc.verifyTxWitnessesWithDAO(bc.dao, t, block, isPartialTx, verificationFee...)
+}
+
+// verifyTxWitnessesWithDAO is the same as verifyTxWitnesses, but verification
+// scripts are executed against the given DAO.
+func (bc *Blockchain) verifyTxWitnessesWithDAO(d *dao.Simple, t *transaction.Transaction, block *block.Block, isPartialTx bool, verificationFee ...int64) error {
+	interopCtx := bc.newInteropContext(trigger.Verification, d, block, t)
 	var gasLimit int64
 	if len(verificationFee) == 0 {
 		gasLimit = t.NetworkFee - int64(t.Size())*bc.FeePerByte() - bc.CalculateAttributesFee(t)
@@ -3004,9 +3337,105 @@ func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block
 	return nil
 }
 
+// verifyBlockTxWitnesses verifies witnesses of the given block transactions
+// concurrently using up to VerificationWorkers workers and returns
+// verification results in the same order. Transactions marked as skipped are
+// not verified. Witnesses don't depend on each other and verification doesn't
+// change the chain state, so the results are the same as for sequential
+// verification.
+func (bc *Blockchain) verifyBlockTxWitnesses(txes []*transaction.Transaction, skip []bool) []error {
+	var (
+		errs    = make([]error, len(txes))
+		workers = min(bc.config.VerificationWorkers, len(txes))
+		next    atomic.Int64
+		wg      sync.WaitGroup
+	)
+	verify := func() {
+		for {
+			i := int(next.Add(1) - 1)
+			if i >= len(txes) {
+				return
+			}
+			if !skip[i] {
+				errs[i] = bc.verifyTxWitnesses(txes[i], nil, false)
+			}
+		}
+	}
+	if workers <= 1 {
+		verify()
+		return errs
+	}
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			verify()
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// PreverifyHeader verifies the header witness against its own verification
+// script before the block with this header is added. It allows to verify
+// witnesses of the subsequent headers concurrently (which is useful for bulk
+// block import), successful verification result is kept until the header
+// is verified against the previous one, so that only a cheap script hash
+// check is performed at that stage. Only standard signature and
+// multisignature witnesses can be preverified, other ones are left for the
+// regular verification.
+func (bc *Blockchain) PreverifyHeader(h *block.Header) error {
+	if bc.config.SkipBlockVerification {
+		return nil
+	}
+	w := h.Script
+	if !vm.IsSignatureContract(w.VerificationScript) && !vm.IsMultiSigContract(w.VerificationScript) {
+		return nil
+	}
+	_, err := bc.VerifyWitness(w.ScriptHash(), h, &w, HeaderVerificationGasLimit)
+	if err != nil {
+		return fmt.Errorf("header %d: %w", h.Index, err)
+	}
+	bc.preverifiedLock.Lock()
+	if bc.preverified == nil {
+		bc.preverified = make(map[util.Uint256]transaction.Witness)
+	}
+	bc.preverified[h.Hash()] = w.Copy()
+	bc.preverifiedLock.Unlock()
+	return nil
+}
+
+// isPreverified checks whether the header witness was successfully verified by
+// PreverifyHeader and has the given script hash. Preverified witness is
+// forgotten after this check.
+func (bc *Blockchain) isPreverified(h *block.Header, scriptHash util.Uint160) bool {
+	bc.preverifiedLock.Lock()
+	w, ok := bc.preverified[h.Hash()]
+	if ok {
+		delete(bc.preverified, h.Hash())
+	}
+	bc.preverifiedLock.Unlock()
+	return ok && w.ScriptHash() == scriptHash &&
+		bytes.Equal(w.InvocationScript, h.Script.InvocationScript) &&
+		bytes.Equal(w.VerificationScript, h.Script.VerificationScript)
+}
+
+// ForgetPreverified drops all header witnesses kept by PreverifyHeader. It
+// should be called when preverified headers are not going to be added (like
+// when block import is stopped), otherwise they're kept until the node
+// shutdown.
+func (bc *Blockchain) ForgetPreverified() {
+	bc.preverifiedLock.Lock()
+	bc.preverified = nil
+	bc.preverifiedLock.Unlock()
+}
+
 // verifyHeaderWitnesses is a block-specific implementation of VerifyWitnesses logic.
 func (bc *Blockchain) verifyHeaderWitnesses(currHeader, prevHeader *block.Header) error {
 	hash := prevHeader.NextConsensus
+	if bc.isPreverified(currHeader, hash) {
+		return nil
+	}
 	_, err := bc.VerifyWitness(hash, currHeader, &currHeader.Script, HeaderVerificationGasLimit)
 	return err
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/core/transfers"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -76,6 +77,30 @@ func TestAddBlock(t *testing.T) {
 	assert.Equal(t, lastBlock.Hash(), bc.CurrentHeaderHash())
 }
 
+type testBlockProfiler struct {
+	indexes []uint32
+}
+
+func (p *testBlockProfiler) BlockAdded(index uint32, verification, execution, mpt time.Duration) {
+	p.indexes = append(p.indexes, index)
+}
+
+func (p *testBlockProfiler) Persisted(time.Duration) {}
+
+func TestBlockchain_SetBlockProfiler(t *testing.T) {
+	bc := newTestChain(t)
+	prof := new(testBlockProfiler)
+	bc.SetBlockProfiler(prof)
+	_, err := bc.genBlocks(2)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{1, 2}, prof.indexes)
+
+	bc.SetBlockProfiler(nil)
+	_, err = bc.genBlocks(1)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{1, 2}, prof.indexes)
+}
+
 func TestRemoveOldTransfers(t *testing.T) {
 	// Creating proper number of transfers/blocks takes unnecessary time, so emulate
 	// some DB with stale entries.
@@ -90,16 +115,16 @@ func TestRemoveOldTransfers(t *testing.T) {
 	ttl := state.TokenTransferLog{Raw: []byte{1}} // It's incorrect, but who cares.
 
 	for i := range uint32(3) {
-		bc.dao.PutTokenTransferLog(acc1, older, i, false, &ttl)
+		transfers.PutLog(bc.dao, acc1, older, i, false, &ttl)
 	}
 	for i := range uint32(3) {
-		bc.dao.PutTokenTransferLog(acc2, newer, i, false, &ttl)
+		transfers.PutLog(bc.dao, acc2, newer, i, false, &ttl)
 	}
 	for i := range uint32(2) {
-		bc.dao.PutTokenTransferLog(acc3, older, i, true, &ttl)
+		transfers.PutLog(bc.dao, acc3, older, i, true, &ttl)
 	}
 	for i := range uint32(2) {
-		bc.dao.PutTokenTransferLog(acc3, newer, i, true, &ttl)
+		transfers.PutLog(bc.dao, acc3, newer, i, true, &ttl)
 	}
 
 	_, err = bc.dao.Persist()
@@ -107,31 +132,31 @@ func TestRemoveOldTransfers(t *testing.T) {
 	_ = bc.removeOldTransfers(0)
 
 	for i := range uint32(2) {
-		log, err := bc.dao.GetTokenTransferLog(acc1, older, i, false)
+		log, err := transfers.GetLog(bc.dao, acc1, older, i, false)
 		require.NoError(t, err)
 		require.Equal(t, 0, len(log.Raw))
 	}
 
-	log, err := bc.dao.GetTokenTransferLog(acc1, older, 2, false)
+	log, err := transfers.GetLog(bc.dao, acc1, older, 2, false)
 	require.NoError(t, err)
 	require.NotEqual(t, 0, len(log.Raw))
 
 	for i := range uint32(3) {
-		log, err = bc.dao.GetTokenTransferLog(acc2, newer, i, false)
+		log, err = transfers.GetLog(bc.dao, acc2, newer, i, false)
 		require.NoError(t, err)
 		require.NotEqual(t, 0, len(log.Raw))
 	}
 
-	log, err = bc.dao.GetTokenTransferLog(acc3, older, 0, true)
+	log, err = transfers.GetLog(bc.dao, acc3, older, 0, true)
 	require.NoError(t, err)
 	require.Equal(t, 0, len(log.Raw))
 
-	log, err = bc.dao.GetTokenTransferLog(acc3, older, 1, true)
+	log, err = transfers.GetLog(bc.dao, acc3, older, 1, true)
 	require.NoError(t, err)
 	require.NotEqual(t, 0, len(log.Raw))
 
 	for i := range uint32(2) {
-		log, err = bc.dao.GetTokenTransferLog(acc3, newer, i, true)
+		log, err = transfers.GetLog(bc.dao, acc3, newer, i, true)
 		require.NoError(t, err)
 		require.NotEqual(t, 0, len(log.Raw))
 	}
@@ -360,6 +385,34 @@ func TestBlockchain_IsRunning(t *testing.T) {
 	require.False(t, chain.isRunning.Load().(bool))
 }
 
+func TestBlockchain_GetNativesAt(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.Hardforks = map[string]uint32{
+			config.HFAspidochelone.String(): 0,
+			config.HFBasilisk.String():      0,
+			config.HFCockatrice.String():    2,
+			config.HFDomovoi.String():       2,
+			config.HFEchidna.String():       4,
+		}
+		// Echidna-enabled CryptoLib methods are NeoGo extensions.
+		c.ProtocolConfiguration.NeoGoExtensions = true
+	})
+	const height = 5
+	// Natives states at every height including the future one.
+	expected := [][]state.Contract{bc.GetNatives()}
+	for range height {
+		_, err := bc.genBlocks(1)
+		require.NoError(t, err)
+		expected = append(expected, bc.GetNatives())
+	}
+	for h := range uint32(height + 1) {
+		require.Equal(t, expected[h], bc.GetNativesAt(h), h)
+	}
+	require.Equal(t, expected[height], bc.GetNativesAt(height+100))
+	require.NotEqual(t, expected[0], expected[2])
+	require.NotEqual(t, expected[2], expected[4])
+}
+
 func TestNewBlockchain_InitHardforks(t *testing.T) {
 	t.Run("nil set", func(t *testing.T) {
 		bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
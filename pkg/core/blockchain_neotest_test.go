@@ -2,9 +2,11 @@ package core_test
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -20,8 +22,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/contractstats"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
@@ -33,6 +37,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/core/transfers"
+	"github.com/nspcc-dev/neo-go/pkg/core/validatorstats"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -41,6 +47,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -146,6 +153,15 @@ func TestBlockchain_StartFromExistingDB(t *testing.T) {
 		require.Error(t, err)
 		require.True(t, strings.Contains(err.Error(), "KeepOnlyLatestState setting mismatch"), err)
 	})
+	t.Run("mismatch CompressAppLogs", func(t *testing.T) {
+		ps = newPS(t)
+		_, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
+			customConfig(c)
+			c.Ledger.CompressAppLogs = true
+		}, ps)
+		require.Error(t, err)
+		require.True(t, strings.Contains(err.Error(), "CompressAppLogs setting mismatch"), err)
+	})
 	t.Run("Magic mismatch", func(t *testing.T) {
 		ps = newPS(t)
 		_, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
@@ -318,6 +334,36 @@ func TestBlockchain_InitializeNeoCache_Bug3181(t *testing.T) {
 	})
 }
 
+// TestBlockchain_FeatureFlagsRestore ensures that feature flags are properly
+// restored from the storage after node restart.
+func TestBlockchain_FeatureFlagsRestore(t *testing.T) {
+	enableExtensions := func(cfg *config.Blockchain) {
+		cfg.NeoGoExtensions = true
+	}
+	ps, path := newLevelDBForTestingWithPath(t, "")
+	bc, validators, committee, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, enableExtensions, ps)
+	require.NoError(t, err)
+	go bc.Run()
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	e.ValidatorInvoker(e.NativeHash(t, nativenames.Gas)).Invoke(t, true, "transfer", e.Validator.ScriptHash(), e.CommitteeHash, 100_0000_0000, nil)
+	policyInvoker := e.CommitteeInvoker(e.NativeHash(t, nativenames.Policy))
+	policyInvoker.Invoke(t, stackitem.Null{}, "setFeatureFlag", "one", 1)
+	policyInvoker.Invoke(t, stackitem.Null{}, "setFeatureFlag", "two", 2)
+	policyInvoker.Invoke(t, stackitem.Null{}, "deleteFeatureFlag", "one")
+
+	bc.Close()
+	ps, _ = newLevelDBForTestingWithPath(t, path)
+	t.Cleanup(func() { require.NoError(t, ps.Close()) })
+	bc, _, _, err = chain.NewMultiWithCustomConfigAndStoreNoCheck(t, enableExtensions, ps)
+	require.NoError(t, err)
+
+	_, ok := bc.GetFeatureFlag("one")
+	require.False(t, ok)
+	v, ok := bc.GetFeatureFlag("two")
+	require.True(t, ok)
+	require.Equal(t, int64(2), v)
+}
+
 // TestBlockchain_InitializeNeoCache_Bug3424 ensures that Neo cache (new epoch
 // committee and stand by validators) is properly initialized after node restart
 // at the dBFT epoch boundary.
@@ -520,6 +566,32 @@ func TestBlockchain_AddHeaders(t *testing.T) {
 	assert.Equal(t, h3.Hash(), bc.CurrentHeaderHash())
 }
 
+func TestBlockchain_AddBlockAfterHeaders(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	// Invalid block doesn't leave its header.
+	invalid := e.NewUnsignedBlock(t)
+	invalid.MerkleRoot = util.Uint256{1, 2, 3}
+	e.SignBlock(invalid)
+	require.Error(t, bc.AddBlock(invalid))
+	require.Equal(t, bc.BlockHeight(), bc.HeaderHeight())
+
+	b := e.NewUnsignedBlock(t)
+	e.SignBlock(b)
+	require.NoError(t, bc.AddHeaders(&b.Header))
+	require.Equal(t, b.Index, bc.HeaderHeight())
+
+	bad := e.NewUnsignedBlock(t)
+	bad.Nonce = b.Nonce + 1
+	e.SignBlock(bad)
+	require.ErrorIs(t, bc.AddBlock(bad), core.ErrBlockHeaderMismatch)
+	require.Equal(t, b.Index-1, bc.BlockHeight())
+
+	require.NoError(t, bc.AddBlock(b))
+	require.Equal(t, b.Index, bc.BlockHeight())
+}
+
 func TestBlockchain_AddBlockStateRoot(t *testing.T) {
 	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
 		c.StateRootInHeader = true
@@ -549,6 +621,33 @@ func TestBlockchain_AddBlockStateRoot(t *testing.T) {
 	require.NoError(t, bc.AddBlock(b))
 }
 
+func TestBlockchain_MaxIntegerSize(t *testing.T) {
+	w := io.NewBufBinWriter()
+	emit.Opcodes(w.BinWriter, opcode.PUSH1)
+	emit.Int(w.BinWriter, 300)
+	emit.Opcodes(w.BinWriter, opcode.SHL)
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	t.Run("default", func(t *testing.T) {
+		bc, acc := chain.NewSingle(t)
+		e := neotest.NewExecutor(t, bc, acc, acc)
+		e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "operand must be between 0 and 256")
+	})
+	t.Run("extended", func(t *testing.T) {
+		bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+			c.MaxIntegerSize = 64
+		})
+		e := neotest.NewExecutor(t, bc, acc, acc)
+		h := e.InvokeScript(t, script, []neotest.Signer{acc})
+		e.CheckHalt(t, h)
+		// Execution result is stored and can be retrieved.
+		res := e.GetTxExecResult(t, h)
+		require.Equal(t, 1, len(res.Stack))
+		require.Equal(t, new(big.Int).Lsh(big.NewInt(1), 300), res.Stack[0].Value())
+	})
+}
+
 func TestBlockchain_AddHeadersStateRoot(t *testing.T) {
 	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
 		c.StateRootInHeader = true
@@ -621,6 +720,68 @@ func TestBlockchain_AddBadBlock(t *testing.T) {
 	})
 }
 
+func TestBlockchain_AddBlockParallelVerification(t *testing.T) {
+	newChain := func(t *testing.T, workers int) *core.Blockchain {
+		bc, _ := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+			c.VerifyTransactions = true
+			c.VerificationWorkers = workers
+		})
+		return bc
+	}
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	neoHash := e.NativeHash(t, nativenames.Neo)
+
+	txes := make([]*transaction.Transaction, 8)
+	for i := range txes {
+		txes[i] = e.NewUnsignedTx(t, neoHash, "transfer", acc.ScriptHash(), util.Uint160{byte(i)}, 1, nil)
+		e.SignTx(t, txes[i], -1, acc)
+	}
+	b := e.NewUnsignedBlock(t, txes...)
+	e.SignBlock(b)
+	for _, workers := range []int{1, 4} {
+		require.NoError(t, newChain(t, workers).AddBlock(b))
+	}
+
+	bad := txes[5]
+	bad.Scripts[0].InvocationScript = slices.Clone(bad.Scripts[0].InvocationScript)
+	bad.Scripts[0].InvocationScript[10] ^= 0xff
+	b = e.NewUnsignedBlock(t, txes...)
+	e.SignBlock(b)
+	for _, workers := range []int{1, 4} {
+		err := newChain(t, workers).AddBlock(b)
+		require.ErrorIs(t, err, core.ErrInvalidSignature)
+		require.ErrorContains(t, err, bad.Hash().StringLE())
+	}
+}
+
+func TestBlockchain_PreverifyHeader(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	b := e.NewUnsignedBlock(t)
+	e.SignBlock(b)
+	require.NoError(t, bc.PreverifyHeader(&b.Header))
+	require.NoError(t, bc.AddBlock(b))
+
+	b = e.NewUnsignedBlock(t)
+	e.SignBlock(b)
+	b.Script.InvocationScript[10] ^= 0xff
+	require.Error(t, bc.PreverifyHeader(&b.Header))
+	require.Error(t, bc.AddBlock(b))
+
+	// Preverified witness of another account doesn't match NextConsensus.
+	other, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	b = e.NewUnsignedBlock(t)
+	b.Script = transaction.Witness{
+		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, other.SignHashable(uint32(bc.GetConfig().Magic), b)...),
+		VerificationScript: other.PublicKey().GetVerificationScript(),
+	}
+	require.NoError(t, bc.PreverifyHeader(&b.Header))
+	require.Error(t, bc.AddBlock(b))
+}
+
 func TestBlockchain_GetHeader(t *testing.T) {
 	bc, acc := chain.NewSingle(t)
 	e := neotest.NewExecutor(t, bc, acc, acc)
@@ -889,6 +1050,18 @@ func TestBlockchain_HasBlock(t *testing.T) {
 	assert.False(t, bc.HasBlock(newBlock.Hash()))
 }
 
+func TestBlockchain_ComputeStorageRoot(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	for range 3 {
+		e.GenerateNewBlocks(t, 2)
+		root, err := bc.ComputeStorageRoot()
+		require.NoError(t, err)
+		require.Equal(t, bc.GetStateModule().CurrentLocalStateRoot(), root)
+	}
+}
+
 func TestBlockchain_GetTransaction(t *testing.T) {
 	bc, acc := chain.NewSingle(t)
 	e := neotest.NewExecutor(t, bc, acc, acc)
@@ -915,6 +1088,34 @@ func TestBlockchain_GetClaimable(t *testing.T) {
 		require.NoError(t, err)
 		require.EqualValues(t, big.NewInt(5*native.GASFactor/10), amount)
 	})
+	t.Run("projected", func(t *testing.T) {
+		amount, err := bc.ProjectClaimable(acc.ScriptHash(), 1)
+		require.NoError(t, err)
+		require.EqualValues(t, big.NewInt(5*native.GASFactor/10), amount)
+
+		amount, err = bc.ProjectClaimable(acc.ScriptHash(), 10)
+		require.NoError(t, err)
+		require.EqualValues(t, big.NewInt(10*5*native.GASFactor/10), amount)
+
+		amount, err = bc.ProjectClaimable(util.Uint160{1, 2, 3}, 10)
+		require.NoError(t, err)
+		require.Zero(t, amount.Sign())
+
+		_, err = bc.ProjectClaimable(acc.ScriptHash(), 0)
+		require.Error(t, err)
+	})
+	t.Run("account state", func(t *testing.T) {
+		st, err := bc.GetNEOAccountState(acc.ScriptHash())
+		require.NoError(t, err)
+		bal, h := bc.GetGoverningTokenBalance(acc.ScriptHash())
+		require.Equal(t, bal, &st.Balance)
+		require.Equal(t, h, st.BalanceHeight)
+		require.Nil(t, st.VoteTo)
+
+		st, err = bc.GetNEOAccountState(util.Uint160{1, 2, 3})
+		require.NoError(t, err)
+		require.Nil(t, st)
+	})
 }
 
 func TestBlockchain_Close(t *testing.T) {
@@ -1045,6 +1246,94 @@ func TestBlockchain_Subscriptions(t *testing.T) {
 	e.GenerateNewBlocks(t, 2*chBufSize)
 }
 
+func TestBlockchain_StreamExecutions(t *testing.T) {
+	check := func(t *testing.T, stream bool) {
+		bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+			c.Ledger.StreamExecutions = stream
+		})
+		e := neotest.NewExecutor(t, bc, acc, acc)
+		blockCh := make(chan *block.Block, 1)
+		executionCh := make(chan *state.AppExecResult) // Unbuffered to control dispatching.
+		bc.SubscribeForBlocks(blockCh)
+		bc.SubscribeForExecutions(executionCh)
+		t.Cleanup(func() {
+			bc.UnsubscribeFromBlocks(blockCh)
+			bc.UnsubscribeFromExecutions(executionCh)
+		})
+
+		tx := e.PrepareInvocation(t, []byte{byte(opcode.PUSH1)}, []neotest.Signer{acc})
+		b := e.SignBlock(e.NewUnsignedBlock(t, tx))
+		errCh := make(chan error, 1)
+		go func() { errCh <- bc.AddBlock(b) }()
+
+		aer := <-executionCh
+		require.Equal(t, b.Hash(), aer.Container)
+		require.Equal(t, trigger.OnPersist, aer.Trigger)
+		if stream {
+			// The block can't be stored until the transaction execution
+			// result is dispatched.
+			require.Equal(t, b.Index-1, bc.BlockHeight())
+		} else {
+			require.Equal(t, b.Index, bc.BlockHeight())
+		}
+		aer = <-executionCh
+		require.Equal(t, tx.Hash(), aer.Container)
+		require.Equal(t, vmstate.Halt, aer.VMState)
+		aer = <-executionCh
+		require.Equal(t, b.Hash(), aer.Container)
+		require.Equal(t, trigger.PostPersist, aer.Trigger)
+
+		require.NoError(t, <-errCh)
+		require.Equal(t, b, <-blockCh)
+		require.Equal(t, b.Index, bc.BlockHeight())
+	}
+	t.Run("after block", func(t *testing.T) {
+		check(t, false)
+	})
+	t.Run("streamed", func(t *testing.T) {
+		check(t, true)
+	})
+	t.Run("rejected", func(t *testing.T) {
+		bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+			c.Ledger.StreamExecutions = true
+			c.StateRootInHeader = true
+		})
+		e := neotest.NewExecutor(t, bc, acc, acc)
+		blockCh := make(chan *block.Block, 1)
+		executionCh := make(chan *state.AppExecResult, 3)
+		rejectedCh := make(chan *state.RejectedBlock, 1)
+		bc.SubscribeForBlocks(blockCh)
+		bc.SubscribeForExecutions(executionCh)
+		bc.SubscribeForRejectedBlocks(rejectedCh)
+		t.Cleanup(func() {
+			bc.UnsubscribeFromBlocks(blockCh)
+			bc.UnsubscribeFromExecutions(executionCh)
+			bc.UnsubscribeFromRejectedBlocks(rejectedCh)
+		})
+
+		// The next header has invalid PrevStateRoot, so the block fails to
+		// be stored after its execution.
+		tx := e.PrepareInvocation(t, []byte{byte(opcode.PUSH1)}, []neotest.Signer{acc})
+		b := e.SignBlock(e.NewUnsignedBlock(t, tx))
+		next := &block.Block{Header: b.Header}
+		next.Index++
+		next.PrevHash = b.Hash()
+		next.Timestamp++
+		next.PrevStateRoot = util.Uint256{1, 2, 3}
+		next.RebuildMerkleRoot()
+		e.SignBlock(next)
+		require.NoError(t, bc.AddHeaders(&b.Header, &next.Header))
+
+		require.ErrorContains(t, bc.AddBlock(b), "PrevStateRoot mismatch")
+		for _, h := range []util.Uint256{b.Hash(), tx.Hash(), b.Hash()} {
+			require.Equal(t, h, (<-executionCh).Container)
+		}
+		require.Equal(t, &state.RejectedBlock{Hash: b.Hash(), Index: b.Index}, <-rejectedCh)
+		require.Equal(t, b.Index-1, bc.BlockHeight())
+		require.Empty(t, blockCh)
+	})
+}
+
 func TestBlockchain_RemoveUntraceable(t *testing.T) {
 	neoCommitteeKey := []byte{0xfb, 0xff, 0xff, 0xff, 0x0e}
 	check := func(t *testing.T, bc *core.Blockchain, tHash, bHash, sHash util.Uint256, errorExpected bool) {
@@ -2363,6 +2652,57 @@ func TestBlockchain_ResetStateErrors(t *testing.T) {
 	})
 }
 
+func TestBlockchain_ConfiguredStateReset(t *testing.T) {
+	db, path := newLevelDBForTestingWithPath(t, t.TempDir())
+	bc, validators, committee := chain.NewMultiWithCustomConfigAndStore(t, nil, db, false)
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	go bc.Run()
+	for range 5 {
+		e.AddNewBlock(t)
+	}
+	resetHash := bc.GetHeaderHash(3)
+	bc.Close()
+
+	restart := func(t *testing.T, h uint32) *core.Blockchain {
+		db, _ = newLevelDBForTestingWithPath(t, path)
+		bc, validators, committee = chain.NewMultiWithCustomConfigAndStore(t, func(c *config.Blockchain) {
+			c.Ledger.StateResetHeight = h
+		}, db, false)
+		return bc
+	}
+
+	// Chain is below the configured height, nothing is done.
+	bc = restart(t, 10)
+	require.Equal(t, uint32(5), bc.BlockHeight())
+	require.NoError(t, db.Close())
+
+	// Chain is reset.
+	bc = restart(t, 3)
+	require.Equal(t, uint32(3), bc.BlockHeight())
+	require.Equal(t, uint32(3), bc.HeaderHeight())
+	require.Equal(t, resetHash, bc.CurrentBlockHash())
+	e = neotest.NewExecutor(t, bc, validators, committee)
+	go bc.Run()
+	for range 3 {
+		e.AddNewBlock(t)
+	}
+	bc.Close()
+
+	// The reset is performed only once.
+	bc = restart(t, 3)
+	require.Equal(t, uint32(6), bc.BlockHeight())
+	require.NoError(t, db.Close())
+
+	// Errors are returned on start.
+	db, _ = newLevelDBForTestingWithPath(t, path)
+	_, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
+		c.Ledger.StateResetHeight = 2
+		c.Ledger.KeepOnlyLatestState = true
+	}, db)
+	require.Error(t, err)
+	require.NoError(t, db.Close())
+}
+
 // TestBlockchain_ResetState is based on knowledge about basic chain transactions,
 // it performs basic chain reset and checks that reset chain has proper state.
 func TestBlockchain_ResetState(t *testing.T) {
@@ -2446,7 +2786,10 @@ func TestBlockchain_ResetState(t *testing.T) {
 	require.Equal(t, topBlockHeight, bc.BlockHeight()) // ensure DB was properly initialized.
 
 	// Reset state.
+	sub := new(transfersRecorder)
+	bc.SubscribeForTransfers(sub)
 	require.NoError(t, bc.Reset(resetBlockIndex))
+	require.Equal(t, []uint32{resetBlockIndex}, sub.resets)
 
 	// Check that state was properly reset.
 	require.Equal(t, resetBlockIndex, bc.BlockHeight())
@@ -2538,6 +2881,308 @@ func TestBlockchain_ResetState(t *testing.T) {
 	require.Equal(t, expectedLUB, lub)
 }
 
+// transfersRecorder is a transfers.Subscriber storing all index updates.
+type transfersRecorder struct {
+	blocks    []uint32
+	transfers []transfers.Transfer
+	resets    []uint32
+}
+
+func (r *transfersRecorder) OnTransfers(b *block.Block, trs []transfers.Transfer) {
+	r.blocks = append(r.blocks, b.Index)
+	r.transfers = append(r.transfers, trs...)
+}
+
+func (r *transfersRecorder) OnReset(height uint32) {
+	r.resets = append(r.resets, height)
+}
+
+func TestBlockchain_SubscribeForTransfers(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	gasH := e.NativeHash(t, nativenames.Gas)
+	to := util.Uint160{1, 2, 3}
+
+	sub := new(transfersRecorder)
+	bc.SubscribeForTransfers(sub)
+	gas := e.CommitteeInvoker(gasH)
+	h := gas.Invoke(t, true, "transfer", acc.ScriptHash(), to, 1_0000_0000, nil)
+
+	require.Equal(t, []uint32{bc.BlockHeight()}, sub.blocks)
+	require.Contains(t, sub.transfers, transfers.Transfer{
+		Asset:     gasH,
+		From:      acc.ScriptHash(),
+		To:        to,
+		Amount:    big.NewInt(1_0000_0000),
+		Container: h,
+	})
+	for _, tr := range sub.transfers {
+		require.Equal(t, gasH, tr.Asset) // Only GAS fees and transfer are expected.
+	}
+
+	bc.UnsubscribeFromTransfers(sub)
+	gas.Invoke(t, true, "transfer", acc.ScriptHash(), to, 1, nil)
+	require.Equal(t, 1, len(sub.blocks))
+}
+
+func TestBlockchain_RebuildTransfers(t *testing.T) {
+	bc, validators, committee := chain.NewMultiWithCustomConfig(t, func(cfg *config.Blockchain) {
+		cfg.P2PSigExtensions = true
+	})
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	basicchain.Init(t, "../../", e)
+
+	acc := e.Validator.(neotest.MultiSigner).Single(2).ScriptHash()
+
+	// Transfers of destroyed tokens are restored as well.
+	src := `package token
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+		"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	)
+	func Mint(to interop.Hash160) {
+		runtime.Notify("Transfer", nil, to, 100)
+	}
+	func Destroy() {
+		management.Destroy()
+	}`
+	transferEvent := []compiler.HybridEvent{{
+		Name: "Transfer",
+		Parameters: []compiler.HybridParameter{
+			{Parameter: manifest.Parameter{Name: "from", Type: smartcontract.Hash160Type}},
+			{Parameter: manifest.Parameter{Name: "to", Type: smartcontract.Hash160Type}},
+			{Parameter: manifest.Parameter{Name: "amount", Type: smartcontract.IntegerType}},
+		},
+	}}
+	var destroyedID int32
+	for _, name := range []string{"DestroyedToken", "Token"} {
+		c := neotest.CompileSource(t, e.Validator.ScriptHash(), strings.NewReader(src), &compiler.Options{
+			Name:           name,
+			ContractEvents: transferEvent,
+			Permissions:    []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+		})
+		e.DeployContract(t, c, nil)
+		tokenInvoker := e.CommitteeInvoker(c.Hash)
+		tokenInvoker.Invoke(t, stackitem.Null{}, "mint", acc)
+		if name == "DestroyedToken" {
+			destroyedID = bc.GetContractState(c.Hash).ID
+			tokenInvoker.Invoke(t, stackitem.Null{}, "destroy")
+			require.Nil(t, bc.GetContractState(c.Hash))
+		}
+	}
+
+	getTransfers := func() ([]*state.NEP11Transfer, []*state.NEP17Transfer, map[int32]uint32) {
+		var (
+			nep11 []*state.NEP11Transfer
+			nep17 []*state.NEP17Transfer
+		)
+		require.NoError(t, bc.ForEachNEP11Transfer(acc, e.TopBlock(t).Timestamp, func(t *state.NEP11Transfer) (bool, error) {
+			nep11 = append(nep11, t)
+			return true, nil
+		}))
+		require.NoError(t, bc.ForEachNEP17Transfer(acc, e.TopBlock(t).Timestamp, func(t *state.NEP17Transfer) (bool, error) {
+			nep17 = append(nep17, t)
+			return true, nil
+		}))
+		lub, err := bc.GetTokenLastUpdated(acc)
+		require.NoError(t, err)
+		return nep11, nep17, lub
+	}
+	expected11, expected17, expectedLUB := getTransfers()
+	require.NotEmpty(t, expected11)
+	require.NotEmpty(t, expected17)
+	require.True(t, slices.ContainsFunc(expected17, func(tr *state.NEP17Transfer) bool {
+		return tr.Asset == destroyedID
+	}))
+
+	sub := new(transfersRecorder)
+	bc.SubscribeForTransfers(sub)
+	require.NoError(t, bc.RebuildTransfers())
+	require.Empty(t, sub.blocks)
+	require.Empty(t, sub.resets)
+
+	actual11, actual17, actualLUB := getTransfers()
+	require.Equal(t, expected11, actual11)
+	require.Equal(t, expected17, actual17)
+	require.Equal(t, expectedLUB, actualLUB)
+
+	t.Run("RemoveUntraceableBlocks", func(t *testing.T) {
+		bc, _, _ := chain.NewMultiWithCustomConfig(t, func(cfg *config.Blockchain) {
+			cfg.Ledger.RemoveUntraceableBlocks = true
+		})
+		require.Error(t, bc.RebuildTransfers())
+	})
+	t.Run("AppLogsMaxBlocks", func(t *testing.T) {
+		bc, _, _ := chain.NewMultiWithCustomConfig(t, func(cfg *config.Blockchain) {
+			cfg.Ledger.AppLogsMaxBlocks = 10
+		})
+		require.Error(t, bc.RebuildTransfers())
+	})
+}
+
+func TestBlockchain_AppLogsMaxBlocks(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compress=%t", compress), func(t *testing.T) {
+			bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+				c.Ledger.AppLogsMaxBlocks = 2
+				c.Ledger.CompressAppLogs = compress
+			})
+			e := neotest.NewExecutor(t, bc, acc, acc)
+			neoValidatorInvoker := e.ValidatorInvoker(e.NativeHash(t, nativenames.Neo))
+			ledgerInvoker := e.CommitteeInvoker(e.NativeHash(t, nativenames.Ledger))
+
+			txH := neoValidatorInvoker.Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+			b := e.TopBlock(t)
+			aers, err := bc.GetAppExecResults(b.Hash(), trigger.All)
+			require.NoError(t, err)
+			require.Equal(t, 2, len(aers))
+			e.CheckHalt(t, txH, stackitem.NewBool(true))
+
+			e.AddNewBlock(t)
+			aers, err = bc.GetAppExecResults(txH, trigger.Application)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(aers))
+
+			e.AddNewBlock(t)
+			_, err = bc.GetAppExecResults(txH, trigger.Application)
+			require.ErrorIs(t, err, dao.ErrAppLogPruned)
+			_, err = bc.GetAppExecResults(b.Hash(), trigger.All)
+			require.ErrorIs(t, err, dao.ErrAppLogPruned)
+
+			// Blocks, transactions and their VM states are still available.
+			actual, err := bc.GetBlock(b.Hash())
+			require.NoError(t, err)
+			require.Equal(t, txH, actual.Transactions[0].Hash())
+			_, h, err := bc.GetTransaction(txH)
+			require.NoError(t, err)
+			require.Equal(t, b.Index, h)
+			ledgerInvoker.Invoke(t, int64(vmstate.Halt), "getTransactionVMState", txH)
+
+			// Logs of the latest blocks are kept.
+			_, err = bc.GetAppExecResults(e.TopBlock(t).Hash(), trigger.All)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBlockchain_AppLogsMaxBlocksExistingDB(t *testing.T) {
+	ps, path := newLevelDBForTestingWithPath(t, "")
+	bc, validators, committee, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, nil, ps)
+	require.NoError(t, err)
+	go bc.Run()
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	txH := e.ValidatorInvoker(e.NativeHash(t, nativenames.Neo)).Invoke(t, true, "transfer", e.Validator.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+	b := e.TopBlock(t)
+	for range 3 {
+		e.AddNewBlock(t)
+	}
+	bc.Close()
+
+	// Logs of all blocks older than the limit are removed once the setting
+	// is enabled for the existing database.
+	ps, _ = newLevelDBForTestingWithPath(t, path)
+	t.Cleanup(func() { require.NoError(t, ps.Close()) })
+	bc, validators, committee, err = chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
+		c.Ledger.AppLogsMaxBlocks = 2
+	}, ps)
+	require.NoError(t, err)
+	go bc.Run()
+	e = neotest.NewExecutor(t, bc, validators, committee)
+	e.AddNewBlock(t)
+
+	_, err = bc.GetAppExecResults(txH, trigger.Application)
+	require.ErrorIs(t, err, dao.ErrAppLogPruned)
+	for _, h := range []util.Uint256{bc.GetHeaderHash(0), b.Hash(), bc.GetHeaderHash(bc.BlockHeight() - 2)} {
+		_, err = bc.GetAppExecResults(h, trigger.All)
+		require.ErrorIs(t, err, dao.ErrAppLogPruned)
+	}
+	_, err = bc.GetAppExecResults(bc.GetHeaderHash(bc.BlockHeight()-1), trigger.All)
+	require.NoError(t, err)
+}
+
+func TestBlockchain_GetContractStats(t *testing.T) {
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Ledger.ContractStatsPeriod = 4
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	gasInvoker := e.ValidatorInvoker(e.NativeHash(t, nativenames.Gas))
+
+	p, err := bc.GetContractStats()
+	require.NoError(t, err)
+	require.Nil(t, p)
+
+	h1 := gasInvoker.Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+	h2 := gasInvoker.InvokeFail(t, "", "balanceOf", 123)
+	p, err = bc.GetContractStats()
+	require.NoError(t, err)
+	require.Nil(t, p)
+
+	e.AddNewBlock(t)
+	p, err = bc.GetContractStats()
+	require.NoError(t, err)
+	require.Equal(t, &contractstats.Period{
+		Start: 0,
+		End:   3,
+		Contracts: map[util.Uint160]contractstats.Stats{
+			e.NativeHash(t, nativenames.Gas): {
+				Calls:       2,
+				Executions:  2,
+				Faults:      1,
+				GasConsumed: e.GetTxExecResult(t, h1).GasConsumed + e.GetTxExecResult(t, h2).GasConsumed,
+			},
+		},
+	}, p)
+
+	t.Run("disabled", func(t *testing.T) {
+		bc, _ := chain.NewSingle(t)
+		_, err := bc.GetContractStats()
+		require.Error(t, err)
+	})
+}
+
+func TestBlockchain_GetValidatorStats(t *testing.T) {
+	bc, validators, committee := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Ledger.ValidatorStatsWindow = 3
+	})
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	vals, err := bc.GetNextBlockValidators()
+	require.NoError(t, err)
+	require.Equal(t, 4, len(vals))
+
+	w, err := bc.GetValidatorStats()
+	require.NoError(t, err)
+	require.Nil(t, w)
+
+	addBlock := func(primary byte) {
+		b := e.NewUnsignedBlock(t)
+		b.PrimaryIndex = primary
+		require.NoError(t, bc.AddBlock(e.SignBlock(b)))
+	}
+	addBlock(1) // View 0.
+	addBlock(0) // View 2, validators 2 and 1 missed.
+	addBlock(3) // View 0.
+	addBlock(3) // View 1, validator 0 missed.
+	w, err = bc.GetValidatorStats()
+	require.NoError(t, err)
+	require.Equal(t, &validatorstats.Window{
+		Start: 2,
+		End:   4,
+		Validators: []validatorstats.Stats{
+			{Key: vals[0], Produced: 1, Missed: 1},
+			{Key: vals[1], Missed: 1},
+			{Key: vals[2], Missed: 1},
+			{Key: vals[3], Produced: 2},
+		},
+	}, w)
+
+	t.Run("disabled", func(t *testing.T) {
+		bc, _ := chain.NewSingle(t)
+		_, err := bc.GetValidatorStats()
+		require.Error(t, err)
+	})
+}
+
 func TestBlockchain_GenesisTransactionExtension(t *testing.T) {
 	priv0 := testchain.PrivateKeyByID(0)
 	acc0 := wallet.NewAccountFromPrivateKey(priv0)
@@ -2762,3 +3407,116 @@ func TestRuntimeNotifyRefcounting(t *testing.T) {
 		require.Equal(t, expected, aer[0].Events[i])
 	}
 }
+
+func TestBlockchain_SimulateTransactions(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	gasHash := e.NativeHash(t, nativenames.Gas)
+
+	w, err := wallet.NewAccount()
+	require.NoError(t, err)
+	newAcc := neotest.NewSingleSigner(w)
+
+	// The second transfer is only possible after the first one.
+	tx1 := e.NewTx(t, []neotest.Signer{acc}, gasHash, "transfer", acc.ScriptHash(), newAcc.ScriptHash(), 10_0000_0000, nil)
+	tx2 := e.NewTx(t, []neotest.Signer{acc, newAcc}, gasHash, "transfer", newAcc.ScriptHash(), acc.ScriptHash(), 5_0000_0000, nil)
+	tx3 := e.PrepareInvocation(t, []byte{byte(opcode.ABORT)}, []neotest.Signer{acc})
+	tx3.Nonce++ // Different from tx2.
+
+	height := bc.BlockHeight()
+	aers, ops, err := bc.SimulateTransactions([]*transaction.Transaction{tx1, tx2, tx3})
+	require.NoError(t, err)
+	require.Len(t, aers, 5)
+	require.Equal(t, trigger.OnPersist, aers[0].Trigger)
+	require.Equal(t, trigger.PostPersist, aers[4].Trigger)
+	for i, tx := range []*transaction.Transaction{tx1, tx2} {
+		aer := aers[i+1]
+		require.Equal(t, tx.Hash(), aer.Container)
+		require.Equal(t, vmstate.Halt, aer.VMState, aer.FaultException)
+		require.Equal(t, []stackitem.Item{stackitem.NewBool(true)}, aer.Stack)
+		require.Len(t, aer.Events, 1)
+	}
+	require.Equal(t, tx3.Hash(), aers[3].Container)
+	require.Equal(t, vmstate.Fault, aers[3].VMState)
+	require.NotEmpty(t, aers[3].FaultException)
+	require.NotEmpty(t, ops)
+
+	// Nothing is persisted.
+	require.Equal(t, height, bc.BlockHeight())
+	e.CheckGASBalance(t, newAcc.ScriptHash(), big.NewInt(0))
+	_, _, err = bc.GetTransaction(tx1.Hash())
+	require.Error(t, err)
+
+	// Real block gives the same results.
+	e.AddNewBlock(t, tx1, tx2, tx3)
+	for i, tx := range []*transaction.Transaction{tx1, tx2, tx3} {
+		aer := e.GetTxExecResult(t, tx.Hash())
+		require.Equal(t, aers[i+1].VMState, aer.VMState)
+		require.Equal(t, aers[i+1].GasConsumed, aer.GasConsumed)
+		require.Equal(t, aers[i+1].Stack, aer.Stack)
+		require.Equal(t, len(aers[i+1].Events), len(aer.Events))
+	}
+	e.CheckGASBalance(t, newAcc.ScriptHash(), big.NewInt(5_0000_0000))
+}
+
+func TestBlockchain_GetTestVMSnapshot(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	to := random.Uint160()
+
+	script, err := smartcontract.CreateCallScript(gasHash, "balanceOf", to)
+	require.NoError(t, err)
+	balance := func(t *testing.T, ic *interop.Context) int64 {
+		ic.VM.LoadScriptWithFlags(script, callflag.All)
+		require.NoError(t, ic.VM.Run())
+		require.Equal(t, 1, ic.VM.Estack().Len())
+		return ic.VM.Estack().Pop().BigInt().Int64()
+	}
+
+	ic, err := bc.GetTestVM(trigger.Application, nil, nil)
+	require.NoError(t, err)
+	e.ValidatorInvoker(gasHash).Invoke(t, true, "transfer", acc.ScriptHash(), to, 1_0000_0000, nil)
+
+	// The context started before the transfer doesn't see it.
+	require.Equal(t, int64(0), balance(t, ic))
+	ic.Finalize()
+
+	ic, err = bc.GetTestVM(trigger.Application, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1_0000_0000), balance(t, ic))
+	ic.Finalize()
+}
+
+func TestBlockchain_DebugInfoFaultLocation(t *testing.T) {
+	src := `package foo
+	func Main(a int) int {
+		if a > 0 {
+			return a
+		}
+		panic("negative")
+	}`
+	c := neotest.CompileSource(t, util.Uint160{}, strings.NewReader(src), &compiler.Options{Name: "Foo"})
+	rawDebug, err := json.Marshal(c.DebugInfo)
+	require.NoError(t, err)
+	debugFile := filepath.Join(t.TempDir(), "foo.debug.json")
+	require.NoError(t, os.WriteFile(debugFile, rawDebug, os.ModePerm))
+
+	t.Run("bad file", func(t *testing.T) {
+		_, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(cfg *config.Blockchain) {
+			cfg.Ledger.DebugInfoFiles = []string{debugFile + ".missing"}
+		}, nil)
+		require.Error(t, err)
+	})
+
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(cfg *config.Blockchain) {
+		cfg.Ledger.DebugInfoFiles = []string{debugFile}
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	c = neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{Name: "Foo"})
+	e.DeployContract(t, c, nil)
+
+	inv := e.CommitteeInvoker(c.Hash)
+	inv.Invoke(t, 1, "main", 1)
+	inv.InvokeFail(t, `unhandled exception: "negative" (at `+c.DebugInfo.Documents[0]+`:6 in foo.Main)`, "main", -1)
+}
@@ -0,0 +1,20 @@
+/*
+Package blockprof contains block processing instrumentation interface used by
+the Blockchain.
+*/
+package blockprof
+
+import (
+	"time"
+)
+
+// Profiler is an interface for block processing instrumentation.
+type Profiler interface {
+	// BlockAdded is called after every successful block addition with the
+	// time spent on block verification (including in-block transactions),
+	// execution and MPT update.
+	BlockAdded(index uint32, verification, execution, mpt time.Duration)
+	// Persisted is called after every persist operation that has written
+	// something to the underlying storage with the time spent on it.
+	Persisted(d time.Duration)
+}
@@ -1,7 +1,10 @@
 package chaindump
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -40,47 +43,123 @@ func Dump(bc DumperRestorer, w *io.BinWriter, start, count uint32) error {
 	return nil
 }
 
+// HeaderPreverifier is an optional interface that can be implemented by
+// DumperRestorer to verify header witnesses of the blocks being restored
+// concurrently before adding them. Preverification results that weren't used
+// (because restore was stopped or some block was rejected) are dropped with
+// ForgetPreverified when restore finishes.
+type HeaderPreverifier interface {
+	PreverifyHeader(*block.Header) error
+	ForgetPreverified()
+}
+
+// restoreJob is a single block processed by the restore pipeline.
+type restoreJob struct {
+	index uint32
+	data  []byte
+	block *block.Block
+	err   error
+	done  chan struct{}
+}
+
 // Restore restores blocks from the provided reader.
 // f is called after addition of every block.
 func Restore(bc DumperRestorer, r *io.BinReader, skip, count uint32, f func(b *block.Block) error) error {
-	var buf []byte
+	return RestoreParallel(bc, r, skip, count, 1, f)
+}
 
-	readBlock := func(r *io.BinReader) ([]byte, error) {
-		var size = r.ReadU32LE()
-		if uint32(cap(buf)) < size {
-			buf = make([]byte, size)
-		} else {
-			buf = buf[:size]
-		}
-		r.ReadBytes(buf)
-		return buf, r.Err
+// RestoreParallel restores blocks from the provided reader using a staged
+// pipeline: blocks are read sequentially, then decoded and preverified (see
+// HeaderPreverifier) by the given number of workers concurrently and then
+// added to the chain in order. f is called after addition of every block.
+func RestoreParallel(bc DumperRestorer, r *io.BinReader, skip, count uint32, workers int, f func(b *block.Block) error) error {
+	if workers <= 0 {
+		return errors.New("number of workers must be positive")
 	}
+	var (
+		stateRootInHeader = bc.GetConfig().StateRootInHeader
+		preverifier, _    = bc.(HeaderPreverifier)
 
-	i := uint32(0)
-	for ; i < skip; i++ {
-		_, err := readBlock(r)
-		if err != nil {
-			return err
+		// Jobs are passed to workers and to the adder in the same order,
+		// the adder waits for every job to be processed.
+		jobs    = make(chan *restoreJob, 2*workers)
+		ordered = make(chan *restoreJob, 2*workers)
+		stop    = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	defer func() {
+		close(stop)
+		wg.Wait()
+		if preverifier != nil {
+			preverifier.ForgetPreverified()
 		}
-	}
+	}()
 
-	stateRootInHeader := bc.GetConfig().StateRootInHeader
-
-	for ; i < skip+count; i++ {
-		buf, err := readBlock(r)
-		if err != nil {
-			return err
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(ordered)
+		defer close(jobs)
+		var skipBuf []byte
+		for i := uint32(0); i < skip+count; i++ {
+			size := r.ReadU32LE()
+			var data []byte
+			if r.Err == nil {
+				if i < skip {
+					skipBuf = slices.Grow(skipBuf[:0], int(size))[:size]
+					data = skipBuf
+				} else {
+					data = make([]byte, size)
+				}
+				r.ReadBytes(data)
+			}
+			if i < skip && r.Err == nil {
+				continue
+			}
+			job := &restoreJob{index: i, data: data, err: r.Err, done: make(chan struct{})}
+			select {
+			case ordered <- job:
+			case <-stop:
+				return
+			}
+			select {
+			case jobs <- job:
+			case <-stop:
+				return
+			}
+			if r.Err != nil {
+				return
+			}
 		}
-		b := block.New(stateRootInHeader)
-		r := io.NewBinReaderFromBuf(buf)
-		b.DecodeBinary(r)
-		if r.Err != nil {
-			return r.Err
+	}()
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if job.err == nil {
+					job.block, job.err = decodeBlock(job.data, stateRootInHeader)
+					job.data = nil
+				}
+				// Genesis block is not added, see below.
+				if job.err == nil && preverifier != nil && job.block.Index != 0 {
+					job.err = preverifier.PreverifyHeader(&job.block.Header)
+				}
+				close(job.done)
+			}
+		}()
+	}
+
+	for job := range ordered {
+		<-job.done
+		if job.err != nil {
+			return fmt.Errorf("failed to restore block %d: %w", job.index, job.err)
 		}
-		if b.Index != 0 || i != 0 || skip != 0 {
-			err = bc.AddBlock(b)
+		b := job.block
+		if b.Index != 0 || job.index != 0 || skip != 0 {
+			err := bc.AddBlock(b)
 			if err != nil {
-				return fmt.Errorf("failed to add block %d: %w", i, err)
+				return fmt.Errorf("failed to add block %d: %w", job.index, err)
 			}
 		}
 		if f != nil {
@@ -91,3 +170,19 @@ func Restore(bc DumperRestorer, r *io.BinReader, skip, count uint32, f func(b *b
 	}
 	return nil
 }
+
+// decodeBlock decodes the block and computes all of its hashes, so that they're
+// cached before the block is added.
+func decodeBlock(data []byte, stateRootInHeader bool) (*block.Block, error) {
+	b := block.New(stateRootInHeader)
+	r := io.NewBinReaderFromBuf(data)
+	b.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	_ = b.Hash()
+	for _, tx := range b.Transactions {
+		_ = tx.Hash()
+	}
+	return b, nil
+}
@@ -1,11 +1,13 @@
 package chaindump_test
 
 import (
+	"bytes"
 	"errors"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/basicchain"
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
 	"github.com/nspcc-dev/neo-go/pkg/io"
@@ -14,6 +16,17 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// forgetCounter counts ForgetPreverified calls.
+type forgetCounter struct {
+	*core.Blockchain
+	forgotten int
+}
+
+func (f *forgetCounter) ForgetPreverified() {
+	f.forgotten++
+	f.Blockchain.ForgetPreverified()
+}
+
 func TestBlockchain_DumpAndRestore(t *testing.T) {
 	t.Run("no state root", func(t *testing.T) {
 		testDumpAndRestore(t, func(c *config.Blockchain) {
@@ -89,4 +102,40 @@ func testDumpAndRestore(t *testing.T, dumpF, restoreF func(c *config.Blockchain)
 			require.Equal(t, bc.BlockHeight()-1, lastIndex)
 		})
 	})
+	t.Run("parallel", func(t *testing.T) {
+		bc2, _, _ := chain.NewMultiWithCustomConfig(t, restoreF)
+
+		r := io.NewBinReaderFromBuf(buf)
+		require.Error(t, chaindump.RestoreParallel(bc2, r, 0, 1, 0, nil))
+		require.NoError(t, chaindump.RestoreParallel(bc2, r, 0, bc.BlockHeight()+1, 4, nil))
+		require.Equal(t, bc.BlockHeight(), bc2.BlockHeight())
+		require.Equal(t, bc.CurrentBlockHash(), bc2.CurrentBlockHash())
+	})
+	t.Run("bad witness", func(t *testing.T) {
+		bc2, _, _ := chain.NewMultiWithCustomConfig(t, restoreF)
+
+		b, err := bc.GetBlock(bc.GetHeaderHash(3))
+		require.NoError(t, err)
+		b.Script.InvocationScript = bytes.Clone(b.Script.InvocationScript)
+		b.Script.InvocationScript[10] ^= 0xff
+		w := io.NewBufBinWriter()
+		for i := range uint32(5) {
+			blk := b
+			if i != b.Index {
+				blk, err = bc.GetBlock(bc.GetHeaderHash(i))
+				require.NoError(t, err)
+			}
+			bw := io.NewBufBinWriter()
+			blk.EncodeBinary(bw.BinWriter)
+			require.NoError(t, bw.Err)
+			w.WriteU32LE(uint32(bw.Len()))
+			w.WriteBytes(bw.Bytes())
+		}
+		r := io.NewBinReaderFromBuf(w.Bytes())
+		fc := &forgetCounter{Blockchain: bc2}
+		err = chaindump.RestoreParallel(fc, r, 0, 5, 4, nil)
+		require.ErrorContains(t, err, "failed to restore block 3")
+		require.Equal(t, uint32(2), bc2.BlockHeight())
+		require.Equal(t, 1, fc.forgotten)
+	})
 }
@@ -0,0 +1,136 @@
+/*
+Package contractstats implements per-contract execution statistics aggregation
+used by the Blockchain.
+*/
+package contractstats
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Stats is the aggregated execution statistics of a single contract.
+type Stats struct {
+	// Calls is the number of calls made to the contract.
+	Calls uint64
+	// Executions is the number of transactions calling the contract.
+	Executions uint64
+	// Faults is the number of Executions that ended in FAULT state.
+	Faults uint64
+	// GasConsumed is the total amount of GAS consumed by Executions.
+	GasConsumed int64
+}
+
+// Period is the statistics for a range of blocks.
+type Period struct {
+	// Start is the index of the first block of the period.
+	Start uint32
+	// End is the index of the last block of the period.
+	End uint32
+	// Contracts contains statistics of every contract called in the period.
+	Contracts map[util.Uint160]Stats
+}
+
+// FaultRate returns the ratio of faulted executions to all executions.
+func (s Stats) FaultRate() float64 {
+	if s.Executions == 0 {
+		return 0
+	}
+	return float64(s.Faults) / float64(s.Executions)
+}
+
+// Batch contains statistics of a single block. It's not thread-safe.
+type Batch struct {
+	contracts map[util.Uint160]Stats
+}
+
+// AddExecution adds a transaction execution to the batch. invocations
+// contain the number of calls for every contract called by the transaction.
+func (b *Batch) AddExecution(invocations map[util.Uint160]int, gas int64, faulted bool) {
+	for h, n := range invocations {
+		s := b.contracts[h]
+		s.Calls += uint64(n)
+		s.Executions++
+		if faulted {
+			s.Faults++
+		}
+		s.GasConsumed += gas
+		b.contracts[h] = s
+	}
+}
+
+// Collector aggregates statistics for periods of a fixed number of blocks.
+// Periods are aligned to the period length, so the first period covers less
+// blocks if collection is not started from its beginning.
+type Collector struct {
+	length uint32
+
+	lock    sync.RWMutex
+	current *Period
+	last    *Period
+}
+
+// NewCollector creates a Collector for periods of the given number of blocks
+// which must be positive.
+func NewCollector(length uint32) *Collector {
+	return &Collector{length: length}
+}
+
+// NewBatch creates a batch for the next block statistics.
+func (c *Collector) NewBatch() *Batch {
+	return &Batch{contracts: make(map[util.Uint160]Stats)}
+}
+
+// AddBlock adds statistics of the block with the given index to the current
+// period. It returns the period completed by this block if any.
+func (c *Collector) AddBlock(index uint32, b *Batch) *Period {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.current == nil {
+		c.current = &Period{
+			Start:     index,
+			Contracts: make(map[util.Uint160]Stats),
+		}
+	}
+	for h, s := range b.contracts {
+		cur := c.current.Contracts[h]
+		cur.Calls += s.Calls
+		cur.Executions += s.Executions
+		cur.Faults += s.Faults
+		cur.GasConsumed += s.GasConsumed
+		c.current.Contracts[h] = cur
+	}
+	c.current.End = index
+	if (index+1)%c.length != 0 {
+		return nil
+	}
+	c.last, c.current = c.current, nil
+	return c.last.copy()
+}
+
+// Last returns a copy of the last completed period or nil if there is none.
+func (c *Collector) Last() *Period {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if c.last == nil {
+		return nil
+	}
+	return c.last.copy()
+}
+
+// Reset drops all collected statistics, it's used when the chain state is
+// reset to some previous height.
+func (c *Collector) Reset() {
+	c.lock.Lock()
+	c.current, c.last = nil, nil
+	c.lock.Unlock()
+}
+
+func (p *Period) copy() *Period {
+	res := *p
+	res.Contracts = maps.Clone(p.Contracts)
+	return &res
+}
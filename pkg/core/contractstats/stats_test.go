@@ -0,0 +1,53 @@
+package contractstats
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	var (
+		c  = NewCollector(4)
+		h1 = util.Uint160{1}
+		h2 = util.Uint160{2}
+	)
+	require.Nil(t, c.Last())
+
+	// Started in the middle of the period.
+	b := c.NewBatch()
+	b.AddExecution(map[util.Uint160]int{h1: 2, h2: 1}, 10, false)
+	b.AddExecution(map[util.Uint160]int{h1: 1}, 5, true)
+	require.Nil(t, c.AddBlock(2, b))
+	require.Nil(t, c.Last())
+
+	b = c.NewBatch()
+	b.AddExecution(map[util.Uint160]int{h2: 3}, 7, true)
+	p := c.AddBlock(3, b)
+	expected := &Period{
+		Start: 2,
+		End:   3,
+		Contracts: map[util.Uint160]Stats{
+			h1: {Calls: 3, Executions: 2, Faults: 1, GasConsumed: 15},
+			h2: {Calls: 4, Executions: 2, Faults: 1, GasConsumed: 17},
+		},
+	}
+	require.Equal(t, expected, p)
+	require.Equal(t, expected, c.Last())
+	require.Equal(t, 0.5, p.Contracts[h1].FaultRate())
+
+	// The returned period is a copy.
+	p.Contracts[h1] = Stats{}
+	require.Equal(t, expected, c.Last())
+
+	for i := uint32(4); i < 7; i++ {
+		require.Nil(t, c.AddBlock(i, c.NewBatch()))
+	}
+	p = c.AddBlock(7, c.NewBatch())
+	require.Equal(t, &Period{Start: 4, End: 7, Contracts: map[util.Uint160]Stats{}}, p)
+	require.Equal(t, 0.0, Stats{}.FaultRate())
+
+	c.Reset()
+	require.Nil(t, c.Last())
+}
@@ -7,9 +7,11 @@ import (
 	"errors"
 	"fmt"
 	iocore "io"
+	"maps"
 	"math/big"
 	"sync"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/nspcc-dev/neo-go/pkg/config/limits"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -35,6 +37,23 @@ var (
 	ErrInternalDBInconsistency = errors.New("internal DB inconsistency")
 )
 
+// ErrAppLogPruned is returned when application execution results are requested
+// for a block or transaction they were removed for (see PruneAppExecResults).
+var ErrAppLogPruned = errors.New("application log is pruned")
+
+var (
+	// zstdEncoder and zstdDecoder are used for application execution results
+	// compression, both are safe for concurrent EncodeAll/DecodeAll calls.
+	zstdEncoder = sync.OnceValue(func() *zstd.Encoder {
+		e, _ := zstd.NewWriter(nil) // Never fails with default options.
+		return e
+	})
+	zstdDecoder = sync.OnceValue(func() *zstd.Decoder {
+		d, _ := zstd.NewReader(nil) // Never fails with default options.
+		return d
+	})
+)
+
 // conflictRecordValueLen is the length of value of transaction conflict record.
 // It consists of 1-byte [storage.ExecTransaction] prefix and 4-bytes block index
 // in the LE form.
@@ -119,6 +138,23 @@ func (dao *Simple) GetPrivate() *Simple {
 	return d
 }
 
+// GetSnapshot returns a new DAO instance backed by a copy-on-write snapshot of
+// the current DAO Store (see storage.MemCachedStore.Snapshot) with a copy of
+// the current native cache. It's not affected by any subsequent changes made
+// to the current DAO and it can be wrapped with GetPrivate for speculative
+// execution, but changes made to it can't be persisted. It can only be used
+// for the lowest DAO (the one having initialized native cache) and the
+// snapshot must be released by closing its Store when it's no longer needed.
+func (dao *Simple) GetSnapshot() *Simple {
+	dao.nativeCacheLock.RLock()
+	defer dao.nativeCacheLock.RUnlock()
+
+	d := newSimple(storage.NewMemCachedStore(dao.Store.Snapshot()), dao.Version.StateRootInHeader)
+	d.Version = dao.Version
+	maps.Copy(d.nativeCache, dao.nativeCache)
+	return d
+}
+
 // GetAndDecode performs get operation and decoding with serializable structures.
 func (dao *Simple) GetAndDecode(entity io.Serializable, key []byte) error {
 	entityBytes, err := dao.Store.Get(key)
@@ -140,117 +176,6 @@ func (dao *Simple) putWithBuffer(entity io.Serializable, key []byte, buf *io.Buf
 	return nil
 }
 
-// -- start NEP-17 transfer info.
-
-func (dao *Simple) makeTTIKey(acc util.Uint160) []byte {
-	key := dao.getKeyBuf(1 + util.Uint160Size)
-	key[0] = byte(storage.STTokenTransferInfo)
-	copy(key[1:], acc.BytesBE())
-	return key
-}
-
-// GetTokenTransferInfo retrieves NEP-17 transfer info from the cache.
-func (dao *Simple) GetTokenTransferInfo(acc util.Uint160) (*state.TokenTransferInfo, error) {
-	key := dao.makeTTIKey(acc)
-	bs := state.NewTokenTransferInfo()
-	err := dao.GetAndDecode(bs, key)
-	if err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
-		return nil, err
-	}
-	return bs, nil
-}
-
-// PutTokenTransferInfo saves NEP-17 transfer info in the cache.
-func (dao *Simple) PutTokenTransferInfo(acc util.Uint160, bs *state.TokenTransferInfo) error {
-	return dao.putTokenTransferInfo(acc, bs, dao.getDataBuf())
-}
-
-func (dao *Simple) putTokenTransferInfo(acc util.Uint160, bs *state.TokenTransferInfo, buf *io.BufBinWriter) error {
-	return dao.putWithBuffer(bs, dao.makeTTIKey(acc), buf)
-}
-
-// -- end NEP-17 transfer info.
-
-// -- start transfer log.
-
-func (dao *Simple) getTokenTransferLogKey(acc util.Uint160, newestTimestamp uint64, index uint32, isNEP11 bool) []byte {
-	key := dao.getKeyBuf(1 + util.Uint160Size + 8 + 4)
-	if isNEP11 {
-		key[0] = byte(storage.STNEP11Transfers)
-	} else {
-		key[0] = byte(storage.STNEP17Transfers)
-	}
-	copy(key[1:], acc.BytesBE())
-	binary.BigEndian.PutUint64(key[1+util.Uint160Size:], newestTimestamp)
-	binary.BigEndian.PutUint32(key[1+util.Uint160Size+8:], index)
-	return key
-}
-
-// SeekNEP17TransferLog executes f for each NEP-17 transfer in log starting from
-// the transfer with the newest timestamp up to the oldest transfer. It continues
-// iteration until false is returned from f. The last non-nil error is returned.
-func (dao *Simple) SeekNEP17TransferLog(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP17Transfer) (bool, error)) error {
-	key := dao.getTokenTransferLogKey(acc, newestTimestamp, 0, false)
-	prefixLen := 1 + util.Uint160Size
-	var seekErr error
-	dao.Store.Seek(storage.SeekRange{
-		Prefix:    key[:prefixLen],
-		Start:     key[prefixLen : prefixLen+8],
-		Backwards: true,
-	}, func(k, v []byte) bool {
-		lg := &state.TokenTransferLog{Raw: v}
-		cont, err := lg.ForEachNEP17(f)
-		if err != nil {
-			seekErr = err
-		}
-		return cont
-	})
-	return seekErr
-}
-
-// SeekNEP11TransferLog executes f for each NEP-11 transfer in log starting from
-// the transfer with the newest timestamp up to the oldest transfer. It continues
-// iteration until false is returned from f. The last non-nil error is returned.
-func (dao *Simple) SeekNEP11TransferLog(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP11Transfer) (bool, error)) error {
-	key := dao.getTokenTransferLogKey(acc, newestTimestamp, 0, true)
-	prefixLen := 1 + util.Uint160Size
-	var seekErr error
-	dao.Store.Seek(storage.SeekRange{
-		Prefix:    key[:prefixLen],
-		Start:     key[prefixLen : prefixLen+8],
-		Backwards: true,
-	}, func(k, v []byte) bool {
-		lg := &state.TokenTransferLog{Raw: v}
-		cont, err := lg.ForEachNEP11(f)
-		if err != nil {
-			seekErr = err
-		}
-		return cont
-	})
-	return seekErr
-}
-
-// GetTokenTransferLog retrieves transfer log from the cache.
-func (dao *Simple) GetTokenTransferLog(acc util.Uint160, newestTimestamp uint64, index uint32, isNEP11 bool) (*state.TokenTransferLog, error) {
-	key := dao.getTokenTransferLogKey(acc, newestTimestamp, index, isNEP11)
-	value, err := dao.Store.Get(key)
-	if err != nil {
-		if errors.Is(err, storage.ErrKeyNotFound) {
-			return new(state.TokenTransferLog), nil
-		}
-		return nil, err
-	}
-	return &state.TokenTransferLog{Raw: value}, nil
-}
-
-// PutTokenTransferLog saves the given transfer log in the cache.
-func (dao *Simple) PutTokenTransferLog(acc util.Uint160, start uint64, index uint32, isNEP11 bool, lg *state.TokenTransferLog) {
-	key := dao.getTokenTransferLogKey(acc, start, index, isNEP11)
-	dao.Store.Put(key, lg.Raw)
-}
-
-// -- end transfer log.
-
 // -- start notification event.
 
 func (dao *Simple) makeExecutableKey(hash util.Uint256) []byte {
@@ -279,26 +204,35 @@ func (dao *Simple) GetAppExecResults(hash util.Uint256, trig trigger.Type) ([]st
 		if err != nil {
 			return nil, err
 		}
-		result := make([]state.AppExecResult, 0, 2)
+		var (
+			result = make([]state.AppExecResult, 0, 2)
+			count  int
+		)
 		for {
-			aer := new(state.AppExecResult)
-			aer.DecodeBinary(r)
+			aer := dao.decodeAppExecResult(r)
 			if r.Err != nil {
 				if errors.Is(r.Err, iocore.EOF) {
 					break
 				}
 				return nil, r.Err
 			}
+			count++
 			if aer.Trigger&trig != 0 {
 				result = append(result, *aer)
 			}
 		}
+		if count == 0 { // Pruned logs, header-only or state-synchronized block.
+			return nil, ErrAppLogPruned
+		}
 		return result, nil
 	case storage.ExecTransaction:
-		_, _, aer, err := decodeTxAndExecResult(bs)
+		_, _, aer, err := dao.decodeTxAndExecResult(bs)
 		if err != nil {
 			return nil, err
 		}
+		if aer.Container.Equals(util.Uint256{}) {
+			return nil, ErrAppLogPruned
+		}
 		if aer.Trigger&trig != 0 {
 			return []state.AppExecResult{*aer}, nil
 		}
@@ -309,7 +243,8 @@ func (dao *Simple) GetAppExecResults(hash util.Uint256, trig trigger.Type) ([]st
 }
 
 // GetTxExecResult gets application execution result of the specified transaction
-// and returns the transaction itself, its height and its AppExecResult.
+// and returns the transaction itself, its height and its AppExecResult. If the
+// result is pruned, only its Trigger, VMState and GasConsumed fields are set.
 func (dao *Simple) GetTxExecResult(hash util.Uint256) (uint32, *transaction.Transaction, *state.AppExecResult, error) {
 	key := dao.makeExecutableKey(hash)
 	bs, err := dao.Store.Get(key)
@@ -322,12 +257,12 @@ func (dao *Simple) GetTxExecResult(hash util.Uint256) (uint32, *transaction.Tran
 	if bs[0] != storage.ExecTransaction {
 		return 0, nil, nil, storage.ErrKeyNotFound
 	}
-	return decodeTxAndExecResult(bs)
+	return dao.decodeTxAndExecResult(bs)
 }
 
 // decodeTxAndExecResult decodes transaction, its height and execution result from
 // the given executable bytes. It performs no executable prefix check.
-func decodeTxAndExecResult(buf []byte) (uint32, *transaction.Transaction, *state.AppExecResult, error) {
+func (dao *Simple) decodeTxAndExecResult(buf []byte) (uint32, *transaction.Transaction, *state.AppExecResult, error) {
 	if len(buf) == conflictRecordValueLen { // conflict record stub.
 		return 0, nil, nil, storage.ErrKeyNotFound
 	}
@@ -339,8 +274,7 @@ func decodeTxAndExecResult(buf []byte) (uint32, *transaction.Transaction, *state
 	if r.Err != nil {
 		return 0, nil, nil, r.Err
 	}
-	aer := new(state.AppExecResult)
-	aer.DecodeBinary(r)
+	aer := dao.decodeAppExecResult(r)
 	if r.Err != nil {
 		return 0, nil, nil, r.Err
 	}
@@ -348,6 +282,48 @@ func decodeTxAndExecResult(buf []byte) (uint32, *transaction.Transaction, *state
 	return h, tx, aer, nil
 }
 
+// encodeAppExecResult writes the given execution result to w compressing it if
+// CompressAppLogs is enabled.
+func (dao *Simple) encodeAppExecResult(aer *state.AppExecResult, w *io.BinWriter) {
+	if !dao.Version.CompressAppLogs {
+		aer.EncodeBinaryWithContext(w, dao.GetItemCtx())
+		return
+	}
+	buf := io.NewBufBinWriter()
+	aer.EncodeBinaryWithContext(buf.BinWriter, dao.GetItemCtx())
+	if buf.Err != nil {
+		w.Err = buf.Err
+		return
+	}
+	w.WriteVarBytes(zstdEncoder().EncodeAll(buf.Bytes(), nil))
+}
+
+// decodeAppExecResult reads execution result written by encodeAppExecResult
+// from r.
+func (dao *Simple) decodeAppExecResult(r *io.BinReader) *state.AppExecResult {
+	aer := new(state.AppExecResult)
+	if !dao.Version.CompressAppLogs {
+		aer.DecodeBinary(r)
+		return aer
+	}
+	data := r.ReadVarBytes()
+	if r.Err != nil {
+		return aer
+	}
+	data, err := zstdDecoder().DecodeAll(data, nil)
+	if err != nil {
+		r.Err = fmt.Errorf("%w: failed to decompress execution result: %s", ErrInternalDBInconsistency, err.Error())
+		return aer
+	}
+	ar := io.NewBinReaderFromBuf(data)
+	aer.DecodeBinary(ar)
+	if ar.Err != nil {
+		// Not wrapped, EOF is a valid end of the block execution results list.
+		r.Err = fmt.Errorf("%w: failed to decode execution result: %s", ErrInternalDBInconsistency, ar.Err.Error())
+	}
+	return aer
+}
+
 // -- end notification event.
 
 // -- start storage item.
@@ -376,6 +352,50 @@ func (dao *Simple) PutBigInt(id int32, key []byte, n *big.Int) {
 	dao.PutStorageItem(id, key, stData)
 }
 
+// Convertible is a pointer to T implementing stackitem.Convertible, it allows
+// generic functions to create new Convertible instances of the given type.
+type Convertible[T any] interface {
+	*T
+	stackitem.Convertible
+}
+
+// GetConvertible retrieves a storage item of type T for the given id with the
+// given key from the store. It returns storage.ErrKeyNotFound if there is no
+// such item.
+func GetConvertible[T any, P Convertible[T]](dao *Simple, id int32, key []byte) (P, error) {
+	si := dao.GetStorageItem(id, key)
+	if si == nil {
+		return nil, storage.ErrKeyNotFound
+	}
+	return DecodeConvertible[T, P](si)
+}
+
+// DecodeConvertible deserializes an item of type T from the given storage item
+// value.
+func DecodeConvertible[T any, P Convertible[T]](si state.StorageItem) (P, error) {
+	var item P = new(T)
+	err := stackitem.DeserializeConvertible(si, item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// PutConvertible serializes and puts the given Convertible item for the given
+// id with the given key into the given store.
+func (dao *Simple) PutConvertible(id int32, key []byte, conv stackitem.Convertible) error {
+	item, err := conv.ToStackItem()
+	if err != nil {
+		return err
+	}
+	data, err := dao.GetItemCtx().Serialize(item, false)
+	if err != nil {
+		return err
+	}
+	dao.PutStorageItem(id, key, data)
+	return nil
+}
+
 // DeleteStorageItem drops a storage item for the given id with the
 // given key from the store.
 func (dao *Simple) DeleteStorageItem(id int32, key []byte) {
@@ -383,8 +403,9 @@ func (dao *Simple) DeleteStorageItem(id int32, key []byte) {
 	dao.Store.Delete(stKey)
 }
 
-// Seek executes f for all storage items matching the given `rng` (matching the given prefix and
-// starting from the point specified). If the key or the value is to be used outside of f, they
+// Seek executes f for all storage items matching the given `rng` (matching the given prefix,
+// starting from the point specified and ending before the End key if it's set, in the order
+// defined by Backwards). If the key or the value is to be used outside of f, they
 // may not be copied. Seek continues iterating until false is returned from f. A requested prefix
 // (if any non-empty) is trimmed before passing to f.
 func (dao *Simple) Seek(id int32, rng storage.SeekRange, f func(k, v []byte) bool) {
@@ -394,8 +415,9 @@ func (dao *Simple) Seek(id int32, rng storage.SeekRange, f func(k, v []byte) boo
 	})
 }
 
-// SeekAsync sends all storage items matching the given `rng` (matching the given prefix and
-// starting from the point specified) to a channel and returns the channel.
+// SeekAsync sends all storage items matching the given `rng` (matching the given prefix,
+// starting from the point specified and ending before the End key if it's set, in the order
+// defined by Backwards) to a channel and returns the channel.
 // Resulting keys and values may not be copied.
 func (dao *Simple) SeekAsync(ctx context.Context, id int32, rng storage.SeekRange) chan storage.KeyValue {
 	rng.Prefix = bytes.Clone(dao.makeStorageItemKey(id, rng.Prefix))
@@ -446,6 +468,7 @@ type Version struct {
 	P2PSigExtensions           bool
 	P2PStateExchangeExtensions bool
 	KeepOnlyLatestState        bool
+	CompressAppLogs            bool
 	Magic                      uint32
 	Value                      string
 }
@@ -455,6 +478,7 @@ const (
 	p2pSigExtensionsBit
 	p2pStateExchangeExtensionsBit
 	keepOnlyLatestStateBit
+	compressAppLogsBit
 )
 
 // FromBytes decodes v from a byte-slice.
@@ -482,6 +506,7 @@ func (v *Version) FromBytes(data []byte) error {
 	v.P2PSigExtensions = data[i+2]&p2pSigExtensionsBit != 0
 	v.P2PStateExchangeExtensions = data[i+2]&p2pStateExchangeExtensionsBit != 0
 	v.KeepOnlyLatestState = data[i+2]&keepOnlyLatestStateBit != 0
+	v.CompressAppLogs = data[i+2]&compressAppLogsBit != 0
 
 	m := i + 3
 	if len(data) == m+4 {
@@ -505,6 +530,9 @@ func (v *Version) Bytes() []byte {
 	if v.KeepOnlyLatestState {
 		mask |= keepOnlyLatestStateBit
 	}
+	if v.CompressAppLogs {
+		mask |= compressAppLogsBit
+	}
 	res := append([]byte(v.Value), '\x00', byte(v.StoragePrefix), mask)
 	res = binary.LittleEndian.AppendUint32(res, v.Magic)
 	return res
@@ -560,6 +588,26 @@ func (dao *Simple) GetStateSyncPoint() (uint32, error) {
 	return binary.LittleEndian.Uint32(b), nil
 }
 
+// GetStateResetHeight returns the last state reset height requested by the
+// node configuration.
+func (dao *Simple) GetStateResetHeight() (uint32, error) {
+	b, err := dao.Store.Get(dao.mkKeyPrefix(storage.SYSStateResetHeight))
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// GetAppLogsPruneHeight returns the height of the first block which
+// application logs are not yet pruned.
+func (dao *Simple) GetAppLogsPruneHeight() (uint32, error) {
+	b, err := dao.Store.Get(dao.mkKeyPrefix(storage.SYSAppLogsPruneHeight))
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
 // GetStateSyncCurrentBlockHeight returns the current block height stored during state
 // synchronization process.
 func (dao *Simple) GetStateSyncCurrentBlockHeight() (uint32, error) {
@@ -663,6 +711,22 @@ func (dao *Simple) PutStateSyncPoint(p uint32) {
 	dao.Store.Put(dao.mkKeyPrefix(storage.SYSStateSyncPoint), buf.Bytes())
 }
 
+// PutStateResetHeight stores the last state reset height requested by the
+// node configuration.
+func (dao *Simple) PutStateResetHeight(h uint32) {
+	buf := dao.getDataBuf()
+	buf.WriteU32LE(h)
+	dao.Store.Put(dao.mkKeyPrefix(storage.SYSStateResetHeight), buf.Bytes())
+}
+
+// PutAppLogsPruneHeight stores the height of the first block which
+// application logs are not yet pruned.
+func (dao *Simple) PutAppLogsPruneHeight(h uint32) {
+	buf := dao.getDataBuf()
+	buf.WriteU32LE(h)
+	dao.Store.Put(dao.mkKeyPrefix(storage.SYSAppLogsPruneHeight), buf.Bytes())
+}
+
 // PutStateSyncCurrentBlockHeight stores the current block height during state synchronization process.
 func (dao *Simple) PutStateSyncCurrentBlockHeight(h uint32) {
 	buf := dao.getDataBuf()
@@ -752,10 +816,10 @@ func (dao *Simple) StoreAsBlock(block *block.Block, aer1 *state.AppExecResult, a
 	buf.WriteB(storage.ExecBlock)
 	block.EncodeTrimmed(buf.BinWriter)
 	if aer1 != nil {
-		aer1.EncodeBinaryWithContext(buf.BinWriter, dao.GetItemCtx())
+		dao.encodeAppExecResult(aer1, buf.BinWriter)
 	}
 	if aer2 != nil {
-		aer2.EncodeBinaryWithContext(buf.BinWriter, dao.GetItemCtx())
+		dao.encodeAppExecResult(aer2, buf.BinWriter)
 	}
 	if buf.Err != nil {
 		return buf.Err
@@ -818,6 +882,58 @@ func (dao *Simple) DeleteBlock(h util.Uint256) error {
 	return nil
 }
 
+// PruneAppExecResults removes execution results of the block with the given hash
+// and of all its transactions. Transaction VM states are kept since they're
+// accessible to smart contracts via Ledger contract. Pruned results are skipped.
+// It's not atomic, so make sure you're using private MemCached instance here.
+func (dao *Simple) PruneAppExecResults(h util.Uint256) error {
+	key := dao.makeExecutableKey(h)
+
+	b, err := dao.getBlock(key)
+	if err != nil {
+		return err
+	}
+	buf := dao.getDataBuf()
+	buf.WriteB(storage.ExecBlock)
+	b.EncodeTrimmed(buf.BinWriter)
+	if buf.Err != nil {
+		return buf.Err
+	}
+	dao.Store.Put(key, buf.Bytes())
+
+	for _, tx := range b.Transactions {
+		copy(key[1:], tx.Hash().BytesBE())
+		v, err := dao.Store.Get(key)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve transaction %s (height %d): %w", tx.Hash().StringLE(), b.Index, err)
+		}
+		index, t, aer, err := dao.decodeTxAndExecResult(v)
+		if err != nil {
+			return fmt.Errorf("failed to decode transaction %s (height %d): %w", tx.Hash().StringLE(), b.Index, err)
+		}
+		if aer.Container.Equals(util.Uint256{}) {
+			continue
+		}
+		stub := &state.AppExecResult{
+			Execution: state.Execution{
+				Trigger:     aer.Trigger,
+				VMState:     aer.VMState,
+				GasConsumed: aer.GasConsumed,
+			},
+		}
+		buf = dao.getDataBuf()
+		buf.WriteB(storage.ExecTransaction)
+		buf.WriteU32LE(index)
+		t.EncodeBinary(buf.BinWriter)
+		dao.encodeAppExecResult(stub, buf.BinWriter)
+		if buf.Err != nil {
+			return buf.Err
+		}
+		dao.Store.Put(key, buf.Bytes())
+	}
+	return nil
+}
+
 // PurgeHeader completely removes specified header from dao. It differs from
 // DeleteBlock in that it removes header anyway and does nothing except removing
 // header. It does no checks for header existence.
@@ -865,7 +981,7 @@ func (dao *Simple) StoreAsTransaction(tx *transaction.Transaction, index uint32,
 	buf.WriteU32LE(index)
 	tx.EncodeBinary(buf.BinWriter)
 	if aer != nil {
-		aer.EncodeBinaryWithContext(buf.BinWriter, dao.GetItemCtx())
+		dao.encodeAppExecResult(aer, buf.BinWriter)
 	}
 	if buf.Err != nil {
 		return buf.Err
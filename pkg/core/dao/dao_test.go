@@ -1,7 +1,10 @@
 package dao
 
 import (
+	"context"
 	"encoding/binary"
+	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
@@ -14,6 +17,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,6 +55,30 @@ func TestPutGetStorageItem(t *testing.T) {
 	require.Equal(t, storageItem, gotStorageItem)
 }
 
+func TestPutGetConvertible(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	key := []byte{1, 2, 3}
+
+	_, err := GetConvertible[state.Deposit](dao, 1, key)
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+
+	d := &state.Deposit{Amount: big.NewInt(42), Till: 100}
+	require.NoError(t, dao.PutConvertible(1, key, d))
+
+	actual, err := GetConvertible[state.Deposit](dao, 1, key)
+	require.NoError(t, err)
+	require.Equal(t, d, actual)
+
+	_, err = GetConvertible[state.Deposit](dao, 2, key)
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+
+	_, err = GetConvertible[state.OracleRequest](dao, 1, key)
+	require.Error(t, err)
+
+	_, err = DecodeConvertible[state.Deposit]([]byte{0xff})
+	require.Error(t, err)
+}
+
 func TestDeleteStorageItem(t *testing.T) {
 	dao := NewSimple(storage.NewMemoryStore(), false)
 	id := int32(random.Int(0, 1024))
@@ -62,6 +90,81 @@ func TestDeleteStorageItem(t *testing.T) {
 	require.Nil(t, gotStorageItem)
 }
 
+func TestSeekStorageRange(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	for _, id := range []int32{1, 2} {
+		for i := range byte(10) {
+			dao.PutStorageItem(id, []byte{0xff, i}, state.StorageItem{i})
+		}
+	}
+	dao = dao.GetWrapped()
+	dao.PutStorageItem(1, []byte{0xff, 4, 1}, state.StorageItem{41})
+	dao.DeleteStorageItem(1, []byte{0xff, 6})
+
+	seek := func(rng storage.SeekRange) []string {
+		var res []string
+		dao.Seek(1, rng, func(k, v []byte) bool {
+			res = append(res, fmt.Sprintf("%x", k))
+			return true
+		})
+		var async []string
+		for kv := range dao.SeekAsync(context.Background(), 1, rng) {
+			async = append(async, fmt.Sprintf("%x", kv.Key))
+		}
+		require.Equal(t, res, async)
+		return res
+	}
+	require.Equal(t, []string{"03", "04", "0401", "05", "07"},
+		seek(storage.SeekRange{Prefix: []byte{0xff}, Start: []byte{3}, End: []byte{8}}))
+	require.Equal(t, []string{"07", "05", "0401"},
+		seek(storage.SeekRange{Prefix: []byte{0xff}, Start: []byte{7}, End: []byte{4}, Backwards: true}))
+	require.Equal(t, []string{"09", "08"},
+		seek(storage.SeekRange{Prefix: []byte{0xff}, End: []byte{7}, Backwards: true}))
+}
+
+type testNativeCache struct {
+	v int
+}
+
+func (c *testNativeCache) Copy() NativeContractCache {
+	cp := *c
+	return &cp
+}
+
+func TestGetSnapshot(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao.PutStorageItem(1, []byte{1}, state.StorageItem{1})
+	dao.SetCache(1, &testNativeCache{v: 1})
+
+	snap := dao.GetSnapshot()
+	require.Equal(t, dao.Version, snap.Version)
+
+	cache := dao.GetPrivate()
+	cache.PutStorageItem(1, []byte{1}, state.StorageItem{2})
+	cache.PutStorageItem(1, []byte{2}, state.StorageItem{2})
+	cache.GetRWCache(1).(*testNativeCache).v = 2
+	_, err := cache.Persist()
+	require.NoError(t, err)
+
+	require.Equal(t, state.StorageItem{2}, dao.GetStorageItem(1, []byte{1}))
+	require.Equal(t, 2, dao.GetROCache(1).(*testNativeCache).v)
+
+	require.Equal(t, state.StorageItem{1}, snap.GetStorageItem(1, []byte{1}))
+	require.Nil(t, snap.GetStorageItem(1, []byte{2}))
+	require.Equal(t, 1, snap.GetROCache(1).(*testNativeCache).v)
+
+	// Speculative changes are possible, but can't be persisted.
+	priv := snap.GetPrivate()
+	priv.PutStorageItem(1, []byte{1}, state.StorageItem{3})
+	require.Equal(t, state.StorageItem{3}, priv.GetStorageItem(1, []byte{1}))
+	_, err = priv.Persist()
+	require.NoError(t, err)
+	_, err = snap.Persist()
+	require.ErrorIs(t, err, storage.ErrReadOnlySnapshot)
+
+	require.NoError(t, snap.Store.Close())
+}
+
 func TestGetBlock_NotExists(t *testing.T) {
 	dao := NewSimple(storage.NewMemoryStore(), false)
 	hash := random.Uint256()
@@ -109,6 +212,87 @@ func TestPutGetBlock(t *testing.T) {
 	require.Equal(t, *appExecResult2, gotAppExecResult[1])
 }
 
+func TestPruneAppExecResults(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compress=%t", compress), func(t *testing.T) {
+			dao := NewSimple(storage.NewMemoryStore(), false)
+			dao.Version.CompressAppLogs = compress
+
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, 1)
+			tx.Signers = append(tx.Signers, transaction.Signer{})
+			tx.Scripts = append(tx.Scripts, transaction.Witness{})
+			b := &block.Block{
+				Header: block.Header{
+					Index: 1,
+					Script: transaction.Witness{
+						VerificationScript: []byte{byte(opcode.PUSH1)},
+						InvocationScript:   []byte{byte(opcode.NOP)},
+					},
+				},
+				Transactions: []*transaction.Transaction{tx},
+			}
+			txAER := &state.AppExecResult{
+				Container: tx.Hash(),
+				Execution: state.Execution{
+					Trigger:     trigger.Application,
+					VMState:     vmstate.Halt,
+					GasConsumed: 42,
+					Events: []state.NotificationEvent{{
+						ScriptHash: util.Uint160{1, 2, 3},
+						Name:       "Event",
+						Item:       stackitem.NewArray([]stackitem.Item{stackitem.NewByteArray(make([]byte, 100))}),
+					}},
+					Stack: []stackitem.Item{stackitem.NewBigInteger(big.NewInt(1))},
+				},
+			}
+			bAER := &state.AppExecResult{
+				Container: b.Hash(),
+				Execution: state.Execution{
+					Trigger: trigger.OnPersist,
+					Events:  []state.NotificationEvent{},
+					Stack:   []stackitem.Item{},
+				},
+			}
+			require.NoError(t, dao.StoreAsTransaction(tx, b.Index, txAER))
+			require.NoError(t, dao.StoreAsBlock(b, bAER, nil))
+
+			aers, err := dao.GetAppExecResults(tx.Hash(), trigger.All)
+			require.NoError(t, err)
+			require.Equal(t, []state.AppExecResult{*txAER}, aers)
+			aers, err = dao.GetAppExecResults(b.Hash(), trigger.All)
+			require.NoError(t, err)
+			require.Equal(t, []state.AppExecResult{*bAER}, aers)
+
+			require.NoError(t, dao.PruneAppExecResults(b.Hash()))
+			_, err = dao.GetAppExecResults(tx.Hash(), trigger.All)
+			require.ErrorIs(t, err, ErrAppLogPruned)
+			_, err = dao.GetAppExecResults(b.Hash(), trigger.All)
+			require.ErrorIs(t, err, ErrAppLogPruned)
+
+			h, actualTx, aer, err := dao.GetTxExecResult(tx.Hash())
+			require.NoError(t, err)
+			require.Equal(t, b.Index, h)
+			require.Equal(t, tx.Hash(), actualTx.Hash())
+			require.Equal(t, trigger.Application, aer.Trigger)
+			require.Equal(t, vmstate.Halt, aer.VMState)
+			require.Equal(t, int64(42), aer.GasConsumed)
+
+			actualB, err := dao.GetBlock(b.Hash())
+			require.NoError(t, err)
+			require.Equal(t, b.Hash(), actualB.Hash())
+			require.Equal(t, 1, len(actualB.Transactions))
+
+			// Pruning again is a no-op.
+			require.NoError(t, dao.PruneAppExecResults(b.Hash()))
+			_, _, aer, err = dao.GetTxExecResult(tx.Hash())
+			require.NoError(t, err)
+			require.Equal(t, vmstate.Halt, aer.VMState)
+
+			require.ErrorIs(t, dao.PruneAppExecResults(util.Uint256{1, 2, 3}), storage.ErrKeyNotFound)
+		})
+	}
+}
+
 func TestGetVersion_NoVersion(t *testing.T) {
 	dao := NewSimple(storage.NewMemoryStore(), false)
 	version, err := dao.GetVersion()
@@ -122,6 +306,7 @@ func TestGetVersion(t *testing.T) {
 		StoragePrefix:     0x42,
 		P2PSigExtensions:  true,
 		StateRootInHeader: true,
+		CompressAppLogs:   true,
 		Value:             "testVersion",
 	}
 	dao.PutVersion(expected)
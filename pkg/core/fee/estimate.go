@@ -0,0 +1,107 @@
+package fee
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// SyscallPriceGetter returns the base price of the syscall with the given ID
+// (that is to be multiplied by the execution fee factor) and a flag showing
+// whether the syscall is known.
+type SyscallPriceGetter func(id uint32) (int64, bool)
+
+// DynamicCost describes a script instruction which execution price can't be
+// determined statically.
+type DynamicCost struct {
+	// Offset is the instruction offset in the script.
+	Offset int
+	// Op is the instruction opcode.
+	Op opcode.Opcode
+	// Reason is a human-readable description of the dynamic part.
+	Reason string
+}
+
+// Estimation is a static estimation of script execution price.
+type Estimation struct {
+	// Fee is the sum of opcode and fixed syscall prices of all script
+	// instructions multiplied by the execution fee factor.
+	Fee int64
+	// Dynamic lists instructions that can add an unknown amount of GAS
+	// to the actual execution price.
+	Dynamic []DynamicCost
+}
+
+// dynamicSyscalls contains syscalls charging additional GAS (or a different
+// amount of GAS) depending on their parameters or chain state.
+var dynamicSyscalls = map[uint32]string{
+	interopnames.ToID([]byte(interopnames.SystemContractCall)):                  "contract call",
+	interopnames.ToID([]byte(interopnames.SystemContractCallNative)):            "native method price",
+	interopnames.ToID([]byte(interopnames.SystemContractCreateMultisigAccount)): "depends on the number of keys",
+	interopnames.ToID([]byte(interopnames.SystemContractCreateStandardAccount)): "depends on enabled hardforks",
+	interopnames.ToID([]byte(interopnames.SystemCryptoCheckMultisig)):           "depends on the number of keys",
+	interopnames.ToID([]byte(interopnames.SystemRuntimeBurnGas)):                "burns the given amount of GAS",
+	interopnames.ToID([]byte(interopnames.SystemRuntimeGetRandom)):              "depends on enabled hardforks",
+	interopnames.ToID([]byte(interopnames.SystemRuntimeLoadScript)):             "script execution",
+	interopnames.ToID([]byte(interopnames.SystemStoragePut)):                    "storage fee depends on the data size",
+}
+
+// Estimate returns a static execution price estimation for the given script
+// using the given execution fee factor and syscall prices. Every instruction
+// is accounted for exactly once, so the resulting Fee is precise for linear
+// scripts without dynamic parts (like typical transaction scripts) and should
+// be treated as an approximation for anything else. Method and contract
+// calls, loops, unknown syscalls and syscalls with parameter-dependent prices
+// are reported in Dynamic.
+func Estimate(base int64, script []byte, syscallPrice SyscallPriceGetter) (*Estimation, error) {
+	var (
+		res Estimation
+		ctx = vm.NewContext(script)
+	)
+	for ctx.NextIP() < len(script) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			return nil, fmt.Errorf("instruction at %d: %w", ctx.IP(), err)
+		}
+		res.Fee += Opcode(base, op)
+		var reason string
+		switch op {
+		case opcode.SYSCALL:
+			id := binary.LittleEndian.Uint32(param)
+			price, ok := syscallPrice(id)
+			if !ok {
+				reason = fmt.Sprintf("unknown syscall %x", param)
+				break
+			}
+			res.Fee += price * base
+			reason = dynamicSyscalls[id]
+		case opcode.CALL, opcode.CALLL:
+			reason = "method call"
+		case opcode.CALLT:
+			reason = "method token call"
+		case opcode.CALLA:
+			reason = "indirect call"
+		case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ, opcode.JMPNE,
+			opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE:
+			if int8(param[0]) < 0 {
+				reason = "loop"
+			}
+		case opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.JMPEQL, opcode.JMPNEL,
+			opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLTL, opcode.JMPLEL:
+			if int32(binary.LittleEndian.Uint32(param)) < 0 {
+				reason = "loop"
+			}
+		}
+		if reason != "" {
+			res.Dynamic = append(res.Dynamic, DynamicCost{
+				Offset: ctx.IP(),
+				Op:     op,
+				Reason: reason,
+			})
+		}
+	}
+	return &res, nil
+}
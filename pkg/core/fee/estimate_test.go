@@ -0,0 +1,70 @@
+package fee
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimate(t *testing.T) {
+	var (
+		logID    = interopnames.ToID([]byte(interopnames.SystemRuntimeLog))
+		putID    = interopnames.ToID([]byte(interopnames.SystemStoragePut))
+		getPrice = func(id uint32) (int64, bool) {
+			switch id {
+			case logID:
+				return 1 << 15, true
+			case putID:
+				return 1 << 15, true
+			}
+			return 0, false
+		}
+	)
+
+	t.Run("linear", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.String(w.BinWriter, "hello")
+		emit.Syscall(w.BinWriter, interopnames.SystemRuntimeLog)
+		emit.Opcodes(w.BinWriter, opcode.PUSH1, opcode.PUSH2, opcode.ADD)
+		require.NoError(t, w.Err)
+
+		est, err := Estimate(feeFactor, w.Bytes(), getPrice)
+		require.NoError(t, err)
+		require.Equal(t, Opcode(feeFactor, opcode.PUSHDATA1, opcode.SYSCALL, opcode.PUSH1, opcode.PUSH2, opcode.ADD)+(1<<15)*feeFactor, est.Fee)
+		require.Empty(t, est.Dynamic)
+	})
+
+	t.Run("dynamic", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Opcodes(w.BinWriter, opcode.NOP)                       // 0
+		emit.Syscall(w.BinWriter, interopnames.SystemStoragePut)    // 1
+		emit.Syscall(w.BinWriter, interopnames.SystemRuntimeNotify) // 6
+		emit.Instruction(w.BinWriter, opcode.CALLT, []byte{0, 0})   // 11
+		emit.Instruction(w.BinWriter, opcode.JMP, []byte{0xff})     // 14
+		emit.Instruction(w.BinWriter, opcode.JMP, []byte{2})        // 16
+		emit.Instruction(w.BinWriter, opcode.CALL, []byte{0xfe})    // 18
+		require.NoError(t, w.Err)
+
+		est, err := Estimate(feeFactor, w.Bytes(), getPrice)
+		require.NoError(t, err)
+		require.Equal(t, Opcode(feeFactor, opcode.NOP, opcode.SYSCALL, opcode.SYSCALL, opcode.CALLT, opcode.JMP, opcode.JMP, opcode.CALL)+(1<<15)*feeFactor, est.Fee)
+		require.Equal(t, []DynamicCost{
+			{Offset: 1, Op: opcode.SYSCALL, Reason: "storage fee depends on the data size"},
+			{Offset: 6, Op: opcode.SYSCALL, Reason: "unknown syscall 95016f61"},
+			{Offset: 11, Op: opcode.CALLT, Reason: "method token call"},
+			{Offset: 14, Op: opcode.JMP, Reason: "loop"},
+			{Offset: 18, Op: opcode.CALL, Reason: "method call"},
+		}, est.Dynamic)
+	})
+
+	t.Run("invalid script", func(t *testing.T) {
+		_, err := Estimate(feeFactor, []byte{byte(opcode.PUSHDATA1), 10}, getPrice)
+		require.Error(t, err)
+		_, err = Estimate(feeFactor, []byte{0xff}, getPrice)
+		require.Error(t, err)
+	})
+}
@@ -70,6 +70,8 @@ type Context struct {
 	loadToken        func(ic *Context, id int32) error
 	GetRandomCounter uint32
 	signers          []transaction.Signer
+	// maxIntegerSize is the VM Integer size limit in bits, 0 means default.
+	maxIntegerSize int
 }
 
 // NewContext returns new interop context.
@@ -94,6 +96,7 @@ func NewContext(trigger trigger.Type, bc Ledger, d *dao.Simple, baseExecFee, bas
 		baseExecFee:    baseExecFee,
 		baseStorageFee: baseStorageFee,
 		loadToken:      loadTokenFunc,
+		maxIntegerSize: int(cfg.MaxIntegerSize) * 8,
 	}
 }
 
@@ -102,6 +105,23 @@ func (ic *Context) InitNonceData() {
 	if tx, ok := ic.Container.(*transaction.Transaction); ok {
 		ic.NonceData = [ContextNonceDataLen]byte(tx.Hash().BytesBE())
 	}
+	ic.applyBlockNonce()
+}
+
+// ReplayRandom reinitializes `GetRandom` state as if the context was created
+// for the transaction with the given hash, so that `GetRandom` returns the
+// same sequence of values it returned during the original transaction
+// execution (provided that the context block has the same nonce as the block
+// containing this transaction). It's intended to be used for historic
+// invocations and debugging, it must be called before the execution starts.
+func (ic *Context) ReplayRandom(txHash util.Uint256) {
+	ic.NonceData = [ContextNonceDataLen]byte(txHash.BytesBE())
+	ic.GetRandomCounter = 0
+	ic.applyBlockNonce()
+}
+
+// applyBlockNonce mixes the context block nonce (if any) into NonceData.
+func (ic *Context) applyBlockNonce() {
 	if ic.Block != nil {
 		nonce := ic.Block.Nonce
 		nonce ^= binary.LittleEndian.Uint64(ic.NonceData[:])
@@ -294,7 +314,7 @@ func (c *ContractMD) buildHFSpecificMD(hf config.Hardfork) {
 	w := io.NewBufBinWriter()
 	for i := range c.methods {
 		m := c.methods[i]
-		if !(m.ActiveFrom == nil || (hf != config.HFDefault && (*m.ActiveFrom).Cmp(hf) >= 0)) ||
+		if !(m.ActiveFrom == nil || (hf != config.HFDefault && (*m.ActiveFrom).Cmp(hf) <= 0)) ||
 			(m.ActiveTill != nil && (*m.ActiveTill).Cmp(hf) <= 0) {
 			continue
 		}
@@ -317,7 +337,7 @@ func (c *ContractMD) buildHFSpecificMD(hf config.Hardfork) {
 	}
 	for i := range c.events {
 		e := c.events[i]
-		if !(e.ActiveFrom == nil || (hf != config.HFDefault && (*e.ActiveFrom).Cmp(hf) >= 0)) ||
+		if !(e.ActiveFrom == nil || (hf != config.HFDefault && (*e.ActiveFrom).Cmp(hf) <= 0)) ||
 			(e.ActiveTill != nil && (*e.ActiveTill).Cmp(hf) <= 0) {
 			continue
 		}
@@ -484,6 +504,9 @@ func (ic *Context) initVM(v *vm.VM) {
 	v.GasLimit = -1
 	v.SyscallHandler = ic.SyscallHandler
 	v.SetPriceGetter(ic.GetPrice)
+	if ic.maxIntegerSize != 0 {
+		v.SetMaxIntegerSize(ic.maxIntegerSize)
+	}
 	ic.VM = v
 }
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,3 +34,32 @@ func TestIsHardforkEnabled(t *testing.T) {
 		require.True(t, ic.IsHardforkEnabled(config.HFAspidochelone))
 	})
 }
+
+func TestBuildHFSpecificMD(t *testing.T) {
+	var (
+		c          = NewContractMD("Test", -100)
+		cockatrice = config.HFCockatrice
+		echidna    = config.HFEchidna
+	)
+	addMethod := func(name string, from, till *config.Hardfork) {
+		c.AddMethod(&MethodAndPrice{ActiveFrom: from, ActiveTill: till}, &manifest.Method{Name: name})
+	}
+	addMethod("always", nil, nil)
+	addMethod("old", nil, &cockatrice)
+	addMethod("cockatrice", &cockatrice, nil)
+	addMethod("echidna", &echidna, nil)
+	c.BuildHFSpecificMD(nil)
+
+	check := func(hf config.Hardfork, expected ...string) {
+		var actual []string
+		for _, m := range c.HFSpecificContractMD(&hf).Methods {
+			actual = append(actual, m.MD.Name)
+		}
+		require.Equal(t, expected, actual, hf.String())
+	}
+	check(config.HFDefault, "always", "old")
+	check(config.HFBasilisk, "always", "old")
+	check(config.HFCockatrice, "always", "cockatrice")
+	check(config.HFDomovoi, "always", "cockatrice")
+	check(config.HFEchidna, "always", "cockatrice", "echidna")
+}
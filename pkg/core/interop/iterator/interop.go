@@ -10,11 +10,25 @@ type iterator interface {
 	Value() stackitem.Item
 }
 
+// meteredIterator is an iterator that limits and charges for the items
+// traversed by contracts.
+type meteredIterator interface {
+	// Charge is called for every item the iterator is advanced to by
+	// contract, it returns an error if this item can't be returned.
+	Charge(ic *interop.Context) error
+}
+
 // Next advances the iterator, pushes true on success and false otherwise.
 func Next(ic *interop.Context) error {
 	iop := ic.VM.Estack().Pop().Interop()
 	arr := iop.Value().(iterator)
-	ic.VM.Estack().PushItem(stackitem.Bool(arr.Next()))
+	next := arr.Next()
+	if m, ok := arr.(meteredIterator); ok && next {
+		if err := m.Charge(ic); err != nil {
+			return err
+		}
+	}
+	ic.VM.Estack().PushItem(stackitem.Bool(next))
 
 	return nil
 }
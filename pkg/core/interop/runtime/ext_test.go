@@ -600,6 +600,32 @@ func TestGetRandom_DifferentTransactions(t *testing.T) {
 	require.NotEqual(t, r1, r2)
 }
 
+func TestGetRandom_Replay(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	w := io.NewBufBinWriter()
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeGetRandom)
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeGetRandom)
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	tx1 := e.PrepareInvocation(t, script, []neotest.Signer{e.Validator}, bc.BlockHeight()+1)
+	tx2 := e.PrepareInvocation(t, script, []neotest.Signer{e.Validator}, bc.BlockHeight()+1)
+	b := e.AddNewBlock(t, tx1, tx2)
+	e.CheckHalt(t, tx2.Hash())
+	expected := e.GetTxExecResult(t, tx2.Hash()).Stack
+
+	tx := e.PrepareInvocation(t, script, []neotest.Signer{e.Validator}, bc.BlockHeight()+1)
+	ic, err := bc.GetTestVM(trigger.Application, tx, b)
+	require.NoError(t, err)
+	ic.ReplayRandom(tx2.Hash())
+	ic.VM.GasLimit = 1_00000000
+	ic.VM.LoadScriptWithFlags(script, callflag.All)
+	require.NoError(t, ic.VM.Run())
+	require.Equal(t, expected, ic.VM.Estack().ToArray())
+}
+
 // Tests are taken from
 // https://github.com/neo-project/neo/blob/master/tests/neo.UnitTests/SmartContract/UT_ApplicationEngine.Runtime.cs
 func TestGetRandomCompatibility(t *testing.T) {
@@ -3,9 +3,11 @@ package storage
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -26,6 +28,16 @@ const (
 		FindDeserialize | FindPick0 | FindPick1 | FindBackwards
 )
 
+// FindItemBytePrice is the price (in the base execution fee units) of every
+// byte of the key-value pair a contract gets via storage iterator starting from
+// Echidna hardfork if NeoGoExtensions are enabled.
+const FindItemBytePrice = 1 << 4
+
+// ErrFindLimitExceeded is returned from System.Iterator.Next when a contract
+// tries to traverse more storage items than allowed by MaxStorageFindResults
+// protocol setting.
+var ErrFindLimitExceeded = errors.New("storage iterator items limit exceeded")
+
 // Iterator is an iterator state representation.
 type Iterator struct {
 	seekCh chan storage.KeyValue
@@ -36,6 +48,15 @@ type Iterator struct {
 	// copied if no FindRemovePrefix option specified since it's shared between all
 	// iterator items.
 	prefix []byte
+	// metered is set for iterators created after Echidna hardfork with
+	// NeoGoExtensions enabled, items traversed by contracts are limited and
+	// charged for in this case.
+	metered bool
+	// limit is the maximum number of items contract can traverse, it's only
+	// relevant for metered iterators.
+	limit int
+	// count is the number of items traversed by contract.
+	count int
 }
 
 // NewIterator creates a new Iterator with the given options for the given channel of store.Seek results.
@@ -54,6 +75,29 @@ func (s *Iterator) Next() bool {
 	return s.next
 }
 
+// Charge is called by System.Iterator.Next for every item a contract gets, it
+// checks the number of items traversed against the limit and charges for the
+// current item size. It's a no-op for iterators created before Echidna
+// hardfork or without NeoGoExtensions enabled. Items traversed outside of
+// contract execution (like RPC server iterator sessions) are not affected.
+func (s *Iterator) Charge(ic *interop.Context) error {
+	if !s.metered {
+		return nil
+	}
+	s.count++
+	if s.count > s.limit {
+		return fmt.Errorf("%w: %d", ErrFindLimitExceeded, s.limit)
+	}
+	size := int64(len(s.curr.Key) + len(s.curr.Value))
+	if s.opts&FindRemovePrefix == 0 {
+		size += int64(len(s.prefix))
+	}
+	if !ic.VM.AddGas(ic.BaseExecFee() * FindItemBytePrice * size) {
+		return ErrGasLimitExceeded
+	}
+	return nil
+}
+
 // Value returns current iterators value (exact type depends on options this
 // iterator was created with).
 func (s *Iterator) Value() stackitem.Item {
@@ -120,6 +164,10 @@ func Find(ic *interop.Context) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	seekres := ic.DAO.SeekAsync(ctx, stc.ID, storage.SeekRange{Prefix: prefix, Backwards: bkwrds})
 	item := NewIterator(seekres, prefix, opts)
+	if cfg := ic.Chain.GetConfig(); cfg.NeoGoExtensions && ic.IsHardforkEnabled(config.HFEchidna) {
+		item.metered = true
+		item.limit = int(cfg.MaxStorageFindResults)
+	}
 	ic.VM.Estack().PushItem(stackitem.NewInterop(item))
 	ic.RegisterCancelFunc(func() {
 		cancel()
@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/limits"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -306,6 +307,97 @@ func TestFind(t *testing.T) {
 	})
 }
 
+func TestFindLimits(t *testing.T) {
+	const limit = 3
+
+	keys := [][]byte{{0x01, 0x01}, {0x01, 0x02}, {0x01, 0x03}, {0x01, 0x04}}
+	values := [][]byte{{1}, {2, 2}, {3, 3, 3}, {4, 4, 4, 4}}
+
+	newContext := func(t *testing.T, echidna uint32, extensions bool) (*vm.VM, *interop.Context, int32) {
+		bc, _ := chain.NewSingleWithCustomConfig(t, func(cfg *config.Blockchain) {
+			cfg.NeoGoExtensions = extensions
+			cfg.MaxStorageFindResults = limit
+			cfg.Hardforks = map[string]uint32{
+				config.HFEchidna.String(): echidna,
+			}
+		})
+		ic, err := bc.GetTestVM(trigger.Application, &transaction.Transaction{}, &block.Block{})
+		require.NoError(t, err)
+		v := ic.SpawnVM()
+		_, cs, _, _ := createVMAndContractState(t)
+		require.NoError(t, native.PutContractState(ic.DAO, cs))
+		for i := range keys {
+			ic.DAO.PutStorageItem(cs.ID, keys[i], values[i])
+		}
+		return v, ic, cs.ID
+	}
+	find := func(t *testing.T, v *vm.VM, ic *interop.Context, id int32, prefix []byte, opts int64) stackitem.Item {
+		v.Estack().PushVal(opts)
+		v.Estack().PushVal(prefix)
+		v.Estack().PushVal(stackitem.NewInterop(&istorage.Context{ID: id}))
+		require.NoError(t, istorage.Find(ic))
+		return v.Estack().Pop().Item()
+	}
+	next := func(t *testing.T, v *vm.VM, ic *interop.Context, iter stackitem.Item) bool {
+		v.Estack().PushItem(iter)
+		require.NoError(t, iterator.Next(ic))
+		return v.Estack().Pop().Bool()
+	}
+
+	checkUnlimited := func(t *testing.T, echidna uint32, extensions bool) {
+		v, ic, id := newContext(t, echidna, extensions)
+		iter := find(t, v, ic, id, []byte{0x01}, istorage.FindDefault)
+		for range keys {
+			require.True(t, next(t, v, ic, iter))
+		}
+		require.False(t, next(t, v, ic, iter))
+		require.Equal(t, int64(0), v.GasConsumed())
+	}
+
+	t.Run("before Echidna", func(t *testing.T) {
+		checkUnlimited(t, 100500, true)
+	})
+	t.Run("without extensions", func(t *testing.T) {
+		checkUnlimited(t, 0, false)
+	})
+	t.Run("after Echidna", func(t *testing.T) {
+		v, ic, id := newContext(t, 0, true)
+		price := func(size int) int64 {
+			return ic.BaseExecFee() * istorage.FindItemBytePrice * int64(size)
+		}
+
+		t.Run("limit", func(t *testing.T) {
+			iter := find(t, v, ic, id, []byte{0x01}, istorage.FindDefault)
+			for range limit {
+				require.True(t, next(t, v, ic, iter))
+			}
+			v.Estack().PushItem(iter)
+			require.ErrorIs(t, iterator.Next(ic), istorage.ErrFindLimitExceeded)
+			require.Equal(t, price(2+1+2+2+2+3), v.GasConsumed())
+		})
+		t.Run("exactly limit items", func(t *testing.T) {
+			before := v.GasConsumed()
+			iter := find(t, v, ic, id, []byte{0x01, 0x02}, istorage.FindRemovePrefix)
+			require.True(t, next(t, v, ic, iter))
+			require.False(t, next(t, v, ic, iter))
+			require.Equal(t, price(2), v.GasConsumed()-before) // Key without prefix and value.
+		})
+		t.Run("not enough gas", func(t *testing.T) {
+			v.GasLimit = v.GasConsumed() + price(3)
+			iter := find(t, v, ic, id, []byte{0x01}, istorage.FindKeysOnly)
+			require.True(t, next(t, v, ic, iter))
+			v.Estack().PushItem(iter)
+			require.ErrorIs(t, iterator.Next(ic), istorage.ErrGasLimitExceeded)
+		})
+		t.Run("traversal outside of contract", func(t *testing.T) {
+			iter := find(t, v, ic, id, []byte{0x01}, istorage.FindDefault)
+			before := v.GasConsumed()
+			require.Len(t, iterator.Values(iter, 10), len(keys))
+			require.Equal(t, before, v.GasConsumed())
+		})
+	})
+}
+
 // Helper functions to create VM, InteropContext, TX, Account, Contract.
 
 func createVM(t testing.TB) (*vm.VM, *interop.Context, *core.Blockchain) {
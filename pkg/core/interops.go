@@ -8,6 +8,9 @@ package core
 */
 
 import (
+	"cmp"
+	"slices"
+
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
@@ -83,6 +86,20 @@ var systemInterops = []interop.Function{
 		RequiredFlags: callflag.ReadStates, ParamCount: 1},
 }
 
+// SyscallPrice returns the base price of the system interop function with the
+// given ID (it's to be multiplied by the execution fee factor) and a flag
+// showing whether this function exists. It can be used as
+// [fee.SyscallPriceGetter].
+func SyscallPrice(id uint32) (int64, bool) {
+	n, ok := slices.BinarySearchFunc(systemInterops, id, func(f interop.Function, id uint32) int {
+		return cmp.Compare(f.ID, id)
+	})
+	if !ok {
+		return 0, false
+	}
+	return systemInterops[n].Price, true
+}
+
 // init initializes IDs in the global interop slices.
 func init() {
 	for i := range systemInterops {
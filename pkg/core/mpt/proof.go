@@ -3,12 +3,33 @@ package mpt
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
+// ErrIncompleteProof is returned from VerifyRangeProof if some node required
+// to check the range is missing from the proof.
+var ErrIncompleteProof = errors.New("incomplete proof")
+
+// rangeWalker traverses MPT in the key order collecting items matching the
+// prefix that are located after the `from` key (if set) along with all nodes
+// visited. It's used both to build and to verify range proofs, so the set of
+// nodes visited depends only on the trie contents and walker parameters.
+type rangeWalker struct {
+	prefix []byte // Nibbles.
+	from   []byte // Nibbles, nil if not set.
+	maxNum int
+	get    func(util.Uint256) (Node, error)
+
+	res   []storage.KeyValue
+	proof [][]byte
+}
+
 // GetProof returns a proof that the key belongs to t.
 // The proof consists of serialized nodes occurring on the path from the root to the leaf of key.
 func (t *Trie) GetProof(key []byte) ([][]byte, error) {
@@ -76,3 +97,127 @@ func VerifyProof(rh util.Uint256, key []byte, proofs [][]byte) ([]byte, bool) {
 	}
 	return bytes.Clone(leaf.(*LeafNode).value), true
 }
+
+// GetRangeProof returns up to maxNum key-value pairs with keys matching the
+// prefix and greater than `from` (if it's not nil) in the ascending key order
+// along with the proof that the result contains exactly all pairs matching
+// these conditions. The proof consists of serialized nodes visited during
+// the ordered traversal of the range, so it also proves the absence of items
+// if the result is empty. If the result contains maxNum items, there can be
+// more items left, they are not covered by the proof.
+func (t *Trie) GetRangeProof(prefix, from []byte, maxNum int) ([]storage.KeyValue, [][]byte, error) {
+	w, err := newRangeWalker(prefix, from, maxNum, t.getFromStore)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = w.walk(t.root, []byte{})
+	if err != nil && !errors.Is(err, errStop) {
+		return nil, nil, err
+	}
+	return w.res, w.proof, nil
+}
+
+// VerifyRangeProof verifies the range proof returned from GetRangeProof for
+// the MPT with the specified root hash and the same prefix, from and maxNum
+// parameters. It returns the set of key-value pairs proven by it.
+func VerifyRangeProof(rh util.Uint256, prefix, from []byte, maxNum int, proofs [][]byte) ([]storage.KeyValue, error) {
+	nodes := make(map[util.Uint256][]byte, len(proofs))
+	for i := range proofs {
+		nodes[hash.DoubleSha256(proofs[i])] = proofs[i]
+	}
+	w, err := newRangeWalker(prefix, from, maxNum, func(h util.Uint256) (Node, error) {
+		data, ok := nodes[h]
+		if !ok {
+			return nil, fmt.Errorf("%w: node %s is missing", ErrIncompleteProof, h.StringLE())
+		}
+		var n NodeObject
+		r := io.NewBinReaderFromBuf(data)
+		n.DecodeBinary(r)
+		if r.Err != nil {
+			return nil, fmt.Errorf("invalid node %s: %w", h.StringLE(), r.Err)
+		}
+		return n.Node, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = w.walk(NewHashNode(rh), []byte{})
+	if err != nil && !errors.Is(err, errStop) {
+		return nil, err
+	}
+	return w.res, nil
+}
+
+func newRangeWalker(prefix, from []byte, maxNum int, get func(util.Uint256) (Node, error)) (*rangeWalker, error) {
+	if len(prefix) > MaxKeyLength {
+		return nil, errors.New("invalid prefix length")
+	}
+	if len(from) > MaxKeyLength {
+		return nil, errors.New("invalid from length")
+	}
+	if maxNum <= 0 {
+		return nil, errors.New("invalid max number of items")
+	}
+	w := &rangeWalker{
+		prefix: toNibbles(prefix),
+		maxNum: maxNum,
+		get:    get,
+	}
+	if from != nil {
+		w.from = toNibbles(from)
+	}
+	return w, nil
+}
+
+// inRange checks whether the subtrie located at the given path can contain
+// keys from the range.
+func (w *rangeWalker) inRange(path []byte) bool {
+	if !bytes.HasPrefix(w.prefix, path) && !bytes.HasPrefix(path, w.prefix) {
+		return false
+	}
+	return w.from == nil || bytes.HasPrefix(w.from, path) || bytes.Compare(path, w.from) > 0
+}
+
+func (w *rangeWalker) walk(curr Node, path []byte) error {
+	if !w.inRange(path) {
+		return nil
+	}
+	switch n := curr.(type) {
+	case EmptyNode:
+		return nil
+	case *HashNode:
+		r, err := w.get(n.Hash())
+		if err != nil {
+			return err
+		}
+		return w.walk(r, path)
+	}
+	w.proof = append(w.proof, bytes.Clone(curr.Bytes()))
+	switch n := curr.(type) {
+	case *LeafNode:
+		if bytes.HasPrefix(path, w.prefix) && (w.from == nil || bytes.Compare(path, w.from) > 0) {
+			w.res = append(w.res, storage.KeyValue{
+				Key:   fromNibbles(path),
+				Value: bytes.Clone(n.value),
+			})
+			if len(w.res) >= w.maxNum {
+				return errStop
+			}
+		}
+	case *BranchNode:
+		// Value stored at the branch path precedes all children keys.
+		if err := w.walk(n.Children[lastChild], path); err != nil {
+			return err
+		}
+		for i := range lastChild {
+			if err := w.walk(n.Children[i], append(slices.Clip(path), byte(i))); err != nil {
+				return err
+			}
+		}
+	case *ExtensionNode:
+		return w.walk(n.next, slices.Concat(path, n.key))
+	default:
+		return fmt.Errorf("unexpected node type %T", curr)
+	}
+	return nil
+}
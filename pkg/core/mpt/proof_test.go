@@ -1,8 +1,11 @@
 package mpt
 
 import (
+	"slices"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -74,3 +77,73 @@ func TestVerifyProof(t *testing.T) {
 		require.Equal(t, []byte("somevalue"), v)
 	})
 }
+
+func TestRangeProof(t *testing.T) {
+	tr := NewTrie(nil, ModeAll, newTestStore())
+	kvs := []storage.KeyValue{
+		{Key: []byte{0x01}, Value: []byte("v01")},
+		{Key: []byte{0x01, 0x02}, Value: []byte("v0102")},
+		{Key: []byte{0x01, 0x03}, Value: []byte("v0103")},
+		{Key: []byte{0x01, 0x13}, Value: []byte("v0113")},
+		{Key: []byte{0x02, 0x01}, Value: []byte("v0201")},
+		{Key: []byte{0x11, 0x01}, Value: []byte("v1101")},
+	}
+	for _, kv := range kvs {
+		require.NoError(t, tr.Put(kv.Key, kv.Value))
+	}
+	tr.Flush(0)
+	root := tr.StateRoot()
+
+	check := func(t *testing.T, prefix, from []byte, maxNum int, expected []storage.KeyValue) [][]byte {
+		res, proof, err := tr.GetRangeProof(prefix, from, maxNum)
+		require.NoError(t, err)
+		require.Equal(t, expected, res)
+
+		actual, err := VerifyRangeProof(root, prefix, from, maxNum, proof)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+		return proof
+	}
+
+	t.Run("all", func(t *testing.T) {
+		check(t, nil, nil, 10, kvs)
+	})
+	t.Run("prefix", func(t *testing.T) {
+		check(t, []byte{0x01}, nil, 10, kvs[:4])
+		check(t, []byte{0x01, 0x03}, nil, 10, kvs[2:3])
+	})
+	t.Run("from", func(t *testing.T) {
+		check(t, []byte{0x01}, []byte{0x01, 0x02}, 10, kvs[2:4])
+		check(t, nil, []byte{0x01, 0x13}, 10, kvs[4:])
+	})
+	t.Run("max", func(t *testing.T) {
+		check(t, []byte{0x01}, nil, 2, kvs[:2])
+		check(t, nil, []byte{0x01}, 3, kvs[1:4])
+	})
+	t.Run("absent", func(t *testing.T) {
+		check(t, []byte{0x03}, nil, 10, nil)
+		check(t, []byte{0x01, 0x02, 0x03}, nil, 10, nil)
+		check(t, []byte{0x02}, []byte{0x02, 0x01}, 10, nil)
+	})
+	t.Run("incomplete", func(t *testing.T) {
+		proof := check(t, []byte{0x01}, nil, 10, kvs[:4])
+		for i := range proof {
+			bad := slices.Delete(slices.Clone(proof), i, i+1)
+			_, err := VerifyRangeProof(root, []byte{0x01}, nil, 10, bad)
+			require.ErrorIs(t, err, ErrIncompleteProof)
+		}
+	})
+	t.Run("wrong root", func(t *testing.T) {
+		proof := check(t, nil, nil, 10, kvs)
+		_, err := VerifyRangeProof(util.Uint256{1, 2, 3}, nil, nil, 10, proof)
+		require.ErrorIs(t, err, ErrIncompleteProof)
+	})
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, _, err := tr.GetRangeProof(nil, nil, 0)
+		require.Error(t, err)
+		_, _, err = tr.GetRangeProof(make([]byte, MaxKeyLength+1), nil, 10)
+		require.Error(t, err)
+		_, err = VerifyRangeProof(root, nil, make([]byte, MaxKeyLength+1), 10, nil)
+		require.Error(t, err)
+	})
+}
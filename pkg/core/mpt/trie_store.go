@@ -97,6 +97,11 @@ func (m *TrieStore) Seek(rng storage.SeekRange, f func(k, v []byte) bool) {
 	b := NewBillet(m.trie.root.Hash(), m.trie.mode, 0, m.trie.Store)
 	process := func(pathToNode []byte, node Node, _ []byte) bool {
 		if leaf, ok := node.(*LeafNode); ok {
+			if len(rng.End) != 0 {
+				if c := bytes.Compare(pathToNode, rng.End); c == 0 || (c < 0) == rng.Backwards {
+					return true // Passed the End, stop.
+				}
+			}
 			// (*Billet).traverse includes `from` path into the result if so. It's OK for Seek, so shouldn't be filtered out.
 			kv := storage.KeyValue{
 				Key:   slices.Concat(rng.Prefix, pathToNode), // Do not cut prefix.
@@ -70,5 +70,20 @@ func TestTrieStore_TestTrieOperations(t *testing.T) {
 				check(t, true)
 			})
 		})
+		t.Run("good: with End", func(t *testing.T) {
+			seek := func(rng storage.SeekRange) [][]byte {
+				var res [][]byte
+				rng.Prefix = []byte{byte(storage.STStorage)}
+				st.Seek(rng, func(k, v []byte) bool {
+					res = append(res, k)
+					return true
+				})
+				return res
+			}
+			all := seek(storage.SeekRange{})
+			require.Equal(t, 4, len(all))
+			require.Equal(t, all[:2], seek(storage.SeekRange{End: all[2][1:]}))
+			require.Equal(t, [][]byte{all[3], all[2]}, seek(storage.SeekRange{End: all[1][1:], Backwards: true}))
+		})
 	})
 }
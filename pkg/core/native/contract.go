@@ -60,11 +60,11 @@ func NewContracts(cfg config.ProtocolConfiguration) *Contracts {
 	cs.Management = mgmt
 	cs.Contracts = append(cs.Contracts, mgmt)
 
-	s := newStd()
+	s := newStd(cfg.NeoGoExtensions)
 	cs.Std = s
 	cs.Contracts = append(cs.Contracts, s)
 
-	c := newCrypto()
+	c := newCrypto(cfg.NeoGoExtensions)
 	cs.Crypto = c
 	cs.Contracts = append(cs.Contracts, c)
 
@@ -72,9 +72,9 @@ func NewContracts(cfg config.ProtocolConfiguration) *Contracts {
 	cs.Ledger = ledger
 	cs.Contracts = append(cs.Contracts, ledger)
 
-	gas := newGAS(int64(cfg.InitialGASSupply), cfg.P2PSigExtensions)
+	gas := newGAS(int64(cfg.InitialGASSupply), cfg.P2PSigExtensions, cfg.NeoGoExtensions)
 	neo := newNEO(cfg)
-	policy := newPolicy(cfg.P2PSigExtensions)
+	policy := newPolicy(cfg.P2PSigExtensions, cfg.NeoGoExtensions)
 	neo.GAS = gas
 	neo.Policy = policy
 	gas.NEO = neo
@@ -88,7 +88,7 @@ func NewContracts(cfg config.ProtocolConfiguration) *Contracts {
 	cs.Policy = policy
 	cs.Contracts = append(cs.Contracts, neo, gas, policy)
 
-	desig := newDesignate(cfg.Genesis.Roles)
+	desig := newDesignate(cfg.Genesis.Roles, cfg.NeoGoExtensions)
 	desig.NEO = neo
 	cs.Designate = desig
 	cs.Contracts = append(cs.Contracts, desig)
@@ -101,7 +101,7 @@ func NewContracts(cfg config.ProtocolConfiguration) *Contracts {
 	cs.Contracts = append(cs.Contracts, oracle)
 
 	if cfg.P2PSigExtensions {
-		notary := newNotary()
+		notary := newNotary(cfg.NeoGoExtensions)
 		notary.GAS = gas
 		notary.NEO = neo
 		notary.Desig = desig
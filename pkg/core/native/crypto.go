@@ -15,13 +15,13 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/vrf"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/twmb/murmur3"
-	"golang.org/x/crypto/sha3"
 )
 
 // Crypto represents CryptoLib contract.
@@ -43,9 +43,31 @@ const (
 	Secp256r1Keccak256 NamedCurveHash = 123
 )
 
+// NamedCurve identifies named elliptic curve.
+type NamedCurve byte
+
+// Various named elliptic curves.
+const (
+	Secp256k1 NamedCurve = 22
+	Secp256r1 NamedCurve = 23
+)
+
+// Hasher identifies hash function applied to the message before ECDSA signature
+// verification.
+type Hasher byte
+
+// Various hash functions.
+const (
+	// HasherNone is used for pre-hashed messages, the message is expected to be
+	// a 32-byte digest in this case.
+	HasherNone      Hasher = 0
+	HasherSha256    Hasher = 1
+	HasherKeccak256 Hasher = 2
+)
+
 const cryptoContractID = -3
 
-func newCrypto() *Crypto {
+func newCrypto(neoGoExtensionsEnabled bool) *Crypto {
 	c := &Crypto{ContractMD: *interop.NewContractMD(nativenames.CryptoLib, cryptoContractID)}
 	defer c.BuildHFSpecificMD(c.ActiveIn())
 
@@ -81,6 +103,24 @@ func newCrypto() *Crypto {
 	md = newMethodAndPrice(c.verifyWithECDsa, 1<<15, callflag.NoneFlag, config.HFCockatrice)
 	c.AddMethod(md, desc)
 
+	if neoGoExtensionsEnabled {
+		desc = newDescriptor("verifyWithECDsa", smartcontract.BoolType,
+			manifest.NewParameter("message", smartcontract.ByteArrayType),
+			manifest.NewParameter("pubkey", smartcontract.ByteArrayType),
+			manifest.NewParameter("signature", smartcontract.ByteArrayType),
+			manifest.NewParameter("curve", smartcontract.IntegerType),
+			manifest.NewParameter("hasher", smartcontract.IntegerType))
+		md = newMethodAndPrice(c.verifyWithECDsaHasher, 1<<15, callflag.NoneFlag, config.HFEchidna)
+		c.AddMethod(md, desc)
+
+		desc = newDescriptor("vrfVerify", smartcontract.ByteArrayType,
+			manifest.NewParameter("message", smartcontract.ByteArrayType),
+			manifest.NewParameter("pubkey", smartcontract.ByteArrayType),
+			manifest.NewParameter("proof", smartcontract.ByteArrayType))
+		md = newMethodAndPrice(c.vrfVerify, 1<<16, callflag.NoneFlag, config.HFEchidna)
+		c.AddMethod(md, desc)
+	}
+
 	desc = newDescriptor("bls12381Serialize", smartcontract.ByteArrayType,
 		manifest.NewParameter("g", smartcontract.InteropInterfaceType))
 	md = newMethodAndPrice(c.bls12381Serialize, 1<<19, callflag.NoneFlag)
@@ -120,6 +160,18 @@ func newCrypto() *Crypto {
 		manifest.NewParameter("data", smartcontract.ByteArrayType))
 	md = newMethodAndPrice(c.keccak256, 1<<15, callflag.NoneFlag, config.HFCockatrice)
 	c.AddMethod(md, desc)
+
+	if neoGoExtensionsEnabled {
+		desc = newDescriptor("bls12381IsOnCurve", smartcontract.BoolType,
+			manifest.NewParameter("data", smartcontract.ByteArrayType))
+		md = newMethodAndPrice(c.bls12381IsOnCurve, 1<<16, callflag.NoneFlag, config.HFEchidna)
+		c.AddMethod(md, desc)
+
+		desc = newDescriptor("bls12381IsInSubgroup", smartcontract.BoolType,
+			manifest.NewParameter("data", smartcontract.ByteArrayType))
+		md = newMethodAndPrice(c.bls12381IsInSubgroup, 1<<19, callflag.NoneFlag, config.HFEchidna)
+		c.AddMethod(md, desc)
+	}
 	return c
 }
 
@@ -160,6 +212,30 @@ func (c *Crypto) verifyWithECDsa(_ *interop.Context, args []stackitem.Item) stac
 }
 
 func verifyWithECDsaGeneric(args []stackitem.Item, allowKeccak bool) stackitem.Item {
+	curve, hasher, err := curveHasherFromStackitem(args[3], allowKeccak)
+	if err != nil {
+		panic(fmt.Errorf("invalid curveHash stackitem: %w", err))
+	}
+	return verifyECDsa(args, curve, hasher)
+}
+
+// verifyWithECDsaHasher is an overload of verifyWithECDsa accepting named curve
+// and hasher separately. It allows to verify signatures over pre-hashed messages.
+func (c *Crypto) verifyWithECDsaHasher(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	curve, err := curveFromStackitem(args[3])
+	if err != nil {
+		panic(fmt.Errorf("invalid curve stackitem: %w", err))
+	}
+	hasher, err := hasherFromStackitem(args[4])
+	if err != nil {
+		panic(fmt.Errorf("invalid hasher stackitem: %w", err))
+	}
+	return verifyECDsa(args, curve, hasher)
+}
+
+// verifyECDsa checks the signature of the message against the public key using
+// the given curve and hasher. Nil hasher means the message is already hashed.
+func verifyECDsa(args []stackitem.Item, curve elliptic.Curve, hasher HashFunc) stackitem.Item {
 	msg, err := args[0].TryBytes()
 	if err != nil {
 		panic(fmt.Errorf("invalid message stackitem: %w", err))
@@ -172,16 +248,20 @@ func verifyWithECDsaGeneric(args []stackitem.Item, allowKeccak bool) stackitem.I
 	if err != nil {
 		panic(fmt.Errorf("invalid signature stackitem: %w", err))
 	}
-	curve, hasher, err := curveHasherFromStackitem(args[3], allowKeccak)
-	if err != nil {
-		panic(fmt.Errorf("invalid curveHash stackitem: %w", err))
+	var hashToCheck []byte
+	if hasher != nil {
+		hashToCheck = hasher(msg).BytesBE()
+	} else {
+		if len(msg) != util.Uint256Size {
+			panic(fmt.Errorf("invalid message hash length: expected %d, got %d", util.Uint256Size, len(msg)))
+		}
+		hashToCheck = msg
 	}
-	hashToCheck := hasher(msg)
 	pkey, err := keys.NewPublicKeyFromBytes(pubkey, curve)
 	if err != nil {
 		panic(fmt.Errorf("failed to decode pubkey: %w", err))
 	}
-	res := pkey.Verify(signature, hashToCheck.BytesBE())
+	res := pkey.Verify(signature, hashToCheck)
 	return stackitem.NewBool(res)
 }
 
@@ -214,6 +294,44 @@ func curveHasherFromStackitem(si stackitem.Item, allowKeccak bool) (elliptic.Cur
 	}
 }
 
+func curveFromStackitem(si stackitem.Item) (elliptic.Curve, error) {
+	curve, err := si.TryInteger()
+	if err != nil {
+		return nil, err
+	}
+	if !curve.IsInt64() {
+		return nil, errors.New("not an int64")
+	}
+	switch curve.Int64() {
+	case int64(Secp256k1):
+		return secp256k1.S256(), nil
+	case int64(Secp256r1):
+		return elliptic.P256(), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown curve", errors.ErrUnsupported)
+	}
+}
+
+func hasherFromStackitem(si stackitem.Item) (HashFunc, error) {
+	hasher, err := si.TryInteger()
+	if err != nil {
+		return nil, err
+	}
+	if !hasher.IsInt64() {
+		return nil, errors.New("not an int64")
+	}
+	switch hasher.Int64() {
+	case int64(HasherNone):
+		return nil, nil
+	case int64(HasherSha256):
+		return hash.Sha256, nil
+	case int64(HasherKeccak256):
+		return Keccak256, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown hasher", errors.ErrUnsupported)
+	}
+}
+
 func (c *Crypto) bls12381Serialize(_ *interop.Context, args []stackitem.Item) stackitem.Item {
 	val, ok := args[0].(*stackitem.Interop).Value().(blsPoint)
 	if !ok {
@@ -322,6 +440,30 @@ func (c *Crypto) bls12381Pairing(_ *interop.Context, args []stackitem.Item) stac
 	return stackitem.NewInterop(p)
 }
 
+// bls12381IsOnCurve checks whether the given serialized BLS12-381 point is
+// properly encoded and lies on the curve. It doesn't perform the subgroup
+// check, so it's cheaper than bls12381IsInSubgroup and bls12381Deserialize.
+func (c *Crypto) bls12381IsOnCurve(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	buf, err := args[0].TryBytes()
+	if err != nil {
+		panic(fmt.Errorf("invalid serialized bls12381 point: %w", err))
+	}
+	onCurve, _ := blsCheckBytes(buf)
+	return stackitem.NewBool(onCurve)
+}
+
+// bls12381IsInSubgroup checks whether the given serialized BLS12-381 point is
+// properly encoded, lies on the curve and belongs to the prime-order subgroup,
+// i.e. whether it can be deserialized with bls12381Deserialize.
+func (c *Crypto) bls12381IsInSubgroup(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	buf, err := args[0].TryBytes()
+	if err != nil {
+		panic(fmt.Errorf("invalid serialized bls12381 point: %w", err))
+	}
+	_, inSubgroup := blsCheckBytes(buf)
+	return stackitem.NewBool(inSubgroup)
+}
+
 func (c *Crypto) keccak256(_ *interop.Context, args []stackitem.Item) stackitem.Item {
 	bs, err := args[0].TryBytes()
 	if err != nil {
@@ -330,6 +472,33 @@ func (c *Crypto) keccak256(_ *interop.Context, args []stackitem.Item) stackitem.
 	return stackitem.NewByteArray(Keccak256(bs).BytesBE())
 }
 
+// vrfVerify checks ECVRF-P256-SHA256-TAI proof of the message against the
+// secp256r1 public key and returns VRF output if the proof is valid or Null
+// otherwise.
+func (c *Crypto) vrfVerify(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	msg, err := args[0].TryBytes()
+	if err != nil {
+		panic(fmt.Errorf("invalid message stackitem: %w", err))
+	}
+	pubkey, err := args[1].TryBytes()
+	if err != nil {
+		panic(fmt.Errorf("invalid pubkey stackitem: %w", err))
+	}
+	proof, err := args[2].TryBytes()
+	if err != nil {
+		panic(fmt.Errorf("invalid proof stackitem: %w", err))
+	}
+	pkey, err := keys.NewPublicKeyFromBytes(pubkey, elliptic.P256())
+	if err != nil {
+		panic(fmt.Errorf("failed to decode pubkey: %w", err))
+	}
+	beta, err := vrf.Verify(pkey, msg, proof)
+	if err != nil {
+		return stackitem.Null{}
+	}
+	return stackitem.NewByteArray(beta)
+}
+
 // Metadata implements the Contract interface.
 func (c *Crypto) Metadata() *interop.ContractMD {
 	return &c.ContractMD
@@ -363,10 +532,5 @@ func (c *Crypto) ActiveIn() *config.Hardfork {
 // Keccak256 hashes the incoming byte slice using the
 // keccak256 algorithm.
 func Keccak256(data []byte) util.Uint256 {
-	var hash util.Uint256
-	hasher := sha3.NewLegacyKeccak256()
-	_, _ = hasher.Write(data)
-
-	hasher.Sum(hash[:0])
-	return hash
+	return hash.Keccak256(data)
 }
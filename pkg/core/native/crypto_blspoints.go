@@ -1,6 +1,7 @@
 package native
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
@@ -133,6 +134,39 @@ func (p *blsPoint) FromBytes(buf []byte) error {
 	return nil
 }
 
+// blsCheckBytes checks BLS12-381 point given in compressed form (the one
+// accepted by FromBytes) without deserializing it into blsPoint. It returns
+// whether the point is properly encoded and lies on the curve (is a valid
+// field element for GT) and whether it belongs to the prime-order subgroup.
+// Unlike FromBytes, the subgroup check is performed separately, so points
+// outside of the subgroup are not treated as encoding errors.
+func blsCheckBytes(buf []byte) (bool, bool) {
+	switch len(buf) {
+	case bls12381.SizeOfG1AffineCompressed:
+		g1Affine := new(bls12381.G1Affine)
+		err := bls12381.NewDecoder(bytes.NewReader(buf), bls12381.NoSubgroupChecks()).Decode(g1Affine)
+		if err != nil || !g1Affine.IsOnCurve() {
+			return false, false
+		}
+		return true, g1Affine.IsInSubGroup()
+	case bls12381.SizeOfG2AffineCompressed:
+		g2Affine := new(bls12381.G2Affine)
+		err := bls12381.NewDecoder(bytes.NewReader(buf), bls12381.NoSubgroupChecks()).Decode(g2Affine)
+		if err != nil || !g2Affine.IsOnCurve() {
+			return false, false
+		}
+		return true, g2Affine.IsInSubGroup()
+	case bls12381.SizeOfGT:
+		gt := new(bls12381.GT)
+		if gt.SetBytes(buf) != nil {
+			return false, false
+		}
+		return true, gt.IsInSubGroup()
+	default:
+		return false, false
+	}
+}
+
 // blsPointAdd performs addition of two BLS12-381 points.
 func blsPointAdd(a, b blsPoint) (blsPoint, error) {
 	var (
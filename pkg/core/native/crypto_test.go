@@ -11,13 +11,14 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
 
 func TestSha256(t *testing.T) {
-	c := newCrypto()
+	c := newCrypto(true)
 	ic := &interop.Context{VM: vm.New()}
 
 	t.Run("bad arg type", func(t *testing.T) {
@@ -33,7 +34,7 @@ func TestSha256(t *testing.T) {
 
 // TestKeccak256_Compat is a C# node compatibility test with data taken from https://github.com/Jim8y/neo/blob/560d35783e428d31e3681eaa7ee9ed00a8a50d09/tests/Neo.UnitTests/SmartContract/Native/UT_CryptoLib.cs#L340
 func TestKeccak256_Compat(t *testing.T) {
-	c := newCrypto()
+	c := newCrypto(true)
 	ic := &interop.Context{VM: vm.New()}
 
 	t.Run("good", func(t *testing.T) {
@@ -84,7 +85,7 @@ func TestKeccak256_Compat(t *testing.T) {
 }
 
 func TestRIPEMD160(t *testing.T) {
-	c := newCrypto()
+	c := newCrypto(true)
 	ic := &interop.Context{VM: vm.New()}
 
 	t.Run("bad arg type", func(t *testing.T) {
@@ -99,7 +100,7 @@ func TestRIPEMD160(t *testing.T) {
 }
 
 func TestMurmur32(t *testing.T) {
-	c := newCrypto()
+	c := newCrypto(true)
 	ic := &interop.Context{VM: vm.New()}
 
 	t.Run("bad arg type", func(t *testing.T) {
@@ -137,7 +138,7 @@ func testECDSAVerify(t *testing.T, curve NamedCurveHash) {
 	var (
 		priv   *keys.PrivateKey
 		err    error
-		c      = newCrypto()
+		c      = newCrypto(true)
 		ic     = &interop.Context{VM: vm.New()}
 		actual stackitem.Item
 		hasher HashFunc
@@ -211,6 +212,93 @@ func testECDSAVerify(t *testing.T, curve NamedCurveHash) {
 	})
 }
 
+func TestCryptoLibVerifyWithECDsaHasher(t *testing.T) {
+	var (
+		c      = newCrypto(true)
+		ic     = &interop.Context{VM: vm.New()}
+		msg    = []byte("test message")
+		actual stackitem.Item
+	)
+	k1, err := keys.NewSecp256k1PrivateKey()
+	require.NoError(t, err)
+	r1, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	runCase := func(t *testing.T, isErr bool, result any, args ...any) {
+		argsArr := make([]stackitem.Item, len(args))
+		for i := range args {
+			argsArr[i] = stackitem.Make(args[i])
+		}
+		if isErr {
+			require.Panics(t, func() {
+				_ = c.verifyWithECDsaHasher(ic, argsArr)
+			})
+		} else {
+			require.NotPanics(t, func() {
+				actual = c.verifyWithECDsaHasher(ic, argsArr)
+			})
+			require.Equal(t, stackitem.Make(result), actual)
+		}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		priv   *keys.PrivateKey
+		curve  NamedCurve
+		hasher Hasher
+		digest util.Uint256
+	}{
+		{"K1 none", k1, Secp256k1, HasherNone, hash.DoubleSha256(msg)},
+		{"R1 none", r1, Secp256r1, HasherNone, hash.DoubleSha256(msg)},
+		{"K1 sha256", k1, Secp256k1, HasherSha256, hash.Sha256(msg)},
+		{"R1 sha256", r1, Secp256r1, HasherSha256, hash.Sha256(msg)},
+		{"K1 keccak256", k1, Secp256k1, HasherKeccak256, Keccak256(msg)},
+		{"R1 keccak256", r1, Secp256r1, HasherKeccak256, Keccak256(msg)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				sign = tc.priv.SignHash(tc.digest)
+				pub  = tc.priv.PublicKey().Bytes()
+				data = msg
+				bad  = []byte("another message")
+			)
+			if tc.hasher == HasherNone {
+				data = tc.digest.BytesBE()
+				bad = hash.Sha256(bad).BytesBE()
+			}
+			runCase(t, false, true, data, pub, sign, int64(tc.curve), int64(tc.hasher))
+			runCase(t, false, false, bad, pub, sign, int64(tc.curve), int64(tc.hasher))
+		})
+	}
+
+	sign := k1.SignHash(hash.Sha256(msg))
+	pub := k1.PublicKey().Bytes()
+	t.Run("bad message item", func(t *testing.T) {
+		runCase(t, true, false, stackitem.NewInterop("cheburek"), pub, sign, int64(Secp256k1), int64(HasherSha256))
+	})
+	t.Run("bad digest length", func(t *testing.T) {
+		runCase(t, true, false, msg, pub, sign, int64(Secp256k1), int64(HasherNone))
+	})
+	t.Run("bad curve item", func(t *testing.T) {
+		runCase(t, true, false, msg, pub, sign, stackitem.NewInterop("cheburek"), int64(HasherSha256))
+	})
+	t.Run("bad curve value", func(t *testing.T) {
+		runCase(t, true, false, msg, pub, sign, new(big.Int).Add(big.NewInt(math.MaxInt64), big.NewInt(1)), int64(HasherSha256))
+	})
+	t.Run("unknown curve", func(t *testing.T) {
+		runCase(t, true, false, msg, pub, sign, int64(Secp256k1Keccak256), int64(HasherSha256))
+	})
+	t.Run("bad hasher item", func(t *testing.T) {
+		runCase(t, true, false, msg, pub, sign, int64(Secp256k1), stackitem.NewInterop("cheburek"))
+	})
+	t.Run("bad hasher value", func(t *testing.T) {
+		runCase(t, true, false, msg, pub, sign, int64(Secp256k1), new(big.Int).Add(big.NewInt(math.MaxInt64), big.NewInt(1)))
+	})
+	t.Run("unknown hasher", func(t *testing.T) {
+		runCase(t, true, false, msg, pub, sign, int64(Secp256k1), int64(3))
+	})
+}
+
 func TestCryptolib_ScalarFromBytes_Compat(t *testing.T) {
 	r2Ref := &fr.Element{
 		0xc999_e990_f3f2_9c6d,
@@ -35,6 +35,9 @@ type Designate struct {
 	// initialNodeRoles defines a set of node roles that should be defined at the contract
 	// deployment (initialization).
 	initialNodeRoles map[noderoles.Role]keys.PublicKeys
+	// neoGoExtensionsEnabled defines whether NeoGo-specific methods and
+	// events are available.
+	neoGoExtensionsEnabled bool
 
 	OracleService atomic.Value
 	// NotaryService represents a Notary node module.
@@ -100,11 +103,12 @@ func (s *Designate) isValidRole(r noderoles.Role) bool {
 		r == noderoles.NeoFSAlphabet || r == noderoles.P2PNotary
 }
 
-func newDesignate(initialNodeRoles map[noderoles.Role]keys.PublicKeys) *Designate {
+func newDesignate(initialNodeRoles map[noderoles.Role]keys.PublicKeys, neoGoExtensionsEnabled bool) *Designate {
 	s := &Designate{ContractMD: *interop.NewContractMD(nativenames.Designation, designateContractID)}
 	defer s.BuildHFSpecificMD(s.ActiveIn())
 
 	s.initialNodeRoles = initialNodeRoles
+	s.neoGoExtensionsEnabled = neoGoExtensionsEnabled
 
 	desc := newDescriptor("getDesignatedByRole", smartcontract.ArrayType,
 		manifest.NewParameter("role", smartcontract.IntegerType),
@@ -121,7 +125,25 @@ func newDesignate(initialNodeRoles map[noderoles.Role]keys.PublicKeys) *Designat
 	eDesc := newEventDescriptor(DesignationEventName,
 		manifest.NewParameter("Role", smartcontract.IntegerType),
 		manifest.NewParameter("BlockIndex", smartcontract.IntegerType))
-	eMD := newEvent(eDesc)
+	if !neoGoExtensionsEnabled {
+		s.AddEvent(newEvent(eDesc))
+		return s
+	}
+	eMD := newEvent(eDesc, config.HFDefault, config.HFEchidna)
+	s.AddEvent(eMD)
+
+	desc = newDescriptor("getDesignationHeight", smartcontract.IntegerType,
+		manifest.NewParameter("role", smartcontract.IntegerType),
+		manifest.NewParameter("index", smartcontract.IntegerType))
+	md = newMethodAndPrice(s.getDesignationHeight, 1<<15, callflag.ReadStates, config.HFEchidna)
+	s.AddMethod(md, desc)
+
+	eDesc = newEventDescriptor(DesignationEventName,
+		manifest.NewParameter("Role", smartcontract.IntegerType),
+		manifest.NewParameter("BlockIndex", smartcontract.IntegerType),
+		manifest.NewParameter("Old", smartcontract.ArrayType),
+		manifest.NewParameter("New", smartcontract.ArrayType))
+	eMD = newEvent(eDesc, config.HFEchidna)
 	s.AddEvent(eMD)
 
 	return s
@@ -200,6 +222,29 @@ func (s *Designate) ActiveIn() *config.Hardfork {
 }
 
 func (s *Designate) getDesignatedByRole(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	r, index := s.getRoleAndIndex(ic, args)
+	pubs, _, err := s.GetDesignatedByRole(ic.DAO, r, index)
+	if err != nil {
+		panic(err)
+	}
+	return pubsToArray(pubs)
+}
+
+// getDesignationHeight returns the height starting from which the nodes
+// designated for the role at the given index are effective. Zero is returned
+// if there are no nodes designated for the role at this index.
+func (s *Designate) getDesignationHeight(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	r, index := s.getRoleAndIndex(ic, args)
+	_, height, err := s.GetDesignatedByRole(ic.DAO, r, index)
+	if err != nil {
+		panic(err)
+	}
+	return stackitem.NewBigInteger(big.NewInt(int64(height)))
+}
+
+// getRoleAndIndex parses role and index arguments of getDesignatedByRole-like
+// methods, it panics if any of them is invalid.
+func (s *Designate) getRoleAndIndex(ic *interop.Context, args []stackitem.Item) (noderoles.Role, uint32) {
 	r, ok := s.getRole(args[0])
 	if !ok {
 		panic(ErrInvalidRole)
@@ -212,11 +257,7 @@ func (s *Designate) getDesignatedByRole(ic *interop.Context, args []stackitem.It
 	if index > uint64(ic.BlockHeight()+1) { // persisting block should be taken into account.
 		panic(ErrInvalidIndex)
 	}
-	pubs, _, err := s.GetDesignatedByRole(ic.DAO, r, uint32(index))
-	if err != nil {
-		panic(err)
-	}
-	return pubsToArray(pubs)
+	return r, uint32(index)
 }
 
 func (s *Designate) hashFromNodes(r noderoles.Role, nodes keys.PublicKeys) util.Uint160 {
@@ -324,7 +365,6 @@ func (s *Designate) GetDesignatedByRole(d *dao.Simple, r noderoles.Role, index u
 // getDesignatedByRoleFromStorage returns nodes for role r from the storage.
 func (s *Designate) getDesignatedByRoleFromStorage(d *dao.Simple, r noderoles.Role, index uint32) (keys.PublicKeys, uint32, error) {
 	var (
-		ns        NodeList
 		bestIndex uint32
 		resVal    []byte
 		start     = make([]byte, 4)
@@ -341,13 +381,14 @@ func (s *Designate) getDesignatedByRoleFromStorage(d *dao.Simple, r noderoles.Ro
 		// Take just the latest item, it's the one we need.
 		return false
 	})
-	if resVal != nil {
-		err := stackitem.DeserializeConvertible(resVal, &ns)
-		if err != nil {
-			return nil, 0, err
-		}
+	if resVal == nil {
+		return nil, bestIndex, nil
 	}
-	return keys.PublicKeys(ns), bestIndex, nil
+	ns, err := dao.DecodeConvertible[NodeList](resVal)
+	if err != nil {
+		return nil, 0, err
+	}
+	return keys.PublicKeys(*ns), bestIndex, nil
 }
 
 func (s *Designate) designateAsRole(ic *interop.Context, args []stackitem.Item) stackitem.Item {
@@ -398,10 +439,21 @@ func (s *Designate) DesignateAsRole(ic *interop.Context, r noderoles.Role, pubs
 	if si != nil {
 		return ErrAlreadyDesignated
 	}
+	var (
+		extendedEvent = s.neoGoExtensionsEnabled && ic.IsHardforkEnabled(config.HFEchidna)
+		oldPubs       keys.PublicKeys
+	)
+	if extendedEvent {
+		var err error
+		oldPubs, _, err = s.GetDesignatedByRole(ic.DAO, r, ic.Block.Index)
+		if err != nil {
+			return fmt.Errorf("failed to get previous designation: %w", err)
+		}
+	}
 	slices.SortFunc(pubs, (*keys.PublicKey).Cmp)
 	nl := NodeList(pubs)
 
-	err := putConvertibleToDAO(s.ID, ic.DAO, key, &nl)
+	err := ic.DAO.PutConvertible(s.ID, key, &nl)
 	if err != nil {
 		return err
 	}
@@ -412,10 +464,17 @@ func (s *Designate) DesignateAsRole(ic *interop.Context, r noderoles.Role, pubs
 		return fmt.Errorf("failed to update Designation role data cache: %w", err)
 	}
 
-	ic.AddNotification(s.Hash, DesignationEventName, stackitem.NewArray([]stackitem.Item{
+	ntf := []stackitem.Item{
 		stackitem.NewBigInteger(big.NewInt(int64(r))),
 		stackitem.NewBigInteger(big.NewInt(int64(ic.Block.Index))),
-	}))
+	}
+	if extendedEvent {
+		// Designated nodes become effective starting from the next block,
+		// so include both sets to allow tracking the change without
+		// additional calls.
+		ntf = append(ntf, pubsToArray(oldPubs), pubsToArray(pubs))
+	}
+	ic.AddNotification(s.Hash, DesignationEventName, stackitem.NewArray(ntf))
 	return nil
 }
 
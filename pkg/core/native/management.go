@@ -699,8 +699,8 @@ func (m *Management) InitializeCache(blockHeight uint32, d *dao.Simple) error {
 
 	var initErr error
 	d.Seek(m.ID, storage.SeekRange{Prefix: []byte{PrefixContract}}, func(_, v []byte) bool {
-		var cs = new(state.Contract)
-		initErr = stackitem.DeserializeConvertible(v, cs)
+		var cs *state.Contract
+		cs, initErr = dao.DecodeConvertible[state.Contract](v)
 		if initErr != nil {
 			return false
 		}
@@ -774,7 +774,7 @@ func PutContractState(d *dao.Simple, cs *state.Contract) error {
 // putContractState is an internal PutContractState representation.
 func putContractState(d *dao.Simple, cs *state.Contract, updateCache bool) error {
 	key := MakeContractKey(cs.Hash)
-	if err := putConvertibleToDAO(ManagementContractID, d, key, cs); err != nil {
+	if err := d.PutConvertible(ManagementContractID, key, cs); err != nil {
 		return err
 	}
 	if updateCache {
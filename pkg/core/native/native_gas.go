@@ -32,14 +32,14 @@ const gasContractID = -6
 const GASFactor = NEOTotalSupply
 
 // newGAS returns GAS native contract.
-func newGAS(init int64, p2pSigExtensionsEnabled bool) *GAS {
+func newGAS(init int64, p2pSigExtensionsEnabled bool, neoGoExtensionsEnabled bool) *GAS {
 	g := &GAS{
 		initialSupply:           init,
 		p2pSigExtensionsEnabled: p2pSigExtensionsEnabled,
 	}
 	defer g.BuildHFSpecificMD(g.ActiveIn())
 
-	nep17 := newNEP17Native(nativenames.Gas, gasContractID)
+	nep17 := newNEP17Native(nativenames.Gas, gasContractID, neoGoExtensionsEnabled)
 	nep17.symbol = "GAS"
 	nep17.decimals = 8
 	nep17.factor = GASFactor
@@ -170,7 +170,7 @@ func newNEO(cfg config.ProtocolConfiguration) *NEO {
 	n := &NEO{}
 	defer n.BuildHFSpecificMD(n.ActiveIn())
 
-	nep17 := newNEP17Native(nativenames.Neo, neoContractID)
+	nep17 := newNEP17Native(nativenames.Neo, neoContractID, cfg.NeoGoExtensions)
 	nep17.symbol = "NEO"
 	nep17.decimals = 0
 	nep17.factor = 1
@@ -842,7 +842,7 @@ func (n *NEO) RegisterCandidateInternal(ic *interop.Context, pub *keys.PublicKey
 		emitEvent = !c.Registered
 		c.Registered = true
 	}
-	err := putConvertibleToDAO(n.ID, ic.DAO, key, c)
+	err := ic.DAO.PutConvertible(n.ID, key, c)
 	if emitEvent {
 		cache := ic.DAO.GetRWCache(n.ID).(*NeoCache)
 		cache.votesChanged = true
@@ -885,7 +885,7 @@ func (n *NEO) UnregisterCandidateInternal(ic *interop.Context, pub *keys.PublicK
 	c.Registered = false
 	ok := n.dropCandidateIfZero(ic.DAO, cache, pub, c)
 	if !ok {
-		err = putConvertibleToDAO(n.ID, ic.DAO, key, c)
+		err = ic.DAO.PutConvertible(n.ID, key, c)
 	}
 	if emitEvent {
 		ic.AddNotification(n.Hash, "CandidateStateChanged", stackitem.NewArray([]stackitem.Item{
@@ -1008,7 +1008,7 @@ func (n *NEO) ModifyAccountVotes(acc *state.NEOBalance, d *dao.Simple, value *bi
 				return nil
 			}
 		}
-		return putConvertibleToDAO(n.ID, d, key, cd)
+		return d.PutConvertible(n.ID, key, cd)
 	}
 	return nil
 }
@@ -1289,6 +1289,27 @@ func (n *NEO) BalanceOf(d *dao.Simple, acc util.Uint160) (*big.Int, uint32) {
 	return &st.Balance, st.BalanceHeight
 }
 
+// GetAccountState returns the NEO state of the given account, nil is returned
+// if there is no such account.
+func (n *NEO) GetAccountState(d *dao.Simple, acc util.Uint160) (*state.NEOBalance, error) {
+	si := d.GetStorageItem(n.ID, makeAccountKey(acc))
+	if si == nil {
+		return nil, nil
+	}
+	return state.NEOBalanceFromBytes(si)
+}
+
+// ProjectBonus returns the amount of GAS the given account would be able to
+// claim at the given height if its state stays the same. GAS per block
+// setting is assumed to stay the same for future blocks, voter reward only
+// includes the part already accrued by the voted candidate.
+func (n *NEO) ProjectBonus(d *dao.Simple, acc *state.NEOBalance, end uint32) (*big.Int, error) {
+	if acc.Balance.Sign() == 0 {
+		return big.NewInt(0), nil
+	}
+	return n.calculateBonus(d, acc, end)
+}
+
 func pubsToArray(pubs keys.PublicKeys) stackitem.Item {
 	arr := make([]stackitem.Item, len(pubs))
 	for i := range pubs {
@@ -23,6 +23,17 @@ import (
 // prefixAccount is the standard prefix used to store account data.
 const prefixAccount = 20
 
+// MaxMultiTransfers is the maximum number of transfers that can be made by a
+// single multiTransfer call.
+const MaxMultiTransfers = 256
+
+// Transfer price (both for a single transfer call and for every transfer made
+// by multiTransfer).
+const (
+	transferCPUFee     = 1 << 17
+	transferStorageFee = 50
+)
+
 // makeAccountKey creates a key from the account script hash.
 func makeAccountKey(h util.Uint160) []byte {
 	return makeUint160Key(prefixAccount, h)
@@ -45,7 +56,7 @@ func (c *nep17TokenNative) Metadata() *interop.ContractMD {
 	return &c.ContractMD
 }
 
-func newNEP17Native(name string, id int32) *nep17TokenNative {
+func newNEP17Native(name string, id int32, neoGoExtensionsEnabled bool) *nep17TokenNative {
 	n := &nep17TokenNative{ContractMD: *interop.NewContractMD(name, id, func(m *manifest.Manifest) {
 		m.SupportedStandards = []string{manifest.NEP17StandardName}
 	})}
@@ -75,10 +86,17 @@ func newNEP17Native(name string, id int32) *nep17TokenNative {
 	desc = newDescriptor("transfer", smartcontract.BoolType,
 		append(transferParams, manifest.NewParameter("data", smartcontract.AnyType))...,
 	)
-	md = newMethodAndPrice(n.Transfer, 1<<17, callflag.States|callflag.AllowCall|callflag.AllowNotify)
-	md.StorageFee = 50
+	md = newMethodAndPrice(n.Transfer, transferCPUFee, callflag.States|callflag.AllowCall|callflag.AllowNotify)
+	md.StorageFee = transferStorageFee
 	n.AddMethod(md, desc)
 
+	if neoGoExtensionsEnabled {
+		desc = newDescriptor("multiTransfer", smartcontract.BoolType,
+			manifest.NewParameter("transfers", smartcontract.ArrayType))
+		md = newMethodAndPrice(n.multiTransfer, 1<<15, callflag.States|callflag.AllowCall|callflag.AllowNotify, config.HFEchidna)
+		n.AddMethod(md, desc)
+	}
+
 	eDesc := newEventDescriptor("Transfer", transferParams...)
 	eMD := newEvent(eDesc)
 	n.AddEvent(eMD)
@@ -123,6 +141,64 @@ func (c *nep17TokenNative) Transfer(ic *interop.Context, args []stackitem.Item)
 	return stackitem.NewBool(err == nil)
 }
 
+// multiTransfer makes a number of transfers given as an array of [from, to,
+// amount, data] entries atomically: if any of them fails, none of them are
+// made and false is returned. Every transfer costs the same as a separate
+// transfer call.
+func (c *nep17TokenNative) multiTransfer(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	arr, ok := args[0].Value().([]stackitem.Item)
+	if !ok {
+		panic("transfers must be an array")
+	}
+	if len(arr) > MaxMultiTransfers {
+		panic(fmt.Errorf("too many transfers: %d (max %d)", len(arr), MaxMultiTransfers))
+	}
+	type transfer struct {
+		from, to util.Uint160
+		amount   *big.Int
+		data     stackitem.Item
+	}
+	var transfers = make([]transfer, len(arr))
+	for i := range arr {
+		t, ok := arr[i].Value().([]stackitem.Item)
+		if !ok || len(t) != 4 {
+			panic(fmt.Errorf("transfer #%d must be an array of 4 elements", i))
+		}
+		transfers[i] = transfer{
+			from:   toUint160(t[0]),
+			to:     toUint160(t[1]),
+			amount: toBigInt(t[2]),
+			data:   t[3],
+		}
+	}
+	if !ic.VM.AddGas(int64(len(transfers)) * (transferCPUFee*ic.BaseExecFee() + transferStorageFee*ic.BaseStorageFee())) {
+		panic("gas limit exceeded")
+	}
+
+	var (
+		baseDAO      = ic.DAO
+		baseNtfCount = len(ic.Notifications)
+		success      bool
+	)
+	ic.DAO = ic.DAO.GetPrivate()
+	defer func() {
+		if !success {
+			ic.Notifications = ic.Notifications[:baseNtfCount]
+		}
+		ic.DAO = baseDAO
+	}()
+	for _, t := range transfers {
+		if err := c.TransferInternal(ic, t.from, t.to, t.amount, t.data); err != nil {
+			return stackitem.NewBool(false)
+		}
+	}
+	if _, err := ic.DAO.Persist(); err != nil {
+		panic(fmt.Errorf("failed to persist changes: %w", err))
+	}
+	success = true
+	return stackitem.NewBool(true)
+}
+
 func addrToStackItem(u *util.Uint160) stackitem.Item {
 	if u == nil {
 		return stackitem.Null{}
@@ -356,7 +432,8 @@ func newEventDescriptor(name string, ps ...manifest.Parameter) *manifest.Event {
 }
 
 // newEvent builds event with the provided descriptor and ActiveFrom/ActiveTill hardfork
-// values consequently specified via activations.
+// values consequently specified via activations. [config.HFDefault] specfied as ActiveFrom
+// is treated as active starting from the genesis block.
 func newEvent(desc *manifest.Event, activations ...config.Hardfork) interop.Event {
 	md := interop.Event{
 		HFSpecificEvent: interop.HFSpecificEvent{
@@ -364,7 +441,9 @@ func newEvent(desc *manifest.Event, activations ...config.Hardfork) interop.Even
 		},
 	}
 	if len(activations) > 0 {
-		md.ActiveFrom = &activations[0]
+		if activations[0] != config.HFDefault {
+			md.ActiveFrom = &activations[0]
+		}
 	}
 	if len(activations) > 1 {
 		md.ActiveTill = &activations[1]
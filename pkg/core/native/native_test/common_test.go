@@ -2,6 +2,7 @@ package native_test
 
 import (
 	"math/big"
+	"slices"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
@@ -23,6 +24,12 @@ func newNativeClient(t *testing.T, name string) *neotest.ContractInvoker {
 	return newCustomNativeClient(t, name, nil)
 }
 
+// enableNeoGoExtensions is a chain configuration function enabling
+// NeoGo-specific protocol extensions.
+func enableNeoGoExtensions(cfg *config.Blockchain) {
+	cfg.NeoGoExtensions = true
+}
+
 func newCustomNativeClient(t *testing.T, name string, f func(cfg *config.Blockchain)) *neotest.ContractInvoker {
 	bc, acc := chain.NewSingleWithCustomConfig(t, f)
 	e := neotest.NewExecutor(t, bc, acc, acc)
@@ -123,14 +130,32 @@ func setNodesByRole(t *testing.T, designateInvoker *neotest.ContractInvoker, ok
 		pubs[i] = nodes[i].Bytes()
 	}
 	if ok {
+		ntf := []stackitem.Item{stackitem.Make(int64(r))}
+		// Extended event is emitted with NeoGoExtensions enabled (Echidna is
+		// always enabled in tests using this helper).
+		extended := designateInvoker.Chain.GetConfig().NeoGoExtensions
+		var oldNodes stackitem.Item
+		if extended {
+			stack, err := designateInvoker.TestInvoke(t, "getDesignatedByRole", int64(r), designateInvoker.Chain.BlockHeight()+1)
+			require.NoError(t, err)
+			oldNodes = stack.Pop().Item()
+		}
+
 		h := designateInvoker.Invoke(t, stackitem.Null{}, "designateAsRole", int64(r), pubs)
+		ntf = append(ntf, stackitem.Make(designateInvoker.Chain.BlockHeight()))
+		if extended {
+			sorted := slices.Clone(nodes)
+			slices.SortFunc(sorted, (*keys.PublicKey).Cmp)
+			newNodes := make([]stackitem.Item, len(sorted))
+			for i := range sorted {
+				newNodes[i] = stackitem.NewByteArray(sorted[i].Bytes())
+			}
+			ntf = append(ntf, oldNodes, stackitem.NewArray(newNodes))
+		}
 		designateInvoker.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
 			ScriptHash: designateInvoker.Hash,
 			Name:       native.DesignationEventName,
-			Item: stackitem.NewArray([]stackitem.Item{
-				stackitem.Make(int64(r)),
-				stackitem.Make(designateInvoker.Chain.BlockHeight()),
-			}),
+			Item:       stackitem.NewArray(ntf),
 		})
 	} else {
 		designateInvoker.InvokeFail(t, "", "designateAsRole", int64(r), pubs)
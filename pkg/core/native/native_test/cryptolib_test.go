@@ -2,13 +2,18 @@ package native_test
 
 import (
 	"encoding/hex"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
 
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/vrf"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
@@ -93,6 +98,54 @@ func TestCryptolib_TestNotG2_Compat(t *testing.T) {
 	cryptoInvoker.InvokeFail(t, "failed to decode bls12381 G2Affine point: invalid point: subgroup check failed", "bls12381Deserialize", notG2)
 }
 
+func TestCryptolib_Bls12381IsOnCurve_IsInSubgroup(t *testing.T) {
+	c := newCustomNativeClient(t, nativenames.CryptoLib, enableNeoGoExtensions)
+
+	// X = 1 is a canonical coordinate, but there is no such point on the curve.
+	notOnCurveG1 := make([]byte, len(g1))
+	notOnCurveG1[0], notOnCurveG1[len(notOnCurveG1)-1] = 0x80, 1
+	// X exceeds the field modulus.
+	nonCanonicalG1 := slices.Clone(g1)
+	nonCanonicalG1[0] = 0x9f
+	// Valid Fp12 element outside of the GT subgroup.
+	notGT := slices.Clone(gt)
+	notGT[len(notGT)-1] ^= 1
+
+	for _, tc := range []struct {
+		name       string
+		data       []byte
+		onCurve    bool
+		inSubgroup bool
+	}{
+		{"G1", g1, true, true},
+		{"G2", g2, true, true},
+		{"GT", gt, true, true},
+		{"G1 infinity", append([]byte{0xc0}, make([]byte, len(g1)-1)...), true, true},
+		{"G1 not in subgroup", notG1, true, false},
+		{"G2 not in subgroup", notG2, true, false},
+		{"GT not in subgroup", notGT, true, false},
+		{"G1 not on curve", notOnCurveG1, false, false},
+		{"G1 non-canonical", nonCanonicalG1, false, false},
+		{"G1 uncompressed flag", append([]byte{g1[0] &^ 0x80}, g1[1:]...), false, false},
+		{"bad length", g1[1:], false, false},
+		{"empty", []byte{}, false, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c.Invoke(t, tc.onCurve, "bls12381IsOnCurve", tc.data)
+			c.Invoke(t, tc.inSubgroup, "bls12381IsInSubgroup", tc.data)
+		})
+	}
+	c.InvokeFail(t, "invalid serialized bls12381 point", "bls12381IsOnCurve", []any{1})
+	c.InvokeFail(t, "invalid serialized bls12381 point", "bls12381IsInSubgroup", []any{1})
+}
+
+func TestCryptolib_Bls12381IsOnCurve_IsInSubgroupWithoutExtensions(t *testing.T) {
+	c := newCryptolibClient(t)
+
+	c.InvokeFail(t, "method not found: bls12381IsOnCurve/1", "bls12381IsOnCurve", g1)
+	c.InvokeFail(t, "method not found: bls12381IsInSubgroup/1", "bls12381IsInSubgroup", g1)
+}
+
 func TestCryptolib_TestBls12381Add_Compat(t *testing.T) {
 	c := newCryptolibClient(t)
 
@@ -134,6 +187,55 @@ func TestKeccak256_Compat(t *testing.T) {
 	require.Equal(t, expected, actual)
 }
 
+func TestCryptoLib_VerifyWithECDsaHasher(t *testing.T) {
+	c := newCustomNativeClient(t, nativenames.CryptoLib, enableNeoGoExtensions)
+	priv, err := keys.NewSecp256k1PrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey().Bytes()
+
+	// Ethereum personal_sign message digest is computed outside of the contract.
+	msg := []byte("hello")
+	digest := native.Keccak256(append([]byte("\x19Ethereum Signed Message:\n"+strconv.Itoa(len(msg))), msg...))
+	sig := priv.SignHash(digest)
+	c.Invoke(t, true, "verifyWithECDsa", digest.BytesBE(), pub, sig, int64(native.Secp256k1), int64(native.HasherNone))
+	c.Invoke(t, false, "verifyWithECDsa", native.Keccak256(msg).BytesBE(), pub, sig, int64(native.Secp256k1), int64(native.HasherNone))
+	c.InvokeFail(t, "invalid message hash length", "verifyWithECDsa", msg, pub, sig, int64(native.Secp256k1), int64(native.HasherNone))
+
+	// Bitcoin double-SHA256 message digest.
+	sig = priv.SignHash(hash.DoubleSha256(msg))
+	c.Invoke(t, true, "verifyWithECDsa", hash.Sha256(msg).BytesBE(), pub, sig, int64(native.Secp256k1), int64(native.HasherSha256))
+
+	// Keccak256 is applied by the contract.
+	sig = priv.SignHash(native.Keccak256(msg))
+	c.Invoke(t, true, "verifyWithECDsa", msg, pub, sig, int64(native.Secp256k1), int64(native.HasherKeccak256))
+}
+
+func TestCryptoLib_VrfVerify(t *testing.T) {
+	c := newCustomNativeClient(t, nativenames.CryptoLib, enableNeoGoExtensions)
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey().Bytes()
+
+	msg := []byte("hello")
+	proof, err := vrf.Prove(priv, msg)
+	require.NoError(t, err)
+	beta, err := vrf.ProofToHash(proof)
+	require.NoError(t, err)
+
+	c.Invoke(t, stackitem.NewByteArray(beta), "vrfVerify", msg, pub, proof)
+	c.Invoke(t, stackitem.Null{}, "vrfVerify", []byte("bye"), pub, proof)
+	c.Invoke(t, stackitem.Null{}, "vrfVerify", msg, pub, proof[1:])
+	c.InvokeFail(t, "failed to decode pubkey", "vrfVerify", msg, pub[1:], proof)
+}
+
+func TestCryptoLib_VrfVerifyWithoutExtensions(t *testing.T) {
+	c := newCryptolibClient(t)
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	c.InvokeFail(t, "method not found: vrfVerify/3", "vrfVerify", []byte("hello"), priv.PublicKey().Bytes(), []byte{1, 2, 3})
+}
+
 func TestCryptolib_TestBls12381Mul_Compat(t *testing.T) {
 	c := newCryptolibClient(t)
 
@@ -18,6 +18,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 )
 
@@ -135,6 +136,26 @@ func TestCryptoLib_KoblitzVerificationScript(t *testing.T) {
 	check(t, buildKoblitzVerificationScriptCompat, constructMessageCompat)
 }
 
+// TestCryptoLib_KoblitzAccount ensures that regular wallet accounts created from Koblitz
+// keys use the preferable verification script and can be used to sign transactions.
+func TestCryptoLib_KoblitzAccount(t *testing.T) {
+	c := newGasClient(t)
+	e := c.Executor
+
+	pk, err := keys.NewSecp256k1PrivateKey()
+	require.NoError(t, err)
+	acc := wallet.NewAccountFromPrivateKey(pk)
+	require.Equal(t, buildKoblitzVerificationScript(t, pk.PublicKey()), acc.Contract.Script)
+	require.Equal(t, hash.Hash160(acc.Contract.Script), acc.ScriptHash())
+
+	c.WithSigners(c.Committee).Invoke(t, true, "transfer", c.Committee.ScriptHash(), acc.ScriptHash(), 10000_0000_0000, nil)
+
+	to := util.Uint160{1, 2, 3}
+	amount := 5
+	c.WithSigners(neotest.NewSingleSigner(acc)).Invoke(t, true, "transfer", acc.ScriptHash(), to, amount, nil)
+	e.CheckGASBalance(t, to, big.NewInt(int64(amount)))
+}
+
 // buildKoblitzVerificationScript builds witness verification script for Koblitz public key.
 // This method checks
 //
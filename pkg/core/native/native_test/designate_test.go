@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -160,3 +161,51 @@ func TestDesignate_GenesisRolesExtension(t *testing.T) {
 	slices.SortFunc(pubs, (*keys.PublicKey).Cmp)
 	checkNodeRoles(t, c, true, noderoles.StateValidator, e.Chain.BlockHeight()+1, pubs)
 }
+
+func TestDesignate_DesignationEventPreEchidna(t *testing.T) {
+	c := newCustomNativeClient(t, nativenames.Designation, func(cfg *config.Blockchain) {
+		cfg.NeoGoExtensions = true
+		cfg.Hardforks = map[string]uint32{
+			config.HFEchidna.String(): 100500,
+		}
+	})
+	designateInvoker := c.WithSigners(c.Committee)
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	h := designateInvoker.Invoke(t, stackitem.Null{}, "designateAsRole", int64(noderoles.Oracle), []any{priv.PublicKey().Bytes()})
+	designateInvoker.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+		ScriptHash: designateInvoker.Hash,
+		Name:       native.DesignationEventName,
+		Item: stackitem.NewArray([]stackitem.Item{
+			stackitem.Make(int64(noderoles.Oracle)),
+			stackitem.Make(designateInvoker.Chain.BlockHeight()),
+		}),
+	})
+	designateInvoker.InvokeFail(t, "method not found: getDesignationHeight/2", "getDesignationHeight", int64(noderoles.Oracle), 1)
+}
+
+func TestDesignate_GetDesignationHeight(t *testing.T) {
+	c := newCustomNativeClient(t, nativenames.Designation, enableNeoGoExtensions)
+	designateInvoker := c.WithSigners(c.Committee)
+
+	// No designation.
+	designateInvoker.Invoke(t, 0, "getDesignationHeight", int64(noderoles.Oracle), designateInvoker.Chain.BlockHeight())
+
+	priv1, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	setNodesByRole(t, designateInvoker, true, noderoles.Oracle, keys.PublicKeys{priv1.PublicKey()})
+	first := designateInvoker.Chain.BlockHeight() + 1
+
+	priv2, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	setNodesByRole(t, designateInvoker, true, noderoles.Oracle, keys.PublicKeys{priv1.PublicKey(), priv2.PublicKey()})
+	second := designateInvoker.Chain.BlockHeight() + 1
+
+	designateInvoker.Invoke(t, 0, "getDesignationHeight", int64(noderoles.Oracle), first-1)
+	designateInvoker.Invoke(t, first, "getDesignationHeight", int64(noderoles.Oracle), first)
+	designateInvoker.Invoke(t, first, "getDesignationHeight", int64(noderoles.Oracle), second-1)
+	designateInvoker.Invoke(t, second, "getDesignationHeight", int64(noderoles.Oracle), second)
+	designateInvoker.InvokeFail(t, native.ErrInvalidIndex.Error(), "getDesignationHeight", int64(noderoles.Oracle), second+100)
+	designateInvoker.InvokeFail(t, native.ErrInvalidRole.Error(), "getDesignationHeight", 0xFF, second)
+}
@@ -4,9 +4,12 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
@@ -138,3 +141,78 @@ func TestGAS_RewardWithP2PSigExtensionsEnabled(t *testing.T) {
 	tsExpected := tsInitial + 5000_0000 - tx.SystemFee
 	require.Equal(t, tsExpected, tsUpdated)
 }
+
+func TestGAS_MultiTransfer(t *testing.T) {
+	c := newCustomNativeClient(t, nativenames.Gas, enableNeoGoExtensions)
+	e := c.Executor
+	gasInvoker := c.WithSigners(c.NewAccount(t))
+	owner := gasInvoker.Signers[0].ScriptHash()
+	a, b := random.Uint160(), random.Uint160()
+
+	t.Run("good", func(t *testing.T) {
+		h := gasInvoker.Invoke(t, true, "multiTransfer", []any{
+			[]any{owner, a, 1_0000_0000, nil},
+			[]any{owner, b, 2_0000_0000, nil},
+			[]any{owner, a, 3, nil},
+		})
+		e.CheckTxNotificationEvent(t, h, 1, state.NotificationEvent{
+			ScriptHash: c.Hash,
+			Name:       "Transfer",
+			Item: stackitem.NewArray([]stackitem.Item{
+				stackitem.NewByteArray(owner.BytesBE()),
+				stackitem.NewByteArray(b.BytesBE()),
+				stackitem.NewBigInteger(big.NewInt(2_0000_0000)),
+			}),
+		})
+		require.Equal(t, 3, len(e.GetTxExecResult(t, h).Events))
+		require.Equal(t, big.NewInt(1_0000_0003), e.Chain.GetUtilityTokenBalance(a))
+		require.Equal(t, big.NewInt(2_0000_0000), e.Chain.GetUtilityTokenBalance(b))
+	})
+	t.Run("bad: not enough funds", func(t *testing.T) {
+		balance := e.Chain.GetUtilityTokenBalance(owner)
+		h := gasInvoker.Invoke(t, false, "multiTransfer", []any{
+			[]any{owner, a, 1_0000_0000, nil},
+			[]any{owner, b, balance.Int64(), nil},
+		})
+		require.Equal(t, 0, len(e.GetTxExecResult(t, h).Events))
+		require.Equal(t, big.NewInt(1_0000_0003), e.Chain.GetUtilityTokenBalance(a))
+		require.Equal(t, big.NewInt(2_0000_0000), e.Chain.GetUtilityTokenBalance(b))
+	})
+	t.Run("bad: no witness", func(t *testing.T) {
+		h := gasInvoker.Invoke(t, false, "multiTransfer", []any{
+			[]any{owner, a, 1, nil},
+			[]any{e.Validator.ScriptHash(), b, 1, nil},
+		})
+		require.Equal(t, 0, len(e.GetTxExecResult(t, h).Events))
+		require.Equal(t, big.NewInt(1_0000_0003), e.Chain.GetUtilityTokenBalance(a))
+	})
+	t.Run("empty", func(t *testing.T) {
+		gasInvoker.Invoke(t, true, "multiTransfer", []any{})
+	})
+	t.Run("malformed entry", func(t *testing.T) {
+		gasInvoker.InvokeFail(t, "transfer #1 must be an array of 4 elements", "multiTransfer", []any{
+			[]any{owner, a, 1, nil},
+			[]any{owner, a, 1},
+		})
+		gasInvoker.InvokeFail(t, "invalid conversion", "multiTransfer", []any{
+			[]any{owner, a, []any{}, nil},
+		})
+	})
+	t.Run("too many transfers", func(t *testing.T) {
+		transfers := make([]any, native.MaxMultiTransfers+1)
+		for i := range transfers {
+			transfers[i] = []any{owner, a, 1, nil}
+		}
+		gasInvoker.InvokeFail(t, "too many transfers", "multiTransfer", transfers)
+	})
+}
+
+func TestGAS_MultiTransferWithoutExtensions(t *testing.T) {
+	c := newGasClient(t)
+	gasInvoker := c.WithSigners(c.NewAccount(t))
+	owner := gasInvoker.Signers[0].ScriptHash()
+
+	gasInvoker.InvokeFail(t, "method not found: multiTransfer/1", "multiTransfer", []any{
+		[]any{owner, random.Uint160(), 1, nil},
+	})
+}
@@ -720,6 +720,40 @@ func TestNEO_TransferNonZeroWithZeroBalance(t *testing.T) {
 	require.Equal(t, uint32(0), updatedHeight)
 }
 
+func TestNEO_MultiTransfer(t *testing.T) {
+	bc, validators, committee := chain.NewMultiWithCustomConfig(t, enableNeoGoExtensions)
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	neoValidatorsInvoker := e.ValidatorInvoker(e.NativeHash(t, nativenames.Neo))
+	from := neoValidatorsInvoker.Validator.ScriptHash()
+	a, b := random.Uint160(), random.Uint160()
+
+	h := neoValidatorsInvoker.Invoke(t, true, "multiTransfer", []any{
+		[]any{from, a, 10, nil},
+		[]any{from, b, 20, nil},
+	})
+	aer := e.CheckHalt(t, h, stackitem.Make(true))
+	require.Equal(t, 3, len(aer.Events)) // GAS claim + 2 transfers.
+	balance, _ := e.Chain.GetGoverningTokenBalance(a)
+	require.Equal(t, int64(10), balance.Int64())
+	balance, _ = e.Chain.GetGoverningTokenBalance(b)
+	require.Equal(t, int64(20), balance.Int64())
+
+	// The first transfer is valid, but the second one fails, so none of them
+	// are made (including GAS claim).
+	fromBalance, fromHeight := e.Chain.GetGoverningTokenBalance(from)
+	h = neoValidatorsInvoker.Invoke(t, false, "multiTransfer", []any{
+		[]any{from, a, 10, nil},
+		[]any{from, b, fromBalance.Int64(), nil},
+	})
+	aer = e.CheckHalt(t, h, stackitem.Make(false))
+	require.Equal(t, 0, len(aer.Events))
+	balance, _ = e.Chain.GetGoverningTokenBalance(a)
+	require.Equal(t, int64(10), balance.Int64())
+	updatedBalance, updatedHeight := e.Chain.GetGoverningTokenBalance(from)
+	require.Equal(t, fromBalance, updatedBalance)
+	require.Equal(t, fromHeight, updatedHeight)
+}
+
 func TestNEO_CalculateBonus(t *testing.T) {
 	neoCommitteeInvoker := newNeoCommitteeClient(t, 10_0000_0000)
 	e := neoCommitteeInvoker.Executor
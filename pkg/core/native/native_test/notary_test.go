@@ -9,14 +9,17 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -26,6 +29,7 @@ import (
 func newNotaryClient(t *testing.T) *neotest.ContractInvoker {
 	bc, acc := chain.NewSingleWithCustomConfig(t, func(cfg *config.Blockchain) {
 		cfg.P2PSigExtensions = true
+		cfg.NeoGoExtensions = true
 	})
 	e := neotest.NewExecutor(t, bc, acc, acc)
 
@@ -42,6 +46,52 @@ func TestNotary_MaxNotValidBeforeDeltaCache(t *testing.T) {
 	testGetSetCache(t, c, "MaxNotValidBeforeDelta", 140)
 }
 
+func TestNotary_NotaryServiceFeePerKey(t *testing.T) {
+	c := newNotaryClient(t)
+	testGetSet(t, c, "NotaryServiceFeePerKey", 1000_0000, 0, 10_00000000)
+
+	// The value is shared with Policy's NotaryAssisted attribute fee.
+	policyInvoker := c.CommitteeInvoker(c.NativeHash(t, nativenames.Policy))
+	policyInvoker.Invoke(t, 1000_0001, "getAttributeFee", int64(transaction.NotaryAssistedT))
+}
+
+func TestNotary_ParameterChangedEvents(t *testing.T) {
+	c := newNotaryClient(t)
+	committeeInvoker := c.WithSigners(c.Committee)
+
+	h := committeeInvoker.Invoke(t, stackitem.Null{}, "setMaxNotValidBeforeDelta", 150)
+	committeeInvoker.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+		ScriptHash: committeeInvoker.Hash,
+		Name:       native.MaxNotValidBeforeDeltaChangedEventName,
+		Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(140), stackitem.Make(150)}),
+	})
+
+	h = committeeInvoker.Invoke(t, stackitem.Null{}, "setNotaryServiceFeePerKey", 2000_0000)
+	committeeInvoker.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+		ScriptHash: committeeInvoker.Hash,
+		Name:       native.NotaryServiceFeePerKeyChangedEventName,
+		Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(1000_0000), stackitem.Make(2000_0000)}),
+	})
+}
+
+func TestNotary_ParameterChangedEventsPreEchidna(t *testing.T) {
+	c := newCustomNativeClient(t, nativenames.Notary, func(cfg *config.Blockchain) {
+		cfg.P2PSigExtensions = true
+		cfg.NeoGoExtensions = true
+		cfg.Hardforks = map[string]uint32{
+			config.HFEchidna.String(): 100500,
+		}
+	})
+	committeeInvoker := c.WithSigners(c.Committee)
+
+	h := committeeInvoker.Invoke(t, stackitem.Null{}, "setMaxNotValidBeforeDelta", 150)
+	aer, err := committeeInvoker.Chain.GetAppExecResults(h, trigger.Application)
+	require.NoError(t, err)
+	require.Empty(t, aer[0].Events)
+	committeeInvoker.InvokeFail(t, "method not found: getNotaryServiceFeePerKey/0", "getNotaryServiceFeePerKey")
+	committeeInvoker.InvokeFail(t, "method not found: setNotaryServiceFeePerKey/1", "setNotaryServiceFeePerKey", 2000_0000)
+}
+
 func TestNotary_Pipeline(t *testing.T) {
 	notaryCommitteeInvoker := newNotaryClient(t)
 	e := notaryCommitteeInvoker.Executor
@@ -2,19 +2,24 @@ package native_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
 )
 
 func newPolicyClient(t *testing.T) *neotest.ContractInvoker {
@@ -158,3 +163,82 @@ func TestPolicy_BlockedAccounts(t *testing.T) {
 		helperInvoker.Invoke(t, true, "do")
 	})
 }
+
+func TestPolicy_FeatureFlags(t *testing.T) {
+	c := newCustomNativeClient(t, nativenames.Policy, enableNeoGoExtensions)
+	e := c.Executor
+	randomInvoker := c.WithSigners(c.NewAccount(t))
+	committeeInvoker := c.WithSigners(c.Committee)
+
+	t.Run("not signed by committee", func(t *testing.T) {
+		randomInvoker.InvokeFail(t, "invalid committee signature", "setFeatureFlag", "flag", 1)
+		randomInvoker.InvokeFail(t, "invalid committee signature", "deleteFeatureFlag", "flag")
+	})
+	t.Run("invalid name", func(t *testing.T) {
+		for _, name := range []string{"", strings.Repeat("a", 33), "bad name", "флаг"} {
+			randomInvoker.InvokeFail(t, "feature flag name", "getFeatureFlag", name)
+			committeeInvoker.InvokeFail(t, "feature flag name", "setFeatureFlag", name, 1)
+			committeeInvoker.InvokeFail(t, "feature flag name", "deleteFeatureFlag", name)
+		}
+	})
+	t.Run("set, get, delete", func(t *testing.T) {
+		const name = "Some.Flag-1_a"
+
+		randomInvoker.Invoke(t, stackitem.Null{}, "getFeatureFlag", name)
+		_, ok := e.Chain.GetFeatureFlag(name)
+		require.False(t, ok)
+
+		h := committeeInvoker.Invoke(t, stackitem.Null{}, "setFeatureFlag", name, 42)
+		committeeInvoker.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+			ScriptHash: c.Hash,
+			Name:       native.FeatureFlagChangedEventName,
+			Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(name), stackitem.Make(42)}),
+		})
+		randomInvoker.Invoke(t, 42, "getFeatureFlag", name)
+		v, ok := e.Chain.GetFeatureFlag(name)
+		require.True(t, ok)
+		require.Equal(t, int64(42), v)
+
+		committeeInvoker.Invoke(t, stackitem.Null{}, "setFeatureFlag", name, -1)
+		randomInvoker.Invoke(t, -1, "getFeatureFlag", name)
+
+		h = committeeInvoker.Invoke(t, stackitem.Null{}, "deleteFeatureFlag", name)
+		committeeInvoker.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+			ScriptHash: c.Hash,
+			Name:       native.FeatureFlagChangedEventName,
+			Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(name), stackitem.Null{}}),
+		})
+		randomInvoker.Invoke(t, stackitem.Null{}, "getFeatureFlag", name)
+		_, ok = e.Chain.GetFeatureFlag(name)
+		require.False(t, ok)
+
+		// Deleting missing flag is a no-op.
+		h = committeeInvoker.Invoke(t, stackitem.Null{}, "deleteFeatureFlag", name)
+		aer, err := e.Chain.GetAppExecResults(h, trigger.Application)
+		require.NoError(t, err)
+		require.Empty(t, aer[0].Events)
+	})
+}
+
+func TestPolicy_FeatureFlagsPreEchidna(t *testing.T) {
+	c := newCustomNativeClient(t, nativenames.Policy, func(cfg *config.Blockchain) {
+		cfg.NeoGoExtensions = true
+		cfg.Hardforks = map[string]uint32{
+			config.HFEchidna.String(): 100500,
+		}
+	})
+	committeeInvoker := c.WithSigners(c.Committee)
+
+	committeeInvoker.InvokeFail(t, "method not found: getFeatureFlag/1", "getFeatureFlag", "flag")
+	committeeInvoker.InvokeFail(t, "method not found: setFeatureFlag/2", "setFeatureFlag", "flag", 1)
+	committeeInvoker.InvokeFail(t, "method not found: deleteFeatureFlag/1", "deleteFeatureFlag", "flag")
+}
+
+func TestPolicy_FeatureFlagsWithoutExtensions(t *testing.T) {
+	c := newPolicyClient(t)
+	committeeInvoker := c.WithSigners(c.Committee)
+
+	committeeInvoker.InvokeFail(t, "method not found: getFeatureFlag/1", "getFeatureFlag", "flag")
+	committeeInvoker.InvokeFail(t, "method not found: setFeatureFlag/2", "setFeatureFlag", "flag", 1)
+	committeeInvoker.InvokeFail(t, "method not found: deleteFeatureFlag/1", "deleteFeatureFlag", "flag")
+}
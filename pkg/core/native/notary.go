@@ -33,6 +33,10 @@ type Notary struct {
 	NEO    *NEO
 	Desig  *Designate
 	Policy *Policy
+
+	// neoGoExtensionsEnabled defines whether NeoGo-specific methods and
+	// events are available.
+	neoGoExtensionsEnabled bool
 }
 
 type NotaryCache struct {
@@ -52,6 +56,15 @@ const (
 	defaultMaxNotValidBeforeDelta = 140 // 20 rounds for 7 validators, a little more than half an hour
 )
 
+const (
+	// MaxNotValidBeforeDeltaChangedEventName is the name of the event emitted
+	// by the Notary contract when MaxNotValidBeforeDelta is changed.
+	MaxNotValidBeforeDeltaChangedEventName = "MaxNotValidBeforeDeltaChanged"
+	// NotaryServiceFeePerKeyChangedEventName is the name of the event emitted
+	// by the Notary contract when the per-key notary service fee is changed.
+	NotaryServiceFeePerKeyChangedEventName = "NotaryServiceFeePerKeyChanged"
+)
+
 var maxNotValidBeforeDeltaKey = []byte{10}
 
 var (
@@ -71,8 +84,11 @@ func copyNotaryCache(src, dst *NotaryCache) {
 }
 
 // newNotary returns Notary native contract.
-func newNotary() *Notary {
-	n := &Notary{ContractMD: *interop.NewContractMD(nativenames.Notary, notaryContractID)}
+func newNotary(neoGoExtensionsEnabled bool) *Notary {
+	n := &Notary{
+		ContractMD:             *interop.NewContractMD(nativenames.Notary, notaryContractID),
+		neoGoExtensionsEnabled: neoGoExtensionsEnabled,
+	}
 	defer n.BuildHFSpecificMD(n.ActiveIn())
 
 	desc := newDescriptor("onNEP17Payment", smartcontract.VoidType,
@@ -115,9 +131,40 @@ func newNotary() *Notary {
 
 	desc = newDescriptor("setMaxNotValidBeforeDelta", smartcontract.VoidType,
 		manifest.NewParameter("value", smartcontract.IntegerType))
-	md = newMethodAndPrice(n.setMaxNotValidBeforeDelta, 1<<15, callflag.States)
+	if !neoGoExtensionsEnabled {
+		md = newMethodAndPrice(n.setMaxNotValidBeforeDelta, 1<<15, callflag.States)
+		n.AddMethod(md, desc)
+		return n
+	}
+	md = newMethodAndPrice(n.setMaxNotValidBeforeDelta, 1<<15, callflag.States, config.HFDefault, config.HFEchidna)
+	n.AddMethod(md, desc)
+
+	desc = newDescriptor("setMaxNotValidBeforeDelta", smartcontract.VoidType,
+		manifest.NewParameter("value", smartcontract.IntegerType))
+	md = newMethodAndPrice(n.setMaxNotValidBeforeDelta, 1<<15, callflag.States|callflag.AllowNotify, config.HFEchidna)
+	n.AddMethod(md, desc)
+
+	desc = newDescriptor("getNotaryServiceFeePerKey", smartcontract.IntegerType)
+	md = newMethodAndPrice(n.getNotaryServiceFeePerKey, 1<<15, callflag.ReadStates, config.HFEchidna)
+	n.AddMethod(md, desc)
+
+	desc = newDescriptor("setNotaryServiceFeePerKey", smartcontract.VoidType,
+		manifest.NewParameter("value", smartcontract.IntegerType))
+	md = newMethodAndPrice(n.setNotaryServiceFeePerKey, 1<<15, callflag.States|callflag.AllowNotify, config.HFEchidna)
 	n.AddMethod(md, desc)
 
+	eDesc := newEventDescriptor(MaxNotValidBeforeDeltaChangedEventName,
+		manifest.NewParameter("Old", smartcontract.IntegerType),
+		manifest.NewParameter("New", smartcontract.IntegerType))
+	eMD := newEvent(eDesc, config.HFEchidna)
+	n.AddEvent(eMD)
+
+	eDesc = newEventDescriptor(NotaryServiceFeePerKeyChangedEventName,
+		manifest.NewParameter("Old", smartcontract.IntegerType),
+		manifest.NewParameter("New", smartcontract.IntegerType))
+	eMD = newEvent(eDesc, config.HFEchidna)
+	n.AddEvent(eMD)
+
 	return n
 }
 
@@ -428,16 +475,49 @@ func (n *Notary) setMaxNotValidBeforeDelta(ic *interop.Context, args []stackitem
 	}
 	setIntWithKey(n.ID, ic.DAO, maxNotValidBeforeDeltaKey, int64(value))
 	cache := ic.DAO.GetRWCache(n.ID).(*NotaryCache)
+	old := cache.maxNotValidBeforeDelta
 	cache.maxNotValidBeforeDelta = value
+	if n.neoGoExtensionsEnabled && ic.IsHardforkEnabled(config.HFEchidna) {
+		ic.AddNotification(n.Hash, MaxNotValidBeforeDeltaChangedEventName, stackitem.NewArray([]stackitem.Item{
+			stackitem.NewBigInteger(big.NewInt(int64(old))),
+			stackitem.NewBigInteger(big.NewInt(int64(value))),
+		}))
+	}
+	return stackitem.Null{}
+}
+
+// getNotaryServiceFeePerKey is a Notary contract method and returns the fee
+// paid for every key of NotaryAssisted transaction attribute.
+func (n *Notary) getNotaryServiceFeePerKey(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	return stackitem.NewBigInteger(big.NewInt(n.Policy.GetAttributeFeeInternal(ic.DAO, transaction.NotaryAssistedT)))
+}
+
+// setNotaryServiceFeePerKey is a Notary contract method and sets the fee paid
+// for every key of NotaryAssisted transaction attribute. The value is the same
+// as Policy's NotaryAssisted attribute fee, it's just a shortcut allowing to
+// track the changes via Notary contract notifications.
+func (n *Notary) setNotaryServiceFeePerKey(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	value := toUint32(args[0])
+	if value > maxAttributeFee {
+		panic(fmt.Errorf("NotaryServiceFeePerKey cannot be more than %d", maxAttributeFee))
+	}
+	if !n.NEO.checkCommittee(ic) {
+		panic("invalid committee signature")
+	}
+	old := n.Policy.GetAttributeFeeInternal(ic.DAO, transaction.NotaryAssistedT)
+	n.Policy.setAttributeFeeInternal(ic.DAO, transaction.NotaryAssistedT, value)
+	ic.AddNotification(n.Hash, NotaryServiceFeePerKeyChangedEventName, stackitem.NewArray([]stackitem.Item{
+		stackitem.NewBigInteger(big.NewInt(old)),
+		stackitem.NewBigInteger(big.NewInt(int64(value))),
+	}))
 	return stackitem.Null{}
 }
 
 // GetDepositFor returns state.Deposit for the account specified. It returns nil in case
 // the deposit is not found in the storage and panics in case of any other error.
-func (n *Notary) GetDepositFor(dao *dao.Simple, acc util.Uint160) *state.Deposit {
+func (n *Notary) GetDepositFor(d *dao.Simple, acc util.Uint160) *state.Deposit {
 	key := append([]byte{prefixDeposit}, acc.BytesBE()...)
-	deposit := new(state.Deposit)
-	err := getConvertibleFromDAO(n.ID, dao, key, deposit)
+	deposit, err := dao.GetConvertible[state.Deposit](d, n.ID, key)
 	if err == nil {
 		return deposit
 	}
@@ -450,7 +530,7 @@ func (n *Notary) GetDepositFor(dao *dao.Simple, acc util.Uint160) *state.Deposit
 // putDepositFor puts the deposit on the balance of the specified account in the storage.
 func (n *Notary) putDepositFor(dao *dao.Simple, deposit *state.Deposit, acc util.Uint160) error {
 	key := append([]byte{prefixDeposit}, acc.BytesBE()...)
-	return putConvertibleToDAO(n.ID, dao, key, deposit)
+	return dao.PutConvertible(n.ID, key, deposit)
 }
 
 // removeDepositFor removes the deposit from the storage.
@@ -189,8 +189,8 @@ func (o *Oracle) PostPersist(ic *interop.Context) error {
 			continue
 		}
 		reqKey := makeRequestKey(resp.ID)
-		req := new(state.OracleRequest)
-		if err := o.getConvertibleFromDAO(ic.DAO, reqKey, req); err != nil {
+		req, err := dao.GetConvertible[state.OracleRequest](ic.DAO, o.ID, reqKey)
+		if err != nil {
 			continue
 		}
 		ic.DAO.DeleteStorageItem(o.ID, reqKey)
@@ -199,19 +199,18 @@ func (o *Oracle) PostPersist(ic *interop.Context) error {
 		}
 
 		idKey := makeIDListKey(req.URL)
-		idList := new(IDList)
-		if err := o.getConvertibleFromDAO(ic.DAO, idKey, idList); err != nil {
+		idList, err := dao.GetConvertible[IDList](ic.DAO, o.ID, idKey)
+		if err != nil {
 			return err
 		}
 		if !idList.Remove(resp.ID) {
 			return errors.New("response ID wasn't found")
 		}
 
-		var err error
 		if len(*idList) == 0 {
 			ic.DAO.DeleteStorageItem(o.ID, idKey)
 		} else {
-			err = putConvertibleToDAO(o.ID, ic.DAO, idKey, idList)
+			err = ic.DAO.PutConvertible(o.ID, idKey, idList)
 		}
 		if err != nil {
 			return err
@@ -443,7 +442,7 @@ func (o *Oracle) RequestInternal(ic *interop.Context, url string, filter *string
 // PutRequestInternal puts the oracle request with the specified id to d.
 func (o *Oracle) PutRequestInternal(id uint64, req *state.OracleRequest, d *dao.Simple) error {
 	reqKey := makeRequestKey(id)
-	if err := putConvertibleToDAO(o.ID, d, reqKey, req); err != nil {
+	if err := d.PutConvertible(o.ID, reqKey, req); err != nil {
 		return err
 	}
 	orc, _ := o.Module.Load().(*OracleService)
@@ -452,16 +451,19 @@ func (o *Oracle) PutRequestInternal(id uint64, req *state.OracleRequest, d *dao.
 	}
 
 	// Add request ID to the id list.
-	lst := new(IDList)
 	key := makeIDListKey(req.URL)
-	if err := o.getConvertibleFromDAO(d, key, lst); err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+	lst, err := dao.GetConvertible[IDList](d, o.ID, key)
+	if errors.Is(err, storage.ErrKeyNotFound) {
+		lst, err = new(IDList), nil
+	}
+	if err != nil {
 		return err
 	}
 	if len(*lst) >= maxRequestsCount {
 		return fmt.Errorf("there are too many pending requests for %s url", req.URL)
 	}
 	*lst = append(*lst, id)
-	return putConvertibleToDAO(o.ID, d, key, lst)
+	return d.PutConvertible(o.ID, key, lst)
 }
 
 // GetScriptHash returns script hash of oracle nodes.
@@ -477,16 +479,12 @@ func (o *Oracle) GetOracleNodes(d *dao.Simple) (keys.PublicKeys, error) {
 
 // GetRequestInternal returns the request by ID and key under which it is stored.
 func (o *Oracle) GetRequestInternal(d *dao.Simple, id uint64) (*state.OracleRequest, error) {
-	key := makeRequestKey(id)
-	req := new(state.OracleRequest)
-	return req, o.getConvertibleFromDAO(d, key, req)
+	return dao.GetConvertible[state.OracleRequest](d, o.ID, makeRequestKey(id))
 }
 
 // GetIDListInternal returns the request by ID and key under which it is stored.
 func (o *Oracle) GetIDListInternal(d *dao.Simple, url string) (*IDList, error) {
-	key := makeIDListKey(url)
-	idList := new(IDList)
-	return idList, o.getConvertibleFromDAO(d, key, idList)
+	return dao.GetConvertible[IDList](d, o.ID, makeIDListKey(url))
 }
 
 func (o *Oracle) verify(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
@@ -520,7 +518,10 @@ func (o *Oracle) getOriginalTxID(d *dao.Simple, tx *transaction.Transaction) uti
 	for i := range tx.Attributes {
 		if tx.Attributes[i].Type == transaction.OracleResponseT {
 			id := tx.Attributes[i].Value.(*transaction.OracleResponse).ID
-			req, _ := o.GetRequestInternal(d, id)
+			req, err := o.GetRequestInternal(d, id)
+			if err != nil {
+				return util.Uint256{}
+			}
 			return req.OriginalTxID
 		}
 	}
@@ -536,8 +537,8 @@ func (o *Oracle) GetRequests(d *dao.Simple) (map[uint64]*state.OracleRequest, er
 			err = errors.New("invalid request ID")
 			return false
 		}
-		req := new(state.OracleRequest)
-		err = stackitem.DeserializeConvertible(v, req)
+		var req *state.OracleRequest
+		req, err = dao.DecodeConvertible[state.OracleRequest](v)
 		if err != nil {
 			return false
 		}
@@ -562,10 +563,6 @@ func makeIDListKey(url string) []byte {
 	return append(prefixIDList, hash.Hash160([]byte(url)).BytesBE()...)
 }
 
-func (o *Oracle) getConvertibleFromDAO(d *dao.Simple, key []byte, item stackitem.Convertible) error {
-	return getConvertibleFromDAO(o.ID, d, key, item)
-}
-
 // updateCache updates cached Oracle values if they've been changed.
 func (o *Oracle) updateCache(d *dao.Simple) error {
 	orc, _ := o.Module.Load().(*OracleService)
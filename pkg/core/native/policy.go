@@ -43,13 +43,21 @@ const (
 	maxStoragePrice = 10000000
 	// maxAttributeFee is the maximum allowed value for a transaction attribute fee.
 	maxAttributeFee = 10_00000000
+	// maxFeatureFlagNameLength is the maximum allowed length of a feature flag name.
+	maxFeatureFlagNameLength = 32
 
 	// blockedAccountPrefix is a prefix used to store blocked account.
 	blockedAccountPrefix = 15
 	// attributeFeePrefix is a prefix used to store attribute fee.
 	attributeFeePrefix = 20
+	// featureFlagPrefix is a prefix used to store feature flags.
+	featureFlagPrefix = 30
 )
 
+// FeatureFlagChangedEventName is the name of the event emitted by the Policy
+// contract when a feature flag is set or deleted.
+const FeatureFlagChangedEventName = "FeatureFlagChanged"
+
 var (
 	// execFeeFactorKey is a key used to store execution fee factor.
 	execFeeFactorKey = []byte{18}
@@ -76,6 +84,7 @@ type PolicyCache struct {
 	storagePrice       uint32
 	attributeFee       map[transaction.AttrType]uint32
 	blockedAccounts    []util.Uint160
+	featureFlags       map[string]int64
 }
 
 var (
@@ -94,10 +103,11 @@ func copyPolicyCache(src, dst *PolicyCache) {
 	*dst = *src
 	dst.attributeFee = maps.Clone(src.attributeFee)
 	dst.blockedAccounts = slices.Clone(src.blockedAccounts)
+	dst.featureFlags = maps.Clone(src.featureFlags)
 }
 
 // newPolicy returns Policy native contract.
-func newPolicy(p2pSigExtensionsEnabled bool) *Policy {
+func newPolicy(p2pSigExtensionsEnabled, neoGoExtensionsEnabled bool) *Policy {
 	p := &Policy{
 		ContractMD:              *interop.NewContractMD(nativenames.Policy, policyContractID),
 		p2pSigExtensionsEnabled: p2pSigExtensionsEnabled,
@@ -157,6 +167,30 @@ func newPolicy(p2pSigExtensionsEnabled bool) *Policy {
 	md = newMethodAndPrice(p.unblockAccount, 1<<15, callflag.States)
 	p.AddMethod(md, desc)
 
+	if neoGoExtensionsEnabled {
+		desc = newDescriptor("getFeatureFlag", smartcontract.AnyType,
+			manifest.NewParameter("name", smartcontract.StringType))
+		md = newMethodAndPrice(p.getFeatureFlag, 1<<15, callflag.ReadStates, config.HFEchidna)
+		p.AddMethod(md, desc)
+
+		desc = newDescriptor("setFeatureFlag", smartcontract.VoidType,
+			manifest.NewParameter("name", smartcontract.StringType),
+			manifest.NewParameter("value", smartcontract.IntegerType))
+		md = newMethodAndPrice(p.setFeatureFlag, 1<<15, callflag.States|callflag.AllowNotify, config.HFEchidna)
+		p.AddMethod(md, desc)
+
+		desc = newDescriptor("deleteFeatureFlag", smartcontract.VoidType,
+			manifest.NewParameter("name", smartcontract.StringType))
+		md = newMethodAndPrice(p.deleteFeatureFlag, 1<<15, callflag.States|callflag.AllowNotify, config.HFEchidna)
+		p.AddMethod(md, desc)
+
+		eDesc := newEventDescriptor(FeatureFlagChangedEventName,
+			manifest.NewParameter("Name", smartcontract.StringType),
+			manifest.NewParameter("Value", smartcontract.AnyType))
+		eMD := newEvent(eDesc, config.HFEchidna)
+		p.AddEvent(eMD)
+	}
+
 	return p
 }
 
@@ -182,6 +216,7 @@ func (p *Policy) Initialize(ic *interop.Context, hf *config.Hardfork, newMD *int
 		storagePrice:       DefaultStoragePrice,
 		attributeFee:       map[transaction.AttrType]uint32{},
 		blockedAccounts:    make([]util.Uint160, 0),
+		featureFlags:       map[string]int64{},
 	}
 	if p.p2pSigExtensionsEnabled {
 		setIntWithKey(p.ID, ic.DAO, []byte{attributeFeePrefix, byte(transaction.NotaryAssistedT)}, defaultNotaryAssistedFee)
@@ -241,6 +276,20 @@ func (p *Policy) fillCacheFromDAO(cache *PolicyCache, d *dao.Simple) error {
 	if fErr != nil {
 		return fmt.Errorf("failed to initialize attribute fees: %w", fErr)
 	}
+
+	cache.featureFlags = make(map[string]int64)
+	d.Seek(p.ID, storage.SeekRange{Prefix: []byte{featureFlagPrefix}}, func(k, v []byte) bool {
+		value := bigint.FromBytes(v)
+		if value == nil || !value.IsInt64() {
+			fErr = fmt.Errorf("unexpected feature flag value: key=%s, value=%s", hex.EncodeToString(k), hex.EncodeToString(v))
+			return false
+		}
+		cache.featureFlags[string(k)] = value.Int64()
+		return true
+	})
+	if fErr != nil {
+		return fmt.Errorf("failed to initialize feature flags: %w", fErr)
+	}
 	return nil
 }
 
@@ -384,12 +433,18 @@ func (p *Policy) setAttributeFee(ic *interop.Context, args []stackitem.Item) sta
 	if !p.NEO.checkCommittee(ic) {
 		panic("invalid committee signature")
 	}
-	setIntWithKey(p.ID, ic.DAO, []byte{attributeFeePrefix, byte(t)}, int64(value))
-	cache := ic.DAO.GetRWCache(p.ID).(*PolicyCache)
-	cache.attributeFee[t] = value
+	p.setAttributeFeeInternal(ic.DAO, t, value)
 	return stackitem.Null{}
 }
 
+// setAttributeFeeInternal stores the fee for the given attribute type without
+// any checks.
+func (p *Policy) setAttributeFeeInternal(d *dao.Simple, t transaction.AttrType, value uint32) {
+	setIntWithKey(p.ID, d, []byte{attributeFeePrefix, byte(t)}, int64(value))
+	cache := d.GetRWCache(p.ID).(*PolicyCache)
+	cache.attributeFee[t] = value
+}
+
 // setFeePerByte is a Policy contract method that sets transaction's fee per byte.
 func (p *Policy) setFeePerByte(ic *interop.Context, args []stackitem.Item) stackitem.Item {
 	value := toBigInt(args[0]).Int64()
@@ -454,6 +509,82 @@ func (p *Policy) unblockAccount(ic *interop.Context, args []stackitem.Item) stac
 	return stackitem.NewBool(true)
 }
 
+// getFeatureFlag is a Policy contract method that returns the value of the
+// given feature flag or Null if it's not set.
+func (p *Policy) getFeatureFlag(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	name := toFeatureFlagName(args[0])
+	v, ok := p.GetFeatureFlagInternal(ic.DAO, name)
+	if !ok {
+		return stackitem.Null{}
+	}
+	return stackitem.NewBigInteger(big.NewInt(v))
+}
+
+// GetFeatureFlagInternal returns the value of the given feature flag and a
+// flag specifying whether it's set. Feature flags are set by the committee
+// and are never used by the contracts or in consensus-critical code, they
+// control various node-side behaviors instead (like relay policies).
+func (p *Policy) GetFeatureFlagInternal(d *dao.Simple, name string) (int64, bool) {
+	cache := d.GetROCache(p.ID).(*PolicyCache)
+	v, ok := cache.featureFlags[name]
+	return v, ok
+}
+
+// setFeatureFlag is a Policy contract method that sets the value of the given
+// feature flag.
+func (p *Policy) setFeatureFlag(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	name := toFeatureFlagName(args[0])
+	value := toInt64(args[1])
+	if !p.NEO.checkCommittee(ic) {
+		panic("invalid committee signature")
+	}
+	key := append([]byte{featureFlagPrefix}, name...)
+	setIntWithKey(p.ID, ic.DAO, key, value)
+	cache := ic.DAO.GetRWCache(p.ID).(*PolicyCache)
+	cache.featureFlags[name] = value
+	ic.AddNotification(p.Hash, FeatureFlagChangedEventName, stackitem.NewArray([]stackitem.Item{
+		stackitem.NewByteArray([]byte(name)),
+		stackitem.NewBigInteger(big.NewInt(value)),
+	}))
+	return stackitem.Null{}
+}
+
+// deleteFeatureFlag is a Policy contract method that removes the given feature
+// flag, nodes use their default behavior for it after that.
+func (p *Policy) deleteFeatureFlag(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	name := toFeatureFlagName(args[0])
+	if !p.NEO.checkCommittee(ic) {
+		panic("invalid committee signature")
+	}
+	if _, ok := p.GetFeatureFlagInternal(ic.DAO, name); !ok {
+		return stackitem.Null{}
+	}
+	ic.DAO.DeleteStorageItem(p.ID, append([]byte{featureFlagPrefix}, name...))
+	cache := ic.DAO.GetRWCache(p.ID).(*PolicyCache)
+	delete(cache.featureFlags, name)
+	ic.AddNotification(p.Hash, FeatureFlagChangedEventName, stackitem.NewArray([]stackitem.Item{
+		stackitem.NewByteArray([]byte(name)),
+		stackitem.Null{},
+	}))
+	return stackitem.Null{}
+}
+
+// toFeatureFlagName converts the given item to a feature flag name checking
+// its validity. Names are limited to maxFeatureFlagNameLength ASCII letters,
+// digits, dots, dashes and underscores.
+func toFeatureFlagName(item stackitem.Item) string {
+	name := toString(item)
+	if len(name) == 0 || len(name) > maxFeatureFlagNameLength {
+		panic(fmt.Errorf("feature flag name length must be between 1 and %d", maxFeatureFlagNameLength))
+	}
+	for _, c := range []byte(name) {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_') {
+			panic(fmt.Errorf("invalid feature flag name %q", name))
+		}
+	}
+	return name
+}
+
 // CheckPolicy checks whether a transaction conforms to the current policy restrictions,
 // like not being signed by a blocked account or not exceeding the block-level system
 // fee limit.
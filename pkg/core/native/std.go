@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"slices"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/mr-tron/base58"
@@ -33,6 +34,16 @@ const (
 
 	// stdMaxInputLength is the maximum input length for string-related methods.
 	stdMaxInputLength = 1024
+
+	// stdMaxTimestamp is the maximum timestamp (in milliseconds) accepted and
+	// returned by time-related methods, it's 9999-12-31T23:59:59.999Z.
+	stdMaxTimestamp = 253402300799999
+	// msPerDay is the number of milliseconds in a day.
+	msPerDay = 24 * 60 * 60 * 1000
+
+	// stdCBORBytePrice is the price of every byte of CBOR data produced by
+	// cborSerialize or given to cborDeserialize (in BaseExecFee units).
+	stdCBORBytePrice = 1 << 2
 )
 
 var (
@@ -42,9 +53,12 @@ var (
 	ErrInvalidFormat = errors.New("invalid format")
 	// ErrTooBigInput is returned when the input exceeds the size limit.
 	ErrTooBigInput = errors.New("input is too big")
+	// ErrInvalidTimestamp is returned when the timestamp (either given or
+	// resulting one) is out of the supported range.
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
 )
 
-func newStd() *Std {
+func newStd(neoGoExtensionsEnabled bool) *Std {
 	s := &Std{ContractMD: *interop.NewContractMD(nativenames.StdLib, stdContractID)}
 	defer s.BuildHFSpecificMD(s.ActiveIn())
 
@@ -165,6 +179,51 @@ func newStd() *Std {
 	md = newMethodAndPrice(s.strLen, 1<<8, callflag.NoneFlag)
 	s.AddMethod(md, desc)
 
+	if neoGoExtensionsEnabled {
+		desc = newDescriptor("timestampToDays", smartcontract.IntegerType,
+			manifest.NewParameter("timestamp", smartcontract.IntegerType))
+		md = newMethodAndPrice(s.timestampToDays, 1<<5, callflag.NoneFlag, config.HFEchidna)
+		s.AddMethod(md, desc)
+
+		desc = newDescriptor("weekday", smartcontract.IntegerType,
+			manifest.NewParameter("timestamp", smartcontract.IntegerType))
+		md = newMethodAndPrice(s.weekday, 1<<5, callflag.NoneFlag, config.HFEchidna)
+		s.AddMethod(md, desc)
+
+		desc = newDescriptor("isLeapYear", smartcontract.BoolType,
+			manifest.NewParameter("year", smartcontract.IntegerType))
+		md = newMethodAndPrice(s.isLeapYear, 1<<5, callflag.NoneFlag, config.HFEchidna)
+		s.AddMethod(md, desc)
+
+		desc = newDescriptor("addDays", smartcontract.IntegerType,
+			manifest.NewParameter("timestamp", smartcontract.IntegerType),
+			manifest.NewParameter("days", smartcontract.IntegerType))
+		md = newMethodAndPrice(s.addDays, 1<<6, callflag.NoneFlag, config.HFEchidna)
+		s.AddMethod(md, desc)
+
+		desc = newDescriptor("addMonths", smartcontract.IntegerType,
+			manifest.NewParameter("timestamp", smartcontract.IntegerType),
+			manifest.NewParameter("months", smartcontract.IntegerType))
+		md = newMethodAndPrice(s.addMonths, 1<<6, callflag.NoneFlag, config.HFEchidna)
+		s.AddMethod(md, desc)
+
+		desc = newDescriptor("addYears", smartcontract.IntegerType,
+			manifest.NewParameter("timestamp", smartcontract.IntegerType),
+			manifest.NewParameter("years", smartcontract.IntegerType))
+		md = newMethodAndPrice(s.addYears, 1<<6, callflag.NoneFlag, config.HFEchidna)
+		s.AddMethod(md, desc)
+
+		desc = newDescriptor("cborSerialize", smartcontract.ByteArrayType,
+			manifest.NewParameter("item", smartcontract.AnyType))
+		md = newMethodAndPrice(s.cborSerialize, 1<<12, callflag.NoneFlag, config.HFEchidna)
+		s.AddMethod(md, desc)
+
+		desc = newDescriptor("cborDeserialize", smartcontract.AnyType,
+			manifest.NewParameter("data", smartcontract.ByteArrayType))
+		md = newMethodAndPrice(s.cborDeserialize, 1<<12, callflag.NoneFlag, config.HFEchidna)
+		s.AddMethod(md, desc)
+	}
+
 	return s
 }
 
@@ -220,6 +279,35 @@ func (s *Std) jsonDeserialize(ic *interop.Context, args []stackitem.Item) stacki
 	return item
 }
 
+func (s *Std) cborSerialize(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	data, err := stackitem.ToCBOR(args[0])
+	if err != nil {
+		panic(err)
+	}
+	if !ic.VM.AddGas(int64(len(data)) * stdCBORBytePrice * ic.BaseExecFee()) {
+		panic("insufficient gas")
+	}
+
+	return stackitem.NewByteArray(data)
+}
+
+func (s *Std) cborDeserialize(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	data, err := args[0].TryBytes()
+	if err != nil {
+		panic(err)
+	}
+	if !ic.VM.AddGas(int64(len(data)) * stdCBORBytePrice * ic.BaseExecFee()) {
+		panic("insufficient gas")
+	}
+
+	item, err := stackitem.FromCBOR(data, stackitem.MaxDeserialized)
+	if err != nil {
+		panic(err)
+	}
+
+	return item
+}
+
 func (s *Std) itoa10(_ *interop.Context, args []stackitem.Item) stackitem.Item {
 	num := toBigInt(args[0])
 	return stackitem.NewByteArray([]byte(num.Text(10)))
@@ -433,6 +521,99 @@ func (s *Std) strLen(_ *interop.Context, args []stackitem.Item) stackitem.Item {
 	return stackitem.NewBigInteger(big.NewInt(int64(utf8.RuneCountInString(str))))
 }
 
+// timestampToDays returns the number of full days passed since the Unix
+// epoch for the given timestamp in milliseconds.
+func (s *Std) timestampToDays(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	ts := s.toTimestamp(args[0])
+	return stackitem.NewBigInteger(big.NewInt(ts / msPerDay))
+}
+
+// weekday returns the day of the week for the given timestamp in milliseconds
+// (0 for Sunday, 1 for Monday and so on).
+func (s *Std) weekday(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	t := time.UnixMilli(s.toTimestamp(args[0])).UTC()
+	return stackitem.NewBigInteger(big.NewInt(int64(t.Weekday())))
+}
+
+func (s *Std) isLeapYear(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	year := toBigInt(args[0])
+	if !year.IsInt64() {
+		panic("year is out of range")
+	}
+	return stackitem.NewBool(isLeap(year.Int64()))
+}
+
+func (s *Std) addDays(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	ts := s.toTimestamp(args[0])
+	days := toBigInt(args[1])
+	// Any shift larger than the whole range is invalid anyway.
+	if !days.IsInt64() || days.Int64() > stdMaxTimestamp/msPerDay || days.Int64() < -stdMaxTimestamp/msPerDay {
+		panic(ErrInvalidTimestamp)
+	}
+	return s.fromTimestamp(ts + days.Int64()*msPerDay)
+}
+
+func (s *Std) addMonths(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	ts := s.toTimestamp(args[0])
+	return s.fromTimestamp(addMonthsAux(ts, toBigInt(args[1]), 1))
+}
+
+func (s *Std) addYears(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	ts := s.toTimestamp(args[0])
+	return s.fromTimestamp(addMonthsAux(ts, toBigInt(args[1]), 12))
+}
+
+// addMonthsAux adds n*mul months to the given timestamp. Unlike
+// [time.Time.AddDate] it doesn't normalize the resulting date, the day is
+// clamped to the last day of the resulting month instead, so that Jan 31 + 1
+// month is Feb 28 (or Feb 29 in leap years) and Feb 29 + 1 year is Feb 28.
+func addMonthsAux(ts int64, n *big.Int, mul int64) int64 {
+	// The supported range is less than 10000 years, so any shift larger than
+	// that is invalid anyway.
+	const maxMonths = 10000 * 12
+	if !n.IsInt64() || n.Int64() > maxMonths/mul || n.Int64() < -maxMonths/mul {
+		panic(ErrInvalidTimestamp)
+	}
+	var (
+		t       = time.UnixMilli(ts).UTC()
+		y, m, d = t.Date()
+		months  = int64(y)*12 + int64(m-1) + n.Int64()*mul
+	)
+	if months < 1970*12 {
+		panic(ErrInvalidTimestamp)
+	}
+	var (
+		ny = int(months / 12)
+		nm = time.Month(months%12 + 1)
+	)
+	// Day 0 of the next month is the last day of this one.
+	d = min(d, time.Date(ny, nm+1, 0, 0, 0, 0, 0, time.UTC).Day())
+	return time.Date(ny, nm, d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC).UnixMilli()
+}
+
+func isLeap(year int64) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// toTimestamp converts the given item to a timestamp in milliseconds, it
+// panics if it's out of the supported range.
+func (s *Std) toTimestamp(item stackitem.Item) int64 {
+	ts := toBigInt(item)
+	if !ts.IsInt64() || ts.Sign() < 0 || ts.Int64() > stdMaxTimestamp {
+		panic(ErrInvalidTimestamp)
+	}
+	return ts.Int64()
+}
+
+// fromTimestamp converts the given timestamp in milliseconds to a stack item,
+// it panics if it's out of the supported range.
+func (s *Std) fromTimestamp(ts int64) stackitem.Item {
+	if ts < 0 || ts > stdMaxTimestamp {
+		panic(ErrInvalidTimestamp)
+	}
+	return stackitem.NewBigInteger(big.NewInt(ts))
+}
+
 // Metadata implements the Contract interface.
 func (s *Std) Metadata() *interop.ContractMD {
 	return &s.ContractMD
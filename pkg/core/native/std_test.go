@@ -7,8 +7,10 @@ import (
 	"math/big"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mr-tron/base58"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	base58neogo "github.com/nspcc-dev/neo-go/pkg/encoding/base58"
@@ -19,7 +21,7 @@ import (
 )
 
 func TestStdLibItoaAtoi(t *testing.T) {
-	s := newStd()
+	s := newStd(true)
 	ic := &interop.Context{VM: vm.New(), DAO: &dao.Simple{}}
 	var actual stackitem.Item
 
@@ -124,7 +126,7 @@ func TestStdLibItoaAtoi(t *testing.T) {
 }
 
 func TestStdLibJSON(t *testing.T) {
-	s := newStd()
+	s := newStd(true)
 	ic := &interop.Context{VM: vm.New()}
 	var actual stackitem.Item
 
@@ -167,8 +169,39 @@ func TestStdLibJSON(t *testing.T) {
 	})
 }
 
+func TestStdLibCBOR(t *testing.T) {
+	s := newStd(true)
+	ic := &interop.Context{VM: vm.New()}
+
+	item := stackitem.NewMapWithValue([]stackitem.MapElement{
+		{Key: stackitem.Make("key"), Value: stackitem.Make([]stackitem.Item{stackitem.Make(-1), stackitem.Null{}})},
+	})
+	var actual stackitem.Item
+	require.NotPanics(t, func() {
+		actual = s.cborSerialize(ic, []stackitem.Item{item})
+	})
+	require.Equal(t, stackitem.Make([]byte{0xa1, 0x43, 'k', 'e', 'y', 0x82, 0x20, 0xf6}), actual)
+
+	require.NotPanics(t, func() {
+		actual = s.cborDeserialize(ic, []stackitem.Item{actual})
+	})
+	require.Equal(t, item, actual)
+
+	t.Run("bad", func(t *testing.T) {
+		require.Panics(t, func() {
+			_ = s.cborSerialize(ic, []stackitem.Item{stackitem.NewInterop(nil)})
+		})
+		require.Panics(t, func() {
+			_ = s.cborDeserialize(ic, []stackitem.Item{stackitem.Make([]byte{0x9f, 0xff})})
+		})
+		require.Panics(t, func() {
+			_ = s.cborDeserialize(ic, []stackitem.Item{stackitem.NewInterop(nil)})
+		})
+	})
+}
+
 func TestStdLibEncodeDecode(t *testing.T) {
-	s := newStd()
+	s := newStd(true)
 	original := []byte("my pretty string")
 	encoded64 := base64.StdEncoding.EncodeToString(original)
 	encoded58 := base58.Encode(original)
@@ -259,7 +292,7 @@ func TestStdLibEncodeDecode(t *testing.T) {
 }
 
 func TestStdLibSerialize(t *testing.T) {
-	s := newStd()
+	s := newStd(true)
 	ic := &interop.Context{VM: vm.New(), DAO: &dao.Simple{}}
 
 	t.Run("recursive", func(t *testing.T) {
@@ -302,7 +335,7 @@ func TestStdLibSerialize(t *testing.T) {
 }
 
 func TestStdLibSerializeDeserialize(t *testing.T) {
-	s := newStd()
+	s := newStd(true)
 	ic := &interop.Context{VM: vm.New(), DAO: &dao.Simple{}}
 	var actual stackitem.Item
 
@@ -389,7 +422,7 @@ func TestStdLibSerializeDeserialize(t *testing.T) {
 }
 
 func TestMemoryCompare(t *testing.T) {
-	s := newStd()
+	s := newStd(true)
 	ic := &interop.Context{VM: vm.New(), DAO: &dao.Simple{}}
 
 	check := func(t *testing.T, result int64, s1, s2 string) {
@@ -424,7 +457,7 @@ func TestMemoryCompare(t *testing.T) {
 }
 
 func TestMemorySearch(t *testing.T) {
-	s := newStd()
+	s := newStd(true)
 	ic := &interop.Context{VM: vm.New()}
 
 	check := func(t *testing.T, result int64, args ...any) {
@@ -516,7 +549,7 @@ func TestMemorySearch(t *testing.T) {
 }
 
 func TestStringSplit(t *testing.T) {
-	s := newStd()
+	s := newStd(true)
 	ic := &interop.Context{VM: vm.New()}
 
 	check := func(t *testing.T, result []string, str, sep string, remove any) {
@@ -560,7 +593,7 @@ func TestStringSplit(t *testing.T) {
 }
 
 func TestStd_StrLen(t *testing.T) {
-	s := newStd()
+	s := newStd(true)
 	ic := &interop.Context{VM: vm.New()}
 
 	check := func(t *testing.T, expected int64, str string) {
@@ -580,3 +613,77 @@ func TestStd_StrLen(t *testing.T) {
 	check(t, 1, bad)
 	check(t, 3, bad+"ab")
 }
+
+func TestStd_Time(t *testing.T) {
+	s := newStd(true)
+	ic := &interop.Context{VM: vm.New()}
+
+	ts := func(t *testing.T, v string) int64 {
+		tm, err := time.Parse(time.RFC3339Nano, v)
+		require.NoError(t, err)
+		return tm.UnixMilli()
+	}
+	call := func(f func(*interop.Context, []stackitem.Item) stackitem.Item, args ...any) stackitem.Item {
+		items := make([]stackitem.Item, len(args))
+		for i := range args {
+			items[i] = stackitem.Make(args[i])
+		}
+		return f(ic, items)
+	}
+	checkTS := func(t *testing.T, expected string, actual stackitem.Item) {
+		require.Equal(t, stackitem.Make(ts(t, expected)), actual)
+	}
+
+	t.Run("timestampToDays", func(t *testing.T) {
+		require.Equal(t, stackitem.Make(0), call(s.timestampToDays, 0))
+		require.Equal(t, stackitem.Make(0), call(s.timestampToDays, msPerDay-1))
+		require.Equal(t, stackitem.Make(1), call(s.timestampToDays, msPerDay))
+		require.Equal(t, stackitem.Make(19723), call(s.timestampToDays, ts(t, "2024-01-01T12:00:00Z")))
+	})
+	t.Run("weekday", func(t *testing.T) {
+		require.Equal(t, stackitem.Make(4), call(s.weekday, 0)) // Thursday.
+		require.Equal(t, stackitem.Make(0), call(s.weekday, ts(t, "2024-03-03T23:59:59.999Z")))
+		require.Equal(t, stackitem.Make(1), call(s.weekday, ts(t, "2024-03-04T00:00:00Z")))
+	})
+	t.Run("isLeapYear", func(t *testing.T) {
+		for year, leap := range map[int64]bool{1900: false, 2000: true, 2023: false, 2024: true, 2100: false} {
+			require.Equal(t, stackitem.NewBool(leap), call(s.isLeapYear, year), year)
+		}
+	})
+	t.Run("addDays", func(t *testing.T) {
+		checkTS(t, "2024-03-01T10:20:30.400Z", call(s.addDays, ts(t, "2024-02-28T10:20:30.400Z"), 2))
+		checkTS(t, "2023-12-31T00:00:00Z", call(s.addDays, ts(t, "2024-01-01T00:00:00Z"), -1))
+	})
+	t.Run("addMonths", func(t *testing.T) {
+		checkTS(t, "2024-02-29T01:02:03Z", call(s.addMonths, ts(t, "2024-01-31T01:02:03Z"), 1))
+		checkTS(t, "2023-02-28T01:02:03Z", call(s.addMonths, ts(t, "2023-01-31T01:02:03Z"), 1))
+		checkTS(t, "2023-11-30T00:00:00Z", call(s.addMonths, ts(t, "2024-03-31T00:00:00Z"), -4))
+		checkTS(t, "2025-01-15T00:00:00Z", call(s.addMonths, ts(t, "2024-12-15T00:00:00Z"), 1))
+	})
+	t.Run("addYears", func(t *testing.T) {
+		checkTS(t, "2025-02-28T00:00:00Z", call(s.addYears, ts(t, "2024-02-29T00:00:00Z"), 1))
+		checkTS(t, "2028-02-29T00:00:00Z", call(s.addYears, ts(t, "2024-02-29T00:00:00Z"), 4))
+		checkTS(t, "2020-06-01T00:00:00Z", call(s.addYears, ts(t, "2024-06-01T00:00:00Z"), -4))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for name, f := range map[string]func(){
+			"negative timestamp":  func() { call(s.weekday, -1) },
+			"too big timestamp":   func() { call(s.timestampToDays, stdMaxTimestamp+1) },
+			"before epoch":        func() { call(s.addDays, 0, -1) },
+			"after max":           func() { call(s.addDays, stdMaxTimestamp, 1) },
+			"huge days":           func() { call(s.addDays, 0, big.NewInt(math.MaxInt64)) },
+			"months before epoch": func() { call(s.addMonths, 0, -1) },
+			"huge months":         func() { call(s.addMonths, 0, new(big.Int).Lsh(big.NewInt(1), 64)) },
+			"years after max":     func() { call(s.addYears, ts(t, "2024-01-01T00:00:00Z"), 8000) },
+		} {
+			require.PanicsWithError(t, ErrInvalidTimestamp.Error(), f, name)
+		}
+	})
+	t.Run("Echidna", func(t *testing.T) {
+		for _, hf := range []config.Hardfork{config.HFCockatrice, config.HFEchidna} {
+			md := s.HFSpecificContractMD(&hf)
+			_, ok := md.GetMethod("addMonths", 2)
+			require.Equal(t, hf == config.HFEchidna, ok)
+		}
+	})
+}
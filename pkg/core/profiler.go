@@ -0,0 +1,31 @@
+package core
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/blockprof"
+)
+
+// blockProfiler is a wrapper allowing to store blockprof.Profiler in an atomic
+// pointer.
+type blockProfiler struct {
+	blockprof.Profiler
+}
+
+// SetBlockProfiler attaches the given profiler to the Blockchain, nil
+// detaches the current one. Profiler methods are called synchronously, so
+// they must not block.
+func (bc *Blockchain) SetBlockProfiler(p blockprof.Profiler) {
+	if p == nil {
+		bc.profiler.Store(nil)
+		return
+	}
+	bc.profiler.Store(&blockProfiler{p})
+}
+
+// getBlockProfiler returns the current block profiler or nil if there is none.
+func (bc *Blockchain) getBlockProfiler() blockprof.Profiler {
+	p := bc.profiler.Load()
+	if p == nil {
+		return nil
+	}
+	return p.Profiler
+}
@@ -1,6 +1,10 @@
 package core
 
 import (
+	"github.com/nspcc-dev/neo-go/pkg/core/contractstats"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/validatorstats"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -38,6 +42,75 @@ var (
 			Namespace: "neogo",
 		},
 	)
+	// contractCalls prometheus metric.
+	contractCalls = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Number of contract calls in the last statistics period",
+			Name:      "contract_calls",
+			Namespace: "neogo",
+		},
+		[]string{"contract"},
+	)
+	// contractGasConsumed prometheus metric.
+	contractGasConsumed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "GAS consumed by transactions calling the contract in the last statistics period",
+			Name:      "contract_gas_consumed",
+			Namespace: "neogo",
+		},
+		[]string{"contract"},
+	)
+	// contractFaultRate prometheus metric.
+	contractFaultRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Share of FAULTed transactions calling the contract in the last statistics period",
+			Name:      "contract_fault_rate",
+			Namespace: "neogo",
+		},
+		[]string{"contract"},
+	)
+	// validatorProducedBlocks prometheus metric.
+	validatorProducedBlocks = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Number of blocks produced by the validator in the statistics window",
+			Name:      "validator_produced_blocks",
+			Namespace: "neogo",
+		},
+		[]string{"validator"},
+	)
+	// validatorMissedViews prometheus metric.
+	validatorMissedViews = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Number of views missed by the validator as the primary in the statistics window",
+			Name:      "validator_missed_views",
+			Namespace: "neogo",
+		},
+		[]string{"validator"},
+	)
+	// boltDBFileSize prometheus metric.
+	boltDBFileSize = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "BoltDB file size in bytes",
+			Name:      "boltdb_file_size_bytes",
+			Namespace: "neogo",
+		},
+	)
+	// boltDBFreePages prometheus metric.
+	boltDBFreePages = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of free and pending BoltDB pages",
+			Name:      "boltdb_free_pages",
+			Namespace: "neogo",
+		},
+	)
+	// boltDBFragmentation prometheus metric.
+	boltDBFragmentation = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Share of BoltDB file occupied by free pages",
+			Name:      "boltdb_fragmentation_ratio",
+			Namespace: "neogo",
+		},
+	)
 )
 
 func init() {
@@ -46,6 +119,14 @@ func init() {
 		persistedHeight,
 		headerHeight,
 		mempoolUnsortedTx,
+		contractCalls,
+		contractGasConsumed,
+		contractFaultRate,
+		validatorProducedBlocks,
+		validatorMissedViews,
+		boltDBFileSize,
+		boltDBFreePages,
+		boltDBFragmentation,
 	)
 }
 
@@ -65,3 +146,36 @@ func updateBlockHeightMetric(bHeight uint32) {
 func updateMempoolMetrics(unsortedTxnLen int) {
 	mempoolUnsortedTx.Set(float64(unsortedTxnLen))
 }
+
+// updateContractStatsMetrics replaces contract statistics metrics with the
+// given period data.
+func updateContractStatsMetrics(p *contractstats.Period) {
+	contractCalls.Reset()
+	contractGasConsumed.Reset()
+	contractFaultRate.Reset()
+	for h, s := range p.Contracts {
+		label := h.StringLE()
+		contractCalls.WithLabelValues(label).Set(float64(s.Calls))
+		contractGasConsumed.WithLabelValues(label).Set(float64(s.GasConsumed) / native.GASFactor)
+		contractFaultRate.WithLabelValues(label).Set(s.FaultRate())
+	}
+}
+
+// updateValidatorStatsMetrics replaces validator statistics metrics with the
+// given window data.
+func updateValidatorStatsMetrics(w *validatorstats.Window) {
+	validatorProducedBlocks.Reset()
+	validatorMissedViews.Reset()
+	for _, s := range w.Validators {
+		label := s.Key.StringCompressed()
+		validatorProducedBlocks.WithLabelValues(label).Set(float64(s.Produced))
+		validatorMissedViews.WithLabelValues(label).Set(float64(s.Missed))
+	}
+}
+
+// updateBoltDBMetrics updates BoltDB file usage metrics.
+func updateBoltDBMetrics(s storage.BoltDBStats) {
+	boltDBFileSize.Set(float64(s.FileSize))
+	boltDBFreePages.Set(float64(s.FreePages + s.PendingPages))
+	boltDBFragmentation.Set(s.FragmentationRatio())
+}
@@ -34,6 +34,16 @@ type ContainedNotificationEvent struct {
 	NotificationEvent
 }
 
+// RejectedBlock is an event describing a block that has failed to be stored
+// after its execution results were streamed to subscribers (see
+// StreamExecutions ledger setting). All execution results, notifications and
+// transactions received for this block before this event must be discarded,
+// the block can be processed again later.
+type RejectedBlock struct {
+	Hash  util.Uint256 `json:"hash"`
+	Index uint32       `json:"index"`
+}
+
 // EncodeBinary implements the Serializable interface.
 func (ne *NotificationEvent) EncodeBinary(w *io.BinWriter) {
 	ne.EncodeBinaryWithContext(w, stackitem.NewSerializationContext())
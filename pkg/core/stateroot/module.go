@@ -102,6 +102,15 @@ func (s *Module) FindStates(root util.Uint256, prefix, start []byte, maxNum int)
 // such item is found in the storage). Traversal process is stopped when `false`
 // is returned from `cont`.
 func (s *Module) SeekStates(root util.Uint256, prefix []byte, cont func(k, v []byte) bool) {
+	s.SeekStatesRange(root, storage.SeekRange{Prefix: prefix}, cont)
+}
+
+// SeekStatesRange is similar to SeekStates, but it allows to specify the
+// starting and ending keys (relative to the prefix) along with the traversal
+// direction via the given storage.SeekRange. `rng.Prefix` is expected to
+// consist of contract ID and the desired storage items prefix, SearchDepth is
+// ignored.
+func (s *Module) SeekStatesRange(root util.Uint256, rng storage.SeekRange, cont func(k, v []byte) bool) {
 	// Allow accessing old values, it's RO thing.
 	store := mpt.NewTrieStore(root, s.mode&^mpt.ModeGCFlag, storage.NewMemCachedStore(s.Store))
 
@@ -109,11 +118,12 @@ func (s *Module) SeekStates(root util.Uint256, prefix []byte, cont func(k, v []b
 	// storage.STStorage prefix is a stub that will be stripped by the
 	// TrieStore.Seek while performing MPT traversal and isn't actually relevant
 	// here.
-	key := make([]byte, len(prefix)+1)
+	key := make([]byte, len(rng.Prefix)+1)
 	key[0] = byte(storage.STStorage)
-	copy(key[1:], prefix)
+	copy(key[1:], rng.Prefix)
+	rng.Prefix = key
 
-	store.Seek(storage.SeekRange{Prefix: key}, func(k, v []byte) bool {
+	store.Seek(rng, func(k, v []byte) bool {
 		// Cut the prefix to match the Blockchain's SeekStorage behaviour.
 		return cont(k[len(key):], v)
 	})
@@ -126,6 +136,16 @@ func (s *Module) GetStateProof(root util.Uint256, key []byte) ([][]byte, error)
 	return tr.GetProof(key)
 }
 
+// GetRangeProof returns up to maxNum key-value pairs matching the prefix and
+// located after the `from` key (if it's not nil) in the MPT with the
+// specified root along with the proof of the whole range, see
+// mpt.Trie.GetRangeProof for details.
+func (s *Module) GetRangeProof(root util.Uint256, prefix, from []byte, maxNum int) ([]storage.KeyValue, [][]byte, error) {
+	// Allow accessing old values, it's RO thing.
+	tr := mpt.NewTrie(mpt.NewHashNode(root), s.mode&^mpt.ModeGCFlag, storage.NewMemCachedStore(s.Store))
+	return tr.GetRangeProof(prefix, from, maxNum)
+}
+
 // GetStateRoot returns state root for a given height.
 func (s *Module) GetStateRoot(height uint32) (*state.MPTRoot, error) {
 	return s.getStateRoot(makeStateRootKey(height))
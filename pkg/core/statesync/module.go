@@ -10,10 +10,14 @@ includes the following stages:
 2. Fetching MPT nodes for height P stating from the corresponding state root.
 3. Fetching blocks starting from height P-MaxTraceableBlocks (or 0) up to P.
 
-Steps 2 and 3 are being performed in parallel. Once all the data are collected
-and stored in the db, an atomic state jump is occurred to the state sync point P.
-Further node operation process is performed using standard sync mechanism until
-the node reaches synchronised state.
+Steps 2 and 3 are being performed in parallel. MPT nodes are requested from
+multiple peers at once, every unknown node is requested from a single peer
+unless the request times out. Received nodes are stored in batches, so the
+process can be resumed after node restart from the same point (the set of
+unknown nodes is restored by traversing the partially stored MPT). Once all
+the data are collected and stored in the db, an atomic state jump is occurred
+to the state sync point P. Further node operation process is performed using
+standard sync mechanism until the node reaches synchronised state.
 */
 package statesync
 
@@ -22,6 +26,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -60,6 +65,10 @@ const (
 	blocksSynced
 )
 
+// mptRequestTimeout is the time after which MPT node requested from some peer
+// can be requested again (from any peer) if it's still unknown.
+const mptRequestTimeout = 10 * time.Second
+
 // Ledger is the interface required from Blockchain for Module to operate.
 type Ledger interface {
 	AddHeaders(...*block.Header) error
@@ -366,6 +375,29 @@ func (s *Module) AddMPTNodes(nodes [][]byte) error {
 		return errors.New("MPT nodes were not requested")
 	}
 
+	// All changes made by the batch are persisted at once, so that the
+	// stored MPT is always consistent with the pool if the node is stopped.
+	cache := s.dao.GetPrivate()
+	s.billet.Store = cache.Store
+	err := s.restoreNodes(nodes)
+	s.billet.Store = s.dao.Store
+	if _, pErr := cache.Persist(); pErr != nil {
+		return fmt.Errorf("failed to persist MPT nodes: %w", pErr)
+	}
+	if err != nil {
+		return err
+	}
+	if s.mptpool.Count() == 0 {
+		s.syncStage |= mptSynced
+		s.log.Info("MPT is in sync",
+			zap.Uint32("height", s.syncPoint))
+		s.checkSyncIsCompleted()
+	}
+	return nil
+}
+
+// restoreNodes decodes the given MPT nodes and restores them.
+func (s *Module) restoreNodes(nodes [][]byte) error {
 	for _, nBytes := range nodes {
 		var n mpt.NodeObject
 		r := io.NewBinReaderFromBuf(nBytes)
@@ -378,12 +410,6 @@ func (s *Module) AddMPTNodes(nodes [][]byte) error {
 			return err
 		}
 	}
-	if s.mptpool.Count() == 0 {
-		s.syncStage |= mptSynced
-		s.log.Info("MPT is in sync",
-			zap.Uint32("height", s.syncPoint))
-		s.checkSyncIsCompleted()
-	}
 	return nil
 }
 
@@ -508,3 +534,14 @@ func (s *Module) GetUnknownMPTNodesBatch(limit int) []util.Uint256 {
 
 	return s.mptpool.GetBatch(limit)
 }
+
+// RequestMPTNodesBatch returns set of currently unknown MPT nodes (`limit` at
+// max) that are not being requested already. Returned nodes are considered to
+// be requested, they're not returned again until either they're received or
+// the request times out.
+func (s *Module) RequestMPTNodesBatch(limit int) []util.Uint256 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.mptpool.GetBatchToRequest(limit, mptRequestTimeout)
+}
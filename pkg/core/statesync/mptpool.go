@@ -4,21 +4,25 @@ import (
 	"bytes"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
 // Pool stores unknown MPT nodes along with the corresponding paths (single node is
-// allowed to have multiple MPT paths).
+// allowed to have multiple MPT paths). It also tracks the time of the last request
+// for every node to avoid requesting the same nodes from different peers.
 type Pool struct {
-	lock   sync.RWMutex
-	hashes map[util.Uint256][][]byte
+	lock      sync.RWMutex
+	hashes    map[util.Uint256][][]byte
+	requested map[util.Uint256]time.Time
 }
 
 // NewPool returns new MPT node hashes pool.
 func NewPool() *Pool {
 	return &Pool{
-		hashes: make(map[util.Uint256][][]byte),
+		hashes:    make(map[util.Uint256][][]byte),
+		requested: make(map[util.Uint256]time.Time),
 	}
 }
 
@@ -69,12 +73,38 @@ func (mp *Pool) GetBatch(limit int) []util.Uint256 {
 	return result
 }
 
+// GetBatchToRequest returns set of unknown MPT nodes hashes (`limit` at max)
+// that were never requested or were requested more than `timeout` ago. Returned
+// hashes are marked as requested, so concurrent calls never return the same
+// node until the timeout expires or the node is removed from the pool.
+func (mp *Pool) GetBatchToRequest(limit int, timeout time.Duration) []util.Uint256 {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	var (
+		now    = time.Now()
+		result = make([]util.Uint256, 0, min(limit, len(mp.hashes)))
+	)
+	for h := range mp.hashes {
+		if len(result) == limit {
+			break
+		}
+		if t, ok := mp.requested[h]; ok && now.Sub(t) < timeout {
+			continue
+		}
+		mp.requested[h] = now
+		result = append(result, h)
+	}
+	return result
+}
+
 // Remove removes MPT node from the pool by the specified hash.
 func (mp *Pool) Remove(hash util.Uint256) {
 	mp.lock.Lock()
 	defer mp.lock.Unlock()
 
 	delete(mp.hashes, hash)
+	delete(mp.requested, hash)
 }
 
 // Add adds path to the set of paths for the specified node.
@@ -100,6 +130,7 @@ func (mp *Pool) Update(remove map[util.Uint256][][]byte, add map[util.Uint256][]
 		}
 		if len(old) == 0 {
 			delete(mp.hashes, h)
+			delete(mp.requested, h)
 		} else {
 			mp.hashes[h] = old
 		}
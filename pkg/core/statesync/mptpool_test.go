@@ -2,7 +2,9 @@ package statesync
 
 import (
 	"encoding/hex"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -104,6 +106,28 @@ func TestPool_GetBatch(t *testing.T) {
 	})
 }
 
+func TestPool_GetBatchToRequest(t *testing.T) {
+	mp := NewPool()
+	for range 5 {
+		mp.Add(random.Uint256(), []byte{0x01})
+	}
+
+	b1 := mp.GetBatchToRequest(3, time.Minute)
+	require.Equal(t, 3, len(b1))
+	b2 := mp.GetBatchToRequest(3, time.Minute)
+	require.Equal(t, 2, len(b2))
+	require.Empty(t, mp.GetBatchToRequest(3, time.Minute))
+	all := slices.Concat(b1, b2)
+	slices.SortFunc(all, util.Uint256.Compare)
+	require.Equal(t, 5, len(slices.Compact(all)))
+
+	// Removed nodes are not returned, the rest are requested again after timeout.
+	mp.Remove(b1[0])
+	mp.Update(map[util.Uint256][][]byte{b1[1]: {{0x01}}}, map[util.Uint256][][]byte{b1[1]: {{0x01}}})
+	require.Equal(t, []util.Uint256{b1[1]}, mp.GetBatchToRequest(3, time.Minute))
+	require.Equal(t, 4, len(mp.GetBatchToRequest(5, 0)))
+}
+
 func TestPool_UpdateUsingSliceFromPool(t *testing.T) {
 	mp := NewPool()
 	p1, _ := hex.DecodeString("0f0a0f0f0f0f0f0f0104020b02080c0a06050e070b050404060206060d07080602030b04040b050e040406030f0708060c05")
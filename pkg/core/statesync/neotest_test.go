@@ -400,7 +400,13 @@ func TestStateSyncModule_RestoreBasicChain(t *testing.T) {
 		})
 		require.NoError(t, err)
 		for {
-			need := module.GetUnknownMPTNodesBatch(10)
+			// Batches requested from different peers never overlap.
+			need := module.RequestMPTNodesBatch(10)
+			other := module.RequestMPTNodesBatch(10)
+			for _, h := range other {
+				require.NotContains(t, need, h)
+			}
+			need = append(need, other...)
 			if len(need) == 0 {
 				break
 			}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
@@ -18,18 +19,53 @@ var Bucket = []byte("DB")
 // BoltDBStore it is the storage implementation for storing and retrieving
 // blockchain data.
 type BoltDBStore struct {
-	db *bbolt.DB
+	// lock protects db from being replaced by Compact while in use.
+	lock sync.RWMutex
+	// wlock blocks writers while Compact copies the data.
+	wlock sync.Mutex
+	db    *bbolt.DB
+	opts  *bbolt.Options
+}
+
+// BoltDBStats contains BoltDB file usage statistics.
+type BoltDBStats struct {
+	// FileSize is the size of the database file in bytes.
+	FileSize int64
+	// PageSize is the size of the database page in bytes.
+	PageSize int
+	// FreePages is the number of free pages that can be reused for new data.
+	FreePages int
+	// PendingPages is the number of freed pages that can't be reused yet
+	// because they're still used by some read transactions.
+	PendingPages int
+	// FreeBytes is the total size of free and pending pages in bytes.
+	FreeBytes int64
+}
+
+// FragmentationRatio returns the share of the database file occupied by free
+// and pending pages, this space can be reclaimed with compaction.
+func (s BoltDBStats) FragmentationRatio() float64 {
+	if s.FileSize == 0 {
+		return 0
+	}
+	return float64(s.FreeBytes) / float64(s.FileSize)
 }
 
 // defaultOpenTimeout is the default timeout for performing flock on a bbolt database.
 // bbolt does retries every 50ms during this interval.
 const defaultOpenTimeout = 1 * time.Second
 
+// boltFileMode is the file mode used for BoltDB files.
+const boltFileMode = os.FileMode(0600) // should be exposed via BoltDBOptions if anything needed
+
+// compactTxMaxSize is the maximum amount of data copied in a single
+// transaction during compaction.
+const compactTxMaxSize = 64 * 1024 * 1024
+
 // NewBoltDBStore returns a new ready to use BoltDB storage with created bucket.
 func NewBoltDBStore(cfg dbconfig.BoltDBOptions) (*BoltDBStore, error) {
 	cp := *bbolt.DefaultOptions // Do not change bbolt's global variable.
 	opts := &cp
-	fileMode := os.FileMode(0600) // should be exposed via BoltDBOptions if anything needed
 	fileName := cfg.FilePath
 	if cfg.ReadOnly {
 		opts.ReadOnly = true
@@ -40,7 +76,7 @@ func NewBoltDBStore(cfg dbconfig.BoltDBOptions) (*BoltDBStore, error) {
 	}
 	opts.Timeout = defaultOpenTimeout
 
-	db, err := bbolt.Open(fileName, fileMode, opts)
+	db, err := bbolt.Open(fileName, boltFileMode, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open BoltDB instance: %w", err)
 	}
@@ -70,11 +106,13 @@ func NewBoltDBStore(cfg dbconfig.BoltDBOptions) (*BoltDBStore, error) {
 		return nil, err
 	}
 
-	return &BoltDBStore{db: db}, nil
+	return &BoltDBStore{db: db, opts: opts}, nil
 }
 
 // Get implements the Store interface.
 func (s *BoltDBStore) Get(key []byte) (val []byte, err error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	err = s.db.View(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(Bucket)
 		// Value from Get is only valid for the lifetime of transaction, #1482
@@ -91,6 +129,10 @@ func (s *BoltDBStore) Get(key []byte) (val []byte, err error) {
 func (s *BoltDBStore) PutChangeSet(puts map[string][]byte, stores map[string][]byte) error {
 	var err error
 
+	s.wlock.Lock()
+	defer s.wlock.Unlock()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	return s.db.Update(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(Bucket)
 		for _, m := range []map[string][]byte{puts, stores} {
@@ -111,6 +153,10 @@ func (s *BoltDBStore) PutChangeSet(puts map[string][]byte, stores map[string][]b
 
 // SeekGC implements the Store interface.
 func (s *BoltDBStore) SeekGC(rng SeekRange, keep func(k, v []byte) bool) error {
+	s.wlock.Lock()
+	defer s.wlock.Unlock()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	return boltSeek(s.db.Update, rng, func(c *bbolt.Cursor, k, v []byte) (bool, error) {
 		if !keep(k, v) {
 			if err := c.Delete(); err != nil {
@@ -123,6 +169,8 @@ func (s *BoltDBStore) SeekGC(rng SeekRange, keep func(k, v []byte) bool) error {
 
 // Seek implements the Store interface.
 func (s *BoltDBStore) Seek(rng SeekRange, f func(k, v []byte) bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	err := boltSeek(s.db.View, rng, func(_ *bbolt.Cursor, k, v []byte) (bool, error) {
 		return f(k, v), nil
 	})
@@ -133,6 +181,16 @@ func (s *BoltDBStore) Seek(rng SeekRange, f func(k, v []byte) bool) {
 
 func boltSeek(txopener func(func(*bbolt.Tx) error) error, rng SeekRange, f func(c *bbolt.Cursor, k, v []byte) (bool, error)) error {
 	rang := seekRangeToPrefixes(rng)
+	// Range bounds are inclusive here, so End is to be checked separately.
+	pastEnd := func(k []byte) bool {
+		if len(rng.End) == 0 {
+			return false
+		}
+		if rng.Backwards {
+			return bytes.Compare(k, rang.Start) < 0
+		}
+		return bytes.Compare(k, rang.Limit) >= 0
+	}
 	return txopener(func(tx *bbolt.Tx) error {
 		var (
 			k, v []byte
@@ -155,7 +213,7 @@ func boltSeek(txopener func(func(*bbolt.Tx) error) error, rng SeekRange, f func(
 			next = c.Prev
 		}
 
-		for ; k != nil && bytes.HasPrefix(k, rng.Prefix) && (len(rang.Limit) == 0 || bytes.Compare(k, rang.Limit) <= 0); k, v = next() {
+		for ; k != nil && bytes.HasPrefix(k, rng.Prefix) && (len(rang.Limit) == 0 || bytes.Compare(k, rang.Limit) <= 0) && !pastEnd(k); k, v = next() {
 			cont, err := f(c, k, v)
 			if err != nil {
 				return err
@@ -168,7 +226,82 @@ func boltSeek(txopener func(func(*bbolt.Tx) error) error, rng SeekRange, f func(
 	})
 }
 
+// Stats returns the current database file usage statistics.
+func (s *BoltDBStore) Stats() (BoltDBStats, error) {
+	var res BoltDBStats
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		res.FileSize = tx.Size()
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+	st := s.db.Stats()
+	res.PageSize = s.db.Info().PageSize
+	res.FreePages = st.FreePageN
+	res.PendingPages = st.PendingPageN
+	res.FreeBytes = int64(st.FreeAlloc)
+	return res, nil
+}
+
+// Compact rewrites the database into a new file without free pages and
+// replaces the current file with it, BoltDB files never shrink otherwise.
+// It can be done while the store is in use: writers are blocked until
+// compaction is finished, while readers are only blocked while the file is
+// being replaced. Compaction requires free disk space for a copy of the live
+// data, the original file is kept intact if anything fails before the
+// replacement.
+func (s *BoltDBStore) Compact() error {
+	if s.opts.ReadOnly {
+		return bbolt.ErrDatabaseReadOnly
+	}
+	s.wlock.Lock()
+	defer s.wlock.Unlock()
+
+	var (
+		path = s.db.Path()
+		tmp  = path + ".compact"
+	)
+	_ = os.Remove(tmp) // Leftover from the previous failed attempt, if any.
+	dst, err := bbolt.Open(tmp, boltFileMode, s.opts)
+	if err != nil {
+		return fmt.Errorf("failed to create compacted DB: %w", err)
+	}
+	err = bbolt.Compact(dst, s.db, compactTxMaxSize)
+	closeErr := dst.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to compact DB: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	err = s.db.Close()
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to close DB: %w", err)
+	}
+	renameErr := os.Rename(tmp, path)
+	s.db, err = bbolt.Open(path, boltFileMode, s.opts)
+	if err != nil {
+		return fmt.Errorf("failed to reopen DB: %w", err)
+	}
+	if renameErr != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to replace DB file: %w", renameErr)
+	}
+	return nil
+}
+
 // Close releases all db resources.
 func (s *BoltDBStore) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	return s.db.Close()
 }
@@ -1,12 +1,14 @@
 package storage
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/bbolt"
 )
@@ -56,3 +58,74 @@ func TestROBoltDB(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "root bucket does not exist"))
 }
+
+func TestBoltDBCompact(t *testing.T) {
+	d := t.TempDir()
+	cfg := dbconfig.BoltDBOptions{FilePath: filepath.Join(d, "test_bolt_db")}
+	store, err := NewBoltDBStore(cfg)
+	require.NoError(t, err)
+
+	var (
+		puts = make(map[string][]byte)
+		dels = make(map[string][]byte)
+	)
+	for i := range 1000 {
+		k := fmt.Sprintf("key%04d", i)
+		puts[k] = make([]byte, 1024)
+		if i%10 != 0 {
+			dels[k] = nil
+		}
+	}
+	require.NoError(t, store.PutChangeSet(puts, nil))
+	require.NoError(t, store.PutChangeSet(dels, nil))
+	// Pending pages are released by subsequent transactions.
+	require.NoError(t, store.PutChangeSet(map[string][]byte{"other": {1}}, nil))
+
+	before, err := store.Stats()
+	require.NoError(t, err)
+	require.NotZero(t, before.PageSize)
+	require.NotZero(t, before.FreePages+before.PendingPages)
+	require.Greater(t, before.FragmentationRatio(), 0.5)
+
+	// Compaction is possible while the store is in use.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 100 {
+			v, err := store.Get([]byte("key0000"))
+			if !assert.NoError(t, err) || !assert.Len(t, v, 1024) {
+				return
+			}
+		}
+	}()
+	require.NoError(t, store.Compact())
+	<-done
+
+	after, err := store.Stats()
+	require.NoError(t, err)
+	require.Less(t, after.FileSize, before.FileSize)
+	require.Less(t, after.FragmentationRatio(), before.FragmentationRatio())
+
+	var count int
+	store.Seek(SeekRange{Prefix: []byte("key")}, func(k, v []byte) bool {
+		count++
+		return true
+	})
+	require.Equal(t, 100, count)
+	require.NoError(t, store.PutChangeSet(map[string][]byte{"new": {2}}, nil))
+	require.NoError(t, store.Close())
+
+	// Compacted DB can be reopened.
+	store, err = NewBoltDBStore(cfg)
+	require.NoError(t, err)
+	v, err := store.Get([]byte("new"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, v)
+	require.NoError(t, store.Close())
+
+	cfg.ReadOnly = true
+	store, err = NewBoltDBStore(cfg)
+	require.NoError(t, err)
+	require.ErrorIs(t, store.Compact(), bbolt.ErrDatabaseReadOnly)
+	require.NoError(t, store.Close())
+}
@@ -11,10 +11,31 @@ type (
 		LevelDBOptions LevelDBOptions `yaml:"LevelDBOptions"`
 		BoltDBOptions  BoltDBOptions  `yaml:"BoltDBOptions"`
 	}
-	// LevelDBOptions configuration for LevelDB.
+	// LevelDBOptions configuration for LevelDB. Zero values of tuning
+	// options mean LevelDB defaults.
 	LevelDBOptions struct {
 		DataDirectoryPath string `yaml:"DataDirectoryPath"`
 		ReadOnly          bool   `yaml:"ReadOnly"`
+		// BloomFilterBitsPerKey is the number of bloom filter bits per key,
+		// 10 is used by default, negative value disables the filter.
+		BloomFilterBitsPerKey int `yaml:"BloomFilterBitsPerKey"`
+		// BlockSize is the (uncompressed) size of table blocks in bytes.
+		BlockSize int `yaml:"BlockSize"`
+		// BlockCacheCapacity is the capacity of the table block cache in bytes.
+		BlockCacheCapacity int `yaml:"BlockCacheCapacity"`
+		// WriteBuffer is the size of the memory table in bytes.
+		WriteBuffer int `yaml:"WriteBuffer"`
+		// CompactionTableSize is the size of tables produced by compaction
+		// in bytes.
+		CompactionTableSize int `yaml:"CompactionTableSize"`
+		// CompactionTotalSize is the total size of tables at level 1 in bytes
+		// (every next level is 10 times bigger).
+		CompactionTotalSize int `yaml:"CompactionTotalSize"`
+		// CompactionL0Trigger is the number of level 0 tables triggering
+		// compaction.
+		CompactionL0Trigger int `yaml:"CompactionL0Trigger"`
+		// DisableSeeksCompaction disables compaction triggered by seeks.
+		DisableSeeksCompaction bool `yaml:"DisableSeeksCompaction"`
 	}
 	// BoltDBOptions configuration for BoltDB.
 	BoltDBOptions struct {
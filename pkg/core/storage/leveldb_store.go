@@ -18,15 +18,17 @@ type LevelDBStore struct {
 	path string
 }
 
+// defaultBloomFilterBitsPerKey is the default number of bloom filter bits per
+// key used for LevelDB.
+const defaultBloomFilterBitsPerKey = 10
+
 // NewLevelDBStore returns a new LevelDBStore object that will
 // initialize the database found at the given path.
 func NewLevelDBStore(cfg dbconfig.LevelDBOptions) (*LevelDBStore, error) {
-	var opts = new(opt.Options) // should be exposed via LevelDBOptions if anything needed
-	if cfg.ReadOnly {
-		opts.ReadOnly = true
-		opts.ErrorIfMissing = true
+	opts, err := levelDBOptions(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LevelDB options: %w", err)
 	}
-	opts.Filter = filter.NewBloomFilter(10)
 	db, err := leveldb.OpenFile(cfg.DataDirectoryPath, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open LevelDB instance: %w", err)
@@ -38,6 +40,42 @@ func NewLevelDBStore(cfg dbconfig.LevelDBOptions) (*LevelDBStore, error) {
 	}, nil
 }
 
+// levelDBOptions converts configuration to LevelDB options.
+func levelDBOptions(cfg dbconfig.LevelDBOptions) (*opt.Options, error) {
+	for name, v := range map[string]int{
+		"BlockSize":           cfg.BlockSize,
+		"BlockCacheCapacity":  cfg.BlockCacheCapacity,
+		"WriteBuffer":         cfg.WriteBuffer,
+		"CompactionTableSize": cfg.CompactionTableSize,
+		"CompactionTotalSize": cfg.CompactionTotalSize,
+		"CompactionL0Trigger": cfg.CompactionL0Trigger,
+	} {
+		if v < 0 {
+			return nil, fmt.Errorf("negative %s: %d", name, v)
+		}
+	}
+	var opts = &opt.Options{
+		BlockSize:              cfg.BlockSize,
+		BlockCacheCapacity:     cfg.BlockCacheCapacity,
+		WriteBuffer:            cfg.WriteBuffer,
+		CompactionTableSize:    cfg.CompactionTableSize,
+		CompactionTotalSize:    cfg.CompactionTotalSize,
+		CompactionL0Trigger:    cfg.CompactionL0Trigger,
+		DisableSeeksCompaction: cfg.DisableSeeksCompaction,
+	}
+	if cfg.ReadOnly {
+		opts.ReadOnly = true
+		opts.ErrorIfMissing = true
+	}
+	switch bits := cfg.BloomFilterBitsPerKey; {
+	case bits == 0:
+		opts.Filter = filter.NewBloomFilter(defaultBloomFilterBitsPerKey)
+	case bits > 0:
+		opts.Filter = filter.NewBloomFilter(bits)
+	}
+	return opts, nil
+}
+
 // Get implements the Store interface.
 func (s *LevelDBStore) Get(key []byte) ([]byte, error) {
 	value, err := s.db.Get(key, nil)
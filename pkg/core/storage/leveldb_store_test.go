@@ -6,6 +6,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
 	"github.com/stretchr/testify/require"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/filter"
 )
 
 func newLevelDBForTesting(t testing.TB) Store {
@@ -43,3 +44,52 @@ func TestROLevelDB(t *testing.T) {
 	putErr := store.PutChangeSet(map[string][]byte{"one": []byte("one")}, nil)
 	require.ErrorIs(t, putErr, leveldb.ErrReadOnly)
 }
+
+func TestLevelDBOptions(t *testing.T) {
+	opts, err := levelDBOptions(dbconfig.LevelDBOptions{})
+	require.NoError(t, err)
+	require.Equal(t, filter.NewBloomFilter(defaultBloomFilterBitsPerKey).Name(), opts.Filter.Name())
+	require.Zero(t, opts.BlockSize)
+
+	opts, err = levelDBOptions(dbconfig.LevelDBOptions{
+		BloomFilterBitsPerKey:  -1,
+		BlockSize:              16 * 1024,
+		BlockCacheCapacity:     64 * 1024 * 1024,
+		WriteBuffer:            32 * 1024 * 1024,
+		CompactionTableSize:    8 * 1024 * 1024,
+		CompactionTotalSize:    80 * 1024 * 1024,
+		CompactionL0Trigger:    8,
+		DisableSeeksCompaction: true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, opts.Filter)
+	require.Equal(t, 16*1024, opts.GetBlockSize())
+	require.Equal(t, 64*1024*1024, opts.GetBlockCacheCapacity())
+	require.Equal(t, 32*1024*1024, opts.GetWriteBuffer())
+	require.Equal(t, 8*1024*1024, opts.GetCompactionTableSize(0))
+	require.Equal(t, int64(80*1024*1024), opts.GetCompactionTotalSize(0))
+	require.Equal(t, 8, opts.GetCompactionL0Trigger())
+	require.True(t, opts.GetDisableSeeksCompaction())
+
+	_, err = levelDBOptions(dbconfig.LevelDBOptions{WriteBuffer: -1})
+	require.Error(t, err)
+
+	// Tuned DB works.
+	opts2 := dbconfig.LevelDBOptions{
+		DataDirectoryPath:     t.TempDir(),
+		BloomFilterBitsPerKey: 16,
+		BlockSize:             1024,
+		WriteBuffer:           1024 * 1024,
+	}
+	store, err := NewLevelDBStore(opts2)
+	require.NoError(t, err)
+	require.NoError(t, store.PutChangeSet(map[string][]byte{"key": []byte("value")}, nil))
+	v, err := store.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), v)
+	require.NoError(t, store.Close())
+
+	opts2.BlockSize = -1
+	_, err = NewLevelDBStore(opts2)
+	require.Error(t, err)
+}
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrReadOnlySnapshot is returned on attempts to change the MemCachedStore
+// snapshot.
+var ErrReadOnlySnapshot = errors.New("snapshot is read-only")
+
+// undoLog contains values that keys had before they were changed in the
+// MemCachedStore after some snapshot was taken, nil value means that the key
+// didn't exist. Logs are chained from older to newer ones, a newer log is
+// started when a snapshot is taken after some changes recorded into the
+// previous one.
+type undoLog struct {
+	vals map[string][]byte
+	next *undoLog
+}
+
+// COWSnapshot is a read-only copy-on-write snapshot of a MemCachedStore. It
+// doesn't copy any data when created, instead MemCachedStore saves old values
+// of keys changed while there are live snapshots, so snapshots are cheap to
+// take and reading from them doesn't block the store for longer than reading
+// from the store itself. COWSnapshot implements Store, so it can be wrapped
+// into a private MemCachedStore for speculative execution, but any changes
+// made to it can't be persisted. It must be closed when it's no longer needed,
+// since the store keeps recording old values until all of its snapshots are
+// closed.
+type COWSnapshot struct {
+	store  *MemCachedStore
+	log    *undoLog
+	closed bool
+}
+
+// Snapshot returns a read-only copy-on-write snapshot of the current
+// MemCachedStore state that is not affected by any subsequent changes made to
+// the store. It can only be done for a shared MemCachedStore.
+func (s *MemCachedStore) Snapshot() *COWSnapshot {
+	if s.private {
+		panic("Snapshot called on private MemCachedStore")
+	}
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if s.undo == nil || len(s.undo.vals) != 0 {
+		l := &undoLog{vals: make(map[string][]byte)}
+		if s.undo != nil {
+			s.undo.next = l
+		}
+		s.undo = l
+	}
+	s.snapshots++
+	return &COWSnapshot{store: s, log: s.undo}
+}
+
+// preserve saves the current value of the key into the undo log if there are
+// live snapshots and the value isn't saved yet. It's supposed to be called
+// with the store write-locked before the key is changed.
+func (s *MemCachedStore) preserve(key string) {
+	if s.undo == nil {
+		return
+	}
+	if _, ok := s.undo.vals[key]; ok {
+		return
+	}
+	v, err := s.get([]byte(key))
+	if err != nil {
+		v = nil
+	}
+	s.undo.vals[key] = v
+}
+
+// undoGet returns the value the key had when the snapshot was taken if the key
+// was changed since then. It's supposed to be called with the store locked.
+func (c *COWSnapshot) undoGet(key string) ([]byte, bool) {
+	for l := c.log; l != nil; l = l.next {
+		if v, ok := l.vals[key]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Get implements the Store interface.
+func (c *COWSnapshot) Get(key []byte) ([]byte, error) {
+	c.store.mut.RLock()
+	defer c.store.mut.RUnlock()
+	if v, ok := c.undoGet(string(key)); ok {
+		if v == nil {
+			return nil, ErrKeyNotFound
+		}
+		return v, nil
+	}
+	return c.store.get(key)
+}
+
+// PutChangeSet implements the Store interface, it always returns
+// ErrReadOnlySnapshot.
+func (c *COWSnapshot) PutChangeSet(map[string][]byte, map[string][]byte) error {
+	return ErrReadOnlySnapshot
+}
+
+// Seek implements the Store interface. Keys changed in the underlying store
+// during Seek are returned with the values they had when the snapshot was
+// taken, but keys deleted from the lower layers of the store during Seek can
+// be missed.
+func (c *COWSnapshot) Seek(rng SeekRange, f func(k, v []byte) bool) {
+	var (
+		s        = c.store
+		isKeyOK  = keyInRange(rng)
+		resolved = make(map[string]struct{})
+	)
+	s.mut.RLock()
+	ps, memRes := s.seekMemSnapshot(rng)
+	for i := range memRes {
+		if v, ok := c.undoGet(string(memRes[i].Key)); ok {
+			memRes[i].Value = v
+			memRes[i].Exists = v != nil
+			resolved[string(memRes[i].Key)] = struct{}{}
+		}
+	}
+	for l := c.log; l != nil; l = l.next {
+		for k, v := range l.vals {
+			if _, ok := resolved[k]; ok || !isKeyOK(k) {
+				continue
+			}
+			memRes = append(memRes, KeyValueExists{
+				KeyValue: KeyValue{Key: []byte(k), Value: v},
+				Exists:   v != nil,
+			})
+			resolved[k] = struct{}{}
+		}
+	}
+	s.mut.RUnlock()
+	performSeek(context.Background(), ps, memRes, rng, false, func(k, v []byte) bool {
+		if _, ok := resolved[string(k)]; !ok {
+			s.mut.RLock()
+			old, changed := c.undoGet(string(k))
+			s.mut.RUnlock()
+			if changed {
+				if old == nil {
+					return true
+				}
+				v = old
+			}
+		}
+		return f(k, v)
+	})
+}
+
+// SeekGC implements the Store interface, it always returns
+// ErrReadOnlySnapshot.
+func (c *COWSnapshot) SeekGC(SeekRange, func(k, v []byte) bool) error {
+	return ErrReadOnlySnapshot
+}
+
+// Close implements the Store interface, it releases the snapshot allowing the
+// store to stop recording old values if there are no other live snapshots.
+// The underlying store is not closed. The snapshot must not be used after
+// Close. It never returns an error and can be called multiple times.
+func (c *COWSnapshot) Close() error {
+	s := c.store
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	s.snapshots--
+	if s.snapshots == 0 {
+		s.undo = nil
+	}
+	return nil
+}
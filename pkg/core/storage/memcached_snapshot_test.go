@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func seekAll(s Store, prefix []byte) []KeyValue {
+	var res []KeyValue
+	s.Seek(SeekRange{Prefix: prefix}, func(k, v []byte) bool {
+		res = append(res, KeyValue{Key: append([]byte{}, k...), Value: append([]byte{}, v...)})
+		return true
+	})
+	return res
+}
+
+func TestMemCachedSnapshot(t *testing.T) {
+	var (
+		ps = NewMemoryStore()
+		s  = NewMemCachedStore(ps)
+		k1 = []byte{byte(STStorage), 1}
+		k2 = []byte{byte(STStorage), 2}
+		k3 = []byte{byte(STStorage), 3}
+		k4 = []byte{byte(STStorage), 4}
+	)
+	require.NoError(t, ps.PutChangeSet(nil, map[string][]byte{string(k1): {1}, string(k2): {2}}))
+	s.Put(k3, []byte{3})
+
+	snap1 := s.Snapshot()
+	initial := seekAll(s, []byte{byte(STStorage)})
+	require.Equal(t, initial, seekAll(snap1, []byte{byte(STStorage)}))
+
+	s.Put(k1, []byte{10})
+	s.Delete(k2)
+	require.NoError(t, s.PutChangeSet(nil, map[string][]byte{string(k4): {4}}))
+	_, err := s.Persist()
+	require.NoError(t, err)
+
+	snap2 := s.Snapshot()
+	s.Put(k4, []byte{40})
+	s.Put(k2, []byte{20})
+
+	checkGet := func(t *testing.T, st Store, key []byte, expected []byte) {
+		v, err := st.Get(key)
+		if expected == nil {
+			require.ErrorIs(t, err, ErrKeyNotFound)
+			return
+		}
+		require.NoError(t, err)
+		require.Equal(t, expected, v)
+	}
+	t.Run("get", func(t *testing.T) {
+		checkGet(t, snap1, k1, []byte{1})
+		checkGet(t, snap1, k2, []byte{2})
+		checkGet(t, snap1, k3, []byte{3})
+		checkGet(t, snap1, k4, nil)
+
+		checkGet(t, snap2, k1, []byte{10})
+		checkGet(t, snap2, k2, nil)
+		checkGet(t, snap2, k3, []byte{3})
+		checkGet(t, snap2, k4, []byte{4})
+
+		checkGet(t, s, k2, []byte{20})
+		checkGet(t, s, k4, []byte{40})
+	})
+	t.Run("seek", func(t *testing.T) {
+		require.Equal(t, initial, seekAll(snap1, []byte{byte(STStorage)}))
+		require.Equal(t, []KeyValue{
+			{Key: k1, Value: []byte{10}},
+			{Key: k3, Value: []byte{3}},
+			{Key: k4, Value: []byte{4}},
+		}, seekAll(snap2, []byte{byte(STStorage)}))
+	})
+	t.Run("seek with concurrent changes", func(t *testing.T) {
+		var res []KeyValue
+		snap1.Seek(SeekRange{Prefix: []byte{byte(STStorage)}}, func(k, v []byte) bool {
+			res = append(res, KeyValue{Key: append([]byte{}, k...), Value: append([]byte{}, v...)})
+			s.Put(k3, []byte{30})
+			return true
+		})
+		require.Equal(t, initial, res)
+	})
+	t.Run("private wrapper", func(t *testing.T) {
+		p := NewPrivateMemCachedStore(snap1)
+		p.Put(k1, []byte{100})
+		checkGet(t, p, k1, []byte{100})
+		checkGet(t, snap1, k1, []byte{1})
+		_, err := p.Persist()
+		require.ErrorIs(t, err, ErrReadOnlySnapshot)
+		require.ErrorIs(t, snap1.SeekGC(SeekRange{}, nil), ErrReadOnlySnapshot)
+	})
+	t.Run("close", func(t *testing.T) {
+		require.NoError(t, snap1.Close())
+		require.NoError(t, snap1.Close())
+		require.NotNil(t, s.undo)
+		require.NoError(t, snap2.Close())
+		require.Nil(t, s.undo)
+		s.Put(k1, []byte{11})
+		require.Nil(t, s.undo)
+	})
+	t.Run("private", func(t *testing.T) {
+		require.Panics(t, func() { NewPrivateMemCachedStore(ps).Snapshot() })
+	})
+}
@@ -18,6 +18,11 @@ type MemCachedStore struct {
 	plock sync.Mutex
 	// Persistent Store.
 	ps Store
+
+	// undo is the newest undo log of live snapshots, nil if there are none.
+	undo *undoLog
+	// snapshots is the number of live snapshots.
+	snapshots int
 }
 
 type (
@@ -92,6 +97,11 @@ func (s *MemCachedStore) runlock() {
 func (s *MemCachedStore) Get(key []byte) ([]byte, error) {
 	s.rlock()
 	defer s.runlock()
+	return s.get(key)
+}
+
+// get is an internal unlocked implementation of Get.
+func (s *MemCachedStore) get(key []byte) ([]byte, error) {
 	m := s.chooseMap(key)
 	if val, ok := m[string(key)]; ok {
 		if val == nil {
@@ -107,6 +117,7 @@ func (s *MemCachedStore) Put(key, value []byte) {
 	newKey := string(key)
 	vcopy := bytes.Clone(value)
 	s.lock()
+	s.preserve(newKey)
 	put(s.chooseMap(key), newKey, vcopy)
 	s.unlock()
 }
@@ -115,6 +126,7 @@ func (s *MemCachedStore) Put(key, value []byte) {
 func (s *MemCachedStore) Delete(key []byte) {
 	newKey := string(key)
 	s.lock()
+	s.preserve(newKey)
 	put(s.chooseMap(key), newKey, nil)
 	s.unlock()
 }
@@ -144,6 +156,14 @@ func (s *MemCachedStore) GetBatch() *MemBatch {
 // PutChangeSet implements the Store interface. Never returns an error.
 func (s *MemCachedStore) PutChangeSet(puts map[string][]byte, stores map[string][]byte) error {
 	s.lock()
+	if s.undo != nil {
+		for k := range puts {
+			s.preserve(k)
+		}
+		for k := range stores {
+			s.preserve(k)
+		}
+	}
 	s.MemoryStore.putChangeSet(puts, stores)
 	s.unlock()
 	return nil
@@ -189,20 +209,18 @@ func (s *MemCachedStore) SeekAsync(ctx context.Context, rng SeekRange, cutPrefix
 // not to hold the lock over MemCachedStore throughout the whole Seek operation.
 // The results of prepareSeekMemSnapshot can be safely used as performSeek arguments.
 func (s *MemCachedStore) prepareSeekMemSnapshot(rng SeekRange) (Store, []KeyValueExists) {
-	var memRes []KeyValueExists
-	sPrefix := string(rng.Prefix)
-	lPrefix := len(sPrefix)
-	sStart := string(rng.Start)
-	lStart := len(sStart)
-	isKeyOK := func(key string) bool {
-		return strings.HasPrefix(key, sPrefix) && (lStart == 0 || strings.Compare(key[lPrefix:], sStart) >= 0)
-	}
-	if rng.Backwards {
-		isKeyOK = func(key string) bool {
-			return strings.HasPrefix(key, sPrefix) && (lStart == 0 || strings.Compare(key[lPrefix:], sStart) <= 0)
-		}
-	}
 	s.rlock()
+	defer s.runlock()
+	return s.seekMemSnapshot(rng)
+}
+
+// seekMemSnapshot is an internal unlocked implementation of
+// prepareSeekMemSnapshot.
+func (s *MemCachedStore) seekMemSnapshot(rng SeekRange) (Store, []KeyValueExists) {
+	var (
+		memRes  []KeyValueExists
+		isKeyOK = keyInRange(rng)
+	)
 	m := s.MemoryStore.chooseMap(rng.Prefix)
 	for k, v := range m {
 		if isKeyOK(k) {
@@ -215,9 +233,28 @@ func (s *MemCachedStore) prepareSeekMemSnapshot(rng SeekRange) (Store, []KeyValu
 			})
 		}
 	}
-	ps := s.ps
-	s.runlock()
-	return ps, memRes
+	return s.ps, memRes
+}
+
+// keyInRange returns a function checking whether the key matches the given
+// SeekRange.
+func keyInRange(rng SeekRange) func(key string) bool {
+	sPrefix := string(rng.Prefix)
+	lPrefix := len(sPrefix)
+	sStart := string(rng.Start)
+	lStart := len(sStart)
+	sEnd := string(rng.End)
+	lEnd := len(sEnd)
+	if rng.Backwards {
+		return func(key string) bool {
+			return strings.HasPrefix(key, sPrefix) && (lStart == 0 || strings.Compare(key[lPrefix:], sStart) <= 0) &&
+				(lEnd == 0 || strings.Compare(key[lPrefix:], sEnd) > 0)
+		}
+	}
+	return func(key string) bool {
+		return strings.HasPrefix(key, sPrefix) && (lStart == 0 || strings.Compare(key[lPrefix:], sStart) >= 0) &&
+			(lEnd == 0 || strings.Compare(key[lPrefix:], sEnd) < 0)
+	}
 }
 
 // performSeek is internal representations of Seek* capable of seeking for the given key
@@ -38,7 +38,13 @@ const (
 	// and the last bit reserved for the state reset process marker (set to 1 on
 	// unfinished state reset and to 0 on unfinished state jump).
 	SYSStateChangeStage KeyPrefix = 0xc4
-	SYSVersion          KeyPrefix = 0xf0
+	// SYSStateResetHeight is used to store the last state reset height
+	// requested by the node configuration.
+	SYSStateResetHeight KeyPrefix = 0xc5
+	// SYSAppLogsPruneHeight is used to store the height of the first block
+	// which application logs are not yet pruned (see AppLogsMaxBlocks).
+	SYSAppLogsPruneHeight KeyPrefix = 0xc6
+	SYSVersion            KeyPrefix = 0xf0
 )
 
 // Executable subtypes.
@@ -62,6 +68,12 @@ type SeekRange struct {
 	// Empty Prefix and empty Start can be combined, which means seeking
 	// through all keys in the DB, but see the Prefix's comment.
 	Start []byte
+	// End denotes value appended to the Prefix to stop Seek at, the key
+	// matching it is not included into the result. Ascending Seek only
+	// returns keys less than Prefix+End and descending Seek only returns
+	// keys greater than Prefix+End. Empty End means seeking through all
+	// the remaining keys with matching Prefix.
+	End []byte
 	// Backwards denotes whether Seek direction should be reversed, i.e.
 	// whether seeking should be performed in a descending way.
 	// Backwards can be safely combined with Prefix and Start.
@@ -112,9 +124,16 @@ func seekRangeToPrefixes(sr SeekRange) *util.Range {
 	if !sr.Backwards {
 		rang = util.BytesPrefix(sr.Prefix)
 		rang.Start = start
+		if len(sr.End) != 0 {
+			rang.Limit = slices.Concat(sr.Prefix, sr.End)
+		}
 	} else {
 		rang = util.BytesPrefix(start)
 		rang.Start = sr.Prefix
+		if len(sr.End) != 0 {
+			// Start is inclusive, so use the key following the End one.
+			rang.Start = slices.Concat(sr.Prefix, sr.End, []byte{0})
+		}
 	}
 	return rang
 }
@@ -223,6 +223,41 @@ func testStoreSeekGC(t *testing.T, s Store) {
 	}
 }
 
+func testStoreSeekEnd(t *testing.T, s Store) {
+	kvs := pushSeekDataSet(t, s)
+	check := func(t *testing.T, s Store, rng SeekRange, expected ...KeyValue) {
+		actual := []KeyValue{}
+		s.Seek(rng, func(k, v []byte) bool {
+			actual = append(actual, KeyValue{Key: bytes.Clone(k), Value: bytes.Clone(v)})
+			return true
+		})
+		assert.Equal(t, append([]KeyValue{}, expected...), actual)
+	}
+	t.Run("forwards", func(t *testing.T) {
+		check(t, s, SeekRange{Prefix: []byte("2"), End: []byte("2")}, kvs[2], kvs[3])
+		check(t, s, SeekRange{Prefix: []byte("2"), Start: []byte("1"), End: []byte("2")}, kvs[3])
+		check(t, s, SeekRange{Prefix: []byte("2"), End: []byte("3")}, kvs[2], kvs[3], kvs[4])
+		check(t, s, SeekRange{Prefix: []byte("2"), Start: []byte("2"), End: []byte("1")})
+		check(t, s, SeekRange{Prefix: []byte("2"), End: []byte("0")})
+	})
+	t.Run("backwards", func(t *testing.T) {
+		check(t, s, SeekRange{Prefix: []byte("2"), End: []byte("0"), Backwards: true}, kvs[4], kvs[3])
+		check(t, s, SeekRange{Prefix: []byte("2"), Start: []byte("1"), End: []byte("0"), Backwards: true}, kvs[3])
+		check(t, s, SeekRange{Prefix: []byte("2"), End: []byte("1"), Backwards: true}, kvs[4])
+		check(t, s, SeekRange{Prefix: []byte("2"), End: []byte("/"), Backwards: true}, kvs[4], kvs[3], kvs[2])
+		check(t, s, SeekRange{Prefix: []byte("2"), Start: []byte("0"), End: []byte("1"), Backwards: true})
+	})
+	t.Run("cached changes", func(t *testing.T) {
+		up := NewMemCachedStore(s)
+		up.Put([]byte("211"), []byte("barg"))
+		up.Delete([]byte("20"))
+		added := KeyValue{Key: []byte("211"), Value: []byte("barg")}
+		check(t, up, SeekRange{Prefix: []byte("2"), End: []byte("2")}, kvs[3], added)
+		check(t, up, SeekRange{Prefix: []byte("2"), End: []byte("1"), Backwards: true}, kvs[4], added)
+		check(t, up, SeekRange{Prefix: []byte("2"), End: []byte("11"), Backwards: true}, kvs[4])
+	})
+}
+
 func TestAllDBs(t *testing.T) {
 	var DBs = []dbSetup{
 		{"BoltDB", newBoltStoreForTesting},
@@ -231,7 +266,7 @@ func TestAllDBs(t *testing.T) {
 		{"Memory", newMemoryStoreForTesting},
 	}
 	var tests = []dbTestFunction{testStoreGetNonExistent, testStoreSeek,
-		testStoreSeekGC}
+		testStoreSeekEnd, testStoreSeekGC}
 	for _, db := range DBs {
 		for _, test := range tests {
 			s := db.create(t)
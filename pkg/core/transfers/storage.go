@@ -0,0 +1,114 @@
+package transfers
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Transfer index storage schema. Keys are:
+//
+//	STTokenTransferInfo + account -> state.TokenTransferInfo
+//	STNEP17Transfers + account + newest timestamp (BE uint64) + batch index (BE uint32) -> state.TokenTransferLog
+//	STNEP11Transfers + account + newest timestamp (BE uint64) + batch index (BE uint32) -> state.TokenTransferLog
+//
+// The layout is compatible with the one used by older node versions, so
+// existing databases don't need to be resynchronized.
+const (
+	infoKeyLen = 1 + util.Uint160Size
+	logKeyLen  = 1 + util.Uint160Size + 8 + 4
+)
+
+func makeInfoKey(acc util.Uint160) []byte {
+	key := make([]byte, infoKeyLen)
+	key[0] = byte(storage.STTokenTransferInfo)
+	copy(key[1:], acc.BytesBE())
+	return key
+}
+
+func makeLogKey(acc util.Uint160, newestTimestamp uint64, index uint32, isNEP11 bool) []byte {
+	key := make([]byte, logKeyLen)
+	if isNEP11 {
+		key[0] = byte(storage.STNEP11Transfers)
+	} else {
+		key[0] = byte(storage.STNEP17Transfers)
+	}
+	copy(key[1:], acc.BytesBE())
+	binary.BigEndian.PutUint64(key[1+util.Uint160Size:], newestTimestamp)
+	binary.BigEndian.PutUint32(key[1+util.Uint160Size+8:], index)
+	return key
+}
+
+// GetInfo retrieves transfer info of the given account from d, empty info is
+// returned if there is none.
+func GetInfo(d *dao.Simple, acc util.Uint160) (*state.TokenTransferInfo, error) {
+	info := state.NewTokenTransferInfo()
+	v, err := d.Store.Get(makeInfoKey(acc))
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return info, nil
+		}
+		return nil, err
+	}
+	r := io.NewBinReaderFromBuf(v)
+	info.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return info, nil
+}
+
+// PutInfo saves transfer info of the given account into d.
+func PutInfo(d *dao.Simple, acc util.Uint160, info *state.TokenTransferInfo) error {
+	w := io.NewBufBinWriter()
+	info.EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return w.Err
+	}
+	d.Store.Put(makeInfoKey(acc), w.Bytes())
+	return nil
+}
+
+// GetLog retrieves the transfer log batch with the given newest timestamp and
+// index from d, empty log is returned if there is none.
+func GetLog(d *dao.Simple, acc util.Uint160, newestTimestamp uint64, index uint32, isNEP11 bool) (*state.TokenTransferLog, error) {
+	v, err := d.Store.Get(makeLogKey(acc, newestTimestamp, index, isNEP11))
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return new(state.TokenTransferLog), nil
+		}
+		return nil, err
+	}
+	return &state.TokenTransferLog{Raw: v}, nil
+}
+
+// PutLog saves the given transfer log batch into d.
+func PutLog(d *dao.Simple, acc util.Uint160, newestTimestamp uint64, index uint32, isNEP11 bool, lg *state.TokenTransferLog) {
+	d.Store.Put(makeLogKey(acc, newestTimestamp, index, isNEP11), lg.Raw)
+}
+
+// seekLog executes f for each transfer log batch of the given account starting
+// from the one with the newest timestamp up to the oldest one. It continues
+// iteration until false is returned from f. The last non-nil error is returned.
+func seekLog(d *dao.Simple, acc util.Uint160, newestTimestamp uint64, isNEP11 bool, f func(*state.TokenTransferLog) (bool, error)) error {
+	key := makeLogKey(acc, newestTimestamp, 0, isNEP11)
+	prefixLen := 1 + util.Uint160Size
+	var seekErr error
+	d.Store.Seek(storage.SeekRange{
+		Prefix:    key[:prefixLen],
+		Start:     key[prefixLen : prefixLen+8],
+		Backwards: true,
+	}, func(k, v []byte) bool {
+		cont, err := f(&state.TokenTransferLog{Raw: v})
+		if err != nil {
+			seekErr = err
+		}
+		return cont
+	})
+	return seekErr
+}
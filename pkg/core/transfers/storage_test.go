@@ -0,0 +1,60 @@
+package transfers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage(t *testing.T) {
+	d := dao.NewSimple(storage.NewMemoryStore(), false)
+	acc := util.Uint160{1, 2, 3}
+
+	info, err := GetInfo(d, acc)
+	require.NoError(t, err)
+	require.Equal(t, state.NewTokenTransferInfo(), info)
+
+	info.LastUpdated[1] = 10
+	info.NextNEP17Batch = 2
+	require.NoError(t, PutInfo(d, acc, info))
+	actual, err := GetInfo(d, acc)
+	require.NoError(t, err)
+	require.Equal(t, info, actual)
+
+	lg, err := GetLog(d, acc, 100, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(lg.Raw))
+
+	for _, ts := range []uint64{100, 200} {
+		lg := new(state.TokenTransferLog)
+		require.NoError(t, lg.Append(&state.NEP17Transfer{Amount: new(big.Int).SetUint64(ts), Timestamp: ts}))
+		PutLog(d, acc, ts, 0, false, lg)
+	}
+	PutLog(d, acc, 300, 0, true, &state.TokenTransferLog{Raw: []byte{1}})              // Another log type.
+	PutLog(d, util.Uint160{4}, 300, 0, false, &state.TokenTransferLog{Raw: []byte{1}}) // Another account.
+
+	var timestamps []uint64
+	err = seekLog(d, acc, 150, false, func(lg *state.TokenTransferLog) (bool, error) {
+		return lg.ForEachNEP17(func(tr *state.NEP17Transfer) (bool, error) {
+			timestamps = append(timestamps, tr.Timestamp)
+			return true, nil
+		})
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{100}, timestamps)
+
+	timestamps = timestamps[:0]
+	err = seekLog(d, acc, 1000, false, func(lg *state.TokenTransferLog) (bool, error) {
+		return lg.ForEachNEP17(func(tr *state.NEP17Transfer) (bool, error) {
+			timestamps = append(timestamps, tr.Timestamp)
+			return true, nil
+		})
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{200, 100}, timestamps)
+}
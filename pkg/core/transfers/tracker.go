@@ -0,0 +1,548 @@
+/*
+Package transfers implements NEP-17 and NEP-11 transfer index.
+
+Transfers are extracted from Transfer notifications of successful executions
+and stored in per-account transfer logs (STNEP17Transfers and STNEP11Transfers
+prefixes, see state.TokenTransferLog) accompanied by per-account transfer info
+(STTokenTransferInfo prefix, see state.TokenTransferInfo). This storage schema
+is owned by the package (DAO doesn't know anything about it) and is kept
+compatible with the one used before the index was separated from the
+Blockchain. All of this data is derived from blocks and their execution
+results, so it can be rolled back to some height on state reset or dropped
+and rebuilt from the stored blocks.
+*/
+package transfers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/limits"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+type (
+	// Transfer is a single token transfer.
+	Transfer struct {
+		// Asset is the token contract hash.
+		Asset util.Uint160
+		// From is the sender, it's zero for minting.
+		From util.Uint160
+		// To is the receiver, it's zero for burning.
+		To util.Uint160
+		// Amount is the amount of tokens transferred.
+		Amount *big.Int
+		// ID is the NEP-11 token ID, it's nil for NEP-17 transfers.
+		ID []byte
+		// Container is the hash of the transaction (or block) the transfer
+		// was made in.
+		Container util.Uint256
+	}
+
+	// Subscriber receives transfer index updates. Its methods are called
+	// synchronously from the block processing code, so they must not block.
+	Subscriber interface {
+		// OnTransfers is called after the block containing the transfers is
+		// stored. It's not called for blocks without transfers.
+		OnTransfers(b *block.Block, transfers []Transfer)
+		// OnReset is called after the index is rolled back to the given
+		// height, transfers from all newer blocks are removed from it.
+		OnReset(height uint32)
+	}
+
+	// ContractIDFunc returns the ID of the contract with the given hash.
+	ContractIDFunc func(d *dao.Simple, h util.Uint160) (int32, error)
+
+	// Tracker manages transfer index.
+	Tracker struct {
+		getID ContractIDFunc
+
+		subsLock sync.RWMutex
+		subs     []Subscriber
+	}
+
+	// Batch is a set of transfer index changes made by a single block.
+	Batch struct {
+		d         *dao.Simple
+		getID     ContractIDFunc
+		block     *block.Block
+		cache     map[util.Uint160]transferData
+		transfers []Transfer
+	}
+
+	// transferData is used for transfer caching during block processing.
+	transferData struct {
+		Info  state.TokenTransferInfo
+		Log11 state.TokenTransferLog
+		Log17 state.TokenTransferLog
+	}
+)
+
+// NewTracker returns a new Tracker using getID to resolve token contract IDs.
+func NewTracker(getID ContractIDFunc) *Tracker {
+	return &Tracker{getID: getID}
+}
+
+// Subscribe adds the given subscriber to the list of index update receivers.
+func (t *Tracker) Subscribe(s Subscriber) {
+	t.subsLock.Lock()
+	defer t.subsLock.Unlock()
+	t.subs = append(t.subs, s)
+}
+
+// Unsubscribe removes the given subscriber from the list of index update
+// receivers.
+func (t *Tracker) Unsubscribe(s Subscriber) {
+	t.subsLock.Lock()
+	defer t.subsLock.Unlock()
+	t.subs = slices.DeleteFunc(t.subs, func(sub Subscriber) bool { return sub == s })
+}
+
+// Notify passes transfers of the given batch to subscribers, it must be called
+// after batch changes are persisted.
+func (t *Tracker) Notify(b *Batch) {
+	if len(b.transfers) == 0 {
+		return
+	}
+	t.subsLock.RLock()
+	defer t.subsLock.RUnlock()
+	for _, s := range t.subs {
+		s.OnTransfers(b.block, b.transfers)
+	}
+}
+
+// NotifyReset notifies subscribers of the index rollback to the given height,
+// it must be called after Reset changes are persisted.
+func (t *Tracker) NotifyReset(height uint32) {
+	t.subsLock.RLock()
+	defer t.subsLock.RUnlock()
+	for _, s := range t.subs {
+		s.OnReset(height)
+	}
+}
+
+// NewBatch creates a new Batch for the given block storing changes into d.
+// Execution results of the block must be added to it in the order of
+// execution.
+func (t *Tracker) NewBatch(d *dao.Simple, b *block.Block) *Batch {
+	return &Batch{
+		d:     d,
+		getID: t.getID,
+		block: b,
+		cache: make(map[util.Uint160]transferData),
+	}
+}
+
+// NewBatchWithIDFunc is similar to NewBatch, but the Batch uses getID instead
+// of the Tracker's function to resolve token contract IDs.
+func (t *Tracker) NewBatchWithIDFunc(d *dao.Simple, b *block.Block, getID ContractIDFunc) *Batch {
+	batch := t.NewBatch(d, b)
+	batch.getID = getID
+	return batch
+}
+
+// AddExecution processes Transfer notifications of the given execution result,
+// FAULTed executions are ignored.
+func (b *Batch) AddExecution(aer *state.AppExecResult) {
+	if aer.Execution.VMState != vmstate.Halt {
+		return
+	}
+	for i := range aer.Execution.Events {
+		b.handleNotification(&aer.Execution.Events[i], aer.Container)
+	}
+}
+
+// Flush stores the transfer info and the latest transfer logs of all accounts
+// changed by the batch.
+func (b *Batch) Flush() error {
+	for acc, trData := range b.cache {
+		err := PutInfo(b.d, acc, &trData.Info)
+		if err != nil {
+			return err
+		}
+		if !trData.Info.NewNEP11Batch {
+			PutLog(b.d, acc, trData.Info.NextNEP11NewestTimestamp, trData.Info.NextNEP11Batch, true, &trData.Log11)
+		}
+		if !trData.Info.NewNEP17Batch {
+			PutLog(b.d, acc, trData.Info.NextNEP17NewestTimestamp, trData.Info.NextNEP17Batch, false, &trData.Log17)
+		}
+	}
+	return nil
+}
+
+func (b *Batch) handleNotification(note *state.NotificationEvent, h util.Uint256) {
+	if note.Name != "Transfer" {
+		return
+	}
+	arr, ok := note.Item.Value().([]stackitem.Item)
+	if !ok || !(len(arr) == 3 || len(arr) == 4) {
+		return
+	}
+	from, err := parseUint160(arr[0])
+	if err != nil {
+		return
+	}
+	to, err := parseUint160(arr[1])
+	if err != nil {
+		return
+	}
+	amount, err := arr[2].TryInteger()
+	if err != nil {
+		return
+	}
+	var id []byte
+	if len(arr) == 4 {
+		id, err = arr[3].TryBytes()
+		if err != nil || len(id) > limits.MaxStorageKeyLen {
+			return
+		}
+	}
+	b.processTokenTransfer(h, note.ScriptHash, from, to, amount, id)
+}
+
+func parseUint160(itm stackitem.Item) (util.Uint160, error) {
+	_, ok := itm.(stackitem.Null) // Minting or burning.
+	if ok {
+		return util.Uint160{}, nil
+	}
+	bytes, err := itm.TryBytes()
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	return util.Uint160DecodeBytesBE(bytes)
+}
+
+func (b *Batch) processTokenTransfer(h util.Uint256, sc util.Uint160, from util.Uint160, to util.Uint160,
+	amount *big.Int, tokenID []byte) {
+	id, err := b.getID(b.d, sc)
+	if err != nil {
+		return
+	}
+	var transfer io.Serializable
+	var nep17xfer *state.NEP17Transfer
+	var isNEP11 = (tokenID != nil)
+	if !isNEP11 {
+		nep17xfer = &state.NEP17Transfer{
+			Asset:        id,
+			Amount:       amount,
+			Block:        b.block.Index,
+			Counterparty: to,
+			Timestamp:    b.block.Timestamp,
+			Tx:           h,
+		}
+		transfer = nep17xfer
+	} else {
+		nep11xfer := &state.NEP11Transfer{
+			NEP17Transfer: state.NEP17Transfer{
+				Asset:        id,
+				Amount:       amount,
+				Block:        b.block.Index,
+				Counterparty: to,
+				Timestamp:    b.block.Timestamp,
+				Tx:           h,
+			},
+			ID: tokenID,
+		}
+		transfer = nep11xfer
+		nep17xfer = &nep11xfer.NEP17Transfer
+	}
+	b.transfers = append(b.transfers, Transfer{
+		Asset:     sc,
+		From:      from,
+		To:        to,
+		Amount:    new(big.Int).Set(amount),
+		ID:        tokenID,
+		Container: h,
+	})
+	if !from.Equals(util.Uint160{}) {
+		_ = nep17xfer.Amount.Neg(nep17xfer.Amount)
+		err := b.appendTokenTransfer(from, transfer, id, isNEP11)
+		_ = nep17xfer.Amount.Neg(nep17xfer.Amount)
+		if err != nil {
+			return
+		}
+	}
+	if !to.Equals(util.Uint160{}) {
+		nep17xfer.Counterparty = from
+		_ = b.appendTokenTransfer(to, transfer, id, isNEP11) // Nothing useful we can do.
+	}
+}
+
+func (b *Batch) appendTokenTransfer(addr util.Uint160, transfer io.Serializable, token int32, isNEP11 bool) error {
+	transferData, ok := b.cache[addr]
+	if !ok {
+		balances, err := GetInfo(b.d, addr)
+		if err != nil {
+			return err
+		}
+		if !balances.NewNEP11Batch {
+			trLog, err := GetLog(b.d, addr, balances.NextNEP11NewestTimestamp, balances.NextNEP11Batch, true)
+			if err != nil {
+				return err
+			}
+			transferData.Log11 = *trLog
+		}
+		if !balances.NewNEP17Batch {
+			trLog, err := GetLog(b.d, addr, balances.NextNEP17NewestTimestamp, balances.NextNEP17Batch, false)
+			if err != nil {
+				return err
+			}
+			transferData.Log17 = *trLog
+		}
+		transferData.Info = *balances
+	}
+	var (
+		log           *state.TokenTransferLog
+		nextBatch     uint32
+		currTimestamp uint64
+	)
+	if !isNEP11 {
+		log = &transferData.Log17
+		nextBatch = transferData.Info.NextNEP17Batch
+		currTimestamp = transferData.Info.NextNEP17NewestTimestamp
+	} else {
+		log = &transferData.Log11
+		nextBatch = transferData.Info.NextNEP11Batch
+		currTimestamp = transferData.Info.NextNEP11NewestTimestamp
+	}
+	err := log.Append(transfer)
+	if err != nil {
+		return err
+	}
+	newBatch := log.Size() >= state.TokenTransferBatchSize
+	if newBatch {
+		PutLog(b.d, addr, currTimestamp, nextBatch, isNEP11, log)
+		// Put makes a copy of it anyway.
+		log.Reset()
+	}
+	appendTokenTransferInfo(&transferData.Info, token, b.block.Index, b.block.Timestamp, isNEP11, newBatch)
+	b.cache[addr] = transferData
+	return nil
+}
+
+// appendTokenTransferInfo updates token transfer info wrt the given transfer
+// that was added to the subsequent transfer batch.
+func appendTokenTransferInfo(transferData *state.TokenTransferInfo,
+	token int32, bIndex uint32, bTimestamp uint64, isNEP11 bool, lastTransferInBatch bool) {
+	var (
+		newBatch      *bool
+		nextBatch     *uint32
+		currTimestamp *uint64
+	)
+	if !isNEP11 {
+		newBatch = &transferData.NewNEP17Batch
+		nextBatch = &transferData.NextNEP17Batch
+		currTimestamp = &transferData.NextNEP17NewestTimestamp
+	} else {
+		newBatch = &transferData.NewNEP11Batch
+		nextBatch = &transferData.NextNEP11Batch
+		currTimestamp = &transferData.NextNEP11NewestTimestamp
+	}
+	transferData.LastUpdated[token] = bIndex
+	*newBatch = lastTransferInBatch
+	if *newBatch {
+		*nextBatch++
+		*currTimestamp = bTimestamp
+	}
+}
+
+// ForEachNEP17Transfer executes f for each NEP-17 transfer in log starting from
+// the transfer with the newest timestamp up to the oldest transfer. It continues
+// iteration until false is returned from f. The last non-nil error is returned.
+func (t *Tracker) ForEachNEP17Transfer(d *dao.Simple, acc util.Uint160, newestTimestamp uint64, f func(*state.NEP17Transfer) (bool, error)) error {
+	return seekLog(d, acc, newestTimestamp, false, func(lg *state.TokenTransferLog) (bool, error) {
+		return lg.ForEachNEP17(f)
+	})
+}
+
+// ForEachNEP11Transfer executes f for each NEP-11 transfer in log starting from
+// the transfer with the newest timestamp up to the oldest transfer. It continues
+// iteration until false is returned from f. The last non-nil error is returned.
+func (t *Tracker) ForEachNEP11Transfer(d *dao.Simple, acc util.Uint160, newestTimestamp uint64, f func(*state.NEP11Transfer) (bool, error)) error {
+	return seekLog(d, acc, newestTimestamp, true, func(lg *state.TokenTransferLog) (bool, error) {
+		return lg.ForEachNEP11(f)
+	})
+}
+
+// GetLastUpdated returns the heights of the last balance changes for all
+// tokens transferred to/from the given account, keyed by token contract ID.
+func (t *Tracker) GetLastUpdated(d *dao.Simple, acc util.Uint160) (map[int32]uint32, error) {
+	info, err := GetInfo(d, acc)
+	if err != nil {
+		return nil, err
+	}
+	return info.LastUpdated, nil
+}
+
+// Drop removes all transfer index data.
+func (t *Tracker) Drop(d *dao.Simple) {
+	prefixes := []byte{byte(storage.STNEP11Transfers), byte(storage.STNEP17Transfers), byte(storage.STTokenTransferInfo)}
+	for i := range prefixes {
+		d.Store.Seek(storage.SeekRange{Prefix: prefixes[i : i+1]}, func(k, v []byte) bool {
+			d.Store.Delete(k)
+			return true
+		})
+	}
+}
+
+// Reset strips the top newest NEP-17 and NEP-11 transfer logs down to the
+// given height (not including the height itself) and updates corresponding
+// token transfer info.
+func (t *Tracker) Reset(cache *dao.Simple, height uint32) error {
+	// Completely remove transfer info, updating it takes too much effort. We'll gather new
+	// transfer info on-the-fly later.
+	cache.Store.Seek(storage.SeekRange{
+		Prefix: []byte{byte(storage.STTokenTransferInfo)},
+	}, func(k, v []byte) bool {
+		cache.Store.Delete(k)
+		return true
+	})
+
+	// Look inside each transfer batch and iterate over the batch transfers, picking those that
+	// not newer than the given height. Also, for each suitable transfer update transfer info
+	// flushing changes after complete account's transfers processing.
+	prefixes := []byte{byte(storage.STNEP11Transfers), byte(storage.STNEP17Transfers)}
+	for i := range prefixes {
+		var (
+			acc             util.Uint160
+			trInfo          *state.TokenTransferInfo
+			removeFollowing bool
+			seekErr         error
+		)
+
+		cache.Store.Seek(storage.SeekRange{
+			Prefix:    prefixes[i : i+1],
+			Backwards: false, // From oldest to newest batch.
+		}, func(k, v []byte) bool {
+			var batchAcc util.Uint160
+			copy(batchAcc[:], k[1:])
+
+			if batchAcc != acc { // Some new account we're iterating over.
+				if trInfo != nil {
+					seekErr = PutInfo(cache, acc, trInfo)
+					if seekErr != nil {
+						return false
+					}
+				}
+				acc = batchAcc
+				trInfo = nil
+				removeFollowing = false
+			} else if removeFollowing {
+				cache.Store.Delete(bytes.Clone(k))
+				return seekErr == nil
+			}
+
+			r := io.NewBinReaderFromBuf(v[1:])
+			l := len(v)
+			bytesRead := 1 // 1 is for batch size byte which is read by default.
+			var (
+				oldBatchSize = v[0]
+				newBatchSize byte
+			)
+			for range v[0] { // From oldest to newest transfer of the batch.
+				var xfer *state.NEP17Transfer
+				if k[0] == byte(storage.STNEP11Transfers) {
+					tr := new(state.NEP11Transfer)
+					tr.DecodeBinary(r)
+					xfer = &tr.NEP17Transfer
+				} else {
+					xfer = new(state.NEP17Transfer)
+					xfer.DecodeBinary(r)
+				}
+				if r.Err != nil {
+					seekErr = fmt.Errorf("failed to decode subsequent transfer: %w", r.Err)
+					break
+				}
+
+				if xfer.Block > height {
+					break
+				}
+				bytesRead = l - r.Len() // Including batch size byte.
+				newBatchSize++
+				if trInfo == nil {
+					var err error
+					trInfo, err = GetInfo(cache, batchAcc)
+					if err != nil {
+						seekErr = fmt.Errorf("failed to retrieve token transfer info for %s: %w", batchAcc.StringLE(), r.Err)
+						return false
+					}
+				}
+				appendTokenTransferInfo(trInfo, xfer.Asset, xfer.Block, xfer.Timestamp, k[0] == byte(storage.STNEP11Transfers), newBatchSize >= state.TokenTransferBatchSize)
+			}
+			if newBatchSize == oldBatchSize {
+				// The batch is already in storage and doesn't need to be changed.
+				return seekErr == nil
+			}
+			if newBatchSize > 0 {
+				v[0] = newBatchSize
+				cache.Store.Put(k, v[:bytesRead])
+			} else {
+				cache.Store.Delete(k)
+				removeFollowing = true
+			}
+			return seekErr == nil
+		})
+		if seekErr != nil {
+			return seekErr
+		}
+		if trInfo != nil {
+			// Flush the last batch of transfer info changes.
+			err := PutInfo(cache, acc, trInfo)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GC removes transfer log batches containing only transfers made before the
+// given timestamp directly from the store. It returns the number of batches
+// removed and kept.
+func (t *Tracker) GC(ts uint64, store storage.Store) (int64, int64, error) {
+	var removed, kept int64
+	prefixes := []byte{byte(storage.STNEP11Transfers), byte(storage.STNEP17Transfers)}
+
+	for i := range prefixes {
+		var acc util.Uint160
+		var canDrop bool
+
+		err := store.SeekGC(storage.SeekRange{
+			Prefix:    prefixes[i : i+1],
+			Backwards: true, // From new to old.
+		}, func(k, v []byte) bool {
+			// We don't look inside of the batches, it requires too much effort, instead
+			// we drop batches that are confirmed to contain outdated entries.
+			var batchAcc util.Uint160
+			var batchTs = binary.BigEndian.Uint64(k[1+util.Uint160Size:])
+			copy(batchAcc[:], k[1:])
+
+			if batchAcc != acc { // Some new account we're iterating over.
+				acc = batchAcc
+			} else if canDrop { // We've seen this account and all entries in this batch are guaranteed to be outdated.
+				removed++
+				return false
+			}
+			// We don't know what's inside, so keep the current
+			// batch anyway, but allow to drop older ones.
+			canDrop = batchTs <= ts
+			kept++
+			return true
+		})
+		if err != nil {
+			return removed, kept, err
+		}
+	}
+	return removed, kept, nil
+}
@@ -0,0 +1,182 @@
+/*
+Package validatorstats implements per-validator block production statistics
+tracking used by the Blockchain.
+*/
+package validatorstats
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// Stats is the block production statistics of a single validator.
+type Stats struct {
+	// Key is the public key of the validator.
+	Key *keys.PublicKey
+	// Produced is the number of blocks the validator was the primary for.
+	Produced uint64
+	// Missed is the number of views the validator was expected to be the
+	// primary in that ended with a view change.
+	Missed uint64
+}
+
+// Window is the statistics for a range of the latest blocks.
+type Window struct {
+	// Start is the index of the first block of the window.
+	Start uint32
+	// End is the index of the last block of the window.
+	End uint32
+	// Validators contains statistics of every validator that produced or
+	// missed some block in the window in the standard validators order.
+	Validators []Stats
+}
+
+// record is the data of a single block, keys are serialized public keys.
+type record struct {
+	primary string
+	missed  []string
+}
+
+// Collector tracks block production statistics for a sliding window of a
+// fixed number of the latest blocks.
+type Collector struct {
+	length      uint32
+	trackMissed bool
+
+	lock    sync.RWMutex
+	records []record // Ring buffer of the window blocks.
+	filled  uint32
+	end     uint32
+	keys    map[string]*keys.PublicKey
+	stats   map[string]Stats
+}
+
+// NewCollector creates a Collector for the window of the given number of
+// blocks which must be positive. trackMissed enables counting missed views
+// which requires dBFT to use the standard round-robin primary selection.
+func NewCollector(length uint32, trackMissed bool) *Collector {
+	return &Collector{
+		length:      length,
+		trackMissed: trackMissed,
+		records:     make([]record, length),
+		keys:        make(map[string]*keys.PublicKey),
+		stats:       make(map[string]Stats),
+	}
+}
+
+// AddBlock adds the block with the given index and primary index made by the
+// given validators (in the standard order) to the window, the oldest block is
+// dropped from it if the window is full. Blocks must be added sequentially,
+// the window is restarted if there is a gap.
+func (c *Collector) AddBlock(index uint32, primary byte, validators keys.PublicKeys) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.filled != 0 && index != c.end+1 {
+		c.reset()
+	}
+	var (
+		pos = index % c.length
+		r   record
+		n   = len(validators)
+	)
+	if c.filled == c.length {
+		c.drop(c.records[pos])
+	} else {
+		c.filled++
+	}
+	if int(primary) < n {
+		r.primary = c.keyOf(validators[primary])
+		s := c.stats[r.primary]
+		s.Produced++
+		c.stats[r.primary] = s
+		if c.trackMissed {
+			// dBFT chooses (index - view) mod n validator as the
+			// primary, so every view before the one the block was
+			// accepted in was missed by its primary.
+			view := (int(index%uint32(n)) - int(primary) + n) % n
+			for v := range view {
+				k := c.keyOf(validators[(int(index%uint32(n))-v+n)%n])
+				r.missed = append(r.missed, k)
+				s := c.stats[k]
+				s.Missed++
+				c.stats[k] = s
+			}
+		}
+	}
+	c.records[pos] = r
+	c.end = index
+}
+
+// keyOf returns the map key for the given public key remembering it.
+func (c *Collector) keyOf(pub *keys.PublicKey) string {
+	k := string(pub.Bytes())
+	if _, ok := c.keys[k]; !ok {
+		c.keys[k] = pub
+	}
+	return k
+}
+
+// drop removes the block data from the statistics.
+func (c *Collector) drop(r record) {
+	if r.primary != "" {
+		s := c.stats[r.primary]
+		s.Produced--
+		c.setStats(r.primary, s)
+	}
+	for _, k := range r.missed {
+		s := c.stats[k]
+		s.Missed--
+		c.setStats(k, s)
+	}
+}
+
+// setStats updates validator statistics forgetting validators with no data.
+func (c *Collector) setStats(k string, s Stats) {
+	if s.Produced == 0 && s.Missed == 0 {
+		delete(c.stats, k)
+		delete(c.keys, k)
+		return
+	}
+	c.stats[k] = s
+}
+
+// Window returns the statistics for the current window or nil if no blocks
+// were added yet.
+func (c *Collector) Window() *Window {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if c.filled == 0 {
+		return nil
+	}
+	var res = &Window{
+		Start:      c.end - c.filled + 1,
+		End:        c.end,
+		Validators: make([]Stats, 0, len(c.stats)),
+	}
+	for k, s := range c.stats {
+		s.Key = c.keys[k]
+		res.Validators = append(res.Validators, s)
+	}
+	slices.SortFunc(res.Validators, func(a, b Stats) int {
+		return a.Key.Cmp(b.Key)
+	})
+	return res
+}
+
+// Reset drops all collected statistics, it's used when the chain state is
+// reset to some previous height.
+func (c *Collector) Reset() {
+	c.lock.Lock()
+	c.reset()
+	c.lock.Unlock()
+}
+
+func (c *Collector) reset() {
+	clear(c.records)
+	c.filled = 0
+	c.keys = make(map[string]*keys.PublicKey)
+	c.stats = make(map[string]Stats)
+}
@@ -0,0 +1,75 @@
+package validatorstats
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+)
+
+func getValidators(t *testing.T, n int) keys.PublicKeys {
+	var res = make(keys.PublicKeys, n)
+	for i := range res {
+		k, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		res[i] = k.PublicKey()
+	}
+	slices.SortFunc(res, (*keys.PublicKey).Cmp)
+	return res
+}
+
+func TestCollector(t *testing.T) {
+	var (
+		vals = getValidators(t, 4)
+		c    = NewCollector(3, true)
+	)
+	require.Nil(t, c.Window())
+
+	c.AddBlock(5, 1, vals) // View 0.
+	require.Equal(t, &Window{Start: 5, End: 5, Validators: []Stats{
+		{Key: vals[1], Produced: 1},
+	}}, c.Window())
+
+	c.AddBlock(6, 0, vals) // View 2, validators 2 and 1 missed.
+	c.AddBlock(7, 3, vals) // View 0.
+	require.Equal(t, &Window{Start: 5, End: 7, Validators: []Stats{
+		{Key: vals[0], Produced: 1},
+		{Key: vals[1], Produced: 1, Missed: 1},
+		{Key: vals[2], Missed: 1},
+		{Key: vals[3], Produced: 1},
+	}}, c.Window())
+
+	c.AddBlock(8, 0, vals) // View 0, block 5 is dropped.
+	require.Equal(t, &Window{Start: 6, End: 8, Validators: []Stats{
+		{Key: vals[0], Produced: 2},
+		{Key: vals[1], Missed: 1},
+		{Key: vals[2], Missed: 1},
+		{Key: vals[3], Produced: 1},
+	}}, c.Window())
+
+	c.AddBlock(9, 0, vals) // View 1, validator 1 missed, block 6 is dropped.
+	require.Equal(t, &Window{Start: 7, End: 9, Validators: []Stats{
+		{Key: vals[0], Produced: 2},
+		{Key: vals[1], Missed: 1},
+		{Key: vals[3], Produced: 1},
+	}}, c.Window())
+
+	t.Run("gap", func(t *testing.T) {
+		c.AddBlock(20, 0, vals)
+		require.Equal(t, &Window{Start: 20, End: 20, Validators: []Stats{
+			{Key: vals[0], Produced: 1},
+		}}, c.Window())
+	})
+	t.Run("reset", func(t *testing.T) {
+		c.Reset()
+		require.Nil(t, c.Window())
+	})
+	t.Run("no missed tracking", func(t *testing.T) {
+		c := NewCollector(10, false)
+		c.AddBlock(6, 0, vals)
+		require.Equal(t, &Window{Start: 6, End: 6, Validators: []Stats{
+			{Key: vals[0], Produced: 1},
+		}}, c.Window())
+	})
+}
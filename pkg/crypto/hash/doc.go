@@ -1,6 +1,7 @@
 /*
 Package hash contains wrappers for Neo hashing algorithms.
 
-It also implements Merkle tree.
+It also implements Merkle tree, see the merkle subpackage for incremental
+Merkle trees with inclusion and consistency proofs.
 */
 package hash
@@ -6,6 +6,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // SA1019: package golang.org/x/crypto/ripemd160 is deprecated
+	"golang.org/x/crypto/sha3"
 )
 
 // Hashable represents an object which can be hashed. Usually, these objects
@@ -33,6 +34,12 @@ func NetSha256(net uint32, hh Hashable) util.Uint256 {
 	return Sha256(GetSignedData(net, hh))
 }
 
+// NetKeccak256 calculates a network-specific Keccak256 hash of the Hashable
+// item, it's used for Secp256k1 signatures checked by CryptoLib.
+func NetKeccak256(net uint32, hh Hashable) util.Uint256 {
+	return Keccak256(GetSignedData(net, hh))
+}
+
 // Sha256 hashes the incoming byte slice
 // using the sha256 algorithm.
 func Sha256(data []byte) util.Uint256 {
@@ -49,6 +56,17 @@ func DoubleSha256(data []byte) util.Uint256 {
 	return hash
 }
 
+// Keccak256 hashes the incoming byte slice using the
+// keccak256 algorithm.
+func Keccak256(data []byte) util.Uint256 {
+	var hash util.Uint256
+	hasher := sha3.NewLegacyKeccak256()
+	_, _ = hasher.Write(data)
+
+	hasher.Sum(hash[:0])
+	return hash
+}
+
 // RipeMD160 performs the RIPEMD160 hash algorithm
 // on the given data.
 func RipeMD160(data []byte) util.Uint160 {
@@ -0,0 +1,272 @@
+/*
+Package merkle implements append-only Merkle trees with inclusion and
+consistency proofs.
+
+Trees are built the same way as block Merkle roots (see hash.CalcMerkleRoot):
+node hash is a double SHA256 of its children hashes concatenation and the
+last node of a level that has an odd number of nodes is paired with itself.
+So a tree of block transaction hashes has the block's MerkleRoot as its root
+and inclusion proofs can be used to prove that some transaction is included
+into some block. Trees can also be used by applications to build off-chain
+commitments, appending data to the tree and anchoring roots on chain, in this
+case consistency proofs allow to prove that a newer tree is an extension of
+the older one.
+
+Leaves are used as is, so a leaf hash can't be distinguished from a node hash
+by proofs, applications should hash the data they commit to in a way that
+doesn't allow to pass a node hash as a valid leaf. Also, the root doesn't
+commit to the number of leaves (a tree with an odd number of leaves has the
+same root as the one with its last leaf duplicated), so the size used for
+verification must be known from some other trusted source.
+*/
+package merkle
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Tree is an append-only Merkle tree. It keeps hashes of all complete
+// subtrees, so roots and proofs for the current and any previous tree size
+// are computed with O(log(N)) hashing. It's not thread-safe.
+type Tree struct {
+	// levels[h] contains hashes of complete subtrees of 2^h leaves.
+	levels [][]util.Uint256
+}
+
+// NewTree creates a Tree with the given leaves.
+func NewTree(leaves ...util.Uint256) *Tree {
+	t := new(Tree)
+	t.Append(leaves...)
+	return t
+}
+
+// Append adds leaves to the tree.
+func (t *Tree) Append(leaves ...util.Uint256) {
+	for _, l := range leaves {
+		if len(t.levels) == 0 {
+			t.levels = append(t.levels, nil)
+		}
+		t.levels[0] = append(t.levels[0], l)
+		for h := 0; len(t.levels[h])%2 == 0; h++ {
+			if len(t.levels) == h+1 {
+				t.levels = append(t.levels, nil)
+			}
+			n := len(t.levels[h])
+			t.levels[h+1] = append(t.levels[h+1], hashPair(t.levels[h][n-2], t.levels[h][n-1]))
+		}
+	}
+}
+
+// Len returns the number of leaves in the tree.
+func (t *Tree) Len() int {
+	if len(t.levels) == 0 {
+		return 0
+	}
+	return len(t.levels[0])
+}
+
+// Root returns the root of the tree, it's zero for an empty tree.
+func (t *Tree) Root() util.Uint256 {
+	r, _ := t.RootAt(t.Len())
+	return r
+}
+
+// RootAt returns the root the tree had when it contained the given number of
+// leaves.
+func (t *Tree) RootAt(size int) (util.Uint256, error) {
+	if size < 0 || size > t.Len() {
+		return util.Uint256{}, fmt.Errorf("invalid tree size %d (tree has %d leaves)", size, t.Len())
+	}
+	if size == 0 {
+		return util.Uint256{}, nil
+	}
+	return t.node(depth(size), 0, size), nil
+}
+
+// Proof returns inclusion proof for the leaf with the given index, it
+// contains a sibling hash for every level of the tree from the bottom up.
+func (t *Tree) Proof(index int) ([]util.Uint256, error) {
+	var size = t.Len()
+
+	if index < 0 || index >= size {
+		return nil, fmt.Errorf("invalid leaf index %d (tree has %d leaves)", index, size)
+	}
+	var proof = make([]util.Uint256, 0, depth(size))
+	for h := range depth(size) {
+		sib := (index >> h) ^ 1
+		if sib >= levelLen(h, size) {
+			sib = index >> h
+		}
+		proof = append(proof, t.node(h, sib, size))
+	}
+	return proof, nil
+}
+
+// ConsistencyProof returns a proof of the current tree being an extension of
+// the tree with the given (non-zero) number of leaves. It contains hashes of
+// complete subtrees covering the older tree followed by hashes of complete
+// subtrees covering the leaves added after it.
+func (t *Tree) ConsistencyProof(oldSize int) ([]util.Uint256, error) {
+	var size = t.Len()
+
+	if oldSize <= 0 || oldSize > size {
+		return nil, fmt.Errorf("invalid old tree size %d (tree has %d leaves)", oldSize, size)
+	}
+	var proof []util.Uint256
+	for _, s := range subtrees(oldSize, size) {
+		proof = append(proof, t.levels[s.height][s.index])
+	}
+	return proof, nil
+}
+
+// VerifyProof checks inclusion proof of the leaf with the given index in the
+// tree with the given number of leaves and root.
+func VerifyProof(root, leaf util.Uint256, index, size int, proof []util.Uint256) error {
+	if index < 0 || index >= size {
+		return fmt.Errorf("invalid leaf index %d for tree of %d leaves", index, size)
+	}
+	if len(proof) != depth(size) {
+		return fmt.Errorf("invalid proof length %d, expected %d", len(proof), depth(size))
+	}
+	var cur = leaf
+	for h, sib := range proof {
+		j := index >> h
+		switch {
+		case j^1 >= levelLen(h, size):
+			if sib != cur {
+				return fmt.Errorf("invalid last node sibling at level %d", h)
+			}
+			cur = hashPair(cur, cur)
+		case j%2 == 0:
+			cur = hashPair(cur, sib)
+		default:
+			cur = hashPair(sib, cur)
+		}
+	}
+	if cur != root {
+		return errors.New("root mismatch")
+	}
+	return nil
+}
+
+// VerifyConsistency checks the proof of the tree with newSize leaves and
+// newRoot being an extension of the tree with oldSize leaves and oldRoot.
+func VerifyConsistency(oldRoot, newRoot util.Uint256, oldSize, newSize int, proof []util.Uint256) error {
+	if oldSize <= 0 || oldSize > newSize {
+		return fmt.Errorf("invalid tree sizes %d and %d", oldSize, newSize)
+	}
+	var (
+		subs = subtrees(oldSize, newSize)
+		nOld = bits.OnesCount(uint(oldSize))
+	)
+	if len(proof) != len(subs) {
+		return fmt.Errorf("invalid proof length %d, expected %d", len(proof), len(subs))
+	}
+	for i := range subs {
+		subs[i].hash = proof[i]
+	}
+	if foldPeaks(subs[:nOld]) != oldRoot {
+		return errors.New("old root mismatch")
+	}
+	// New subtrees are aligned, so they're merged with the old peaks as if
+	// leaves were appended one by one.
+	var stack []subtree
+	for _, s := range subs {
+		stack = append(stack, s)
+		for len(stack) > 1 && stack[len(stack)-1].height == stack[len(stack)-2].height {
+			l, r := stack[len(stack)-2], stack[len(stack)-1]
+			stack = append(stack[:len(stack)-2], subtree{height: l.height + 1, index: l.index / 2, hash: hashPair(l.hash, r.hash)})
+		}
+	}
+	if foldPeaks(stack) != newRoot {
+		return errors.New("new root mismatch")
+	}
+	return nil
+}
+
+// subtree is a complete subtree of 2^height leaves with the given index at
+// its level.
+type subtree struct {
+	height int
+	index  int
+	hash   util.Uint256
+}
+
+// subtrees returns the peaks of the tree of oldSize leaves followed by the
+// maximal aligned complete subtrees covering the leaves from oldSize to
+// newSize.
+func subtrees(oldSize, newSize int) []subtree {
+	var res []subtree
+	for h := bits.Len(uint(oldSize)) - 1; h >= 0; h-- {
+		if oldSize&(1<<h) != 0 {
+			res = append(res, subtree{height: h, index: (oldSize >> h) - 1})
+		}
+	}
+	for pos := oldSize; pos < newSize; {
+		h := bits.TrailingZeros(uint(pos))
+		for pos+(1<<h) > newSize {
+			h--
+		}
+		res = append(res, subtree{height: h, index: pos >> h})
+		pos += 1 << h
+	}
+	return res
+}
+
+// foldPeaks computes the tree root from its peaks given in the descending
+// order of their sizes. The smallest peak is paired with itself up to the
+// height of the next one and then with it, this repeats up to the top of the
+// tree.
+func foldPeaks(peaks []subtree) util.Uint256 {
+	var (
+		last = len(peaks) - 1
+		cur  = peaks[last].hash
+		h    = peaks[last].height
+	)
+	for i := last - 1; i >= 0; i-- {
+		for ; h < peaks[i].height; h++ {
+			cur = hashPair(cur, cur)
+		}
+		cur = hashPair(peaks[i].hash, cur)
+		h++
+	}
+	return cur
+}
+
+// node returns the hash of the node with the given index at the given level
+// of the tree with the given number of leaves. Incomplete nodes (only the
+// last one at each level can be incomplete) are computed recursively.
+func (t *Tree) node(h, j, size int) util.Uint256 {
+	if (j+1)<<h <= size {
+		return t.levels[h][j]
+	}
+	left := t.node(h-1, 2*j, size)
+	if 2*j+1 >= levelLen(h-1, size) {
+		return hashPair(left, left)
+	}
+	return hashPair(left, t.node(h-1, 2*j+1, size))
+}
+
+// depth returns the number of levels above leaves in the tree of the given
+// (positive) size.
+func depth(size int) int {
+	return bits.Len(uint(size - 1))
+}
+
+// levelLen returns the number of nodes at the given level of the tree of the
+// given size.
+func levelLen(h, size int) int {
+	return (size + 1<<h - 1) >> h
+}
+
+func hashPair(l, r util.Uint256) util.Uint256 {
+	var b [2 * util.Uint256Size]byte
+	copy(b[:], l.BytesBE())
+	copy(b[util.Uint256Size:], r.BytesBE())
+	return hash.DoubleSha256(b[:])
+}
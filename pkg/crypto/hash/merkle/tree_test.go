@@ -0,0 +1,113 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func getLeaves(n int) []util.Uint256 {
+	var res = make([]util.Uint256, n)
+	for i := range res {
+		res[i] = random.Uint256()
+	}
+	return res
+}
+
+func TestTreeRoot(t *testing.T) {
+	var (
+		leaves = getLeaves(70)
+		tr     = NewTree()
+		roots  = []util.Uint256{{}}
+	)
+	require.Equal(t, util.Uint256{}, tr.Root())
+	for i := range leaves {
+		tr.Append(leaves[i])
+		require.Equal(t, i+1, tr.Len())
+		expected := hash.CalcMerkleRoot(append([]util.Uint256{}, leaves[:i+1]...))
+		require.Equal(t, expected, tr.Root(), i+1)
+		roots = append(roots, expected)
+	}
+	for i, r := range roots {
+		actual, err := tr.RootAt(i)
+		require.NoError(t, err)
+		require.Equal(t, r, actual, i)
+	}
+	_, err := tr.RootAt(len(leaves) + 1)
+	require.Error(t, err)
+	require.Equal(t, tr.Root(), NewTree(leaves...).Root())
+}
+
+func TestTreeProof(t *testing.T) {
+	leaves := getLeaves(33)
+	for n := 1; n <= len(leaves); n++ {
+		var (
+			tr   = NewTree(leaves[:n]...)
+			root = tr.Root()
+		)
+		for i := range n {
+			proof, err := tr.Proof(i)
+			require.NoError(t, err)
+			require.NoError(t, VerifyProof(root, leaves[i], i, n, proof), "%d/%d", i, n)
+
+			if n > 1 {
+				require.Error(t, VerifyProof(root, leaves[(i+1)%n], i, n, proof))
+				require.Error(t, VerifyProof(root, leaves[i], i, n, proof[1:]))
+			}
+		}
+		_, err := tr.Proof(n)
+		require.Error(t, err)
+		require.Error(t, VerifyProof(root, leaves[0], n, n, nil))
+	}
+	t.Run("duplicated node", func(t *testing.T) {
+		// The last leaf of odd-sized tree is paired with itself, a proof
+		// can't claim it's paired with some other node.
+		tr := NewTree(leaves[:3]...)
+		proof, err := tr.Proof(2)
+		require.NoError(t, err)
+		require.Equal(t, leaves[2], proof[0])
+		proof[0] = leaves[1]
+		require.Error(t, VerifyProof(tr.Root(), leaves[2], 2, 3, proof))
+	})
+}
+
+func TestTreeConsistencyProof(t *testing.T) {
+	leaves := getLeaves(40)
+	tr := NewTree(leaves...)
+	for n := 1; n <= len(leaves); n++ {
+		newRoot, err := tr.RootAt(n)
+		require.NoError(t, err)
+		cur := NewTree(leaves[:n]...)
+		require.Equal(t, newRoot, cur.Root())
+		for m := 1; m <= n; m++ {
+			oldRoot, err := tr.RootAt(m)
+			require.NoError(t, err)
+			proof, err := cur.ConsistencyProof(m)
+			require.NoError(t, err)
+			require.NoError(t, VerifyConsistency(oldRoot, newRoot, m, n, proof), "%d/%d", m, n)
+
+			require.Error(t, VerifyConsistency(newRoot, oldRoot, m, n, proof[:len(proof)-1]))
+			if m != n {
+				require.Error(t, VerifyConsistency(newRoot, newRoot, m, n, proof))
+				require.Error(t, VerifyConsistency(oldRoot, oldRoot, m, n, proof))
+			}
+		}
+		_, err = cur.ConsistencyProof(0)
+		require.Error(t, err)
+		_, err = cur.ConsistencyProof(n + 1)
+		require.Error(t, err)
+	}
+	t.Run("different trees", func(t *testing.T) {
+		var (
+			other = NewTree(getLeaves(5)...)
+			tr    = NewTree(leaves[:10]...)
+		)
+		proof, err := tr.ConsistencyProof(5)
+		require.NoError(t, err)
+		require.Error(t, VerifyConsistency(other.Root(), tr.Root(), 5, 10, proof))
+		require.Error(t, VerifyConsistency(util.Uint256{}, tr.Root(), 0, 10, proof))
+	})
+}
@@ -0,0 +1,7 @@
+//go:build !keyaudit
+
+package keys
+
+// auditMode enables use-after-destroy checks for private keys, it's controlled
+// by keyaudit build tag.
+const auditMode = false
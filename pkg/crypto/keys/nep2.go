@@ -109,17 +109,27 @@ func NEP2Decrypt(key, passphrase string, params ScryptParams) (*PrivateKey, erro
 	privBytes := xor(decrypted, derivedKey1)
 	defer clear(privBytes)
 
-	// Rebuild the private key.
+	// Rebuild the private key. NEP-2 doesn't store the curve, but address hash
+	// allows to distinguish Secp256k1 keys.
 	privKey, err := NewPrivateKeyFromBytes(privBytes)
 	if err != nil {
 		return nil, err
 	}
+	if compareAddressHash(privKey, addrHash) {
+		return privKey, nil
+	}
+	privKey.Destroy()
 
-	if !compareAddressHash(privKey, addrHash) {
-		return nil, errors.New("password mismatch")
+	privKey, err = NewSecp256k1PrivateKeyFromBytes(privBytes)
+	if err != nil {
+		return nil, err
+	}
+	if compareAddressHash(privKey, addrHash) {
+		return privKey, nil
 	}
+	privKey.Destroy()
 
-	return privKey, nil
+	return nil, errors.New("password mismatch")
 }
 
 func compareAddressHash(priv *PrivateKey, inhash []byte) bool {
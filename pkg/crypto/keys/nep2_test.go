@@ -5,6 +5,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/internal/keytestcases"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNEP2Encrypt(t *testing.T) {
@@ -43,6 +44,24 @@ func TestNEP2Decrypt(t *testing.T) {
 	}
 }
 
+func TestNEP2Secp256k1(t *testing.T) {
+	const pass = "qwerty"
+
+	privKey, err := NewSecp256k1PrivateKey()
+	require.NoError(t, err)
+	encrypted, err := NEP2Encrypt(privKey, pass, NEP2ScryptParams())
+	require.NoError(t, err)
+
+	decrypted, err := NEP2Decrypt(encrypted, pass, NEP2ScryptParams())
+	require.NoError(t, err)
+	require.Equal(t, privKey.Bytes(), decrypted.Bytes())
+	require.Equal(t, privKey.Address(), decrypted.Address())
+	require.Equal(t, privKey.PublicKey().Bytes(), decrypted.PublicKey().Bytes())
+
+	_, err = NEP2Decrypt(encrypted, pass+"1", NEP2ScryptParams())
+	require.Error(t, err)
+}
+
 func TestNEP2DecryptErrors(t *testing.T) {
 	p := "qwerty"
 
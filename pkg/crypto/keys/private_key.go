@@ -20,6 +20,8 @@ import (
 // ecdsa.PrivateKey.
 type PrivateKey struct {
 	ecdsa.PrivateKey
+
+	destroyed bool
 }
 
 // NewPrivateKey creates a new random Secp256r1 private key.
@@ -38,7 +40,7 @@ func newPrivateKeyOnCurve(c elliptic.Curve) (*PrivateKey, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &PrivateKey{*pk}, nil
+	return &PrivateKey{PrivateKey: *pk}, nil
 }
 
 // NewPrivateKeyFromHex returns a Secp256k1 PrivateKey created from the
@@ -55,20 +57,29 @@ func NewPrivateKeyFromHex(str string) (*PrivateKey, error) {
 // NewPrivateKeyFromBytes returns a NEO Secp256r1 PrivateKey from the given
 // byte slice.
 func NewPrivateKeyFromBytes(b []byte) (*PrivateKey, error) {
+	return newPrivateKeyFromBytesOnCurve(b, elliptic.P256())
+}
+
+// NewSecp256k1PrivateKeyFromBytes returns a Secp256k1 PrivateKey from the
+// given byte slice.
+func NewSecp256k1PrivateKeyFromBytes(b []byte) (*PrivateKey, error) {
+	return newPrivateKeyFromBytesOnCurve(b, secp256k1.S256())
+}
+
+// newPrivateKeyFromBytesOnCurve creates a PrivateKey on curve c from the given
+// byte slice.
+func newPrivateKeyFromBytesOnCurve(b []byte, c elliptic.Curve) (*PrivateKey, error) {
 	if len(b) != 32 {
 		return nil, fmt.Errorf(
 			"invalid byte length: expected %d bytes got %d", 32, len(b),
 		)
 	}
-	var (
-		c = elliptic.P256()
-		d = new(big.Int).SetBytes(b)
-	)
+	var d = new(big.Int).SetBytes(b)
 
 	x, y := c.ScalarBaseMult(b)
 
 	return &PrivateKey{
-		ecdsa.PrivateKey{
+		PrivateKey: ecdsa.PrivateKey{
 			PublicKey: ecdsa.PublicKey{
 				Curve: c,
 				X:     x,
@@ -86,11 +97,12 @@ func NewPrivateKeyFromASN1(b []byte) (*PrivateKey, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &PrivateKey{*privkey}, nil
+	return &PrivateKey{PrivateKey: *privkey}, nil
 }
 
 // PublicKey derives the public key from the private key.
 func (p *PrivateKey) PublicKey() *PublicKey {
+	p.checkDestroyed()
 	result := PublicKey(p.PrivateKey.PublicKey)
 	return &result
 }
@@ -109,6 +121,7 @@ func NewPrivateKeyFromWIF(wif string) (*PrivateKey, error) {
 // Good documentation about this process can be found here:
 // https://en.bitcoin.it/wiki/Wallet_import_format
 func (p *PrivateKey) WIF() string {
+	p.checkDestroyed()
 	pb := p.Bytes()
 	defer clear(pb)
 	w, err := WIFEncode(pb, WIFVersion, true)
@@ -121,9 +134,25 @@ func (p *PrivateKey) WIF() string {
 }
 
 // Destroy wipes the contents of the private key from memory. Any operations
-// with the key after call to Destroy have undefined behavior.
+// with the key after call to Destroy have undefined behavior. If the code is
+// built with keyaudit tag, they panic, which allows to detect use-after-destroy
+// errors. Destroy can be called multiple times.
 func (p *PrivateKey) Destroy() {
 	clear(p.D.Bits())
+	p.D.SetInt64(0)
+	p.destroyed = true
+}
+
+// IsDestroyed returns true if the key was destroyed with Destroy.
+func (p *PrivateKey) IsDestroyed() bool {
+	return p.destroyed
+}
+
+// checkDestroyed panics if the key is used after Destroy in audit mode.
+func (p *PrivateKey) checkDestroyed() {
+	if auditMode && p.destroyed {
+		panic("keys: private key is used after Destroy")
+	}
 }
 
 // Address derives the public NEO address that is coupled with the private key, and
@@ -151,13 +180,18 @@ func (p *PrivateKey) Sign(data []byte) []byte {
 
 // SignHash signs a particular hash with the private key.
 func (p *PrivateKey) SignHash(digest util.Uint256) []byte {
+	p.checkDestroyed()
 	r, s := rfc6979.SignECDSA(&p.PrivateKey, digest[:], sha256.New)
 	return getSignatureSlice(p.PrivateKey.Curve, r, s)
 }
 
 // SignHashable signs some Hashable item for the network specified using
-// hash.NetSha256() with the private key.
+// hash.NetSha256() with the private key. Secp256k1 keys use hash.NetKeccak256()
+// instead to match their verification script.
 func (p *PrivateKey) SignHashable(net uint32, hh hash.Hashable) []byte {
+	if p.PublicKey().isSecp256k1() {
+		return p.SignHash(hash.NetKeccak256(net, hh))
+	}
 	return p.SignHash(hash.NetSha256(net, hh))
 }
 
@@ -178,6 +212,7 @@ func (p *PrivateKey) String() string {
 
 // Bytes returns the underlying bytes of the PrivateKey.
 func (p *PrivateKey) Bytes() []byte {
+	p.checkDestroyed()
 	result := make([]byte, 32)
 	_ = p.D.FillBytes(result)
 
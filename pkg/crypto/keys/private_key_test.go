@@ -34,6 +34,38 @@ func TestPrivateKey(t *testing.T) {
 	}
 }
 
+func TestPrivateKeyDestroy(t *testing.T) {
+	p, err := NewPrivateKey()
+	require.NoError(t, err)
+	bits := p.D.Bits()
+	require.False(t, p.IsDestroyed())
+
+	p.Destroy()
+	require.True(t, p.IsDestroyed())
+	require.Zero(t, p.D.Sign())
+	for _, w := range bits {
+		require.Zero(t, w)
+	}
+	p.Destroy() // No-op.
+
+	uses := map[string]func(){
+		"Bytes":        func() { p.Bytes() },
+		"WIF":          func() { p.WIF() },
+		"PublicKey":    func() { p.PublicKey() },
+		"Sign":         func() { p.Sign([]byte{1, 2, 3}) },
+		"SignHashable": func() { p.SignHashable(42, testHashable{}) },
+	}
+	for name, f := range uses {
+		t.Run(name, func(t *testing.T) {
+			if auditMode {
+				require.Panics(t, f)
+			} else {
+				require.NotPanics(t, f)
+			}
+		})
+	}
+}
+
 func TestNewPrivateKeyOnCurve(t *testing.T) {
 	msg := []byte{1, 2, 3}
 	h := hash.Sha256(msg).BytesBE()
@@ -299,7 +299,9 @@ func (p *PublicKey) EncodeBinary(w *io.BinWriter) {
 }
 
 // GetVerificationScript returns NEO VM bytecode with CHECKSIG command for the
-// public key.
+// public key. Secp256k1 keys can't be checked with CHECKSIG, so for them
+// a script calling CryptoLib's verifyWithECDsa method is returned (see
+// emit.CheckSecp256k1Sig).
 func (p *PublicKey) GetVerificationScript() []byte {
 	b := p.Bytes()
 	buf := io.NewBufBinWriter()
@@ -309,6 +311,10 @@ func (p *PublicKey) GetVerificationScript() []byte {
 		buf.WriteB(0xAC) // CHECKSIG
 		return buf.Bytes()
 	}
+	if p.isSecp256k1() {
+		emit.CheckSecp256k1Sig(buf.BinWriter, b)
+		return buf.Bytes()
+	}
 	emit.CheckSig(buf.BinWriter, b)
 
 	return buf.Bytes()
@@ -336,12 +342,25 @@ func (p *PublicKey) Verify(signature []byte, hash []byte) bool {
 }
 
 // VerifyHashable returns true if the signature is valid and corresponds
-// to the hash and public key.
+// to the hash and public key. Secp256k1 signatures are checked against
+// hash.NetKeccak256() (as the verification script does), all others use
+// hash.NetSha256().
 func (p *PublicKey) VerifyHashable(signature []byte, net uint32, hh hash.Hashable) bool {
-	var digest = hash.NetSha256(net, hh)
+	var digest util.Uint256
+	if p.isSecp256k1() {
+		digest = hash.NetKeccak256(net, hh)
+	} else {
+		digest = hash.NetSha256(net, hh)
+	}
 	return p.Verify(signature, digest[:])
 }
 
+// isSecp256k1 checks whether the key belongs to Secp256k1 curve.
+func (p *PublicKey) isSecp256k1() bool {
+	_, ok := p.Curve.(*secp256k1.KoblitzCurve)
+	return ok
+}
+
 // IsInfinity checks if the key is infinite (null, basically).
 func (p *PublicKey) IsInfinity() bool {
 	return p.X == nil && p.Y == nil
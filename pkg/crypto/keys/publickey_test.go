@@ -9,7 +9,11 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
@@ -126,6 +130,24 @@ func TestPubkeyToAddress(t *testing.T) {
 	require.Equal(t, expected, actual)
 }
 
+func TestSecp256k1VerificationScript(t *testing.T) {
+	k, err := NewSecp256k1PrivateKey()
+	require.NoError(t, err)
+	pub := k.PublicKey()
+
+	buf := io.NewBufBinWriter()
+	emit.CheckSecp256k1Sig(buf.BinWriter, pub.Bytes())
+	script := buf.Bytes()
+	require.Equal(t, script, pub.GetVerificationScript())
+	require.Equal(t, hash.Hash160(script), pub.GetScriptHash())
+
+	// Keys decoded with Secp256k1 curve produce the same script.
+	decoded, err := NewPublicKeyFromBytes(pub.Bytes(), secp256k1.S256())
+	require.NoError(t, err)
+	require.Equal(t, pub.GetVerificationScript(), decoded.GetVerificationScript())
+	require.Equal(t, k.Address(), decoded.Address())
+}
+
 func TestDecodeBytes(t *testing.T) {
 	pubKey := getPubKey(t)
 	var testBytesFunction = func(t *testing.T, bytesFunction func() []byte) {
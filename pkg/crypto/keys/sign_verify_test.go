@@ -7,6 +7,7 @@ import (
 
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -70,6 +71,31 @@ func TestPubKeyVerify(t *testing.T) {
 	})
 }
 
+type testHashable util.Uint256
+
+func (h testHashable) Hash() util.Uint256 { return util.Uint256(h) }
+
+func TestSecp256k1Hashable(t *testing.T) {
+	const net = 42
+	var item = testHashable(hash.Sha256([]byte("sample")))
+
+	k1, err := NewSecp256k1PrivateKey()
+	require.NoError(t, err)
+	pub := k1.PublicKey()
+	sig := k1.SignHashable(net, item)
+	require.True(t, pub.VerifyHashable(sig, net, item))
+	require.False(t, pub.VerifyHashable(sig, net+1, item))
+	// CryptoLib checks Keccak256 hash of the signed data.
+	require.True(t, pub.Verify(sig, hash.Keccak256(hash.GetSignedData(net, item)).BytesBE()))
+	require.False(t, pub.Verify(sig, hash.NetSha256(net, item).BytesBE()))
+
+	r1, err := NewPrivateKey()
+	require.NoError(t, err)
+	sig = r1.SignHashable(net, item)
+	require.True(t, r1.PublicKey().VerifyHashable(sig, net, item))
+	require.True(t, r1.PublicKey().Verify(sig, hash.NetSha256(net, item).BytesBE()))
+}
+
 func TestWrongPubKey(t *testing.T) {
 	sample := []byte("sample")
 	hashedData := hash.Sha256(sample)
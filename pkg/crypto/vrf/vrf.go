@@ -0,0 +1,226 @@
+/*
+Package vrf implements verifiable random function (VRF) as specified in RFC 9381
+for the ECVRF-P256-SHA256-TAI cipher suite.
+
+VRF is a public-key version of a keyed cryptographic hash. Only the private key
+holder can compute the hash (VRF output), but anyone with the public key can
+verify its correctness using the proof.
+*/
+package vrf
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+const (
+	// suiteString is the ECVRF-P256-SHA256-TAI suite identifier.
+	suiteString = 0x01
+
+	// ptLen is the length of compressed point encoding.
+	ptLen = 33
+	// cLen is the length of challenge encoding.
+	cLen = 16
+	// qLen is the length of scalar encoding.
+	qLen = 32
+
+	// ProofSize is the size of VRF proof in bytes.
+	ProofSize = ptLen + cLen + qLen
+	// OutputSize is the size of VRF output in bytes.
+	OutputSize = sha256.Size
+)
+
+// Domain separators of various hashing steps.
+const (
+	encodeToCurveDomain byte = 0x01
+	challengeDomain     byte = 0x02
+	proofToHashDomain   byte = 0x03
+	backDomain          byte = 0x00
+)
+
+// ErrInvalidProof is returned when VRF proof doesn't match the public key and
+// the input.
+var ErrInvalidProof = errors.New("invalid VRF proof")
+
+var curve = elliptic.P256()
+
+// Prove computes VRF proof for the given input using secp256r1 private key.
+func Prove(priv *keys.PrivateKey, alpha []byte) ([]byte, error) {
+	if priv.Curve != curve {
+		return nil, errors.New("unsupported curve")
+	}
+	x := priv.D
+	pk := priv.PublicKey().Bytes()
+	hx, hy, err := encodeToCurve(pk, alpha)
+	if err != nil {
+		return nil, err
+	}
+	h := elliptic.MarshalCompressed(curve, hx, hy)
+	gx, gy := curve.ScalarMult(hx, hy, x.Bytes())
+	k := generateNonce(x, h)
+	ux, uy := curve.ScalarBaseMult(k.Bytes())
+	vx, vy := curve.ScalarMult(hx, hy, k.Bytes())
+	gamma := elliptic.MarshalCompressed(curve, gx, gy)
+	c := challenge(pk, h, gamma, elliptic.MarshalCompressed(curve, ux, uy), elliptic.MarshalCompressed(curve, vx, vy))
+
+	n := curve.Params().N
+	s := new(big.Int).Mul(new(big.Int).SetBytes(c), x)
+	s.Add(s, k)
+	s.Mod(s, n)
+
+	proof := make([]byte, ProofSize)
+	copy(proof, gamma)
+	copy(proof[ptLen:], c)
+	s.FillBytes(proof[ptLen+cLen:])
+	return proof, nil
+}
+
+// Verify checks VRF proof for the given input against the secp256r1 public key
+// and returns VRF output if the proof is valid.
+func Verify(pub *keys.PublicKey, alpha, proof []byte) ([]byte, error) {
+	if pub.Curve != curve {
+		return nil, errors.New("unsupported curve")
+	}
+	if pub.IsInfinity() {
+		return nil, errors.New("invalid public key")
+	}
+	gx, gy, c, s, err := decodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	pk := pub.Bytes()
+	hx, hy, err := encodeToCurve(pk, alpha)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		n    = curve.Params().N
+		negC = new(big.Int).Sub(n, c)
+	)
+	// U = s*B - c*Y.
+	ux, uy := curve.ScalarBaseMult(s.Bytes())
+	tx, ty := curve.ScalarMult(pub.X, pub.Y, negC.Bytes())
+	ux, uy = curve.Add(ux, uy, tx, ty)
+	// V = s*H - c*Gamma.
+	vx, vy := curve.ScalarMult(hx, hy, s.Bytes())
+	tx, ty = curve.ScalarMult(gx, gy, negC.Bytes())
+	vx, vy = curve.Add(vx, vy, tx, ty)
+
+	expected := challenge(pk, elliptic.MarshalCompressed(curve, hx, hy), proof[:ptLen],
+		elliptic.MarshalCompressed(curve, ux, uy), elliptic.MarshalCompressed(curve, vx, vy))
+	if !hmac.Equal(expected, proof[ptLen:ptLen+cLen]) {
+		return nil, ErrInvalidProof
+	}
+	return proofToHash(proof[:ptLen]), nil
+}
+
+// ProofToHash returns VRF output for the given proof. It doesn't verify the
+// proof, so it should only be used for proofs that are known to be valid.
+func ProofToHash(proof []byte) ([]byte, error) {
+	if _, _, _, _, err := decodeProof(proof); err != nil {
+		return nil, err
+	}
+	return proofToHash(proof[:ptLen]), nil
+}
+
+func proofToHash(gamma []byte) []byte {
+	// Cofactor of P-256 is 1, so Gamma is used as is.
+	h := sha256.New()
+	h.Write([]byte{suiteString, proofToHashDomain})
+	h.Write(gamma)
+	h.Write([]byte{backDomain})
+	return h.Sum(nil)
+}
+
+func decodeProof(proof []byte) (*big.Int, *big.Int, *big.Int, *big.Int, error) {
+	if len(proof) != ProofSize {
+		return nil, nil, nil, nil, fmt.Errorf("invalid proof length: expected %d, got %d", ProofSize, len(proof))
+	}
+	gx, gy := elliptic.UnmarshalCompressed(curve, proof[:ptLen])
+	if gx == nil {
+		return nil, nil, nil, nil, errors.New("invalid Gamma point")
+	}
+	c := new(big.Int).SetBytes(proof[ptLen : ptLen+cLen])
+	s := new(big.Int).SetBytes(proof[ptLen+cLen:])
+	if s.Cmp(curve.Params().N) >= 0 {
+		return nil, nil, nil, nil, errors.New("invalid s scalar")
+	}
+	return gx, gy, c, s, nil
+}
+
+// encodeToCurve hashes the input to the curve point using try-and-increment
+// method with the public key as a salt.
+func encodeToCurve(salt, alpha []byte) (*big.Int, *big.Int, error) {
+	var buf = make([]byte, 0, ptLen)
+	for ctr := range 256 {
+		h := sha256.New()
+		h.Write([]byte{suiteString, encodeToCurveDomain})
+		h.Write(salt)
+		h.Write(alpha)
+		h.Write([]byte{byte(ctr), backDomain})
+		buf = append(buf[:0], 0x02)
+		buf = h.Sum(buf)
+		x, y := elliptic.UnmarshalCompressed(curve, buf)
+		if x != nil {
+			return x, y, nil
+		}
+	}
+	return nil, nil, errors.New("failed to encode input to curve")
+}
+
+// challenge computes truncated challenge hash for the given points.
+func challenge(points ...[]byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{suiteString, challengeDomain})
+	for _, p := range points {
+		h.Write(p)
+	}
+	h.Write([]byte{backDomain})
+	return h.Sum(nil)[:cLen]
+}
+
+// generateNonce generates deterministic nonce as specified in RFC 6979
+// section 3.2 for SHA-256 and P-256 group order.
+func generateNonce(x *big.Int, h []byte) *big.Int {
+	var (
+		n     = curve.Params().N
+		hash  = sha256.Sum256(h)
+		bx    = make([]byte, 0, 2*qLen)
+		v     = make([]byte, sha256.Size)
+		k     = make([]byte, sha256.Size)
+		hmacF = func(key []byte, data ...[]byte) []byte {
+			m := hmac.New(sha256.New, key)
+			for _, d := range data {
+				m.Write(d)
+			}
+			return m.Sum(nil)
+		}
+	)
+	bx = x.FillBytes(bx[:qLen])
+	h1 := new(big.Int).SetBytes(hash[:])
+	h1.Mod(h1, n)
+	bx = append(bx, h1.FillBytes(make([]byte, qLen))...)
+
+	for i := range v {
+		v[i] = 0x01
+	}
+	k = hmacF(k, v, []byte{0x00}, bx)
+	v = hmacF(k, v)
+	k = hmacF(k, v, []byte{0x01}, bx)
+	v = hmacF(k, v)
+	for {
+		v = hmacF(k, v)
+		nonce := new(big.Int).SetBytes(v)
+		if nonce.Sign() > 0 && nonce.Cmp(n) < 0 {
+			return nonce
+		}
+		k = hmacF(k, v, []byte{0x00})
+		v = hmacF(k, v)
+	}
+}
@@ -0,0 +1,96 @@
+package vrf
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRFC9381Vector(t *testing.T) {
+	// RFC 9381, Appendix B.1, example 10.
+	priv, err := keys.NewPrivateKeyFromHex("c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721")
+	require.NoError(t, err)
+	require.Equal(t, "0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6", hex.EncodeToString(priv.PublicKey().Bytes()))
+
+	alpha := []byte("sample")
+	proof, err := Prove(priv, alpha)
+	require.NoError(t, err)
+	require.Equal(t, "035b5c726e8c0e2c488a107c600578ee75cb702343c153cb1eb8dec77f4b5071b4a53f0a46f018bc2c56e58d383f2305e0975972c26feea0eb122fe7893c15af376b33edf7de17c6ea056d4d82de6bc02f", hex.EncodeToString(proof))
+
+	beta, err := Verify(priv.PublicKey(), alpha, proof)
+	require.NoError(t, err)
+	require.Equal(t, "a3ad7b0ef73d8fc6655053ea22f9bede8c743f08bbed3d38821f0e16474b505e", hex.EncodeToString(beta))
+
+	beta2, err := ProofToHash(proof)
+	require.NoError(t, err)
+	require.Equal(t, beta, beta2)
+}
+
+func TestProveVerify(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+	alpha := []byte("neo-go")
+
+	proof, err := Prove(priv, alpha)
+	require.NoError(t, err)
+	require.Len(t, proof, ProofSize)
+
+	beta, err := Verify(pub, alpha, proof)
+	require.NoError(t, err)
+	require.Len(t, beta, OutputSize)
+
+	t.Run("deterministic", func(t *testing.T) {
+		p, err := Prove(priv, alpha)
+		require.NoError(t, err)
+		require.Equal(t, proof, p)
+	})
+	t.Run("another message", func(t *testing.T) {
+		_, err := Verify(pub, []byte("neo"), proof)
+		require.ErrorIs(t, err, ErrInvalidProof)
+	})
+	t.Run("another key", func(t *testing.T) {
+		other, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		_, err = Verify(other.PublicKey(), alpha, proof)
+		require.ErrorIs(t, err, ErrInvalidProof)
+	})
+	t.Run("tampered proof", func(t *testing.T) {
+		for _, i := range []int{ptLen, ptLen + cLen, ProofSize - 1} {
+			bad := bytes.Clone(proof)
+			bad[i] ^= 1
+			_, err := Verify(pub, alpha, bad)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		}
+	})
+	t.Run("bad length", func(t *testing.T) {
+		_, err := Verify(pub, alpha, proof[1:])
+		require.Error(t, err)
+		_, err = ProofToHash(proof[1:])
+		require.Error(t, err)
+	})
+	t.Run("bad Gamma", func(t *testing.T) {
+		bad := bytes.Clone(proof)
+		bad[0] = 0x04
+		_, err := Verify(pub, alpha, bad)
+		require.Error(t, err)
+	})
+	t.Run("bad s", func(t *testing.T) {
+		bad := bytes.Clone(proof)
+		copy(bad[ptLen+cLen:], bytes.Repeat([]byte{0xff}, qLen))
+		_, err := Verify(pub, alpha, bad)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrInvalidProof)
+	})
+	t.Run("unsupported curve", func(t *testing.T) {
+		k, err := keys.NewSecp256k1PrivateKey()
+		require.NoError(t, err)
+		_, err = Prove(k, alpha)
+		require.Error(t, err)
+		_, err = Verify(k.PublicKey(), alpha, proof)
+		require.Error(t, err)
+	})
+}
@@ -2,16 +2,21 @@ package fixedn
 
 import (
 	"errors"
-	"fmt"
 	"math/big"
-	"strconv"
 	"strings"
 )
 
 const maxAllowedPrecision = 16
 
-// ErrInvalidFormat is returned when decimal format is invalid.
-var ErrInvalidFormat = errors.New("invalid decimal format")
+// Various errors.
+var (
+	// ErrInvalidFormat is returned when decimal format is invalid.
+	ErrInvalidFormat = errors.New("invalid decimal format")
+	// ErrDivisionByZero is returned on attempt to divide by zero.
+	ErrDivisionByZero = errors.New("division by zero")
+	// ErrOverflow is returned when the result doesn't fit into the target type.
+	ErrOverflow = errors.New("value overflow")
+)
 
 var _pow10 []*big.Int
 
@@ -36,45 +41,139 @@ func pow10(n int) *big.Int {
 	return p
 }
 
+// RoundingMode specifies the way values that can't be represented with the
+// given precision exactly are rounded.
+type RoundingMode byte
+
+// Rounding modes.
+const (
+	// RoundDown discards extra digits, rounding towards zero.
+	RoundDown RoundingMode = iota
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundHalfUp rounds to the nearest value, halves are rounded away from
+	// zero.
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest value, halves are rounded to the
+	// nearest even value (banker's rounding).
+	RoundHalfEven
+)
+
 // ToString converts a big decimal with the specified precision to a string.
 func ToString(bi *big.Int, precision int) string {
 	var dp, fp big.Int
-	dp.QuoRem(bi, pow10(precision), &fp)
+	dp.QuoRem(new(big.Int).Abs(bi), pow10(precision), &fp)
 
 	var s = dp.String()
+	if bi.Sign() < 0 {
+		s = "-" + s
+	}
 	if fp.Sign() == 0 {
 		return s
 	}
-	frac := fp.Uint64()
-	trimmed := 0
-	for ; frac%10 == 0; frac /= 10 {
-		trimmed++
-	}
-	return s + "." + fmt.Sprintf("%0"+strconv.FormatUint(uint64(precision-trimmed), 10)+"d", frac)
+	frac := fp.String()
+	frac = strings.Repeat("0", precision-len(frac)) + frac
+	return s + "." + strings.TrimRight(frac, "0")
 }
 
 // FromString converts a string to a big decimal with the specified precision.
+// An error is returned if the number has more fractional digits than
+// precision allows, use [ParseString] to round such values.
 func FromString(s string, precision int) (*big.Int, error) {
-	parts := strings.SplitN(s, ".", 2)
-	bi, ok := new(big.Int).SetString(parts[0], 10)
+	bi, exact, err := parse(s, precision, RoundDown)
+	if err == nil && !exact {
+		err = ErrInvalidFormat
+	}
+	return bi, err
+}
+
+// ParseString converts a string with an arbitrary number of fractional digits
+// to a big decimal with the specified precision rounding it with the given
+// mode if needed.
+func ParseString(s string, precision int, mode RoundingMode) (*big.Int, error) {
+	bi, _, err := parse(s, precision, mode)
+	return bi, err
+}
+
+// parse parses s and returns a big decimal with the specified precision and
+// a flag signaling whether it's exact (no rounding was performed).
+func parse(s string, precision int, mode RoundingMode) (*big.Int, bool, error) {
+	ip, fp, found := strings.Cut(s, ".")
+	if ip == "" || ip == "-" || ip == "+" || found && (fp == "" || fp[0] == '-' || fp[0] == '+') {
+		return nil, false, ErrInvalidFormat
+	}
+	bi, ok := new(big.Int).SetString(ip+fp, 10)
 	if !ok {
-		return nil, ErrInvalidFormat
+		return nil, false, ErrInvalidFormat
 	}
-	bi.Mul(bi, pow10(precision))
-	if len(parts) == 1 {
-		return bi, nil
+	if len(fp) <= precision {
+		return bi.Mul(bi, pow10(precision-len(fp))), true, nil
 	}
+	var (
+		d    = pow10(len(fp) - precision)
+		rem  = new(big.Int)
+		res  = new(big.Int)
+		exct bool
+	)
+	res.QuoRem(bi, d, rem)
+	exct = rem.Sign() == 0
+	return round(res, rem, d, mode), exct, nil
+}
 
-	if len(parts[1]) > precision {
-		return nil, ErrInvalidFormat
+// Rescale converts a big decimal with the from precision to a big decimal with
+// the to precision rounding it with the given mode if needed.
+func Rescale(bi *big.Int, from, to int, mode RoundingMode) *big.Int {
+	if from <= to {
+		return new(big.Int).Mul(bi, pow10(to-from))
 	}
-	fp, ok := new(big.Int).SetString(parts[1], 10)
-	if !ok {
-		return nil, ErrInvalidFormat
+	return quo(bi, pow10(from-to), mode)
+}
+
+// Mul multiplies two big decimals with the specified precision and returns the
+// result with the same precision rounding it with the given mode if needed.
+func Mul(a, b *big.Int, precision int, mode RoundingMode) *big.Int {
+	return quo(new(big.Int).Mul(a, b), pow10(precision), mode)
+}
+
+// Div divides a by b (both being big decimals with the specified precision)
+// and returns the result with the same precision rounding it with the given
+// mode if needed. ErrDivisionByZero is returned if b is zero.
+func Div(a, b *big.Int, precision int, mode RoundingMode) (*big.Int, error) {
+	if b.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	return quo(new(big.Int).Mul(a, pow10(precision)), b, mode), nil
+}
+
+// quo returns n/d rounded with the given mode.
+func quo(n, d *big.Int, mode RoundingMode) *big.Int {
+	var q, r big.Int
+	q.QuoRem(n, d, &r)
+	return round(&q, &r, d, mode)
+}
+
+// round adjusts truncated quotient q using remainder r of division by d
+// according to the rounding mode. q is modified and returned.
+func round(q, r, d *big.Int, mode RoundingMode) *big.Int {
+	if r.Sign() == 0 {
+		return q
+	}
+	var up bool
+	switch mode {
+	case RoundUp:
+		up = true
+	case RoundHalfUp, RoundHalfEven:
+		var r2 = new(big.Int).Abs(r)
+		r2.Lsh(r2, 1)
+		c := r2.CmpAbs(d)
+		up = c > 0 || c == 0 && (mode == RoundHalfUp || q.Bit(0) == 1)
+	}
+	if !up {
+		return q
 	}
-	fp.Mul(fp, pow10(precision-len(parts[1])))
-	if bi.Sign() == -1 {
-		return bi.Sub(bi, fp), nil
+	// The remainder has the sign of the dividend, so it points away from zero.
+	if r.Sign()*d.Sign() < 0 {
+		return q.Sub(q, big.NewInt(1))
 	}
-	return bi.Add(bi, fp), nil
+	return q.Add(q, big.NewInt(1))
 }
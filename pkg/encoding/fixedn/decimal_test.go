@@ -20,6 +20,9 @@ func TestDecimalFromStringGood(t *testing.T) {
 		{big.NewInt(35), 8, "0.00000035"},
 		{big.NewInt(1230), 5, "0.0123"},
 		{big.NewInt(123456789), 20, "0.00000000000123456789"},
+		{big.NewInt(-5), 1, "-0.5"},
+		{big.NewInt(-105), 2, "-1.05"},
+		{new(big.Int).Exp(big.NewInt(10), big.NewInt(60), nil), 77, "0.00000000000000001"},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.s, func(t *testing.T) {
@@ -43,6 +46,10 @@ func TestDecimalFromStringBad(t *testing.T) {
 		{"12A", 1},
 		{"12.345", 2},
 		{"12.3A", 2},
+		{"12.-3", 2},
+		{"12.", 2},
+		{".5", 2},
+		{"-.5", 2},
 	}
 	for _, tc := range errCases {
 		t.Run(tc.s, func(t *testing.T) {
@@ -51,3 +58,67 @@ func TestDecimalFromStringBad(t *testing.T) {
 		})
 	}
 }
+
+func TestDecimalParseString(t *testing.T) {
+	var testCases = []struct {
+		s    string
+		prec int
+		mode RoundingMode
+		res  int64
+	}{
+		{"1.234", 2, RoundDown, 123},
+		{"1.235", 2, RoundDown, 123},
+		{"1.231", 2, RoundUp, 124},
+		{"1.23", 2, RoundUp, 123},
+		{"1.235", 2, RoundHalfUp, 124},
+		{"1.2349", 2, RoundHalfUp, 123},
+		{"1.235", 2, RoundHalfEven, 124},
+		{"1.245", 2, RoundHalfEven, 124},
+		{"1.2451", 2, RoundHalfEven, 125},
+		{"-1.234", 2, RoundDown, -123},
+		{"-1.231", 2, RoundUp, -124},
+		{"-1.235", 2, RoundHalfUp, -124},
+		{"-1.245", 2, RoundHalfEven, -124},
+		{"12", 0, RoundUp, 12},
+		{"0.5", 0, RoundHalfUp, 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.s, func(t *testing.T) {
+			bi, err := ParseString(tc.s, tc.prec, tc.mode)
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(tc.res), bi)
+		})
+	}
+	_, err := ParseString("1.2A", 1, RoundDown)
+	require.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func TestDecimalArith(t *testing.T) {
+	var (
+		a = big.NewInt(150) // 1.50
+		b = big.NewInt(-25) // -0.25
+	)
+	require.Equal(t, big.NewInt(-37), Mul(a, b, 2, RoundDown))
+	require.Equal(t, big.NewInt(-38), Mul(a, b, 2, RoundUp))
+	require.Equal(t, big.NewInt(-38), Mul(a, b, 2, RoundHalfUp))
+	require.Equal(t, big.NewInt(-38), Mul(a, b, 2, RoundHalfEven))
+
+	r, err := Div(a, b, 2, RoundDown)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(-600), r)
+	r, err = Div(big.NewInt(100), big.NewInt(300), 2, RoundHalfUp)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(33), r)
+	r, err = Div(big.NewInt(200), big.NewInt(300), 2, RoundHalfUp)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(67), r)
+	_, err = Div(a, big.NewInt(0), 2, RoundDown)
+	require.ErrorIs(t, err, ErrDivisionByZero)
+
+	require.Equal(t, big.NewInt(15000), Rescale(a, 2, 4, RoundDown))
+	require.Equal(t, big.NewInt(1), Rescale(a, 2, 0, RoundDown))
+	require.Equal(t, big.NewInt(2), Rescale(a, 2, 0, RoundHalfUp))
+	require.Equal(t, big.NewInt(2), Rescale(a, 2, 0, RoundHalfEven))
+	require.Zero(t, Rescale(b, 2, 0, RoundHalfUp).Sign())
+	require.Equal(t, big.NewInt(-1), Rescale(b, 2, 0, RoundUp))
+}
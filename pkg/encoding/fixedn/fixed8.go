@@ -2,6 +2,7 @@ package fixedn
 
 import (
 	"cmp"
+	"math/big"
 	"strconv"
 	"strings"
 
@@ -72,7 +73,21 @@ func Fixed8FromString(s string) (Fixed8, error) {
 	if err != nil {
 		return 0, err
 	}
-	return Fixed8(num.Int64()), err
+	return Fixed8FromBigInt(num)
+}
+
+// Fixed8FromBigInt converts a big decimal with precision 10^-8 to Fixed8,
+// ErrOverflow is returned if it doesn't fit.
+func Fixed8FromBigInt(bi *big.Int) (Fixed8, error) {
+	if !bi.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return Fixed8(bi.Int64()), nil
+}
+
+// BigInt returns f as a big decimal with precision 10^-8.
+func (f Fixed8) BigInt() *big.Int {
+	return big.NewInt(int64(f))
 }
 
 // UnmarshalJSON implements the json unmarshaller interface.
@@ -144,6 +159,41 @@ func (f Fixed8) Sub(g Fixed8) Fixed8 {
 	return f - g
 }
 
+// AddChecked returns f+g or ErrOverflow if the result doesn't fit into Fixed8.
+func (f Fixed8) AddChecked(g Fixed8) (Fixed8, error) {
+	r := f + g
+	if (r > f) != (g > 0) {
+		return 0, ErrOverflow
+	}
+	return r, nil
+}
+
+// SubChecked returns f-g or ErrOverflow if the result doesn't fit into Fixed8.
+func (f Fixed8) SubChecked(g Fixed8) (Fixed8, error) {
+	r := f - g
+	if (r < f) != (g > 0) {
+		return 0, ErrOverflow
+	}
+	return r, nil
+}
+
+// MulChecked returns f*g rounded with the given mode or ErrOverflow if the
+// result doesn't fit into Fixed8.
+func (f Fixed8) MulChecked(g Fixed8, mode RoundingMode) (Fixed8, error) {
+	return Fixed8FromBigInt(Mul(f.BigInt(), g.BigInt(), precision, mode))
+}
+
+// DivChecked returns f/g rounded with the given mode. ErrDivisionByZero is
+// returned if g is zero and ErrOverflow is returned if the result doesn't fit
+// into Fixed8.
+func (f Fixed8) DivChecked(g Fixed8, mode RoundingMode) (Fixed8, error) {
+	r, err := Div(f.BigInt(), g.BigInt(), precision, mode)
+	if err != nil {
+		return 0, err
+	}
+	return Fixed8FromBigInt(r)
+}
+
 // LessThan implements Fixd8 < operator.
 func (f Fixed8) LessThan(g Fixed8) bool {
 	return f < g
@@ -81,6 +81,10 @@ func TestFixed8FromString(t *testing.T) {
 	val = "90.1s"
 	_, err = Fixed8FromString(val)
 	assert.Error(t, err)
+
+	val = "92233720368.54775808"
+	_, err = Fixed8FromString(val)
+	assert.ErrorIs(t, err, ErrOverflow)
 }
 
 func TestSatoshi(t *testing.T) {
@@ -169,6 +173,52 @@ func TestFixed8_Arith(t *testing.T) {
 	assert.EqualValues(t, Fixed8(2), u2.Div(3))
 }
 
+func TestFixed8_ArithChecked(t *testing.T) {
+	u1 := Fixed8FromInt64(3)
+	u2 := Fixed8FromInt64(8)
+
+	r, err := u1.AddChecked(u2)
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8FromInt64(11), r)
+	_, err = Fixed8(math.MaxInt64).AddChecked(Satoshi())
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = Fixed8(math.MinInt64).AddChecked(-Satoshi())
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	r, err = u1.SubChecked(u2)
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8FromInt64(-5), r)
+	_, err = Fixed8(math.MinInt64).SubChecked(Satoshi())
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = Fixed8(0).SubChecked(math.MinInt64)
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	r, err = u1.MulChecked(u2, RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8FromInt64(24), r)
+	r, err = Satoshi().MulChecked(Fixed8FromFloat(0.5), RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, Satoshi(), r)
+	_, err = Fixed8FromInt64(100000).MulChecked(Fixed8FromInt64(100000000), RoundDown)
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	r, err = u2.DivChecked(u1, RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8(266666666), r)
+	r, err = u2.DivChecked(u1, RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8(266666667), r)
+	_, err = u2.DivChecked(0, RoundDown)
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+	_, err = Fixed8(math.MaxInt64).DivChecked(Satoshi(), RoundDown)
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	bi := u1.BigInt()
+	r, err = Fixed8FromBigInt(bi)
+	assert.NoError(t, err)
+	assert.Equal(t, u1, r)
+}
+
 func TestFixed8_Serializable(t *testing.T) {
 	a := Fixed8(0x0102030405060708)
 
@@ -24,6 +24,27 @@ const (
 	Secp256r1Keccak256 NamedCurveHash = 123
 )
 
+// NamedCurve represents named elliptic curve.
+type NamedCurve byte
+
+// Various named elliptic curves.
+const (
+	Secp256k1 NamedCurve = 22
+	Secp256r1 NamedCurve = 23
+)
+
+// Hasher represents hash function applied to the message before signature
+// verification.
+type Hasher byte
+
+// Various hash functions.
+const (
+	// HasherNone is used for pre-hashed messages (32-byte digests).
+	HasherNone      Hasher = 0
+	HasherSha256    Hasher = 1
+	HasherKeccak256 Hasher = 2
+)
+
 // Sha256 calls `sha256` method of native CryptoLib contract and computes SHA256 hash of b.
 func Sha256(b []byte) interop.Hash256 {
 	return neogointernal.CallWithToken(Hash, "sha256", int(contract.NoneFlag), b).(interop.Hash256)
@@ -46,6 +67,16 @@ func VerifyWithECDsa(msg []byte, pub interop.PublicKey, sig interop.Signature, c
 	return neogointernal.CallWithToken(Hash, "verifyWithECDsa", int(contract.NoneFlag), msg, pub, sig, curveHash).(bool)
 }
 
+// VerifyWithECDsaHasher calls `verifyWithECDsa` method of native CryptoLib
+// contract and checks that sig is a correct msg's signature for the given pub
+// (serialized public key on the given curve). msg is hashed with the given
+// hasher before verification, HasherNone allows to verify signatures over
+// externally computed 32-byte digests. This method is available since Echidna
+// hardfork.
+func VerifyWithECDsaHasher(msg []byte, pub interop.PublicKey, sig interop.Signature, curve NamedCurve, hasher Hasher) bool {
+	return neogointernal.CallWithToken(Hash, "verifyWithECDsa", int(contract.NoneFlag), msg, pub, sig, curve, hasher).(bool)
+}
+
 // Bls12381Point represents BLS12-381 curve point (G1 or G2 in the Affine or
 // Jacobian form or GT). Bls12381Point structure is needed for the operations
 // with the curve's points (serialization, addition, multiplication, pairing and
@@ -95,8 +126,34 @@ func Bls12381Pairing(g1, g2 Bls12381Point) Bls12381Point {
 	return neogointernal.CallWithToken(Hash, "bls12381Pairing", int(contract.NoneFlag), g1, g2).(Bls12381Point)
 }
 
+// Bls12381IsOnCurve calls `bls12381IsOnCurve` method of native CryptoLib
+// contract and checks whether the given serialized BLS12-381 point (G1, G2 or
+// GT in compressed form) is properly encoded and lies on the curve. It doesn't
+// check the subgroup, so it's cheaper than Bls12381IsInSubgroup. This method
+// is only available on networks with NeoGoExtensions enabled.
+func Bls12381IsOnCurve(data []byte) bool {
+	return neogointernal.CallWithToken(Hash, "bls12381IsOnCurve", int(contract.NoneFlag), data).(bool)
+}
+
+// Bls12381IsInSubgroup calls `bls12381IsInSubgroup` method of native CryptoLib
+// contract and checks whether the given serialized BLS12-381 point lies on the
+// curve and belongs to the prime-order subgroup, i.e. whether it can be
+// deserialized with Bls12381Deserialize. This method is only available on
+// networks with NeoGoExtensions enabled.
+func Bls12381IsInSubgroup(data []byte) bool {
+	return neogointernal.CallWithToken(Hash, "bls12381IsInSubgroup", int(contract.NoneFlag), data).(bool)
+}
+
 // Keccak256 calls `keccak256` method of native CryptoLib contract and
 // computes Keccak256 hash of b.
 func Keccak256(b []byte) interop.Hash256 {
 	return neogointernal.CallWithToken(Hash, "keccak256", int(contract.NoneFlag), b).(interop.Hash256)
 }
+
+// VrfVerify calls `vrfVerify` method of native CryptoLib contract and checks
+// ECVRF-P256-SHA256-TAI (RFC 9381) proof of msg against secp256r1 public key
+// pub. It returns VRF output if the proof is valid and nil otherwise. This
+// method is only available on networks with NeoGoExtensions enabled.
+func VrfVerify(msg []byte, pub interop.PublicKey, proof []byte) []byte {
+	return neogointernal.CallWithToken(Hash, "vrfVerify", int(contract.NoneFlag), msg, pub, proof).([]byte)
+}
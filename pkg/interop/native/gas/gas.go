@@ -10,6 +10,14 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
 )
 
+// TransferEntry is a single transfer for MultiTransfer.
+type TransferEntry struct {
+	From   interop.Hash160
+	To     interop.Hash160
+	Amount int
+	Data   any
+}
+
 // Hash represents GAS contract hash.
 const Hash = "\xcf\x76\xe2\x8b\xd0\x06\x2c\x4a\x47\x8e\xe3\x55\x61\x01\x13\x19\xf3\xcf\xa4\xd2"
 
@@ -38,3 +46,10 @@ func Transfer(from, to interop.Hash160, amount int, data any) bool {
 	return neogointernal.CallWithToken(Hash, "transfer",
 		int(contract.All), from, to, amount, data).(bool)
 }
+
+// MultiTransfer represents `multiTransfer` method of GAS native contract.
+// Transfers are made atomically, either all of them succeed or none.
+func MultiTransfer(transfers []TransferEntry) bool {
+	return neogointernal.CallWithToken(Hash, "multiTransfer",
+		int(contract.All), transfers).(bool)
+}
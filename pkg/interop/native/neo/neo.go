@@ -21,6 +21,14 @@ type AccountState struct {
 	LastGasPerVote int
 }
 
+// TransferEntry is a single transfer for MultiTransfer.
+type TransferEntry struct {
+	From   interop.Hash160
+	To     interop.Hash160
+	Amount int
+	Data   any
+}
+
 // Hash represents NEO contract hash.
 const Hash = "\xf5\x63\xea\x40\xbc\x28\x3d\x4d\x0e\x05\xc4\x8e\xa3\x05\xb3\xf2\xa0\x73\x40\xef"
 
@@ -50,6 +58,13 @@ func Transfer(from, to interop.Hash160, amount int, data any) bool {
 		int(contract.All), from, to, amount, data).(bool)
 }
 
+// MultiTransfer represents `multiTransfer` method of NEO native contract.
+// Transfers are made atomically, either all of them succeed or none.
+func MultiTransfer(transfers []TransferEntry) bool {
+	return neogointernal.CallWithToken(Hash, "multiTransfer",
+		int(contract.All), transfers).(bool)
+}
+
 // GetCommittee represents `getCommittee` method of NEO native contract.
 func GetCommittee() []interop.PublicKey {
 	return neogointernal.CallWithToken(Hash, "getCommittee", int(contract.ReadStates)).([]interop.PublicKey)
@@ -43,5 +43,15 @@ func GetMaxNotValidBeforeDelta() int {
 
 // SetMaxNotValidBeforeDelta represents `setMaxNotValidBeforeDelta` method of Notary native contract.
 func SetMaxNotValidBeforeDelta(value int) {
-	neogointernal.CallWithTokenNoRet(Hash, "setMaxNotValidBeforeDelta", int(contract.States), value)
+	neogointernal.CallWithTokenNoRet(Hash, "setMaxNotValidBeforeDelta", int(contract.States|contract.AllowNotify), value)
+}
+
+// GetNotaryServiceFeePerKey represents `getNotaryServiceFeePerKey` method of Notary native contract.
+func GetNotaryServiceFeePerKey() int {
+	return neogointernal.CallWithToken(Hash, "getNotaryServiceFeePerKey", int(contract.ReadStates)).(int)
+}
+
+// SetNotaryServiceFeePerKey represents `setNotaryServiceFeePerKey` method of Notary native contract.
+func SetNotaryServiceFeePerKey(value int) {
+	neogointernal.CallWithTokenNoRet(Hash, "setNotaryServiceFeePerKey", int(contract.States|contract.AllowNotify), value)
 }
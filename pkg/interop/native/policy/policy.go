@@ -67,3 +67,19 @@ func BlockAccount(addr interop.Hash160) bool {
 func UnblockAccount(addr interop.Hash160) bool {
 	return neogointernal.CallWithToken(Hash, "unblockAccount", int(contract.States), addr).(bool)
 }
+
+// GetFeatureFlag represents `getFeatureFlag` method of Policy native contract.
+// It returns an integer flag value or nil if the flag is not set.
+func GetFeatureFlag(name string) any {
+	return neogointernal.CallWithToken(Hash, "getFeatureFlag", int(contract.ReadStates), name)
+}
+
+// SetFeatureFlag represents `setFeatureFlag` method of Policy native contract.
+func SetFeatureFlag(name string, value int) {
+	neogointernal.CallWithTokenNoRet(Hash, "setFeatureFlag", int(contract.States|contract.AllowNotify), name, value)
+}
+
+// DeleteFeatureFlag represents `deleteFeatureFlag` method of Policy native contract.
+func DeleteFeatureFlag(name string) {
+	neogointernal.CallWithTokenNoRet(Hash, "deleteFeatureFlag", int(contract.States|contract.AllowNotify), name)
+}
@@ -31,6 +31,15 @@ func GetDesignatedByRole(r Role, height uint32) []interop.PublicKey {
 		int(contract.ReadStates), r, height).([]interop.PublicKey)
 }
 
+// GetDesignationHeight represents `getDesignationHeight` method of RoleManagement
+// native contract. It returns the height starting from which the nodes
+// designated for the role at the given height are effective (0 if there are
+// none). It's available starting from Echidna hardfork.
+func GetDesignationHeight(r Role, height uint32) int {
+	return neogointernal.CallWithToken(Hash, "getDesignationHeight",
+		int(contract.ReadStates), r, height).(int)
+}
+
 // DesignateAsRole represents `designateAsRole` method of RoleManagement native contract.
 func DesignateAsRole(r Role, pubs []interop.PublicKey) {
 	neogointernal.CallWithTokenNoRet(Hash, "designateAsRole",
@@ -180,3 +180,80 @@ func StrLen(s string) int {
 	return neogointernal.CallWithToken(Hash, "strLen", int(contract.NoneFlag),
 		s).(int)
 }
+
+// TimestampToDays returns the number of full days passed since the Unix epoch
+// for the given timestamp in milliseconds (like the one returned by
+// runtime.GetTime). It uses `timestampToDays` method of StdLib native contract
+// available since Echidna hardfork.
+func TimestampToDays(timestamp int) int {
+	return neogointernal.CallWithToken(Hash, "timestampToDays", int(contract.NoneFlag),
+		timestamp).(int)
+}
+
+// Weekday returns the day of the week (0 for Sunday, 1 for Monday and so on)
+// for the given timestamp in milliseconds. It uses `weekday` method of StdLib
+// native contract available since Echidna hardfork.
+func Weekday(timestamp int) int {
+	return neogointernal.CallWithToken(Hash, "weekday", int(contract.NoneFlag),
+		timestamp).(int)
+}
+
+// IsLeapYear returns true if the given year is a leap one. It uses `isLeapYear`
+// method of StdLib native contract available since Echidna hardfork.
+func IsLeapYear(year int) bool {
+	return neogointernal.CallWithToken(Hash, "isLeapYear", int(contract.NoneFlag),
+		year).(bool)
+}
+
+// AddDays adds the given (possibly negative) number of days to the timestamp
+// in milliseconds. It uses `addDays` method of StdLib native contract
+// available since Echidna hardfork.
+func AddDays(timestamp, days int) int {
+	return neogointernal.CallWithToken(Hash, "addDays", int(contract.NoneFlag),
+		timestamp, days).(int)
+}
+
+// AddMonths adds the given (possibly negative) number of months to the
+// timestamp in milliseconds. The day is clamped to the last day of the
+// resulting month, so Jan 31 + 1 month is Feb 28 (or Feb 29 in leap years).
+// It uses `addMonths` method of StdLib native contract available since
+// Echidna hardfork.
+func AddMonths(timestamp, months int) int {
+	return neogointernal.CallWithToken(Hash, "addMonths", int(contract.NoneFlag),
+		timestamp, months).(int)
+}
+
+// AddYears adds the given (possibly negative) number of years to the
+// timestamp in milliseconds. Feb 29 is turned into Feb 28 for non-leap
+// resulting years. It uses `addYears` method of StdLib native contract
+// available since Echidna hardfork.
+func AddYears(timestamp, years int) int {
+	return neogointernal.CallWithToken(Hash, "addYears", int(contract.NoneFlag),
+		timestamp, years).(int)
+}
+
+// CBORSerialize serializes a value to CBOR using the deterministic encoding,
+// so the same value always has the same representation. It uses
+// `cborSerialize` method of StdLib native contract available since Echidna
+// hardfork. Serialization format is the following:
+//
+//	[]byte, string -> byte string
+//	bool -> true/false
+//	nil -> null
+//	(u)int* -> integer (bignum for values not fitting into 64 bits)
+//	[]any, structures -> array
+//	map[type1]type2 -> map with keys sorted by their encoding
+func CBORSerialize(item any) []byte {
+	return neogointernal.CallWithToken(Hash, "cborSerialize", int(contract.NoneFlag),
+		item).([]byte)
+}
+
+// CBORDeserialize deserializes a value from CBOR produced by CBORSerialize (or
+// any other deterministic encoder). It uses `cborDeserialize` method of
+// StdLib native contract available since Echidna hardfork. Byte and text
+// strings are deserialized into []byte, integers into int, arrays into []any
+// and maps into map[any]any.
+func CBORDeserialize(data []byte) any {
+	return neogointernal.CallWithToken(Hash, "cborDeserialize", int(contract.NoneFlag),
+		data)
+}
@@ -0,0 +1,16 @@
+package neogointernal
+
+// Asm emits raw NeoVM instructions given in code (which must be a constant
+// string) after pushing args onto the stack (the last argument ends up on
+// top). Instructions are separated by newlines or semicolons, each one is an
+// opcode name with an optional operand, like "PUSHINT8 5; ADD". Jumps, calls,
+// exception handling and slot management instructions are not allowed. The
+// code is expected to leave exactly one item on the stack that is returned.
+func Asm(code string, args ...any) any {
+	return nil
+}
+
+// AsmNoReturn is similar to Asm, but the code is expected to leave nothing
+// on the stack.
+func AsmNoReturn(code string, args ...any) {
+}
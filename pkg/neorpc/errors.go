@@ -136,6 +136,13 @@ const (
 	ErrInvalidProofCode = -607
 	// ErrExecutionFailedCode is returned from a call made a VM execution, but it has failed.
 	ErrExecutionFailedCode = -608
+	// ErrAppLogPrunedCode is returned if application log requested is removed because this node is
+	// configured to keep logs for a limited number of blocks only.
+	ErrAppLogPrunedCode = -609
+	// ErrConsensusDisabledCode is returned if consensus state is requested, but it's not available
+	// because either the consensus service is not running or the request is not allowed by the
+	// RPC server configuration.
+	ErrConsensusDisabledCode = -610
 )
 
 var (
@@ -268,6 +275,12 @@ var (
 	// ErrExecutionFailed represents an error with code [ErrExecutionFailedCode].
 	// Call made a VM execution, but it has failed.
 	ErrExecutionFailed = NewErrorWithCode(ErrExecutionFailedCode, "Execution failed")
+	// ErrAppLogPruned represents an error with code [ErrAppLogPrunedCode].
+	// Application log is removed because this node keeps logs for a limited number of blocks only.
+	ErrAppLogPruned = NewErrorWithCode(ErrAppLogPrunedCode, "Application log is pruned")
+	// ErrConsensusDisabled represents an error with code [ErrConsensusDisabledCode].
+	// Consensus service is not running or its state is not exposed via RPC.
+	ErrConsensusDisabled = NewErrorWithCode(ErrConsensusDisabledCode, "Consensus state is not available")
 )
 
 // NewError is an Error constructor that takes Error contents from its parameters.
@@ -24,6 +24,12 @@ const (
 	NotaryRequestEventID
 	// HeaderOfAddedBlockEventID is used for the `header_of_added_block` event.
 	HeaderOfAddedBlockEventID
+	// StorageBatchEventID is used for `storage_batch` events sent in response
+	// to `findstoragestream` requests. It can't be subscribed to.
+	StorageBatchEventID
+	// BlockRejectedEventID is used for `block_rejected` events sent when a
+	// block execution results of which were streamed fails to be stored.
+	BlockRejectedEventID
 	// MissedEventID notifies user of missed events.
 	MissedEventID EventID = 255
 )
@@ -43,6 +49,10 @@ func (e EventID) String() string {
 		return "notary_request_event"
 	case HeaderOfAddedBlockEventID:
 		return "header_of_added_block"
+	case StorageBatchEventID:
+		return "storage_batch"
+	case BlockRejectedEventID:
+		return "block_rejected"
 	case MissedEventID:
 		return "event_missed"
 	default:
@@ -65,6 +75,10 @@ func GetEventIDFromString(s string) (EventID, error) {
 		return NotaryRequestEventID, nil
 	case "header_of_added_block":
 		return HeaderOfAddedBlockEventID, nil
+	case "storage_batch":
+		return StorageBatchEventID, nil
+	case "block_rejected":
+		return BlockRejectedEventID, nil
 	case "event_missed":
 		return MissedEventID, nil
 	default:
@@ -0,0 +1,67 @@
+package result
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// AccountState is the NEO account state returned by getaccountstates call
+// along with the GAS amount that can be claimed by the account.
+type AccountState struct {
+	Address       util.Uint160
+	Balance       big.Int
+	BalanceHeight uint32
+	VoteTo        *keys.PublicKey
+	Unclaimed     big.Int
+}
+
+// accountState is an auxiliary struct for JSON marshalling.
+type accountState struct {
+	Address       string          `json:"address"`
+	Balance       string          `json:"balance"`
+	BalanceHeight uint32          `json:"balanceheight"`
+	VoteTo        *keys.PublicKey `json:"voteto"`
+	Unclaimed     string          `json:"unclaimed"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s AccountState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&accountState{
+		Address:       address.Uint160ToString(s.Address),
+		Balance:       s.Balance.String(),
+		BalanceHeight: s.BalanceHeight,
+		VoteTo:        s.VoteTo,
+		Unclaimed:     s.Unclaimed.String(),
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *AccountState) UnmarshalJSON(data []byte) error {
+	aux := new(accountState)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	addr, err := address.StringToUint160(aux.Address)
+	if err != nil {
+		return err
+	}
+	bal, ok := new(big.Int).SetString(aux.Balance, 10)
+	if !ok {
+		return errors.New("failed to convert balance")
+	}
+	uncl, ok := new(big.Int).SetString(aux.Unclaimed, 10)
+	if !ok {
+		return errors.New("failed to convert unclaimed gas")
+	}
+	s.Address = addr
+	s.Balance = *bal
+	s.BalanceHeight = aux.BalanceHeight
+	s.VoteTo = aux.VoteTo
+	s.Unclaimed = *uncl
+	return nil
+}
@@ -0,0 +1,66 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+type (
+	// ConsensusState is a snapshot of the dBFT consensus process state used
+	// in `getconsensusstate` RPC call.
+	ConsensusState struct {
+		// Height is the index of the block being agreed upon.
+		Height uint32 `json:"height"`
+		// View is the current view number.
+		View byte `json:"view"`
+		// Index is the index of this node in Validators, it's -1 for
+		// watch-only nodes.
+		Index int `json:"index"`
+		// Primary is the index of the primary (speaker) for the current view.
+		Primary uint `json:"primary"`
+		// NextPrimary is the index of the primary for the next view, it
+		// becomes the speaker if view change happens.
+		NextPrimary uint `json:"nextprimary"`
+		// M is the number of validators required to agree.
+		M int `json:"m"`
+		// Preparations is the number of PrepareRequest and PrepareResponse
+		// messages received for the current view.
+		Preparations int `json:"preparations"`
+		// Commits is the number of Commit messages received for the height.
+		Commits int `json:"commits"`
+		// ChangeViews is the number of ChangeView messages received for the
+		// current view.
+		ChangeViews int `json:"changeviews"`
+		// MissingTransactions is the number of transactions proposed for the
+		// block, but not yet received by this node.
+		MissingTransactions int `json:"missingtransactions"`
+		// TimerDeadline is the time (in milliseconds since Unix epoch) the
+		// consensus timer fires at, it's 0 if the timer is stopped.
+		TimerDeadline uint64 `json:"timerdeadline"`
+		// Validators contains per-validator state.
+		Validators []ConsensusValidator `json:"validators"`
+	}
+
+	// ConsensusValidator is a state of a single consensus node as seen by
+	// this node.
+	ConsensusValidator struct {
+		PublicKey *keys.PublicKey `json:"publickey"`
+		// Prepared is true if a preparation message for the current view was
+		// received from this validator.
+		Prepared bool `json:"prepared"`
+		// Committed is true if a Commit message was received from this
+		// validator.
+		Committed bool `json:"committed"`
+		// ChangeView is true if a ChangeView message for the current view was
+		// received from this validator.
+		ChangeView bool `json:"changeview"`
+		// LastSeen is the height and view of the last message received from
+		// this validator, it's nil if no messages were received.
+		LastSeen *ConsensusHeightView `json:"lastseen"`
+	}
+
+	// ConsensusHeightView is a block height and view number pair.
+	ConsensusHeightView struct {
+		Height uint32 `json:"height"`
+		View   byte   `json:"view"`
+	}
+)
@@ -0,0 +1,27 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+type (
+	// ContractStats is a result of the `getcontractstats` RPC call, it contains
+	// per-contract execution statistics aggregated for a range of blocks.
+	ContractStats struct {
+		StartBlock uint32              `json:"startblock"`
+		EndBlock   uint32              `json:"endblock"`
+		Contracts  []ContractStatsItem `json:"contracts"`
+	}
+
+	// ContractStatsItem is the execution statistics of a single contract.
+	// Executions is the number of transactions calling the contract, Faults
+	// is the number of them that ended in FAULT state and GasConsumed is the
+	// total amount of GAS consumed by them.
+	ContractStatsItem struct {
+		Hash        util.Uint160 `json:"hash"`
+		Calls       uint64       `json:"calls"`
+		Executions  uint64       `json:"executions"`
+		Faults      uint64       `json:"faults"`
+		GasConsumed int64        `json:"gasconsumed,string"`
+	}
+)
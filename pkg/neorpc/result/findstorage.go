@@ -8,3 +8,13 @@ type FindStorage struct {
 	Next      int  `json:"next"`
 	Truncated bool `json:"truncated"`
 }
+
+// StorageBatch represents a part of contract storage items sent to the client
+// as a `storage_batch` event in response to `findstoragestream` request.
+type StorageBatch struct {
+	// ID is the stream identifier specified by the client.
+	ID      string     `json:"id"`
+	Results []KeyValue `json:"results"`
+	// Done is set for the last batch of the stream.
+	Done bool `json:"done"`
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dboper"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/invocations"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
@@ -24,12 +25,67 @@ type Invoke struct {
 	Transaction    *transaction.Transaction
 	Diagnostics    *InvokeDiag
 	Session        uuid.UUID
+	// Checkpoint is an opaque serialized state of the paused invocation
+	// returned by stepping invocation calls, it can be used to resume it.
+	Checkpoint []byte
+	// StrictStackSize enables strict (C#-compatible, see
+	// stackitem.ToJSONWithTypesStrict) serialization of stack items with the
+	// given size limit for every item if non-zero. It's not serialized itself.
+	StrictStackSize int
 }
 
 // InvokeDiag is an additional diagnostic data for invocation.
 type InvokeDiag struct {
 	Changes     []dboper.Operation  `json:"storagechanges"`
 	Invocations []*invocations.Tree `json:"invokedcontracts"`
+	// StackUsage is the peak stack usage during invocation.
+	StackUsage *StackUsage `json:"stackusage,omitempty"`
+	// Verification is only filled in for contract verification calls.
+	Verification *VerificationDiag `json:"verification,omitempty"`
+}
+
+// StackUsage is the peak stack usage during invocation, see vm.StackStats.
+type StackUsage struct {
+	// InvocationDepth is the maximum number of contexts on the invocation
+	// stack.
+	InvocationDepth int `json:"invocationdepth"`
+	// EvaluationDepth is the maximum number of items on the evaluation stack
+	// of a single context.
+	EvaluationDepth int `json:"evaluationdepth"`
+	// Items is the maximum number of referenced stack items.
+	Items int `json:"items"`
+}
+
+// VerificationDiag is an additional diagnostic data for contract verification.
+type VerificationDiag struct {
+	// Steps is the number of executed instructions.
+	Steps uint64 `json:"steps"`
+	// Contracts contains the consumed GAS and executed instructions per
+	// contract in the order contracts were called.
+	Contracts []ContractUsage `json:"contracts"`
+	// Fault is the instruction execution failed at (if any).
+	Fault *ExecutionPoint `json:"fault,omitempty"`
+	// VerificationFee is the GAS consumed by verification.
+	VerificationFee int64 `json:"verificationfee,string"`
+	// SizeFee is the fee for the witness size.
+	SizeFee int64 `json:"sizefee,string"`
+	// NetworkFee is the part of the transaction network fee required for
+	// this witness, it's the sum of VerificationFee and SizeFee.
+	NetworkFee int64 `json:"networkfee,string"`
+}
+
+// ContractUsage is the resources consumed by a contract during invocation.
+type ContractUsage struct {
+	Hash        util.Uint160 `json:"hash"`
+	GasConsumed int64        `json:"gasconsumed,string"`
+	Steps       uint64       `json:"steps"`
+}
+
+// ExecutionPoint is a position in the contract script.
+type ExecutionPoint struct {
+	Hash   util.Uint160 `json:"hash"`
+	Offset int          `json:"offset"`
+	Opcode string       `json:"opcode"`
 }
 
 type invokeAux struct {
@@ -42,6 +98,7 @@ type invokeAux struct {
 	Transaction    []byte                    `json:"tx,omitempty"`
 	Diagnostics    *InvokeDiag               `json:"diagnostics,omitempty"`
 	Session        string                    `json:"session,omitempty"`
+	Checkpoint     []byte                    `json:"checkpoint,omitempty"`
 }
 
 // iteratorInterfaceName is a string used to mark Iterator inside the InteropInterface.
@@ -139,6 +196,8 @@ func (r Invoke) MarshalJSON() ([]byte, error) {
 		iter, ok := r.Stack[i].Value().(Iterator)
 		if (r.Stack[i].Type() == stackitem.InteropT) && ok {
 			data, err = json.Marshal(iter)
+		} else if r.StrictStackSize != 0 {
+			data, err = stackitem.ToJSONWithTypesStrict(r.Stack[i], r.StrictStackSize)
 		} else {
 			data, err = stackitem.ToJSONWithTypes(r.Stack[i])
 		}
@@ -172,6 +231,7 @@ func (r Invoke) MarshalJSON() ([]byte, error) {
 		Transaction:   txbytes,
 		Diagnostics:   r.Diagnostics,
 		Session:       sessionID,
+		Checkpoint:    r.Checkpoint,
 	}
 	if len(r.FaultException) != 0 {
 		aux.FaultException = &r.FaultException
@@ -230,6 +290,7 @@ func (r *Invoke) UnmarshalJSON(data []byte) error {
 	r.Notifications = aux.Notifications
 	r.Transaction = tx
 	r.Diagnostics = aux.Diagnostics
+	r.Checkpoint = aux.Checkpoint
 	return nil
 }
 
@@ -52,6 +52,29 @@ func TestInvoke_MarshalJSON(t *testing.T) {
 	actual := new(Invoke)
 	require.NoError(t, json.Unmarshal(data, actual))
 	require.Equal(t, result, actual)
+
+	t.Run("strict stack", func(t *testing.T) {
+		shared := stackitem.NewArray([]stackitem.Item{})
+		res := &Invoke{
+			State:           "HALT",
+			Stack:           []stackitem.Item{stackitem.NewArray([]stackitem.Item{shared, shared})},
+			StrictStackSize: stackitem.MaxSize,
+		}
+		data, err := json.Marshal(res)
+		require.NoError(t, err)
+		actual := new(Invoke)
+		require.NoError(t, json.Unmarshal(data, actual))
+		require.Empty(t, actual.Stack)
+		require.Contains(t, actual.FaultException, "json error")
+
+		res.StrictStackSize = 0
+		data, err = json.Marshal(res)
+		require.NoError(t, err)
+		actual = new(Invoke)
+		require.NoError(t, json.Unmarshal(data, actual))
+		require.Equal(t, res.Stack, actual.Stack)
+		require.Empty(t, actual.FaultException)
+	})
 }
 
 func TestAppExecToInvocation(t *testing.T) {
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"math"
 
 	"github.com/nspcc-dev/neo-go/pkg/io"
 )
@@ -106,3 +108,98 @@ func (p *VerifyProof) UnmarshalJSON(data []byte) error {
 	p.Value = b
 	return nil
 }
+
+// RangeProof is a proof of the whole set of MPT key-value pairs matching
+// the Prefix and located after the From key (if set), up to Count items.
+type RangeProof struct {
+	Prefix []byte
+	From   []byte
+	Count  int
+	Proof  [][]byte
+}
+
+// GetRangeProof is a result of getrangeproof RPC. If Truncated is true,
+// the number of Results reached the requested count and there may be more
+// items in the range, they can be requested with the next call using the
+// last key as `from` parameter.
+type GetRangeProof struct {
+	Results   []KeyValue  `json:"results"`
+	Truncated bool        `json:"truncated"`
+	Proof     *RangeProof `json:"proof"`
+}
+
+// VerifyRangeProof is a result of verifyrangeproof RPC containing all the
+// key-value pairs proven by the range proof.
+type VerifyRangeProof struct {
+	Results []KeyValue `json:"results"`
+}
+
+// MarshalJSON implements the json.Marshaler.
+func (p *RangeProof) MarshalJSON() ([]byte, error) {
+	w := io.NewBufBinWriter()
+	p.EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	return []byte(`"` + base64.StdEncoding.EncodeToString(w.Bytes()) + `"`), nil
+}
+
+// EncodeBinary implements io.Serializable.
+func (p *RangeProof) EncodeBinary(w *io.BinWriter) {
+	w.WriteVarBytes(p.Prefix)
+	w.WriteVarBytes(p.From)
+	w.WriteVarUint(uint64(p.Count))
+	w.WriteVarUint(uint64(len(p.Proof)))
+	for i := range p.Proof {
+		w.WriteVarBytes(p.Proof[i])
+	}
+}
+
+// DecodeBinary implements io.Serializable.
+func (p *RangeProof) DecodeBinary(r *io.BinReader) {
+	p.Prefix = r.ReadVarBytes()
+	p.From = r.ReadVarBytes()
+	if len(p.From) == 0 {
+		p.From = nil
+	}
+	count := r.ReadVarUint()
+	if r.Err == nil && count > math.MaxInt32 {
+		r.Err = errors.New("invalid count")
+		return
+	}
+	p.Count = int(count)
+	sz := r.ReadVarUint()
+	for range sz {
+		p.Proof = append(p.Proof, r.ReadVarBytes())
+		if r.Err != nil {
+			return
+		}
+	}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler.
+func (p *RangeProof) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return p.FromString(s)
+}
+
+// String implements fmt.Stringer.
+func (p *RangeProof) String() string {
+	w := io.NewBufBinWriter()
+	p.EncodeBinary(w.BinWriter)
+	return base64.StdEncoding.EncodeToString(w.Bytes())
+}
+
+// FromString decodes p from base64-encoded string.
+func (p *RangeProof) FromString(s string) error {
+	rawProof, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	r := io.NewBinReaderFromBuf(rawProof)
+	p.DecodeBinary(r)
+	return r.Err
+}
@@ -1,7 +1,9 @@
 package result
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
@@ -60,3 +62,32 @@ func TestVerifyProof_MarshalJSON(t *testing.T) {
 		testserdes.MarshalUnmarshalJSON(t, vp, &VerifyProof{[]byte{1, 2, 3}})
 	})
 }
+
+func TestRangeProof_MarshalJSON(t *testing.T) {
+	t.Run("Good", func(t *testing.T) {
+		p := &RangeProof{
+			Prefix: random.Bytes(5),
+			From:   random.Bytes(10),
+			Count:  42,
+			Proof:  [][]byte{random.Bytes(12), random.Bytes(34)},
+		}
+		testserdes.MarshalUnmarshalJSON(t, p, new(RangeProof))
+	})
+	t.Run("NoFrom", func(t *testing.T) {
+		p := &RangeProof{
+			Prefix: random.Bytes(5),
+			Count:  1,
+			Proof:  [][]byte{random.Bytes(12)},
+		}
+		testserdes.MarshalUnmarshalJSON(t, p, new(RangeProof))
+	})
+	t.Run("BadCount", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		w.WriteVarBytes([]byte{1})
+		w.WriteVarBytes(nil)
+		w.WriteVarUint(math.MaxInt32 + 1)
+		w.WriteVarUint(0)
+		var p RangeProof
+		require.Error(t, p.FromString(base64.StdEncoding.EncodeToString(w.Bytes())))
+	})
+}
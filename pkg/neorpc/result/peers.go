@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net"
 	"strconv"
+	"strings"
 
 	"github.com/nspcc-dev/neo-go/pkg/network"
 )
@@ -25,6 +26,19 @@ type (
 		Port            uint16 `json:"port"`
 		UserAgent       string `json:"useragent,omitempty"`
 		LastKnownHeight uint32 `json:"lastknownheight,omitempty"`
+		// Messages contains per-command statistics of messages exchanged
+		// with the peer, command names are lowercase without the "CMD"
+		// prefix (like "getdata").
+		Messages map[string]PeerMessageStats `json:"messages,omitempty"`
+	}
+
+	// PeerMessageStats contains the number of messages of some type sent to
+	// and received from a connected peer along with their total size.
+	PeerMessageStats struct {
+		Sent          uint64 `json:"sent"`
+		SentBytes     uint64 `json:"sentbytes"`
+		Received      uint64 `json:"received"`
+		ReceivedBytes uint64 `json:"receivedbytes"`
 	}
 )
 
@@ -81,6 +95,12 @@ func (p *Peers) addConnectedPeers(connectedPeers []network.PeerInfo) {
 			UserAgent:       connectedPeers[i].UserAgent,
 			LastKnownHeight: connectedPeers[i].Height,
 		}
+		if len(connectedPeers[i].Messages) != 0 {
+			peer.Messages = make(map[string]PeerMessageStats, len(connectedPeers[i].Messages))
+			for cmd, st := range connectedPeers[i].Messages {
+				peer.Messages[strings.ToLower(strings.TrimPrefix(cmd.String(), "CMD"))] = PeerMessageStats(st)
+			}
+		}
 
 		*p = append(*p, peer)
 	}
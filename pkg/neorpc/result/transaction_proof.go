@@ -0,0 +1,19 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// TransactionProof is a result of the `gettransactionproof` RPC call, it
+// contains a proof of transaction inclusion into the block. Index is the
+// position of the transaction in the block, TxCount is the number of
+// transactions in the block and Proof is the Merkle tree inclusion proof that
+// can be checked against the block's MerkleRoot with merkle.VerifyProof.
+type TransactionProof struct {
+	BlockHash  util.Uint256   `json:"blockhash"`
+	BlockIndex uint32         `json:"blockindex"`
+	MerkleRoot util.Uint256   `json:"merkleroot"`
+	Index      int            `json:"index"`
+	TxCount    int            `json:"txcount"`
+	Proof      []util.Uint256 `json:"proof"`
+}
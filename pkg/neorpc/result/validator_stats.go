@@ -0,0 +1,26 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+type (
+	// ValidatorStats is a result of the `getvalidatorstats` RPC call, it
+	// contains block production statistics of validators for a range of the
+	// latest blocks.
+	ValidatorStats struct {
+		StartBlock uint32               `json:"startblock"`
+		EndBlock   uint32               `json:"endblock"`
+		Validators []ValidatorStatsItem `json:"validators"`
+	}
+
+	// ValidatorStatsItem is the block production statistics of a single
+	// validator. Produced is the number of blocks the validator was the
+	// primary for and Missed is the number of views it was expected to be the
+	// primary in that ended with a view change.
+	ValidatorStatsItem struct {
+		PublicKey keys.PublicKey `json:"publickey"`
+		Produced  uint64         `json:"produced"`
+		Missed    uint64         `json:"missed"`
+	}
+)
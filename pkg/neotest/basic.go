@@ -14,12 +14,14 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
@@ -317,9 +319,16 @@ func AddNetworkFee(t testing.TB, bc *core.Blockchain, tx *transaction.Transactio
 	size := io.GetVarSize(tx)
 	for _, sgr := range signers {
 		csgr, ok := sgr.(SingleSigner)
-		if ok && csgr.Account().Contract.InvocationBuilder != nil {
-			sc, err := csgr.Account().Contract.InvocationBuilder(tx)
-			require.NoError(t, err)
+		if ok && (csgr.Account().Contract.InvocationBuilder != nil || vm.IsSecp256k1SignatureContract(csgr.Script())) {
+			var sc []byte
+			if build := csgr.Account().Contract.InvocationBuilder; build != nil {
+				var err error
+				sc, err = build(tx)
+				require.NoError(t, err)
+			} else {
+				// Dummy signature, verification price doesn't depend on it.
+				sc = append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, make([]byte, keys.SignatureLen)...)
+			}
 
 			txCopy := *tx
 			ic, err := bc.GetTestVM(trigger.Verification, &txCopy, nil)
@@ -330,6 +339,7 @@ func AddNetworkFee(t testing.TB, bc *core.Blockchain, tx *transaction.Transactio
 
 			require.NoError(t, bc.InitVerificationContext(ic, csgr.ScriptHash(), &transaction.Witness{InvocationScript: sc, VerificationScript: csgr.Script()}))
 			require.NoError(t, ic.VM.Run())
+			ic.Finalize()
 
 			tx.NetworkFee += ic.VM.GasConsumed()
 			size += io.GetVarSize(sc) + io.GetVarSize(csgr.Script())
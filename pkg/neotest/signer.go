@@ -57,9 +57,10 @@ type multiSigner struct {
 }
 
 // NewSingleSigner creates a [SingleSigner] from the provided account. It has
-// just one key, see [NewMultiSigner] for multisignature accounts.
+// just one key, see [NewMultiSigner] for multisignature accounts. Secp256k1
+// signature accounts are supported as well.
 func NewSingleSigner(acc *wallet.Account) SingleSigner {
-	if !vm.IsSignatureContract(acc.Contract.Script) {
+	if !vm.IsSignatureContract(acc.Contract.Script) && !vm.IsSecp256k1SignatureContract(acc.Contract.Script) {
 		panic("account must have simple-signature verification script")
 	}
 	return (*signer)(acc)
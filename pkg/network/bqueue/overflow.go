@@ -0,0 +1,132 @@
+package bqueue
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// OverflowConfig is the configuration of the on-disk block queue overflow used
+// to store blocks that don't fit into the in-memory queue.
+type OverflowConfig struct {
+	// Size is the number of blocks that can be stored on disk above the
+	// in-memory queue capacity. Zero disables the overflow.
+	Size int
+	// Dir is the directory where the temporary overflow storage is created,
+	// the default OS temporary directory is used if empty.
+	Dir string
+}
+
+// overflow is an on-disk ring buffer of blocks, every block is stored in a
+// separate file named by its position in the buffer. The storage directory
+// is created on the first write. overflow is not thread-safe, it's protected
+// by the Queue lock.
+type overflow struct {
+	dir   string
+	path  string
+	slots []uint32
+	len   int
+}
+
+func newOverflow(cfg OverflowConfig) *overflow {
+	if cfg.Size <= 0 {
+		return nil
+	}
+	return &overflow{
+		dir:   cfg.Dir,
+		slots: make([]uint32, cfg.Size),
+	}
+}
+
+func (o *overflow) size() int {
+	return len(o.slots)
+}
+
+func (o *overflow) slotFile(pos int) string {
+	return filepath.Join(o.path, strconv.Itoa(pos))
+}
+
+// has checks whether the block with the given index is stored. Genesis block
+// is never stored, so zero index means an empty slot.
+func (o *overflow) has(index uint32) bool {
+	return index != 0 && o.slots[int(index)%len(o.slots)] == index
+}
+
+// put stores the block, the old one is kept if it's already stored.
+func (o *overflow) put(b *block.Block) error {
+	if o.path == "" {
+		path, err := os.MkdirTemp(o.dir, "neogo-bqueue-")
+		if err != nil {
+			return fmt.Errorf("failed to create overflow directory: %w", err)
+		}
+		o.path = path
+	}
+	pos := int(b.Index) % len(o.slots)
+	if o.slots[pos] >= b.Index {
+		return nil
+	}
+	w := io.NewBufBinWriter()
+	w.WriteBool(b.StateRootEnabled)
+	b.EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return fmt.Errorf("failed to encode block: %w", w.Err)
+	}
+	err := os.WriteFile(o.slotFile(pos), w.Bytes(), 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write block: %w", err)
+	}
+	if o.slots[pos] == 0 {
+		o.len++
+	}
+	o.slots[pos] = b.Index
+	return nil
+}
+
+// get reads the block with the given index, it must be stored.
+func (o *overflow) get(index uint32) (*block.Block, error) {
+	data, err := os.ReadFile(o.slotFile(int(index) % len(o.slots)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block: %w", err)
+	}
+	r := io.NewBinReaderFromBuf(data)
+	b := block.New(r.ReadBool())
+	b.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, fmt.Errorf("failed to decode block: %w", r.Err)
+	}
+	if b.Index != index {
+		return nil, fmt.Errorf("unexpected block %d instead of %d", b.Index, index)
+	}
+	return b, nil
+}
+
+// remove drops the block with the given index if it's stored.
+func (o *overflow) remove(index uint32) {
+	if !o.has(index) {
+		return
+	}
+	pos := int(index) % len(o.slots)
+	o.slots[pos] = 0
+	o.len--
+	_ = os.Remove(o.slotFile(pos))
+}
+
+// close removes all stored blocks along with the storage directory.
+func (o *overflow) close() error {
+	clear(o.slots)
+	o.len = 0
+	if o.path == "" {
+		return nil
+	}
+	path := o.path
+	o.path = ""
+	err := os.RemoveAll(path)
+	if err != nil {
+		return fmt.Errorf("failed to remove overflow directory: %w", err)
+	}
+	return nil
+}
@@ -40,6 +40,7 @@ type Queue struct {
 	len         int
 	lenUpdateF  func(int)
 	cacheSize   int
+	overflow    *overflow
 	mode        OperationMode
 }
 
@@ -51,8 +52,9 @@ func (bq *Queue) indexToPosition(i uint32) int {
 	return int(i) % bq.cacheSize
 }
 
-// New creates an instance of BlockQueue.
-func New(bc Blockqueuer, log *zap.Logger, relayer func(*block.Block), cacheSize int, lenMetricsUpdater func(l int), mode OperationMode) *Queue {
+// New creates an instance of BlockQueue. Blocks that don't fit into cacheSize
+// are stored on disk if overflow is enabled.
+func New(bc Blockqueuer, log *zap.Logger, relayer func(*block.Block), cacheSize int, lenMetricsUpdater func(l int), mode OperationMode, overflowCfg OverflowConfig) *Queue {
 	if log == nil {
 		return nil
 	}
@@ -68,6 +70,7 @@ func New(bc Blockqueuer, log *zap.Logger, relayer func(*block.Block), cacheSize
 		relayF:      relayer,
 		lenUpdateF:  lenMetricsUpdater,
 		cacheSize:   cacheSize,
+		overflow:    newOverflow(overflowCfg),
 		mode:        mode,
 	}
 }
@@ -92,6 +95,19 @@ func (bq *Queue) Run() {
 					bq.len--
 					bq.queue[old] = nil
 				}
+				if bq.overflow != nil {
+					bq.overflow.remove(i + 1)
+				}
+			}
+			if b == nil && bq.overflow != nil && bq.overflow.has(h+1) {
+				var err error
+				b, err = bq.overflow.get(h + 1)
+				if err != nil {
+					bq.log.Warn("blockQueue: failed to read block from disk",
+						zap.Uint32("index", h+1),
+						zap.Error(err))
+					bq.overflow.remove(h + 1)
+				}
 			}
 			bq.queueLock.Unlock()
 			lastHeight = h
@@ -112,11 +128,13 @@ func (bq *Queue) Run() {
 				bq.relayF(b)
 			}
 			bq.queueLock.Lock()
-			bq.len--
-			l := bq.len
 			if bq.queue[pos] == b {
+				bq.len--
 				bq.queue[pos] = nil
+			} else if bq.overflow != nil {
+				bq.overflow.remove(b.Index)
 			}
+			l := bq.totalLen()
 			bq.queueLock.Unlock()
 			if bq.lenUpdateF != nil {
 				bq.lenUpdateF(l)
@@ -138,40 +156,45 @@ func (bq *Queue) PutBlock(block *block.Block) error {
 	if block.Index <= h {
 		return nil
 	}
-	if h+uint32(bq.cacheSize) < block.Index {
+	if h+uint32(bq.capacity()) < block.Index {
 		switch bq.mode {
 		case NonBlocking:
 			return nil
 		case Blocking:
-			bq.queueLock.Unlock()
-			t := time.NewTicker(time.Second)
-			defer t.Stop()
-			for range t.C {
-				if bq.discarded.Load() {
-					bq.queueLock.Lock()
-					return nil
-				}
-				h = bq.chain.BlockHeight()
-				if h+uint32(bq.cacheSize) >= block.Index {
-					bq.queueLock.Lock()
-					break
-				}
+			if !bq.waitSpace(block.Index, bq.capacity()) {
+				return nil
+			}
+			h = bq.chain.BlockHeight()
+		}
+	}
+	var onDisk bool
+	if h+uint32(bq.cacheSize) < block.Index {
+		// It's only possible with overflow enabled.
+		err := bq.overflow.put(block)
+		if err == nil {
+			onDisk = true
+		} else {
+			bq.log.Warn("blockQueue: failed to store block on disk",
+				zap.Uint32("index", block.Index),
+				zap.Error(err))
+			if bq.mode == NonBlocking || !bq.waitSpace(block.Index, bq.cacheSize) {
+				return nil
 			}
 		}
 	}
 	pos := bq.indexToPosition(block.Index)
 	// If we already have it, keep the old block, throw away the new one.
-	if bq.queue[pos] == nil || bq.queue[pos].Index < block.Index {
+	if !onDisk && (bq.queue[pos] == nil || bq.queue[pos].Index < block.Index) &&
+		(bq.overflow == nil || !bq.overflow.has(block.Index)) {
 		bq.len++
 		bq.queue[pos] = block
-		for pos < bq.cacheSize && bq.queue[pos] != nil && bq.lastQ+1 == bq.queue[pos].Index {
-			bq.lastQ = bq.queue[pos].Index
-			pos++
-		}
+	}
+	for bq.has(bq.lastQ + 1) {
+		bq.lastQ++
 	}
 	// update metrics
 	if bq.lenUpdateF != nil {
-		bq.lenUpdateF(bq.len)
+		bq.lenUpdateF(bq.totalLen())
 	}
 	select {
 	case bq.checkBlocks <- struct{}{}:
@@ -182,12 +205,56 @@ func (bq *Queue) PutBlock(block *block.Block) error {
 	return nil
 }
 
+// waitSpace waits until the block with the given index fits into the queue
+// window of the given size. It must be called with the queue lock held, the
+// lock is released while waiting. It returns false if the queue was
+// discarded.
+func (bq *Queue) waitSpace(index uint32, window int) bool {
+	bq.queueLock.Unlock()
+	defer bq.queueLock.Lock()
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+	for range t.C {
+		if bq.discarded.Load() {
+			return false
+		}
+		if bq.chain.BlockHeight()+uint32(window) >= index {
+			break
+		}
+	}
+	return true
+}
+
+// has checks whether the block with the given index is queued. It must be
+// called with the queue lock held.
+func (bq *Queue) has(index uint32) bool {
+	b := bq.queue[bq.indexToPosition(index)]
+	return b != nil && b.Index == index || bq.overflow != nil && bq.overflow.has(index)
+}
+
+// capacity returns the maximum number of queued blocks.
+func (bq *Queue) capacity() int {
+	if bq.overflow == nil {
+		return bq.cacheSize
+	}
+	return bq.cacheSize + bq.overflow.size()
+}
+
+// totalLen returns the number of queued blocks both in memory and on disk. It
+// must be called with the queue lock held.
+func (bq *Queue) totalLen() int {
+	if bq.overflow == nil {
+		return bq.len
+	}
+	return bq.len + bq.overflow.len
+}
+
 // LastQueued returns the index of the last queued block and the queue's capacity
 // left.
 func (bq *Queue) LastQueued() (uint32, int) {
 	bq.queueLock.RLock()
 	defer bq.queueLock.RUnlock()
-	return bq.lastQ, bq.cacheSize - bq.len
+	return bq.lastQ, bq.capacity() - bq.totalLen()
 }
 
 // Discard stops the queue and prevents it from accepting more blocks to enqueue.
@@ -199,6 +266,11 @@ func (bq *Queue) Discard() {
 		// another if in Run().
 		clear(bq.queue)
 		bq.len = 0
+		if bq.overflow != nil {
+			if err := bq.overflow.close(); err != nil {
+				bq.log.Warn("blockQueue: failed to cleanup disk storage", zap.Error(err))
+			}
+		}
 		bq.queueLock.Unlock()
 	}
 }
@@ -1,6 +1,7 @@
 package bqueue
 
 import (
+	"os"
 	"testing"
 	"time"
 
@@ -13,7 +14,7 @@ import (
 func TestBlockQueue(t *testing.T) {
 	chain := fakechain.NewFakeChain()
 	// notice, it's not yet running
-	bq := New(chain, zaptest.NewLogger(t), nil, 0, nil, NonBlocking)
+	bq := New(chain, zaptest.NewLogger(t), nil, 0, nil, NonBlocking, OverflowConfig{})
 	blocks := make([]*block.Block, 11)
 	for i := 1; i < 11; i++ {
 		blocks[i] = &block.Block{Header: block.Header{Index: uint32(i)}}
@@ -88,3 +89,39 @@ func (bq *Queue) length() int {
 	defer bq.queueLock.Unlock()
 	return bq.len
 }
+
+func TestBlockQueueOverflow(t *testing.T) {
+	chain := fakechain.NewFakeChain()
+	dir := t.TempDir()
+	bq := New(chain, zaptest.NewLogger(t), nil, 2, nil, Blocking, OverflowConfig{Size: 3, Dir: dir})
+	blocks := make([]*block.Block, 8)
+	for i := 1; i < len(blocks); i++ {
+		blocks[i] = &block.Block{Header: block.Header{Index: uint32(i)}}
+	}
+	// Blocks 3-5 don't fit into memory, so they're stored on disk.
+	for i := 5; i > 0; i-- {
+		assert.NoError(t, bq.PutBlock(blocks[i]))
+	}
+	last, capLeft := bq.LastQueued()
+	assert.Equal(t, uint32(5), last)
+	assert.Equal(t, 0, capLeft)
+	assert.Equal(t, 2, bq.length())
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entries))
+
+	go bq.Run()
+	assert.Eventually(t, func() bool { return chain.BlockHeight() == 5 }, 4*time.Second, 100*time.Millisecond)
+	// Now there is some space in the memory and on disk.
+	assert.NoError(t, bq.PutBlock(blocks[7]))
+	assert.NoError(t, bq.PutBlock(blocks[6]))
+	assert.Eventually(t, func() bool { return chain.BlockHeight() == 7 }, 4*time.Second, 100*time.Millisecond)
+	last, capLeft = bq.LastQueued()
+	assert.Equal(t, uint32(7), last)
+	assert.Equal(t, 5, capLeft)
+
+	bq.Discard()
+	entries, err = os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(entries))
+}
@@ -9,6 +9,18 @@ import (
 // MaxCapabilities is the maximum number of capabilities per payload.
 const MaxCapabilities = 32
 
+const (
+	// MaxExtensibleCategories is the maximum number of extensible payload
+	// categories in ExtensibleFilter capability.
+	MaxExtensibleCategories = 16
+	// maxExtensibleCategorySize is the maximum extensible payload category
+	// size, it matches the one of extensible payload.
+	maxExtensibleCategorySize = 32
+	// maxExtensionSize is the maximum size of Compact and Extensible
+	// capability data.
+	maxExtensionSize = 1024
+)
+
 // Capabilities is a list of Capability.
 type Capabilities []Capability
 
@@ -26,7 +38,7 @@ func (cs *Capabilities) EncodeBinary(br *io.BinWriter) {
 // checkUniqueCapabilities checks whether payload capabilities have a unique type.
 func (cs Capabilities) checkUniqueCapabilities() error {
 	err := errors.New("capabilities with the same type are not allowed")
-	var isFullNode, isTCP, isWS bool
+	var isFullNode, isTCP, isWS, isCompact, isFilter bool
 	for _, cap := range cs {
 		switch cap.Type {
 		case FullNode:
@@ -44,6 +56,16 @@ func (cs Capabilities) checkUniqueCapabilities() error {
 				return err
 			}
 			isWS = true
+		case CompactBlocks:
+			if isCompact {
+				return err
+			}
+			isCompact = true
+		case ExtensibleFilter:
+			if isFilter {
+				return err
+			}
+			isFilter = true
 		}
 	}
 	return nil
@@ -63,9 +85,12 @@ func (c *Capability) DecodeBinary(br *io.BinReader) {
 		c.Data = &Node{}
 	case TCPServer, WSServer:
 		c.Data = &Server{}
+	case CompactBlocks:
+		c.Data = &Compact{}
+	case ExtensibleFilter:
+		c.Data = &Extensible{}
 	default:
-		br.Err = errors.New("unknown node capability type")
-		return
+		c.Data = &Unknown{}
 	}
 	c.Data.DecodeBinary(br)
 }
@@ -110,3 +135,105 @@ func (s *Server) DecodeBinary(br *io.BinReader) {
 func (s *Server) EncodeBinary(bw *io.BinWriter) {
 	bw.WriteU16LE(s.Port)
 }
+
+// Unknown represents a capability of unknown type. Its data is opaque, such
+// capabilities are decoded to be skipped, so that nodes don't reject peers
+// announcing capabilities added in newer protocol versions. All capabilities
+// not defined in the reference protocol (like Compact and Extensible) use
+// the same var-sized data encoding for this to work.
+type Unknown struct {
+	Data []byte
+}
+
+// DecodeBinary implements io.Serializable.
+func (u *Unknown) DecodeBinary(br *io.BinReader) {
+	u.Data = br.ReadVarBytes()
+}
+
+// EncodeBinary implements io.Serializable.
+func (u *Unknown) EncodeBinary(bw *io.BinWriter) {
+	bw.WriteVarBytes(u.Data)
+}
+
+// readExtension returns a reader for the var-sized data of extension
+// capability. Trailing data is allowed there for future extensions.
+func readExtension(br *io.BinReader) *io.BinReader {
+	data := br.ReadVarBytes(maxExtensionSize)
+	if br.Err != nil {
+		return nil
+	}
+	return io.NewBinReaderFromBuf(data)
+}
+
+// writeExtension writes data produced by f as a var-sized extension capability
+// data.
+func writeExtension(bw *io.BinWriter, f func(*io.BinWriter)) {
+	w := io.NewBufBinWriter()
+	f(w.BinWriter)
+	if w.Err != nil {
+		bw.Err = w.Err
+		return
+	}
+	bw.WriteVarBytes(w.Bytes())
+}
+
+// Compact represents compact block relay capability with the version of
+// compact block protocol supported by the node.
+type Compact struct {
+	Version uint8
+}
+
+// DecodeBinary implements io.Serializable.
+func (c *Compact) DecodeBinary(br *io.BinReader) {
+	r := readExtension(br)
+	if r == nil {
+		return
+	}
+	c.Version = r.ReadB()
+	br.Err = r.Err
+}
+
+// EncodeBinary implements io.Serializable.
+func (c *Compact) EncodeBinary(bw *io.BinWriter) {
+	writeExtension(bw, func(w *io.BinWriter) {
+		w.WriteB(c.Version)
+	})
+}
+
+// Extensible represents extensible payload filter capability with the list of
+// extensible payload categories the node is interested in. Nodes announcing
+// this capability are only sent the payloads of the listed categories.
+type Extensible struct {
+	Categories []string
+}
+
+// DecodeBinary implements io.Serializable.
+func (e *Extensible) DecodeBinary(br *io.BinReader) {
+	r := readExtension(br)
+	if r == nil {
+		return
+	}
+	defer func() { br.Err = r.Err }()
+	n := r.ReadVarUint()
+	if r.Err != nil {
+		return
+	}
+	if n > MaxExtensibleCategories {
+		r.Err = errors.New("too many extensible categories")
+		return
+	}
+	e.Categories = make([]string, n)
+	for i := range e.Categories {
+		e.Categories[i] = r.ReadString(maxExtensibleCategorySize)
+	}
+}
+
+// EncodeBinary implements io.Serializable.
+func (e *Extensible) EncodeBinary(bw *io.BinWriter) {
+	writeExtension(bw, func(w *io.BinWriter) {
+		w.WriteVarUint(uint64(len(e.Categories)))
+		for _, c := range e.Categories {
+			w.WriteString(c)
+		}
+	})
+}
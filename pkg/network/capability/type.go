@@ -10,4 +10,8 @@ const (
 	WSServer Type = 0x02
 	// FullNode represents full node capability type.
 	FullNode Type = 0x10
+	// CompactBlocks represents compact block relay capability type.
+	CompactBlocks Type = 0x20
+	// ExtensibleFilter represents extensible payload filter capability type.
+	ExtensibleFilter Type = 0x21
 )
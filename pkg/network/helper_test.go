@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -76,17 +77,19 @@ func (d *testDiscovery) GoodPeers() []AddressWithCapabilities { return []Address
 var defaultMessageHandler = func(t *testing.T, msg *Message) {}
 
 type localPeer struct {
-	netaddr        net.TCPAddr
-	server         *Server
-	version        *payload.Version
-	lastBlockIndex uint32
-	handshaked     int32 // TODO: use atomic.Bool after #2626.
-	isFullNode     bool
-	t              *testing.T
-	messageHandler func(t *testing.T, msg *Message)
-	pingSent       int
-	getAddrSent    int
-	droppedWith    atomic.Value
+	netaddr          net.TCPAddr
+	server           *Server
+	version          *payload.Version
+	lastBlockIndex   uint32
+	handshaked       int32 // TODO: use atomic.Bool after #2626.
+	isFullNode       bool
+	compactBlocks    bool
+	extensibleFilter []string
+	t                *testing.T
+	messageHandler   func(t *testing.T, msg *Message)
+	pingSent         int
+	getAddrSent      int
+	droppedWith      atomic.Value
 }
 
 func newLocalPeer(t *testing.T, s *Server) *localPeer {
@@ -195,6 +198,14 @@ func (p *localPeer) IsFullNode() bool {
 	return p.isFullNode
 }
 
+func (p *localPeer) SupportsCompactBlocks() bool {
+	return p.compactBlocks
+}
+
+func (p *localPeer) WantsExtensible(category string) bool {
+	return p.extensibleFilter == nil || slices.Contains(p.extensibleFilter, category)
+}
+
 func (p *localPeer) AddGetAddrSent() {
 	p.getAddrSent++
 }
@@ -202,6 +213,9 @@ func (p *localPeer) CanProcessAddr() bool {
 	p.getAddrSent--
 	return p.getAddrSent >= 0
 }
+func (p *localPeer) MessageStats() map[CommandType]MessageStats {
+	return nil
+}
 
 func newTestServer(t *testing.T, serverConfig ServerConfig) *Server {
 	return newTestServerWithCustomCfg(t, serverConfig, nil)
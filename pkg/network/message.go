@@ -73,6 +73,9 @@ const (
 	CMDP2PNotaryRequest             = CommandType(payload.P2PNotaryRequestType)
 	CMDGetMPTData       CommandType = 0x51 // 0x5.. commands are used for extensions (P2PNotary, state exchange cmds)
 	CMDMPTData          CommandType = 0x52
+	CMDCompactBlock     CommandType = 0x53
+	CMDGetBlockTxs      CommandType = 0x54
+	CMDBlockTxs         CommandType = 0x55
 	CMDReject           CommandType = 0x2f
 
 	// SPV protocol.
@@ -148,6 +151,12 @@ func (m *Message) decodePayload() error {
 		p = block.New(m.StateRootInHeader)
 	case CMDExtensible:
 		p = payload.NewExtensible()
+	case CMDCompactBlock:
+		p = payload.NewCompactBlock(m.StateRootInHeader)
+	case CMDGetBlockTxs:
+		p = &payload.GetBlockTxs{}
+	case CMDBlockTxs:
+		p = &payload.BlockTxs{}
 	case CMDP2PNotaryRequest:
 		p = &payload.P2PNotaryRequest{}
 	case CMDGetBlocks:
@@ -227,7 +236,7 @@ func (m *Message) tryCompressPayload() error {
 	if m.Flags&Compressed == 0 {
 		switch m.Payload.(type) {
 		case *payload.Headers, *payload.MerkleBlock, payload.NullPayload,
-			*payload.Inventory, *payload.MPTInventory:
+			*payload.Inventory, *payload.MPTInventory, *payload.GetBlockTxs:
 			break
 		default:
 			size := len(compressedPayload)
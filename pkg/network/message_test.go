@@ -40,6 +40,18 @@ func TestEncodeDecodeVersion(t *testing.T) {
 					StartHeight: 123,
 				},
 			},
+			{
+				Type: capability.CompactBlocks,
+				Data: &capability.Compact{
+					Version: payload.CompactBlocksVersion,
+				},
+			},
+			{
+				Type: capability.ExtensibleFilter,
+				Data: &capability.Extensible{
+					Categories: []string{payload.ConsensusCategory, "StateService"},
+				},
+			},
 		},
 	})
 	testserdes.EncodeDecode(t, expected, &Message{})
@@ -280,6 +292,18 @@ func TestEncodeDecodeMPTData(t *testing.T) {
 	})
 }
 
+func TestEncodeDecodeCompactBlock(t *testing.T) {
+	testEncodeDecode(t, CMDCompactBlock, payload.NewCompactBlockFromBlock(newDummyBlock(1, 3), rand.Uint64()))
+}
+
+func TestEncodeDecodeGetBlockTxs(t *testing.T) {
+	testEncodeDecode(t, CMDGetBlockTxs, payload.NewGetBlockTxs(random.Uint256(), []uint16{0, 5, 7}))
+}
+
+func TestEncodeDecodeBlockTxs(t *testing.T) {
+	testEncodeDecode(t, CMDBlockTxs, payload.NewBlockTxs(random.Uint256(), []*transaction.Transaction{newDummyTx(), newDummyTx()}))
+}
+
 func TestInvalidMessages(t *testing.T) {
 	t.Run("CMDBlock, empty payload", func(t *testing.T) {
 		testEncodeDecodeFail(t, CMDBlock, payload.NullPayload{})
@@ -0,0 +1,104 @@
+package payload
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// GetBlockTxs is a request for transactions of the given block specified by
+// their indexes in the block.
+type GetBlockTxs struct {
+	Hash    util.Uint256
+	Indexes []uint16
+}
+
+// NewGetBlockTxs returns GetBlockTxs payload for the given block hash and
+// transaction indexes.
+func NewGetBlockTxs(h util.Uint256, indexes []uint16) *GetBlockTxs {
+	return &GetBlockTxs{
+		Hash:    h,
+		Indexes: indexes,
+	}
+}
+
+// DecodeBinary implements the Serializable interface.
+func (g *GetBlockTxs) DecodeBinary(br *io.BinReader) {
+	br.ReadBytes(g.Hash[:])
+	n := br.ReadVarUint()
+	if br.Err != nil {
+		return
+	}
+	if n == 0 {
+		br.Err = errors.New("empty transaction index list")
+		return
+	}
+	if n > block.MaxTransactionsPerBlock {
+		br.Err = block.ErrMaxContentsPerBlock
+		return
+	}
+	g.Indexes = make([]uint16, n)
+	for i := range g.Indexes {
+		g.Indexes[i] = br.ReadU16LE()
+	}
+}
+
+// EncodeBinary implements the Serializable interface.
+func (g *GetBlockTxs) EncodeBinary(bw *io.BinWriter) {
+	bw.WriteBytes(g.Hash[:])
+	bw.WriteVarUint(uint64(len(g.Indexes)))
+	for _, i := range g.Indexes {
+		bw.WriteU16LE(i)
+	}
+}
+
+// BlockTxs is a response to GetBlockTxs containing the requested block
+// transactions in the order they were requested.
+type BlockTxs struct {
+	Hash         util.Uint256
+	Transactions []*transaction.Transaction
+}
+
+// NewBlockTxs returns BlockTxs payload for the given block hash and
+// transactions.
+func NewBlockTxs(h util.Uint256, txs []*transaction.Transaction) *BlockTxs {
+	return &BlockTxs{
+		Hash:         h,
+		Transactions: txs,
+	}
+}
+
+// DecodeBinary implements the Serializable interface.
+func (b *BlockTxs) DecodeBinary(br *io.BinReader) {
+	br.ReadBytes(b.Hash[:])
+	n := br.ReadVarUint()
+	if br.Err != nil {
+		return
+	}
+	if n == 0 {
+		br.Err = errors.New("empty transaction list")
+		return
+	}
+	if n > block.MaxTransactionsPerBlock {
+		br.Err = block.ErrMaxContentsPerBlock
+		return
+	}
+	b.Transactions = make([]*transaction.Transaction, n)
+	for i := range b.Transactions {
+		tx := new(transaction.Transaction)
+		tx.DecodeBinary(br)
+		if br.Err != nil {
+			return
+		}
+		b.Transactions[i] = tx
+	}
+}
+
+// EncodeBinary implements the Serializable interface.
+func (b *BlockTxs) EncodeBinary(bw *io.BinWriter) {
+	bw.WriteBytes(b.Hash[:])
+	bw.WriteArray(b.Transactions)
+}
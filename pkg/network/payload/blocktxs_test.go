@@ -0,0 +1,62 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBlockTxs_EncodeDecodeBinary(t *testing.T) {
+	t.Run("good", func(t *testing.T) {
+		testserdes.EncodeDecodeBinary(t, NewGetBlockTxs(random.Uint256(), []uint16{0, 3, 65535}), new(GetBlockTxs))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		data, err := testserdes.EncodeBinary(NewGetBlockTxs(random.Uint256(), nil))
+		require.NoError(t, err)
+		require.Error(t, testserdes.DecodeBinary(data, new(GetBlockTxs)))
+	})
+
+	t.Run("too many indexes", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		w.WriteBytes(random.Bytes(32))
+		w.WriteVarUint(block.MaxTransactionsPerBlock + 1)
+		require.NoError(t, w.Err)
+		require.ErrorIs(t, testserdes.DecodeBinary(w.Bytes(), new(GetBlockTxs)), block.ErrMaxContentsPerBlock)
+	})
+}
+
+func TestBlockTxs_EncodeDecodeBinary(t *testing.T) {
+	t.Run("good", func(t *testing.T) {
+		txs := []*transaction.Transaction{newDummyTx(), newDummyTx()}
+		testserdes.EncodeDecodeBinary(t, NewBlockTxs(random.Uint256(), txs), new(BlockTxs))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		data, err := testserdes.EncodeBinary(NewBlockTxs(random.Uint256(), nil))
+		require.NoError(t, err)
+		require.Error(t, testserdes.DecodeBinary(data, new(BlockTxs)))
+	})
+
+	t.Run("too many transactions", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		w.WriteBytes(random.Bytes(32))
+		w.WriteVarUint(block.MaxTransactionsPerBlock + 1)
+		require.NoError(t, w.Err)
+		require.ErrorIs(t, testserdes.DecodeBinary(w.Bytes(), new(BlockTxs)), block.ErrMaxContentsPerBlock)
+	})
+}
+
+func newDummyTx() *transaction.Transaction {
+	tx := transaction.New(random.Bytes(100), 123)
+	tx.Signers = []transaction.Signer{{Account: random.Uint160()}}
+	tx.Scripts = []transaction.Witness{{InvocationScript: []byte{}, VerificationScript: []byte{}}}
+	tx.Size()
+	tx.Hash()
+	return tx
+}
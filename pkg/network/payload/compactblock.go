@@ -0,0 +1,82 @@
+package payload
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/twmb/murmur3"
+)
+
+// CompactBlocksVersion is the version of compact block relay protocol
+// implemented by the node.
+const CompactBlocksVersion = 1
+
+// CompactBlock is a block announcement that contains the block header and
+// short identifiers of block transactions instead of transactions themselves.
+// Receiver is expected to reconstruct the block from its mempool and request
+// the missing transactions via GetBlockTxs.
+type CompactBlock struct {
+	*block.Header
+	// Nonce is a random value used to salt short transaction IDs.
+	Nonce uint64
+	// ShortIDs contains short IDs of block transactions in the block order.
+	ShortIDs []uint64
+}
+
+// NewCompactBlock returns an empty CompactBlock payload ready to be decoded.
+func NewCompactBlock(stateRootInHeader bool) *CompactBlock {
+	return &CompactBlock{
+		Header: &block.Header{StateRootEnabled: stateRootInHeader},
+	}
+}
+
+// NewCompactBlockFromBlock creates CompactBlock payload for the given block
+// using the given nonce for short IDs.
+func NewCompactBlockFromBlock(b *block.Block, nonce uint64) *CompactBlock {
+	var ids = make([]uint64, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		ids[i] = ShortID(nonce, tx.Hash())
+	}
+	return &CompactBlock{
+		Header:   &b.Header,
+		Nonce:    nonce,
+		ShortIDs: ids,
+	}
+}
+
+// ShortID returns the short transaction ID for the given transaction hash
+// salted with the given nonce.
+func ShortID(nonce uint64, h util.Uint256) uint64 {
+	return murmur3.SeedSum64(nonce, h[:])
+}
+
+// DecodeBinary implements the Serializable interface.
+func (c *CompactBlock) DecodeBinary(br *io.BinReader) {
+	if c.Header == nil {
+		c.Header = new(block.Header)
+	}
+	c.Header.DecodeBinary(br)
+	c.Nonce = br.ReadU64LE()
+	n := br.ReadVarUint()
+	if br.Err != nil {
+		return
+	}
+	if n > block.MaxTransactionsPerBlock {
+		br.Err = block.ErrMaxContentsPerBlock
+		return
+	}
+	c.ShortIDs = make([]uint64, n)
+	for i := range c.ShortIDs {
+		c.ShortIDs[i] = br.ReadU64LE()
+	}
+}
+
+// EncodeBinary implements the Serializable interface.
+func (c *CompactBlock) EncodeBinary(bw *io.BinWriter) {
+	c.Header.EncodeBinary(bw)
+	bw.WriteU64LE(c.Nonce)
+	bw.WriteVarUint(uint64(len(c.ShortIDs)))
+	for _, id := range c.ShortIDs {
+		bw.WriteU64LE(id)
+	}
+}
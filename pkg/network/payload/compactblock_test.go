@@ -0,0 +1,68 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactBlock_EncodeDecodeBinary(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		b := newDumbBlock()
+		_ = b.Hash()
+		expected := &CompactBlock{
+			Header:   b,
+			Nonce:    42,
+			ShortIDs: []uint64{},
+		}
+		testserdes.EncodeDecodeBinary(t, expected, NewCompactBlock(false))
+	})
+
+	t.Run("from block", func(t *testing.T) {
+		b := &block.Block{
+			Header:       *newDumbBlock(),
+			Transactions: []*transaction.Transaction{newDummyTx(), newDummyTx()},
+		}
+		_ = b.Hash()
+		expected := NewCompactBlockFromBlock(b, 123)
+		require.Equal(t, []uint64{
+			ShortID(123, b.Transactions[0].Hash()),
+			ShortID(123, b.Transactions[1].Hash()),
+		}, expected.ShortIDs)
+		testserdes.EncodeDecodeBinary(t, expected, NewCompactBlock(false))
+	})
+
+	t.Run("state root in header", func(t *testing.T) {
+		b := newDumbBlock()
+		b.StateRootEnabled = true
+		b.PrevStateRoot = random.Uint256()
+		_ = b.Hash()
+		expected := &CompactBlock{
+			Header:   b,
+			Nonce:    42,
+			ShortIDs: []uint64{1, 2, 3},
+		}
+		testserdes.EncodeDecodeBinary(t, expected, NewCompactBlock(true))
+	})
+
+	t.Run("too many transactions", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		newDumbBlock().EncodeBinary(w.BinWriter)
+		w.WriteU64LE(42)
+		w.WriteVarUint(block.MaxTransactionsPerBlock + 1)
+		require.NoError(t, w.Err)
+		require.ErrorIs(t, testserdes.DecodeBinary(w.Bytes(), NewCompactBlock(false)), block.ErrMaxContentsPerBlock)
+	})
+}
+
+func TestShortID(t *testing.T) {
+	h := random.Uint256()
+	require.Equal(t, ShortID(1, h), ShortID(1, h))
+	require.NotEqual(t, ShortID(1, h), ShortID(2, h))
+	require.NotEqual(t, ShortID(1, h), ShortID(1, random.Uint256()))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestVersionEncodeDecode(t *testing.T) {
@@ -46,3 +47,38 @@ func TestVersionEncodeDecode(t *testing.T) {
 	assert.Equal(t, versionDecoded.UserAgent, []byte(useragent))
 	assert.Equal(t, version, versionDecoded)
 }
+
+func TestVersionUnknownCapabilities(t *testing.T) {
+	var capabilities = []capability.Capability{
+		{
+			Type: capability.FullNode,
+			Data: &capability.Node{StartHeight: 123},
+		},
+		{
+			Type: 0x7f,
+			Data: &capability.Unknown{Data: []byte{1, 2, 3}},
+		},
+		{
+			Type: capability.CompactBlocks,
+			Data: &capability.Compact{Version: CompactBlocksVersion},
+		},
+		{
+			Type: capability.ExtensibleFilter,
+			Data: &capability.Extensible{Categories: []string{ConsensusCategory}},
+		},
+	}
+	version := NewVersion(1, 2, "/NEO:0.0.1/", capabilities)
+	testserdes.EncodeDecodeBinary(t, version, &Version{})
+
+	// Extension capabilities can be skipped by nodes not knowing them.
+	for _, c := range capabilities[2:] {
+		data, err := testserdes.EncodeBinary(&c)
+		require.NoError(t, err)
+		data[0] = 0x7f
+
+		var u capability.Capability
+		require.NoError(t, testserdes.DecodeBinary(data, &u))
+		require.Equal(t, capability.Type(0x7f), u.Type)
+		require.IsType(t, &capability.Unknown{}, u.Data)
+	}
+}
@@ -12,6 +12,9 @@ type PeerInfo struct {
 	Address   string
 	UserAgent string
 	Height    uint32
+	// Messages contains per-command statistics of messages exchanged with
+	// the peer.
+	Messages map[CommandType]MessageStats
 }
 
 type AddressablePeer interface {
@@ -71,6 +74,12 @@ type Peer interface {
 	LastBlockIndex() uint32
 	Handshaked() bool
 	IsFullNode() bool
+	// SupportsCompactBlocks returns whether the peer has announced compact
+	// block relay support.
+	SupportsCompactBlocks() bool
+	// WantsExtensible returns whether the peer is interested in extensible
+	// payloads of the given category.
+	WantsExtensible(category string) bool
 
 	// SetPingTimer adds an outgoing ping to the counter and sets a PingTimeout
 	// timer that will shut the connection down in case of no response.
@@ -99,4 +108,8 @@ type Peer interface {
 	// CanProcessAddr checks whether an addr command is expected to come from
 	// this peer and can be processed.
 	CanProcessAddr() bool
+
+	// MessageStats returns per-command statistics of messages sent to and
+	// received from this peer.
+	MessageStats() map[CommandType]MessageStats
 }
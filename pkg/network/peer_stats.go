@@ -0,0 +1,84 @@
+package network
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// MessageStats contains the number of messages of some type sent to and
+// received from a peer along with their total size in bytes.
+type MessageStats struct {
+	Sent          uint64
+	SentBytes     uint64
+	Received      uint64
+	ReceivedBytes uint64
+}
+
+// messageCounters tracks per-command message statistics of a single peer.
+type messageCounters struct {
+	lock  sync.Mutex
+	stats map[CommandType]MessageStats
+}
+
+// addSent accounts for a message of the given type and size sent to the peer.
+func (c *messageCounters) addSent(cmd CommandType, size int) {
+	c.lock.Lock()
+	if c.stats == nil {
+		c.stats = make(map[CommandType]MessageStats)
+	}
+	s := c.stats[cmd]
+	s.Sent++
+	s.SentBytes += uint64(size)
+	c.stats[cmd] = s
+	c.lock.Unlock()
+	addMessageMetric(cmd, directionSent, size)
+}
+
+// addReceived accounts for a message of the given type and size received
+// from the peer.
+func (c *messageCounters) addReceived(cmd CommandType, size int) {
+	c.lock.Lock()
+	if c.stats == nil {
+		c.stats = make(map[CommandType]MessageStats)
+	}
+	s := c.stats[cmd]
+	s.Received++
+	s.ReceivedBytes += uint64(size)
+	c.stats[cmd] = s
+	c.lock.Unlock()
+	addMessageMetric(cmd, directionReceived, size)
+}
+
+// addSentPacket accounts for all messages contained in the given serialized
+// packet as sent ones. It only relies on message headers, so trailing garbage
+// (which never happens for packets made by Message.Bytes) is ignored.
+func (c *messageCounters) addSentPacket(b []byte) {
+	for len(b) > 0 {
+		r := io.NewBinReaderFromBuf(b)
+		_ = r.ReadB() // Flags.
+		cmd := CommandType(r.ReadB())
+		l := r.ReadVarUint()
+		if r.Err != nil || l > uint64(r.Len()) {
+			return
+		}
+		size := len(b) - r.Len() + int(l)
+		c.addSent(cmd, size)
+		b = b[size:]
+	}
+}
+
+// snapshot returns a copy of the current statistics.
+func (c *messageCounters) snapshot() map[CommandType]MessageStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return maps.Clone(c.stats)
+}
+
+// size returns the size of the serialized message, it's only valid for
+// messages that are decoded or encoded already.
+func (m *Message) size() int {
+	l := len(m.compressedPayload)
+	return 2 + io.GetVarSize(l) + l
+}
@@ -68,6 +68,33 @@ var (
 	)
 	p2pCmds = make(map[CommandType]prometheus.Histogram)
 
+	p2pMessages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of P2P messages sent and received",
+			Name:      "p2p_messages_total",
+			Namespace: "neogo",
+		},
+		[]string{"command", "direction"},
+	)
+
+	p2pMessageBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Size of P2P messages sent and received",
+			Name:      "p2p_message_bytes_total",
+			Namespace: "neogo",
+		},
+		[]string{"command", "direction"},
+	)
+
+	// blockFetcherHandover prometheus metric.
+	blockFetcherHandover = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Index of the last block fetched from NeoFS before switching to P2P synchronization",
+			Name:      "neofs_blockfetcher_handover_height",
+			Namespace: "neogo",
+		},
+	)
+
 	// notarypoolUnsortedTx prometheus metric.
 	notarypoolUnsortedTx = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -88,13 +115,16 @@ func init() {
 		poolCount,
 		blockQueueLength,
 		notarypoolUnsortedTx,
+		blockFetcherHandover,
+		p2pMessages,
+		p2pMessageBytes,
 	)
 	for _, cmd := range []CommandType{CMDVersion, CMDVerack, CMDGetAddr,
 		CMDAddr, CMDPing, CMDPong, CMDGetHeaders, CMDHeaders, CMDGetBlocks,
 		CMDMempool, CMDInv, CMDGetData, CMDGetBlockByIndex, CMDNotFound,
 		CMDTX, CMDBlock, CMDExtensible, CMDP2PNotaryRequest, CMDGetMPTData,
-		CMDMPTData, CMDReject, CMDFilterLoad, CMDFilterAdd, CMDFilterClear,
-		CMDMerkleBlock, CMDAlert} {
+		CMDMPTData, CMDCompactBlock, CMDGetBlockTxs, CMDBlockTxs, CMDReject,
+		CMDFilterLoad, CMDFilterAdd, CMDFilterClear, CMDMerkleBlock, CMDAlert} {
 		p2pCmds[cmd] = prometheus.NewHistogram(
 			prometheus.HistogramOpts{
 				Help:      "P2P " + cmd.String() + " handling time",
@@ -114,6 +144,10 @@ func updateBlockQueueLenMetric(bqLen int) {
 	blockQueueLength.Set(float64(bqLen))
 }
 
+func updateBlockFetcherHandoverMetric(last uint32) {
+	blockFetcherHandover.Set(float64(last))
+}
+
 func updatePoolCountMetric(pCount int) {
 	poolCount.Set(float64(pCount))
 }
@@ -144,6 +178,25 @@ func addCmdTimeMetric(cmd CommandType, t time.Duration) {
 	p2pCmds[cmd].Observe(t.Seconds())
 }
 
+// Directions of P2P messages used for metrics.
+const (
+	directionSent     = "sent"
+	directionReceived = "received"
+)
+
+// addMessageMetric accounts for a P2P message of the given type and size sent
+// or received via any peer.
+func addMessageMetric(cmd CommandType, direction string, size int) {
+	name := commandName(cmd)
+	p2pMessages.WithLabelValues(name, direction).Inc()
+	p2pMessageBytes.WithLabelValues(name, direction).Add(float64(size))
+}
+
+// commandName returns a short lowercase name of the command (like "getdata").
+func commandName(cmd CommandType) string {
+	return strings.ToLower(strings.TrimPrefix(cmd.String(), "CMD"))
+}
+
 // updateNotarypoolMetrics updates metric of the number of fallback txs inside
 // the notary request pool.
 func updateNotarypoolMetrics(unsortedTxnLen int) {
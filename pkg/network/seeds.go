@@ -0,0 +1,207 @@
+package network
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
+	"go.uber.org/zap"
+)
+
+const (
+	// extSeedsRefreshInterval is the interval between external seed sources
+	// requests made while the node has less than MinPeers peers.
+	extSeedsRefreshInterval = 5 * time.Minute
+	// defaultNeoFSSeedsTimeout is the default timeout for NeoFS seed list
+	// request.
+	defaultNeoFSSeedsTimeout = time.Minute
+	// maxPeerListSize is the maximum size of the NeoFS peer list object.
+	maxPeerListSize = 1 << 20
+)
+
+// PeerList is a list of node addresses signed by some trusted key, it can be
+// published as a NeoFS object to be used as an additional seed source.
+type PeerList struct {
+	Addresses []string `json:"addresses"`
+	// Timestamp is the list creation time in Unix milliseconds.
+	Timestamp uint64 `json:"timestamp"`
+	Signature []byte `json:"signature"`
+}
+
+// signedData returns the data signature is calculated for: little-endian
+// timestamp followed by newline-separated addresses.
+func (l *PeerList) signedData() []byte {
+	var b = binary.LittleEndian.AppendUint64(nil, l.Timestamp)
+	return append(b, strings.Join(l.Addresses, "\n")...)
+}
+
+// Sign signs the list with the given key.
+func (l *PeerList) Sign(priv *keys.PrivateKey) {
+	l.Signature = priv.Sign(l.signedData())
+}
+
+// Verify checks that the list is signed with the given key.
+func (l *PeerList) Verify(pub *keys.PublicKey) bool {
+	return pub.Verify(l.Signature, hash.Sha256(l.signedData()).BytesBE())
+}
+
+// initExtSeeds sets up DNS and NeoFS seed sources if they're configured.
+func (s *Server) initExtSeeds() error {
+	s.lookupTXT = net.DefaultResolver.LookupTXT
+	cfg := s.NeoFSSeedsCfg
+	if cfg.Object == "" {
+		return nil
+	}
+	u, err := url.Parse(cfg.Object)
+	if err != nil {
+		return fmt.Errorf("invalid object URL: %w", err)
+	}
+	s.seedListKey, err = keys.NewPublicKeyFromString(cfg.PublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	tokens, err := neofs.NewTokens(s.log, cfg.BearerTokens, cfg.SessionTokens)
+	if err != nil {
+		return fmt.Errorf("failed to load tokens: %w", err)
+	}
+	s.getSeedList = func(ctx context.Context) ([]byte, error) {
+		// Objects are requested on behalf of a random user, access to
+		// private containers is granted by tokens.
+		priv, err := keys.NewPrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		defer priv.Destroy()
+		var errs []error
+		for _, addr := range cfg.Addresses {
+			rc, err := neofs.Get(ctx, priv, tokens, u, addr)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", addr, err))
+				continue
+			}
+			data, err := io.ReadAll(io.LimitReader(rc, maxPeerListSize))
+			_ = rc.Close()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", addr, err))
+				continue
+			}
+			return data, nil
+		}
+		return nil, errors.Join(errs...)
+	}
+	return nil
+}
+
+// extSeedsLoop requests external seed sources on start and then periodically
+// while the node doesn't have enough peers, all addresses received are added
+// to the discovery pool.
+func (s *Server) extSeedsLoop() {
+	defer close(s.extSeedsFin)
+	if len(s.DNSSeeds) == 0 && s.getSeedList == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-s.quit
+		cancel()
+	}()
+	var timer = time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-timer.C:
+		}
+		if s.HandshakedPeersCount() < s.MinPeers {
+			if addrs := s.resolveExtSeeds(ctx); len(addrs) != 0 {
+				s.discovery.BackFill(addrs...)
+			}
+		}
+		timer.Reset(extSeedsRefreshInterval)
+	}
+}
+
+// resolveExtSeeds returns addresses from all configured external seed
+// sources, failing sources are logged and skipped.
+func (s *Server) resolveExtSeeds(ctx context.Context) []string {
+	var res []string
+	for _, name := range s.DNSSeeds {
+		addrs, err := s.resolveDNSSeed(ctx, name)
+		if err != nil {
+			s.log.Warn("failed to resolve DNS seed", zap.String("name", name), zap.Error(err))
+			continue
+		}
+		s.log.Info("resolved DNS seed", zap.String("name", name), zap.Int("addresses", len(addrs)))
+		res = append(res, addrs...)
+	}
+	if s.getSeedList != nil {
+		addrs, err := s.fetchNeoFSSeeds(ctx)
+		if err != nil {
+			s.log.Warn("failed to fetch NeoFS seed list", zap.String("object", s.NeoFSSeedsCfg.Object), zap.Error(err))
+		} else {
+			s.log.Info("fetched NeoFS seed list", zap.Int("addresses", len(addrs)))
+			res = append(res, addrs...)
+		}
+	}
+	return res
+}
+
+// resolveDNSSeed returns addresses listed in TXT records of the given domain,
+// every record can contain several whitespace or comma separated addresses in
+// "host:port" form. Invalid addresses are skipped.
+func (s *Server) resolveDNSSeed(ctx context.Context, name string) ([]string, error) {
+	records, err := s.lookupTXT(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, r := range records {
+		for _, addr := range strings.FieldsFunc(r, func(c rune) bool {
+			return c == ',' || c == ' ' || c == '\t'
+		}) {
+			if _, _, err := net.SplitHostPort(addr); err != nil {
+				s.log.Debug("skipping invalid DNS seed address", zap.String("name", name), zap.String("address", addr))
+				continue
+			}
+			res = append(res, addr)
+		}
+	}
+	return res, nil
+}
+
+// fetchNeoFSSeeds fetches and checks the signed peer list from NeoFS.
+func (s *Server) fetchNeoFSSeeds(ctx context.Context) ([]string, error) {
+	var timeout = s.NeoFSSeedsCfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultNeoFSSeedsTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	data, err := s.getSeedList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var l PeerList
+	if err = json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("invalid peer list: %w", err)
+	}
+	if !l.Verify(s.seedListKey) {
+		return nil, errors.New("invalid peer list signature")
+	}
+	if maxAge := s.NeoFSSeedsCfg.MaxAge; maxAge > 0 && time.Since(time.UnixMilli(int64(l.Timestamp))) > maxAge {
+		return nil, fmt.Errorf("peer list is too old (%s)", time.UnixMilli(int64(l.Timestamp)).UTC())
+	}
+	return l.Addresses, nil
+}
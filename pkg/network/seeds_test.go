@@ -0,0 +1,80 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerList_SignVerify(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	other, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	l := &PeerList{Addresses: []string{"1.2.3.4:10333", "seed.example.org:10333"}, Timestamp: 123}
+	l.Sign(priv)
+	require.True(t, l.Verify(priv.PublicKey()))
+	require.False(t, l.Verify(other.PublicKey()))
+
+	l.Timestamp++
+	require.False(t, l.Verify(priv.PublicKey()))
+}
+
+func TestServer_ResolveExtSeeds(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	s := newTestServer(t, ServerConfig{DNSSeeds: []string{"seeds.example.org", "broken.example.org"}})
+	s.lookupTXT = func(_ context.Context, name string) ([]string, error) {
+		if name == "broken.example.org" {
+			return nil, errors.New("no such host")
+		}
+		return []string{"1.2.3.4:10333, 5.6.7.8:10333", "garbage [::1]:10333"}, nil
+	}
+	require.Equal(t, []string{"1.2.3.4:10333", "5.6.7.8:10333", "[::1]:10333"}, s.resolveExtSeeds(context.Background()))
+
+	var list = &PeerList{Addresses: []string{"9.9.9.9:10333"}, Timestamp: uint64(time.Now().UnixMilli())}
+	s.DNSSeeds = nil
+	s.seedListKey = priv.PublicKey()
+	s.getSeedList = func(context.Context) ([]byte, error) {
+		return json.Marshal(list)
+	}
+
+	t.Run("bad signature", func(t *testing.T) {
+		require.Empty(t, s.resolveExtSeeds(context.Background()))
+	})
+
+	list.Sign(priv)
+	t.Run("good", func(t *testing.T) {
+		require.Equal(t, []string{"9.9.9.9:10333"}, s.resolveExtSeeds(context.Background()))
+	})
+
+	t.Run("too old", func(t *testing.T) {
+		s.NeoFSSeedsCfg.MaxAge = time.Millisecond
+		time.Sleep(2 * time.Millisecond)
+		_, err := s.fetchNeoFSSeeds(context.Background())
+		require.ErrorContains(t, err, "peer list is too old")
+	})
+}
+
+func TestServer_ExtSeedsLoop(t *testing.T) {
+	s := newTestServer(t, ServerConfig{MinPeers: 1, DNSSeeds: []string{"seeds.example.org"}})
+	s.lookupTXT = func(context.Context, string) ([]string, error) {
+		return []string{"1.2.3.4:10333"}, nil
+	}
+	go s.extSeedsLoop()
+	d := s.discovery.(*testDiscovery)
+	require.Eventually(t, func() bool {
+		d.Lock()
+		defer d.Unlock()
+		return len(d.backfill) == 1
+	}, time.Second, 10*time.Millisecond)
+	close(s.quit)
+	<-s.extSeedsFin
+}
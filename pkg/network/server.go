@@ -22,6 +22,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/bqueue"
@@ -41,7 +42,25 @@ const (
 	defaultExtensiblePoolSize = 20
 	defaultBroadcastFactor    = 0
 	maxBlockBatch             = 200
+	maxPendingCompactBlocks   = 16
 	peerTimeFactor            = 1000
+	// mptRequestsPerPeer is the number of MPT data requests sent to a peer at
+	// once during state synchronization, every response triggers one more
+	// request to keep the pipeline full.
+	mptRequestsPerPeer = 4
+)
+
+// Feature flags (set by the committee via Policy contract) affecting Server
+// behavior. They override local configuration at runtime, allowing to change
+// relay policies network-wide without node reconfiguration.
+const (
+	// FlagBroadcastFactor overrides configured BroadcastFactor when set to a
+	// value in 0-100 range.
+	FlagBroadcastFactor = "BroadcastFactor"
+	// FlagDisableCompactBlocks disables compact block relay (if it's
+	// enabled in the configuration) when set to a non-zero value. Compact
+	// blocks received from other peers are still processed.
+	FlagDisableCompactBlocks = "DisableCompactBlocks"
 )
 
 var (
@@ -52,6 +71,11 @@ var (
 	errServerShutdown      = errors.New("server shutdown")
 	errInvalidInvType      = errors.New("invalid inventory type")
 	errBlocksRequestFailed = errors.New("blocks request failed")
+	errCompactDisabled     = errors.New("compact blocks are disabled")
+	errHeaderMismatch      = errors.New("block doesn't match verified header")
+	// ErrSyncInProgress is returned from RelayBlock when the node is being
+	// synchronized by NeoFS BlockFetcher or state sync module.
+	ErrSyncInProgress = errors.New("node synchronization is in progress")
 )
 
 type (
@@ -62,6 +86,7 @@ type (
 		bqueue.Blockqueuer
 		GetBlock(hash util.Uint256) (*block.Block, error)
 		GetConfig() config.Blockchain
+		GetFeatureFlag(name string) (int64, bool)
 		GetHeader(hash util.Uint256) (*block.Header, error)
 		GetHeaderHash(uint32) util.Uint256
 		GetMaxVerificationGAS() int64
@@ -87,6 +112,12 @@ type (
 		Shutdown()
 	}
 
+	// HealthReporter is an optional interface for Service that is able to
+	// report its state, nil error means the service is working properly.
+	HealthReporter interface {
+		Health() error
+	}
+
 	// Server represents the local Node in the network. Its transport could
 	// be of any kind.
 	Server struct {
@@ -110,13 +141,18 @@ type (
 		extensiblePool    *extpool.Pool
 		notaryFeer        NotaryFeer
 		blockFetcher      *blockfetcher.Service
+		syncProfiler      *syncProfiler
 
 		serviceLock    sync.RWMutex
 		services       map[string]Service
+		serviceOrder   []string // Service names in the order they were added.
 		extensHandlers map[string]func(*payload.Extensible) error
 		txCallback     func(*transaction.Transaction)
 		txCbList       atomic.Value
 
+		compactLock   sync.Mutex
+		pendingBlocks map[util.Uint256]*pendingCompactBlock
+
 		txInLock sync.RWMutex
 		txin     chan *transaction.Transaction
 		txInMap  map[util.Uint256]struct{}
@@ -137,10 +173,19 @@ type (
 		broadcastTxFin      chan struct{}
 		runProtoFin         chan struct{}
 		blockFetcherFin     chan struct{}
+		extSeedsFin         chan struct{}
+
+		// External seed sources, see extSeedsLoop.
+		lookupTXT   func(ctx context.Context, name string) ([]string, error)
+		getSeedList func(ctx context.Context) ([]byte, error)
+		seedListKey *keys.PublicKey
 
 		transactions chan *transaction.Transaction
 
 		syncReached atomic.Bool
+		// blockFetcherHandover is set once NeoFS BlockFetcher reaches the
+		// container tip, P2P blocks synchronization is resumed after that.
+		blockFetcherHandover atomic.Bool
 
 		stateSync StateSync
 
@@ -156,6 +201,14 @@ type (
 		peer   Peer
 		reason error
 	}
+
+	// pendingCompactBlock is a block reconstructed from CompactBlock payload
+	// that waits for the missing transactions requested from peer.
+	pendingCompactBlock struct {
+		peer    Peer
+		block   *block.Block
+		missing []uint16
+	}
 )
 
 func randomID() uint32 {
@@ -179,6 +232,9 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		return nil, errors.New("logger is a required parameter")
 	}
 
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = defaultShutdownTimeout
+	}
 	if config.ExtensiblePoolSize <= 0 {
 		config.ExtensiblePoolSize = defaultExtensiblePoolSize
 		log.Info("ExtensiblePoolSize is not set or wrong, using default value",
@@ -196,10 +252,12 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		broadcastTxFin:  make(chan struct{}),
 		runProtoFin:     make(chan struct{}),
 		blockFetcherFin: make(chan struct{}),
+		extSeedsFin:     make(chan struct{}),
 		register:        make(chan Peer),
 		unregister:      make(chan peerDrop),
 		handshake:       make(chan Peer),
 		txInMap:         make(map[util.Uint256]struct{}),
+		pendingBlocks:   make(map[util.Uint256]*pendingCompactBlock),
 		peers:           make(map[Peer]bool),
 		mempool:         chain.GetMemPool(),
 		extensiblePool:  extpool.New(chain, config.ExtensiblePoolSize),
@@ -219,20 +277,35 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 			}, s.notaryFeer)
 		})
 	}
+	if config.SyncProfile {
+		if ps, ok := chain.(blockProfilerSetter); ok {
+			s.syncProfiler = newSyncProfiler(log, syncProfileWindow)
+			ps.SetBlockProfiler(s.syncProfiler)
+		} else {
+			log.Warn("sync profiling is not supported by the ledger")
+		}
+	}
 	s.bQueue = bqueue.New(chain, log, func(b *block.Block) {
 		s.tryStartServices()
-	}, bqueue.DefaultCacheSize, updateBlockQueueLenMetric, bqueue.NonBlocking)
+	}, bqueue.DefaultCacheSize, updateBlockQueueLenMetric, bqueue.NonBlocking, bqueue.OverflowConfig{})
 
-	s.bSyncQueue = bqueue.New(s.stateSync, log, nil, bqueue.DefaultCacheSize, updateBlockQueueLenMetric, bqueue.NonBlocking)
-	s.bFetcherQueue = bqueue.New(chain, log, nil, s.NeoFSBlockFetcherCfg.BQueueSize, updateBlockQueueLenMetric, bqueue.Blocking)
+	s.bSyncQueue = bqueue.New(s.stateSync, log, nil, bqueue.DefaultCacheSize, updateBlockQueueLenMetric, bqueue.NonBlocking, bqueue.OverflowConfig{})
+	s.bFetcherQueue = bqueue.New(chain, log, nil, s.NeoFSBlockFetcherCfg.BQueueSize, updateBlockQueueLenMetric, bqueue.Blocking, bqueue.OverflowConfig{
+		Size: s.NeoFSBlockFetcherCfg.BQueueOverflowSize,
+		Dir:  s.NeoFSBlockFetcherCfg.BQueueOverflowPath,
+	})
 	var err error
-	s.blockFetcher, err = blockfetcher.New(chain, s.NeoFSBlockFetcherCfg, log, s.bFetcherQueue.PutBlock, func() {
+	s.blockFetcher, err = blockfetcher.New(chain, s.NeoFSBlockFetcherCfg, log, s.bFetcherQueue.PutBlock, s.handleBlockFetcherHandover, func() {
 		close(s.blockFetcherFin)
 	})
 	if err != nil && config.NeoFSBlockFetcherCfg.Enabled {
 		return nil, fmt.Errorf("failed to create NeoFS BlockFetcher: %w", err)
 	}
 
+	if err = s.initExtSeeds(); err != nil {
+		return nil, fmt.Errorf("failed to initialize NeoFS seeds: %w", err)
+	}
+
 	if s.MinPeers < 0 {
 		s.log.Info("bad MinPeers configured, using the default value",
 			zap.Int("configured", s.MinPeers),
@@ -321,6 +394,7 @@ func (s *Server) Start() {
 	setServerAndNodeVersions(s.UserAgent, strconv.FormatUint(uint64(s.id), 10))
 	setNeoGoVersion(config.Version)
 	setSeverID(strconv.FormatUint(uint64(s.id), 10))
+	go s.extSeedsLoop()
 	go s.run()
 }
 
@@ -331,8 +405,11 @@ func (s *Server) Shutdown() {
 		return
 	}
 	s.log.Info("shutting down server", zap.Int("peers", s.PeerCount()))
+	// Components are stopped in the order reverse to their dependencies, every
+	// one of them is given ShutdownTimeout to stop, so that a single stuck
+	// service doesn't prevent the node from exiting.
 	if s.ServerConfig.NeoFSBlockFetcherCfg.Enabled {
-		s.blockFetcher.Shutdown()
+		s.stopWithDeadline("NeoFSBlockFetcher", s.blockFetcher.Shutdown)
 	}
 	for _, tr := range s.transports {
 		tr.Close()
@@ -344,19 +421,27 @@ func (s *Server) Shutdown() {
 	s.bSyncQueue.Discard()
 	s.bFetcherQueue.Discard()
 	s.serviceLock.RLock()
-	for _, svc := range s.services {
-		svc.Shutdown()
+	services := make([]Service, 0, len(s.serviceOrder))
+	for i := len(s.serviceOrder) - 1; i >= 0; i-- {
+		services = append(services, s.services[s.serviceOrder[i]])
 	}
 	s.serviceLock.RUnlock()
+	for _, svc := range services {
+		s.stopWithDeadline(svc.Name(), svc.Shutdown)
+	}
 	if s.chain.P2PSigExtensionsEnabled() {
 		s.notaryRequestPool.StopSubscriptions()
 	}
+	s.stopSyncProfiler()
 	close(s.quit)
-	<-s.broadcastTxFin
-	<-s.runProtoFin
-	<-s.relayFin
-	<-s.runFin
-	s.txHandlerLoopWG.Wait()
+	s.stopWithDeadline("network", func() {
+		<-s.broadcastTxFin
+		<-s.runProtoFin
+		<-s.relayFin
+		<-s.runFin
+		<-s.extSeedsFin
+		s.txHandlerLoopWG.Wait()
+	})
 
 	_ = s.log.Sync()
 }
@@ -370,6 +455,9 @@ func (s *Server) AddService(svc Service) {
 
 // addService is an unlocked version of AddService.
 func (s *Server) addService(svc Service) {
+	if _, ok := s.services[svc.Name()]; !ok {
+		s.serviceOrder = append(s.serviceOrder, svc.Name())
+	}
 	s.services[svc.Name()] = svc
 }
 
@@ -410,6 +498,7 @@ func (s *Server) DelService(svc Service) {
 // delService is an unlocked version of DelService.
 func (s *Server) delService(svc Service) {
 	delete(s.services, svc.Name())
+	s.serviceOrder = slices.DeleteFunc(s.serviceOrder, func(name string) bool { return name == svc.Name() })
 }
 
 // DelExtensibleService drops a service that handler extensible payloads from the
@@ -461,6 +550,7 @@ func (s *Server) ConnectedPeers() []PeerInfo {
 			Address:   k.PeerAddr().String(),
 			UserAgent: string(k.Version().UserAgent),
 			Height:    k.LastBlockIndex(),
+			Messages:  k.MessageStats(),
 		})
 	}
 
@@ -606,6 +696,7 @@ func (s *Server) tryStartServices() {
 
 	if s.IsInSync() && s.syncReached.CompareAndSwap(false, true) {
 		s.log.Info("node reached synchronized state, starting services")
+		s.stopSyncProfiler()
 		if s.chain.P2PSigExtensionsEnabled() {
 			s.notaryRequestPool.RunSubscriptions() // WSClient is also a subscriber.
 		}
@@ -617,6 +708,16 @@ func (s *Server) tryStartServices() {
 	}
 }
 
+// stopSyncProfiler detaches sync profiler from the chain (if it's enabled) and
+// logs the final report.
+func (s *Server) stopSyncProfiler() {
+	if s.syncProfiler == nil {
+		return
+	}
+	s.chain.(blockProfilerSetter).SetBlockProfiler(nil)
+	s.syncProfiler.report()
+}
+
 // SubscribeForNotaryRequests adds the given channel to a notary request event
 // broadcasting, so when a new P2PNotaryRequest is received or an existing
 // P2PNotaryRequest is removed from the pool you'll receive it via this channel.
@@ -707,6 +808,34 @@ func (s *Server) getVersionMsg(localAddr net.Addr) (*Message, error) {
 			},
 		})
 	}
+	if s.CompactBlocks {
+		capabilities = append(capabilities, capability.Capability{
+			Type: capability.CompactBlocks,
+			Data: &capability.Compact{
+				Version: payload.CompactBlocksVersion,
+			},
+		})
+	}
+	if s.ExtensibleFilter {
+		s.serviceLock.RLock()
+		categories := slices.Clone(s.ExtensibleCategories)
+		for c := range s.extensHandlers {
+			if !slices.Contains(categories, c) {
+				categories = append(categories, c)
+			}
+		}
+		s.serviceLock.RUnlock()
+		if len(categories) > capability.MaxExtensibleCategories {
+			return nil, fmt.Errorf("too many extensible categories: %d", len(categories))
+		}
+		slices.Sort(categories)
+		capabilities = append(capabilities, capability.Capability{
+			Type: capability.ExtensibleFilter,
+			Data: &capability.Extensible{
+				Categories: categories,
+			},
+		})
+	}
 	payload := payload.NewVersion(
 		s.Net,
 		s.id,
@@ -757,6 +886,42 @@ func (s *Server) IsInSync() bool {
 	return peersNumber >= s.MinPeers && (3*notHigher > 2*peersNumber) // && s.bQueue.length() == 0
 }
 
+// Health returns the state of node subsystems keyed by their names, nil error
+// means the subsystem is working properly. Services (see AddService) are only
+// checked after the node reaches synchronized state since they're not started
+// before it.
+func (s *Server) Health() map[string]error {
+	var res = make(map[string]error)
+
+	res["network"] = nil
+	if s.MinPeers > 0 && s.HandshakedPeersCount() == 0 {
+		res["network"] = errors.New("no connected peers")
+	}
+	if s.NeoFSBlockFetcherCfg.Enabled {
+		res["blockfetcher"] = s.blockFetcher.Health()
+	}
+	if !s.syncReached.Load() {
+		return res
+	}
+	s.serviceLock.RLock()
+	for name, svc := range s.services {
+		if h, ok := svc.(HealthReporter); ok {
+			res[name] = h.Health()
+		}
+	}
+	s.serviceLock.RUnlock()
+	return res
+}
+
+// Ready returns an error if the node is not yet synchronized with the network
+// (see IsInSync) and thus can't serve up-to-date data.
+func (s *Server) Ready() error {
+	if !s.IsInSync() {
+		return fmt.Errorf("not in sync: height %d", s.chain.BlockHeight())
+	}
+	return nil
+}
+
 // When a peer sends out its version, we reply with verack after validating
 // the version.
 func (s *Server) handleVersionCmd(p Peer, version *payload.Version) error {
@@ -791,15 +956,147 @@ func (s *Server) handleVersionCmd(p Peer, version *payload.Version) error {
 
 // handleBlockCmd processes the block received from its peer.
 func (s *Server) handleBlockCmd(p Peer, block *block.Block) error {
-	if s.blockFetcher.IsActive() {
+	if s.blockFetcherSyncing() {
 		return nil
 	}
 	if s.stateSync.IsActive() {
 		return s.bSyncQueue.PutBlock(block)
 	}
+	// Headers might be synchronized ahead of blocks, don't let a peer
+	// occupy the queue slot with a block not matching them.
+	if block.Index > s.chain.BlockHeight() && block.Index <= s.chain.HeaderHeight() {
+		if h := s.chain.GetHeaderHash(block.Index); !h.Equals(block.Hash()) {
+			return fmt.Errorf("%w: %d", errHeaderMismatch, block.Index)
+		}
+	}
 	return s.bQueue.PutBlock(block)
 }
 
+// handleCompactBlockCmd processes the compact block received from its peer.
+// It tries to reconstruct the block using mempool transactions and requests
+// the missing ones from the peer.
+func (s *Server) handleCompactBlockCmd(p Peer, cb *payload.CompactBlock) error {
+	if !s.CompactBlocks {
+		return fmt.Errorf("%w: CompactBlockCMD was received", errCompactDisabled)
+	}
+	if s.blockFetcherSyncing() || s.stateSync.IsActive() || cb.Index <= s.chain.BlockHeight() {
+		return nil
+	}
+	var (
+		h   = cb.Hash()
+		ids = make(map[uint64]int, len(cb.ShortIDs))
+		b   = &block.Block{
+			Header:       *cb.Header,
+			Transactions: make([]*transaction.Transaction, len(cb.ShortIDs)),
+		}
+		missing []uint16
+	)
+	for i, id := range cb.ShortIDs {
+		if _, ok := ids[id]; ok {
+			// Short ID collision, the block can't be reconstructed.
+			return s.requestBlock(p, h)
+		}
+		ids[id] = i
+	}
+	if len(ids) != 0 {
+		for _, tx := range s.mempool.GetVerifiedTransactions() {
+			if i, ok := ids[payload.ShortID(cb.Nonce, tx.Hash())]; ok {
+				b.Transactions[i] = tx
+			}
+		}
+	}
+	for i, tx := range b.Transactions {
+		if tx == nil {
+			missing = append(missing, uint16(i))
+		}
+	}
+	if len(missing) == 0 {
+		return s.completeCompactBlock(p, b)
+	}
+
+	s.compactLock.Lock()
+	for ph, pb := range s.pendingBlocks {
+		if pb.block.Index <= s.chain.BlockHeight() {
+			delete(s.pendingBlocks, ph)
+		}
+	}
+	_, requested := s.pendingBlocks[h]
+	full := len(s.pendingBlocks) >= maxPendingCompactBlocks
+	if !requested && !full {
+		s.pendingBlocks[h] = &pendingCompactBlock{peer: p, block: b, missing: missing}
+	}
+	s.compactLock.Unlock()
+	switch {
+	case requested:
+		return nil
+	case full:
+		return s.requestBlock(p, h)
+	default:
+		return p.EnqueueP2PMessage(NewMessage(CMDGetBlockTxs, payload.NewGetBlockTxs(h, missing)))
+	}
+}
+
+// handleGetBlockTxsCmd processes the request for block transactions.
+func (s *Server) handleGetBlockTxsCmd(p Peer, req *payload.GetBlockTxs) error {
+	if !s.CompactBlocks {
+		return fmt.Errorf("%w: GetBlockTxsCMD was received", errCompactDisabled)
+	}
+	b, err := s.chain.GetBlock(req.Hash)
+	if err != nil {
+		return p.EnqueueP2PMessage(NewMessage(CMDNotFound, payload.NewInventory(payload.BlockType, []util.Uint256{req.Hash})))
+	}
+	txs := make([]*transaction.Transaction, len(req.Indexes))
+	for i, idx := range req.Indexes {
+		if int(idx) >= len(b.Transactions) {
+			return fmt.Errorf("invalid transaction index %d for block %s", idx, req.Hash.StringLE())
+		}
+		txs[i] = b.Transactions[idx]
+	}
+	return p.EnqueueP2PMessage(NewMessage(CMDBlockTxs, payload.NewBlockTxs(req.Hash, txs)))
+}
+
+// handleBlockTxsCmd processes transactions received for the pending compact
+// block.
+func (s *Server) handleBlockTxsCmd(p Peer, bt *payload.BlockTxs) error {
+	if !s.CompactBlocks {
+		return fmt.Errorf("%w: BlockTxsCMD was received", errCompactDisabled)
+	}
+	s.compactLock.Lock()
+	pb, ok := s.pendingBlocks[bt.Hash]
+	ok = ok && pb.peer == p
+	if ok {
+		delete(s.pendingBlocks, bt.Hash)
+	}
+	s.compactLock.Unlock()
+	if !ok {
+		return nil // Not requested from this peer or already processed.
+	}
+	if len(bt.Transactions) != len(pb.missing) {
+		return fmt.Errorf("unexpected number of transactions for block %s: %d instead of %d",
+			bt.Hash.StringLE(), len(bt.Transactions), len(pb.missing))
+	}
+	for i, idx := range pb.missing {
+		pb.block.Transactions[idx] = bt.Transactions[i]
+	}
+	return s.completeCompactBlock(p, pb.block)
+}
+
+// completeCompactBlock checks the reconstructed block against its header and
+// processes it as a regular block. The full block is requested from the peer
+// if it doesn't match (which is possible in case of short ID collisions).
+func (s *Server) completeCompactBlock(p Peer, b *block.Block) error {
+	if b.ComputeMerkleRoot() != b.MerkleRoot {
+		return s.requestBlock(p, b.Hash())
+	}
+	return s.handleBlockCmd(p, b)
+}
+
+// requestBlock sends a CMDGetData message for the block with the given hash
+// to the peer.
+func (s *Server) requestBlock(p Peer, h util.Uint256) error {
+	return p.EnqueueP2PMessage(NewMessage(CMDGetData, payload.NewInventory(payload.BlockType, []util.Uint256{h})))
+}
+
 // handlePing processes a ping request.
 func (s *Server) handlePing(p Peer, ping *payload.Ping) error {
 	err := p.HandlePing(ping)
@@ -813,8 +1110,33 @@ func (s *Server) handlePing(p Peer, ping *payload.Ping) error {
 	return p.EnqueueP2PMessage(NewMessage(CMDPong, payload.NewPing(s.chain.BlockHeight(), s.id)))
 }
 
+// blockFetcherSyncing returns true if NeoFS BlockFetcher is active and hasn't
+// reached the container tip yet, P2P blocks synchronization is paused then.
+func (s *Server) blockFetcherSyncing() bool {
+	return s.blockFetcher.IsActive() && !s.blockFetcherHandover.Load()
+}
+
+// handleBlockFetcherHandover is called by NeoFS BlockFetcher once it reaches
+// the container tip, last is the index of the last block available in NeoFS.
+// It resumes P2P blocks synchronization without waiting for BlockFetcher to
+// finish downloading, blocks not exceeding last are still fetched from NeoFS.
+func (s *Server) handleBlockFetcherHandover(last uint32) {
+	var netHeight uint32
+	for _, p := range s.getPeers(nil) {
+		netHeight = max(netHeight, p.LastBlockIndex())
+	}
+	updateBlockFetcherHandoverMetric(last)
+	s.log.Info("NeoFS BlockFetcher reached the container tip, resuming P2P synchronization",
+		zap.Uint32("handover", last),
+		zap.Uint32("network", netHeight))
+	s.blockFetcherHandover.Store(true)
+	// Peers reply with pongs containing their heights, that triggers blocks
+	// requests.
+	s.broadcastMessage(NewMessage(CMDPing, payload.NewPing(s.chain.BlockHeight(), s.id)))
+}
+
 func (s *Server) requestBlocksOrHeaders(p Peer) error {
-	if s.blockFetcher.IsActive() {
+	if s.blockFetcherSyncing() {
 		return nil
 	}
 	if s.stateSync.NeedHeaders() {
@@ -830,6 +1152,8 @@ func (s *Server) requestBlocksOrHeaders(p Peer) error {
 	if s.stateSync.IsActive() {
 		bq = s.stateSync
 		requestMPTNodes = s.stateSync.NeedMPTNodes()
+	} else if s.HeadersPrefetch > 0 {
+		return s.requestHeadersFirst(p)
 	}
 	if bq.BlockHeight() >= p.LastBlockIndex() {
 		return nil
@@ -839,7 +1163,7 @@ func (s *Server) requestBlocksOrHeaders(p Peer) error {
 		return fmt.Errorf("%w: %w", errBlocksRequestFailed, err)
 	}
 	if requestMPTNodes {
-		return s.requestMPTNodes(p, s.stateSync.GetUnknownMPTNodesBatch(payload.MaxMPTHashesCount))
+		return s.requestMPTNodesBatches(p, mptRequestsPerPeer)
 	}
 	return nil
 }
@@ -850,6 +1174,37 @@ func (s *Server) requestHeaders(p Peer) error {
 	return p.EnqueueP2PMessage(NewMessage(CMDGetHeaders, pl))
 }
 
+// requestHeadersFirst requests headers ahead of the current block height (up
+// to HeadersPrefetch of them) and blocks for the headers that are already
+// verified and stored.
+func (s *Server) requestHeadersFirst(p Peer) error {
+	var (
+		height     = s.chain.BlockHeight()
+		hdrHeight  = s.chain.HeaderHeight()
+		peerHeight = p.LastBlockIndex()
+	)
+	if hdrHeight < peerHeight && hdrHeight-height < s.HeadersPrefetch {
+		count := min(s.HeadersPrefetch-(hdrHeight-height), peerHeight-hdrHeight, payload.MaxHeadersAllowed)
+		// Headers can only be added successively, so they're always
+		// requested from the current header height and duplicates are
+		// just ignored.
+		err := p.EnqueueP2PMessage(NewMessage(CMDGetHeaders, payload.NewGetBlockByIndex(hdrHeight+1, int16(count))))
+		if err != nil {
+			return fmt.Errorf("%w: %w", errBlocksRequestFailed, err)
+		}
+	}
+	// The peer can't have blocks above its height.
+	hdrHeight = min(hdrHeight, peerHeight)
+	if hdrHeight <= height {
+		return nil
+	}
+	err := s.requestBlocksByHash(p, height, hdrHeight)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errBlocksRequestFailed, err)
+	}
+	return nil
+}
+
 // handlePing processes a pong request.
 func (s *Server) handlePong(p Peer, pong *payload.Ping) error {
 	err := p.HandlePong(pong)
@@ -1033,7 +1388,30 @@ func (s *Server) handleMPTDataCmd(p Peer, data *payload.MPTData) error {
 	if !s.config.P2PStateExchangeExtensions {
 		return errors.New("MPTDataCMD was received, but P2PStateExchangeExtensions are disabled")
 	}
-	return s.stateSync.AddMPTNodes(data.Nodes)
+	err := s.stateSync.AddMPTNodes(data.Nodes)
+	if err != nil {
+		return err
+	}
+	if s.stateSync.NeedMPTNodes() {
+		return s.requestMPTNodesBatches(p, 1)
+	}
+	return nil
+}
+
+// requestMPTNodesBatches requests up to n batches of unknown MPT nodes from
+// the peer. Nodes that are already requested from other peers are skipped.
+func (s *Server) requestMPTNodesBatches(p Peer, n int) error {
+	for range n {
+		itms := s.stateSync.RequestMPTNodesBatch(payload.MaxMPTHashesCount)
+		if len(itms) == 0 {
+			return nil
+		}
+		err := s.requestMPTNodes(p, itms)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // requestMPTNodes requests the specified MPT nodes from the peer or broadcasts
@@ -1138,7 +1516,19 @@ func (s *Server) handleHeadersCmd(p Peer, h *payload.Headers) error {
 	if s.blockFetcher.IsActive() {
 		return nil
 	}
-	return s.stateSync.AddHeaders(h.Hdrs...)
+	if s.stateSync.IsActive() || s.HeadersPrefetch == 0 {
+		return s.stateSync.AddHeaders(h.Hdrs...)
+	}
+	if len(h.Hdrs) == 0 || h.Hdrs[0].Index > s.chain.HeaderHeight()+1 {
+		// Reply to some outdated request, there is a gap now.
+		return nil
+	}
+	err := s.chain.AddHeaders(h.Hdrs...)
+	if err != nil {
+		return err
+	}
+	// Request blocks for the new headers (and more headers) right away.
+	return s.requestBlocksOrHeaders(p)
 }
 
 // handleExtensibleCmd processes the received extensible payload.
@@ -1167,14 +1557,19 @@ func (s *Server) handleExtensibleCmd(e *payload.Extensible) error {
 }
 
 func (s *Server) advertiseExtensible(e *payload.Extensible) {
-	msg := NewMessage(CMDInv, payload.NewInventory(payload.ExtensibleType, []util.Uint256{e.Hash()}))
+	var (
+		msg  = NewMessage(CMDInv, payload.NewInventory(payload.ExtensibleType, []util.Uint256{e.Hash()}))
+		send = Peer.BroadcastPacket
+	)
 	if e.Category == payload.ConsensusCategory {
 		// It's high priority because it directly affects consensus process,
 		// even though it's just an inv.
-		s.broadcastHPMessage(msg)
-	} else {
-		s.broadcastMessage(msg)
+		send = Peer.BroadcastHPPacket
 	}
+	// Peers filtering extensible payloads don't need other categories.
+	s.iteratePeersWithSendMsg(msg, send, func(p Peer) bool {
+		return p.Handshaked() && p.WantsExtensible(e.Category)
+	})
 }
 
 // handleTxCmd processes the received transaction.
@@ -1250,6 +1645,29 @@ func (s *Server) handleP2PNotaryRequestCmd(r *payload.P2PNotaryRequest) error {
 	return nil
 }
 
+// RelayBlock adds the given block to the chain and relays it to the connected
+// peers. It's intended for out-of-band block submission, like blocks produced
+// by external consensus implementations replacing dBFT, so the block passes
+// the same validation as any block received via P2P (its index must be the
+// next one and the header witness must match the previous block's
+// NextConsensus). Blocks can't be submitted while the chain is being
+// synchronized via NeoFS BlockFetcher or state sync module (ErrSyncInProgress
+// is returned then). Consensus service (if any) is notified about the new block
+// in the same way it's notified about P2P ones, so that it moves to the next
+// height.
+func (s *Server) RelayBlock(b *block.Block) error {
+	if s.blockFetcher.IsActive() || s.stateSync.IsActive() {
+		return ErrSyncInProgress
+	}
+	err := s.chain.AddBlock(b)
+	if err != nil {
+		return err
+	}
+	// Blocks are relayed by relayBlocksLoop once they're added to the chain.
+	s.tryStartServices()
+	return nil
+}
+
 // RelayP2PNotaryRequest adds the given request to the pool and relays. It does not check
 // P2PSigExtensions enabled.
 func (s *Server) RelayP2PNotaryRequest(r *payload.P2PNotaryRequest) error {
@@ -1349,6 +1767,42 @@ func (s *Server) requestBlocks(bq bqueue.Blockqueuer, p Peer) error {
 	return p.EnqueueP2PMessage(NewMessage(CMDGetBlockByIndex, pl))
 }
 
+// requestBlocksByHash sends a CMDGetData message to the peer requesting
+// blocks for the verified headers above the current height. The same chunked
+// distribution as in requestBlocks is used to fetch blocks from different
+// peers in parallel, but requests are keyed by header hashes, so that only
+// the blocks matching headers can be received.
+func (s *Server) requestBlocksByHash(p Peer, height uint32, hdrHeight uint32) error {
+	pl := getRequestBlocksPayload(p, height, &s.lastRequestedBlock)
+	lq, capLeft := s.bQueue.LastQueued()
+	if capLeft == 0 {
+		// No more blocks will fit into the queue.
+		return nil
+	}
+	var (
+		start = pl.IndexStart
+		count = payload.MaxHashesCount
+	)
+	if lq >= start {
+		count = min(count, capLeft)
+		start = lq + 1
+	}
+	if start > hdrHeight {
+		// Blocks above the header height can't be requested by hash
+		// yet, so retry the first missing ones.
+		start = max(height, lq) + 1
+		if start > hdrHeight {
+			return nil
+		}
+	}
+	end := min(hdrHeight, start+uint32(count)-1)
+	hashes := make([]util.Uint256, 0, end-start+1)
+	for i := start; i <= end; i++ {
+		hashes = append(hashes, s.chain.GetHeaderHash(i))
+	}
+	return p.EnqueueP2PMessage(NewMessage(CMDGetData, payload.NewInventory(payload.BlockType, hashes)))
+}
+
 func getRequestBlocksPayload(p Peer, currHeight uint32, lastRequestedHeight *atomic.Uint32) *payload.GetBlockByIndex {
 	var peerHeight = p.LastBlockIndex()
 	var needHeight uint32
@@ -1429,6 +1883,15 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 		case CMDBlock:
 			block := msg.Payload.(*block.Block)
 			return s.handleBlockCmd(peer, block)
+		case CMDCompactBlock:
+			cb := msg.Payload.(*payload.CompactBlock)
+			return s.handleCompactBlockCmd(peer, cb)
+		case CMDGetBlockTxs:
+			req := msg.Payload.(*payload.GetBlockTxs)
+			return s.handleGetBlockTxsCmd(peer, req)
+		case CMDBlockTxs:
+			bt := msg.Payload.(*payload.BlockTxs)
+			return s.handleBlockTxsCmd(peer, bt)
 		case CMDExtensible:
 			cp := msg.Payload.(*payload.Extensible)
 			return s.handleExtensibleCmd(cp)
@@ -1549,6 +2012,26 @@ func (s *Server) StopTxFlow() {
 	s.txCbList.Store(hashes)
 }
 
+// broadcastFactor returns the broadcast factor to use, it's taken from the
+// FlagBroadcastFactor feature flag if it's set properly and from the
+// configuration otherwise.
+func (s *Server) broadcastFactor() int {
+	if v, ok := s.chain.GetFeatureFlag(FlagBroadcastFactor); ok && v >= 0 && v <= 100 {
+		return int(v)
+	}
+	return s.BroadcastFactor
+}
+
+// compactRelayEnabled returns whether new blocks should be relayed as compact
+// blocks to the peers supporting them.
+func (s *Server) compactRelayEnabled() bool {
+	if !s.CompactBlocks {
+		return false
+	}
+	v, ok := s.chain.GetFeatureFlag(FlagDisableCompactBlocks)
+	return !ok || v == 0
+}
+
 // iteratePeersWithSendMsg sends the given message to all peers using two functions
 // passed, one is to send the message and the other is to filtrate peers (the
 // peer is considered invalid if it returns false).
@@ -1572,7 +2055,8 @@ func (s *Server) iteratePeersWithSendMsg(msg *Message, send func(Peer, context.C
 		replies     = make(chan error, peerN) // Cache is there just to make goroutines exit faster.
 		ctx, cancel = context.WithTimeout(context.Background(), s.TimePerBlock/2)
 	)
-	enoughN = (enoughN*(100-s.BroadcastFactor) + peerN*s.BroadcastFactor) / 100
+	factor := s.broadcastFactor()
+	enoughN = (enoughN*(100-factor) + peerN*factor) / 100
 	for _, peer := range peers {
 		go func(p Peer, ctx context.Context, pkt []byte) {
 			// Do this before packet is sent, reader thread can get the reply before this routine wakes up.
@@ -1624,11 +2108,24 @@ mainloop:
 			s.chain.UnsubscribeFromBlocks(ch)
 			break mainloop
 		case b := <-ch:
-			msg := NewMessage(CMDInv, payload.NewInventory(payload.BlockType, []util.Uint256{b.Hash()}))
 			// Filter out nodes that are more current (avoid spamming the network
 			// during initial sync).
-			s.iteratePeersWithSendMsg(msg, Peer.BroadcastPacket, func(p Peer) bool {
+			isBehind := func(p Peer) bool {
 				return p.Handshaked() && p.LastBlockIndex() < b.Index
+			}
+			compact := s.compactRelayEnabled()
+			isCompact := func(p Peer) bool {
+				return compact && p.SupportsCompactBlocks()
+			}
+			if compact {
+				msg := NewMessage(CMDCompactBlock, payload.NewCompactBlockFromBlock(b, mrand.Uint64()))
+				s.iteratePeersWithSendMsg(msg, Peer.BroadcastPacket, func(p Peer) bool {
+					return isBehind(p) && isCompact(p)
+				})
+			}
+			msg := NewMessage(CMDInv, payload.NewInventory(payload.BlockType, []util.Uint256{b.Hash()}))
+			s.iteratePeersWithSendMsg(msg, Peer.BroadcastPacket, func(p Peer) bool {
+				return isBehind(p) && !isCompact(p)
 			})
 			s.extensiblePool.RemoveStale(b.Index)
 		}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -44,6 +45,13 @@ type (
 		// Seeds is a list of initial nodes used to establish connectivity.
 		Seeds []string
 
+		// DNSSeeds is a list of domain names with TXT records containing
+		// additional seed node addresses.
+		DNSSeeds []string
+
+		// NeoFSSeedsCfg configures fetching a signed seed list from NeoFS.
+		NeoFSSeedsCfg config.NeoFSSeeds
+
 		// Maximum duration a single dial may take.
 		DialTimeout time.Duration
 
@@ -77,6 +85,30 @@ type (
 		// BroadcastFactor is the factor (0-100) for fan-out optimization.
 		BroadcastFactor int
 
+		// CompactBlocks enables compact block relay with peers supporting it.
+		CompactBlocks bool
+
+		// ExtensibleFilter enables announcing ExtensibleCategories (along
+		// with the categories of extensible services) to peers, so that only
+		// these extensible payloads are relayed to the node.
+		ExtensibleFilter bool
+
+		// ExtensibleCategories is the list of extensible payload categories
+		// requested from peers.
+		ExtensibleCategories []string
+
+		// HeadersPrefetch is the number of headers requested ahead of the
+		// current block height, zero disables header-first synchronization.
+		HeadersPrefetch uint32
+
+		// SyncProfile enables initial block download profiling.
+		SyncProfile bool
+
+		// ShutdownTimeout is the time given to every service to stop, when
+		// it's exceeded the service is abandoned and stack traces of all
+		// goroutines are logged.
+		ShutdownTimeout time.Duration
+
 		NeoFSBlockFetcherCfg config.NeoFSBlockFetcher
 	}
 )
@@ -90,12 +122,18 @@ func NewServerConfig(cfg config.Config) (ServerConfig, error) {
 	if err != nil {
 		return ServerConfig{}, fmt.Errorf("failed to parse addresses: %w", err)
 	}
+	if len(appConfig.P2P.ExtensibleCategories) > capability.MaxExtensibleCategories {
+		return ServerConfig{}, fmt.Errorf("too many extensible categories: %d (max %d)",
+			len(appConfig.P2P.ExtensibleCategories), capability.MaxExtensibleCategories)
+	}
 	c := ServerConfig{
 		UserAgent:            cfg.GenerateUserAgent(),
 		Addresses:            addrs,
 		Net:                  protoConfig.Magic,
 		Relay:                appConfig.Relay,
 		Seeds:                protoConfig.SeedList,
+		DNSSeeds:             appConfig.P2P.DNSSeeds,
+		NeoFSSeedsCfg:        appConfig.P2P.NeoFSSeeds,
 		DialTimeout:          appConfig.P2P.DialTimeout,
 		ProtoTickInterval:    appConfig.P2P.ProtoTickInterval,
 		PingInterval:         appConfig.P2P.PingInterval,
@@ -109,6 +147,12 @@ func NewServerConfig(cfg config.Config) (ServerConfig, error) {
 		StateRootCfg:         appConfig.StateRoot,
 		ExtensiblePoolSize:   appConfig.P2P.ExtensiblePoolSize,
 		BroadcastFactor:      appConfig.P2P.BroadcastFactor,
+		CompactBlocks:        appConfig.P2P.CompactBlocks,
+		ExtensibleFilter:     appConfig.P2P.ExtensibleFilter,
+		ExtensibleCategories: appConfig.P2P.ExtensibleCategories,
+		HeadersPrefetch:      appConfig.P2P.HeadersPrefetch,
+		SyncProfile:          appConfig.P2P.SyncProfile,
+		ShutdownTimeout:      appConfig.ShutdownTimeout,
 		NeoFSBlockFetcherCfg: appConfig.NeoFSBlockFetcher,
 	}
 	return c, nil
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"slices"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -14,7 +15,6 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/fakechain"
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/config"
-	"github.com/nspcc-dev/neo-go/pkg/consensus"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
@@ -27,7 +27,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 type fakeConsensus struct {
@@ -38,11 +41,17 @@ type fakeConsensus struct {
 	txs      []*transaction.Transaction
 }
 
-var _ consensus.Service = (*fakeConsensus)(nil)
+var _ Service = (*fakeConsensus)(nil)
 
 func (f *fakeConsensus) Name() string { return "fake" }
 func (f *fakeConsensus) Start()       { f.started.Store(true) }
 func (f *fakeConsensus) Shutdown()    { f.stopped.Store(true) }
+func (f *fakeConsensus) Health() error {
+	if !f.started.Load() {
+		return errors.New("not started")
+	}
+	return nil
+}
 func (f *fakeConsensus) OnPayload(p *payload.Extensible) error {
 	f.payloads = append(f.payloads, p)
 	return nil
@@ -70,6 +79,7 @@ func TestNewServer(t *testing.T) {
 		require.Equal(t, defaultMinPeers, s.ServerConfig.MinPeers)
 		require.Equal(t, defaultMaxPeers, s.ServerConfig.MaxPeers)
 		require.Equal(t, defaultAttemptConnPeers, s.ServerConfig.AttemptConnPeers)
+		require.Equal(t, defaultShutdownTimeout, s.ServerConfig.ShutdownTimeout)
 	})
 	t.Run("don't defaults", func(t *testing.T) {
 		cfg := ServerConfig{
@@ -150,6 +160,74 @@ func TestServerStartAndShutdown(t *testing.T) {
 	})
 }
 
+type testService struct {
+	name string
+	stop func()
+}
+
+func (s *testService) Name() string { return s.name }
+func (s *testService) Start()       {}
+func (s *testService) Shutdown()    { s.stop() }
+
+func TestServerShutdownTimeout(t *testing.T) {
+	var (
+		s       = newTestServer(t, ServerConfig{ShutdownTimeout: 100 * time.Millisecond})
+		lock    sync.Mutex
+		stopped []string
+		release = make(chan struct{})
+	)
+	core, logs := observer.New(zapcore.ErrorLevel)
+	s.log = zap.New(core)
+	newService := func(name string) *testService {
+		return &testService{name: name, stop: func() {
+			lock.Lock()
+			stopped = append(stopped, name)
+			lock.Unlock()
+		}}
+	}
+	s.AddService(newService("first"))
+	s.AddService(&testService{name: "stuck", stop: func() { <-release }})
+	s.AddService(newService("dropped"))
+	s.AddService(newService("last"))
+	s.DelService(newService("dropped"))
+	defer close(release)
+
+	s.Start()
+	s.Shutdown()
+
+	lock.Lock()
+	require.Equal(t, []string{"last", "first"}, stopped)
+	lock.Unlock()
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	require.Equal(t, "failed to stop in time, giving up", entry.Message)
+	require.Equal(t, "stuck", entry.ContextMap()["service"])
+	require.Contains(t, entry.ContextMap()["goroutines"], "TestServerShutdownTimeout")
+}
+
+func TestServerHealth(t *testing.T) {
+	t.Run("not in sync", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{MinPeers: 1})
+		cons := new(fakeConsensus)
+		s.AddConsensusService(cons, cons.OnPayload, cons.OnTransaction)
+
+		require.Equal(t, map[string]error{"network": errors.New("no connected peers")}, s.Health())
+		require.ErrorContains(t, s.Ready(), "not in sync")
+	})
+	t.Run("in sync", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{})
+		cons := new(fakeConsensus)
+		s.AddConsensusService(cons, cons.OnPayload, cons.OnTransaction)
+		startWithCleanup(t, s)
+
+		require.Equal(t, map[string]error{"network": nil, "fake": nil}, s.Health())
+		require.NoError(t, s.Ready())
+
+		cons.started.Store(false)
+		require.Equal(t, map[string]error{"network": nil, "fake": errors.New("not started")}, s.Health())
+	})
+}
+
 func TestServerRegisterPeer(t *testing.T) {
 	const peerCount = 3
 
@@ -425,6 +503,22 @@ func TestBlock(t *testing.T) {
 	require.Eventually(t, func() bool { return s.chain.BlockHeight() == 12345 }, 2*time.Second, time.Millisecond*500)
 }
 
+func TestRelayBlock(t *testing.T) {
+	s := startTestServer(t)
+	s.chain.(*fakechain.FakeChain).Blockheight.Store(12344)
+
+	b := block.New(false)
+	b.Index = 12345
+
+	s.stateSync.(*fakechain.FakeStateSync).IsActiveFlag.Store(true)
+	require.ErrorIs(t, s.RelayBlock(b), ErrSyncInProgress)
+	require.Equal(t, uint32(12344), s.chain.BlockHeight())
+
+	s.stateSync.(*fakechain.FakeStateSync).IsActiveFlag.Store(false)
+	require.NoError(t, s.RelayBlock(b))
+	require.Equal(t, uint32(12345), s.chain.BlockHeight())
+}
+
 func TestConsensus(t *testing.T) {
 	s := newTestServer(t, ServerConfig{})
 	cons := new(fakeConsensus)
@@ -470,6 +564,54 @@ func TestConsensus(t *testing.T) {
 	})
 }
 
+func TestExtensibleFilter(t *testing.T) {
+	t.Run("version", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{ExtensibleFilter: true, ExtensibleCategories: []string{"StateService"}})
+		cons := new(fakeConsensus)
+		s.AddConsensusService(cons, cons.OnPayload, cons.OnTransaction)
+		s.transports[0].Accept()
+		msg, err := s.getVersionMsg(nil)
+		require.NoError(t, err)
+		require.Contains(t, msg.Payload.(*payload.Version).Capabilities, capability.Capability{
+			Type: capability.ExtensibleFilter,
+			Data: &capability.Extensible{Categories: []string{"StateService", payload.ConsensusCategory}},
+		})
+	})
+
+	s := newTestServer(t, ServerConfig{})
+	startWithCleanup(t, s)
+	s.chain.(*fakechain.FakeChain).VerifyWitnessF = func() (int64, error) { return 0, nil }
+	s.chain.(*fakechain.FakeChain).Blockheight.Store(4)
+
+	newPeer := func(filter []string) (*localPeer, *atomic.Int32) {
+		var cnt = new(atomic.Int32)
+		p := newLocalPeer(t, s)
+		p.handshaked = 1
+		p.extensibleFilter = filter
+		p.messageHandler = func(t *testing.T, msg *Message) {
+			if msg.Command == CMDInv {
+				cnt.Add(1)
+			}
+		}
+		s.register <- p
+		return p, cnt
+	}
+	_, all := newPeer(nil)
+	_, cons := newPeer([]string{payload.ConsensusCategory})
+	_, none := newPeer([]string{})
+	require.Eventually(t, func() bool { return 3 == s.PeerCount() }, time.Second, time.Millisecond*10)
+
+	for i, category := range []string{payload.ConsensusCategory, "StateService"} {
+		pl := payload.NewExtensible()
+		pl.Category = category
+		pl.ValidBlockEnd = s.chain.BlockHeight() + 1
+		pl.Data = []byte{byte(i)}
+		s.BroadcastExtensible(pl)
+	}
+	require.Eventually(t, func() bool { return all.Load() == 2 && cons.Load() == 1 }, time.Second, time.Millisecond*10)
+	require.Equal(t, int32(0), none.Load())
+}
+
 func TestTransaction(t *testing.T) {
 	s := newTestServer(t, ServerConfig{})
 	cons := new(fakeConsensus)
@@ -721,6 +863,81 @@ func TestGetHeaders(t *testing.T) {
 	})
 }
 
+func TestHeadersFirstSync(t *testing.T) {
+	var (
+		s     = newTestServer(t, ServerConfig{UserAgent: "/test/", HeadersPrefetch: 1000})
+		chain = s.chain.(*fakechain.FakeChain)
+		p     = newLocalPeer(t, s)
+		msgs  []*Message
+	)
+	chain.Blockheight.Store(100)
+	p.handshaked = 1
+	p.lastBlockIndex = 5000
+	p.messageHandler = func(t *testing.T, msg *Message) {
+		msgs = append(msgs, msg)
+	}
+	newHeaders := func(start, end uint32) []*block.Header {
+		var hdrs []*block.Header
+		for i := start; i <= end; i++ {
+			b := block.New(false)
+			b.Index = i
+			hdrs = append(hdrs, &b.Header)
+		}
+		return hdrs
+	}
+
+	// Only headers are requested initially.
+	require.NoError(t, s.requestBlocksOrHeaders(p))
+	require.Len(t, msgs, 1)
+	require.Equal(t, CMDGetHeaders, msgs[0].Command)
+	require.Equal(t, &payload.GetBlockByIndex{IndexStart: 101, Count: 1000}, msgs[0].Payload)
+
+	// Headers with a gap are ignored.
+	msgs = nil
+	s.testHandleMessage(t, p, CMDHeaders, &payload.Headers{Hdrs: newHeaders(102, 110)})
+	require.Equal(t, uint32(100), chain.HeaderHeight())
+	require.Empty(t, msgs)
+
+	// Then blocks are requested by header hashes along with more headers.
+	hdrs := newHeaders(101, 600)
+	s.testHandleMessage(t, p, CMDHeaders, &payload.Headers{Hdrs: hdrs})
+	require.Equal(t, uint32(600), chain.HeaderHeight())
+	require.Len(t, msgs, 2)
+	require.Equal(t, CMDGetHeaders, msgs[0].Command)
+	require.Equal(t, &payload.GetBlockByIndex{IndexStart: 601, Count: 500}, msgs[0].Payload)
+	require.Equal(t, CMDGetData, msgs[1].Command)
+	inv := msgs[1].Payload.(*payload.Inventory)
+	require.Equal(t, payload.BlockType, inv.Type)
+	require.Len(t, inv.Hashes, payload.MaxHashesCount)
+	for i, h := range inv.Hashes {
+		require.Equal(t, hdrs[i].Hash(), h)
+	}
+
+	// The next peer gets the next chunk (capped by the header height).
+	msgs = nil
+	p2 := newLocalPeer(t, s)
+	p2.handshaked = 1
+	p2.lastBlockIndex = 5000
+	p2.messageHandler = p.messageHandler
+	require.NoError(t, chain.AddHeaders(newHeaders(601, 700)...))
+	require.NoError(t, s.requestBlocksOrHeaders(p2))
+	require.Len(t, msgs, 2)
+	inv = msgs[1].Payload.(*payload.Inventory)
+	require.Len(t, inv.Hashes, 100)
+	require.Equal(t, chain.GetHeaderHash(601), inv.Hashes[0])
+
+	t.Run("block mismatch", func(t *testing.T) {
+		b := block.New(false)
+		b.Index = 101
+		b.Nonce = 1
+		err := s.handleMessage(p, NewMessage(CMDBlock, b))
+		require.ErrorIs(t, err, errHeaderMismatch)
+
+		b.Header = *hdrs[0]
+		require.NoError(t, s.handleMessage(p, NewMessage(CMDBlock, b)))
+	})
+}
+
 func TestInv(t *testing.T) {
 	s := startTestServer(t)
 	s.chain.(*fakechain.FakeChain).UtilityTokenBalance = big.NewInt(10000000)
@@ -920,6 +1137,59 @@ func TestRequestMPTNodes(t *testing.T) {
 	})
 }
 
+func TestRequestMPTNodesBatches(t *testing.T) {
+	s := newTestServer(t, ServerConfig{UserAgent: "/test/"})
+	s.config.P2PStateExchangeExtensions = true
+	var (
+		batches [][]util.Uint256
+		actual  []util.Uint256
+	)
+	for range 3 {
+		batches = append(batches, []util.Uint256{random.Uint256(), random.Uint256()})
+	}
+	ss := &fakechain.FakeStateSync{
+		AddMPTNodesFunc: func(nodes [][]byte) error { return nil },
+		MPTNodesBatchFunc: func(limit int) []util.Uint256 {
+			require.Equal(t, payload.MaxMPTHashesCount, limit)
+			if len(batches) == 0 {
+				return nil
+			}
+			b := batches[0]
+			batches = batches[1:]
+			return b
+		},
+	}
+	s.stateSync = ss
+	startWithCleanup(t, s)
+
+	p := newLocalPeer(t, s)
+	p.handshaked = 1
+	p.messageHandler = func(t *testing.T, msg *Message) {
+		if msg.Command == CMDGetMPTData {
+			actual = append(actual, msg.Payload.(*payload.MPTInventory).Hashes...)
+		}
+	}
+
+	expected := slices.Concat(batches[0], batches[1])
+	require.NoError(t, s.requestMPTNodesBatches(p, 2))
+	require.Equal(t, expected, actual)
+
+	// Every response triggers a new request while MPT nodes are needed.
+	msg := NewMessage(CMDMPTData, &payload.MPTData{Nodes: [][]byte{{1, 2, 3}}})
+	ss.RequestMPTNodes.Store(true)
+	expected = append(expected, batches[0]...)
+	require.NoError(t, s.handleMessage(p, msg))
+	require.Equal(t, expected, actual)
+
+	// No more nodes to request.
+	require.NoError(t, s.handleMessage(p, msg))
+	require.Equal(t, expected, actual)
+
+	ss.RequestMPTNodes.Store(false)
+	require.NoError(t, s.handleMessage(p, msg))
+	require.Equal(t, expected, actual)
+}
+
 func TestRequestTx(t *testing.T) {
 	s := startTestServer(t)
 
@@ -1169,3 +1439,212 @@ func TestServer_Port(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint16(123), actual)
 }
+
+func TestCompactBlock(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		s := startTestServer(t)
+		p := newLocalPeer(t, s)
+		p.handshaked = 1
+		b := newDummyBlock(1, 1)
+		for cmd, pl := range map[CommandType]payload.Payload{
+			CMDCompactBlock: payload.NewCompactBlockFromBlock(b, 1),
+			CMDGetBlockTxs:  payload.NewGetBlockTxs(b.Hash(), []uint16{0}),
+			CMDBlockTxs:     payload.NewBlockTxs(b.Hash(), b.Transactions),
+		} {
+			require.ErrorIs(t, s.handleMessage(p, NewMessage(cmd, pl)), errCompactDisabled)
+		}
+	})
+
+	s := newTestServer(t, ServerConfig{UserAgent: "/test/", CompactBlocks: true})
+	startWithCleanup(t, s)
+	bc := s.chain.(*fakechain.FakeChain)
+	bc.Blockheight.Store(10)
+
+	newBlock := func(txs ...*transaction.Transaction) *block.Block {
+		b := block.New(false)
+		b.Index = bc.BlockHeight() + 1
+		b.PrevHash = random.Uint256()
+		b.Transactions = txs
+		b.MerkleRoot = b.ComputeMerkleRoot()
+		b.Hash()
+		return b
+	}
+	newPooledTx := func(t *testing.T) *transaction.Transaction {
+		tx := newDummyTx()
+		require.NoError(t, bc.Pool.Add(tx, &feerStub{blockHeight: 10}))
+		return tx
+	}
+	newPeer := func(t *testing.T, msgs *[]*Message) *localPeer {
+		p := newLocalPeer(t, s)
+		p.handshaked = 1
+		p.compactBlocks = true
+		p.messageHandler = func(t *testing.T, msg *Message) {
+			*msgs = append(*msgs, msg)
+		}
+		return p
+	}
+
+	t.Run("version", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{UserAgent: "/test/", CompactBlocks: true})
+		s.transports[0].Accept()
+		msg, err := s.getVersionMsg(nil)
+		require.NoError(t, err)
+		require.Contains(t, msg.Payload.(*payload.Version).Capabilities, capability.Capability{
+			Type: capability.CompactBlocks,
+			Data: &capability.Compact{Version: payload.CompactBlocksVersion},
+		})
+	})
+	t.Run("all transactions in mempool", func(t *testing.T) {
+		var msgs []*Message
+		p := newPeer(t, &msgs)
+		b := newBlock(newPooledTx(t), newPooledTx(t))
+		s.testHandleMessage(t, p, CMDCompactBlock, payload.NewCompactBlockFromBlock(b, 42))
+		require.Empty(t, msgs)
+		require.Eventually(t, func() bool { return bc.BlockHeight() == b.Index }, 2*time.Second, 10*time.Millisecond)
+		actual, err := bc.GetBlock(b.Hash())
+		require.NoError(t, err)
+		require.Equal(t, b.Transactions, actual.Transactions)
+	})
+	t.Run("empty block", func(t *testing.T) {
+		var msgs []*Message
+		p := newPeer(t, &msgs)
+		b := newBlock()
+		s.testHandleMessage(t, p, CMDCompactBlock, payload.NewCompactBlockFromBlock(b, 42))
+		require.Empty(t, msgs)
+		require.Eventually(t, func() bool { return bc.BlockHeight() == b.Index }, 2*time.Second, 10*time.Millisecond)
+	})
+	t.Run("old block", func(t *testing.T) {
+		var msgs []*Message
+		p := newPeer(t, &msgs)
+		b := newBlock(newDummyTx())
+		b.Index = bc.BlockHeight()
+		s.testHandleMessage(t, p, CMDCompactBlock, payload.NewCompactBlockFromBlock(b, 42))
+		require.Empty(t, msgs)
+	})
+	t.Run("missing transactions", func(t *testing.T) {
+		var msgs, otherMsgs []*Message
+		p := newPeer(t, &msgs)
+		other := newPeer(t, &otherMsgs)
+		b := newBlock(newDummyTx(), newPooledTx(t), newDummyTx())
+		cb := payload.NewCompactBlockFromBlock(b, 42)
+		s.testHandleMessage(t, p, CMDCompactBlock, cb)
+		require.Len(t, msgs, 1)
+		require.Equal(t, CMDGetBlockTxs, msgs[0].Command)
+		require.Equal(t, payload.NewGetBlockTxs(b.Hash(), []uint16{0, 2}), msgs[0].Payload)
+
+		// Requested already, no new request is sent.
+		s.testHandleMessage(t, other, CMDCompactBlock, cb)
+		require.Empty(t, otherMsgs)
+
+		// Transactions from unexpected peer are ignored.
+		txs := []*transaction.Transaction{b.Transactions[0], b.Transactions[2]}
+		s.testHandleMessage(t, other, CMDBlockTxs, payload.NewBlockTxs(b.Hash(), txs))
+		require.False(t, bc.HasBlock(b.Hash()))
+
+		s.testHandleMessage(t, p, CMDBlockTxs, payload.NewBlockTxs(b.Hash(), txs))
+		require.Eventually(t, func() bool { return bc.BlockHeight() == b.Index }, 2*time.Second, 10*time.Millisecond)
+		actual, err := bc.GetBlock(b.Hash())
+		require.NoError(t, err)
+		require.Equal(t, b.Transactions, actual.Transactions)
+
+		// Not pending anymore.
+		s.testHandleMessage(t, p, CMDBlockTxs, payload.NewBlockTxs(b.Hash(), txs))
+	})
+	t.Run("bad number of transactions", func(t *testing.T) {
+		var msgs []*Message
+		p := newPeer(t, &msgs)
+		b := newBlock(newDummyTx(), newDummyTx())
+		s.testHandleMessage(t, p, CMDCompactBlock, payload.NewCompactBlockFromBlock(b, 42))
+		require.Len(t, msgs, 1)
+		require.Error(t, s.handleMessage(p, NewMessage(CMDBlockTxs, payload.NewBlockTxs(b.Hash(), b.Transactions[:1]))))
+	})
+	t.Run("merkle root mismatch", func(t *testing.T) {
+		var msgs []*Message
+		p := newPeer(t, &msgs)
+		b := newBlock(newDummyTx())
+		cb := payload.NewCompactBlockFromBlock(b, 42)
+		s.testHandleMessage(t, p, CMDCompactBlock, cb)
+		require.Len(t, msgs, 1)
+		s.testHandleMessage(t, p, CMDBlockTxs, payload.NewBlockTxs(b.Hash(), []*transaction.Transaction{newDummyTx()}))
+		require.Len(t, msgs, 2)
+		require.Equal(t, CMDGetData, msgs[1].Command)
+		require.Equal(t, payload.NewInventory(payload.BlockType, []util.Uint256{b.Hash()}), msgs[1].Payload)
+	})
+	t.Run("short ID collision", func(t *testing.T) {
+		var msgs []*Message
+		p := newPeer(t, &msgs)
+		b := newBlock(newDummyTx(), newDummyTx())
+		cb := payload.NewCompactBlockFromBlock(b, 42)
+		cb.ShortIDs[1] = cb.ShortIDs[0]
+		s.testHandleMessage(t, p, CMDCompactBlock, cb)
+		require.Len(t, msgs, 1)
+		require.Equal(t, CMDGetData, msgs[0].Command)
+	})
+	t.Run("get block transactions", func(t *testing.T) {
+		var msgs []*Message
+		p := newPeer(t, &msgs)
+		b := newBlock(newDummyTx(), newDummyTx(), newDummyTx())
+		bc.PutBlock(b)
+
+		s.testHandleMessage(t, p, CMDGetBlockTxs, payload.NewGetBlockTxs(b.Hash(), []uint16{2, 0}))
+		require.Len(t, msgs, 1)
+		require.Equal(t, CMDBlockTxs, msgs[0].Command)
+		require.Equal(t, payload.NewBlockTxs(b.Hash(), []*transaction.Transaction{b.Transactions[2], b.Transactions[0]}), msgs[0].Payload)
+
+		unknown := random.Uint256()
+		s.testHandleMessage(t, p, CMDGetBlockTxs, payload.NewGetBlockTxs(unknown, []uint16{0}))
+		require.Len(t, msgs, 2)
+		require.Equal(t, CMDNotFound, msgs[1].Command)
+		require.Equal(t, payload.NewInventory(payload.BlockType, []util.Uint256{unknown}), msgs[1].Payload)
+
+		require.Error(t, s.handleMessage(p, NewMessage(CMDGetBlockTxs, payload.NewGetBlockTxs(b.Hash(), []uint16{3}))))
+	})
+}
+
+func TestBlockFetcherHandover(t *testing.T) {
+	var (
+		s     = newTestServer(t, ServerConfig{})
+		p     = newLocalPeer(t, s)
+		pings atomic.Int32
+	)
+	p.handshaked = 1
+	p.lastBlockIndex = 100
+	p.messageHandler = func(t *testing.T, msg *Message) {
+		if msg.Command == CMDPing {
+			pings.Add(1)
+		}
+	}
+	s.peers[p] = true
+
+	require.False(t, s.blockFetcherHandover.Load())
+	s.handleBlockFetcherHandover(50)
+	require.True(t, s.blockFetcherHandover.Load())
+	require.False(t, s.blockFetcherSyncing())
+	require.Equal(t, int32(1), pings.Load())
+}
+
+func TestServerFeatureFlags(t *testing.T) {
+	s := newTestServer(t, ServerConfig{UserAgent: "/test/", BroadcastFactor: 10, CompactBlocks: true})
+	bc := s.chain.(*fakechain.FakeChain)
+
+	t.Run("broadcast factor", func(t *testing.T) {
+		require.Equal(t, 10, s.broadcastFactor())
+		bc.SetFeatureFlag(FlagBroadcastFactor, 50)
+		require.Equal(t, 50, s.broadcastFactor())
+		bc.SetFeatureFlag(FlagBroadcastFactor, 0)
+		require.Equal(t, 0, s.broadcastFactor())
+		bc.SetFeatureFlag(FlagBroadcastFactor, 101) // Invalid value is ignored.
+		require.Equal(t, 10, s.broadcastFactor())
+	})
+	t.Run("compact blocks", func(t *testing.T) {
+		require.True(t, s.compactRelayEnabled())
+		bc.SetFeatureFlag(FlagDisableCompactBlocks, 1)
+		require.False(t, s.compactRelayEnabled())
+		bc.SetFeatureFlag(FlagDisableCompactBlocks, 0)
+		require.True(t, s.compactRelayEnabled())
+
+		// The flag can't enable compact relay.
+		s := newTestServer(t, ServerConfig{UserAgent: "/test/"})
+		require.False(t, s.compactRelayEnabled())
+	})
+}
@@ -0,0 +1,48 @@
+package network
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultShutdownTimeout is the time given to every service (and the Server
+// itself) to stop if ShutdownTimeout is not set.
+const defaultShutdownTimeout = 30 * time.Second
+
+// stopWithDeadline runs stop and waits for it to return for at most
+// ShutdownTimeout. If it takes longer, an error is logged along with stack
+// traces of all goroutines, so that it's possible to find out what the
+// component is stuck on. stop keeps running in background then and false is
+// returned, allowing to proceed with the shutdown of other components.
+func (s *Server) stopWithDeadline(name string, stop func()) bool {
+	var (
+		done = make(chan struct{})
+		t    = time.NewTimer(s.ShutdownTimeout)
+	)
+	defer t.Stop()
+	go func() {
+		stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-t.C:
+		s.log.Error("failed to stop in time, giving up",
+			zap.String("service", name),
+			zap.Duration("timeout", s.ShutdownTimeout),
+			zap.String("goroutines", goroutineDump()))
+		return false
+	}
+}
+
+// goroutineDump returns stack traces of all goroutines in the same format
+// that is used by unrecovered panics.
+func goroutineDump() string {
+	var buf bytes.Buffer
+	_ = pprof.Lookup("goroutine").WriteTo(&buf, 2)
+	return buf.String()
+}
@@ -0,0 +1,163 @@
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/blockprof"
+	"go.uber.org/zap"
+)
+
+// syncProfileWindow is the number of blocks in a single sync profiler report
+// window.
+const syncProfileWindow = 10000
+
+// blockProfilerSetter is implemented by Ledger implementations supporting block
+// processing instrumentation.
+type blockProfilerSetter interface {
+	SetBlockProfiler(blockprof.Profiler)
+}
+
+// syncTimings is a set of block synchronization stage timings.
+type syncTimings struct {
+	blocks       int
+	verification time.Duration
+	execution    time.Duration
+	mpt          time.Duration
+	persist      time.Duration
+}
+
+// syncProfiler collects initial block download timings and logs them for every
+// window of syncProfileWindow blocks and for the whole sync once it's done. It
+// implements blockprof.Profiler.
+type syncProfiler struct {
+	log    *zap.Logger
+	window uint32
+
+	lock        sync.Mutex
+	done        bool
+	start       time.Time
+	windowStart time.Time
+	firstIndex  uint32
+	windowFirst uint32
+	lastIndex   uint32
+	current     syncTimings
+	total       syncTimings
+}
+
+func newSyncProfiler(log *zap.Logger, window uint32) *syncProfiler {
+	return &syncProfiler{
+		log:    log,
+		window: window,
+	}
+}
+
+// BlockAdded implements blockprof.Profiler.
+func (p *syncProfiler) BlockAdded(index uint32, verification, execution, mpt time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.done {
+		return
+	}
+	if p.start.IsZero() {
+		// Time before the first block can't be attributed to anything.
+		now := time.Now().Add(-(verification + execution + mpt))
+		p.start, p.windowStart = now, now
+		p.firstIndex, p.windowFirst = index, index
+	}
+	p.lastIndex = index
+	p.current.blocks++
+	p.current.verification += verification
+	p.current.execution += execution
+	p.current.mpt += mpt
+	if index%p.window == 0 {
+		p.flushWindow()
+	}
+}
+
+// Persisted implements blockprof.Profiler.
+func (p *syncProfiler) Persisted(d time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.done {
+		return
+	}
+	p.current.persist += d
+}
+
+// flushWindow logs the current window report and starts a new window. It must
+// be called with the lock held.
+func (p *syncProfiler) flushWindow() {
+	now := time.Now()
+	p.log.Info("sync profile window",
+		append([]zap.Field{
+			zap.Uint32("from", p.windowFirst),
+			zap.Uint32("to", p.lastIndex),
+		}, p.current.fields(now.Sub(p.windowStart))...)...)
+	p.total.add(p.current)
+	p.current = syncTimings{}
+	p.windowStart = now
+	p.windowFirst = p.lastIndex + 1
+}
+
+// report logs the final sync report, it's a no-op if nothing was recorded or
+// the report was already made. Profiler ignores all subsequent events.
+func (p *syncProfiler) report() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.done {
+		return
+	}
+	p.done = true
+	if p.start.IsZero() {
+		return
+	}
+	if p.current.blocks != 0 {
+		p.flushWindow()
+	}
+	p.log.Info("sync profile report",
+		append([]zap.Field{
+			zap.Uint32("from", p.firstIndex),
+			zap.Uint32("to", p.lastIndex),
+		}, p.total.fields(time.Since(p.start))...)...)
+}
+
+func (t *syncTimings) add(o syncTimings) {
+	t.blocks += o.blocks
+	t.verification += o.verification
+	t.execution += o.execution
+	t.mpt += o.mpt
+	t.persist += o.persist
+}
+
+// fields returns log fields describing timings for the given wall time period.
+// Network wait is the time when no block was being processed. Persist happens
+// concurrently with block processing, so its share is given for reference.
+func (t syncTimings) fields(wall time.Duration) []zap.Field {
+	wait := max(wall-t.verification-t.execution-t.mpt, 0)
+	share := func(d time.Duration) float64 {
+		if wall <= 0 {
+			return 0
+		}
+		return float64(d*1000/wall) / 10
+	}
+	var bps float64
+	if wall > 0 {
+		bps = float64(t.blocks) / wall.Seconds()
+	}
+	return []zap.Field{
+		zap.Int("blocks", t.blocks),
+		zap.Duration("took", wall),
+		zap.Float64("blocksPerSec", bps),
+		zap.Duration("networkWait", wait),
+		zap.Float64("networkWait%", share(wait)),
+		zap.Duration("verification", t.verification),
+		zap.Float64("verification%", share(t.verification)),
+		zap.Duration("execution", t.execution),
+		zap.Float64("execution%", share(t.execution)),
+		zap.Duration("mpt", t.mpt),
+		zap.Float64("mpt%", share(t.mpt)),
+		zap.Duration("persist", t.persist),
+		zap.Float64("persist%", share(t.persist)),
+	}
+}
@@ -0,0 +1,56 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSyncProfiler(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	p := newSyncProfiler(zap.New(core), 4)
+
+	// Nothing recorded, nothing reported.
+	newSyncProfiler(zap.New(core), 4).report()
+	require.Equal(t, 0, logs.Len())
+
+	for i := uint32(1); i <= 10; i++ {
+		p.BlockAdded(i, time.Millisecond, 2*time.Millisecond, 3*time.Millisecond)
+	}
+	p.Persisted(time.Second)
+	windows := logs.FilterMessage("sync profile window").AllUntimed()
+	require.Equal(t, 2, len(windows))
+	fields := windows[0].ContextMap()
+	require.EqualValues(t, 1, fields["from"])
+	require.EqualValues(t, 4, fields["to"])
+	require.EqualValues(t, 4, fields["blocks"])
+	require.EqualValues(t, 8*time.Millisecond, fields["execution"])
+	fields = windows[1].ContextMap()
+	require.EqualValues(t, 5, fields["from"])
+	require.EqualValues(t, 8, fields["to"])
+
+	p.report()
+	windows = logs.FilterMessage("sync profile window").AllUntimed()
+	require.Equal(t, 3, len(windows))
+	fields = windows[2].ContextMap()
+	require.EqualValues(t, 9, fields["from"])
+	require.EqualValues(t, 10, fields["to"])
+	require.EqualValues(t, time.Second, fields["persist"])
+
+	reports := logs.FilterMessage("sync profile report").AllUntimed()
+	require.Equal(t, 1, len(reports))
+	fields = reports[0].ContextMap()
+	require.EqualValues(t, 1, fields["from"])
+	require.EqualValues(t, 10, fields["to"])
+	require.EqualValues(t, 10, fields["blocks"])
+	require.EqualValues(t, 30*time.Millisecond, fields["mpt"])
+
+	// Everything is ignored after the report.
+	p.BlockAdded(12, time.Millisecond, time.Millisecond, time.Millisecond)
+	p.report()
+	require.Equal(t, 1, logs.FilterMessage("sync profile report").Len())
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -50,10 +51,14 @@ type TCPPeer struct {
 	// pre-handshake non-canonical connection address.
 	addr string
 
-	lock       sync.RWMutex
-	finale     sync.Once
-	handShake  handShakeStage
-	isFullNode bool
+	lock          sync.RWMutex
+	finale        sync.Once
+	handShake     handShakeStage
+	isFullNode    bool
+	compactBlocks bool
+	// extensibleFilter is the list of extensible categories requested by
+	// the peer, nil if it doesn't filter them.
+	extensibleFilter []string
 
 	done     chan struct{}
 	sendQ    chan []byte
@@ -67,6 +72,9 @@ type TCPPeer struct {
 	// number of sent pings.
 	pingSent  int
 	pingTimer *time.Timer
+
+	// per-command statistics of sent and received messages.
+	messages messageCounters
 }
 
 // NewTCPPeer returns a TCPPeer structure based on the given connection.
@@ -147,7 +155,9 @@ func (p *TCPPeer) writeMsg(msg *Message) error {
 	}
 
 	_, err = p.conn.Write(b)
-
+	if err == nil {
+		p.messages.addSent(msg.Command, len(b))
+	}
 	return err
 }
 
@@ -175,6 +185,7 @@ func (p *TCPPeer) handleConn() {
 			} else if err != nil {
 				break
 			}
+			p.messages.addReceived(msg.Command, msg.size())
 			select {
 			case p.incoming <- msg:
 			case <-p.done:
@@ -252,6 +263,7 @@ func (p *TCPPeer) handleQueues() {
 		if err != nil {
 			break
 		}
+		p.messages.addSentPacket(msg)
 		p2pSkipCounter++
 	}
 	p.Disconnect(err)
@@ -320,6 +332,22 @@ func (p *TCPPeer) IsFullNode() bool {
 	return p.handshaked() && p.isFullNode
 }
 
+// SupportsCompactBlocks returns whether the peer supports compact block relay.
+func (p *TCPPeer) SupportsCompactBlocks() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.handshaked() && p.compactBlocks
+}
+
+// WantsExtensible returns whether the peer is interested in extensible payloads
+// of the given category, that is it either has not announced extensible filter
+// capability or the category is in its filter.
+func (p *TCPPeer) WantsExtensible(category string) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.handshaked() && (p.extensibleFilter == nil || slices.Contains(p.extensibleFilter, category))
+}
+
 // SendVersion checks for the handshake state and sends a message to the peer.
 func (p *TCPPeer) SendVersion() error {
 	msg, err := p.server.getVersionMsg(p.conn.LocalAddr())
@@ -347,10 +375,14 @@ func (p *TCPPeer) HandleVersion(version *payload.Version) error {
 	}
 	p.version = version
 	for _, cap := range version.Capabilities {
-		if cap.Type == capability.FullNode {
+		switch cap.Type {
+		case capability.FullNode:
 			p.isFullNode = true
 			p.lastBlockIndex = cap.Data.(*capability.Node).StartHeight
-			break
+		case capability.CompactBlocks:
+			p.compactBlocks = cap.Data.(*capability.Compact).Version == payload.CompactBlocksVersion
+		case capability.ExtensibleFilter:
+			p.extensibleFilter = append([]string{}, cap.Data.(*capability.Extensible).Categories...)
 		}
 	}
 
@@ -446,6 +478,11 @@ func (p *TCPPeer) Disconnect(err error) {
 	})
 }
 
+// MessageStats implements the Peer interface.
+func (p *TCPPeer) MessageStats() map[CommandType]MessageStats {
+	return p.messages.snapshot()
+}
+
 // Version implements the Peer interface.
 func (p *TCPPeer) Version() *payload.Version {
 	return p.version
@@ -4,6 +4,8 @@ import (
 	"net"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/stretchr/testify/require"
 )
@@ -50,7 +52,10 @@ func TestPeerHandshake(t *testing.T) {
 	// server.
 	require.NoError(t, tcpC.SendVersion())
 	require.Error(t, tcpC.HandleVersionAck()) // Didn't receive version yet.
-	require.NoError(t, tcpS.HandleVersion(&payload.Version{}))
+	require.NoError(t, tcpS.HandleVersion(&payload.Version{Capabilities: capability.Capabilities{{
+		Type: capability.ExtensibleFilter,
+		Data: &capability.Extensible{Categories: []string{payload.ConsensusCategory}},
+	}}}))
 	require.Error(t, tcpS.SendVersionAck(&Message{})) // Didn't send version yet.
 	require.NoError(t, tcpC.HandleVersion(&payload.Version{}))
 	require.NoError(t, tcpS.SendVersion())
@@ -58,6 +63,8 @@ func TestPeerHandshake(t *testing.T) {
 	// No handshake yet.
 	require.Equal(t, false, tcpS.Handshaked())
 	require.Equal(t, false, tcpC.Handshaked())
+	require.False(t, tcpS.WantsExtensible(payload.ConsensusCategory))
+	require.False(t, tcpC.WantsExtensible(payload.ConsensusCategory))
 
 	// These are sent/received and should fail now.
 	require.Error(t, tcpC.SendVersion())
@@ -76,6 +83,11 @@ func TestPeerHandshake(t *testing.T) {
 	require.Equal(t, true, tcpS.Handshaked())
 	require.Equal(t, true, tcpC.Handshaked())
 
+	// Only the peer announcing extensible filter is picky.
+	require.True(t, tcpS.WantsExtensible(payload.ConsensusCategory))
+	require.False(t, tcpS.WantsExtensible("StateService"))
+	require.True(t, tcpC.WantsExtensible("StateService"))
+
 	// Subsequent ACKing should fail.
 	require.Error(t, tcpC.SendVersionAck(&Message{}))
 	require.Error(t, tcpS.HandleVersionAck())
@@ -86,3 +98,42 @@ func TestPeerHandshake(t *testing.T) {
 	require.NoError(t, tcpS.EnqueueP2PMessage(&Message{}))
 	require.NoError(t, tcpC.EnqueueP2PMessage(&Message{}))
 }
+
+func TestMessageCounters(t *testing.T) {
+	var c messageCounters
+	require.Empty(t, c.snapshot())
+
+	ping := NewMessage(CMDPing, payload.NewPing(1, 2))
+	pingBytes, err := ping.Bytes()
+	require.NoError(t, err)
+	verack := NewMessage(CMDVerack, payload.NewNullPayload())
+	verackBytes, err := verack.Bytes()
+	require.NoError(t, err)
+
+	c.addSentPacket(append(append([]byte{}, pingBytes...), verackBytes...))
+	c.addSentPacket(pingBytes[:len(pingBytes)-1]) // Truncated, ignored.
+	c.addReceived(CMDPing, len(pingBytes))
+
+	stats := c.snapshot()
+	require.Equal(t, map[CommandType]MessageStats{
+		CMDPing: {
+			Sent:          1,
+			SentBytes:     uint64(len(pingBytes)),
+			Received:      1,
+			ReceivedBytes: uint64(len(pingBytes)),
+		},
+		CMDVerack: {
+			Sent:      1,
+			SentBytes: uint64(len(verackBytes)),
+		},
+	}, stats)
+
+	// Snapshot is a copy.
+	c.addSent(CMDVerack, 3)
+	require.Equal(t, uint64(1), stats[CMDVerack].Sent)
+
+	// Decoded message size matches the serialized one.
+	var m Message
+	require.NoError(t, m.Decode(io.NewBinReaderFromBuf(pingBytes)))
+	require.Equal(t, len(pingBytes), m.size())
+}
@@ -80,6 +80,9 @@ type SignerAccount struct {
 // [waiter.ErrContextDone] wrapped with the context's error will be returned in this case.
 // Otherwise, transaction awaiting process is ended with ValidUntilBlock acceptance
 // and [waiter.ErrTxNotAccepted] is returned if transaction wasn't accepted by this moment.
+// Awaiting strategy, required number of confirmations and timeout can be
+// configured via [Options.WaiterConfig], [Actor.Await] returns detailed
+// awaiting outcome instead of an error.
 type Actor struct {
 	invoker.Invoker
 	waiter.Waiter
@@ -326,3 +329,9 @@ func (a *Actor) WaitSuccess(h util.Uint256, vub uint32, err error) (*state.AppEx
 	}
 	return aer, nil
 }
+
+// Await is similar to [waiter.Wait], but returns detailed [waiter.Result]
+// describing the awaiting outcome (see [waiter.Await]).
+func (a *Actor) Await(h util.Uint256, vub uint32, err error) *waiter.Result {
+	return waiter.Await(a.Waiter, h, vub, err)
+}
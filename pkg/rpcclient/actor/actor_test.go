@@ -11,6 +11,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -25,6 +26,7 @@ type RPCClient struct {
 	invRes  *result.Invoke
 	netFee  int64
 	bCount  atomic.Uint32
+	bStep   uint32
 	version *result.Version
 	hash    util.Uint256
 	appLog  *result.ApplicationLog
@@ -44,6 +46,9 @@ func (r *RPCClient) CalculateNetworkFee(tx *transaction.Transaction) (int64, err
 	return r.netFee, r.err
 }
 func (r *RPCClient) GetBlockCount() (uint32, error) {
+	if r.bStep != 0 {
+		return r.bCount.Add(r.bStep), r.err
+	}
 	return r.bCount.Load(), r.err
 }
 func (r *RPCClient) GetVersion() (*result.Version, error) {
@@ -337,3 +342,30 @@ func TestWaitSuccess(t *testing.T) {
 		Execution: ex,
 	}, res)
 }
+
+func TestAwait(t *testing.T) {
+	client, acc := testRPCAndAccount(t)
+	client.version.Protocol.MillisecondsPerBlock = 1 // reduce testing time.
+	a, err := NewSimple(client, acc)
+	require.NoError(t, err)
+
+	someErr := errors.New("someErr")
+	res := a.Await(util.Uint256{}, 0, someErr)
+	require.Equal(t, waiter.StatusFailed, res.Status)
+	require.ErrorIs(t, res.Err, someErr)
+
+	res = a.Await(util.Uint256{}, 0, nil)
+	require.Equal(t, waiter.StatusExpired, res.Status)
+	require.ErrorIs(t, res.Err, waiter.ErrTxNotAccepted)
+
+	cont := util.Uint256{1, 2, 3}
+	client.appLog = &result.ApplicationLog{
+		Container:     cont,
+		IsTransaction: true,
+		Executions:    []state.Execution{{Trigger: trigger.Application, VMState: vmstate.Halt}},
+	}
+	res = a.Await(cont, 0, nil)
+	require.Equal(t, waiter.StatusAccepted, res.Status)
+	require.NoError(t, res.Err)
+	require.Equal(t, cont, res.AppExecResult.Container)
+}
@@ -0,0 +1,70 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
+)
+
+// ErrResendLimit is returned from [LinearResendPolicy] callback when the
+// maximum number of resend attempts is reached.
+var ErrResendLimit = errors.New("resend attempts limit reached")
+
+// ResendPolicy is a callback used by [Actor.SignAndSendWithResend] to decide
+// whether the transaction that has expired without being accepted should be
+// resent. It receives the number of the upcoming resend attempt (starting
+// from 1) and the expired transaction and returns the amount of GAS to be
+// added to the network fee of the new transaction. Any error returned stops
+// the resending process and is returned to the caller.
+type ResendPolicy func(attempt int, expired *transaction.Transaction) (int64, error)
+
+// LinearResendPolicy returns a ResendPolicy that allows up to maxAttempts
+// resends increasing network fee by feeStep every time.
+func LinearResendPolicy(maxAttempts int, feeStep int64) ResendPolicy {
+	return func(attempt int, _ *transaction.Transaction) (int64, error) {
+		if attempt > maxAttempts {
+			return 0, fmt.Errorf("%w: %d", ErrResendLimit, maxAttempts)
+		}
+		return feeStep, nil
+	}
+}
+
+// SignAndSendWithResend signs and sends the transaction given (see also
+// SignAndSend) and waits for it to be accepted (see also [waiter.Waiter]).
+// If the transaction expires without being accepted into a block, it's
+// rebuilt with a new ValidUntilBlock value (see CalculateValidUntilBlock) and
+// an increased network fee according to the policy, then it's signed and sent
+// again. The new transaction is only sent after the previous one has expired,
+// so at most one of them can be accepted. A nil policy means no resending.
+// The execution result of the accepted transaction is returned.
+//
+// The transaction must have the same set of signers as the Actor and all of
+// them must be able to sign it without external cosigners since it's
+// re-signed on every attempt.
+func (a *Actor) SignAndSendWithResend(tx *transaction.Transaction, policy ResendPolicy) (*state.AppExecResult, error) {
+	for attempt := 1; ; attempt++ {
+		aer, err := a.Wait(a.SignAndSend(tx))
+		if policy == nil || !errors.Is(err, waiter.ErrTxNotAccepted) {
+			return aer, err
+		}
+		feeIncrement, err := policy(attempt, tx)
+		if err != nil {
+			return nil, err
+		}
+		next := tx.Copy()
+		next.ValidUntilBlock, err = a.CalculateValidUntilBlock()
+		if err != nil {
+			return nil, fmt.Errorf("calculating validUntilBlock: %w", err)
+		}
+		next.NetworkFee += feeIncrement
+		for i := range a.signers {
+			if i < len(next.Scripts) && !a.signers[i].Account.Contract.Deployed {
+				next.Scripts[i].InvocationScript = nil
+			}
+		}
+		tx = next
+	}
+}
@@ -0,0 +1,82 @@
+package actor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndSendWithResend(t *testing.T) {
+	client, acc := testRPCAndAccount(t)
+	client.version.Protocol.MillisecondsPerBlock = 10
+	// Every transaction expires by the next block count request.
+	client.bStep = 10
+	a, err := NewSimple(client, acc)
+	require.NoError(t, err)
+
+	newTx := func(t *testing.T) *transaction.Transaction {
+		client.netFee = 100
+		tx, err := a.MakeUnsignedUncheckedRun([]byte{1, 2, 3}, 1, nil)
+		require.NoError(t, err)
+		return tx
+	}
+
+	t.Run("no policy", func(t *testing.T) {
+		_, err := a.SignAndSendWithResend(newTx(t), nil)
+		require.ErrorIs(t, err, waiter.ErrTxNotAccepted)
+	})
+	t.Run("limit", func(t *testing.T) {
+		var expired []*transaction.Transaction
+		linear := LinearResendPolicy(2, 10)
+		_, err := a.SignAndSendWithResend(newTx(t), func(attempt int, tx *transaction.Transaction) (int64, error) {
+			require.Equal(t, len(expired)+1, attempt)
+			expired = append(expired, tx)
+			return linear(attempt, tx)
+		})
+		require.ErrorIs(t, err, ErrResendLimit)
+		require.Len(t, expired, 3)
+		for i := 1; i < len(expired); i++ {
+			require.Equal(t, expired[i-1].NetworkFee+10, expired[i].NetworkFee)
+			require.Greater(t, expired[i].ValidUntilBlock, expired[i-1].ValidUntilBlock)
+			require.NotEqual(t, expired[i-1].Hash(), expired[i].Hash())
+			// Single signature pushed with PUSHDATA1.
+			sig := expired[i].Scripts[0].InvocationScript[2:]
+			require.True(t, acc.PublicKey().VerifyHashable(sig, uint32(a.GetNetwork()), expired[i]))
+		}
+	})
+	t.Run("policy error", func(t *testing.T) {
+		someErr := errors.New("someErr")
+		_, err := a.SignAndSendWithResend(newTx(t), func(int, *transaction.Transaction) (int64, error) {
+			return 0, someErr
+		})
+		require.ErrorIs(t, err, someErr)
+	})
+	t.Run("accepted after resend", func(t *testing.T) {
+		defer func() { client.appLog = nil }()
+		ex := state.Execution{
+			Trigger: trigger.Application,
+			VMState: vmstate.Halt,
+		}
+		res, err := a.SignAndSendWithResend(newTx(t), func(attempt int, _ *transaction.Transaction) (int64, error) {
+			client.appLog = &result.ApplicationLog{
+				Container:     util.Uint256{1, 2, 3},
+				IsTransaction: true,
+				Executions:    []state.Execution{ex},
+			}
+			return 1, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, &state.AppExecResult{
+			Container: util.Uint256{1, 2, 3},
+			Execution: ex,
+		}, res)
+	})
+}
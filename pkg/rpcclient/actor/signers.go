@@ -0,0 +1,158 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// ResolveSigners creates a list of SignerAccount for the given signers using
+// accounts from the wallet and additional (usually watch-only, having just a
+// verification script) accounts from extra. It doesn't require any of these
+// accounts to be able to sign, so the result can be used to create an Actor
+// for transactions that are signed partially (see [Actor.SignPartially]).
+//
+// The first signer is treated as a sender and is kept first, repeating
+// signers are merged into one (combining their scopes, allowed contracts,
+// groups and rules) preserving the order of the first occurrence, as
+// transactions can't have duplicate signers. An error is returned if there is
+// no account for some signer.
+func ResolveSigners(w *wallet.Wallet, signers []transaction.Signer, extra ...*wallet.Account) ([]SignerAccount, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no signers")
+	}
+	var res = make([]SignerAccount, 0, len(signers))
+	for i, s := range signers {
+		j := slices.IndexFunc(res, func(sa SignerAccount) bool {
+			return sa.Signer.Account == s.Account
+		})
+		if j != -1 {
+			res[j].Signer = mergeSigners(res[j].Signer, s)
+			continue
+		}
+		acc := findAccount(w, s.Account, extra)
+		if acc == nil {
+			return nil, fmt.Errorf("no account was found for signer #%d (%s)", i, address.Uint160ToString(s.Account))
+		}
+		if acc.Contract == nil {
+			return nil, fmt.Errorf("account for signer #%d (%s) has no contract", i, acc.Address)
+		}
+		res = append(res, SignerAccount{
+			Signer:  s,
+			Account: acc,
+		})
+	}
+	return res, nil
+}
+
+// findAccount returns the account for the given hash, the one that can sign
+// is preferred if there are several of them.
+func findAccount(w *wallet.Wallet, h util.Uint160, extra []*wallet.Account) *wallet.Account {
+	var (
+		found    *wallet.Account
+		accounts = extra
+	)
+	if w != nil {
+		accounts = append(slices.Clone(w.Accounts), extra...)
+	}
+	for _, acc := range accounts {
+		if acc.ScriptHash() != h {
+			continue
+		}
+		if acc.CanSign() {
+			return acc
+		}
+		if found == nil {
+			found = acc
+		}
+	}
+	return found
+}
+
+// mergeSigners combines scopes of two signers for the same account. Slices of
+// the given signers are not modified.
+func mergeSigners(a, b transaction.Signer) transaction.Signer {
+	a.Scopes |= b.Scopes
+	a.AllowedContracts = slices.Clone(a.AllowedContracts)
+	a.AllowedGroups = slices.Clone(a.AllowedGroups)
+	a.Rules = slices.Clone(a.Rules)
+	for _, c := range b.AllowedContracts {
+		if !slices.Contains(a.AllowedContracts, c) {
+			a.AllowedContracts = append(a.AllowedContracts, c)
+		}
+	}
+	for _, g := range b.AllowedGroups {
+		if !slices.ContainsFunc(a.AllowedGroups, g.Equal) {
+			a.AllowedGroups = append(a.AllowedGroups, g)
+		}
+	}
+	a.Rules = append(a.Rules, b.Rules...)
+	if a.Scopes&transaction.Global != 0 {
+		// Global can't be combined with anything else.
+		a = transaction.Signer{Account: a.Account, Scopes: transaction.Global}
+	}
+	return a
+}
+
+// MissingSigners returns the list of Actor signers that are not able to sign
+// transactions (their accounts have no private key or are locked).
+func (a *Actor) MissingSigners() []transaction.Signer {
+	var res []transaction.Signer
+	for _, s := range a.signers {
+		if !canWitness(s.Account) {
+			res = append(res, s.Signer)
+		}
+	}
+	return res
+}
+
+// SignPartially adds witnesses for all signers that are able to produce them
+// and returns a ParameterContext with the transaction and signatures that
+// were made. If all witnesses were added the transaction is complete (can be
+// sent) and nil context is returned. The context is to be passed to other
+// parties owning missing keys (see [Actor.MissingSigners]), they can add
+// their signatures and get the complete transaction with
+// [context.ParameterContext.GetCompleteTransaction].
+func (a *Actor) SignPartially(tx *transaction.Transaction) (*context.ParameterContext, error) {
+	if len(tx.Signers) != len(a.signers) {
+		return nil, errors.New("incorrect number of signers in the transaction")
+	}
+	if len(a.MissingSigners()) == 0 {
+		return nil, a.Sign(tx)
+	}
+	var (
+		net   = a.GetNetwork()
+		scCtx = context.NewParameterContext(context.TransactionType, net, tx)
+	)
+	for i, signer := range a.signers {
+		acc := signer.Account
+		var err error
+		switch {
+		case acc.Contract.Deployed && len(acc.Contract.Parameters) == 0:
+			// No parameters, but the item is still required for the witness.
+			err = scCtx.AddSignature(signer.Signer.Account, acc.Contract, nil, nil)
+		case acc.CanSign():
+			sig := acc.SignHashable(net, tx)
+			err = scCtx.AddSignature(signer.Signer.Account, acc.Contract, acc.PublicKey(), sig)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to add signature for signer #%d (%s): %w", i, acc.Address, err)
+		}
+	}
+	return scCtx, nil
+}
+
+// canWitness returns true if the account can produce a witness without any
+// external data.
+func canWitness(acc *wallet.Account) bool {
+	if acc.Contract.Deployed && (acc.Contract.InvocationBuilder != nil || len(acc.Contract.Parameters) == 0) {
+		return true
+	}
+	return acc.CanSign()
+}
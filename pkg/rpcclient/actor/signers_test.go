@@ -0,0 +1,144 @@
+package actor
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSigners(t *testing.T) {
+	acc1, err := wallet.NewAccount()
+	require.NoError(t, err)
+	acc2, err := wallet.NewAccount()
+	require.NoError(t, err)
+	watchOnly := &wallet.Account{ // Looks like acc2, but has no private key.
+		Address:  acc2.Address,
+		Contract: acc2.Contract,
+	}
+	w := &wallet.Wallet{Accounts: []*wallet.Account{acc1, watchOnly}}
+
+	_, err = ResolveSigners(w, nil)
+	require.Error(t, err)
+
+	_, err = ResolveSigners(w, []transaction.Signer{{Account: util.Uint160{1, 2, 3}}})
+	require.Error(t, err)
+
+	signers := []transaction.Signer{
+		{Account: acc2.ScriptHash(), Scopes: transaction.CalledByEntry},
+		{Account: acc1.ScriptHash(), Scopes: transaction.None},
+		{Account: acc2.ScriptHash(), Scopes: transaction.CustomContracts, AllowedContracts: []util.Uint160{{7}}},
+	}
+	res, err := ResolveSigners(w, signers)
+	require.NoError(t, err)
+	require.Equal(t, []SignerAccount{{
+		Signer: transaction.Signer{
+			Account:          acc2.ScriptHash(),
+			Scopes:           transaction.CalledByEntry | transaction.CustomContracts,
+			AllowedContracts: []util.Uint160{{7}},
+		},
+		Account: watchOnly,
+	}, {
+		Signer:  signers[1],
+		Account: acc1,
+	}}, res)
+
+	// Extra accounts that can sign are preferred.
+	res, err = ResolveSigners(w, signers[:1], acc2)
+	require.NoError(t, err)
+	require.Equal(t, acc2, res[0].Account)
+
+	// Global scope is not combined with anything.
+	res, err = ResolveSigners(w, []transaction.Signer{signers[2], {Account: acc2.ScriptHash(), Scopes: transaction.Global}})
+	require.NoError(t, err)
+	require.Equal(t, transaction.Signer{Account: acc2.ScriptHash(), Scopes: transaction.Global}, res[0].Signer)
+}
+
+func TestMergeSigners(t *testing.T) {
+	var (
+		acc  = util.Uint160{1, 2, 3}
+		rule = transaction.WitnessRule{
+			Action:    transaction.WitnessAllow,
+			Condition: (*transaction.ConditionCalledByEntry)(nil),
+		}
+		a = transaction.Signer{
+			Account:          acc,
+			Scopes:           transaction.CustomContracts | transaction.Rules,
+			AllowedContracts: make([]util.Uint160, 1, 4),
+			Rules:            make([]transaction.WitnessRule, 1, 4),
+		}
+		b = transaction.Signer{
+			Account:          acc,
+			Scopes:           transaction.CustomContracts | transaction.Rules,
+			AllowedContracts: []util.Uint160{{7}},
+			Rules:            []transaction.WitnessRule{rule},
+		}
+	)
+	a.AllowedContracts[0] = util.Uint160{5}
+	a.Rules[0] = rule
+	aContracts := a.AllowedContracts[:cap(a.AllowedContracts)]
+	aRules := a.Rules[:cap(a.Rules)]
+
+	res := mergeSigners(a, b)
+	require.Equal(t, []util.Uint160{{5}, {7}}, res.AllowedContracts)
+	require.Equal(t, []transaction.WitnessRule{rule, rule}, res.Rules)
+
+	// Input signers and their backing arrays are left intact.
+	require.Equal(t, []util.Uint160{{5}}, a.AllowedContracts)
+	require.Equal(t, []util.Uint160{{5}, {}, {}, {}}, aContracts)
+	require.Equal(t, []transaction.WitnessRule{{}, {}, {}}, aRules[1:])
+	require.Equal(t, []util.Uint160{{7}}, b.AllowedContracts)
+	require.Equal(t, []transaction.WitnessRule{rule}, b.Rules)
+}
+
+func TestSignPartially(t *testing.T) {
+	client, acc := testRPCAndAccount(t)
+	acc2, err := wallet.NewAccount()
+	require.NoError(t, err)
+	w := &wallet.Wallet{Accounts: []*wallet.Account{acc, {
+		Address:  acc2.Address,
+		Contract: acc2.Contract,
+	}}}
+
+	signers, err := ResolveSigners(w, []transaction.Signer{
+		{Account: acc.ScriptHash(), Scopes: transaction.None},
+		{Account: acc2.ScriptHash(), Scopes: transaction.CalledByEntry},
+	})
+	require.NoError(t, err)
+	a, err := New(client, signers)
+	require.NoError(t, err)
+	require.Equal(t, []transaction.Signer{signers[1].Signer}, a.MissingSigners())
+
+	script := []byte{1, 2, 3}
+	client.invRes = &result.Invoke{State: "HALT", GasConsumed: 3, Script: script}
+	tx, err := a.MakeUnsignedRun(script, nil)
+	require.NoError(t, err)
+
+	_, err = a.SignPartially(&transaction.Transaction{})
+	require.Error(t, err)
+
+	scCtx, err := a.SignPartially(tx)
+	require.NoError(t, err)
+	require.NotNil(t, scCtx)
+	require.Len(t, scCtx.Items, 1)
+
+	// The other party completes the transaction.
+	require.NoError(t, scCtx.AddSignature(acc2.ScriptHash(), acc2.Contract, acc2.PublicKey(), acc2.SignHashable(a.GetNetwork(), tx)))
+	tx, err = scCtx.GetCompleteTransaction()
+	require.NoError(t, err)
+	require.Len(t, tx.Scripts, 2)
+
+	// All signers are available.
+	a, err = NewSimple(client, acc)
+	require.NoError(t, err)
+	require.Nil(t, a.MissingSigners())
+	tx, err = a.MakeUnsignedRun(script, nil)
+	require.NoError(t, err)
+	scCtx, err = a.SignPartially(tx)
+	require.NoError(t, err)
+	require.Nil(t, scCtx)
+	require.Len(t, tx.Scripts, 1)
+}
@@ -65,6 +65,7 @@ eventloop:
 		}
 	}
 	close(c.readerDone)
+	c.dropStorageStreams()
 	c.ctxCancel()
 	// ctx is cancelled, server is notified and will finish soon.
 drainloop:
@@ -226,6 +226,12 @@ func itemsToValidators(arr []stackitem.Item) ([]result.Validator, error) {
 	return res, nil
 }
 
+// GetCandidateVote returns the number of votes for the given candidate key or
+// -1 if the key is not a registered candidate.
+func (c *ContractReader) GetCandidateVote(k *keys.PublicKey) (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(Hash, "getCandidateVote", k))
+}
+
 // GetCommittee returns the list of committee member public keys. This
 // method is mostly useful for historic invocations because the RPC protocol
 // provides direct getcommittee call that works faster.
@@ -391,6 +391,37 @@ func TestUnclaimedGas(t *testing.T) {
 	require.Equal(t, big.NewInt(42), val)
 }
 
+func TestGetCandidateVote(t *testing.T) {
+	ta := &testAct{}
+	neo := NewReader(ta)
+	k, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	ta.err = errors.New("")
+	_, err = neo.GetCandidateVote(k.PublicKey())
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make([]stackitem.Item{}),
+		},
+	}
+	_, err = neo.GetCandidateVote(k.PublicKey())
+	require.Error(t, err)
+
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(-1),
+		},
+	}
+	val, err := neo.GetCandidateVote(k.PublicKey())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(-1), val)
+}
+
 func TestIntSetters(t *testing.T) {
 	ta := new(testAct)
 	neo := New(ta)
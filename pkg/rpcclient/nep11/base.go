@@ -18,6 +18,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neptoken"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -25,6 +26,12 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
+// MaxExpandedItems is the maximum number of items that can be retrieved by
+// *All methods (like TokensOfAll) from the server that doesn't support
+// sessions (iterators are expanded in the VM then, which is limited by the
+// VM stack size).
+const MaxExpandedItems = 2000
+
 // Invoker is used by reader types to call various methods.
 type Invoker interface {
 	neptoken.Invoker
@@ -75,6 +82,16 @@ type TransferEvent struct {
 	ID     []byte
 }
 
+// TokenProperties is a typed representation of NEP-11 token properties.
+type TokenProperties struct {
+	Name        string
+	Description string
+	Image       string
+	TokenURI    string
+	// Extra contains all other (non-standard) properties as is.
+	Extra map[string]stackitem.Item
+}
+
 // TokenIterator is used for iterating over TokensOf results.
 type TokenIterator struct {
 	client   Invoker
@@ -145,6 +162,28 @@ func (t *BaseReader) TokensOfExpanded(account util.Uint160, num int) ([][]byte,
 	return unwrap.ArrayOfBytes(t.invoker.CallAndExpandIterator(t.hash, "tokensOf", num, account))
 }
 
+// TokensAll returns all tokens minted by the contract. Unlike Tokens it
+// retrieves all of them at once (see TokensOfAll for details).
+func (t *BaseReader) TokensAll() ([][]byte, error) {
+	return unwrapAllBytes(allItems(t.invoker, t.hash, "tokens"))
+}
+
+// TokensOfAll returns all tokens owned by the given account. Unlike TokensOf
+// it retrieves all of them at once, transparently traversing session-based
+// iterator (and terminating the session), using server-expanded one or
+// expanding the iterator in the VM if the server doesn't support sessions
+// (in which case up to MaxExpandedItems can be returned, an error is
+// returned if there are more).
+func (t *BaseReader) TokensOfAll(account util.Uint160) ([][]byte, error) {
+	return unwrapAllBytes(allItems(t.invoker, t.hash, "tokensOf", account))
+}
+
+// TokenProperties is similar to Properties, but decodes well-known NEP-11
+// properties into TokenProperties (see UnwrapTokenProperties).
+func (t *BaseReader) TokenProperties(token []byte) (*TokenProperties, error) {
+	return UnwrapTokenProperties(t.Properties(token))
+}
+
 // Transfer creates and sends a transaction that performs a `transfer` method
 // call using the given parameters and checks for this call result, failing the
 // transaction if it's not true. It works for divisible NFTs only when there is
@@ -217,6 +256,84 @@ func (v *TokenIterator) Terminate() error {
 	return v.client.TerminateSession(v.session)
 }
 
+// allItems calls the given iterator-returning method and gets all items from
+// the iterator regardless of the server's session and iterator expansion
+// settings.
+func allItems(inv Invoker, hash util.Uint160, method string, params ...any) ([]stackitem.Item, error) {
+	sess, iter, err := unwrap.SessionIterator(inv.Call(hash, method, params...))
+	if err != nil && !errors.Is(err, unwrap.ErrNoSessionID) {
+		return nil, err
+	}
+	if err == nil && iter.ID != nil {
+		var res []stackitem.Item
+		for {
+			items, err := inv.TraverseIterator(sess, &iter, invoker.DefaultIteratorResultItems)
+			if err != nil {
+				_ = inv.TerminateSession(sess)
+				return nil, err
+			}
+			res = append(res, items...)
+			if len(items) < invoker.DefaultIteratorResultItems {
+				break
+			}
+		}
+		return res, inv.TerminateSession(sess)
+	}
+	if err == nil && !iter.Truncated {
+		return iter.Values, nil
+	}
+	// No sessions or truncated server-side expansion, expand in the VM then.
+	items, err := unwrap.Array(inv.CallAndExpandIterator(hash, method, MaxExpandedItems+1, params...))
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > MaxExpandedItems {
+		return nil, fmt.Errorf("more than %d items can't be retrieved without sessions", MaxExpandedItems)
+	}
+	return items, nil
+}
+
+func unwrapAllBytes(items []stackitem.Item, err error) ([][]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	res := make([][]byte, len(items))
+	for i := range items {
+		res[i], err = items[i].TryBytes()
+		if err != nil {
+			return nil, fmt.Errorf("element %d is not a byte string: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// UnwrapTokenProperties can be used as a proxy function to decode NEP-11
+// properties into TokenProperties. Well-known properties are checked the same
+// way UnwrapKnownProperties does, other ones are stored in Extra.
+func UnwrapTokenProperties(m *stackitem.Map, err error) (*TokenProperties, error) {
+	known, err := UnwrapKnownProperties(m, err)
+	if err != nil {
+		return nil, err
+	}
+	res := &TokenProperties{
+		Name:        known["name"],
+		Description: known["description"],
+		Image:       known["image"],
+		TokenURI:    known["tokenURI"],
+	}
+	for _, e := range m.Value().([]stackitem.MapElement) {
+		k, err := e.Key.TryBytes()
+		if err != nil || result.KnownNEP11Properties[string(k)] {
+			continue
+		}
+		if res.Extra == nil {
+			res.Extra = make(map[string]stackitem.Item)
+		}
+		res.Extra[string(k)] = e.Value
+	}
+	return res, nil
+}
+
 // UnwrapKnownProperties can be used as a proxy function to extract well-known
 // NEP-11 properties (name/description/image/tokenURI) defined in the standard.
 // These properties are checked to be valid UTF-8 strings, but can contain
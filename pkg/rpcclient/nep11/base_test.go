@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
@@ -19,6 +20,11 @@ type testAct struct {
 	tx  *transaction.Transaction
 	txh util.Uint256
 	vub uint32
+
+	// Optional iterator-specific results.
+	pages      [][]stackitem.Item
+	expanded   *result.Invoke
+	terminated bool
 }
 
 func (t *testAct) Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
@@ -34,12 +40,23 @@ func (t *testAct) SendRun(script []byte) (util.Uint256, uint32, error) {
 	return t.txh, t.vub, t.err
 }
 func (t *testAct) CallAndExpandIterator(contract util.Uint160, method string, maxItems int, params ...any) (*result.Invoke, error) {
+	if t.expanded != nil {
+		return t.expanded, t.err
+	}
 	return t.res, t.err
 }
 func (t *testAct) TerminateSession(sessionID uuid.UUID) error {
+	t.terminated = true
 	return t.err
 }
 func (t *testAct) TraverseIterator(sessionID uuid.UUID, iterator *result.Iterator, num int) ([]stackitem.Item, error) {
+	if t.pages != nil {
+		var page []stackitem.Item
+		if len(t.pages) != 0 {
+			page, t.pages = t.pages[0], t.pages[1:]
+		}
+		return page, t.err
+	}
 	return t.res.Stack, t.err
 }
 
@@ -339,3 +356,120 @@ func TestUnwrapKnownProperties(t *testing.T) {
 	require.Equal(t, "thing", m["name"])
 	require.Equal(t, "good NFT", m["description"])
 }
+
+func TestReaderTokensOfAll(t *testing.T) {
+	ta := new(testAct)
+	tr := NewBaseReader(ta, util.Uint160{1, 2, 3})
+
+	ta.err = errors.New("")
+	_, err := tr.TokensOfAll(util.Uint160{2, 3, 4})
+	require.Error(t, err)
+
+	ta.err = nil
+	t.Run("session", func(t *testing.T) {
+		page := make([]stackitem.Item, invoker.DefaultIteratorResultItems)
+		for i := range page {
+			page[i] = stackitem.Make([]byte{byte(i)})
+		}
+		ta.res = &result.Invoke{
+			State:   "HALT",
+			Session: uuid.New(),
+			Stack: []stackitem.Item{
+				stackitem.NewInterop(result.Iterator{
+					ID: &uuid.UUID{},
+				}),
+			},
+		}
+		ta.pages = [][]stackitem.Item{page, {stackitem.Make("last")}}
+		ta.terminated = false
+		toks, err := tr.TokensOfAll(util.Uint160{2, 3, 4})
+		require.NoError(t, err)
+		require.Equal(t, invoker.DefaultIteratorResultItems+1, len(toks))
+		require.Equal(t, []byte{1}, toks[1])
+		require.Equal(t, []byte("last"), toks[len(toks)-1])
+		require.True(t, ta.terminated)
+
+		ta.pages = [][]stackitem.Item{{stackitem.Make([]stackitem.Item{})}}
+		_, err = tr.TokensOfAll(util.Uint160{2, 3, 4})
+		require.Error(t, err)
+		ta.pages = nil
+	})
+	t.Run("expanded", func(t *testing.T) {
+		ta.res = &result.Invoke{
+			State:   "HALT",
+			Session: uuid.New(),
+			Stack: []stackitem.Item{
+				stackitem.NewInterop(result.Iterator{
+					Values: []stackitem.Item{stackitem.Make("one"), stackitem.Make("two")},
+				}),
+			},
+		}
+		toks, err := tr.TokensOfAll(util.Uint160{2, 3, 4})
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("one"), []byte("two")}, toks)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		ta.res = &result.Invoke{
+			State:   "HALT",
+			Session: uuid.New(),
+			Stack: []stackitem.Item{
+				stackitem.NewInterop(result.Iterator{
+					Values:    []stackitem.Item{stackitem.Make("one")},
+					Truncated: true,
+				}),
+			},
+		}
+		ta.expanded = &result.Invoke{
+			State: "HALT",
+			Stack: []stackitem.Item{
+				stackitem.Make([]stackitem.Item{stackitem.Make("one"), stackitem.Make("two")}),
+			},
+		}
+		toks, err := tr.TokensOfAll(util.Uint160{2, 3, 4})
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("one"), []byte("two")}, toks)
+	})
+	t.Run("no session", func(t *testing.T) {
+		ta.res = &result.Invoke{
+			State: "HALT",
+			Stack: []stackitem.Item{
+				stackitem.NewInterop(result.Iterator{
+					ID: &uuid.UUID{},
+				}),
+			},
+		}
+		toks, err := tr.TokensAll()
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("one"), []byte("two")}, toks)
+
+		items := make([]stackitem.Item, MaxExpandedItems+1)
+		for i := range items {
+			items[i] = stackitem.Make(i)
+		}
+		ta.expanded.Stack = []stackitem.Item{stackitem.Make(items)}
+		_, err = tr.TokensAll()
+		require.Error(t, err)
+	})
+}
+
+func TestUnwrapTokenProperties(t *testing.T) {
+	_, err := UnwrapTokenProperties(stackitem.NewMap(), errors.New(""))
+	require.Error(t, err)
+
+	m := stackitem.NewMap()
+	m.Add(stackitem.Make("name"), stackitem.Make([]stackitem.Item{}))
+	_, err = UnwrapTokenProperties(m, nil)
+	require.Error(t, err)
+
+	m = stackitem.NewMap()
+	m.Add(stackitem.Make("name"), stackitem.Make("thing"))
+	m.Add(stackitem.Make("tokenURI"), stackitem.Make("https://neo.org/"))
+	m.Add(stackitem.Make("color"), stackitem.Make(42))
+	res, err := UnwrapTokenProperties(m, nil)
+	require.NoError(t, err)
+	require.Equal(t, &TokenProperties{
+		Name:     "thing",
+		TokenURI: "https://neo.org/",
+		Extra:    map[string]stackitem.Item{"color": stackitem.Make(42)},
+	}, res)
+}
@@ -68,6 +68,27 @@ func (t *DivisibleReader) OwnerOfExpanded(token []byte, num int) ([]util.Uint160
 	return unwrap.ArrayOfUint160(t.invoker.CallAndExpandIterator(t.hash, "ownerOf", num, token))
 }
 
+// OwnerOfAll returns all owners of the given token. Unlike OwnerOf it
+// retrieves all of them at once (see TokensOfAll for details).
+func (t *DivisibleReader) OwnerOfAll(token []byte) ([]util.Uint160, error) {
+	items, err := allItems(t.invoker, t.hash, "ownerOf", token)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]util.Uint160, len(items))
+	for i := range items {
+		b, err := items[i].TryBytes()
+		if err != nil {
+			return nil, fmt.Errorf("element %d is not a byte string: %w", i, err)
+		}
+		res[i], err = util.Uint160DecodeBytesBE(b)
+		if err != nil {
+			return nil, fmt.Errorf("element %d is not a uint160: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
 // BalanceOfD is a BalanceOf for divisible NFTs, it returns the amount of token
 // owned by a particular account.
 func (t *DivisibleReader) BalanceOfD(owner util.Uint160, token []byte) (*big.Int, error) {
@@ -236,3 +236,26 @@ func TestDivisibleTransferTransaction(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+func TestDivisibleOwnerOfAll(t *testing.T) {
+	ta := new(testAct)
+	tr := NewDivisibleReader(ta, util.Uint160{1, 2, 3})
+
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.NewInterop(result.Iterator{
+				Values: []stackitem.Item{stackitem.Make(util.Uint160{1, 2, 3}.BytesBE())},
+			}),
+		},
+	}
+	owners, err := tr.OwnerOfAll([]byte{1})
+	require.NoError(t, err)
+	require.Equal(t, []util.Uint160{{1, 2, 3}}, owners)
+
+	ta.res.Stack[0] = stackitem.NewInterop(result.Iterator{
+		Values: []stackitem.Item{stackitem.Make([]byte{1, 2})},
+	})
+	_, err = tr.OwnerOfAll([]byte{1})
+	require.Error(t, err)
+}
@@ -0,0 +1,215 @@
+package nep17
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// ApprovableReader represents safe methods of NEP-17 tokens implementing
+// widely used (but not standardized) approve/transferFrom extension, it adds
+// `allowance` method to the standard TokenReader set.
+type ApprovableReader struct {
+	TokenReader
+
+	invoker Invoker
+	hash    util.Uint160
+}
+
+// ApprovableWriter contains state-changing methods of approve/transferFrom
+// extension along with the standard TokenWriter ones. It's not meant to be
+// used directly, Approvable is more convenient.
+type ApprovableWriter struct {
+	TokenWriter
+}
+
+// Approvable provides full interface of NEP-17 tokens implementing
+// approve/transferFrom extension. It expects the following methods to be
+// implemented by the contract:
+//
+//	approve(owner Hash160, spender Hash160, amount Integer) Boolean
+//	allowance(owner Hash160, spender Hash160) Integer
+//	transferFrom(spender Hash160, from Hash160, to Hash160, amount Integer, data Any) Boolean
+//
+// and `Approval` event with owner, spender and amount parameters to be emitted
+// on every allowance change.
+type Approvable struct {
+	ApprovableReader
+	ApprovableWriter
+}
+
+// ApprovalEvent represents an Approval event emitted by tokens implementing
+// approve/transferFrom extension.
+type ApprovalEvent struct {
+	Owner   util.Uint160
+	Spender util.Uint160
+	Amount  *big.Int
+}
+
+// NewApprovableReader creates an instance of ApprovableReader for contract with
+// the given hash using the given Invoker.
+func NewApprovableReader(invoker Invoker, hash util.Uint160) *ApprovableReader {
+	return &ApprovableReader{*NewReader(invoker, hash), invoker, hash}
+}
+
+// NewApprovable creates an instance of Approvable for contract with the given
+// hash using the given Actor.
+func NewApprovable(actor Actor, hash util.Uint160) *Approvable {
+	return &Approvable{*NewApprovableReader(actor, hash), ApprovableWriter{TokenWriter{hash, actor}}}
+}
+
+// Allowance returns the amount of owner's tokens that can be transferred by
+// spender.
+func (t *ApprovableReader) Allowance(owner util.Uint160, spender util.Uint160) (*big.Int, error) {
+	return unwrap.BigInt(t.invoker.Call(t.hash, "allowance", owner, spender))
+}
+
+func (t *ApprovableWriter) approveScript(owner util.Uint160, spender util.Uint160, amount *big.Int) ([]byte, error) {
+	if amount.Sign() < 0 {
+		return nil, errors.New("negative amount")
+	}
+	b := smartcontract.NewBuilder()
+	b.InvokeWithAssert(t.hash, "approve", owner, spender, amount)
+	return b.Script()
+}
+
+// Approve creates and sends a transaction that performs an `approve` method
+// call allowing spender to transfer up to amount of owner's tokens (zero
+// amount revokes the approval) and checks for this call result, failing the
+// transaction if it's not true. The returned values are transaction hash, its
+// ValidUntilBlock value and an error if any.
+func (t *ApprovableWriter) Approve(owner util.Uint160, spender util.Uint160, amount *big.Int) (util.Uint256, uint32, error) {
+	script, err := t.approveScript(owner, spender, amount)
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	return t.actor.SendRun(script)
+}
+
+// ApproveTransaction is similar to Approve, but returns the signed transaction
+// that is not yet sent.
+func (t *ApprovableWriter) ApproveTransaction(owner util.Uint160, spender util.Uint160, amount *big.Int) (*transaction.Transaction, error) {
+	script, err := t.approveScript(owner, spender, amount)
+	if err != nil {
+		return nil, err
+	}
+	return t.actor.MakeRun(script)
+}
+
+// ApproveUnsigned is similar to Approve, but returns the transaction that is
+// not yet signed.
+func (t *ApprovableWriter) ApproveUnsigned(owner util.Uint160, spender util.Uint160, amount *big.Int) (*transaction.Transaction, error) {
+	script, err := t.approveScript(owner, spender, amount)
+	if err != nil {
+		return nil, err
+	}
+	return t.actor.MakeUnsignedRun(script, nil)
+}
+
+func (t *ApprovableWriter) transferFromScript(spender util.Uint160, from util.Uint160, to util.Uint160, amount *big.Int, data any) ([]byte, error) {
+	b := smartcontract.NewBuilder()
+	b.InvokeWithAssert(t.hash, "transferFrom", spender, from, to, amount, data)
+	return b.Script()
+}
+
+// TransferFrom creates and sends a transaction that performs a `transferFrom`
+// method call transferring previously approved amount of tokens from the given
+// account by spender and checks for this call result, failing the transaction
+// if it's not true. The returned values are transaction hash, its
+// ValidUntilBlock value and an error if any.
+func (t *ApprovableWriter) TransferFrom(spender util.Uint160, from util.Uint160, to util.Uint160, amount *big.Int, data any) (util.Uint256, uint32, error) {
+	script, err := t.transferFromScript(spender, from, to, amount, data)
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	return t.actor.SendRun(script)
+}
+
+// TransferFromTransaction is similar to TransferFrom, but returns the signed
+// transaction that is not yet sent.
+func (t *ApprovableWriter) TransferFromTransaction(spender util.Uint160, from util.Uint160, to util.Uint160, amount *big.Int, data any) (*transaction.Transaction, error) {
+	script, err := t.transferFromScript(spender, from, to, amount, data)
+	if err != nil {
+		return nil, err
+	}
+	return t.actor.MakeRun(script)
+}
+
+// TransferFromUnsigned is similar to TransferFrom, but returns the transaction
+// that is not yet signed.
+func (t *ApprovableWriter) TransferFromUnsigned(spender util.Uint160, from util.Uint160, to util.Uint160, amount *big.Int, data any) (*transaction.Transaction, error) {
+	script, err := t.transferFromScript(spender, from, to, amount, data)
+	if err != nil {
+		return nil, err
+	}
+	return t.actor.MakeUnsignedRun(script, nil)
+}
+
+// ApprovalEventsFromApplicationLog retrieves all emitted ApprovalEvents from
+// the provided [result.ApplicationLog].
+func ApprovalEventsFromApplicationLog(log *result.ApplicationLog) ([]*ApprovalEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+	var res []*ApprovalEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "Approval" {
+				continue
+			}
+			event := new(ApprovalEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode event from stackitem (event #%d, execution #%d): %w", j, i, err)
+			}
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// FromStackItem converts provided [stackitem.Array] to ApprovalEvent or returns
+// an error if it's not possible to do to so.
+func (e *ApprovalEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 3 {
+		return errors.New("wrong number of event parameters")
+	}
+
+	b, err := arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("invalid Owner: %w", err)
+	}
+	e.Owner, err = util.Uint160DecodeBytesBE(b)
+	if err != nil {
+		return fmt.Errorf("failed to decode Owner: %w", err)
+	}
+
+	b, err = arr[1].TryBytes()
+	if err != nil {
+		return fmt.Errorf("invalid Spender: %w", err)
+	}
+	e.Spender, err = util.Uint160DecodeBytesBE(b)
+	if err != nil {
+		return fmt.Errorf("failed to decode Spender: %w", err)
+	}
+
+	e.Amount, err = arr[2].TryInteger()
+	if err != nil {
+		return fmt.Errorf("failed to decode Amount: %w", err)
+	}
+	return nil
+}
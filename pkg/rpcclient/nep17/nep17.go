@@ -105,6 +105,37 @@ func (t *TokenWriter) TransferUnsigned(from util.Uint160, to util.Uint160, amoun
 	return t.MultiTransferUnsigned([]TransferParameters{{from, to, amount, data}})
 }
 
+// TransferAmount is similar to Transfer, but accepts the amount as a decimal
+// string (like "1.5") that is converted to the integer value using token
+// decimals (see [neptoken.Base.ParseAmount]).
+func (t *Token) TransferAmount(from util.Uint160, to util.Uint160, amount string, data any) (util.Uint256, uint32, error) {
+	a, err := t.ParseAmount(amount)
+	if err != nil {
+		return util.Uint256{}, 0, fmt.Errorf("invalid amount: %w", err)
+	}
+	return t.Transfer(from, to, a, data)
+}
+
+// TransferAmountTransaction is similar to TransferTransaction, but accepts the
+// amount as a decimal string like TransferAmount does.
+func (t *Token) TransferAmountTransaction(from util.Uint160, to util.Uint160, amount string, data any) (*transaction.Transaction, error) {
+	a, err := t.ParseAmount(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	return t.TransferTransaction(from, to, a, data)
+}
+
+// TransferAmountUnsigned is similar to TransferUnsigned, but accepts the
+// amount as a decimal string like TransferAmount does.
+func (t *Token) TransferAmountUnsigned(from util.Uint160, to util.Uint160, amount string, data any) (*transaction.Transaction, error) {
+	a, err := t.ParseAmount(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	return t.TransferUnsigned(from, to, a, data)
+}
+
 func (t *TokenWriter) multiTransferScript(params []TransferParameters) ([]byte, error) {
 	if len(params) == 0 {
 		return nil, errors.New("at least one transfer parameter required")
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -169,3 +170,165 @@ func TestTokenTransferTransaction(t *testing.T) {
 	_, err = tok.MultiTransferUnsigned([]TransferParameters{})
 	require.Error(t, err)
 }
+
+func TestTokenTransferAmount(t *testing.T) {
+	ta := new(testAct)
+	tok := New(ta, util.Uint160{1, 2, 3})
+
+	ta.err = errors.New("")
+	_, _, err := tok.TransferAmount(util.Uint160{3, 2, 1}, util.Uint160{3, 2, 1}, "1", nil)
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(2),
+		},
+	}
+	ta.txh = util.Uint256{1, 2, 3}
+	ta.vub = 42
+	h, vub, err := tok.TransferAmount(util.Uint160{3, 2, 1}, util.Uint160{3, 2, 1}, "1.5", nil)
+	require.NoError(t, err)
+	require.Equal(t, ta.txh, h)
+	require.Equal(t, ta.vub, vub)
+
+	ta.tx = &transaction.Transaction{Nonce: 100500, ValidUntilBlock: 42}
+	for _, fun := range []func(from util.Uint160, to util.Uint160, amount string, data any) (*transaction.Transaction, error){
+		tok.TransferAmountTransaction,
+		tok.TransferAmountUnsigned,
+	} {
+		tx, err := fun(util.Uint160{3, 2, 1}, util.Uint160{3, 2, 1}, "0.01", nil)
+		require.NoError(t, err)
+		require.Equal(t, ta.tx, tx)
+
+		_, err = fun(util.Uint160{3, 2, 1}, util.Uint160{3, 2, 1}, "0.001", nil)
+		require.ErrorContains(t, err, "invalid amount")
+	}
+	_, _, err = tok.TransferAmount(util.Uint160{3, 2, 1}, util.Uint160{3, 2, 1}, "bad", nil)
+	require.ErrorContains(t, err, "invalid amount")
+}
+
+func TestApprovableAllowance(t *testing.T) {
+	ta := new(testAct)
+	tr := NewApprovableReader(ta, util.Uint160{1, 2, 3})
+
+	ta.err = errors.New("")
+	_, err := tr.Allowance(util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6})
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(42),
+		},
+	}
+	allowance, err := tr.Allowance(util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(42), allowance)
+
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make([]stackitem.Item{}),
+		},
+	}
+	_, err = tr.Allowance(util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6})
+	require.Error(t, err)
+}
+
+func TestApprovableApprove(t *testing.T) {
+	ta := new(testAct)
+	tok := NewApprovable(ta, util.Uint160{1, 2, 3})
+
+	ta.err = errors.New("")
+	_, _, err := tok.Approve(util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6}, big.NewInt(1))
+	require.Error(t, err)
+	_, err = tok.ApproveTransaction(util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6}, big.NewInt(1))
+	require.Error(t, err)
+	_, err = tok.ApproveUnsigned(util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6}, big.NewInt(1))
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.txh = util.Uint256{1, 2, 3}
+	ta.vub = 42
+	h, vub, err := tok.Approve(util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6}, big.NewInt(0))
+	require.NoError(t, err)
+	require.Equal(t, ta.txh, h)
+	require.Equal(t, ta.vub, vub)
+
+	ta.tx = &transaction.Transaction{Nonce: 100500, ValidUntilBlock: 42}
+	for _, fun := range []func(util.Uint160, util.Uint160, *big.Int) (*transaction.Transaction, error){
+		tok.ApproveTransaction,
+		tok.ApproveUnsigned,
+	} {
+		tx, err := fun(util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6}, big.NewInt(1))
+		require.NoError(t, err)
+		require.Equal(t, ta.tx, tx)
+
+		_, err = fun(util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6}, big.NewInt(-1))
+		require.Error(t, err)
+	}
+	_, _, err = tok.Approve(util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6}, big.NewInt(-1))
+	require.Error(t, err)
+}
+
+func TestApprovableTransferFrom(t *testing.T) {
+	ta := new(testAct)
+	tok := NewApprovable(ta, util.Uint160{1, 2, 3})
+
+	ta.err = errors.New("")
+	_, _, err := tok.TransferFrom(util.Uint160{4, 5, 6}, util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6}, big.NewInt(1), nil)
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.txh = util.Uint256{1, 2, 3}
+	ta.vub = 42
+	h, vub, err := tok.TransferFrom(util.Uint160{4, 5, 6}, util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6}, big.NewInt(1), nil)
+	require.NoError(t, err)
+	require.Equal(t, ta.txh, h)
+	require.Equal(t, ta.vub, vub)
+
+	ta.tx = &transaction.Transaction{Nonce: 100500, ValidUntilBlock: 42}
+	for _, fun := range []func(util.Uint160, util.Uint160, util.Uint160, *big.Int, any) (*transaction.Transaction, error){
+		tok.TransferFromTransaction,
+		tok.TransferFromUnsigned,
+	} {
+		tx, err := fun(util.Uint160{4, 5, 6}, util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6}, big.NewInt(1), nil)
+		require.NoError(t, err)
+		require.Equal(t, ta.tx, tx)
+
+		_, err = fun(util.Uint160{4, 5, 6}, util.Uint160{3, 2, 1}, util.Uint160{4, 5, 6}, big.NewInt(1), stackitem.NewInterop(nil))
+		require.Error(t, err)
+	}
+}
+
+func TestApprovalEventsFromApplicationLog(t *testing.T) {
+	_, err := ApprovalEventsFromApplicationLog(nil)
+	require.Error(t, err)
+
+	log := &result.ApplicationLog{
+		Executions: []state.Execution{{
+			Events: []state.NotificationEvent{
+				{Name: "Transfer", Item: stackitem.NewArray(nil)},
+				{Name: "Approval", Item: stackitem.NewArray([]stackitem.Item{
+					stackitem.Make(util.Uint160{1, 2, 3}.BytesBE()),
+					stackitem.Make(util.Uint160{4, 5, 6}.BytesBE()),
+					stackitem.Make(42),
+				})},
+			},
+		}},
+	}
+	events, err := ApprovalEventsFromApplicationLog(log)
+	require.NoError(t, err)
+	require.Equal(t, []*ApprovalEvent{{
+		Owner:   util.Uint160{1, 2, 3},
+		Spender: util.Uint160{4, 5, 6},
+		Amount:  big.NewInt(42),
+	}}, events)
+
+	log.Executions[0].Events[1].Item = stackitem.NewArray([]stackitem.Item{stackitem.Make(1)})
+	_, err = ApprovalEventsFromApplicationLog(log)
+	require.Error(t, err)
+}
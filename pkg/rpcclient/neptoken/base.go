@@ -7,7 +7,9 @@ package neptoken
 
 import (
 	"math/big"
+	"sync"
 
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -30,14 +32,23 @@ type Invoker interface {
 // Base is a reader interface for common NEP-11 and NEP-17 methods built
 // on top of Invoker.
 type Base struct {
-	invoker Invoker
-	hash    util.Uint160
+	invoker  Invoker
+	hash     util.Uint160
+	decimals *decimalsCache
+}
+
+// decimalsCache stores token decimals used for amount conversions, it's shared
+// between all copies of the same Base.
+type decimalsCache struct {
+	lock  sync.Mutex
+	valid bool
+	value int
 }
 
 // New creates an instance of Base for contract with the given hash using the
 // given invoker.
 func New(invoker Invoker, hash util.Uint160) *Base {
-	return &Base{invoker, hash}
+	return &Base{invoker, hash, new(decimalsCache)}
 }
 
 // Decimals implements `decimals` NEP-17 or NEP-11 method and returns the number
@@ -70,3 +81,45 @@ func (b *Base) TotalSupply() (*big.Int, error) {
 func (b *Base) BalanceOf(account util.Uint160) (*big.Int, error) {
 	return unwrap.BigInt(b.invoker.Call(b.hash, "balanceOf", account))
 }
+
+// ParseAmount converts the decimal string representation of the token amount
+// (like "1.5") to the integer value used by contract methods (150 for a token
+// with 2 decimals). An error is returned if the amount has more fractional
+// digits than token decimals allow. Token decimals are requested once and
+// then cached for all subsequent ParseAmount and FormatAmount calls.
+func (b *Base) ParseAmount(s string) (*big.Int, error) {
+	dec, err := b.cachedDecimals()
+	if err != nil {
+		return nil, err
+	}
+	return fixedn.FromString(s, dec)
+}
+
+// FormatAmount converts the integer token amount returned from contract
+// methods (like BalanceOf) to its decimal string representation. It uses
+// the same cached decimals value as ParseAmount.
+func (b *Base) FormatAmount(amount *big.Int) (string, error) {
+	dec, err := b.cachedDecimals()
+	if err != nil {
+		return "", err
+	}
+	return fixedn.ToString(amount, dec), nil
+}
+
+// cachedDecimals returns token decimals requesting them via Decimals only if
+// there is no successfully retrieved value yet.
+func (b *Base) cachedDecimals() (int, error) {
+	if b.decimals == nil {
+		return b.Decimals()
+	}
+	b.decimals.lock.Lock()
+	defer b.decimals.lock.Unlock()
+	if !b.decimals.valid {
+		dec, err := b.Decimals()
+		if err != nil {
+			return 0, err
+		}
+		b.decimals.value, b.decimals.valid = dec, true
+	}
+	return b.decimals.value, nil
+}
@@ -33,6 +33,10 @@ func TestBaseErrors(t *testing.T) {
 	require.Error(t, err)
 	_, err = base.BalanceOf(util.Uint160{1, 2, 3})
 	require.Error(t, err)
+	_, err = base.ParseAmount("1")
+	require.Error(t, err)
+	_, err = base.FormatAmount(big.NewInt(1))
+	require.Error(t, err)
 
 	ti.err = nil
 	ti.res = &result.Invoke{
@@ -165,3 +169,36 @@ func TestBaseBalanceOf(t *testing.T) {
 	_, err = base.BalanceOf(util.Uint160{1, 2, 3})
 	require.Error(t, err)
 }
+
+func TestBaseAmounts(t *testing.T) {
+	ti := new(testInv)
+	base := New(ti, util.Uint160{1, 2, 3})
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(2),
+		},
+	}
+	amount, err := base.ParseAmount("-1.5")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(-150), amount)
+	_, err = base.ParseAmount("1.505")
+	require.Error(t, err)
+
+	s, err := base.FormatAmount(big.NewInt(105))
+	require.NoError(t, err)
+	require.Equal(t, "1.05", s)
+
+	// Decimals are cached, even for copies.
+	ti.err = errors.New("")
+	cp := *base
+	amount, err = cp.ParseAmount("3")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(300), amount)
+	s, err = cp.FormatAmount(big.NewInt(-7))
+	require.NoError(t, err)
+	require.Equal(t, "-0.07", s)
+	_, err = cp.Decimals()
+	require.Error(t, err)
+}
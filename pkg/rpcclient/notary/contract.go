@@ -61,6 +61,22 @@ type Contract struct {
 	actor ContractActor
 }
 
+// MaxNotValidBeforeDeltaChangedEvent represents an event emitted by the Notary
+// contract when MaxNotValidBeforeDelta value is changed. It's only emitted
+// starting from Echidna hardfork.
+type MaxNotValidBeforeDeltaChangedEvent struct {
+	Old uint32
+	New uint32
+}
+
+// NotaryServiceFeePerKeyChangedEvent represents an event emitted by the Notary
+// contract when the per-key notary service fee is changed via Notary contract.
+// It's only emitted starting from Echidna hardfork.
+type NotaryServiceFeePerKeyChangedEvent struct {
+	Old int64
+	New int64
+}
+
 // OnNEP17PaymentData is the data set that is accepted by the notary contract
 // onNEP17Payment handler. It's mandatory for GAS tranfers to this contract.
 type OnNEP17PaymentData struct {
@@ -110,6 +126,14 @@ func (c *ContractReader) GetMaxNotValidBeforeDelta() (uint32, error) {
 	return uint32(ret), err
 }
 
+// GetNotaryServiceFeePerKey returns the fee paid for every key of the
+// NotaryAssisted transaction attribute. It's the same value as Policy's
+// NotaryAssisted attribute fee, the method is available starting from
+// Echidna hardfork.
+func (c *ContractReader) GetNotaryServiceFeePerKey() (int64, error) {
+	return unwrap.Int64(c.invoker.Call(Hash, "getNotaryServiceFeePerKey"))
+}
+
 // LockDepositUntil creates and sends a transaction that extends the deposit lock
 // time for the given account. The return result from the "lockDepositUntil"
 // method is checked to be true, so transaction fails (with FAULT state) if not
@@ -175,6 +199,36 @@ func (c *Contract) SetMaxNotValidBeforeDeltaUnsigned(blocks uint32) (*transactio
 	return c.actor.MakeUnsignedCall(Hash, setMaxNVBDeltaMethod, nil, blocks)
 }
 
+// SetNotaryServiceFeePerKey creates and sends a transaction that sets the new
+// fee paid for every key of the NotaryAssisted transaction attribute. The
+// action is successful when transaction ends in HALT state. Notice that this
+// setting can be changed only by the network's committee, so use an
+// appropriate Actor. The returned values are transaction hash, its
+// ValidUntilBlock value and an error if any.
+func (c *Contract) SetNotaryServiceFeePerKey(fee int64) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(Hash, setFeePKMethod, fee)
+}
+
+// SetNotaryServiceFeePerKeyTransaction creates a transaction that sets the new
+// fee paid for every key of the NotaryAssisted transaction attribute. The
+// action is successful when transaction ends in HALT state. Notice that this
+// setting can be changed only by the network's committee, so use an
+// appropriate Actor. The transaction is signed, but not sent to the network,
+// instead it's returned to the caller.
+func (c *Contract) SetNotaryServiceFeePerKeyTransaction(fee int64) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(Hash, setFeePKMethod, fee)
+}
+
+// SetNotaryServiceFeePerKeyUnsigned creates a transaction that sets the new
+// fee paid for every key of the NotaryAssisted transaction attribute. The
+// action is successful when transaction ends in HALT state. Notice that this
+// setting can be changed only by the network's committee, so use an
+// appropriate Actor. The transaction is not signed and just returned to the
+// caller.
+func (c *Contract) SetNotaryServiceFeePerKeyUnsigned(fee int64) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(Hash, setFeePKMethod, nil, fee)
+}
+
 // Withdraw creates and sends a transaction that withdraws the deposit belonging
 // to "from" account and sends it to "to" account. The return result from the
 // "withdraw" method is checked to be true, so transaction fails (with FAULT
@@ -252,3 +306,54 @@ func (d *OnNEP17PaymentData) FromStackItem(si stackitem.Item) error {
 
 	return nil
 }
+
+// FromStackItem converts provided [stackitem.Array] to
+// MaxNotValidBeforeDeltaChangedEvent or returns an error if it's not possible
+// to do to so.
+func (e *MaxNotValidBeforeDeltaChangedEvent) FromStackItem(item *stackitem.Array) error {
+	oldV, newV, err := changedValuesFromStackItem(item, math.MaxUint32)
+	if err != nil {
+		return err
+	}
+	e.Old, e.New = uint32(oldV), uint32(newV)
+	return nil
+}
+
+// FromStackItem converts provided [stackitem.Array] to
+// NotaryServiceFeePerKeyChangedEvent or returns an error if it's not possible
+// to do to so.
+func (e *NotaryServiceFeePerKeyChangedEvent) FromStackItem(item *stackitem.Array) error {
+	oldV, newV, err := changedValuesFromStackItem(item, math.MaxInt64)
+	if err != nil {
+		return err
+	}
+	e.Old, e.New = oldV, newV
+	return nil
+}
+
+// changedValuesFromStackItem parses Old and New parameters of the Notary
+// contract parameter change events.
+func changedValuesFromStackItem(item *stackitem.Array, maxValue int64) (int64, int64, error) {
+	if item == nil {
+		return 0, 0, errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return 0, 0, errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return 0, 0, errors.New("wrong number of event parameters")
+	}
+	var res [2]int64
+	for i, name := range []string{"Old", "New"} {
+		v, err := arr[i].TryInteger()
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		if !v.IsInt64() || v.Sign() < 0 || v.Int64() > maxValue {
+			return 0, 0, fmt.Errorf("invalid %s: out of range", name)
+		}
+		res[i] = v.Int64()
+	}
+	return res[0], res[1], nil
+}
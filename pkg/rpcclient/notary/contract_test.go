@@ -103,6 +103,26 @@ func TestUint32Getters(t *testing.T) {
 	}
 }
 
+func TestGetNotaryServiceFeePerKey(t *testing.T) {
+	ta := &testAct{}
+	ntr := NewReader(ta)
+
+	ta.err = errors.New("")
+	_, err := ntr.GetNotaryServiceFeePerKey()
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(1000_0000),
+		},
+	}
+	res, err := ntr.GetNotaryServiceFeePerKey()
+	require.NoError(t, err)
+	require.Equal(t, int64(1000_0000), res)
+}
+
 func TestTxSenders(t *testing.T) {
 	ta := new(testAct)
 	ntr := New(ta)
@@ -114,6 +134,9 @@ func TestTxSenders(t *testing.T) {
 		"SetMaxNotValidBeforeDelta": func() (util.Uint256, uint32, error) {
 			return ntr.SetMaxNotValidBeforeDelta(42)
 		},
+		"SetNotaryServiceFeePerKey": func() (util.Uint256, uint32, error) {
+			return ntr.SetNotaryServiceFeePerKey(100500)
+		},
 		"Withdraw": func() (util.Uint256, uint32, error) {
 			return ntr.Withdraw(util.Uint160{1, 2, 3}, util.Uint160{3, 2, 1})
 		},
@@ -151,6 +174,12 @@ func TestTxMakers(t *testing.T) {
 		"SetMaxNotValidBeforeDeltaUnsigned": func() (*transaction.Transaction, error) {
 			return ntr.SetMaxNotValidBeforeDeltaUnsigned(42)
 		},
+		"SetNotaryServiceFeePerKeyTransaction": func() (*transaction.Transaction, error) {
+			return ntr.SetNotaryServiceFeePerKeyTransaction(100500)
+		},
+		"SetNotaryServiceFeePerKeyUnsigned": func() (*transaction.Transaction, error) {
+			return ntr.SetNotaryServiceFeePerKeyUnsigned(100500)
+		},
 		"WithdrawTransaction": func() (*transaction.Transaction, error) {
 			return ntr.WithdrawTransaction(util.Uint160{1, 2, 3}, util.Uint160{3, 2, 1})
 		},
@@ -243,3 +272,37 @@ func TestOnNEP17PaymentData_FromStackItem(t *testing.T) {
 		})
 	}
 }
+
+func TestParameterChangedEvents_FromStackItem(t *testing.T) {
+	for name, tc := range map[string]struct {
+		item  *stackitem.Array
+		delta bool
+		fee   bool
+	}{
+		"nil":           {item: nil},
+		"wrong length":  {item: stackitem.NewArray([]stackitem.Item{stackitem.Make(1)})},
+		"invalid Old":   {item: stackitem.NewArray([]stackitem.Item{stackitem.NewArray(nil), stackitem.Make(1)})},
+		"negative New":  {item: stackitem.NewArray([]stackitem.Item{stackitem.Make(1), stackitem.Make(-1)})},
+		"large for NVB": {item: stackitem.NewArray([]stackitem.Item{stackitem.Make(1), stackitem.Make(int64(math.MaxUint32) + 1)}), fee: true},
+		"good":          {item: stackitem.NewArray([]stackitem.Item{stackitem.Make(140), stackitem.Make(150)}), delta: true, fee: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			delta := new(MaxNotValidBeforeDeltaChangedEvent)
+			err := delta.FromStackItem(tc.item)
+			if tc.delta {
+				require.NoError(t, err)
+				require.Equal(t, MaxNotValidBeforeDeltaChangedEvent{Old: 140, New: 150}, *delta)
+			} else {
+				require.Error(t, err)
+			}
+
+			fee := new(NotaryServiceFeePerKeyChangedEvent)
+			err = fee.FromStackItem(tc.item)
+			if tc.fee {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
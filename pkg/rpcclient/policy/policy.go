@@ -7,12 +7,15 @@ various methods to perform PolicyContract state-changing calls.
 package policy
 
 import (
+	"errors"
+
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
 // Invoker is used by ContractReader to call various methods.
@@ -40,6 +43,8 @@ const (
 	feePerByteSetter   = "setFeePerByte"
 	storagePriceSetter = "setStoragePrice"
 	attributeFeeSetter = "setAttributeFee"
+	featureFlagSetter  = "setFeatureFlag"
+	featureFlagDeleter = "deleteFeatureFlag"
 )
 
 // ContractReader provides an interface to call read-only PolicyContract
@@ -99,6 +104,28 @@ func (c *ContractReader) IsBlocked(account util.Uint160) (bool, error) {
 	return unwrap.Bool(c.invoker.Call(Hash, "isBlocked", account))
 }
 
+// GetFeatureFlag returns the value of the given feature flag and a flag
+// specifying whether it's set. Feature flags are set by the committee to
+// control various node-side behaviors (like relay policies), they're
+// available starting from Echidna hardfork.
+func (c *ContractReader) GetFeatureFlag(name string) (int64, bool, error) {
+	itm, err := unwrap.Item(c.invoker.Call(Hash, "getFeatureFlag", name))
+	if err != nil {
+		return 0, false, err
+	}
+	if _, ok := itm.(stackitem.Null); ok {
+		return 0, false, nil
+	}
+	v, err := itm.TryInteger()
+	if err != nil {
+		return 0, false, err
+	}
+	if !v.IsInt64() {
+		return 0, false, errors.New("int64 overflow")
+	}
+	return v.Int64(), true, nil
+}
+
 // SetExecFeeFactor creates and sends a transaction that sets the new
 // execution fee factor for the network to use. The action is successful when
 // transaction ends in HALT state. The returned values are transaction hash, its
@@ -245,3 +272,47 @@ func unblockScript(account util.Uint160) []byte {
 	script, _ := smartcontract.CreateCallWithAssertScript(Hash, "unblockAccount", account)
 	return script
 }
+
+// SetFeatureFlag creates and sends a transaction that sets the value of the
+// given feature flag. The action is successful when transaction ends in HALT
+// state. The returned values are transaction hash, its ValidUntilBlock value
+// and an error if any.
+func (c *Contract) SetFeatureFlag(name string, value int64) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(Hash, featureFlagSetter, name, value)
+}
+
+// SetFeatureFlagTransaction creates a transaction that sets the value of the
+// given feature flag. This transaction is signed, but not sent to the network,
+// instead it's returned to the caller.
+func (c *Contract) SetFeatureFlagTransaction(name string, value int64) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(Hash, featureFlagSetter, name, value)
+}
+
+// SetFeatureFlagUnsigned creates a transaction that sets the value of the
+// given feature flag. This transaction is not signed and just returned to the
+// caller.
+func (c *Contract) SetFeatureFlagUnsigned(name string, value int64) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(Hash, featureFlagSetter, nil, name, value)
+}
+
+// DeleteFeatureFlag creates and sends a transaction that removes the given
+// feature flag, so that nodes return to their default behavior. The action is
+// successful when transaction ends in HALT state. The returned values are
+// transaction hash, its ValidUntilBlock value and an error if any.
+func (c *Contract) DeleteFeatureFlag(name string) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(Hash, featureFlagDeleter, name)
+}
+
+// DeleteFeatureFlagTransaction creates a transaction that removes the given
+// feature flag. This transaction is signed, but not sent to the network,
+// instead it's returned to the caller.
+func (c *Contract) DeleteFeatureFlagTransaction(name string) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(Hash, featureFlagDeleter, name)
+}
+
+// DeleteFeatureFlagUnsigned creates a transaction that removes the given
+// feature flag. This transaction is not signed and just returned to the
+// caller.
+func (c *Contract) DeleteFeatureFlagUnsigned(name string) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(Hash, featureFlagDeleter, nil, name)
+}
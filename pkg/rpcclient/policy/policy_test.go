@@ -87,6 +87,72 @@ func TestReader(t *testing.T) {
 	require.True(t, val)
 }
 
+func TestGetFeatureFlag(t *testing.T) {
+	ta := new(testAct)
+	pc := NewReader(ta)
+
+	ta.err = errors.New("")
+	_, _, err := pc.GetFeatureFlag("flag")
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Null{}},
+	}
+	_, ok, err := pc.GetFeatureFlag("flag")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ta.res.Stack = []stackitem.Item{stackitem.Make(42)}
+	v, ok, err := pc.GetFeatureFlag("flag")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(42), v)
+
+	ta.res.Stack = []stackitem.Item{stackitem.NewArray(nil)}
+	_, _, err = pc.GetFeatureFlag("flag")
+	require.Error(t, err)
+}
+
+func TestFeatureFlagSetters(t *testing.T) {
+	ta := new(testAct)
+	pc := New(ta)
+
+	ta.err = errors.New("")
+	_, _, err := pc.SetFeatureFlag("flag", 1)
+	require.Error(t, err)
+	_, _, err = pc.DeleteFeatureFlag("flag")
+	require.Error(t, err)
+	_, err = pc.SetFeatureFlagTransaction("flag", 1)
+	require.Error(t, err)
+	_, err = pc.DeleteFeatureFlagUnsigned("flag")
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.txh = util.Uint256{1, 2, 3}
+	ta.vub = 42
+	ta.tx = transaction.New([]byte{1, 2, 3}, 100500)
+	h, vub, err := pc.SetFeatureFlag("flag", 1)
+	require.NoError(t, err)
+	require.Equal(t, ta.txh, h)
+	require.Equal(t, ta.vub, vub)
+	h, vub, err = pc.DeleteFeatureFlag("flag")
+	require.NoError(t, err)
+	require.Equal(t, ta.txh, h)
+	require.Equal(t, ta.vub, vub)
+	for _, m := range []func() (*transaction.Transaction, error){
+		func() (*transaction.Transaction, error) { return pc.SetFeatureFlagTransaction("flag", 1) },
+		func() (*transaction.Transaction, error) { return pc.SetFeatureFlagUnsigned("flag", 1) },
+		func() (*transaction.Transaction, error) { return pc.DeleteFeatureFlagTransaction("flag") },
+		func() (*transaction.Transaction, error) { return pc.DeleteFeatureFlagUnsigned("flag") },
+	} {
+		tx, err := m()
+		require.NoError(t, err)
+		require.Equal(t, ta.tx, tx)
+	}
+}
+
 func TestIntSetters(t *testing.T) {
 	ta := new(testAct)
 	pc := New(ta)
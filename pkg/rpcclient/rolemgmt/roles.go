@@ -7,13 +7,21 @@ various methods to perform the only RoleManagement state-changing call.
 package rolemgmt
 
 import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math"
+
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
 // Invoker is used by ContractReader to call various methods.
@@ -33,7 +41,13 @@ type Actor interface {
 // Hash stores the hash of the native RoleManagement contract.
 var Hash = nativehashes.RoleManagement
 
-const designateMethod = "designateAsRole"
+const (
+	designateMethod = "designateAsRole"
+
+	// DesignationEventName is the name of the event emitted by RoleManagement
+	// contract on every designation.
+	DesignationEventName = "Designation"
+)
 
 // ContractReader provides an interface to call read-only RoleManagement
 // contract's methods.
@@ -50,10 +64,14 @@ type Contract struct {
 }
 
 // DesignationEvent represents an event emitted by RoleManagement contract when
-// a new role designation is done.
+// a new role designation is done. New designation is effective starting from
+// BlockIndex+1. Old and New are only emitted starting from Echidna hardfork,
+// they're nil for older events.
 type DesignationEvent struct {
 	Role       noderoles.Role
 	BlockIndex uint32
+	Old        keys.PublicKeys
+	New        keys.PublicKeys
 }
 
 // NewReader creates an instance of ContractReader that can be used to read
@@ -77,6 +95,21 @@ func (c *ContractReader) GetDesignatedByRole(role noderoles.Role, index uint32)
 	return unwrap.ArrayOfPublicKeys(c.invoker.Call(Hash, "getDesignatedByRole", int64(role), index))
 }
 
+// GetDesignationHeight returns the height starting from which the keys
+// designated for the given role at the given height are effective. Zero is
+// returned if there are no keys designated for this role/height. This method
+// is available starting from Echidna hardfork.
+func (c *ContractReader) GetDesignationHeight(role noderoles.Role, index uint32) (uint32, error) {
+	h, err := unwrap.Int64(c.invoker.Call(Hash, "getDesignationHeight", int64(role), index))
+	if err != nil {
+		return 0, err
+	}
+	if h < 0 || h > math.MaxUint32 {
+		return 0, fmt.Errorf("invalid height: %d", h)
+	}
+	return uint32(h), nil
+}
+
 // DesignateAsRole creates and sends a transaction that sets the keys used for
 // the given node role. The action is successful when transaction ends in HALT
 // state. The returned values are transaction hash, its ValidUntilBlock value
@@ -98,3 +131,122 @@ func (c *Contract) DesignateAsRoleTransaction(role noderoles.Role, pubs keys.Pub
 func (c *Contract) DesignateAsRoleUnsigned(role noderoles.Role, pubs keys.PublicKeys) (*transaction.Transaction, error) {
 	return c.actor.MakeUnsignedCall(Hash, designateMethod, nil, int(role), pubs)
 }
+
+// NewDesignationFilter returns a notification filter matching designation
+// events only, it can be used to subscribe to role changes via WSClient's
+// ReceiveExecutionNotifications. Events for all roles are
+// delivered, use [DesignationEvent.FromNotification] to decode them and check
+// the role.
+func NewDesignationFilter() *neorpc.NotificationFilter {
+	var (
+		h    = Hash
+		name = DesignationEventName
+	)
+	return &neorpc.NotificationFilter{Contract: &h, Name: &name}
+}
+
+// DesignationEventsFromApplicationLog retrieves all emitted DesignationEvents
+// from the provided [result.ApplicationLog].
+func DesignationEventsFromApplicationLog(log *result.ApplicationLog) ([]*DesignationEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+	var res []*DesignationEvent
+	for i, ex := range log.Executions {
+		for j := range ex.Events {
+			if !isDesignation(&ex.Events[j]) {
+				continue
+			}
+			event := new(DesignationEvent)
+			err := event.FromStackItem(ex.Events[j].Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode event from stackitem (event #%d, execution #%d): %w", j, i, err)
+			}
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// FromNotification converts provided [state.NotificationEvent] to
+// DesignationEvent or returns an error if it's not a RoleManagement
+// designation event or it can't be decoded.
+func (e *DesignationEvent) FromNotification(ntf *state.NotificationEvent) error {
+	if ntf == nil {
+		return errors.New("nil notification")
+	}
+	if !isDesignation(ntf) {
+		return fmt.Errorf("not a designation event: %s from %s", ntf.Name, ntf.ScriptHash.StringLE())
+	}
+	return e.FromStackItem(ntf.Item)
+}
+
+func isDesignation(ntf *state.NotificationEvent) bool {
+	return ntf.ScriptHash.Equals(Hash) && ntf.Name == DesignationEventName
+}
+
+// FromStackItem converts provided [stackitem.Array] to DesignationEvent or
+// returns an error if it's not possible to do to so.
+func (e *DesignationEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 && len(arr) != 4 {
+		return errors.New("wrong number of event parameters")
+	}
+
+	r, err := arr[0].TryInteger()
+	if err != nil {
+		return fmt.Errorf("invalid Role: %w", err)
+	}
+	if !r.IsUint64() || r.Uint64() > math.MaxUint8 {
+		return errors.New("invalid Role: out of range")
+	}
+	e.Role = noderoles.Role(r.Uint64())
+
+	ind, err := arr[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("invalid BlockIndex: %w", err)
+	}
+	if !ind.IsUint64() || ind.Uint64() > math.MaxUint32 {
+		return errors.New("invalid BlockIndex: out of range")
+	}
+	e.BlockIndex = uint32(ind.Uint64())
+
+	e.Old, e.New = nil, nil
+	if len(arr) == 2 {
+		return nil
+	}
+	e.Old, err = keysFromStackItem(arr[2])
+	if err != nil {
+		return fmt.Errorf("invalid Old: %w", err)
+	}
+	e.New, err = keysFromStackItem(arr[3])
+	if err != nil {
+		return fmt.Errorf("invalid New: %w", err)
+	}
+	return nil
+}
+
+func keysFromStackItem(item stackitem.Item) (keys.PublicKeys, error) {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return nil, errors.New("not an array")
+	}
+	res := make(keys.PublicKeys, len(arr))
+	for i := range arr {
+		b, err := arr[i].TryBytes()
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		res[i], err = keys.NewPublicKeyFromBytes(b, elliptic.P256())
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
@@ -149,3 +150,108 @@ func TestDesignateAsRoleTransaction(t *testing.T) {
 		require.Equal(t, ta.tx, tx)
 	}
 }
+
+func TestReaderGetDesignationHeight(t *testing.T) {
+	ta := new(testAct)
+	rc := NewReader(ta)
+
+	ta.err = errors.New("")
+	_, err := rc.GetDesignationHeight(noderoles.Oracle, 0)
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(-1),
+		},
+	}
+	_, err = rc.GetDesignationHeight(noderoles.Oracle, 0)
+	require.Error(t, err)
+
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(42),
+		},
+	}
+	h, err := rc.GetDesignationHeight(noderoles.Oracle, 100)
+	require.NoError(t, err)
+	require.Equal(t, uint32(42), h)
+}
+
+func TestDesignationEvent_FromStackItem(t *testing.T) {
+	k1, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	k2, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	var e DesignationEvent
+	require.Error(t, e.FromStackItem(nil))
+	require.Error(t, e.FromStackItem(stackitem.NewArray([]stackitem.Item{stackitem.Make(4)})))
+	require.Error(t, e.FromStackItem(stackitem.NewArray([]stackitem.Item{stackitem.Make(256), stackitem.Make(1)})))
+	require.Error(t, e.FromStackItem(stackitem.NewArray([]stackitem.Item{stackitem.Make(4), stackitem.Make(-1)})))
+	require.Error(t, e.FromStackItem(stackitem.NewArray([]stackitem.Item{
+		stackitem.Make(4), stackitem.Make(1), stackitem.Make([]stackitem.Item{stackitem.Make([]byte{1})}), stackitem.Make([]stackitem.Item{}),
+	})))
+
+	require.NoError(t, e.FromStackItem(stackitem.NewArray([]stackitem.Item{stackitem.Make(4), stackitem.Make(10)})))
+	require.Equal(t, DesignationEvent{Role: noderoles.StateValidator, BlockIndex: 10}, e)
+
+	require.NoError(t, e.FromStackItem(stackitem.NewArray([]stackitem.Item{
+		stackitem.Make(8),
+		stackitem.Make(11),
+		stackitem.Make([]stackitem.Item{stackitem.Make(k1.PublicKey().Bytes())}),
+		stackitem.Make([]stackitem.Item{stackitem.Make(k1.PublicKey().Bytes()), stackitem.Make(k2.PublicKey().Bytes())}),
+	})))
+	require.Equal(t, DesignationEvent{
+		Role:       noderoles.Oracle,
+		BlockIndex: 11,
+		Old:        keys.PublicKeys{k1.PublicKey()},
+		New:        keys.PublicKeys{k1.PublicKey(), k2.PublicKey()},
+	}, e)
+}
+
+func TestNewDesignationFilter(t *testing.T) {
+	f := NewDesignationFilter()
+	require.NoError(t, f.IsValid())
+	require.Equal(t, Hash, *f.Contract)
+	require.Equal(t, DesignationEventName, *f.Name)
+}
+
+func TestDesignationEvent_FromNotification(t *testing.T) {
+	var (
+		e    DesignationEvent
+		item = stackitem.NewArray([]stackitem.Item{stackitem.Make(8), stackitem.Make(10)})
+	)
+	require.Error(t, e.FromNotification(nil))
+	require.Error(t, e.FromNotification(&state.NotificationEvent{ScriptHash: util.Uint160{1}, Name: DesignationEventName, Item: item}))
+	require.Error(t, e.FromNotification(&state.NotificationEvent{ScriptHash: Hash, Name: "Transfer", Item: item}))
+	require.Error(t, e.FromNotification(&state.NotificationEvent{ScriptHash: Hash, Name: DesignationEventName, Item: stackitem.NewArray(nil)}))
+
+	require.NoError(t, e.FromNotification(&state.NotificationEvent{ScriptHash: Hash, Name: DesignationEventName, Item: item}))
+	require.Equal(t, DesignationEvent{Role: noderoles.Oracle, BlockIndex: 10}, e)
+}
+
+func TestDesignationEventsFromApplicationLog(t *testing.T) {
+	_, err := DesignationEventsFromApplicationLog(nil)
+	require.Error(t, err)
+
+	log := &result.ApplicationLog{Executions: []state.Execution{{
+		Events: []state.NotificationEvent{
+			{ScriptHash: Hash, Name: DesignationEventName, Item: stackitem.NewArray([]stackitem.Item{stackitem.Make(4), stackitem.Make(10)})},
+			{ScriptHash: util.Uint160{1}, Name: DesignationEventName, Item: stackitem.NewArray(nil)},
+			{ScriptHash: Hash, Name: DesignationEventName, Item: stackitem.NewArray([]stackitem.Item{stackitem.Make(8), stackitem.Make(11)})},
+		},
+	}}}
+	res, err := DesignationEventsFromApplicationLog(log)
+	require.NoError(t, err)
+	require.Equal(t, []*DesignationEvent{
+		{Role: noderoles.StateValidator, BlockIndex: 10},
+		{Role: noderoles.Oracle, BlockIndex: 11},
+	}, res)
+
+	log.Executions[0].Events[1].ScriptHash = Hash
+	_, err = DesignationEventsFromApplicationLog(log)
+	require.Error(t, err)
+}
@@ -264,6 +264,30 @@ func (c *Client) getContractState(param any) (*state.Contract, error) {
 	return resp, nil
 }
 
+// GetContractStats returns per-contract execution statistics for the last
+// completed statistics period. It's only supported by NeoGo servers with
+// statistics collection enabled.
+func (c *Client) GetContractStats() (*result.ContractStats, error) {
+	var resp = new(result.ContractStats)
+
+	if err := c.performRequest("getcontractstats", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetValidatorStats returns block production statistics of validators for
+// the latest blocks. It's only supported by NeoGo servers with statistics
+// collection enabled.
+func (c *Client) GetValidatorStats() (*result.ValidatorStats, error) {
+	var resp = new(result.ValidatorStats)
+
+	if err := c.performRequest("getvalidatorstats", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetNativeContracts queries information about native contracts.
 func (c *Client) GetNativeContracts() ([]state.Contract, error) {
 	var resp []state.Contract
@@ -281,6 +305,27 @@ func (c *Client) GetNativeContracts() ([]state.Contract, error) {
 	return resp, nil
 }
 
+// GetNativeContractsByHeight queries information about native contracts
+// active at the given block height.
+func (c *Client) GetNativeContractsByHeight(height uint32) ([]state.Contract, error) {
+	var resp []state.Contract
+	if err := c.performRequest("getnativecontracts", []any{height}, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNativeContractsByHardfork queries information about native contracts
+// active since the given hardfork (by its name) activation. The hardfork must
+// be enabled in the server's protocol configuration.
+func (c *Client) GetNativeContractsByHardfork(hf string) ([]state.Contract, error) {
+	var resp []state.Contract
+	if err := c.performRequest("getnativecontracts", []any{hf}, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetNEP11Balances is a wrapper for getnep11balances RPC.
 func (c *Client) GetNEP11Balances(address util.Uint160) (*result.NEP11Balances, error) {
 	params := []any{address.StringLE()}
@@ -387,6 +432,18 @@ func (c *Client) GetNEP17Transfers(address util.Uint160, start, stop *uint64, li
 	return resp, nil
 }
 
+// GetConsensusState returns the snapshot of the current consensus state of
+// the node. It's only available if the node runs consensus service and its
+// RPC server is configured to expose this data (ConsensusStateEnabled).
+func (c *Client) GetConsensusState() (*result.ConsensusState, error) {
+	var resp = new(result.ConsensusState)
+
+	if err := c.performRequest("getconsensusstate", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetPeers returns a list of the nodes that the node is currently connected to/disconnected from.
 func (c *Client) GetPeers() (*result.GetPeers, error) {
 	var resp = &result.GetPeers{}
@@ -505,6 +562,72 @@ func (c *Client) FindStates(stateroot util.Uint256, historicalContractHash util.
 	return resp, nil
 }
 
+// FindStatesBackwards is similar to FindStates, but it returns items in the
+// reverse order, so the last `maxCount` items can be retrieved efficiently. If
+// `start` path is specified, items preceding it in the normal order are
+// returned (excluding item located at the start path). It's a NeoGo
+// extension.
+func (c *Client) FindStatesBackwards(stateroot util.Uint256, historicalContractHash util.Uint160, historicalPrefix []byte,
+	start []byte, maxCount int) (result.FindStates, error) {
+	if historicalPrefix == nil {
+		historicalPrefix = []byte{}
+	}
+	if start == nil {
+		start = []byte{}
+	}
+	var (
+		params = []any{stateroot.StringLE(), historicalContractHash.StringLE(), historicalPrefix, start, maxCount, true}
+		resp   result.FindStates
+	)
+	if err := c.performRequest("findstates", params, &resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// GetRangeProof returns historical contract storage items matching the given
+// historical prefix along with the proof that the result contains all such
+// items for the given stateroot. If `from` key is specified, only items located
+// after it are returned (it must include the prefix). If `maxCount` is specified,
+// the maximum number of items to be returned equals to `maxCount`. The proof can
+// be checked locally with mpt.VerifyRangeProof or via VerifyRangeProof.
+func (c *Client) GetRangeProof(stateroot util.Uint256, historicalContractHash util.Uint160, historicalPrefix []byte,
+	from []byte, maxCount *int) (*result.GetRangeProof, error) {
+	if historicalPrefix == nil {
+		historicalPrefix = []byte{}
+	}
+	var (
+		params = []any{stateroot.StringLE(), historicalContractHash.StringLE(), historicalPrefix}
+		resp   = new(result.GetRangeProof)
+	)
+	if from == nil && maxCount != nil {
+		from = []byte{}
+	}
+	if from != nil {
+		params = append(params, from)
+	}
+	if maxCount != nil {
+		params = append(params, *maxCount)
+	}
+	if err := c.performRequest("getrangeproof", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// VerifyRangeProof returns storage items proven by the given range proof for
+// the given stateroot.
+func (c *Client) VerifyRangeProof(stateroot util.Uint256, proof *result.RangeProof) ([]result.KeyValue, error) {
+	var (
+		params = []any{stateroot.StringLE(), proof.String()}
+		resp   result.VerifyRangeProof
+	)
+	if err := c.performRequest("verifyrangeproof", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
 // GetStateRootByHeight returns the state root for the specified height.
 func (c *Client) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
 	return c.getStateRoot(height)
@@ -551,6 +674,28 @@ func (c *Client) getStorage(params []any) ([]byte, error) {
 	return resp, nil
 }
 
+// GetStorageBatchByID returns the values stored by the given keys of the
+// contract with the given ID. The result has the same order as keys, Value
+// is nil for missing items. The number of keys is limited by the server
+// (MaxFindStoragePageSize setting).
+func (c *Client) GetStorageBatchByID(id int32, keys [][]byte) ([]result.KeyValue, error) {
+	return c.getStorageBatch([]any{id, keys})
+}
+
+// GetStorageBatchByHash is the same as GetStorageBatchByID, but accepts
+// contract script hash.
+func (c *Client) GetStorageBatchByHash(hash util.Uint160, keys [][]byte) ([]result.KeyValue, error) {
+	return c.getStorageBatch([]any{hash.StringLE(), keys})
+}
+
+func (c *Client) getStorageBatch(params []any) ([]result.KeyValue, error) {
+	var resp []result.KeyValue
+	if err := c.performRequest("getstoragebatch", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetStorageByIDHistoric returns the historical stored value according to the
 // contract ID and, stored key and specified stateroot.
 func (c *Client) GetStorageByIDHistoric(root util.Uint256, id int32, key []byte) ([]byte, error) {
@@ -657,6 +802,20 @@ func (c *Client) GetTransactionHeight(hash util.Uint256) (uint32, error) {
 	return resp, nil
 }
 
+// GetTransactionProof returns a proof of the transaction inclusion into the
+// block it's persisted in. The proof can be checked against the block's
+// MerkleRoot with merkle.VerifyProof. It's only supported by NeoGo servers.
+func (c *Client) GetTransactionProof(hash util.Uint256) (*result.TransactionProof, error) {
+	var (
+		params = []any{hash.StringLE()}
+		resp   = new(result.TransactionProof)
+	)
+	if err := c.performRequest("gettransactionproof", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetUnclaimedGas returns the unclaimed GAS amount for the specified address.
 func (c *Client) GetUnclaimedGas(address string) (result.UnclaimedGas, error) {
 	var (
@@ -669,6 +828,28 @@ func (c *Client) GetUnclaimedGas(address string) (result.UnclaimedGas, error) {
 	return resp, nil
 }
 
+// GetAccountStates returns NEO account states of the given accounts along
+// with GAS amounts they can claim at the next block. It's a neo-go extension,
+// the number of accounts is limited by the server (100 by default).
+func (c *Client) GetAccountStates(accounts []util.Uint160) ([]result.AccountState, error) {
+	return c.getAccountStates([]any{accounts})
+}
+
+// GetAccountStatesAt is the same as GetAccountStates, but the GAS amounts
+// are projected to the given height (which can't be lower than the next block
+// height) assuming the current GAS per block value.
+func (c *Client) GetAccountStatesAt(accounts []util.Uint160, height uint32) ([]result.AccountState, error) {
+	return c.getAccountStates([]any{accounts, height})
+}
+
+func (c *Client) getAccountStates(params []any) ([]result.AccountState, error) {
+	var resp []result.AccountState
+	if err := c.performRequest("getaccountstates", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetCandidates returns the current list of NEO candidate node with voting data and
 // validator status.
 func (c *Client) GetCandidates() ([]result.Candidate, error) {
@@ -707,6 +888,25 @@ func (c *Client) InvokeScript(script []byte, signers []transaction.Signer) (*res
 	return c.invokeSomething("invokescript", p, signers)
 }
 
+// InvokeScriptWithDiagnostics is similar to InvokeScript, but it also returns
+// invocation diagnostics including storage changes made by the script and
+// contracts invoked by it.
+// NOTE: This is a test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptWithDiagnostics(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	var resp = new(result.Invoke)
+	if signers == nil {
+		signers = []transaction.Signer{}
+	}
+	p, err := appendSigners([]any{script}, signers, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.performRequest("invokescript", append(p, true), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // InvokeScriptAtHeight returns the result of the given script after running it
 // true the VM using the provided chain state retrieved from the specified chain
 // height.
@@ -716,6 +916,27 @@ func (c *Client) InvokeScriptAtHeight(height uint32, script []byte, signers []tr
 	return c.invokeSomething("invokescripthistoric", p, signers)
 }
 
+// InvokeScriptSteps executes the given number of instructions of the given
+// script (see InvokeScript) and pauses the invocation. Unless the execution is
+// finished, the result has BREAK state and contains a checkpoint that can be
+// passed to ResumeInvocation to continue it. This method is a NeoGo extension.
+// NOTE: This is a test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptSteps(script []byte, steps int, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = []any{script, steps}
+	return c.invokeSomething("invokescriptsteps", p, signers)
+}
+
+// ResumeInvocation restores the invocation paused at the given checkpoint
+// (see InvokeScriptSteps) and executes the given number of instructions more.
+// The invocation is performed against the same chain state it was started at,
+// so it requires historic states to be kept by the node if the chain moves on.
+// This method is a NeoGo extension.
+// NOTE: This is a test invoke and will not affect the blockchain.
+func (c *Client) ResumeInvocation(checkpoint []byte, steps int) (*result.Invoke, error) {
+	var p = []any{checkpoint, steps}
+	return c.invokeSomething("resumeinvocation", p, nil)
+}
+
 // InvokeScriptWithState returns the result of the given script after running it
 // true the VM using the provided chain state retrieved from the specified
 // state root or block hash.
@@ -759,6 +980,27 @@ func (c *Client) InvokeContractVerify(contract util.Uint160, params []smartcontr
 	return c.invokeSomething("invokecontractverify", p, signers, witnesses...)
 }
 
+// InvokeContractVerifyWithDiagnostics is similar to InvokeContractVerify, but
+// it also returns invocation diagnostics including GAS consumed by every called
+// contract, the failed instruction (if any) and the network fee required for
+// the contract witness with the given parameters. If signers are nil, the
+// contract is used as the only signer.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeContractVerifyWithDiagnostics(contract util.Uint160, params []smartcontract.Parameter, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
+	var resp = new(result.Invoke)
+	if signers == nil {
+		signers = []transaction.Signer{{Account: contract}}
+	}
+	p, err := appendSigners([]any{contract.StringLE(), params}, signers, witnesses)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.performRequest("invokecontractverify", append(p, true), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // InvokeContractVerifyAtHeight returns the results after calling `verify` method
 // of the smart contract with the given parameters under verification trigger type
 // at the blockchain state specified by the blockchain height.
@@ -777,32 +1019,74 @@ func (c *Client) InvokeContractVerifyWithState(stateOrBlock util.Uint256, contra
 	return c.invokeSomething("invokecontractverifyhistoric", p, signers, witnesses...)
 }
 
+// InvokeContainedTransaction returns the result of the given script after
+// running it through the VM using the given (possibly unsigned) transaction as a
+// script container. If script is nil, the transaction script is executed.
+// Witness checks are performed against the transaction signers and their
+// scopes, signatures are not verified, so the transaction doesn't need to
+// be signed. Missing witnesses are filled with empty ones.
+// NOTE: This is a test invoke and will not affect the blockchain.
+func (c *Client) InvokeContainedTransaction(tx *transaction.Transaction, script []byte) (*result.Invoke, error) {
+	if len(tx.Scripts) != len(tx.Signers) {
+		tx = tx.Copy()
+		tx.Scripts = make([]transaction.Witness, len(tx.Signers))
+	}
+	var p = []any{tx.Bytes()}
+	if script != nil {
+		p = append(p, script)
+	}
+	return c.invokeSomething("invokecontainedscript", p, nil)
+}
+
+// InvokeContainedBlock returns the result of the given script after running it
+// through the VM using the given (possibly unsigned) block as a script container.
+// The block must be the next one after the current chain height. Witness checks
+// are performed against the previous block's NextConsensus.
+// NOTE: This is a test invoke and will not affect the blockchain.
+func (c *Client) InvokeContainedBlock(b *block.Block, script []byte) (*result.Invoke, error) {
+	buf := io.NewBufBinWriter()
+	b.EncodeBinary(buf.BinWriter)
+	if buf.Err != nil {
+		return nil, buf.Err
+	}
+	return c.invokeSomething("invokecontainedscript", []any{buf.Bytes(), script}, nil)
+}
+
 // invokeSomething is an inner wrapper for Invoke* functions.
 func (c *Client) invokeSomething(method string, p []any, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
 	var resp = new(result.Invoke)
-	if signers != nil {
-		if witnesses == nil {
-			p = append(p, signers)
-		} else {
-			if len(witnesses) != len(signers) {
-				return nil, fmt.Errorf("number of witnesses should match number of signers, got %d vs %d", len(witnesses), len(signers))
-			}
-			signersWithWitnesses := make([]neorpc.SignerWithWitness, len(signers))
-			for i := range signersWithWitnesses {
-				signersWithWitnesses[i] = neorpc.SignerWithWitness{
-					Signer:  signers[i],
-					Witness: witnesses[i],
-				}
-			}
-			p = append(p, signersWithWitnesses)
-		}
+	p, err := appendSigners(p, signers, witnesses)
+	if err != nil {
+		return nil, err
 	}
-	if err := c.performRequest(method, p, resp); err != nil {
+	if err = c.performRequest(method, p, resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
+// appendSigners appends signers (with witnesses if any) to the invocation
+// parameters if they're not nil.
+func appendSigners(p []any, signers []transaction.Signer, witnesses []transaction.Witness) ([]any, error) {
+	if signers == nil {
+		return p, nil
+	}
+	if witnesses == nil {
+		return append(p, signers), nil
+	}
+	if len(witnesses) != len(signers) {
+		return nil, fmt.Errorf("number of witnesses should match number of signers, got %d vs %d", len(witnesses), len(signers))
+	}
+	signersWithWitnesses := make([]neorpc.SignerWithWitness, len(signers))
+	for i := range signersWithWitnesses {
+		signersWithWitnesses[i] = neorpc.SignerWithWitness{
+			Signer:  signers[i],
+			Witness: witnesses[i],
+		}
+	}
+	return append(p, signersWithWitnesses), nil
+}
+
 // SendRawTransaction broadcasts the given transaction to the Neo network.
 // It always returns transaction hash, when successful (no error) this is the
 // hash returned from server, when not it's a locally calculated rawTX hash.
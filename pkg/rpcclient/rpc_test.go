@@ -596,6 +596,37 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getconsensusstate": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetConsensusState()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"height":10,"view":1,"index":0,"primary":1,"nextprimary":0,"m":1,"preparations":1,"commits":0,"changeviews":1,"missingtransactions":2,"timerdeadline":1700000000000,"validators":[{"publickey":"02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e","prepared":true,"committed":false,"changeview":true,"lastseen":{"height":10,"view":1}}]}}`,
+			result: func(c *Client) any {
+				pub, err := keys.NewPublicKeyFromString("02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e")
+				if err != nil {
+					panic(err)
+				}
+				return &result.ConsensusState{
+					Height:              10,
+					View:                1,
+					Primary:             1,
+					M:                   1,
+					Preparations:        1,
+					ChangeViews:         1,
+					MissingTransactions: 2,
+					TimerDeadline:       1700000000000,
+					Validators: []result.ConsensusValidator{{
+						PublicKey:  pub,
+						Prepared:   true,
+						ChangeView: true,
+						LastSeen:   &result.ConsensusHeightView{Height: 10, View: 1},
+					}},
+				}
+			},
+		},
+	},
 	"getrawmempool": {
 		{
 			name: "positive",
@@ -785,6 +816,21 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive, backwards",
+			invoke: func(c *Client) (any, error) {
+				root, _ := util.Uint256DecodeStringLE("252e9d73d49c95c7618d40650da504e05183a1b2eed0685e42c360413c329170")
+				cHash, _ := util.Uint160DecodeStringLE("5c9e40a12055c6b9e3f72271c9779958c842135d")
+				return c.FindStatesBackwards(root, cHash, []byte("aa"), nil, 1)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"results":[{"key":"YWE=","value":"djE="}],"truncated":true}}`,
+			result: func(c *Client) any {
+				return result.FindStates{
+					Results:   []result.KeyValue{{Key: []byte("aa"), Value: []byte("v1")}},
+					Truncated: true,
+				}
+			},
+		},
 	},
 	"findstorage": {
 		{
@@ -869,6 +915,52 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getaccountstates": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetAccountStates([]util.Uint160{{1, 2, 3}})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[{"address":"NMipL5VsNoLUBUJKPKLhxaEbPQVCZnyJyB","balance":"100","balanceheight":5,"voteto":"03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c","unclaimed":"11500"}]}`,
+			result: func(c *Client) any {
+				pub, _ := keys.NewPublicKeyFromString("03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c")
+				addr, _ := address.StringToUint160("NMipL5VsNoLUBUJKPKLhxaEbPQVCZnyJyB")
+				return []result.AccountState{{
+					Address:       addr,
+					Balance:       *big.NewInt(100),
+					BalanceHeight: 5,
+					VoteTo:        pub,
+					Unclaimed:     *big.NewInt(11500),
+				}}
+			},
+		},
+		{
+			name: "projected, no account",
+			invoke: func(c *Client) (any, error) {
+				return c.GetAccountStatesAt([]util.Uint160{{1, 2, 3}}, 100)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[{"address":"NMipL5VsNoLUBUJKPKLhxaEbPQVCZnyJyB","balance":"0","balanceheight":0,"voteto":null,"unclaimed":"0"}]}`,
+			result: func(c *Client) any {
+				addr, _ := address.StringToUint160("NMipL5VsNoLUBUJKPKLhxaEbPQVCZnyJyB")
+				return []result.AccountState{{Address: addr}}
+			},
+		},
+	},
+	"getstoragebatch": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetStorageBatchByID(-1, [][]byte{[]byte("Peter"), []byte("Paul")})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[{"key":"UGV0ZXI=","value":"TGlu"},{"key":"UGF1bA==","value":null}]}`,
+			result: func(c *Client) any {
+				return []result.KeyValue{
+					{Key: []byte("Peter"), Value: []byte("Lin")},
+					{Key: []byte("Paul")},
+				}
+			},
+		},
+	},
 	"getstorage": {
 		{
 			name: "by hash, positive",
@@ -971,6 +1063,34 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"gettransactionproof": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				hash, err := util.Uint256DecodeStringLE("cb6ddb5f99d6af4c94a6c396d5294472f2eebc91a2c933e0f527422296fa9fb2")
+				if err != nil {
+					panic(err)
+				}
+				return c.GetTransactionProof(hash)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"blockhash":"0x7c0a5b2a8e5e5a8dcbd0d7d1b2e7cc6ba9a3ff7b8d77ed7b3a1f6b7e1d2c3b4a","blockindex":2,"merkleroot":"0x1ab8bfa1e7e2bdd1c85a7c1e0c2a0c6e0d3e1e5f5b1f2e4d0f7b1a9e8d6c4b2a","index":1,"txcount":2,"proof":["0xcb6ddb5f99d6af4c94a6c396d5294472f2eebc91a2c933e0f527422296fa9fb3"]}}`,
+			result: func(c *Client) any {
+				var (
+					bh, _ = util.Uint256DecodeStringLE("7c0a5b2a8e5e5a8dcbd0d7d1b2e7cc6ba9a3ff7b8d77ed7b3a1f6b7e1d2c3b4a")
+					mr, _ = util.Uint256DecodeStringLE("1ab8bfa1e7e2bdd1c85a7c1e0c2a0c6e0d3e1e5f5b1f2e4d0f7b1a9e8d6c4b2a")
+					p, _  = util.Uint256DecodeStringLE("cb6ddb5f99d6af4c94a6c396d5294472f2eebc91a2c933e0f527422296fa9fb3")
+				)
+				return &result.TransactionProof{
+					BlockHash:  bh,
+					BlockIndex: 2,
+					MerkleRoot: mr,
+					Index:      1,
+					TxCount:    2,
+					Proof:      []util.Uint256{p},
+				}
+			},
+		},
+	},
 	"getunclaimedgas": {
 		{
 			name: "positive",
@@ -1005,6 +1125,56 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getcontractstats": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetContractStats()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"startblock":10,"endblock":19,"contracts":[{"hash":"0xef4073a0f2b305a38ec4050e4d3d28bc40ea63f5","calls":5,"executions":3,"faults":1,"gasconsumed":"1234567"}]}}`,
+			result: func(c *Client) any {
+				h, err := util.Uint160DecodeStringLE("ef4073a0f2b305a38ec4050e4d3d28bc40ea63f5")
+				if err != nil {
+					panic(err)
+				}
+				return &result.ContractStats{
+					StartBlock: 10,
+					EndBlock:   19,
+					Contracts: []result.ContractStatsItem{{
+						Hash:        h,
+						Calls:       5,
+						Executions:  3,
+						Faults:      1,
+						GasConsumed: 1234567,
+					}},
+				}
+			},
+		},
+	},
+	"getvalidatorstats": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetValidatorStats()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"startblock":10,"endblock":19,"validators":[{"publickey":"02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e","produced":7,"missed":2}]}}`,
+			result: func(c *Client) any {
+				pub, err := keys.NewPublicKeyFromString("02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e")
+				if err != nil {
+					panic(err)
+				}
+				return &result.ValidatorStats{
+					StartBlock: 10,
+					EndBlock:   19,
+					Validators: []result.ValidatorStatsItem{{
+						PublicKey: *pub,
+						Produced:  7,
+						Missed:    2,
+					}},
+				}
+			},
+		},
+	},
 	"getvalidators": {
 		{
 			name: "positive",
@@ -0,0 +1,108 @@
+package waiter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Status is the outcome of transaction awaiting.
+type Status byte
+
+const (
+	// StatusFailed means that awaiting failed for some other reason (like
+	// transaction sending or RPC communication error).
+	StatusFailed Status = iota
+	// StatusAccepted means that transaction was accepted to the chain (and
+	// got the required number of confirmations).
+	StatusAccepted
+	// StatusExpired means that transaction wasn't accepted to the chain
+	// before its ValidUntilBlock.
+	StatusExpired
+	// StatusDropped means that transaction was seen in the chain, but then
+	// dropped from it.
+	StatusDropped
+	// StatusTimedOut means that awaiting deadline was exceeded before the
+	// transaction was accepted or expired.
+	StatusTimedOut
+	// StatusCancelled means that awaiting context was cancelled before the
+	// transaction was accepted or expired.
+	StatusCancelled
+	// StatusUnsupported means that Waiter doesn't support awaiting.
+	StatusUnsupported
+)
+
+// Result is a detailed transaction awaiting result.
+type Result struct {
+	// Status is the awaiting outcome.
+	Status Status
+	// AppExecResult is the execution result of the accepted transaction,
+	// it's only set for StatusAccepted.
+	AppExecResult *state.AppExecResult
+	// Err is the error returned by Waiter, it's nil for StatusAccepted.
+	Err error
+}
+
+// String implements fmt.Stringer interface.
+func (s Status) String() string {
+	switch s {
+	case StatusFailed:
+		return "failed"
+	case StatusAccepted:
+		return "accepted"
+	case StatusExpired:
+		return "expired"
+	case StatusDropped:
+		return "dropped"
+	case StatusTimedOut:
+		return "timed out"
+	case StatusCancelled:
+		return "cancelled"
+	case StatusUnsupported:
+		return "unsupported"
+	default:
+		return "unknown"
+	}
+}
+
+// Await is similar to [Waiter.Wait], but returns detailed Result instead of
+// the execution result and error pair.
+func Await(w Waiter, h util.Uint256, vub uint32, err error) *Result {
+	if err != nil && !errIsAlreadyExists(err) {
+		return &Result{Status: StatusFailed, Err: err}
+	}
+	return AwaitAny(context.TODO(), w, vub, h)
+}
+
+// AwaitAny is similar to [Waiter.WaitAny], but returns detailed Result
+// instead of the execution result and error pair.
+func AwaitAny(ctx context.Context, w Waiter, vub uint32, hashes ...util.Uint256) *Result {
+	aer, err := w.WaitAny(ctx, vub, hashes...)
+	return NewResult(aer, err)
+}
+
+// NewResult creates Result from the execution result and error returned by
+// Waiter, error is used to determine the Status.
+func NewResult(aer *state.AppExecResult, err error) *Result {
+	var res = &Result{Err: err}
+	switch {
+	case err == nil:
+		res.Status = StatusAccepted
+		res.AppExecResult = aer
+	case errors.Is(err, ErrTxNotAccepted):
+		res.Status = StatusExpired
+	case errors.Is(err, ErrTxDropped):
+		res.Status = StatusDropped
+	case errors.Is(err, context.DeadlineExceeded):
+		res.Status = StatusTimedOut
+	case errors.Is(err, ErrContextDone):
+		res.Status = StatusCancelled
+	case errors.Is(err, ErrAwaitingNotSupported):
+		res.Status = StatusUnsupported
+	default:
+		res.Status = StatusFailed
+	}
+	return res
+}
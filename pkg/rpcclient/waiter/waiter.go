@@ -34,6 +34,31 @@ var (
 	// ErrMissedEvent is returned when RPCEventBased closes receiver channel
 	// which happens if missed event was received from the RPC server.
 	ErrMissedEvent = errors.New("some event was missed")
+	// ErrTxDropped is returned when transaction was seen in the chain, but
+	// its execution result disappeared before the required number of
+	// confirmations was reached (which can only happen in case of chain
+	// reorganization).
+	ErrTxDropped = errors.New("transaction was dropped from chain")
+)
+
+// Strategy is a transaction awaiting strategy used by [NewCustom].
+type Strategy byte
+
+const (
+	// StrategyAuto uses websocket events if the RPC client supports them
+	// (falling back to polling if subscription-based awaiting fails) and
+	// polling otherwise.
+	StrategyAuto Strategy = iota
+	// StrategyPoll uses polling even if the RPC client supports websocket
+	// events.
+	StrategyPoll
+	// StrategyEvent only uses websocket events without falling back to
+	// polling, an error is returned if subscription-based awaiting fails.
+	StrategyEvent
+	// StrategyHybrid uses websocket events and additionally polls for
+	// execution results while waiting for them, it falls back to polling
+	// if subscription-based awaiting fails.
+	StrategyHybrid
 )
 
 type (
@@ -87,13 +112,27 @@ type Null struct{}
 type PollingBased struct {
 	polling RPCPollingBased
 	version *result.Version
-	config  PollConfig
+	config  Config
 }
 
 // Config is a unified configuration for [Waiter] implementations that allows to
 // customize awaiting behaviour.
 type Config struct {
 	PollConfig
+	// Strategy is the awaiting strategy used by [NewCustom].
+	// [NewCustomEventBased] only distinguishes StrategyEvent and
+	// StrategyHybrid, other constructors ignore it.
+	Strategy Strategy
+	// Confirmations is the number of blocks to wait for after the transaction
+	// is seen in the chain before returning its execution result. The result
+	// is rechecked every poll interval and [ErrTxDropped] is returned if it
+	// disappears. Zero (default) means the result is returned immediately.
+	Confirmations uint32
+	// Timeout limits the time of every Wait and WaitAny call (in addition to
+	// any deadline of the context passed to WaitAny), [ErrContextDone]
+	// wrapping [context.DeadlineExceeded] is returned when it's exceeded.
+	// Zero (default) means no limit.
+	Timeout time.Duration
 }
 
 // PollConfig is a configuration for PollingBased waiter.
@@ -108,8 +147,10 @@ type PollConfig struct {
 
 // EventBased is a websocket-based Waiter.
 type EventBased struct {
-	ws      RPCEventBased
-	polling Waiter
+	ws         RPCEventBased
+	polling    *PollingBased
+	hybrid     bool
+	noFallback bool
 }
 
 // errIsAlreadyExists is a temporary helper until we have #2248 solved. Both C#
@@ -131,19 +172,27 @@ func New(base any, v *result.Version) Waiter {
 // polling-base, otherwise Waiter stub is returned. As a first argument
 // it accepts RPCEventBased implementation, RPCPollingBased implementation
 // or not an implementation of these two interfaces. It returns websocket-based
-// waiter, polling-based waiter or a stub correspondingly. As the second
+// waiter, polling-based waiter or a stub correspondingly (subject to
+// config.Strategy, StrategyPoll always returns polling-based waiter for
+// RPCPollingBased implementations and StrategyEvent and StrategyHybrid
+// return a stub for anything that is not RPCEventBased). As the second
 // argument it accepts the RPC node version necessary for awaiting behaviour
 // customisation. As a third argument it accepts the configuration of
 // [Waiter].
 func NewCustom(base any, v *result.Version, config Config) Waiter {
-	if eventW, ok := base.(RPCEventBased); ok {
+	if eventW, ok := base.(RPCEventBased); ok && config.Strategy != StrategyPoll {
 		return &EventBased{
-			ws:      eventW,
-			polling: newCustomPollingBased(eventW, v, config.PollConfig),
+			ws:         eventW,
+			polling:    newCustomPollingBased(eventW, v, config),
+			hybrid:     config.Strategy == StrategyHybrid,
+			noFallback: config.Strategy == StrategyEvent,
 		}
 	}
+	if config.Strategy == StrategyEvent || config.Strategy == StrategyHybrid {
+		return NewNull()
+	}
 	if pollW, ok := base.(RPCPollingBased); ok {
-		return newCustomPollingBased(pollW, v, config.PollConfig)
+		return newCustomPollingBased(pollW, v, config)
 	}
 	return NewNull()
 }
@@ -175,12 +224,12 @@ func NewCustomPollingBased(waiter RPCPollingBased, config PollConfig) (*PollingB
 	if err != nil {
 		return nil, err
 	}
-	return newCustomPollingBased(waiter, v, config), nil
+	return newCustomPollingBased(waiter, v, Config{PollConfig: config}), nil
 }
 
 // newCustomPollingBased is an internal constructor of PollingBased waiter that sets
 // default configuration values if needed.
-func newCustomPollingBased(waiter RPCPollingBased, v *result.Version, config PollConfig) *PollingBased {
+func newCustomPollingBased(waiter RPCPollingBased, v *result.Version, config Config) *PollingBased {
 	if config.PollInterval <= 0 {
 		config.PollInterval = time.Millisecond * time.Duration(v.Protocol.MillisecondsPerBlock) / 2
 	}
@@ -204,6 +253,21 @@ func (w *PollingBased) Wait(h util.Uint256, vub uint32, err error) (*state.AppEx
 
 // WaitAny implements Waiter interface.
 func (w *PollingBased) WaitAny(ctx context.Context, vub uint32, hashes ...util.Uint256) (*state.AppExecResult, error) {
+	ctx, cancel := w.withTimeout(ctx)
+	defer cancel()
+	return w.waitAny(ctx, vub, hashes...)
+}
+
+// withTimeout applies configured Timeout (if any) to the given context.
+func (w *PollingBased) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if w.config.Timeout > 0 {
+		return context.WithTimeout(ctx, w.config.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// waitAny is WaitAny without Timeout applied.
+func (w *PollingBased) waitAny(ctx context.Context, vub uint32, hashes ...util.Uint256) (*state.AppExecResult, error) {
 	var (
 		currentHeight uint32
 		failedAttempt int
@@ -225,15 +289,8 @@ func (w *PollingBased) WaitAny(ctx context.Context, vub uint32, hashes ...util.U
 			if blockCount-1 > currentHeight {
 				currentHeight = blockCount - 1
 			}
-			t := trigger.Application
-			for _, h := range hashes {
-				res, err := w.polling.GetApplicationLog(h, &t)
-				if err == nil {
-					return &state.AppExecResult{
-						Container: res.Container,
-						Execution: res.Executions[0],
-					}, nil
-				}
+			if res := w.findAny(hashes...); res != nil {
+				return w.confirm(ctx, res)
 			}
 			if currentHeight >= vub {
 				return nil, ErrTxNotAccepted
@@ -246,6 +303,69 @@ func (w *PollingBased) WaitAny(ctx context.Context, vub uint32, hashes ...util.U
 	}
 }
 
+// findAny returns execution result of the first transaction from the given
+// list that is found in the chain or nil if none of them is.
+func (w *PollingBased) findAny(hashes ...util.Uint256) *state.AppExecResult {
+	t := trigger.Application
+	for _, h := range hashes {
+		res, err := w.polling.GetApplicationLog(h, &t)
+		if err == nil {
+			return &state.AppExecResult{
+				Container: res.Container,
+				Execution: res.Executions[0],
+			}
+		}
+	}
+	return nil
+}
+
+// confirm waits for the configured number of blocks to be added after the
+// transaction was seen, rechecking its execution result every poll interval.
+// It returns ErrTxDropped if the result disappears.
+func (w *PollingBased) confirm(ctx context.Context, res *state.AppExecResult) (*state.AppExecResult, error) {
+	if w.config.Confirmations == 0 {
+		return res, nil
+	}
+	var (
+		seenAt        uint32
+		seen          bool
+		failedAttempt int
+		t             = trigger.Application
+	)
+	timer := time.NewTicker(w.config.PollInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			blockCount, err := w.polling.GetBlockCount()
+			if err == nil {
+				_, err = w.polling.GetApplicationLog(res.Container, &t)
+				if errors.Is(err, neorpc.ErrUnknownScriptContainer) {
+					return nil, fmt.Errorf("%w: %s", ErrTxDropped, res.Container.StringLE())
+				}
+			}
+			if err != nil {
+				failedAttempt++
+				if failedAttempt > w.config.RetryCount {
+					return nil, fmt.Errorf("failed to confirm transaction %s: %w", res.Container.StringLE(), err)
+				}
+				continue
+			}
+			failedAttempt = 0
+			if !seen {
+				seenAt, seen = blockCount-1, true
+			}
+			if blockCount-1 >= seenAt+w.config.Confirmations {
+				return res, nil
+			}
+		case <-w.polling.Context().Done():
+			return nil, fmt.Errorf("%w: %w", ErrContextDone, w.polling.Context().Err())
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrContextDone, ctx.Err())
+		}
+	}
+}
+
 // NewEventBased creates an instance of Waiter supporting websocket event-based transaction awaiting.
 // EventBased contains PollingBased under the hood and falls back to polling when subscription-based
 // awaiting fails.
@@ -258,13 +378,15 @@ func NewEventBased(waiter RPCEventBased) (*EventBased, error) {
 // awaiting fails. Waiter configuration options may be specified via config parameter
 // (defaults are used if not specified).
 func NewCustomEventBased(waiter RPCEventBased, config Config) (*EventBased, error) {
-	polling, err := NewCustomPollingBased(waiter, config.PollConfig)
+	v, err := waiter.GetVersion()
 	if err != nil {
 		return nil, err
 	}
 	return &EventBased{
-		ws:      waiter,
-		polling: polling,
+		ws:         waiter,
+		polling:    newCustomPollingBased(waiter, v, config),
+		hybrid:     config.Strategy == StrategyHybrid,
+		noFallback: config.Strategy == StrategyEvent,
 	}, nil
 }
 
@@ -278,6 +400,9 @@ func (w *EventBased) Wait(h util.Uint256, vub uint32, err error) (res *state.App
 
 // WaitAny implements Waiter interface.
 func (w *EventBased) WaitAny(ctx context.Context, vub uint32, hashes ...util.Uint256) (res *state.AppExecResult, waitErr error) {
+	ctx, cancel := w.polling.withTimeout(ctx)
+	defer cancel()
+
 	var (
 		wsWaitErr     error
 		waitersActive int
@@ -342,7 +467,13 @@ func (w *EventBased) WaitAny(ctx context.Context, vub uint32, hashes ...util.Uin
 		}
 	}
 
-	if wsWaitErr == nil && res == nil {
+	var pollTicks <-chan time.Time
+	if w.hybrid {
+		ticker := time.NewTicker(w.polling.config.PollInterval)
+		defer ticker.Stop()
+		pollTicks = ticker.C
+	}
+	for wsWaitErr == nil && waitErr == nil && res == nil {
 		select {
 		case _, ok := <-hRcvr:
 			if !ok {
@@ -378,6 +509,10 @@ func (w *EventBased) WaitAny(ctx context.Context, vub uint32, hashes ...util.Uin
 			waitErr = fmt.Errorf("%w: %w", ErrContextDone, w.ws.Context().Err())
 		case <-ctx.Done():
 			waitErr = fmt.Errorf("%w: %w", ErrContextDone, ctx.Err())
+		case <-pollTicks:
+			// Execution event can be missed without the missed event
+			// notification, so check it explicitly in hybrid mode.
+			res = w.polling.findAny(hashes...)
 		}
 	}
 	close(exit)
@@ -434,9 +569,15 @@ func (w *EventBased) WaitAny(ctx context.Context, vub uint32, hashes ...util.Uin
 	}
 	close(unsubErrs)
 
+	if wsWaitErr != nil && waitErr == nil && w.noFallback {
+		return nil, wsWaitErr
+	}
+	if res != nil && waitErr == nil {
+		return w.polling.confirm(ctx, res)
+	}
 	// Rollback to a poll-based waiter if needed.
 	if wsWaitErr != nil && waitErr == nil {
-		res, waitErr = w.polling.WaitAny(ctx, vub, hashes...)
+		res, waitErr = w.polling.waitAny(ctx, vub, hashes...)
 		if waitErr != nil {
 			// Wrap the poll-based error, it's more important.
 			waitErr = fmt.Errorf("event-based error: %w; poll-based waiter error: %w", wsWaitErr, waitErr)
@@ -3,6 +3,7 @@ package waiter_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -76,7 +77,7 @@ func (r *RPCClient) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*r
 	if r.appLog != nil {
 		return r.appLog, nil
 	}
-	return nil, errors.New("not found")
+	return nil, neorpc.ErrUnknownScriptContainer
 }
 
 type AwaitableRPCClient struct {
@@ -259,6 +260,192 @@ func TestWSWaiter_Wait(t *testing.T) {
 	})
 }
 
+// droppingRPCClient only returns application log once.
+type droppingRPCClient struct {
+	*RPCClient
+	calls atomic.Int32
+}
+
+func (c *droppingRPCClient) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error) {
+	if c.calls.Add(1) > 1 {
+		return nil, neorpc.ErrUnknownScriptContainer
+	}
+	return c.RPCClient.GetApplicationLog(hash, trig)
+}
+
+// lateLogRPCClient returns application log once found is set.
+type lateLogRPCClient struct {
+	*AwaitableRPCClient
+	found atomic.Bool
+}
+
+func (c *lateLogRPCClient) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error) {
+	if !c.found.Load() {
+		return nil, neorpc.ErrUnknownScriptContainer
+	}
+	return c.AwaitableRPCClient.GetApplicationLog(hash, trig)
+}
+
+// noSubRPCClient fails to subscribe for blocks and headers.
+type noSubRPCClient struct {
+	*AwaitableRPCClient
+}
+
+func (c *noSubRPCClient) ReceiveHeadersOfAddedBlocks(flt *neorpc.BlockFilter, rcvr chan<- *block.Header) (string, error) {
+	return "", errors.New("no subscriptions")
+}
+func (c *noSubRPCClient) ReceiveBlocks(flt *neorpc.BlockFilter, rcvr chan<- *block.Block) (string, error) {
+	return "", errors.New("no subscriptions")
+}
+
+func TestNewWaiterStrategy(t *testing.T) {
+	var (
+		poll = &RPCClient{}
+		ws   = &AwaitableRPCClient{}
+		v    = &result.Version{}
+	)
+	for _, tc := range []struct {
+		strategy waiter.Strategy
+		base     any
+		expected waiter.Waiter
+	}{
+		{waiter.StrategyAuto, poll, &waiter.PollingBased{}},
+		{waiter.StrategyAuto, ws, &waiter.EventBased{}},
+		{waiter.StrategyPoll, poll, &waiter.PollingBased{}},
+		{waiter.StrategyPoll, ws, &waiter.PollingBased{}},
+		{waiter.StrategyPoll, nil, waiter.Null{}},
+		{waiter.StrategyEvent, poll, waiter.Null{}},
+		{waiter.StrategyEvent, ws, &waiter.EventBased{}},
+		{waiter.StrategyHybrid, poll, waiter.Null{}},
+		{waiter.StrategyHybrid, ws, &waiter.EventBased{}},
+	} {
+		w := waiter.NewCustom(tc.base, v, waiter.Config{Strategy: tc.strategy})
+		require.IsType(t, tc.expected, w, "strategy %d, base %T", tc.strategy, tc.base)
+	}
+}
+
+func TestPollingWaiter_Confirmations(t *testing.T) {
+	h := util.Uint256{1, 2, 3}
+	appLog := &result.ApplicationLog{Container: h, Executions: []state.Execution{{}}}
+	v := &result.Version{Protocol: result.Protocol{MillisecondsPerBlock: 1}}
+	cfg := waiter.Config{Confirmations: 2}
+
+	t.Run("confirmed", func(t *testing.T) {
+		c := &RPCClient{appLog: appLog}
+		c.bCount.Store(5)
+		w := waiter.NewCustom(c, v, cfg)
+
+		resCh := make(chan *waiter.Result)
+		go func() { resCh <- waiter.Await(w, h, 10, nil) }()
+		for i := uint32(6); ; i++ {
+			select {
+			case res := <-resCh:
+				require.Equal(t, waiter.StatusAccepted, res.Status, res.Err)
+				require.Equal(t, h, res.AppExecResult.Container)
+				require.GreaterOrEqual(t, c.bCount.Load(), uint32(7))
+				return
+			case <-time.After(10 * time.Millisecond):
+				c.bCount.Store(i)
+			}
+		}
+	})
+	t.Run("dropped", func(t *testing.T) {
+		c := &droppingRPCClient{RPCClient: &RPCClient{appLog: appLog}}
+		c.bCount.Store(5)
+		w := waiter.NewCustom(c, v, cfg)
+
+		_, err := w.Wait(h, 10, nil)
+		require.ErrorIs(t, err, waiter.ErrTxDropped)
+		require.Equal(t, waiter.StatusDropped, waiter.NewResult(nil, err).Status)
+	})
+}
+
+func TestWaiter_Timeout(t *testing.T) {
+	h := util.Uint256{1, 2, 3}
+	v := &result.Version{Protocol: result.Protocol{MillisecondsPerBlock: 1}}
+	poll := &RPCClient{}
+	poll.bCount.Store(5)
+	ws := &AwaitableRPCClient{}
+	ws.bCount.Store(5)
+	for _, base := range []any{poll, ws} {
+		w := waiter.NewCustom(base, v, waiter.Config{Timeout: 10 * time.Millisecond})
+		res := waiter.Await(w, h, 10, nil)
+		require.Equal(t, waiter.StatusTimedOut, res.Status, "%T", base)
+		require.ErrorIs(t, res.Err, waiter.ErrContextDone)
+		require.ErrorIs(t, res.Err, context.DeadlineExceeded)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		res = waiter.AwaitAny(ctx, w, 10, h)
+		require.Equal(t, waiter.StatusCancelled, res.Status, "%T", base)
+	}
+}
+
+func TestWSWaiter_Strategies(t *testing.T) {
+	h := util.Uint256{1, 2, 3}
+	appLog := &result.ApplicationLog{Container: h, Executions: []state.Execution{{}}}
+	v := &result.Version{Protocol: result.Protocol{MillisecondsPerBlock: 1}}
+
+	t.Run("event without fallback", func(t *testing.T) {
+		c := &noSubRPCClient{&AwaitableRPCClient{RPCClient: RPCClient{appLog: appLog}}}
+
+		_, err := waiter.NewCustom(c, v, waiter.Config{Strategy: waiter.StrategyEvent}).Wait(h, 10, nil)
+		require.ErrorContains(t, err, "failed to subscribe")
+
+		// Auto strategy falls back to polling.
+		aer, err := waiter.NewCustom(c, v, waiter.Config{}).Wait(h, 10, nil)
+		require.NoError(t, err)
+		require.Equal(t, h, aer.Container)
+	})
+	t.Run("hybrid", func(t *testing.T) {
+		c := &lateLogRPCClient{AwaitableRPCClient: &AwaitableRPCClient{RPCClient: RPCClient{appLog: appLog}}}
+		w := waiter.NewCustom(c, v, waiter.Config{Strategy: waiter.StrategyHybrid})
+
+		resCh := make(chan *waiter.Result)
+		go func() { resCh <- waiter.Await(w, h, 10, nil) }()
+		select {
+		case <-resCh:
+			t.Fatal("unexpected result")
+		case <-time.After(50 * time.Millisecond):
+		}
+		// No execution event is sent, the result is found by polling.
+		c.found.Store(true)
+		select {
+		case res := <-resCh:
+			require.Equal(t, waiter.StatusAccepted, res.Status, res.Err)
+		case <-time.After(time.Second):
+			t.Fatal("failed to await result")
+		}
+	})
+}
+
+func TestNewResult(t *testing.T) {
+	aer := &state.AppExecResult{Container: util.Uint256{1}}
+	for _, tc := range []struct {
+		err      error
+		expected waiter.Status
+	}{
+		{nil, waiter.StatusAccepted},
+		{waiter.ErrTxNotAccepted, waiter.StatusExpired},
+		{fmt.Errorf("wrapped: %w", waiter.ErrTxDropped), waiter.StatusDropped},
+		{fmt.Errorf("%w: %w", waiter.ErrContextDone, context.DeadlineExceeded), waiter.StatusTimedOut},
+		{fmt.Errorf("%w: %w", waiter.ErrContextDone, context.Canceled), waiter.StatusCancelled},
+		{waiter.ErrAwaitingNotSupported, waiter.StatusUnsupported},
+		{errors.New("some error"), waiter.StatusFailed},
+	} {
+		res := waiter.NewResult(aer, tc.err)
+		require.Equal(t, tc.expected, res.Status, tc.expected.String())
+		require.Equal(t, tc.err, res.Err)
+		if tc.err == nil {
+			require.Equal(t, aer, res.AppExecResult)
+		} else {
+			require.Nil(t, res.AppExecResult)
+		}
+	}
+	require.Equal(t, waiter.StatusFailed, waiter.Await(waiter.NewNull(), util.Uint256{}, 0, errors.New("send")).Status)
+	require.Equal(t, waiter.StatusUnsupported, waiter.Await(waiter.NewNull(), util.Uint256{}, 0, nil).Status)
+}
+
 func TestRPCWaiterRPCClientCompat(t *testing.T) {
 	_ = waiter.RPCPollingBased(&rpcclient.Client{})
 	_ = waiter.RPCPollingBased(&rpcclient.WSClient{})
@@ -17,6 +17,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/rpcevent"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
 // WSClient is a websocket-enabled RPC client that can be used with appropriate
@@ -81,6 +82,11 @@ type WSClient struct {
 
 	respLock     sync.RWMutex
 	respChannels map[uint64]chan *neorpc.Response
+
+	// storageStreams maps findstoragestream identifiers to the receiver
+	// channels, it must be accessed with streamsLock taken.
+	streamsLock    sync.Mutex
+	storageStreams map[string]chan<- *result.StorageBatch
 }
 
 // WSOptions defines options for the web-socket RPC client. It contains a
@@ -396,6 +402,50 @@ func (r *notaryRequestReceiver) Close() {
 	close(r.ch)
 }
 
+// rejectedBlockReceiver stores information about rejected block events
+// subscriber.
+type rejectedBlockReceiver struct {
+	ch chan<- *state.RejectedBlock
+}
+
+// EventID implements neorpc.Comparator interface.
+func (r *rejectedBlockReceiver) EventID() neorpc.EventID {
+	return neorpc.BlockRejectedEventID
+}
+
+// Filter implements neorpc.Comparator interface.
+func (r *rejectedBlockReceiver) Filter() neorpc.SubscriptionFilter {
+	return nil
+}
+
+// Receiver implements notificationReceiver interface.
+func (r *rejectedBlockReceiver) Receiver() any {
+	return r.ch
+}
+
+// TrySend implements notificationReceiver interface.
+func (r *rejectedBlockReceiver) TrySend(ntf Notification, nonBlocking bool) (bool, bool) {
+	if rpcevent.Matches(r, ntf) {
+		if nonBlocking {
+			select {
+			case r.ch <- ntf.Value.(*state.RejectedBlock):
+			default:
+				return true, true
+			}
+		} else {
+			r.ch <- ntf.Value.(*state.RejectedBlock)
+		}
+
+		return true, false
+	}
+	return false, false
+}
+
+// Close implements notificationReceiver interface.
+func (r *rejectedBlockReceiver) Close() {
+	close(r.ch)
+}
+
 // Notification represents a server-generated notification for client subscriptions.
 // Value can be one of *block.Block, *state.AppExecResult, *state.ContainedNotificationEvent
 // *transaction.Transaction or *subscriptions.NotaryRequestEvent based on Type.
@@ -574,6 +624,10 @@ readloop:
 					break readloop
 				}
 				ntf.Value = &block.New(sr).Header
+			case neorpc.StorageBatchEventID:
+				ntf.Value = new(result.StorageBatch)
+			case neorpc.BlockRejectedEventID:
+				ntf.Value = new(state.RejectedBlock)
 			case neorpc.MissedEventID:
 				// No value.
 			default:
@@ -629,6 +683,7 @@ readloop:
 		c.dropSubCh(rcvrCh, ids[0], true)
 	}
 	c.subscriptionsLock.Unlock()
+	c.dropStorageStreams()
 	c.Client.ctxCancel()
 }
 
@@ -687,6 +742,10 @@ writeloop:
 }
 
 func (c *WSClient) notifySubscribers(ntf Notification) {
+	if ntf.Type == neorpc.StorageBatchEventID {
+		c.deliverStorageBatch(ntf.Value.(*result.StorageBatch))
+		return
+	}
 	if ntf.Type == neorpc.MissedEventID {
 		c.subscriptionsLock.Lock()
 		for rcvr, ids := range c.receivers {
@@ -916,6 +975,115 @@ func (c *WSClient) ReceiveNotaryRequests(flt *neorpc.NotaryRequestFilter, rcvr c
 	return c.performSubscription(params, r)
 }
 
+// ReceiveRejectedBlocks registers provided channel as a receiver for rejected
+// block events. They're only sent by nodes streaming execution results (see
+// StreamExecutions ledger setting) when a block fails to be stored after its
+// execution results are sent, all execution results, notifications and
+// transactions received for this block are to be discarded then. See
+// WSClient comments for generic Receive* behaviour details.
+func (c *WSClient) ReceiveRejectedBlocks(rcvr chan<- *state.RejectedBlock) (string, error) {
+	if rcvr == nil {
+		return "", ErrNilNotificationReceiver
+	}
+	r := &rejectedBlockReceiver{
+		ch: rcvr,
+	}
+	return c.performSubscription([]any{"block_rejected"}, r)
+}
+
+// FindStorageStreamByHash requests all storage items of the contract with the
+// given hash matching the given prefix and receives them via the provided
+// channel in batches of up to batchSize items (0 means server default, which
+// is also the maximum). It's intended for indexers that need to fetch the whole
+// contract state and works only over websocket connection. The method blocks
+// until the server sends all items and returns their number, so the channel
+// must be read from in a separate goroutine. The channel is closed after the
+// last batch (the one with Done set) is received, on request failure or on
+// disconnection.
+func (c *WSClient) FindStorageStreamByHash(contractHash util.Uint160, prefix []byte, batchSize int, rcvr chan<- *result.StorageBatch) (int, error) {
+	return c.findStorageStream(contractHash.StringLE(), prefix, batchSize, rcvr)
+}
+
+// FindStorageStreamByID is the same as FindStorageStreamByHash, but accepts
+// contract ID.
+func (c *WSClient) FindStorageStreamByID(contractID int32, prefix []byte, batchSize int, rcvr chan<- *result.StorageBatch) (int, error) {
+	return c.findStorageStream(contractID, prefix, batchSize, rcvr)
+}
+
+func (c *WSClient) findStorageStream(contract any, prefix []byte, batchSize int, rcvr chan<- *result.StorageBatch) (int, error) {
+	if rcvr == nil {
+		return 0, ErrNilNotificationReceiver
+	}
+	if prefix == nil {
+		prefix = []byte{}
+	}
+	var (
+		id     = strconv.FormatUint(c.getNextRequestID(), 10)
+		params = []any{contract, prefix, id}
+		resp   int
+	)
+	if batchSize != 0 {
+		params = append(params, batchSize)
+	}
+
+	c.streamsLock.Lock()
+	select {
+	case <-c.readerDone:
+		c.streamsLock.Unlock()
+		return 0, fmt.Errorf("%w: before registering stream", c.closeErrOrConnLost())
+	default:
+	}
+	if c.storageStreams == nil {
+		c.storageStreams = make(map[string]chan<- *result.StorageBatch)
+	}
+	c.storageStreams[id] = rcvr
+	c.streamsLock.Unlock()
+
+	err := c.performRequest("findstoragestream", params, &resp)
+	if err != nil {
+		c.streamsLock.Lock()
+		if ch, ok := c.storageStreams[id]; ok {
+			delete(c.storageStreams, id)
+			close(ch)
+		}
+		c.streamsLock.Unlock()
+		return 0, err
+	}
+	return resp, nil
+}
+
+// deliverStorageBatch sends the batch to the corresponding stream receiver
+// closing its channel after the last batch. Batches of unknown streams are
+// ignored.
+func (c *WSClient) deliverStorageBatch(b *result.StorageBatch) {
+	c.streamsLock.Lock()
+	defer c.streamsLock.Unlock()
+	ch, ok := c.storageStreams[b.ID]
+	if !ok {
+		return
+	}
+	select {
+	case ch <- b:
+	case <-c.shutdown:
+		return
+	}
+	if b.Done {
+		delete(c.storageStreams, b.ID)
+		close(ch)
+	}
+}
+
+// dropStorageStreams closes all active stream receivers, it's used on
+// disconnection.
+func (c *WSClient) dropStorageStreams() {
+	c.streamsLock.Lock()
+	for id, ch := range c.storageStreams {
+		delete(c.storageStreams, id)
+		close(ch)
+	}
+	c.streamsLock.Unlock()
+}
+
 // Unsubscribe removes subscription for the given event stream. It will return an
 // error in case if there's no subscription with the provided ID. Call to Unsubscribe
 // doesn't block notifications receive process for given subscriber, thus, ensure
@@ -51,6 +51,7 @@ func TestWSClientSubscription(t *testing.T) {
 	aerCh := make(chan *state.AppExecResult)
 	ntfCh := make(chan *state.ContainedNotificationEvent)
 	ntrCh := make(chan *result.NotaryRequestEvent)
+	rjCh := make(chan *state.RejectedBlock)
 	var cases = map[string]func(*WSClient) (string, error){
 		"blocks": func(wsc *WSClient) (string, error) {
 			return wsc.ReceiveBlocks(nil, bCh)
@@ -67,6 +68,9 @@ func TestWSClientSubscription(t *testing.T) {
 		"notary requests": func(wsc *WSClient) (string, error) {
 			return wsc.ReceiveNotaryRequests(nil, ntrCh)
 		},
+		"rejected blocks": func(wsc *WSClient) (string, error) {
+			return wsc.ReceiveRejectedBlocks(rjCh)
+		},
 	}
 	t.Run("good", func(t *testing.T) {
 		for name, f := range cases {
@@ -153,6 +157,7 @@ func TestWSClientEvents(t *testing.T) {
 		`{"jsonrpc":"2.0","method":"notification_from_execution","params":[{"container":"0xe1cd5e57e721d2a2e05fb1f08721b12057b25ab1dd7fd0f33ee1639932fdfad7","contract":"0x1b4357bff5a01bdf2a6581247cf9ed1e24629176","eventname":"contract call","state":{"type":"Array","value":[{"type":"ByteString","value":"dHJhbnNmZXI="},{"type":"Array","value":[{"type":"ByteString","value":"dpFiJB7t+XwkgWUq3xug9b9XQxs="},{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"Integer","value":"1000"}]}]}}]}`,
 		`{"jsonrpc":"2.0","method":"transaction_executed","params":[{"container":"0xf97a72b7722c109f909a8bc16c22368c5023d85828b09b127b237aace33cf099","trigger":"Application","vmstate":"HALT","gasconsumed":"6042610","stack":[],"notifications":[{"contract":"0xe65ff7b3a02d207b584a5c27057d4e9862ef01da","eventname":"contract call","state":{"type":"Array","value":[{"type":"ByteString","value":"dHJhbnNmZXI="},{"type":"Array","value":[{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"ByteString","value":"IHKCdK+vw29DoHHTKM+j5inZy7A="},{"type":"Integer","value":"123"}]}]}},{"contract":"0xe65ff7b3a02d207b584a5c27057d4e9862ef01da","eventname":"transfer","state":{"type":"Array","value":[{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"ByteString","value":"IHKCdK+vw29DoHHTKM+j5inZy7A="},{"type":"Integer","value":"123"}]}}]}]}`,
 		fmt.Sprintf(`{"jsonrpc":"2.0","method":"block_added","params":[%s]}`, b1Verbose),
+		`{"jsonrpc":"2.0","method":"block_rejected","params":[{"hash":"0xf97a72b7722c109f909a8bc16c22368c5023d85828b09b127b237aace33cf099","index":7}]}`,
 		`{"jsonrpc":"2.0","method":"event_missed","params":[]}`, // the last one, will trigger receiver channels closing.
 	}
 	startSending := make(chan struct{})
@@ -190,6 +195,7 @@ func TestWSClientEvents(t *testing.T) {
 	aerCh2 := make(chan *state.AppExecResult)
 	aerCh3 := make(chan *state.AppExecResult)
 	ntfCh := make(chan *state.ContainedNotificationEvent)
+	rjCh := make(chan *state.RejectedBlock)
 	halt := "HALT"
 	fault := "FAULT"
 	wsc.subscriptionsLock.Lock()
@@ -208,6 +214,8 @@ func TestWSClientEvents(t *testing.T) {
 	wsc.receivers[chan<- *state.AppExecResult(aerCh2)] = []string{"5"}
 	wsc.subscriptions["6"] = &executionReceiver{filter: &neorpc.ExecutionFilter{State: &fault}, ch: aerCh3}
 	wsc.receivers[chan<- *state.AppExecResult(aerCh3)] = []string{"6"}
+	wsc.subscriptions["7"] = &rejectedBlockReceiver{ch: rjCh}
+	wsc.receivers[chan<- *state.RejectedBlock(rjCh)] = []string{"7"}
 	// MissedEvent must close the channels above.
 
 	wsc.subscriptionsLock.Unlock()
@@ -217,17 +225,20 @@ func TestWSClientEvents(t *testing.T) {
 		b1Cnt, b2Cnt                                      int
 		aer1Cnt, aer2Cnt, aer3Cnt                         int
 		ntfCnt                                            int
+		rjCnt                                             int
 		expectedb1Cnt, expectedb2Cnt                      = 1, 1    // single Block event
 		expectedaer1Cnt, expectedaer2Cnt, expectedaer3Cnt = 2, 2, 0 // two HALTED AERs
 		expectedntfCnt                                    = 1       // single notification event
+		expectedrjCnt                                     = 1       // single rejected block event
 		aer                                               *state.AppExecResult
+		rj                                                *state.RejectedBlock
 	)
 	for b1Cnt+b2Cnt+
 		aer1Cnt+aer2Cnt+aer3Cnt+
-		ntfCnt !=
+		ntfCnt+rjCnt !=
 		expectedb1Cnt+expectedb2Cnt+
 			expectedaer1Cnt+expectedaer2Cnt+expectedaer3Cnt+
-			expectedntfCnt {
+			expectedntfCnt+expectedrjCnt {
 		select {
 		case _, ok = <-bCh1:
 			if ok {
@@ -254,6 +265,11 @@ func TestWSClientEvents(t *testing.T) {
 			if ok {
 				ntfCnt++
 			}
+		case rj, ok = <-rjCh:
+			if ok {
+				require.Equal(t, uint32(7), rj.Index)
+				rjCnt++
+			}
 		case <-time.After(time.Second):
 			t.Fatal("timeout waiting for event")
 		}
@@ -264,6 +280,7 @@ func TestWSClientEvents(t *testing.T) {
 	assert.Equal(t, expectedaer2Cnt, aer2Cnt)
 	assert.Equal(t, expectedaer3Cnt, aer3Cnt)
 	assert.Equal(t, expectedntfCnt, ntfCnt)
+	assert.Equal(t, expectedrjCnt, rjCnt)
 
 	// Channels must be closed by server
 	_, ok = <-bCh1
@@ -280,6 +297,8 @@ func TestWSClientEvents(t *testing.T) {
 	require.False(t, ok)
 	_, ok = <-ntfCh
 	require.False(t, ok)
+	_, ok = <-rjCh
+	require.False(t, ok)
 }
 
 func TestWSClientNonBlockingEvents(t *testing.T) {
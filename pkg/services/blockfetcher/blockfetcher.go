@@ -1,23 +1,25 @@
 package blockfetcher
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
-	"net/url"
+	"math/rand/v2"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	gio "github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
-	"github.com/nspcc-dev/neofs-sdk-go/client"
 	"github.com/nspcc-dev/neofs-sdk-go/object"
 	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
 	"go.uber.org/zap"
@@ -32,6 +34,25 @@ const (
 	defaultOIDBatchSize = 8000
 	// defaultDownloaderWorkersCount is the default number of workers downloading blocks.
 	defaultDownloaderWorkersCount = 100
+	// defaultRetryAttempts is the default number of attempts to download an object.
+	defaultRetryAttempts = 5
+	// defaultRetryBackoff is the default delay before the first retry.
+	defaultRetryBackoff = time.Second
+	// defaultRetryMaxBackoff is the default maximum delay between retries.
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+const (
+	// CompressionAttribute is the block object attribute specifying the
+	// algorithm used to compress the object payload. The payload is expected
+	// to be a plain serialized block if there is no such attribute.
+	CompressionAttribute = "Compression"
+	// CompressionZstd is the CompressionAttribute value for zstd-compressed
+	// blocks.
+	CompressionZstd = "zstd"
+	// CompressionGzip is the CompressionAttribute value for gzip-compressed
+	// blocks.
+	CompressionGzip = "gzip"
 )
 
 // Ledger is an interface to Blockchain sufficient for Service.
@@ -43,15 +64,23 @@ type Ledger interface {
 // Service is a service that fetches blocks from NeoFS.
 type Service struct {
 	// isActive denotes whether the service is working or in the process of shutdown.
-	isActive          atomic.Bool
+	isActive atomic.Bool
+	// lastProgress is the time (in Unix nanoseconds) of the last block
+	// queued by the service, it's used to detect stalled downloads.
+	lastProgress      atomic.Int64
 	log               *zap.Logger
 	cfg               config.NeoFSBlockFetcher
 	stateRootInHeader bool
 
 	chain        Ledger
-	client       *client.Client
+	client       Client
 	enqueueBlock func(*block.Block) error
 	account      *wallet.Account
+	tokens       *neofs.Tokens
+	// bytesLimiter and objectsLimiter throttle downloads of all workers,
+	// they're nil if there are no limits.
+	bytesLimiter   *rateLimiter
+	objectsLimiter *rateLimiter
 
 	oidsCh   chan oid.ID
 	blocksCh chan *block.Block
@@ -70,11 +99,16 @@ type Service struct {
 	oidDownloaderToExiter chan struct{}
 	blockQueuerToExiter   chan struct{}
 
+	handoverCallback func(uint32)
 	shutdownCallback func()
 }
 
-// New creates a new BlockFetcher Service.
-func New(chain Ledger, cfg config.NeoFSBlockFetcher, logger *zap.Logger, putBlock func(*block.Block) error, shutdownCallback func()) (*Service, error) {
+// New creates a new BlockFetcher Service. handoverCallback (if not nil) is
+// called with the index of the last block available in NeoFS as soon as the
+// container tip is reached, it happens before all blocks are downloaded and
+// the service is stopped (which is signalled via shutdownCallback), so it
+// allows to resume P2P synchronization early.
+func New(chain Ledger, cfg config.NeoFSBlockFetcher, logger *zap.Logger, putBlock func(*block.Block) error, handoverCallback func(uint32), shutdownCallback func()) (*Service, error) {
 	var (
 		account *wallet.Account
 		err     error
@@ -100,6 +134,24 @@ func New(chain Ledger, cfg config.NeoFSBlockFetcher, logger *zap.Logger, putBloc
 			return &Service{}, err
 		}
 	}
+	if len(cfg.Addresses) == 0 {
+		return &Service{}, errors.New("no addresses provided")
+	}
+	tokens, err := neofs.NewTokens(logger, cfg.BearerTokens, cfg.SessionTokens)
+	if err != nil {
+		return &Service{}, fmt.Errorf("failed to load NeoFS tokens: %w", err)
+	}
+	bfs := NewWithClient(chain, cfg, logger, nil, putBlock, handoverCallback, shutdownCallback)
+	bfs.account = account
+	bfs.tokens = tokens
+	return bfs, nil
+}
+
+// NewWithClient creates a new BlockFetcher Service using the given NeoFS
+// client instead of connecting to the configured addresses (wallet and tokens
+// configuration is not used either). It allows to test the Service with
+// MockClient. See New for callbacks description.
+func NewWithClient(chain Ledger, cfg config.NeoFSBlockFetcher, logger *zap.Logger, c Client, putBlock func(*block.Block) error, handoverCallback func(uint32), shutdownCallback func()) *Service {
 	if cfg.Timeout <= 0 {
 		cfg.Timeout = defaultTimeout
 	}
@@ -109,17 +161,26 @@ func New(chain Ledger, cfg config.NeoFSBlockFetcher, logger *zap.Logger, putBloc
 	if cfg.DownloaderWorkersCount <= 0 {
 		cfg.DownloaderWorkersCount = defaultDownloaderWorkersCount
 	}
-	if len(cfg.Addresses) == 0 {
-		return &Service{}, errors.New("no addresses provided")
+	if cfg.RetryAttempts <= 0 {
+		cfg.RetryAttempts = defaultRetryAttempts
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+	if cfg.RetryMaxBackoff <= 0 {
+		cfg.RetryMaxBackoff = max(defaultRetryMaxBackoff, cfg.RetryBackoff)
 	}
 	return &Service{
 		chain: chain,
 		log:   logger,
 		cfg:   cfg,
 
+		client:            c,
 		enqueueBlock:      putBlock,
-		account:           account,
+		bytesLimiter:      newRateLimiter(cfg.MaxBytesPerSecond),
+		objectsLimiter:    newRateLimiter(int64(cfg.MaxObjectsPerSecond)),
 		stateRootInHeader: chain.GetConfig().StateRootInHeader,
+		handoverCallback:  handoverCallback,
 		shutdownCallback:  shutdownCallback,
 
 		quit:                  make(chan bool),
@@ -137,7 +198,7 @@ func New(chain Ledger, cfg config.NeoFSBlockFetcher, logger *zap.Logger, putBloc
 		// Use buffer of a single OIDs batch size to provide smooth downloading and
 		// avoid pauses during blockqueue insertion.
 		blocksCh: make(chan *block.Block, cfg.OIDBatchSize),
-	}, nil
+	}
 }
 
 // Start runs the NeoFS BlockFetcher service.
@@ -146,13 +207,28 @@ func (bfs *Service) Start() error {
 		return nil
 	}
 	bfs.log.Info("starting NeoFS BlockFetcher service")
+	bfs.lastProgress.Store(time.Now().UnixNano())
 
-	var err error
 	bfs.ctx, bfs.ctxCancel = context.WithCancel(context.Background())
-	bfs.client, err = neofs.GetSDKClient(bfs.ctx, bfs.cfg.Addresses[0], 10*time.Minute)
-	if err != nil {
-		bfs.isActive.CompareAndSwap(true, false)
-		return fmt.Errorf("create SDK client: %w", err)
+	if bfs.client == nil {
+		c, err := neofs.GetSDKClient(bfs.ctx, bfs.cfg.Addresses[0], 10*time.Minute)
+		if err != nil {
+			bfs.isActive.CompareAndSwap(true, false)
+			return fmt.Errorf("create SDK client: %w", err)
+		}
+		bfs.client = &sdkClient{
+			client:      c,
+			key:         bfs.account.PrivateKey(),
+			tokens:      bfs.tokens,
+			containerID: bfs.cfg.ContainerID,
+		}
+	}
+
+	// Start the set of blocks downloading routines. Wait group counter is
+	// increased before the shutdown routine is started to wait for them.
+	bfs.wg.Add(bfs.cfg.DownloaderWorkersCount)
+	for range bfs.cfg.DownloaderWorkersCount {
+		go bfs.blockDownloader()
 	}
 
 	// Start routine that manages Service shutdown process.
@@ -161,12 +237,6 @@ func (bfs *Service) Start() error {
 	// Start OIDs downloader routine.
 	go bfs.oidDownloader()
 
-	// Start the set of blocks downloading routines.
-	for range bfs.cfg.DownloaderWorkersCount {
-		bfs.wg.Add(1)
-		go bfs.blockDownloader()
-	}
-
 	// Start routine that puts blocks into bQueue.
 	go bfs.blockQueuer()
 
@@ -192,30 +262,36 @@ func (bfs *Service) oidDownloader() {
 	bfs.stopService(force)
 }
 
+// handover is called once the container tip is reached, last is the index of
+// the last block available in NeoFS. Blocks above it are to be synchronized
+// via P2P.
+func (bfs *Service) handover(last uint32) {
+	bfs.log.Info("NeoFS BlockFetcher service: container tip reached, handing over to P2P synchronization",
+		zap.Uint32("height", last))
+	if bfs.handoverCallback != nil {
+		bfs.handoverCallback(last)
+	}
+}
+
 // blockDownloader downloads the block from NeoFS and sends it to the blocks channel.
 func (bfs *Service) blockDownloader() {
 	defer bfs.wg.Done()
 
 	for blkOid := range bfs.oidsCh {
-		ctx, cancel := context.WithTimeout(bfs.ctx, bfs.cfg.Timeout)
-		defer cancel()
-
-		rc, err := bfs.objectGet(ctx, blkOid.String())
-		if err != nil {
-			if isContextCanceledErr(err) {
-				return
+		var b *block.Block
+		err := bfs.retry(func() error {
+			var err error
+			b, err = bfs.downloadBlock(blkOid)
+			if err != nil && !isContextCanceledErr(err) {
+				bfs.log.Warn("failed to download block", zap.String("oid", blkOid.String()), zap.Error(err))
 			}
-			bfs.log.Error("failed to objectGet block", zap.String("oid", blkOid.String()), zap.Error(err))
-			bfs.stopService(true)
-			return
-		}
-
-		b, err := bfs.readBlock(rc)
+			return err
+		})
 		if err != nil {
 			if isContextCanceledErr(err) {
 				return
 			}
-			bfs.log.Error("failed to decode block from stream", zap.String("oid", blkOid.String()), zap.Error(err))
+			bfs.log.Error("failed to download block, retry attempts exhausted", zap.String("oid", blkOid.String()), zap.Error(err))
 			bfs.stopService(true)
 			return
 		}
@@ -227,6 +303,50 @@ func (bfs *Service) blockDownloader() {
 	}
 }
 
+// downloadBlock fetches and decodes the block with the specified OID.
+func (bfs *Service) downloadBlock(blkOid oid.ID) (*block.Block, error) {
+	ctx, cancel := context.WithTimeout(bfs.ctx, bfs.cfg.Timeout)
+	defer cancel()
+
+	hdr, rc, err := bfs.objectGet(ctx, blkOid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to objectGet block: %w", err)
+	}
+	b, err := bfs.readBlock(rc, objectAttribute(hdr, CompressionAttribute))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode block from stream: %w", err)
+	}
+	return b, nil
+}
+
+// retry calls action until it succeeds, retry attempts are exhausted or the
+// service context is canceled. Delays between attempts grow exponentially
+// (up to RetryMaxBackoff) with a random jitter. It returns the last action
+// error.
+func (bfs *Service) retry(action func() error) error {
+	var (
+		err     error
+		backoff = bfs.cfg.RetryBackoff
+	)
+	for i := range bfs.cfg.RetryAttempts {
+		if i > 0 {
+			// Equal jitter: wait for [backoff/2, backoff).
+			delay := backoff/2 + rand.N(backoff/2+1)
+			select {
+			case <-bfs.ctx.Done():
+				return bfs.ctx.Err()
+			case <-time.After(delay):
+			}
+			backoff = min(2*backoff, bfs.cfg.RetryMaxBackoff)
+		}
+		err = action()
+		if err == nil || isContextCanceledErr(err) {
+			return err
+		}
+	}
+	return err
+}
+
 // blockQueuer puts the block into the bqueue.
 func (bfs *Service) blockQueuer() {
 	defer close(bfs.blockQueuerToExiter)
@@ -242,62 +362,111 @@ func (bfs *Service) blockQueuer() {
 				bfs.stopService(true)
 				return
 			}
+			bfs.lastProgress.Store(time.Now().UnixNano())
 		}
 	}
 }
 
+// indexFile is a result of index file prefetching.
+type indexFile struct {
+	data []byte
+	err  error
+}
+
 // fetchOIDsFromIndexFiles fetches block OIDs from NeoFS by searching index files first.
+// The next index file is prefetched while OIDs from the current one are being
+// streamed, so that block downloaders don't stall at index files boundaries.
 func (bfs *Service) fetchOIDsFromIndexFiles() error {
 	h := bfs.chain.BlockHeight()
 	startIndex := h / bfs.cfg.IndexFileSize
 	skip := h % bfs.cfg.IndexFileSize
 
+	next := bfs.prefetchIndexFile(startIndex)
 	for {
+		var f indexFile
 		select {
 		case <-bfs.exiterToOIDDownloader:
 			return nil
-		default:
-			prm := client.PrmObjectSearch{}
-			filters := object.NewSearchFilters()
-			filters.AddFilter(bfs.cfg.IndexFileAttribute, fmt.Sprintf("%d", startIndex), object.MatchStringEqual)
-			prm.SetFilters(filters)
-
-			ctx, cancel := context.WithTimeout(bfs.ctx, bfs.cfg.Timeout)
-			blockOidsObject, err := bfs.objectSearch(ctx, prm)
-			cancel()
-			if err != nil {
-				if isContextCanceledErr(err) {
-					return nil
-				}
-				return fmt.Errorf("failed to find '%s' object with index %d: %w", bfs.cfg.IndexFileAttribute, startIndex, err)
-			}
-			if len(blockOidsObject) == 0 {
-				bfs.log.Info(fmt.Sprintf("NeoFS BlockFetcher service: no '%s' object found with index %d, stopping", bfs.cfg.IndexFileAttribute, startIndex))
+		case f = <-next:
+		}
+		if f.err != nil {
+			if isContextCanceledErr(f.err) {
 				return nil
 			}
-
-			blockCtx, blockCancel := context.WithTimeout(bfs.ctx, bfs.cfg.Timeout)
-			defer blockCancel()
-			oidsRC, err := bfs.objectGet(blockCtx, blockOidsObject[0].String())
-			if err != nil {
-				if isContextCanceledErr(err) {
-					return nil
-				}
-				return fmt.Errorf("failed to fetch '%s' object with index %d: %w", bfs.cfg.IndexFileAttribute, startIndex, err)
+			return f.err
+		}
+		if f.data == nil {
+			bfs.log.Info(fmt.Sprintf("NeoFS BlockFetcher service: no '%s' object found with index %d, stopping", bfs.cfg.IndexFileAttribute, startIndex))
+			last := h
+			if startIndex > 0 {
+				last = max(last, startIndex*bfs.cfg.IndexFileSize-1)
 			}
+			bfs.handover(last)
+			return nil
+		}
 
-			err = bfs.streamBlockOIDs(oidsRC, int(skip))
-			if err != nil {
-				if isContextCanceledErr(err) {
-					return nil
-				}
-				return fmt.Errorf("failed to stream block OIDs with index %d: %w", startIndex, err)
+		next = bfs.prefetchIndexFile(startIndex + 1)
+		err := bfs.streamBlockOIDs(io.NopCloser(bytes.NewReader(f.data)), int(skip))
+		if err != nil {
+			return fmt.Errorf("failed to stream block OIDs with index %d: %w", startIndex, err)
+		}
+
+		startIndex++
+		skip = 0
+	}
+}
+
+// prefetchIndexFile starts index file fetching in a separate routine and
+// returns a channel the result is to be sent to. Index file data is nil if
+// there is no index file with the specified index.
+func (bfs *Service) prefetchIndexFile(index uint32) <-chan indexFile {
+	ch := make(chan indexFile, 1)
+	go func() {
+		var data []byte
+		err := bfs.retry(func() error {
+			var err error
+			data, err = bfs.fetchIndexFile(index)
+			if err != nil && !isContextCanceledErr(err) {
+				bfs.log.Warn("failed to fetch index file", zap.Uint32("index", index), zap.Error(err))
 			}
+			return err
+		})
+		ch <- indexFile{data: data, err: err}
+	}()
+	return ch
+}
 
-			startIndex++
-			skip = 0
-		}
+// fetchIndexFile searches for the index file with the specified index and
+// downloads it. It returns nil data if there is no such index file.
+func (bfs *Service) fetchIndexFile(index uint32) ([]byte, error) {
+	filters := object.NewSearchFilters()
+	filters.AddFilter(bfs.cfg.IndexFileAttribute, fmt.Sprintf("%d", index), object.MatchStringEqual)
+
+	ctx, cancel := context.WithTimeout(bfs.ctx, bfs.cfg.Timeout)
+	blockOidsObject, err := bfs.client.ObjectSearch(ctx, filters)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find '%s' object with index %d: %w", bfs.cfg.IndexFileAttribute, index, err)
+	}
+	if len(blockOidsObject) == 0 {
+		return nil, nil
 	}
+
+	ctx, cancel = context.WithTimeout(bfs.ctx, bfs.cfg.Timeout)
+	defer cancel()
+	_, oidsRC, err := bfs.objectGet(ctx, blockOidsObject[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch '%s' object with index %d: %w", bfs.cfg.IndexFileAttribute, index, err)
+	}
+	defer oidsRC.Close()
+	data, err := io.ReadAll(oidsRC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s' object with index %d: %w", bfs.cfg.IndexFileAttribute, index, err)
+	}
+	if data == nil {
+		data = []byte{}
+	}
+	return data, nil
 }
 
 // streamBlockOIDs reads block OIDs from the read closer and sends them to the OIDs channel.
@@ -343,19 +512,19 @@ func (bfs *Service) streamBlockOIDs(rc io.ReadCloser, skip int) error {
 func (bfs *Service) fetchOIDsBySearch() error {
 	startIndex := bfs.chain.BlockHeight()
 	batchSize := uint32(bfs.cfg.OIDBatchSize)
+	// last is the index of the last block found in NeoFS so far.
+	last := startIndex
 
 	for {
 		select {
 		case <-bfs.exiterToOIDDownloader:
 			return nil
 		default:
-			prm := client.PrmObjectSearch{}
 			filters := object.NewSearchFilters()
 			filters.AddFilter(bfs.cfg.BlockAttribute, fmt.Sprintf("%d", startIndex), object.MatchNumGE)
 			filters.AddFilter(bfs.cfg.BlockAttribute, fmt.Sprintf("%d", startIndex+batchSize-1), object.MatchNumLE)
-			prm.SetFilters(filters)
 			ctx, cancel := context.WithTimeout(bfs.ctx, bfs.cfg.Timeout)
-			blockOids, err := bfs.objectSearch(ctx, prm)
+			blockOids, err := bfs.client.ObjectSearch(ctx, filters)
 			cancel()
 			if err != nil {
 				if isContextCanceledErr(err) {
@@ -366,6 +535,7 @@ func (bfs *Service) fetchOIDsBySearch() error {
 
 			if len(blockOids) == 0 {
 				bfs.log.Info(fmt.Sprintf("NeoFS BlockFetcher service: no block found with index %d, stopping", startIndex))
+				bfs.handover(last)
 				return nil
 			}
 			for _, oid := range blockOids {
@@ -375,20 +545,62 @@ func (bfs *Service) fetchOIDsBySearch() error {
 				case bfs.oidsCh <- oid:
 				}
 			}
+			last = startIndex + min(uint32(len(blockOids)), batchSize) - 1
+			// Incomplete batch means there are no more blocks in the container
+			// (provided there are no gaps), so there is no need to search for
+			// the next one.
+			if uint32(len(blockOids)) < batchSize {
+				bfs.log.Info(fmt.Sprintf("NeoFS BlockFetcher service: no block found with index %d, stopping", last+1))
+				bfs.handover(last)
+				return nil
+			}
 			startIndex += batchSize
 		}
 	}
 }
 
-// readBlock decodes the block from the read closer and prepares it for adding to the blockchain.
-func (bfs *Service) readBlock(rc io.ReadCloser) (*block.Block, error) {
+// readBlock decodes the block compressed with the specified algorithm (if
+// any) from the read closer and prepares it for adding to the blockchain.
+func (bfs *Service) readBlock(rc io.ReadCloser, compression string) (*block.Block, error) {
+	defer rc.Close()
+
+	var src io.Reader = rc
+	switch compression {
+	case "":
+	case CompressionZstd:
+		d, err := zstd.NewReader(rc, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to init zstd decoder: %w", err)
+		}
+		defer d.Close()
+		src = d
+	case CompressionGzip:
+		d, err := gzip.NewReader(rc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init gzip decoder: %w", err)
+		}
+		defer d.Close()
+		src = d
+	default:
+		return nil, fmt.Errorf("unsupported block compression '%s'", compression)
+	}
 	b := block.New(bfs.stateRootInHeader)
-	r := gio.NewBinReaderFromIO(rc)
+	r := gio.NewBinReaderFromIO(src)
 	b.DecodeBinary(r)
-	rc.Close()
 	return b, r.Err
 }
 
+// objectAttribute returns the value of the object attribute with the given
+// key or an empty string if there is no such attribute.
+func objectAttribute(hdr object.Object, key string) string {
+	for _, attr := range hdr.UserAttributes() {
+		if attr.Key() == key {
+			return attr.Value()
+		}
+	}
+	return ""
+}
+
 // Shutdown stops the NeoFS BlockFetcher service. It prevents service from new
 // block OIDs search, cancels all in-progress downloading operations and waits
 // until all service routines finish their work.
@@ -454,21 +666,34 @@ func (bfs *Service) IsActive() bool {
 	return bfs.isActive.Load()
 }
 
-func (bfs *Service) objectGet(ctx context.Context, oid string) (io.ReadCloser, error) {
-	u, err := url.Parse(fmt.Sprintf("neofs:%s/%s", bfs.cfg.ContainerID, oid))
-	if err != nil {
-		return nil, err
+// Health returns an error if the service is active, but no blocks were
+// fetched from NeoFS for longer than the configured request timeout.
+func (bfs *Service) Health() error {
+	if !bfs.IsActive() {
+		return nil
 	}
-	rc, err := neofs.GetWithClient(ctx, bfs.client, bfs.account.PrivateKey(), u, false)
-	if err != nil {
-		return nil, err
+	idle := time.Since(time.Unix(0, bfs.lastProgress.Load()))
+	if idle > bfs.cfg.Timeout {
+		return fmt.Errorf("stalled %ds", int64(idle.Seconds()))
 	}
-
-	return rc, nil
+	return nil
 }
 
-func (bfs *Service) objectSearch(ctx context.Context, prm client.PrmObjectSearch) ([]oid.ID, error) {
-	return neofs.ObjectSearch(ctx, bfs.client, bfs.account.PrivateKey(), bfs.cfg.ContainerID, prm)
+// objectGet fetches the object with the specified ID applying the configured
+// rate limits.
+func (bfs *Service) objectGet(ctx context.Context, id oid.ID) (object.Object, io.ReadCloser, error) {
+	err := bfs.objectsLimiter.wait(ctx, 1)
+	if err != nil {
+		return object.Object{}, nil, err
+	}
+	hdr, rc, err := bfs.client.ObjectGet(ctx, id)
+	if err != nil {
+		return object.Object{}, nil, err
+	}
+	if bfs.bytesLimiter != nil {
+		rc = &limitedReadCloser{ReadCloser: rc, ctx: ctx, limiter: bfs.bytesLimiter}
+	}
+	return hdr, rc, nil
 }
 
 // isContextCanceledErr returns whether error is a wrapped [context.Canceled].
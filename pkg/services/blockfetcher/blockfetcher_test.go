@@ -1,10 +1,25 @@
 package blockfetcher
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/klauspost/compress/zstd"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	apistatus "github.com/nspcc-dev/neofs-sdk-go/client/status"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -42,7 +57,7 @@ func TestServiceConstructor(t *testing.T) {
 			OIDBatchSize:           0,
 			DownloaderWorkersCount: 0,
 		}
-		_, err := New(ledger, cfg, logger, mockPut.putBlock, shutdownCallback)
+		_, err := New(ledger, cfg, logger, mockPut.putBlock, nil, shutdownCallback)
 		require.Error(t, err)
 	})
 
@@ -50,7 +65,7 @@ func TestServiceConstructor(t *testing.T) {
 		cfg := config.NeoFSBlockFetcher{
 			Addresses: []string{},
 		}
-		_, err := New(ledger, cfg, logger, mockPut.putBlock, shutdownCallback)
+		_, err := New(ledger, cfg, logger, mockPut.putBlock, nil, shutdownCallback)
 		require.Error(t, err)
 	})
 
@@ -58,7 +73,7 @@ func TestServiceConstructor(t *testing.T) {
 		cfg := config.NeoFSBlockFetcher{
 			Addresses: []string{"http://localhost:8080"},
 		}
-		service, err := New(ledger, cfg, logger, mockPut.putBlock, shutdownCallback)
+		service, err := New(ledger, cfg, logger, mockPut.putBlock, nil, shutdownCallback)
 		require.NoError(t, err)
 		require.NotNil(t, service)
 
@@ -66,6 +81,9 @@ func TestServiceConstructor(t *testing.T) {
 		require.Equal(t, service.cfg.Timeout, defaultTimeout)
 		require.Equal(t, service.cfg.OIDBatchSize, defaultOIDBatchSize)
 		require.Equal(t, service.cfg.DownloaderWorkersCount, defaultDownloaderWorkersCount)
+		require.Equal(t, service.cfg.RetryAttempts, defaultRetryAttempts)
+		require.Equal(t, service.cfg.RetryBackoff, defaultRetryBackoff)
+		require.Equal(t, service.cfg.RetryMaxBackoff, defaultRetryMaxBackoff)
 		require.Equal(t, service.IsActive(), false)
 	})
 
@@ -73,7 +91,7 @@ func TestServiceConstructor(t *testing.T) {
 		cfg := config.NeoFSBlockFetcher{
 			Addresses: []string{"http://localhost:8080"},
 		}
-		service, err := New(ledger, cfg, logger, mockPut.putBlock, shutdownCallback)
+		service, err := New(ledger, cfg, logger, mockPut.putBlock, nil, shutdownCallback)
 		require.NoError(t, err)
 		err = service.Start()
 		require.Error(t, err)
@@ -92,7 +110,330 @@ func TestServiceConstructor(t *testing.T) {
 				},
 			},
 		}
-		_, err := New(ledger, cfg, logger, mockPut.putBlock, shutdownCallback)
+		_, err := New(ledger, cfg, logger, mockPut.putBlock, nil, shutdownCallback)
 		require.Error(t, err)
 	})
 }
+
+func TestServiceRetry(t *testing.T) {
+	newService := func() *Service {
+		bfs := &Service{
+			log: zap.NewNop(),
+			cfg: config.NeoFSBlockFetcher{
+				RetryAttempts:   3,
+				RetryBackoff:    time.Millisecond,
+				RetryMaxBackoff: 2 * time.Millisecond,
+			},
+		}
+		bfs.ctx, bfs.ctxCancel = context.WithCancel(context.Background())
+		return bfs
+	}
+	errTest := errors.New("test")
+
+	t.Run("success after failures", func(t *testing.T) {
+		var calls int
+		err := newService().retry(func() error {
+			calls++
+			if calls < 3 {
+				return errTest
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		var calls int
+		err := newService().retry(func() error {
+			calls++
+			return errTest
+		})
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		var (
+			calls int
+			bfs   = newService()
+		)
+		bfs.cfg.RetryBackoff = time.Hour
+		bfs.cfg.RetryMaxBackoff = time.Hour
+		err := bfs.retry(func() error {
+			calls++
+			bfs.ctxCancel()
+			return errTest
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestServiceHealth(t *testing.T) {
+	bfs := &Service{
+		log: zap.NewNop(),
+		cfg: config.NeoFSBlockFetcher{Timeout: time.Minute},
+	}
+	require.NoError(t, bfs.Health())
+
+	bfs.isActive.Store(true)
+	bfs.lastProgress.Store(time.Now().UnixNano())
+	require.NoError(t, bfs.Health())
+
+	bfs.lastProgress.Store(time.Now().Add(-2 * time.Minute).UnixNano())
+	err := bfs.Health()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stalled 120s")
+
+	bfs.isActive.Store(false)
+	require.NoError(t, bfs.Health())
+}
+
+func TestRateLimiter(t *testing.T) {
+	require.Nil(t, newRateLimiter(0))
+	var l *rateLimiter
+	require.NoError(t, l.wait(context.Background(), 100500))
+
+	l = newRateLimiter(100)
+	start := time.Now()
+	// A second worth of tokens is available immediately.
+	require.NoError(t, l.wait(context.Background(), 100))
+	require.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// Then they're refilled with the given rate.
+	require.NoError(t, l.wait(context.Background(), 10))
+	require.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, newRateLimiter(1).wait(ctx, 10), context.Canceled)
+	})
+}
+
+func TestReadBlock(t *testing.T) {
+	bfs := &Service{}
+	b := block.New(false)
+	b.Index = 5
+	b.Script = transaction.Witness{InvocationScript: []byte{}, VerificationScript: []byte{}}
+	b.RebuildMerkleRoot()
+	data, err := testserdes.EncodeBinary(b)
+	require.NoError(t, err)
+
+	var zstdData, gzipData bytes.Buffer
+	zw, err := zstd.NewWriter(&zstdData)
+	require.NoError(t, err)
+	_, err = zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	gw := gzip.NewWriter(&gzipData)
+	_, err = gw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	for _, tc := range []struct {
+		compression string
+		data        []byte
+	}{
+		{"", data},
+		{CompressionZstd, zstdData.Bytes()},
+		{CompressionGzip, gzipData.Bytes()},
+	} {
+		t.Run(tc.compression, func(t *testing.T) {
+			var hdr object.Object
+			if tc.compression != "" {
+				hdr.SetAttributes(*object.NewAttribute(CompressionAttribute, tc.compression))
+			}
+			actual, err := bfs.readBlock(io.NopCloser(bytes.NewReader(tc.data)), objectAttribute(hdr, CompressionAttribute))
+			require.NoError(t, err)
+			require.Equal(t, b.Hash(), actual.Hash())
+		})
+	}
+	t.Run("mismatch", func(t *testing.T) {
+		_, err := bfs.readBlock(io.NopCloser(bytes.NewReader(data)), CompressionGzip)
+		require.Error(t, err)
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := bfs.readBlock(io.NopCloser(bytes.NewReader(data)), "lz4")
+		require.ErrorContains(t, err, "unsupported block compression 'lz4'")
+	})
+}
+
+// testBlocks returns encoded blocks with the given indexes.
+func testBlocks(t *testing.T, indexes ...uint32) [][]byte {
+	res := make([][]byte, len(indexes))
+	for i, index := range indexes {
+		b := block.New(false)
+		b.Index = index
+		b.Script = transaction.Witness{InvocationScript: []byte{}, VerificationScript: []byte{}}
+		b.RebuildMerkleRoot()
+		data, err := testserdes.EncodeBinary(b)
+		require.NoError(t, err)
+		res[i] = data
+	}
+	return res
+}
+
+// testRun contains the results of the Service run with MockClient.
+type testRun struct {
+	bfs      *Service
+	lock     sync.Mutex
+	indexes  []uint32
+	handover []uint32
+	done     chan struct{}
+}
+
+func newTestRun(t *testing.T, c Client, cfg config.NeoFSBlockFetcher, height uint32) *testRun {
+	r := &testRun{done: make(chan struct{})}
+	cfg.DownloaderWorkersCount = 2
+	cfg.BlockAttribute = "Block"
+	cfg.IndexFileAttribute = "Index"
+	r.bfs = NewWithClient(&mockLedger{height: height}, cfg, zap.NewNop(), c, func(b *block.Block) error {
+		r.lock.Lock()
+		r.indexes = append(r.indexes, b.Index)
+		r.lock.Unlock()
+		return nil
+	}, func(last uint32) {
+		r.handover = append(r.handover, last)
+	}, func() {
+		close(r.done)
+	})
+	require.NoError(t, r.bfs.Start())
+	return r
+}
+
+func (r *testRun) wait(t *testing.T) {
+	select {
+	case <-r.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("service is not stopped")
+	}
+	require.False(t, r.bfs.IsActive())
+	slices.Sort(r.indexes)
+}
+
+func TestServiceWithMockClient(t *testing.T) {
+	errTest := errors.New("test")
+
+	t.Run("search", func(t *testing.T) {
+		c := NewMockClient()
+		for i, data := range testBlocks(t, 1, 2, 3, 4, 5) {
+			c.Put(data, *object.NewAttribute("Block", strconv.Itoa(i+1)))
+		}
+		r := newTestRun(t, c, config.NeoFSBlockFetcher{
+			SkipIndexFilesSearch: true,
+			OIDBatchSize:         3,
+		}, 1)
+		r.wait(t)
+		require.Equal(t, []uint32{1, 2, 3, 4, 5}, r.indexes)
+		require.Equal(t, []uint32{5}, r.handover)
+		require.True(t, c.IsClosed())
+	})
+
+	t.Run("index files", func(t *testing.T) {
+		c := NewMockClient()
+		var oids [2][]byte
+		for i, data := range testBlocks(t, 0, 1, 2, 3) {
+			id := c.Put(data)
+			oids[i/2] = append(oids[i/2], id[:]...)
+		}
+		for i := range oids {
+			c.Put(oids[i], *object.NewAttribute("Index", strconv.Itoa(i)))
+		}
+		r := newTestRun(t, c, config.NeoFSBlockFetcher{IndexFileSize: 2}, 1)
+		r.wait(t)
+		// The first block is skipped as it's already in the chain.
+		require.Equal(t, []uint32{1, 2, 3}, r.indexes)
+		require.Equal(t, []uint32{3}, r.handover)
+		require.True(t, c.IsClosed())
+	})
+
+	t.Run("missing block", func(t *testing.T) {
+		c := NewMockClient()
+		id := c.Put(testBlocks(t, 0)[0])
+		oids := append(id[:], make([]byte, oidSize)...)
+		c.Put(oids, *object.NewAttribute("Index", "0"))
+		r := newTestRun(t, c, config.NeoFSBlockFetcher{
+			IndexFileSize: 2,
+			RetryAttempts: 2,
+			RetryBackoff:  time.Millisecond,
+		}, 0)
+		r.wait(t)
+		require.NotContains(t, r.indexes, uint32(1))
+		require.True(t, c.IsClosed())
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		c := NewMockClient()
+		c.SetError(errTest)
+		r := newTestRun(t, c, config.NeoFSBlockFetcher{SkipIndexFilesSearch: true}, 0)
+		r.wait(t)
+		require.Nil(t, r.indexes)
+		require.Nil(t, r.handover)
+		require.True(t, c.IsClosed())
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		c := NewMockClient()
+		c.SetError(errTest)
+		// Index file fetching is stuck in retries until shutdown.
+		r := newTestRun(t, c, config.NeoFSBlockFetcher{
+			IndexFileSize:   2,
+			RetryBackoff:    time.Hour,
+			RetryMaxBackoff: time.Hour,
+		}, 0)
+		require.True(t, r.bfs.IsActive())
+		r.bfs.Shutdown()
+		r.wait(t)
+		require.Nil(t, r.handover)
+		require.True(t, c.IsClosed())
+	})
+}
+
+func TestMockClient(t *testing.T) {
+	c := NewMockClient()
+	id1 := c.Put([]byte{1}, *object.NewAttribute("Block", "1"))
+	id2 := c.Put([]byte{1}, *object.NewAttribute("Block", "20"), *object.NewAttribute("Index", "x"))
+	require.NotEqual(t, id1, id2)
+
+	hdr, rc, err := c.ObjectGet(context.Background(), id2)
+	require.NoError(t, err)
+	require.Equal(t, "x", objectAttribute(hdr, "Index"))
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, data)
+
+	_, _, err = c.ObjectGet(context.Background(), oid.ID{})
+	require.ErrorIs(t, err, apistatus.ErrObjectNotFound)
+
+	search := func(t *testing.T, key, value string, op object.SearchMatchType) []oid.ID {
+		filters := object.NewSearchFilters()
+		filters.AddFilter(key, value, op)
+		ids, err := c.ObjectSearch(context.Background(), filters)
+		require.NoError(t, err)
+		return ids
+	}
+	require.Equal(t, []oid.ID{id1, id2}, search(t, "Block", "1", object.MatchNumGE))
+	require.Equal(t, []oid.ID{id2}, search(t, "Block", "1", object.MatchNumGT))
+	require.Equal(t, []oid.ID{id1}, search(t, "Block", "19", object.MatchNumLE))
+	require.Nil(t, search(t, "Block", "1", object.MatchNumLT))
+	require.Equal(t, []oid.ID{id2}, search(t, "Index", "x", object.MatchStringEqual))
+	require.Equal(t, []oid.ID{id1}, search(t, "Index", "x", object.MatchStringNotEqual))
+	require.Equal(t, []oid.ID{id1}, search(t, "Index", "", object.MatchNotPresent))
+
+	filters := object.NewSearchFilters()
+	filters.AddFilter("Block", "1", object.MatchCommonPrefix)
+	_, err = c.ObjectSearch(context.Background(), filters)
+	require.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.ObjectSearch(ctx, nil)
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.NoError(t, c.Close())
+	require.True(t, c.IsClosed())
+	_, _, err = c.ObjectGet(context.Background(), id1)
+	require.Error(t, err)
+}
@@ -0,0 +1,218 @@
+package blockfetcher
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
+	"github.com/nspcc-dev/neofs-sdk-go/client"
+	apistatus "github.com/nspcc-dev/neofs-sdk-go/client/status"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
+)
+
+// Client is an interface to NeoFS container sufficient for Service. The
+// default implementation uses NeoFS SDK client connected to the first
+// configured address, MockClient can be used for tests.
+type Client interface {
+	// ObjectGet returns the header and the payload reader of the object with
+	// the specified ID. The reader must be closed by the caller.
+	ObjectGet(ctx context.Context, id oid.ID) (object.Object, io.ReadCloser, error)
+	// ObjectSearch returns IDs of the objects matching all the filters.
+	ObjectSearch(ctx context.Context, filters object.SearchFilters) ([]oid.ID, error)
+	// Close releases client resources, it's called on Service shutdown.
+	Close() error
+}
+
+// sdkClient is a Client implementation using NeoFS SDK.
+type sdkClient struct {
+	client      *client.Client
+	key         *keys.PrivateKey
+	tokens      *neofs.Tokens
+	containerID string
+}
+
+// ObjectGet implements the Client interface.
+func (c *sdkClient) ObjectGet(ctx context.Context, id oid.ID) (object.Object, io.ReadCloser, error) {
+	u, err := url.Parse(fmt.Sprintf("neofs:%s/%s", c.containerID, id))
+	if err != nil {
+		return object.Object{}, nil, err
+	}
+	return neofs.ObjectGet(ctx, c.client, c.key, c.tokens, u)
+}
+
+// ObjectSearch implements the Client interface.
+func (c *sdkClient) ObjectSearch(ctx context.Context, filters object.SearchFilters) ([]oid.ID, error) {
+	prm := client.PrmObjectSearch{}
+	prm.SetFilters(filters)
+	return neofs.ObjectSearch(ctx, c.client, c.key, c.tokens, c.containerID, prm)
+}
+
+// Close implements the Client interface.
+func (c *sdkClient) Close() error {
+	return c.client.Close()
+}
+
+// MockClient is an in-memory Client implementation allowing to test Service
+// (and the code using it) without NeoFS network. Objects are searched for in
+// the order they were put, search filters support attribute string equality
+// and numeric comparisons. It's safe for concurrent use.
+type MockClient struct {
+	lock    sync.RWMutex
+	objects []mockObject
+	err     error
+	closed  bool
+}
+
+type mockObject struct {
+	id      oid.ID
+	hdr     object.Object
+	payload []byte
+}
+
+// NewMockClient returns a new empty MockClient.
+func NewMockClient() *MockClient {
+	return &MockClient{}
+}
+
+// Put stores the object with the given payload and attributes and returns
+// its ID.
+func (c *MockClient) Put(payload []byte, attrs ...object.Attribute) oid.ID {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var n [4]byte
+	binary.LittleEndian.PutUint32(n[:], uint32(len(c.objects)))
+	o := mockObject{
+		id:      sha256.Sum256(append(n[:], payload...)),
+		payload: bytes.Clone(payload),
+	}
+	o.hdr.SetAttributes(attrs...)
+	c.objects = append(c.objects, o)
+	return o.id
+}
+
+// SetError makes all subsequent requests fail with the given error, nil
+// error restores normal operation.
+func (c *MockClient) SetError(err error) {
+	c.lock.Lock()
+	c.err = err
+	c.lock.Unlock()
+}
+
+// IsClosed returns true if the client was closed.
+func (c *MockClient) IsClosed() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.closed
+}
+
+// ObjectGet implements the Client interface.
+func (c *MockClient) ObjectGet(ctx context.Context, id oid.ID) (object.Object, io.ReadCloser, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if err := c.check(ctx); err != nil {
+		return object.Object{}, nil, err
+	}
+	for i := range c.objects {
+		if c.objects[i].id == id {
+			return c.objects[i].hdr, io.NopCloser(bytes.NewReader(c.objects[i].payload)), nil
+		}
+	}
+	return object.Object{}, nil, fmt.Errorf("object %s: %w", id, apistatus.ErrObjectNotFound)
+}
+
+// ObjectSearch implements the Client interface.
+func (c *MockClient) ObjectSearch(ctx context.Context, filters object.SearchFilters) ([]oid.ID, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if err := c.check(ctx); err != nil {
+		return nil, err
+	}
+	var res []oid.ID
+	for i := range c.objects {
+		ok, err := matchFilters(c.objects[i].hdr, filters)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			res = append(res, c.objects[i].id)
+		}
+	}
+	return res, nil
+}
+
+// Close implements the Client interface.
+func (c *MockClient) Close() error {
+	c.lock.Lock()
+	c.closed = true
+	c.lock.Unlock()
+	return nil
+}
+
+// check returns an error if the request can't be served.
+func (c *MockClient) check(ctx context.Context) error {
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case c.closed:
+		return errors.New("client is closed")
+	default:
+		return c.err
+	}
+}
+
+// matchFilters checks whether the object header matches all the filters.
+func matchFilters(hdr object.Object, filters object.SearchFilters) (bool, error) {
+	for i := range filters {
+		var (
+			f        = &filters[i]
+			val, has = "", false
+		)
+		for _, attr := range hdr.UserAttributes() {
+			if attr.Key() == f.Header() {
+				val, has = attr.Value(), true
+				break
+			}
+		}
+		switch op := f.Operation(); op {
+		case object.MatchStringEqual:
+			if !has || val != f.Value() {
+				return false, nil
+			}
+		case object.MatchStringNotEqual:
+			if has && val == f.Value() {
+				return false, nil
+			}
+		case object.MatchNotPresent:
+			if has {
+				return false, nil
+			}
+		case object.MatchNumGT, object.MatchNumGE, object.MatchNumLT, object.MatchNumLE:
+			fv, err := strconv.ParseInt(f.Value(), 10, 64)
+			if err != nil {
+				return false, fmt.Errorf("invalid numeric filter value '%s': %w", f.Value(), err)
+			}
+			v, err := strconv.ParseInt(val, 10, 64)
+			if !has || err != nil {
+				return false, nil
+			}
+			if op == object.MatchNumGT && v <= fv || op == object.MatchNumGE && v < fv ||
+				op == object.MatchNumLT && v >= fv || op == object.MatchNumLE && v > fv {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported filter operation %s", op)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,80 @@
+package blockfetcher
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by all downloading routines. Bucket
+// capacity is equal to the rate, so at most a second worth of tokens can be
+// accumulated. A nil rateLimiter doesn't limit anything.
+type rateLimiter struct {
+	lock sync.Mutex
+	rate float64
+	// tokens can be negative since they're reserved in advance.
+	tokens float64
+	last   time.Time
+}
+
+// limitedReadCloser is an io.ReadCloser consuming rateLimiter tokens for
+// every byte read.
+type limitedReadCloser struct {
+	io.ReadCloser
+	ctx     context.Context
+	limiter *rateLimiter
+}
+
+// newRateLimiter creates a rateLimiter allowing rate tokens per second, it
+// returns nil if rate is not positive.
+func newRateLimiter(rate int64) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		rate:   float64(rate),
+		tokens: float64(rate),
+		last:   time.Now(),
+	}
+}
+
+// wait reserves n tokens and blocks until they're available or ctx is done.
+// Requests exceeding bucket capacity are allowed, they make subsequent
+// callers wait longer.
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	if l == nil || n <= 0 {
+		return nil
+	}
+	l.lock.Lock()
+	now := time.Now()
+	l.tokens = min(l.rate, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.lock.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Read implements the io.Reader interface.
+func (r *limitedReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if lErr := r.limiter.wait(r.ctx, n); lErr != nil && err == nil {
+		err = lErr
+	}
+	return n, err
+}
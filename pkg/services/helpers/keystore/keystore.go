@@ -0,0 +1,24 @@
+package keystore
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// Open creates a service keystore from the given wallet configuration. If
+// Keystore directory is specified, wallets are loaded lazily and account
+// passwords are resolved via [config.Wallet.GetPassword]. Otherwise a single
+// wallet from Path is read and its accounts are unlocked with Password
+// immediately, an error is returned if none of them can be unlocked.
+func Open(cfg config.Wallet) (*wallet.Keystore, error) {
+	if cfg.Keystore != "" {
+		return wallet.NewKeystoreFromDir(cfg.Keystore, cfg.GetPassword)
+	}
+	ks := wallet.NewKeystore(func(string) (string, bool) {
+		return cfg.Password, true
+	}, cfg.Path)
+	if err := ks.Unlock(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
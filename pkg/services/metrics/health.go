@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"go.uber.org/zap"
+)
+
+// HealthChecker is an interface to the node able to report the state of its
+// subsystems.
+type HealthChecker interface {
+	// Health returns the state of node subsystems keyed by their names, nil
+	// error means the subsystem is working properly.
+	Health() map[string]error
+	// Ready returns an error if the node is not ready to serve requests.
+	Ready() error
+}
+
+// HealthStatus is a JSON response of health and readiness endpoints.
+type HealthStatus struct {
+	Status   string            `json:"status"`
+	Error    string            `json:"error,omitempty"`
+	Services map[string]string `json:"services,omitempty"`
+}
+
+// Health and readiness statuses.
+const (
+	StatusOK       = "ok"
+	StatusFailed   = "failed"
+	StatusNotReady = "not ready"
+)
+
+// NewHealthService creates a new service exposing /health and /ready
+// endpoints. /health responds with 200 if all node subsystems are working
+// properly and with 503 otherwise, listing the state of every subsystem.
+// /ready additionally requires the node to be synchronized.
+func NewHealthService(cfg config.BasicService, checker HealthChecker, log *zap.Logger) *Service {
+	if log == nil {
+		return nil
+	}
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		res := getHealthStatus(checker)
+		writeHealthStatus(w, res)
+	})
+	handler.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		res := getHealthStatus(checker)
+		if err := checker.Ready(); err != nil {
+			res.Status = StatusNotReady
+			res.Error = err.Error()
+		}
+		writeHealthStatus(w, res)
+	})
+
+	addrs := cfg.Addresses
+	srvs := make([]*http.Server, len(addrs))
+	for i, addr := range addrs {
+		srvs[i] = &http.Server{
+			Addr:    addr,
+			Handler: handler,
+		}
+	}
+	return NewService("Health", srvs, cfg, log)
+}
+
+func getHealthStatus(checker HealthChecker) HealthStatus {
+	var res = HealthStatus{
+		Status:   StatusOK,
+		Services: make(map[string]string),
+	}
+	for name, err := range checker.Health() {
+		if err != nil {
+			res.Status = StatusFailed
+			res.Services[name] = err.Error()
+		} else {
+			res.Services[name] = StatusOK
+		}
+	}
+	return res
+}
+
+func writeHealthStatus(w http.ResponseWriter, res HealthStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	if res.Status != StatusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
@@ -207,7 +207,29 @@ func TestNotary(t *testing.T) {
 		require.NoError(t, err)
 		return fallback
 	}
+	// makeDeposits funds Notary deposits of requesters that don't have them
+	// yet, they sponsor their fallback transactions.
+	makeDeposits := func(requesters []requester) {
+		var txs []*transaction.Transaction
+		for _, r := range requesters {
+			for _, acc := range r.accounts {
+				if bc.GetNotaryBalance(acc.ScriptHash()).Sign() > 0 {
+					continue
+				}
+				tx := gasValidatorInvoker.PrepareInvoke(t, "transfer", e.Validator.ScriptHash(), bc.GetNotaryContractScriptHash(), 10_0000_0000, []any{acc.ScriptHash(), int64(bc.BlockHeight() + 100)})
+				txs = append(txs, tx)
+			}
+		}
+		if len(txs) == 0 {
+			return
+		}
+		e.AddNewBlock(t, txs...)
+		for _, tx := range txs {
+			e.CheckHalt(t, tx.Hash(), stackitem.Make(true))
+		}
+	}
 	createMixedRequest := func(requesters []requester, NVBincrements ...uint32) []*payload.P2PNotaryRequest {
+		makeDeposits(requesters)
 		mainTx := *transaction.New([]byte{byte(opcode.RET)}, 11000000)
 		mainTx.Nonce = nonce
 		nonce++
@@ -754,10 +776,11 @@ func TestNotary(t *testing.T) {
 	requester1, _ := wallet.NewAccount()
 	requester2, _ := wallet.NewAccount()
 	amount := int64(100_0000_0000)
+	notaryBalance := e.Chain.GetUtilityTokenBalance(notaryHash)
 	gasValidatorInvoker.Invoke(t, true, "transfer", e.Validator.ScriptHash(), bc.GetNotaryContractScriptHash(), amount, []any{requester1.ScriptHash(), int64(bc.BlockHeight() + 50)})
-	e.CheckGASBalance(t, notaryHash, big.NewInt(amount))
+	e.CheckGASBalance(t, notaryHash, new(big.Int).Add(notaryBalance, big.NewInt(amount)))
 	gasValidatorInvoker.Invoke(t, true, "transfer", e.Validator.ScriptHash(), bc.GetNotaryContractScriptHash(), amount, []any{requester2.ScriptHash(), int64(bc.BlockHeight() + 50)})
-	e.CheckGASBalance(t, notaryHash, big.NewInt(2*amount))
+	e.CheckGASBalance(t, notaryHash, new(big.Int).Add(notaryBalance, big.NewInt(2*amount)))
 
 	// create request for 2 standard signatures => main tx should be completed after the second request is added to the pool
 	requests = createMixedRequest([]requester{
@@ -21,18 +21,15 @@ func (n *Notary) UpdateNotaryNodes(notaryNodes keys.PublicKeys) {
 
 	var acc *wallet.Account
 	for _, node := range notaryNodes {
-		acc = n.wallet.GetAccount(node.GetScriptHash())
+		var err error
+		acc, err = n.keystore.GetAccount(node.GetScriptHash())
+		if err != nil {
+			n.Config.Log.Warn("can't unlock notary node account",
+				zap.String("address", address.Uint160ToString(node.GetScriptHash())),
+				zap.Error(err))
+			break
+		}
 		if acc != nil {
-			if acc.CanSign() {
-				break
-			}
-			err := acc.Decrypt(n.Config.MainCfg.UnlockWallet.Password, n.wallet.Scrypt)
-			if err != nil {
-				n.Config.Log.Warn("can't unlock notary node account",
-					zap.String("address", address.Uint160ToString(acc.Contract.ScriptHash())),
-					zap.Error(err))
-				acc = nil
-			}
 			break
 		}
 	}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -20,6 +21,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/keystore"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -32,6 +34,7 @@ type (
 	Ledger interface {
 		BlockHeight() uint32
 		GetMaxVerificationGAS() int64
+		GetNotaryBalance(acc util.Uint160) *big.Int
 		GetNotaryContractScriptHash() util.Uint160
 		SubscribeForBlocks(ch chan *block.Block)
 		UnsubscribeFromBlocks(ch chan *block.Block)
@@ -57,11 +60,15 @@ type (
 		// requests represents a map of main transactions which needs to be completed
 		// with the associated fallback transactions grouped by the main transaction hash
 		requests map[util.Uint256]*request
+		// sponsors contains the number of pending fallback transactions and
+		// their fees grouped by their sponsors (payers), it's protected by
+		// reqMtx.
+		sponsors map[util.Uint160]sponsorState
 
 		// accMtx protects account.
 		accMtx      sync.RWMutex
 		currAccount *wallet.Account
-		wallet      *wallet.Wallet
+		keystore    *wallet.Keystore
 
 		mp *mempool.Pool
 		// requests channel
@@ -100,6 +107,15 @@ type (
 		witnessInfo []witnessInfo
 	}
 
+	// sponsorState represents pending fallback transactions of a single sponsor.
+	sponsorState struct {
+		// requests is the number of pending fallback transactions.
+		requests int
+		// fees is the sum of system and network fees of pending fallback
+		// transactions that are to be paid from the sponsor's deposit.
+		fees int64
+	}
+
 	// witnessInfo represents information about the signer and its witness.
 	witnessInfo struct {
 		typ RequestType
@@ -131,24 +147,17 @@ func (r request) isMainCompleted() bool {
 
 // NewNotary returns a new Notary module.
 func NewNotary(cfg Config, net netmode.Magic, mp *mempool.Pool, onTransaction func(tx *transaction.Transaction) error) (*Notary, error) {
-	w := cfg.MainCfg.UnlockWallet
-	wall, err := wallet.NewWalletFromFile(w.Path)
+	ks, err := keystore.Open(cfg.MainCfg.UnlockWallet)
 	if err != nil {
 		return nil, err
 	}
 
-	var haveAccount = slices.ContainsFunc(wall.Accounts, func(acc *wallet.Account) bool {
-		return acc.Decrypt(w.Password, wall.Scrypt) == nil
-	})
-	if !haveAccount {
-		return nil, errors.New("no wallet account could be unlocked")
-	}
-
 	return &Notary{
 		requests:      make(map[util.Uint256]*request),
+		sponsors:      make(map[util.Uint160]sponsorState),
 		Config:        cfg,
 		Network:       net,
-		wallet:        wall,
+		keystore:      ks,
 		onTransaction: onTransaction,
 		newTxs:        make(chan txHashPair, defaultTxChannelCapacity),
 		mp:            mp,
@@ -213,6 +222,14 @@ drainLoop:
 	close(n.done)
 }
 
+// Health returns an error if the service is not running.
+func (n *Notary) Health() error {
+	if !n.started.Load() {
+		return errors.New("not started")
+	}
+	return nil
+}
+
 // Shutdown stops the Notary module. It can only be called once, subsequent calls
 // to Shutdown on the same instance are no-op. The instance that was stopped can
 // not be started again by calling Start (use a new instance if needed).
@@ -223,7 +240,7 @@ func (n *Notary) Shutdown() {
 	n.Config.Log.Info("stopping notary service")
 	close(n.stopCh)
 	<-n.done
-	n.wallet.Close()
+	n.keystore.Close()
 	_ = n.Config.Log.Sync()
 }
 
@@ -253,15 +270,32 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 			zap.String("fallback hash", payload.FallbackTransaction.Hash().StringLE()),
 			zap.String("verification error", validationErr.Error()))
 	}
+	sponsor := fallbackSponsor(payload.FallbackTransaction)
 	n.reqMtx.Lock()
 	defer n.reqMtx.Unlock()
 	r, exists := n.requests[payload.MainTransaction.Hash()]
+	if exists && slices.ContainsFunc(r.fallbacks, func(fb *transaction.Transaction) bool {
+		return fb.Hash().Equals(payload.FallbackTransaction.Hash())
+	}) {
+		return // then we already have processed this request
+	}
+	if err := n.verifySponsorWitness(payload.FallbackTransaction); err != nil {
+		n.Config.Log.Info("fallback transaction sponsor witness check failed, request is ignored",
+			zap.String("sponsor", sponsor.StringLE()),
+			zap.String("main hash", payload.MainTransaction.Hash().StringLE()),
+			zap.String("fallback hash", payload.FallbackTransaction.Hash().StringLE()),
+			zap.Error(err))
+		return
+	}
+	if err := n.checkSponsor(sponsor, payload.FallbackTransaction); err != nil {
+		n.Config.Log.Info("fallback transaction can't be sponsored, request is ignored",
+			zap.String("sponsor", sponsor.StringLE()),
+			zap.String("main hash", payload.MainTransaction.Hash().StringLE()),
+			zap.String("fallback hash", payload.FallbackTransaction.Hash().StringLE()),
+			zap.Error(err))
+		return
+	}
 	if exists {
-		if slices.ContainsFunc(r.fallbacks, func(fb *transaction.Transaction) bool {
-			return fb.Hash().Equals(payload.FallbackTransaction.Hash())
-		}) {
-			return // then we already have processed this request
-		}
 		r.minNotValidBefore = min(r.minNotValidBefore, nvbFallback)
 	} else {
 		// Avoid changes in the main transaction witnesses got from the notary request pool to
@@ -281,6 +315,10 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 	// affect the other users of notary pool and cause race. Avoid this by making
 	// the copy.
 	r.fallbacks = append(r.fallbacks, payload.FallbackTransaction.Copy())
+	st := n.sponsors[sponsor]
+	st.requests++
+	st.fees += fallbackFees(payload.FallbackTransaction)
+	n.sponsors[sponsor] = st
 	if exists && r.isMainCompleted() || validationErr != nil {
 		return
 	}
@@ -353,15 +391,72 @@ func (n *Notary) OnRequestRemoval(pld *payload.P2PNotaryRequest) {
 	if !ok {
 		return
 	}
+	n.removeFallback(r, pld.MainTransaction.Hash(), pld.FallbackTransaction.Hash())
+}
+
+// removeFallback removes the fallback transaction with the given hash from
+// the request along with the request itself if there are no fallbacks left.
+// It must be called under reqMtx lock.
+func (n *Notary) removeFallback(r *request, mainHash util.Uint256, fbHash util.Uint256) {
 	for i, fb := range r.fallbacks {
-		if fb.Hash().Equals(pld.FallbackTransaction.Hash()) {
+		if fb.Hash().Equals(fbHash) {
+			sponsor := fallbackSponsor(fb)
+			st := n.sponsors[sponsor]
+			st.requests--
+			st.fees -= fallbackFees(fb)
+			if st.requests <= 0 {
+				delete(n.sponsors, sponsor)
+			} else {
+				n.sponsors[sponsor] = st
+			}
 			r.fallbacks = append(r.fallbacks[:i], r.fallbacks[i+1:]...)
 			break
 		}
 	}
 	if len(r.fallbacks) == 0 {
-		delete(n.requests, r.main.Hash())
+		delete(n.requests, mainHash)
+	}
+}
+
+// fallbackSponsor returns the account paying for the fallback transaction,
+// it's the second signer of the fallback (the first one is always the Notary
+// contract).
+func fallbackSponsor(fb *transaction.Transaction) util.Uint160 {
+	return fb.Signers[1].Account
+}
+
+// fallbackFees returns the amount of GAS to be paid for the fallback
+// transaction from its sponsor's Notary deposit.
+func fallbackFees(fb *transaction.Transaction) int64 {
+	return fb.SystemFee + fb.NetworkFee
+}
+
+// verifySponsorWitness checks the witness of the fallback transaction sponsor
+// (its second signer that pays the fees), it ensures that nobody can spend the
+// sponsor's deposit without its consent.
+func (n *Notary) verifySponsorWitness(fb *transaction.Transaction) error {
+	if len(fb.Signers) < 2 || len(fb.Scripts) < 2 {
+		return errors.New("fallback transaction has no sponsor")
 	}
+	_, err := n.Config.Chain.VerifyWitness(fb.Signers[1].Account, fb, &fb.Scripts[1], n.Config.Chain.GetMaxVerificationGAS())
+	return err
+}
+
+// checkSponsor checks whether the sponsor is allowed to pay for one more
+// fallback transaction: the number of its pending requests must not exceed
+// the configured limit and its Notary deposit (that is funded with NEP-17 GAS
+// transfers to the Notary contract) must cover the fees of all of them. It
+// must be called under reqMtx lock.
+func (n *Notary) checkSponsor(sponsor util.Uint160, fb *transaction.Transaction) error {
+	st := n.sponsors[sponsor]
+	if limit := n.Config.MainCfg.MaxRequestsPerSponsor; limit > 0 && st.requests >= limit {
+		return fmt.Errorf("too many pending requests: %d", st.requests)
+	}
+	need := big.NewInt(st.fees + fallbackFees(fb))
+	if balance := n.Config.Chain.GetNotaryBalance(sponsor); balance.Cmp(need) < 0 {
+		return fmt.Errorf("insufficient Notary deposit: %s, need %s", balance, need)
+	}
+	return nil
 }
 
 // PostPersist is a callback which is called after a new block event is received.
@@ -466,15 +561,7 @@ func (n *Notary) newTxCallbackLoop() {
 			if isMain {
 				r.isSent = true
 			} else {
-				for i := range r.fallbacks {
-					if r.fallbacks[i].Hash() == tx.tx.Hash() {
-						r.fallbacks = append(r.fallbacks[:i], r.fallbacks[i+1:]...)
-						break
-					}
-				}
-				if len(r.fallbacks) == 0 {
-					delete(n.requests, tx.mainHash)
-				}
+				n.removeFallback(r, tx.mainHash, tx.tx.Hash())
 			}
 			n.reqMtx.Unlock()
 		case <-n.stopCh:
@@ -1,6 +1,8 @@
 package notary
 
 import (
+	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/fakechain"
@@ -10,6 +12,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -489,3 +492,82 @@ func TestVerifyIncompleteRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestSponsorChecks(t *testing.T) {
+	bc := fakechain.NewFakeChain()
+	notaryContractHash := util.Uint160{1, 2, 3}
+	bc.NotaryContractScriptHash = notaryContractHash
+	bc.MaxVerificationGAS = 1_0000_0000
+	bc.NotaryBalance = big.NewInt(10)
+	bc.VerifyWitnessF = func() (int64, error) { return 0, nil }
+	acc, ntr, _ := getTestNotary(t, bc, "./testdata/notary1.json", "one")
+	ntr.Config.MainCfg.MaxRequestsPerSponsor = 2
+	ntr.UpdateNotaryNodes(keys.PublicKeys{acc.PublicKey()})
+	ntr.started.Store(true)
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	sponsor1, sponsor2 := util.Uint160{4, 5, 6}, util.Uint160{7, 8, 9}
+	newRequest := func(nonce uint32, sponsor util.Uint160, fee int64) *payload.P2PNotaryRequest {
+		return &payload.P2PNotaryRequest{
+			MainTransaction: &transaction.Transaction{
+				Nonce:      nonce,
+				Attributes: []transaction.Attribute{{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 1}}},
+				Signers:    []transaction.Signer{{Account: priv.GetScriptHash()}, {Account: notaryContractHash}},
+				Scripts:    []transaction.Witness{{VerificationScript: priv.PublicKey().GetVerificationScript()}, {}},
+			},
+			FallbackTransaction: &transaction.Transaction{
+				Nonce:      nonce,
+				SystemFee:  fee,
+				Attributes: []transaction.Attribute{{Type: transaction.NotValidBeforeT, Value: &transaction.NotValidBefore{Height: 100}}},
+				Signers:    []transaction.Signer{{Account: notaryContractHash}, {Account: sponsor}},
+				Scripts:    []transaction.Witness{{}, {}},
+			},
+		}
+	}
+
+	t.Run("max requests", func(t *testing.T) {
+		reqs := []*payload.P2PNotaryRequest{newRequest(1, sponsor1, 1), newRequest(2, sponsor1, 1), newRequest(3, sponsor1, 1), newRequest(4, sponsor2, 1)}
+		for _, r := range reqs {
+			ntr.OnNewRequest(r)
+		}
+		require.Equal(t, 3, len(ntr.requests))
+		require.NotContains(t, ntr.requests, reqs[2].MainTransaction.Hash())
+		require.Equal(t, map[util.Uint160]sponsorState{sponsor1: {2, 2}, sponsor2: {1, 1}}, ntr.sponsors)
+
+		// Request removal allows to accept new ones from the same sponsor.
+		ntr.OnRequestRemoval(reqs[0])
+		require.Equal(t, map[util.Uint160]sponsorState{sponsor1: {1, 1}, sponsor2: {1, 1}}, ntr.sponsors)
+		ntr.OnNewRequest(reqs[2])
+		require.Contains(t, ntr.requests, reqs[2].MainTransaction.Hash())
+		require.Equal(t, map[util.Uint160]sponsorState{sponsor1: {2, 2}, sponsor2: {1, 1}}, ntr.sponsors)
+
+		ntr.OnRequestRemoval(reqs[3])
+		require.Equal(t, map[util.Uint160]sponsorState{sponsor1: {2, 2}}, ntr.sponsors)
+		ntr.OnRequestRemoval(reqs[1])
+		ntr.OnRequestRemoval(reqs[2])
+		require.Empty(t, ntr.requests)
+		require.Empty(t, ntr.sponsors)
+	})
+
+	t.Run("insufficient deposit", func(t *testing.T) {
+		reqs := []*payload.P2PNotaryRequest{newRequest(5, sponsor1, 6), newRequest(6, sponsor1, 5), newRequest(7, sponsor1, 4)}
+		for _, r := range reqs {
+			ntr.OnNewRequest(r)
+		}
+		require.Equal(t, 2, len(ntr.requests))
+		require.NotContains(t, ntr.requests, reqs[1].MainTransaction.Hash())
+		require.Equal(t, map[util.Uint160]sponsorState{sponsor1: {2, 10}}, ntr.sponsors)
+		ntr.OnRequestRemoval(reqs[0])
+		ntr.OnRequestRemoval(reqs[2])
+		require.Empty(t, ntr.sponsors)
+	})
+
+	t.Run("bad sponsor witness", func(t *testing.T) {
+		bc.VerifyWitnessF = func() (int64, error) { return 0, errors.New("bad witness") }
+		t.Cleanup(func() { bc.VerifyWitnessF = func() (int64, error) { return 0, nil } })
+		ntr.OnNewRequest(newRequest(8, sponsor1, 1))
+		require.Empty(t, ntr.requests)
+		require.Empty(t, ntr.sponsors)
+	})
+}
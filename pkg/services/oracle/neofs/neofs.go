@@ -17,6 +17,7 @@ import (
 	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
 	"github.com/nspcc-dev/neofs-sdk-go/object"
 	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
+	"github.com/nspcc-dev/neofs-sdk-go/session"
 	"github.com/nspcc-dev/neofs-sdk-go/user"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -46,20 +47,22 @@ var (
 
 // Get returns a neofs object from the provided url.
 // URI scheme is "neofs:<Container-ID>/<Object-ID/<Command>/<Params>".
-// If Command is not provided, full object is requested.
-func Get(ctx context.Context, priv *keys.PrivateKey, u *url.URL, addr string) (io.ReadCloser, error) {
+// If Command is not provided, full object is requested. Suitable tokens
+// (if any) are attached to the request.
+func Get(ctx context.Context, priv *keys.PrivateKey, tokens *Tokens, u *url.URL, addr string) (io.ReadCloser, error) {
 	c, err := GetSDKClient(ctx, addr, 0)
 	if err != nil {
 		return clientCloseWrapper{c: c}, fmt.Errorf("failed to create client: %w", err)
 	}
-	return GetWithClient(ctx, c, priv, u, true)
+	return GetWithClient(ctx, c, priv, tokens, u, true)
 }
 
 // GetWithClient returns a neofs object from the provided url using the provided client.
 // URI scheme is "neofs:<Container-ID>/<Object-ID/<Command>/<Params>".
-// If Command is not provided, full object is requested. If wrapClientCloser is true,
-// the client will be closed when the returned ReadCloser is closed.
-func GetWithClient(ctx context.Context, c *client.Client, priv *keys.PrivateKey, u *url.URL, wrapClientCloser bool) (io.ReadCloser, error) {
+// If Command is not provided, full object is requested. Suitable tokens (if any)
+// are attached to the request. If wrapClientCloser is true, the client will be
+// closed when the returned ReadCloser is closed.
+func GetWithClient(ctx context.Context, c *client.Client, priv *keys.PrivateKey, tokens *Tokens, u *url.URL, wrapClientCloser bool) (io.ReadCloser, error) {
 	objectAddr, ps, err := parseNeoFSURL(u)
 	if err != nil {
 		return nil, err
@@ -70,13 +73,13 @@ func GetWithClient(ctx context.Context, c *client.Client, priv *keys.PrivateKey,
 	)
 	switch {
 	case len(ps) == 0 || ps[0] == "":
-		res, err = getPayload(ctx, s, c, objectAddr)
+		res, err = getPayload(ctx, s, tokens, c, objectAddr)
 	case ps[0] == rangeCmd:
-		res, err = getRange(ctx, s, c, objectAddr, ps[1:]...)
+		res, err = getRange(ctx, s, tokens, c, objectAddr, ps[1:]...)
 	case ps[0] == headerCmd:
-		res, err = getHeader(ctx, s, c, objectAddr)
+		res, err = getHeader(ctx, s, tokens, c, objectAddr)
 	case ps[0] == hashCmd:
-		res, err = getHash(ctx, s, c, objectAddr, ps[1:]...)
+		res, err = getHash(ctx, s, tokens, c, objectAddr, ps[1:]...)
 	default:
 		return nil, ErrInvalidCommand
 	}
@@ -137,16 +140,41 @@ func parseNeoFSURL(u *url.URL) (*oid.Address, []string, error) {
 	return objAddr, ps[2:], nil
 }
 
-func getPayload(ctx context.Context, s user.Signer, c *client.Client, addr *oid.Address) (io.ReadCloser, error) {
-	var iorc io.ReadCloser
-	_, rc, err := c.ObjectGetInit(ctx, addr.Container(), addr.Object(), s, client.PrmObjectGet{})
+// ObjectGet returns the header and the payload reader of the neofs object
+// from the provided url. URI scheme is "neofs:<Container-ID>/<Object-ID>",
+// commands are not allowed. Suitable tokens (if any) are attached to the
+// request.
+func ObjectGet(ctx context.Context, c *client.Client, priv *keys.PrivateKey, tokens *Tokens, u *url.URL) (object.Object, io.ReadCloser, error) {
+	objectAddr, ps, err := parseNeoFSURL(u)
+	if err != nil {
+		return object.Object{}, nil, err
+	}
+	if len(ps) != 0 && ps[0] != "" {
+		return object.Object{}, nil, ErrInvalidCommand
+	}
+	return getObject(ctx, user.NewAutoIDSignerRFC6979(priv.PrivateKey), tokens, c, objectAddr)
+}
+
+func getPayload(ctx context.Context, s user.Signer, t *Tokens, c *client.Client, addr *oid.Address) (io.ReadCloser, error) {
+	_, rc, err := getObject(ctx, s, t, c, addr)
+	return rc, err
+}
+
+func getObject(ctx context.Context, s user.Signer, t *Tokens, c *client.Client, addr *oid.Address) (object.Object, io.ReadCloser, error) {
+	var (
+		iorc io.ReadCloser
+		prm  client.PrmObjectGet
+		obj  = addr.Object()
+	)
+	t.apply(&prm, s, addr.Container(), &obj, session.VerbObjectGet)
+	hdr, rc, err := c.ObjectGetInit(ctx, addr.Container(), obj, s, prm)
 	if rc != nil {
 		iorc = rc
 	}
-	return iorc, err
+	return hdr, iorc, err
 }
 
-func getRange(ctx context.Context, s user.Signer, c *client.Client, addr *oid.Address, ps ...string) (io.ReadCloser, error) {
+func getRange(ctx context.Context, s user.Signer, t *Tokens, c *client.Client, addr *oid.Address, ps ...string) (io.ReadCloser, error) {
 	var iorc io.ReadCloser
 	if len(ps) == 0 {
 		return nil, ErrInvalidRange
@@ -156,19 +184,29 @@ func getRange(ctx context.Context, s user.Signer, c *client.Client, addr *oid.Ad
 		return nil, err
 	}
 
-	rc, err := c.ObjectRangeInit(ctx, addr.Container(), addr.Object(), r.GetOffset(), r.GetLength(), s, client.PrmObjectRange{})
+	var (
+		prm client.PrmObjectRange
+		obj = addr.Object()
+	)
+	t.apply(&prm, s, addr.Container(), &obj, session.VerbObjectRange)
+	rc, err := c.ObjectRangeInit(ctx, addr.Container(), obj, r.GetOffset(), r.GetLength(), s, prm)
 	if rc != nil {
 		iorc = rc
 	}
 	return iorc, err
 }
 
-func getObjHeader(ctx context.Context, s user.Signer, c *client.Client, addr *oid.Address) (*object.Object, error) {
-	return c.ObjectHead(ctx, addr.Container(), addr.Object(), s, client.PrmObjectHead{})
+func getObjHeader(ctx context.Context, s user.Signer, t *Tokens, c *client.Client, addr *oid.Address) (*object.Object, error) {
+	var (
+		prm client.PrmObjectHead
+		obj = addr.Object()
+	)
+	t.apply(&prm, s, addr.Container(), &obj, session.VerbObjectHead)
+	return c.ObjectHead(ctx, addr.Container(), obj, s, prm)
 }
 
-func getHeader(ctx context.Context, s user.Signer, c *client.Client, addr *oid.Address) (io.ReadCloser, error) {
-	obj, err := getObjHeader(ctx, s, c, addr)
+func getHeader(ctx context.Context, s user.Signer, t *Tokens, c *client.Client, addr *oid.Address) (io.ReadCloser, error) {
+	obj, err := getObjHeader(ctx, s, t, c, addr)
 	if err != nil {
 		return nil, err
 	}
@@ -179,9 +217,9 @@ func getHeader(ctx context.Context, s user.Signer, c *client.Client, addr *oid.A
 	return io.NopCloser(bytes.NewReader(res)), nil
 }
 
-func getHash(ctx context.Context, s user.Signer, c *client.Client, addr *oid.Address, ps ...string) (io.ReadCloser, error) {
+func getHash(ctx context.Context, s user.Signer, t *Tokens, c *client.Client, addr *oid.Address, ps ...string) (io.ReadCloser, error) {
 	if len(ps) == 0 || ps[0] == "" { // hash of the full payload
-		obj, err := getObjHeader(ctx, s, c, addr)
+		obj, err := getObjHeader(ctx, s, t, c, addr)
 		if err != nil {
 			return nil, err
 		}
@@ -195,10 +233,14 @@ func getHash(ctx context.Context, s user.Signer, c *client.Client, addr *oid.Add
 	if err != nil {
 		return nil, err
 	}
-	var hashPrm client.PrmObjectHash
+	var (
+		hashPrm client.PrmObjectHash
+		obj     = addr.Object()
+	)
 	hashPrm.SetRangeList(r.GetOffset(), r.GetLength())
+	t.apply(&hashPrm, s, addr.Container(), &obj, session.VerbObjectRangeHash)
 
-	hashes, err := c.ObjectHash(ctx, addr.Container(), addr.Object(), s, hashPrm)
+	hashes, err := c.ObjectHash(ctx, addr.Container(), obj, s, hashPrm)
 	if err != nil {
 		return nil, err
 	}
@@ -242,7 +284,8 @@ type ObjectSearchInitter interface {
 }
 
 // ObjectSearch returns a list of object IDs from the provided container.
-func ObjectSearch(ctx context.Context, initter ObjectSearchInitter, priv *keys.PrivateKey, containerIDStr string, prm client.PrmObjectSearch) ([]oid.ID, error) {
+// Suitable tokens (if any) are attached to the request.
+func ObjectSearch(ctx context.Context, initter ObjectSearchInitter, priv *keys.PrivateKey, tokens *Tokens, containerIDStr string, prm client.PrmObjectSearch) ([]oid.ID, error) {
 	var (
 		s           = user.NewAutoIDSignerRFC6979(priv.PrivateKey)
 		objectIDs   []oid.ID
@@ -252,6 +295,7 @@ func ObjectSearch(ctx context.Context, initter ObjectSearchInitter, priv *keys.P
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrInvalidContainer, err)
 	}
+	tokens.apply(&prm, s, containerID, nil, session.VerbObjectSearch)
 	reader, err := initter.ObjectSearchInit(ctx, containerID, s, prm)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initiate object search: %w", err)
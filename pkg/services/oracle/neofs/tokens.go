@@ -0,0 +1,172 @@
+package neofs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neofs-sdk-go/bearer"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
+	"github.com/nspcc-dev/neofs-sdk-go/session"
+	"github.com/nspcc-dev/neofs-sdk-go/user"
+	"go.uber.org/zap"
+)
+
+// defaultTokensCheckInterval is the minimum interval between token file
+// modification checks.
+const defaultTokensCheckInterval = 10 * time.Second
+
+// Tokens is a set of bearer and object session tokens used to access objects
+// in containers with restricted access rules. Tokens are read from files
+// (either binary or JSON-encoded) and files are checked for modifications
+// periodically, so tokens can be renewed by replacing files without
+// recreating Tokens. Tokens that can't be reloaded are kept and the error is
+// logged. It's safe for concurrent use. A nil Tokens is valid and contains no
+// tokens.
+type Tokens struct {
+	log           *zap.Logger
+	bearerFiles   []string
+	sessionFiles  []string
+	checkInterval time.Duration
+
+	lock      sync.RWMutex
+	lastCheck time.Time
+	modTimes  map[string]time.Time
+	bearers   []bearer.Token
+	sessions  []session.Object
+}
+
+// tokenPrm is a set of request parameters tokens can be attached to.
+type tokenPrm interface {
+	WithBearerToken(bearer.Token)
+	WithinSession(session.Object)
+}
+
+// NewTokens reads bearer and object session tokens from the given files. It
+// returns nil if there are no files. The logger is used to report subsequent
+// token reload failures.
+func NewTokens(log *zap.Logger, bearerFiles, sessionFiles []string) (*Tokens, error) {
+	if len(bearerFiles) == 0 && len(sessionFiles) == 0 {
+		return nil, nil
+	}
+	t := &Tokens{
+		log:           log,
+		bearerFiles:   bearerFiles,
+		sessionFiles:  sessionFiles,
+		checkInterval: defaultTokensCheckInterval,
+		modTimes:      make(map[string]time.Time),
+		bearers:       make([]bearer.Token, len(bearerFiles)),
+		sessions:      make([]session.Object, len(sessionFiles)),
+	}
+	if err := t.reload(true); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// reload rereads modified token files. The previous token is kept if the file
+// can't be read or decoded (the file is reread on the next check then), errors
+// for all such files are returned. Files are checked at most once per
+// checkInterval unless force is set. It must be called with the lock held.
+func (t *Tokens) reload(force bool) error {
+	now := time.Now()
+	if !force && now.Sub(t.lastCheck) < t.checkInterval {
+		return nil
+	}
+	t.lastCheck = now
+	var errs []error
+	for i, f := range t.bearerFiles {
+		err := t.reloadFile(f, func(data []byte) error {
+			var tok bearer.Token
+			if err := decodeToken(data, tok.UnmarshalJSON, tok.Unmarshal); err != nil {
+				return err
+			}
+			t.bearers[i] = tok
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("bearer token: %w", err))
+		}
+	}
+	for i, f := range t.sessionFiles {
+		err := t.reloadFile(f, func(data []byte) error {
+			var tok session.Object
+			if err := decodeToken(data, tok.UnmarshalJSON, tok.Unmarshal); err != nil {
+				return err
+			}
+			t.sessions[i] = tok
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("session token: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reloadFile reads the file and passes its contents to the decoder if it's
+// modified since the last successful read.
+func (t *Tokens) reloadFile(path string, decode func([]byte) error) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("can't stat %s: %w", path, err)
+	}
+	if mt, ok := t.modTimes[path]; ok && mt.Equal(fi.ModTime()) {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("can't read %s: %w", path, err)
+	}
+	if err = decode(data); err != nil {
+		return fmt.Errorf("can't decode %s: %w", path, err)
+	}
+	t.modTimes[path] = fi.ModTime()
+	return nil
+}
+
+// decodeToken decodes JSON-encoded token if data looks like JSON and binary
+// one otherwise.
+func decodeToken(data []byte, fromJSON, fromBinary func([]byte) error) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) != 0 && trimmed[0] == '{' {
+		return fromJSON(trimmed)
+	}
+	return fromBinary(data)
+}
+
+// apply attaches the first suitable bearer and session tokens (if any) for
+// the operation with the given container, object (nil for search) and verb
+// performed by the signer to prm. Token reload errors are logged, previously
+// loaded tokens are used in this case.
+func (t *Tokens) apply(prm tokenPrm, s user.Signer, cnr cid.ID, obj *oid.ID, verb session.ObjectVerb) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	err := t.reload(false)
+	t.lock.Unlock()
+	if err != nil {
+		t.log.Warn("failed to reload NeoFS tokens, using previous ones", zap.Error(err))
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	var usr = s.UserID()
+	for _, b := range t.bearers {
+		if b.AssertContainer(cnr) && b.AssertUser(usr) {
+			prm.WithBearerToken(b)
+			break
+		}
+	}
+	for _, st := range t.sessions {
+		if st.AssertContainer(cnr) && st.AssertVerb(verb) && st.AssertAuthKey(s.Public()) &&
+			(obj == nil || st.AssertObject(*obj)) {
+			prm.WithinSession(st)
+			break
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package neofs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neofs-sdk-go/bearer"
+	cidtest "github.com/nspcc-dev/neofs-sdk-go/container/id/test"
+	"github.com/nspcc-dev/neofs-sdk-go/eacl"
+	oidtest "github.com/nspcc-dev/neofs-sdk-go/object/id/test"
+	"github.com/nspcc-dev/neofs-sdk-go/session"
+	"github.com/nspcc-dev/neofs-sdk-go/user"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type testTokenPrm struct {
+	bearer  *bearer.Token
+	session *session.Object
+}
+
+func (p *testTokenPrm) WithBearerToken(t bearer.Token) {
+	p.bearer = &t
+}
+
+func (p *testTokenPrm) WithinSession(t session.Object) {
+	p.session = &t
+}
+
+func newTestSigner(t *testing.T) user.Signer {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	return user.NewAutoIDSignerRFC6979(priv.PrivateKey)
+}
+
+func TestTokens(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		owner  = newTestSigner(t)
+		s      = newTestSigner(t)
+		cnr    = cidtest.ID()
+		other  = cidtest.ID()
+		obj    = oidtest.ID()
+		bFile  = filepath.Join(dir, "bearer")
+		sFile  = filepath.Join(dir, "session.json")
+		newBTk = func(exp uint64, forUser user.ID) bearer.Token {
+			var (
+				tok   bearer.Token
+				table = eacl.NewTable()
+			)
+			table.SetCID(cnr)
+			tok.SetEACLTable(*table)
+			tok.ForUser(forUser)
+			tok.SetExp(exp)
+			require.NoError(t, tok.Sign(owner))
+			return tok
+		}
+	)
+	bTok := newBTk(10, s.UserID())
+	require.NoError(t, os.WriteFile(bFile, bTok.Marshal(), 0o600))
+
+	var sTok session.Object
+	sTok.BindContainer(cnr)
+	sTok.ForVerb(session.VerbObjectGet)
+	sTok.SetAuthKey(s.Public())
+	sTok.SetExp(10)
+	require.NoError(t, sTok.Sign(owner))
+	raw, err := sTok.MarshalJSON()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(sFile, raw, 0o600))
+
+	t.Run("empty", func(t *testing.T) {
+		tokens, err := NewTokens(zaptest.NewLogger(t), nil, nil)
+		require.NoError(t, err)
+		require.Nil(t, tokens)
+
+		var prm testTokenPrm
+		tokens.apply(&prm, s, cnr, &obj, session.VerbObjectGet)
+		require.Nil(t, prm.bearer)
+		require.Nil(t, prm.session)
+	})
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewTokens(zaptest.NewLogger(t), []string{filepath.Join(dir, "unknown")}, nil)
+		require.Error(t, err)
+	})
+	t.Run("invalid file", func(t *testing.T) {
+		invalid := filepath.Join(dir, "invalid")
+		require.NoError(t, os.WriteFile(invalid, []byte{1, 2, 3}, 0o600))
+		_, err := NewTokens(zaptest.NewLogger(t), nil, []string{invalid})
+		require.Error(t, err)
+	})
+
+	tokens, err := NewTokens(zaptest.NewLogger(t), []string{bFile}, []string{sFile})
+	require.NoError(t, err)
+
+	t.Run("matching", func(t *testing.T) {
+		var prm testTokenPrm
+		tokens.apply(&prm, s, cnr, &obj, session.VerbObjectGet)
+		require.NotNil(t, prm.bearer)
+		require.Equal(t, bTok.Marshal(), prm.bearer.Marshal())
+		require.NotNil(t, prm.session)
+		require.Equal(t, sTok.Marshal(), prm.session.Marshal())
+	})
+	t.Run("other container", func(t *testing.T) {
+		var prm testTokenPrm
+		tokens.apply(&prm, s, other, &obj, session.VerbObjectGet)
+		require.Nil(t, prm.bearer)
+		require.Nil(t, prm.session)
+	})
+	t.Run("other verb", func(t *testing.T) {
+		var prm testTokenPrm
+		tokens.apply(&prm, s, cnr, nil, session.VerbObjectSearch)
+		require.NotNil(t, prm.bearer)
+		require.Nil(t, prm.session)
+	})
+	t.Run("other user", func(t *testing.T) {
+		var prm testTokenPrm
+		tokens.apply(&prm, newTestSigner(t), cnr, &obj, session.VerbObjectGet)
+		require.Nil(t, prm.bearer)
+		require.Nil(t, prm.session)
+	})
+	t.Run("renewal", func(t *testing.T) {
+		renewed := newBTk(20, s.UserID())
+		require.NoError(t, os.WriteFile(bFile, renewed.Marshal(), 0o600))
+		future := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(bFile, future, future))
+
+		// Files are not checked until the interval passes.
+		var prm testTokenPrm
+		tokens.apply(&prm, s, cnr, &obj, session.VerbObjectGet)
+		require.Equal(t, bTok.Marshal(), prm.bearer.Marshal())
+
+		tokens.checkInterval = 0
+		tokens.apply(&prm, s, cnr, &obj, session.VerbObjectGet)
+		require.Equal(t, renewed.Marshal(), prm.bearer.Marshal())
+
+		// Broken and missing files don't affect previously loaded tokens.
+		require.NoError(t, os.WriteFile(bFile, []byte{1, 2, 3}, 0o600))
+		prm = testTokenPrm{}
+		tokens.apply(&prm, s, cnr, &obj, session.VerbObjectGet)
+		require.Equal(t, renewed.Marshal(), prm.bearer.Marshal())
+
+		require.NoError(t, os.Remove(bFile))
+		prm = testTokenPrm{}
+		tokens.apply(&prm, s, cnr, &obj, session.VerbObjectGet)
+		require.Equal(t, renewed.Marshal(), prm.bearer.Marshal())
+
+		// Fixed file is reloaded.
+		renewed = newBTk(30, s.UserID())
+		require.NoError(t, os.WriteFile(bFile, renewed.Marshal(), 0o600))
+		tokens.apply(&prm, s, cnr, &obj, session.VerbObjectGet)
+		require.Equal(t, renewed.Marshal(), prm.bearer.Marshal())
+	})
+}
@@ -21,19 +21,16 @@ func (o *Oracle) UpdateOracleNodes(oracleNodes keys.PublicKeys) {
 
 	var acc *wallet.Account
 	for i := range oracleNodes {
-		acc = o.wallet.GetAccount(oracleNodes[i].GetScriptHash())
+		var err error
+		acc, err = o.keystore.GetAccount(oracleNodes[i].GetScriptHash())
+		if err != nil {
+			o.Log.Error("can't unlock account",
+				zap.String("address", address.Uint160ToString(oracleNodes[i].GetScriptHash())),
+				zap.Error(err))
+			o.currAccount = nil
+			return
+		}
 		if acc != nil {
-			if acc.CanSign() {
-				break
-			}
-			err := acc.Decrypt(o.MainCfg.UnlockWallet.Password, o.wallet.Scrypt)
-			if err != nil {
-				o.Log.Error("can't unlock account",
-					zap.String("address", address.Uint160ToString(acc.Contract.ScriptHash())),
-					zap.Error(err))
-				o.currAccount = nil
-				return
-			}
 			break
 		}
 	}
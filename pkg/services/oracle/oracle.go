@@ -3,8 +3,8 @@ package oracle
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
-	"slices"
 	"sync"
 	"time"
 
@@ -15,7 +15,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/keystore"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
@@ -68,7 +70,9 @@ type (
 		// removed contains ids of requests which won't be processed further due to expiration.
 		removed map[uint64]bool
 
-		wallet *wallet.Wallet
+		keystore *wallet.Keystore
+		// neofsTokens are used to access restricted NeoFS containers.
+		neofsTokens *neofs.Tokens
 	}
 
 	// Config contains oracle module parameters.
@@ -146,16 +150,11 @@ func NewOracle(cfg Config) (*Oracle, error) {
 	}
 
 	var err error
-	w := cfg.MainCfg.UnlockWallet
-	if o.wallet, err = wallet.NewWalletFromFile(w.Path); err != nil {
+	if o.keystore, err = keystore.Open(cfg.MainCfg.UnlockWallet); err != nil {
 		return nil, err
 	}
-
-	var haveAccount = slices.ContainsFunc(o.wallet.Accounts, func(acc *wallet.Account) bool {
-		return acc.Decrypt(w.Password, o.wallet.Scrypt) == nil
-	})
-	if !haveAccount {
-		return nil, errors.New("no wallet account could be unlocked")
+	if o.neofsTokens, err = neofs.NewTokens(cfg.Log, cfg.MainCfg.NeoFS.BearerTokens, cfg.MainCfg.NeoFS.SessionTokens); err != nil {
+		return nil, fmt.Errorf("failed to load NeoFS tokens: %w", err)
 	}
 
 	if o.ResponseHandler == nil {
@@ -189,7 +188,7 @@ func (o *Oracle) Shutdown() {
 	close(o.close)
 	o.ResponseHandler.Shutdown()
 	<-o.done
-	o.wallet.Close()
+	o.keystore.Close()
 	_ = o.Log.Sync()
 }
 
@@ -205,6 +204,16 @@ func (o *Oracle) Start() {
 	go o.start()
 }
 
+// Health returns an error if the service is not running.
+func (o *Oracle) Health() error {
+	o.respMtx.Lock()
+	defer o.respMtx.Unlock()
+	if !o.running {
+		return errors.New("not started")
+	}
+	return nil
+}
+
 // IsAuthorized returns whether Oracle service currently is authorized to collect
 // signatures. It returns true iff designated Oracle node's account provided to
 // the Oracle service in decrypted state.
@@ -166,7 +166,7 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 			ctx, cancel := context.WithTimeout(context.Background(), o.MainCfg.NeoFS.Timeout)
 			defer cancel()
 			index := (int(req.ID) + incTx.attempts) % len(o.MainCfg.NeoFS.Nodes)
-			rc, err := neofs.Get(ctx, priv, u, o.MainCfg.NeoFS.Nodes[index])
+			rc, err := neofs.Get(ctx, priv, o.neofsTokens, u, o.MainCfg.NeoFS.Nodes[index])
 			if err != nil {
 				resp.Code = transaction.Error
 				o.Log.Warn("failed to perform oracle request", zap.String("url", req.Req.URL), zap.Error(err))
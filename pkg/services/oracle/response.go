@@ -171,6 +171,7 @@ func (o *Oracle) testVerify(tx *transaction.Transaction) (int64, bool, error) {
 	if err != nil {
 		return 0, false, fmt.Errorf("failed to create test VM: %w", err)
 	}
+	defer ic.Finalize()
 	ic.VM.GasLimit = o.Chain.GetMaxVerificationGAS()
 
 	o.oracleInfoLock.RLock()
@@ -24,6 +24,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
@@ -444,18 +445,30 @@ func TestClientNEOContract(t *testing.T) {
 	require.NoError(t, err)
 	neo0 := neo.New(act0)
 
+	votes, err := neoR.GetCandidateVote(testchain.PrivateKey(0).PublicKey())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(-1), votes) // Not registered.
+
 	txreg, err := neo0.RegisterCandidateTransaction(testchain.PrivateKey(0).PublicKey())
 	require.NoError(t, err)
 	bl = testchain.NewBlock(t, chain, 1, 0, txreg)
 	_, err = c.SubmitBlock(*bl)
 	require.NoError(t, err)
 
+	votes, err = neoR.GetCandidateVote(testchain.PrivateKey(0).PublicKey())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(0), votes)
+
 	txvote, err := neo0.VoteTransaction(acc0, testchain.PrivateKey(0).PublicKey())
 	require.NoError(t, err)
 	bl = testchain.NewBlock(t, chain, 1, 0, txvote)
 	_, err = c.SubmitBlock(*bl)
 	require.NoError(t, err)
 
+	votes, err = neoR.GetCandidateVote(testchain.PrivateKey(0).PublicKey())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), votes)
+
 	txunreg, err := neo0.UnregisterCandidateTransaction(testchain.PrivateKey(0).PublicKey())
 	require.NoError(t, err)
 	bl = testchain.NewBlock(t, chain, 1, 0, txunreg)
@@ -1105,6 +1118,57 @@ func TestCreateNEP17TransferTx(t *testing.T) {
 	})
 }
 
+func TestClient_InvokeScriptSteps(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	b := smartcontract.NewBuilder()
+	b.InvokeMethod(gas.Hash, "balanceOf", testchain.PrivateKeyByID(0).GetScriptHash())
+	script, err := b.Script()
+	require.NoError(t, err)
+
+	expected, err := c.InvokeScript(script, nil)
+	require.NoError(t, err)
+	require.Equal(t, vmstate.Halt.String(), expected.State)
+
+	res, err := c.InvokeScriptSteps(script, 2, nil)
+	require.NoError(t, err)
+	require.Equal(t, vmstate.Break.String(), res.State)
+	require.Equal(t, 2, len(res.Stack))
+	require.NotEmpty(t, res.Checkpoint)
+	checkpoint := res.Checkpoint
+
+	res, err = c.ResumeInvocation(checkpoint, 1)
+	require.NoError(t, err)
+	require.Equal(t, vmstate.Break.String(), res.State)
+	require.NotEmpty(t, res.Checkpoint)
+
+	// Balance changes with the new block, but the invocation is performed
+	// against the original state.
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+	actual, err := c.InvokeScript(script, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, expected.Stack, actual.Stack)
+	res, err = c.ResumeInvocation(checkpoint, 1000)
+	require.NoError(t, err)
+	require.Equal(t, expected.State, res.State)
+	require.Equal(t, expected.GasConsumed, res.GasConsumed)
+	require.Equal(t, expected.Stack, res.Stack)
+	require.Empty(t, res.Checkpoint)
+
+	_, err = c.InvokeScriptSteps(script, 0, nil)
+	require.Error(t, err)
+	_, err = c.ResumeInvocation([]byte{1, 2, 3}, 1)
+	require.Error(t, err)
+	checkpoint[len(checkpoint)-1]++ // Wrong stack size.
+	_, err = c.ResumeInvocation(checkpoint, 1)
+	require.ErrorContains(t, err, "checkpoint mismatch")
+}
+
 func TestInvokeVerify(t *testing.T) {
 	chain, _, httpSrv := initServerWithInMemoryChain(t)
 
@@ -1124,6 +1188,25 @@ func TestInvokeVerify(t *testing.T) {
 		require.True(t, res.Stack[0].Value().(bool))
 	})
 
+	t.Run("positive, with diagnostics", func(t *testing.T) {
+		res, err := c.InvokeContractVerifyWithDiagnostics(contract, []smartcontract.Parameter{}, []transaction.Signer{{Account: testchain.PrivateKeyByID(0).PublicKey().GetScriptHash()}})
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State)
+		require.True(t, res.Stack[0].Value().(bool))
+		require.NotNil(t, res.Diagnostics)
+		diag := res.Diagnostics.Verification
+		require.NotNil(t, diag)
+		require.Equal(t, []result.ContractUsage{{Hash: contract, GasConsumed: res.GasConsumed, Steps: diag.Steps}}, diag.Contracts)
+		require.Equal(t, res.GasConsumed+2*chain.FeePerByte(), diag.NetworkFee)
+
+		// The contract is the signer by default.
+		res, err = c.InvokeContractVerifyWithDiagnostics(contract, []smartcontract.Parameter{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State)
+		require.False(t, res.Stack[0].Value().(bool))
+		require.NotNil(t, res.Diagnostics.Verification)
+	})
+
 	t.Run("positive, historic, by height, with signer", func(t *testing.T) {
 		h := chain.BlockHeight() - 1
 		res, err := c.InvokeContractVerifyAtHeight(h, contract, []smartcontract.Parameter{}, []transaction.Signer{{Account: testchain.PrivateKeyByID(0).PublicKey().GetScriptHash()}})
@@ -1196,6 +1279,79 @@ func TestInvokeVerify(t *testing.T) {
 	})
 }
 
+func TestClient_InvokeContained(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	checkWitness := func(h util.Uint160) []byte {
+		w := io.NewBufBinWriter()
+		emit.Bytes(w.BinWriter, h.BytesBE())
+		emit.Syscall(w.BinWriter, interopnames.SystemRuntimeCheckWitness)
+		require.NoError(t, w.Err)
+		return w.Bytes()
+	}
+	checkResult := func(t *testing.T, res *result.Invoke, expected bool) {
+		require.Equal(t, "HALT", res.State, res.FaultException)
+		require.Equal(t, 1, len(res.Stack))
+		require.Equal(t, expected, res.Stack[0].Value())
+	}
+	acc := testchain.PrivateKeyByID(0).PublicKey().GetScriptHash()
+	other := testchain.PrivateKeyByID(1).PublicKey().GetScriptHash()
+
+	t.Run("transaction", func(t *testing.T) {
+		tx := transaction.New(checkWitness(acc), 0)
+		tx.ValidUntilBlock = chain.BlockHeight() + 1
+		tx.Signers = []transaction.Signer{{Account: acc, Scopes: transaction.CalledByEntry}}
+
+		// Unsigned, transaction script.
+		res, err := c.InvokeContainedTransaction(tx, nil)
+		require.NoError(t, err)
+		checkResult(t, res, true)
+
+		// Custom script.
+		res, err = c.InvokeContainedTransaction(tx, checkWitness(other))
+		require.NoError(t, err)
+		checkResult(t, res, false)
+
+		// Signer scope is respected.
+		tx.Signers[0].Scopes = transaction.None
+		res, err = c.InvokeContainedTransaction(tx, nil)
+		require.NoError(t, err)
+		checkResult(t, res, false)
+
+		// No script.
+		tx.Script = []byte{}
+		_, err = c.InvokeContainedTransaction(tx, nil)
+		require.ErrorIs(t, err, neorpc.ErrInvalidParams)
+	})
+	t.Run("block", func(t *testing.T) {
+		prev, err := chain.GetHeader(chain.CurrentBlockHash())
+		require.NoError(t, err)
+		b := block.New(chain.GetConfig().StateRootInHeader)
+		b.Index = chain.BlockHeight() + 1
+		b.PrevHash = prev.Hash()
+		b.Timestamp = prev.Timestamp + 1
+		b.NextConsensus = prev.NextConsensus
+
+		res, err := c.InvokeContainedBlock(b, checkWitness(prev.NextConsensus))
+		require.NoError(t, err)
+		checkResult(t, res, true)
+
+		res, err = c.InvokeContainedBlock(b, checkWitness(acc))
+		require.NoError(t, err)
+		checkResult(t, res, false)
+
+		// Not the next block.
+		b.Index++
+		_, err = c.InvokeContainedBlock(b, checkWitness(acc))
+		require.ErrorIs(t, err, neorpc.ErrInvalidParams)
+	})
+}
+
 func TestClient_GetNativeContracts(t *testing.T) {
 	chain, _, httpSrv := initServerWithInMemoryChain(t)
 
@@ -1207,6 +1363,17 @@ func TestClient_GetNativeContracts(t *testing.T) {
 	cs, err := c.GetNativeContracts()
 	require.NoError(t, err)
 	require.Equal(t, chain.GetNatives(), cs)
+
+	cs, err = c.GetNativeContractsByHeight(0)
+	require.NoError(t, err)
+	require.Equal(t, chain.GetNativesAt(0), cs)
+
+	cs, err = c.GetNativeContractsByHardfork(config.HFAspidochelone.String())
+	require.NoError(t, err)
+	require.Equal(t, chain.GetNativesAt(chain.GetConfig().Hardforks[config.HFAspidochelone.String()]), cs)
+
+	_, err = c.GetNativeContractsByHardfork(config.HFEchidna.String())
+	require.Error(t, err)
 }
 
 func TestClient_NEP11_ND(t *testing.T) {
@@ -2104,7 +2271,7 @@ func testSubClientWaitWithMissedEvent(t *testing.T, local bool) {
 	rpcSrv.subsLock.Lock()
 	// Deliver overflow message -> triggers subscriber to retry with polling waiter.
 	for s := range rpcSrv.subscribers {
-		s.writer <- intEvent{overflowMsg, &overNotification}
+		s.writer <- intEvent{msg: overflowMsg, ntf: &overNotification, data: overEvent}
 	}
 	rpcSrv.subsLock.Unlock()
 
@@ -2460,3 +2627,54 @@ func TestClient_GetVersion_Hardforks(t *testing.T) {
 	}
 	require.InDeltaMapValues(t, expected, v.Protocol.Hardforks, 0)
 }
+
+func TestWSClient_FindStorageStream(t *testing.T) {
+	runWSAndLocal(t, testWSClientFindStorageStream)
+}
+
+func testWSClientFindStorageStream(t *testing.T, local bool) {
+	_, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	c := mkSubsClient(t, rpcSrv, httpSrv, local)
+
+	h, err := util.Uint160DecodeStringLE(testContractHash)
+	require.NoError(t, err)
+
+	var (
+		rcvr    = make(chan *result.StorageBatch)
+		batches []*result.StorageBatch
+		done    = make(chan struct{})
+	)
+	go func() {
+		for b := range rcvr {
+			batches = append(batches, b)
+		}
+		close(done)
+	}()
+	n, err := c.FindStorageStreamByHash(h, []byte("aa"), 0, rcvr)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	<-done
+
+	require.Equal(t, []*result.StorageBatch{
+		{
+			ID: batches[0].ID,
+			Results: []result.KeyValue{
+				{Key: []byte("aa"), Value: []byte("v1")},
+				{Key: []byte("aa10"), Value: []byte("v2")},
+			},
+		},
+		{
+			ID:      batches[0].ID,
+			Results: []result.KeyValue{{Key: []byte("aa50"), Value: []byte("v3")}},
+			Done:    true,
+		},
+	}, batches)
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		rcvr := make(chan *result.StorageBatch)
+		_, err := c.FindStorageStreamByHash(h, []byte("aa"), 100, rcvr)
+		require.Error(t, err)
+		_, ok := <-rcvr
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,128 @@
+package rpcsrv
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+)
+
+const (
+	defaultCORSMethods = "GET, POST" // GET for websockets and SSE.
+	defaultCORSHeaders = "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With"
+	defaultCORSMaxAge  = 6 * time.Hour
+)
+
+// corsRule is a CORSRule prepared for use.
+type corsRule struct {
+	origins     []string
+	anyOrigin   bool
+	methods     string
+	headers     string
+	credentials bool
+	maxAge      string
+}
+
+// corsPolicy is a set of CORS rules, the first one matching the request origin
+// is applied. A nil policy doesn't allow any cross-origin requests.
+type corsPolicy []corsRule
+
+// newCORSPolicy creates a policy from the RPC configuration, EnableCORSWorkaround
+// is treated as a rule allowing any origin following the configured ones. It
+// returns nil if there are no rules.
+func newCORSPolicy(conf config.RPC) corsPolicy {
+	var res corsPolicy
+	for _, r := range conf.CORS {
+		rule := corsRule{
+			origins:     r.Origins,
+			anyOrigin:   slices.Contains(r.Origins, "*"),
+			methods:     defaultCORSMethods,
+			headers:     defaultCORSHeaders,
+			credentials: r.AllowCredentials,
+			maxAge:      strconv.Itoa(int(defaultCORSMaxAge / time.Second)),
+		}
+		if len(r.Methods) != 0 {
+			rule.methods = strings.Join(r.Methods, ", ")
+		}
+		if len(r.Headers) != 0 {
+			rule.headers = strings.Join(r.Headers, ", ")
+		}
+		if r.MaxAge != 0 {
+			rule.maxAge = strconv.Itoa(int(r.MaxAge / time.Second))
+		}
+		res = append(res, rule)
+	}
+	if conf.EnableCORSWorkaround {
+		res = append(res, corsRule{
+			anyOrigin: true,
+			methods:   defaultCORSMethods,
+			headers:   defaultCORSHeaders,
+			maxAge:    strconv.Itoa(int(defaultCORSMaxAge / time.Second)),
+		})
+	}
+	return res
+}
+
+// match returns the rule for the given origin or nil if it's not allowed.
+// Requests without an origin are only matched by rules allowing any origin.
+func (p corsPolicy) match(origin string) *corsRule {
+	for i := range p {
+		if p[i].anyOrigin || (origin != "" && slices.ContainsFunc(p[i].origins, func(o string) bool {
+			return strings.EqualFold(o, origin)
+		})) {
+			return &p[i]
+		}
+	}
+	return nil
+}
+
+// setHeaders sets CORS response headers for the request if its origin is
+// allowed and returns the rule applied.
+func (p corsPolicy) setHeaders(h http.Header, r *http.Request) *corsRule {
+	var origin = r.Header.Get("Origin")
+
+	rule := p.match(origin)
+	if rule == nil {
+		return nil
+	}
+	if rule.anyOrigin && !rule.credentials {
+		h.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		h.Set("Access-Control-Allow-Origin", origin)
+		h.Add("Vary", "Origin")
+	}
+	if rule.credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+	h.Set("Access-Control-Allow-Headers", rule.headers)
+	return rule
+}
+
+// setPreflightHeaders sets preflight CORS response headers for the request if
+// its origin is allowed.
+func (p corsPolicy) setPreflightHeaders(h http.Header, r *http.Request) {
+	rule := p.setHeaders(h, r)
+	if rule == nil {
+		return
+	}
+	h.Set("Access-Control-Allow-Methods", rule.methods)
+	h.Set("Access-Control-Max-Age", rule.maxAge)
+}
+
+// checkOrigin is a websocket origin checker allowing same-origin connections
+// and connections from origins allowed by the policy.
+func (p corsPolicy) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || p.match(origin) != nil {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
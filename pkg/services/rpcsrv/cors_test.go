@@ -0,0 +1,81 @@
+package rpcsrv
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORSPolicy(t *testing.T) {
+	newRequest := func(origin string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "http://node.example.com/", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		return r
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		p := newCORSPolicy(config.RPC{})
+		require.Nil(t, p)
+		require.Nil(t, p.match("https://dapp.example.com"))
+	})
+	t.Run("workaround", func(t *testing.T) {
+		p := newCORSPolicy(config.RPC{EnableCORSWorkaround: true})
+		h := make(http.Header)
+		p.setPreflightHeaders(h, newRequest(""))
+		require.Equal(t, "*", h.Get("Access-Control-Allow-Origin"))
+		require.Equal(t, defaultCORSHeaders, h.Get("Access-Control-Allow-Headers"))
+		require.Equal(t, defaultCORSMethods, h.Get("Access-Control-Allow-Methods"))
+		require.Equal(t, "21600", h.Get("Access-Control-Max-Age"))
+		require.Empty(t, h.Get("Access-Control-Allow-Credentials"))
+		require.True(t, p.checkOrigin(newRequest("https://any.example.com")))
+	})
+
+	p := newCORSPolicy(config.RPC{
+		CORS: []config.CORSRule{{
+			Origins:          []string{"https://dapp.example.com"},
+			Methods:          []string{"POST"},
+			Headers:          []string{"Content-Type", "Authorization"},
+			AllowCredentials: true,
+			MaxAge:           time.Minute,
+		}, {
+			Origins: []string{"http://localhost:3000"},
+		}},
+	})
+	t.Run("credentials", func(t *testing.T) {
+		h := make(http.Header)
+		p.setPreflightHeaders(h, newRequest("https://DApp.example.com"))
+		require.Equal(t, "https://DApp.example.com", h.Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "Origin", h.Get("Vary"))
+		require.Equal(t, "true", h.Get("Access-Control-Allow-Credentials"))
+		require.Equal(t, "Content-Type, Authorization", h.Get("Access-Control-Allow-Headers"))
+		require.Equal(t, "POST", h.Get("Access-Control-Allow-Methods"))
+		require.Equal(t, "60", h.Get("Access-Control-Max-Age"))
+	})
+	t.Run("defaults", func(t *testing.T) {
+		h := make(http.Header)
+		p.setPreflightHeaders(h, newRequest("http://localhost:3000"))
+		require.Equal(t, "http://localhost:3000", h.Get("Access-Control-Allow-Origin"))
+		require.Empty(t, h.Get("Access-Control-Allow-Credentials"))
+		require.Equal(t, defaultCORSMethods, h.Get("Access-Control-Allow-Methods"))
+		require.Equal(t, "21600", h.Get("Access-Control-Max-Age"))
+	})
+	t.Run("not allowed", func(t *testing.T) {
+		for _, origin := range []string{"", "https://evil.example.com", "http://dapp.example.com"} {
+			h := make(http.Header)
+			p.setPreflightHeaders(h, newRequest(origin))
+			require.Empty(t, h, origin)
+		}
+	})
+	t.Run("websocket", func(t *testing.T) {
+		require.True(t, p.checkOrigin(newRequest("")))
+		require.True(t, p.checkOrigin(newRequest("https://dapp.example.com")))
+		require.True(t, p.checkOrigin(newRequest("https://node.example.com")))
+		require.False(t, p.checkOrigin(newRequest("https://evil.example.com")))
+	})
+}
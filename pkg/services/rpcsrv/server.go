@@ -2,6 +2,7 @@ package rpcsrv
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"crypto/elliptic"
 	"encoding/binary"
@@ -13,6 +14,7 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,6 +28,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/contractstats"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/iterator"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
@@ -35,7 +39,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/core/validatorstats"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash/merkle"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
@@ -67,6 +73,7 @@ type (
 		CalculateAttributesFee(tx *transaction.Transaction) int64
 		CalculateClaimable(h util.Uint160, endHeight uint32) (*big.Int, error)
 		CurrentBlockHash() util.Uint256
+		DescribeFault(v *vm.VM, err error) string
 		FeePerByte() int64
 		ForEachNEP11Transfer(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP11Transfer) (bool, error)) error
 		ForEachNEP17Transfer(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP17Transfer) (bool, error)) error
@@ -77,16 +84,19 @@ type (
 		GetConfig() config.Blockchain
 		GetContractScriptHash(id int32) (util.Uint160, error)
 		GetContractState(hash util.Uint160) *state.Contract
+		GetContractStats() (*contractstats.Period, error)
 		GetEnrollments() ([]state.Validator, error)
 		GetGoverningTokenBalance(acc util.Uint160) (*big.Int, uint32)
 		GetHeader(hash util.Uint256) (*block.Header, error)
 		GetHeaderHash(uint32) util.Uint256
 		GetMaxVerificationGAS() int64
 		GetMemPool() *mempool.Pool
+		GetNEOAccountState(acc util.Uint160) (*state.NEOBalance, error)
 		GetNEP11Contracts() []util.Uint160
 		GetNEP17Contracts() []util.Uint160
 		GetNativeContractScriptHash(string) (util.Uint160, error)
 		GetNatives() []state.Contract
+		GetNativesAt(height uint32) []state.Contract
 		GetNextBlockValidators() ([]*keys.PublicKey, error)
 		GetNotaryContractScriptHash() util.Uint160
 		GetStateModule() core.StateRoot
@@ -95,18 +105,22 @@ type (
 		GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) (*interop.Context, error)
 		GetTokenLastUpdated(acc util.Uint160) (map[int32]uint32, error)
 		GetTransaction(util.Uint256) (*transaction.Transaction, uint32, error)
+		GetValidatorStats() (*validatorstats.Window, error)
 		HeaderHeight() uint32
 		InitVerificationContext(ic *interop.Context, hash util.Uint160, witness *transaction.Witness) error
 		P2PSigExtensionsEnabled() bool
+		ProjectClaimable(acc util.Uint160, endHeight uint32) (*big.Int, error)
 		SubscribeForBlocks(ch chan *block.Block)
 		SubscribeForHeadersOfAddedBlocks(ch chan *block.Header)
 		SubscribeForExecutions(ch chan *state.AppExecResult)
 		SubscribeForNotifications(ch chan *state.ContainedNotificationEvent)
+		SubscribeForRejectedBlocks(ch chan *state.RejectedBlock)
 		SubscribeForTransactions(ch chan *transaction.Transaction)
 		UnsubscribeFromBlocks(ch chan *block.Block)
 		UnsubscribeFromHeadersOfAddedBlocks(ch chan *block.Header)
 		UnsubscribeFromExecutions(ch chan *state.AppExecResult)
 		UnsubscribeFromNotifications(ch chan *state.ContainedNotificationEvent)
+		UnsubscribeFromRejectedBlocks(ch chan *state.RejectedBlock)
 		UnsubscribeFromTransactions(ch chan *transaction.Transaction)
 		VerifyTx(*transaction.Transaction) error
 		VerifyWitness(util.Uint160, hash.Hashable, *transaction.Witness, int64) (int64, error)
@@ -125,6 +139,12 @@ type (
 		AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte)
 	}
 
+	// ConsensusHandler is the interface consensus service needs to provide for
+	// the Server.
+	ConsensusHandler interface {
+		GetState() *result.ConsensusState
+	}
+
 	// Server represents the JSON-RPC 2.0 server.
 	Server struct {
 		http  []*http.Server
@@ -132,6 +152,7 @@ type (
 
 		chain  Ledger
 		config config.RPC
+		cors   corsPolicy
 		// wsReadLimit represents web-socket message limit for a receiving side.
 		wsReadLimit      int64
 		upgrader         websocket.Upgrader
@@ -139,6 +160,8 @@ type (
 		stateRootEnabled bool
 		coreServer       *network.Server
 		oracle           *atomic.Value
+		consensusLock    sync.RWMutex
+		consensus        ConsensusHandler
 		log              *zap.Logger
 		shutdown         chan struct{}
 		started          atomic.Bool
@@ -149,6 +172,10 @@ type (
 
 		subsLock    sync.RWMutex
 		subscribers map[*subscriber]bool
+		// eventSeq and sseHistory are only modified by handleSubEvents
+		// with subsLock read-locked and read with subsLock locked.
+		eventSeq   uint64
+		sseHistory []sseEvent
 
 		subsCounterLock   sync.RWMutex
 		blockSubs         int
@@ -157,6 +184,7 @@ type (
 		notificationSubs  int
 		transactionSubs   int
 		notaryRequestSubs int
+		rejectedSubs      int
 
 		blockCh           chan *block.Block
 		blockHeaderCh     chan *block.Header
@@ -164,6 +192,7 @@ type (
 		notificationCh    chan *state.ContainedNotificationEvent
 		transactionCh     chan *transaction.Transaction
 		notaryRequestCh   chan mempoolevent.Event
+		rejectedCh        chan *state.RejectedBlock
 		subEventsToExitCh chan struct{}
 	}
 
@@ -203,12 +232,20 @@ const (
 	// Maximum number of elements for get*transfers requests.
 	maxTransfersLimit = 1000
 
+	// Maximum number of accounts for getaccountstates request.
+	maxAccountStatesCount = 100
+
 	// defaultSessionPoolSize is the number of concurrently running iterator sessions.
 	defaultSessionPoolSize = 20
+
+	// strictStackJSONSize is the stack item JSON size limit used by C# node
+	// (its default MaxStackSize setting) in strict mode.
+	strictStackJSONSize = math.MaxUint16
 )
 
 var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"calculatenetworkfee":          (*Server).calculateNetworkFee,
+	"getaccountstates":             (*Server).getAccountStates,
 	"findstates":                   (*Server).findStates,
 	"findstorage":                  (*Server).findStorage,
 	"findstoragehistoric":          (*Server).findStorageHistoric,
@@ -223,7 +260,9 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"getcandidates":                (*Server).getCandidates,
 	"getcommittee":                 (*Server).getCommittee,
 	"getconnectioncount":           (*Server).getConnectionCount,
+	"getconsensusstate":            (*Server).getConsensusState,
 	"getcontractstate":             (*Server).getContractState,
+	"getcontractstats":             (*Server).getContractStats,
 	"getnativecontracts":           (*Server).getNativeContracts,
 	"getnep11balances":             (*Server).getNEP11Balances,
 	"getnep11properties":           (*Server).getNEP11Properties,
@@ -232,6 +271,7 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"getnep17transfers":            (*Server).getNEP17Transfers,
 	"getpeers":                     (*Server).getPeers,
 	"getproof":                     (*Server).getProof,
+	"getrangeproof":                (*Server).getRangeProof,
 	"getrawmempool":                (*Server).getRawMempool,
 	"getrawnotarypool":             (*Server).getRawNotaryPool,
 	"getrawnotarytransaction":      (*Server).getRawNotaryTransaction,
@@ -240,30 +280,38 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"getstateheight":               (*Server).getStateHeight,
 	"getstateroot":                 (*Server).getStateRoot,
 	"getstorage":                   (*Server).getStorage,
+	"getstoragebatch":              (*Server).getStorageBatch,
 	"getstoragehistoric":           (*Server).getStorageHistoric,
 	"gettransactionheight":         (*Server).getTransactionHeight,
+	"gettransactionproof":          (*Server).getTransactionProof,
 	"getunclaimedgas":              (*Server).getUnclaimedGas,
 	"getnextblockvalidators":       (*Server).getNextBlockValidators,
+	"getvalidatorstats":            (*Server).getValidatorStats,
 	"getversion":                   (*Server).getVersion,
 	"invokefunction":               (*Server).invokeFunction,
 	"invokefunctionhistoric":       (*Server).invokeFunctionHistoric,
 	"invokescript":                 (*Server).invokescript,
 	"invokescripthistoric":         (*Server).invokescripthistoric,
+	"invokescriptsteps":            (*Server).invokeScriptSteps,
 	"invokecontractverify":         (*Server).invokeContractVerify,
 	"invokecontractverifyhistoric": (*Server).invokeContractVerifyHistoric,
+	"invokecontainedscript":        (*Server).invokeContainedScript,
 	"sendrawtransaction":           (*Server).sendrawtransaction,
 	"submitblock":                  (*Server).submitBlock,
 	"submitnotaryrequest":          (*Server).submitNotaryRequest,
 	"submitoracleresponse":         (*Server).submitOracleResponse,
+	"resumeinvocation":             (*Server).resumeInvocation,
 	"terminatesession":             (*Server).terminateSession,
 	"traverseiterator":             (*Server).traverseIterator,
 	"validateaddress":              (*Server).validateAddress,
 	"verifyproof":                  (*Server).verifyProof,
+	"verifyrangeproof":             (*Server).verifyRangeProof,
 }
 
 var rpcWsHandlers = map[string]func(*Server, params.Params, *subscriber) (any, *neorpc.Error){
-	"subscribe":   (*Server).subscribe,
-	"unsubscribe": (*Server).unsubscribe,
+	"findstoragestream": (*Server).findStorageStream,
+	"subscribe":         (*Server).subscribe,
+	"unsubscribe":       (*Server).unsubscribe,
 }
 
 // New creates a new Server struct. Pay attention that orc is expected to be either
@@ -309,13 +357,20 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 		conf.MaxWebSocketClients = defaultMaxWebSocketClients
 		log.Info("MaxWebSocketClients is not set or wrong, setting default value", zap.Int("MaxWebSocketClients", defaultMaxWebSocketClients))
 	}
+	if conf.SSEHistorySize <= 0 {
+		conf.SSEHistorySize = defaultSSEHistorySize
+		log.Info("SSEHistorySize is not set or wrong, setting default value", zap.Int("SSEHistorySize", defaultSSEHistorySize))
+	}
 	var oracleWrapped = new(atomic.Value)
 	if orc != nil {
 		oracleWrapped.Store(orc)
 	}
-	var wsOriginChecker func(*http.Request) bool
-	if conf.EnableCORSWorkaround {
-		wsOriginChecker = func(_ *http.Request) bool { return true }
+	var (
+		cors            = newCORSPolicy(conf)
+		wsOriginChecker func(*http.Request) bool
+	)
+	if cors != nil {
+		wsOriginChecker = cors.checkOrigin
 	}
 
 	addrs := conf.Addresses
@@ -345,6 +400,7 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 
 		chain:            chain,
 		config:           conf,
+		cors:             cors,
 		wsReadLimit:      int64(protoCfg.MaxBlockSize*4)/3 + 1024, // Enough for Base64-encoded content of `submitblock` and `submitp2pnotaryrequest`.
 		upgrader:         websocket.Upgrader{CheckOrigin: wsOriginChecker},
 		network:          protoCfg.Magic,
@@ -365,6 +421,7 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 		transactionCh:     make(chan *transaction.Transaction),
 		notaryRequestCh:   make(chan mempoolevent.Event),
 		blockHeaderCh:     make(chan *block.Header),
+		rejectedCh:        make(chan *state.RejectedBlock),
 		subEventsToExitCh: make(chan struct{}),
 	}
 }
@@ -432,6 +489,14 @@ func (s *Server) Start() {
 	}
 }
 
+// Health returns an error if the RPC server is enabled, but not running.
+func (s *Server) Health() error {
+	if s.config.Enabled && !s.started.Load() {
+		return errors.New("not started")
+	}
+	return nil
+}
+
 // Shutdown stops the RPC server if it's running. It can only be called once,
 // subsequent calls to Shutdown on the same instance are no-op. The instance
 // that was stopped can not be started again by calling Start (use a new
@@ -489,11 +554,27 @@ func (s *Server) SetOracleHandler(orc OracleHandler) {
 	s.oracle.Store(orc)
 }
 
+// SetConsensusHandler allows to update consensus handler used by the Server,
+// nil value means that the consensus service is not running.
+func (s *Server) SetConsensusHandler(c ConsensusHandler) {
+	s.consensusLock.Lock()
+	s.consensus = c
+	s.consensusLock.Unlock()
+}
+
 func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Request) {
 	// Restrict request body before further processing.
 	httpRequest.Body = http.MaxBytesReader(w, httpRequest.Body, int64(s.config.MaxRequestBodyBytes))
 	req := params.NewRequest()
 
+	if httpRequest.Method == "OPTIONS" && s.cors != nil { // Preflight CORS.
+		s.cors.setPreflightHeaders(w.Header(), httpRequest)
+		return
+	}
+	if s.cors != nil {
+		s.cors.setHeaders(w.Header(), httpRequest)
+	}
+
 	if httpRequest.URL.Path == "/ws" && httpRequest.Method == "GET" {
 		// Technically there is a race between this check and
 		// s.subscribers modification 20 lines below, but it's tiny
@@ -526,10 +607,8 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		return
 	}
 
-	if httpRequest.Method == "OPTIONS" && s.config.EnableCORSWorkaround { // Preflight CORS.
-		setCORSOriginHeaders(w.Header())
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST") // GET for websockets.
-		w.Header().Set("Access-Control-Max-Age", "21600")           // 6 hours.
+	if httpRequest.URL.Path == ssePath && httpRequest.Method == "GET" {
+		s.handleSSE(w, httpRequest)
 		return
 	}
 
@@ -898,6 +977,25 @@ func (s *Server) getVersion(_ params.Params) (any, *neorpc.Error) {
 	}, nil
 }
 
+// getConsensusState returns the snapshot of the current consensus state, it's
+// only available if allowed by the configuration.
+func (s *Server) getConsensusState(_ params.Params) (any, *neorpc.Error) {
+	if !s.config.ConsensusStateEnabled {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrConsensusDisabled, "consensus state is disabled in the RPC server configuration")
+	}
+	s.consensusLock.RLock()
+	c := s.consensus
+	s.consensusLock.RUnlock()
+	if c == nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrConsensusDisabled, "consensus service is not enabled")
+	}
+	st := c.GetState()
+	if st == nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrConsensusDisabled, "consensus service is not started")
+	}
+	return st, nil
+}
+
 func (s *Server) getPeers(_ params.Params) (any, *neorpc.Error) {
 	peers := result.NewGetPeers()
 	peers.AddUnconnected(s.coreServer.UnconnectedPeers())
@@ -974,7 +1072,7 @@ func (s *Server) calculateNetworkFee(reqParams params.Params) (any, *neorpc.Erro
 				}
 				paramz = md.Parameters // Might as well have none params and it's OK.
 			} else { // Regular signature verification.
-				if vm.IsSignatureContract(w.VerificationScript) {
+				if vm.IsSignatureContract(w.VerificationScript) || vm.IsSecp256k1SignatureContract(w.VerificationScript) {
 					paramz = []manifest.Parameter{{Type: smartcontract.SignatureType}}
 				} else if nSigs, _, ok := vm.ParseMultiSigContract(w.VerificationScript); ok {
 					paramz = make([]manifest.Parameter, nSigs)
@@ -1024,6 +1122,9 @@ func (s *Server) getApplicationLog(reqParams params.Params) (any, *neorpc.Error)
 	}
 
 	appExecResults, err := s.chain.GetAppExecResults(hash, trigger.All)
+	if errors.Is(err, dao.ErrAppLogPruned) {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrAppLogPruned, fmt.Sprintf("failed to locate application log: %s", err))
+	}
 	if err != nil {
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownScriptContainer, fmt.Sprintf("failed to locate application log: %s", err))
 	}
@@ -1612,6 +1713,106 @@ func (s *Server) verifyProof(ps params.Params) (any, *neorpc.Error) {
 	return vp, nil
 }
 
+func (s *Server) getRangeProof(ps params.Params) (any, *neorpc.Error) {
+	root, respErr := s.getStateRootFromParam(ps.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	csHash, err := ps.Value(1).GetUint160FromHex()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid contract hash: %s", err))
+	}
+	prefix, err := ps.Value(2).GetBytesBase64()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid prefix: %s", err))
+	}
+	var (
+		from  []byte
+		count = s.config.MaxFindResultItems
+	)
+	if len(ps) > 3 {
+		from, err = ps.Value(3).GetBytesBase64()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid from: %s", err))
+		}
+		if len(from) > 0 && !bytes.HasPrefix(from, prefix) {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "from key doesn't match prefix")
+		}
+	}
+	if len(ps) > 4 {
+		count, err = ps.Value(4).GetInt()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid count: %s", err))
+		}
+		if count <= 0 {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid count")
+		}
+		count = min(count, s.config.MaxFindResultItems)
+	}
+	cs, respErr := s.getHistoricalContractState(root, csHash)
+	if respErr != nil {
+		return nil, respErr
+	}
+	pKey := makeStorageKey(cs.ID, prefix)
+	var fKey []byte
+	if len(from) > 0 {
+		fKey = makeStorageKey(cs.ID, from)
+	}
+	kvs, proof, err := s.chain.GetStateModule().GetRangeProof(root, pKey, fKey, count)
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get range proof: %s", err))
+	}
+	res := &result.GetRangeProof{
+		Results:   make([]result.KeyValue, len(kvs)),
+		Truncated: len(kvs) == count,
+		Proof: &result.RangeProof{
+			Prefix: pKey,
+			From:   fKey,
+			Count:  count,
+			Proof:  proof,
+		},
+	}
+	for i, kv := range kvs {
+		res.Results[i] = result.KeyValue{
+			Key:   kv.Key[4:], // cut contract ID as it is done for findstates.
+			Value: kv.Value,
+		}
+	}
+	return res, nil
+}
+
+func (s *Server) verifyRangeProof(ps params.Params) (any, *neorpc.Error) {
+	root, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, neorpc.ErrInvalidParams
+	}
+	proofStr, err := ps.Value(1).GetString()
+	if err != nil {
+		return nil, neorpc.ErrInvalidParams
+	}
+	var p result.RangeProof
+	if err := p.FromString(proofStr); err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid proof: %s", err))
+	}
+	if len(p.Prefix) < 4 {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid proof: prefix doesn't contain contract ID")
+	}
+	kvs, err := mpt.VerifyRangeProof(root, p.Prefix, p.From, p.Count, p.Proof)
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidProof, err.Error())
+	}
+	res := &result.VerifyRangeProof{
+		Results: make([]result.KeyValue, len(kvs)),
+	}
+	for i, kv := range kvs {
+		res.Results[i] = result.KeyValue{
+			Key:   kv.Key[4:],
+			Value: kv.Value,
+		}
+	}
+	return res, nil
+}
+
 func (s *Server) getState(ps params.Params) (any, *neorpc.Error) {
 	root, respErr := s.getStateRootFromParam(ps.Value(0))
 	if respErr != nil {
@@ -1654,8 +1855,9 @@ func (s *Server) findStates(ps params.Params) (any, *neorpc.Error) {
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid prefix: %s", err))
 	}
 	var (
-		key   []byte
-		count = s.config.MaxFindResultItems
+		key       []byte
+		count     = s.config.MaxFindResultItems
+		backwards bool
 	)
 	if len(ps) > 3 {
 		key, err = ps.Value(3).GetBytesBase64()
@@ -1679,14 +1881,25 @@ func (s *Server) findStates(ps params.Params) (any, *neorpc.Error) {
 		}
 		count = min(count, s.config.MaxFindResultItems)
 	}
+	if len(ps) > 5 {
+		backwards, err = ps.Value(5).GetBooleanStrict()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid backwards flag: %s", err))
+		}
+	}
 	cs, respErr := s.getHistoricalContractState(root, csHash)
 	if respErr != nil {
 		return nil, respErr
 	}
 	pKey := makeStorageKey(cs.ID, prefix)
-	kvs, err := s.chain.GetStateModule().FindStates(root, pKey, key, count+1) // +1 to define result truncation
-	if err != nil && !errors.Is(err, mpt.ErrNotFound) {
-		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to find state items: %s", err))
+	var kvs []storage.KeyValue
+	if backwards {
+		kvs = s.findStatesBackwards(root, pKey, key, count+1)
+	} else {
+		kvs, err = s.chain.GetStateModule().FindStates(root, pKey, key, count+1) // +1 to define result truncation
+		if err != nil && !errors.Is(err, mpt.ErrNotFound) {
+			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to find state items: %s", err))
+		}
 	}
 	res := result.FindStates{}
 	if len(kvs) == count+1 {
@@ -1723,6 +1936,23 @@ func (s *Server) findStates(ps params.Params) (any, *neorpc.Error) {
 	return res, nil
 }
 
+// findStatesBackwards returns at most maxNum contract storage items matching
+// the given prefix (that includes contract ID) located before the given key
+// (relative to the prefix, it's not included) in the reverse MPT traversal
+// order, so that the last items can be retrieved without traversing all of
+// the preceding ones.
+func (s *Server) findStatesBackwards(root util.Uint256, prefix, key []byte, maxNum int) []storage.KeyValue {
+	var res []storage.KeyValue
+	s.chain.GetStateModule().SeekStatesRange(root, storage.SeekRange{Prefix: prefix, Start: key, Backwards: true}, func(k, v []byte) bool {
+		if key != nil && bytes.Equal(k, key) {
+			return true
+		}
+		res = append(res, storage.KeyValue{Key: slices.Concat(prefix, k), Value: v})
+		return len(res) < maxNum
+	})
+	return res
+}
+
 // getStateRootFromParam retrieves state root hash from the provided parameter
 // (only util.Uint256 serialized representation is allowed) and checks whether
 // MPT states are supported for the old stateroot.
@@ -1778,6 +2008,90 @@ func (s *Server) findStorageInternal(id int32, prefix []byte, start, take int, s
 	return res, nil
 }
 
+// findStorageStream pushes all contract storage items matching the given prefix
+// to the subscriber as a sequence of `storage_batch` events and returns the
+// number of items sent. Batches are pushed with the subscriber's writer
+// backpressure, so the stream is not limited by MaxFindStoragePageSize.
+func (s *Server) findStorageStream(reqParams params.Params, sub *subscriber) (any, *neorpc.Error) {
+	if len(reqParams) < 3 {
+		return nil, neorpc.ErrInvalidParams
+	}
+	id, respErr := s.contractIDFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	prefix, err := reqParams.Value(1).GetBytesBase64()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid prefix: %s", err))
+	}
+	streamID, err := reqParams.Value(2).GetString()
+	if err != nil || len(streamID) == 0 {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid stream ID")
+	}
+	var batchSize = s.config.MaxFindStorageResultItems
+	if len(reqParams) > 3 {
+		batchSize, err = reqParams.Value(3).GetInt()
+		if err != nil || batchSize <= 0 || batchSize > s.config.MaxFindStorageResultItems {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid batch size, expected 1-%d", s.config.MaxFindStorageResultItems))
+		}
+	}
+
+	var (
+		count int
+		batch = &result.StorageBatch{ID: streamID, Results: make([]result.KeyValue, 0, batchSize)}
+	)
+	s.chain.SeekStorage(id, prefix, func(k, v []byte) bool {
+		batch.Results = append(batch.Results, result.KeyValue{
+			Key:   bytes.Clone(append(prefix, k...)), // Don't strip prefix, as findstorage does.
+			Value: bytes.Clone(v),
+		})
+		count++
+		if len(batch.Results) < batchSize {
+			return true
+		}
+		respErr = s.sendStorageBatch(sub, batch)
+		batch = &result.StorageBatch{ID: streamID, Results: make([]result.KeyValue, 0, batchSize)}
+		return respErr == nil
+	})
+	if respErr != nil {
+		return nil, respErr
+	}
+	batch.Done = true
+	if respErr = s.sendStorageBatch(sub, batch); respErr != nil {
+		return nil, respErr
+	}
+	return count, nil
+}
+
+// sendStorageBatch delivers a single `storage_batch` event to the subscriber.
+// Unlike regular notifications it blocks until the event is accepted by the
+// writer, giving up after wsPongLimit or server shutdown.
+func (s *Server) sendStorageBatch(sub *subscriber, batch *result.StorageBatch) *neorpc.Error {
+	var resp = &neorpc.Notification{
+		JSONRPC: neorpc.JSONRPCVersion,
+		Event:   neorpc.StorageBatchEventID,
+		Payload: []any{batch},
+	}
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return neorpc.NewInternalServerError(fmt.Sprintf("failed to marshal storage batch: %s", err))
+	}
+	msg, err := websocket.NewPreparedMessage(websocket.TextMessage, b)
+	if err != nil {
+		return neorpc.NewInternalServerError(fmt.Sprintf("failed to prepare storage batch message: %s", err))
+	}
+	var timer = time.NewTimer(wsPongLimit)
+	defer timer.Stop()
+	select {
+	case sub.writer <- intEvent{msg: msg, ntf: resp, data: b}:
+		return nil
+	case <-s.shutdown:
+		return neorpc.NewInternalServerError("server is shutting down")
+	case <-timer.C:
+		return neorpc.NewInternalServerError("storage batch delivery timeout")
+	}
+}
+
 func (s *Server) findStorageHistoric(reqParams params.Params) (any, *neorpc.Error) {
 	root, respErr := s.getStateRootFromParam(reqParams.Value(0))
 	if respErr != nil {
@@ -1903,6 +2217,36 @@ func (s *Server) getStorage(ps params.Params) (any, *neorpc.Error) {
 	return []byte(item), nil
 }
 
+// getStorageBatch returns values for a set of keys of the same contract, the
+// value is null for missing keys. The number of keys is limited by
+// MaxFindStoragePageSize.
+func (s *Server) getStorageBatch(ps params.Params) (any, *neorpc.Error) {
+	id, rErr := s.contractIDFromParam(ps.Value(0))
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	keys, err := ps.Value(1).GetArray()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid keys: %s", err))
+	}
+	if len(keys) == 0 || len(keys) > s.config.MaxFindStorageResultItems {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("expected 1-%d keys, got %d", s.config.MaxFindStorageResultItems, len(keys)))
+	}
+	var res = make([]result.KeyValue, len(keys))
+	for i := range keys {
+		key, err := keys[i].GetBytesBase64()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid key #%d: %s", i, err))
+		}
+		res[i].Key = key
+		if item := s.chain.GetStorageItem(id, key); item != nil {
+			res[i].Value = []byte(item)
+		}
+	}
+	return res, nil
+}
+
 func (s *Server) getStorageHistoric(ps params.Params) (any, *neorpc.Error) {
 	root, respErr := s.getStateRootFromParam(ps.Value(0))
 	if respErr != nil {
@@ -1954,19 +2298,22 @@ func (s *Server) getrawtransaction(reqParams params.Params) (any, *neorpc.Error)
 		if err != nil {
 			return nil, neorpc.NewInternalServerError(fmt.Sprintf("Failed to get header for the transaction: %s", err.Error()))
 		}
+		res.TransactionMetadata = result.TransactionMetadata{
+			Blockhash:     header.Hash(),
+			Confirmations: int(s.chain.BlockHeight() - header.Index + 1),
+			Timestamp:     header.Timestamp,
+		}
 		aers, err := s.chain.GetAppExecResults(txHash, trigger.Application)
+		if errors.Is(err, dao.ErrAppLogPruned) {
+			return res, nil // VM state is omitted for pruned logs.
+		}
 		if err != nil {
 			return nil, neorpc.NewInternalServerError(fmt.Sprintf("Failed to get application log for the transaction: %s", err.Error()))
 		}
 		if len(aers) == 0 {
 			return nil, neorpc.NewInternalServerError("Inconsistent application log: application log for the transaction is empty")
 		}
-		res.TransactionMetadata = result.TransactionMetadata{
-			Blockhash:     header.Hash(),
-			Confirmations: int(s.chain.BlockHeight() - header.Index + 1),
-			Timestamp:     header.Timestamp,
-			VMState:       aers[0].VMState.String(),
-		}
+		res.TransactionMetadata.VMState = aers[0].VMState.String()
 		return res, nil
 	}
 	return tx.Bytes(), nil
@@ -1986,6 +2333,49 @@ func (s *Server) getTransactionHeight(ps params.Params) (any, *neorpc.Error) {
 	return height, nil
 }
 
+// getTransactionProof returns a proof of the transaction inclusion into the
+// block it's persisted in.
+func (s *Server) getTransactionProof(ps params.Params) (any, *neorpc.Error) {
+	h, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, neorpc.ErrInvalidParams
+	}
+
+	_, height, err := s.chain.GetTransaction(h)
+	if err != nil || height == math.MaxUint32 {
+		return nil, neorpc.ErrUnknownTransaction
+	}
+	b, err := s.chain.GetBlock(s.chain.GetHeaderHash(height))
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get block %d: %s", height, err))
+	}
+	var (
+		tr    = merkle.NewTree()
+		index = -1
+	)
+	for i, tx := range b.Transactions {
+		if tx.Hash() == h {
+			index = i
+		}
+		tr.Append(tx.Hash())
+	}
+	if index < 0 {
+		return nil, neorpc.ErrUnknownTransaction
+	}
+	proof, err := tr.Proof(index)
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(err.Error())
+	}
+	return result.TransactionProof{
+		BlockHash:  b.Hash(),
+		BlockIndex: b.Index,
+		MerkleRoot: b.MerkleRoot,
+		Index:      index,
+		TxCount:    len(b.Transactions),
+		Proof:      proof,
+	}, nil
+}
+
 // getContractState returns contract state (contract information, according to the contract script hash,
 // contract id or native contract name).
 func (s *Server) getContractState(reqParams params.Params) (any, *neorpc.Error) {
@@ -2000,8 +2390,26 @@ func (s *Server) getContractState(reqParams params.Params) (any, *neorpc.Error)
 	return cs, nil
 }
 
-func (s *Server) getNativeContracts(_ params.Params) (any, *neorpc.Error) {
-	return s.chain.GetNatives(), nil
+// getNativeContracts returns native contracts states. Optional parameter is either
+// a block height or a hardfork name, contracts states active at this height (or
+// since this hardfork activation) are returned then.
+func (s *Server) getNativeContracts(reqParams params.Params) (any, *neorpc.Error) {
+	param := reqParams.Value(0)
+	if param == nil {
+		return s.chain.GetNatives(), nil
+	}
+	if name, err := param.GetStringStrict(); err == nil && config.IsHardforkValid(name) {
+		height, ok := s.chain.GetConfig().Hardforks[name]
+		if !ok {
+			return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("hardfork %s is not enabled", name))
+		}
+		return s.chain.GetNativesAt(height), nil
+	}
+	height, respErr := s.blockHeightFromParam(param)
+	if respErr != nil {
+		return nil, respErr
+	}
+	return s.chain.GetNativesAt(height), nil
 }
 
 // getBlockSysFee returns the system fees of the block, based on the specified index.
@@ -2078,6 +2486,108 @@ func (s *Server) getUnclaimedGas(ps params.Params) (any, *neorpc.Error) {
 	}, nil
 }
 
+// getContractStats returns per-contract execution statistics for the last
+// completed statistics period sorted by the number of calls.
+func (s *Server) getContractStats(_ params.Params) (any, *neorpc.Error) {
+	p, err := s.chain.GetContractStats()
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(err.Error())
+	}
+	if p == nil {
+		return nil, neorpc.NewInternalServerError("no complete contract statistics period yet")
+	}
+	res := result.ContractStats{
+		StartBlock: p.Start,
+		EndBlock:   p.End,
+		Contracts:  make([]result.ContractStatsItem, 0, len(p.Contracts)),
+	}
+	for h, st := range p.Contracts {
+		res.Contracts = append(res.Contracts, result.ContractStatsItem{
+			Hash:        h,
+			Calls:       st.Calls,
+			Executions:  st.Executions,
+			Faults:      st.Faults,
+			GasConsumed: st.GasConsumed,
+		})
+	}
+	slices.SortFunc(res.Contracts, func(a, b result.ContractStatsItem) int {
+		if a.Calls != b.Calls {
+			return cmp.Compare(b.Calls, a.Calls)
+		}
+		return a.Hash.Compare(b.Hash)
+	})
+	return res, nil
+}
+
+// getValidatorStats returns block production statistics of validators for
+// the latest blocks.
+func (s *Server) getValidatorStats(_ params.Params) (any, *neorpc.Error) {
+	w, err := s.chain.GetValidatorStats()
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(err.Error())
+	}
+	if w == nil {
+		return nil, neorpc.NewInternalServerError("no validator statistics collected yet")
+	}
+	res := result.ValidatorStats{
+		StartBlock: w.Start,
+		EndBlock:   w.End,
+		Validators: make([]result.ValidatorStatsItem, 0, len(w.Validators)),
+	}
+	for _, st := range w.Validators {
+		res.Validators = append(res.Validators, result.ValidatorStatsItem{
+			PublicKey: *st.Key,
+			Produced:  st.Produced,
+			Missed:    st.Missed,
+		})
+	}
+	return res, nil
+}
+
+// getAccountStates returns NEO states of a batch of accounts along with GAS
+// they can claim at the next block or at the given (future) height.
+func (s *Server) getAccountStates(ps params.Params) (any, *neorpc.Error) {
+	accs, err := ps.Value(0).GetArray()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid accounts: %s", err))
+	}
+	if len(accs) == 0 || len(accs) > maxAccountStatesCount {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("expected 1-%d accounts, got %d", maxAccountStatesCount, len(accs)))
+	}
+	var height = s.chain.BlockHeight() + 1 // Next block, as for getunclaimedgas.
+	if len(ps) > 1 {
+		h, err := ps[1].GetInt()
+		if err != nil || h < int(height) {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("height should be not lower than %d", height))
+		}
+		height = uint32(h)
+	}
+	var res = make([]result.AccountState, len(accs))
+	for i := range accs {
+		u, err := accs[i].GetUint160FromAddressOrHex()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid account #%d: %s", i, err))
+		}
+		res[i].Address = u
+		st, err := s.chain.GetNEOAccountState(u)
+		if err != nil {
+			return nil, neorpc.NewInternalServerError(fmt.Sprintf("can't get %s state: %s", address.Uint160ToString(u), err))
+		}
+		if st == nil {
+			continue
+		}
+		res[i].Balance = st.Balance
+		res[i].BalanceHeight = st.BalanceHeight
+		res[i].VoteTo = st.VoteTo
+		gas, err := s.chain.ProjectClaimable(u, height)
+		if err != nil {
+			return nil, neorpc.NewInternalServerError(fmt.Sprintf("can't calculate claimable: %s", err))
+		}
+		res[i].Unclaimed = *gas
+	}
+	return res, nil
+}
+
 // getCandidates returns the current list of candidates with their active/inactive voting status.
 func (s *Server) getCandidates(_ params.Params) (any, *neorpc.Error) {
 	var validators keys.PublicKeys
@@ -2141,12 +2651,12 @@ func (s *Server) invokeFunction(reqParams params.Params) (any, *neorpc.Error) {
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, nil, verbose)
 }
 
 // invokeFunctionHistoric implements the `invokeFunctionHistoric` RPC call.
 func (s *Server) invokeFunctionHistoric(reqParams params.Params) (any, *neorpc.Error) {
-	nextH, respErr := s.getHistoricParams(reqParams)
+	hp, respErr := s.getHistoricParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
 	}
@@ -2157,7 +2667,7 @@ func (s *Server) invokeFunctionHistoric(reqParams params.Params) (any, *neorpc.E
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, hp, verbose)
 }
 
 func (s *Server) getInvokeFunctionParams(reqParams params.Params) (*transaction.Transaction, bool, *neorpc.Error) {
@@ -2208,12 +2718,12 @@ func (s *Server) invokescript(reqParams params.Params) (any, *neorpc.Error) {
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, nil, verbose)
 }
 
 // invokescripthistoric implements the `invokescripthistoric` RPC call.
 func (s *Server) invokescripthistoric(reqParams params.Params) (any, *neorpc.Error) {
-	nextH, respErr := s.getHistoricParams(reqParams)
+	hp, respErr := s.getHistoricParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
 	}
@@ -2224,7 +2734,130 @@ func (s *Server) invokescripthistoric(reqParams params.Params) (any, *neorpc.Err
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, hp, verbose)
+}
+
+// invokeScriptSteps implements the `invokescriptsteps` RPC call.
+func (s *Server) invokeScriptSteps(reqParams params.Params) (any, *neorpc.Error) {
+	if len(reqParams) < 2 {
+		return nil, neorpc.ErrInvalidParams
+	}
+	steps, respErr := getStepsParam(reqParams[1])
+	if respErr != nil {
+		return nil, respErr
+	}
+	tx, _, respErr := s.getInvokeScriptParams(append(params.Params{reqParams[0]}, reqParams[2:]...))
+	if respErr != nil {
+		return nil, respErr
+	}
+	cp := &invocationCheckpoint{
+		height:    s.chain.BlockHeight(),
+		script:    tx.Script,
+		signers:   tx.Signers,
+		witnesses: tx.Scripts,
+	}
+	return s.runScriptSteps(cp, steps)
+}
+
+// resumeInvocation implements the `resumeinvocation` RPC call.
+func (s *Server) resumeInvocation(reqParams params.Params) (any, *neorpc.Error) {
+	raw, err := reqParams.Value(0).GetBytesBase64()
+	if err != nil {
+		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("missing parameter or not a base64: %s", err))
+	}
+	cp := new(invocationCheckpoint)
+	r := io.NewBinReaderFromBuf(raw)
+	cp.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("can't decode checkpoint: %s", r.Err))
+	}
+	if len(reqParams) < 2 {
+		return nil, neorpc.ErrInvalidParams
+	}
+	steps, respErr := getStepsParam(reqParams[1])
+	if respErr != nil {
+		return nil, respErr
+	}
+	return s.runScriptSteps(cp, steps)
+}
+
+func getStepsParam(param params.Param) (uint64, *neorpc.Error) {
+	steps, err := param.GetInt()
+	if err != nil {
+		return 0, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid steps number: %s", err))
+	}
+	if steps <= 0 {
+		return 0, neorpc.NewInvalidParamsError("steps number should be positive")
+	}
+	return uint64(steps), nil
+}
+
+// runScriptSteps restores the invocation from the given checkpoint (see
+// vm.Checkpoint) and executes the given number of instructions more. The
+// invocation is performed against the chain state the checkpoint was made at,
+// so historic state is used once the chain moves on. A new checkpoint is
+// returned in the result unless the execution is finished.
+func (s *Server) runScriptSteps(cp *invocationCheckpoint, steps uint64) (any, *neorpc.Error) {
+	var hp *historicParams
+	if cp.height != s.chain.BlockHeight() {
+		if s.chain.GetConfig().Ledger.KeepOnlyLatestState {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("only latest state is supported: %s", errKeepOnlyLatestState))
+		}
+		if cp.height > s.chain.BlockHeight() {
+			return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("checkpoint height %d is higher than the current chain height", cp.height))
+		}
+		hp = &historicParams{nextH: cp.height + 1}
+	}
+	tx := &transaction.Transaction{
+		Script:  cp.script,
+		Signers: cp.signers,
+		Scripts: cp.witnesses,
+	}
+	ic, respErr := s.prepareInvocationContext(trigger.Application, cp.script, util.Uint160{}, tx, nil, hp, false)
+	if respErr != nil {
+		return nil, respErr
+	}
+	defer ic.Finalize()
+
+	if cp.vm.Steps != 0 {
+		err := ic.VM.Restore(&cp.vm)
+		if err != nil {
+			return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("can't restore invocation: %s", err))
+		}
+	}
+	target := cp.vm.Steps + steps
+	ic.VM.SetBreakCondition(func(*vm.Context) bool {
+		return ic.VM.Steps() >= target
+	})
+	err := ic.VM.Run()
+	var faultException string
+	if err != nil {
+		faultException = s.chain.DescribeFault(ic.VM, err)
+	}
+	notifications := ic.Notifications
+	if notifications == nil {
+		notifications = make([]state.NotificationEvent, 0)
+	}
+	res := &result.Invoke{
+		State:          ic.VM.State().String(),
+		GasConsumed:    ic.VM.GasConsumed(),
+		Script:         cp.script,
+		Stack:          ic.VM.Estack().ToArray(),
+		FaultException: faultException,
+		Notifications:  notifications,
+	}
+	if s.config.StrictStackJSON {
+		res.StrictStackSize = strictStackJSONSize
+	}
+	if ic.VM.AtBreakpoint() {
+		c, err := ic.VM.Checkpoint()
+		if err != nil {
+			return nil, neorpc.NewInternalServerError(fmt.Sprintf("can't create checkpoint: %s", err))
+		}
+		cp.vm = *c
+		res.Checkpoint = cp.Bytes()
+	}
+	return res, nil
 }
 
 func (s *Server) getInvokeScriptParams(reqParams params.Params) (*transaction.Transaction, bool, *neorpc.Error) {
@@ -2256,47 +2889,91 @@ func (s *Server) getInvokeScriptParams(reqParams params.Params) (*transaction.Tr
 	return tx, verbose, nil
 }
 
+// invokeContainedScript implements the `invokecontainedscript` RPC call.
+func (s *Server) invokeContainedScript(reqParams params.Params) (any, *neorpc.Error) {
+	raw, err := reqParams.Value(0).GetBytesBase64()
+	if err != nil {
+		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("missing parameter or not a base64: %s", err))
+	}
+	var (
+		b      *block.Block
+		script []byte
+	)
+	tx, err := transaction.NewTransactionFromBytes(raw)
+	if err == nil {
+		script = tx.Script
+	} else {
+		tx = nil
+		b = block.New(s.stateRootEnabled)
+		r := io.NewBinReaderFromBuf(raw)
+		b.DecodeBinary(r)
+		if r.Err != nil {
+			return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("can't decode container: not a transaction (%s) and not a block (%s)", err, r.Err))
+		}
+		if b.Index != s.chain.BlockHeight()+1 || b.PrevHash != s.chain.CurrentBlockHash() {
+			return nil, neorpc.NewInvalidParamsError("block container must be the next block")
+		}
+	}
+	if len(reqParams) > 1 && !reqParams[1].IsNull() {
+		script, err = reqParams[1].GetBytesBase64()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid script: %s", err))
+		}
+	}
+	if len(script) == 0 {
+		return nil, neorpc.NewInvalidParamsError("no script to execute")
+	}
+	var verbose bool
+	if len(reqParams) > 2 {
+		verbose, err = reqParams[2].GetBoolean()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+		}
+	}
+	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx, b, nil, verbose)
+}
+
 // invokeContractVerify implements the `invokecontractverify` RPC call.
 func (s *Server) invokeContractVerify(reqParams params.Params) (any, *neorpc.Error) {
-	scriptHash, tx, invocationScript, respErr := s.getInvokeContractVerifyParams(reqParams)
+	scriptHash, tx, invocationScript, verbose, respErr := s.getInvokeContractVerifyParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, nil, false)
+	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, nil, nil, verbose)
 }
 
 // invokeContractVerifyHistoric implements the `invokecontractverifyhistoric` RPC call.
 func (s *Server) invokeContractVerifyHistoric(reqParams params.Params) (any, *neorpc.Error) {
-	nextH, respErr := s.getHistoricParams(reqParams)
+	hp, respErr := s.getHistoricParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
 	}
 	if len(reqParams) < 2 {
 		return nil, neorpc.ErrInvalidParams
 	}
-	scriptHash, tx, invocationScript, respErr := s.getInvokeContractVerifyParams(reqParams[1:])
+	scriptHash, tx, invocationScript, verbose, respErr := s.getInvokeContractVerifyParams(reqParams[1:])
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, &nextH, false)
+	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, nil, hp, verbose)
 }
 
-func (s *Server) getInvokeContractVerifyParams(reqParams params.Params) (util.Uint160, *transaction.Transaction, []byte, *neorpc.Error) {
+func (s *Server) getInvokeContractVerifyParams(reqParams params.Params) (util.Uint160, *transaction.Transaction, []byte, bool, *neorpc.Error) {
 	scriptHash, responseErr := s.contractScriptHashFromParam(reqParams.Value(0))
 	if responseErr != nil {
-		return util.Uint160{}, nil, nil, responseErr
+		return util.Uint160{}, nil, nil, false, responseErr
 	}
 
 	bw := io.NewBufBinWriter()
 	if len(reqParams) > 1 {
 		args, err := reqParams[1].GetArray() // second `invokecontractverify` parameter is an array of arguments for `verify` method
 		if err != nil {
-			return util.Uint160{}, nil, nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+			return util.Uint160{}, nil, nil, false, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
 		}
 		if len(args) > 0 {
 			err := params.ExpandArrayIntoScript(bw.BinWriter, args)
 			if err != nil {
-				return util.Uint160{}, nil, nil, neorpc.NewInternalServerError(fmt.Sprintf("can't create witness invocation script: %s", err))
+				return util.Uint160{}, nil, nil, false, neorpc.NewInternalServerError(fmt.Sprintf("can't create witness invocation script: %s", err))
 			}
 		}
 	}
@@ -2306,7 +2983,7 @@ func (s *Server) getInvokeContractVerifyParams(reqParams params.Params) (util.Ui
 	if len(reqParams) > 2 {
 		signers, witnesses, err := reqParams[2].GetSignersWithWitnesses()
 		if err != nil {
-			return util.Uint160{}, nil, nil, neorpc.ErrInvalidParams
+			return util.Uint160{}, nil, nil, false, neorpc.ErrInvalidParams
 		}
 		tx.Signers = signers
 		tx.Scripts = witnesses
@@ -2314,55 +2991,137 @@ func (s *Server) getInvokeContractVerifyParams(reqParams params.Params) (util.Ui
 		tx.Signers = []transaction.Signer{{Account: scriptHash}}
 		tx.Scripts = []transaction.Witness{{InvocationScript: invocationScript, VerificationScript: []byte{}}}
 	}
-	return scriptHash, tx, invocationScript, nil
+	var verbose bool
+	if len(reqParams) > 3 {
+		var err error
+		verbose, err = reqParams[3].GetBoolean()
+		if err != nil {
+			return util.Uint160{}, nil, nil, false, neorpc.ErrInvalidParams
+		}
+	}
+	return scriptHash, tx, invocationScript, verbose, nil
+}
+
+// invocationCheckpoint is a paused invocation state returned by stepping
+// invocation calls to the client, it contains everything needed to restore
+// the invocation.
+type invocationCheckpoint struct {
+	// height is the chain height the invocation is performed at.
+	height    uint32
+	script    []byte
+	signers   []transaction.Signer
+	witnesses []transaction.Witness
+	vm        vm.Checkpoint
+}
+
+// EncodeBinary implements the io.Serializable interface.
+func (c *invocationCheckpoint) EncodeBinary(w *io.BinWriter) {
+	w.WriteU32LE(c.height)
+	w.WriteVarBytes(c.script)
+	w.WriteArray(c.signers)
+	w.WriteArray(c.witnesses)
+	c.vm.EncodeBinary(w)
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (c *invocationCheckpoint) DecodeBinary(r *io.BinReader) {
+	c.height = r.ReadU32LE()
+	c.script = r.ReadVarBytes(transaction.MaxScriptLength)
+	r.ReadArray(&c.signers, transaction.MaxAttributes)
+	r.ReadArray(&c.witnesses, transaction.MaxAttributes)
+	c.vm.DecodeBinary(r)
+}
+
+// Bytes returns serialized checkpoint.
+func (c *invocationCheckpoint) Bytes() []byte {
+	w := io.NewBufBinWriter()
+	c.EncodeBinary(w.BinWriter)
+	return w.Bytes()
+}
+
+// historicParams describes the state historic call is performed against.
+type historicParams struct {
+	// nextH is the index of a fake next block to perform the historic call in.
+	nextH uint32
+	// replayTx is the hash of a transaction which execution is replayed, it's
+	// nil unless historic call is performed for a transaction.
+	replayTx *util.Uint256
 }
 
 // getHistoricParams checks that historic calls are supported and returns index of
 // a fake next block to perform the historic call. It also checks that
 // specified stateroot is stored at the specified height for further request
-// handling consistency.
-func (s *Server) getHistoricParams(reqParams params.Params) (uint32, *neorpc.Error) {
+// handling consistency. If a transaction hash is specified, the call is performed
+// on the state before the block containing this transaction and
+// System.Runtime.GetRandom values of the original transaction are replayed.
+func (s *Server) getHistoricParams(reqParams params.Params) (*historicParams, *neorpc.Error) {
 	if s.chain.GetConfig().Ledger.KeepOnlyLatestState {
-		return 0, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("only latest state is supported: %s", errKeepOnlyLatestState))
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("only latest state is supported: %s", errKeepOnlyLatestState))
 	}
 	if len(reqParams) < 1 {
-		return 0, neorpc.ErrInvalidParams
+		return nil, neorpc.ErrInvalidParams
 	}
 	height, respErr := s.blockHeightFromParam(reqParams.Value(0))
 	if respErr != nil {
 		hash, err := reqParams.Value(0).GetUint256()
 		if err != nil {
-			return 0, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid block hash or index or stateroot hash: %s", err))
+			return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid block hash or index or stateroot hash or transaction hash: %s", err))
 		}
 		b, err := s.chain.GetBlock(hash)
+		if err == nil {
+			return &historicParams{nextH: b.Index + 1}, nil
+		}
+		if _, txH, err := s.chain.GetTransaction(hash); err == nil {
+			return &historicParams{nextH: txH, replayTx: &hash}, nil
+		}
+		stateH, err := s.chain.GetStateModule().GetLatestStateHeight(hash)
 		if err != nil {
-			stateH, err := s.chain.GetStateModule().GetLatestStateHeight(hash)
-			if err != nil {
-				return 0, neorpc.NewInvalidParamsError(fmt.Sprintf("unknown block or stateroot: %s", err))
-			}
-			height = stateH
-		} else {
-			height = b.Index
+			return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("unknown block, transaction or stateroot: %s", err))
 		}
+		height = stateH
 	}
-	return height + 1, nil
+	return &historicParams{nextH: height + 1}, nil
 }
 
-func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, verbose bool) (*interop.Context, *neorpc.Error) {
+func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, b *block.Block, hp *historicParams, verbose bool) (*interop.Context, *neorpc.Error) {
 	var (
 		err error
 		ic  *interop.Context
 	)
-	if nextH == nil {
+	if hp == nil {
 		ic, err = s.chain.GetTestVM(t, tx, nil)
 		if err != nil {
 			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to create test VM: %s", err))
 		}
 	} else {
-		ic, err = s.chain.GetTestHistoricVM(t, tx, *nextH)
+		ic, err = s.chain.GetTestHistoricVM(t, tx, hp.nextH)
 		if err != nil {
 			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to create historic VM: %s", err))
 		}
+		if hp.replayTx != nil {
+			// Use the real block instead of the fake one to get the same
+			// time and random numbers as the original transaction had.
+			hdr, err := s.chain.GetHeader(s.chain.GetHeaderHash(hp.nextH))
+			if err != nil {
+				ic.Finalize()
+				return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get block header: %s", err))
+			}
+			ic.Block.Timestamp = hdr.Timestamp
+			ic.Block.Nonce = hdr.Nonce
+			ic.ReplayRandom(*hp.replayTx)
+		}
+	}
+	if b != nil {
+		// Block is witnessed by the previous block's NextConsensus.
+		prev, err := s.chain.GetHeader(b.PrevHash)
+		if err != nil {
+			ic.Finalize()
+			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get previous block header: %s", err))
+		}
+		ic.Block = b
+		ic.Container = b
+		ic.InitNonceData()
+		ic.UseSigners([]transaction.Signer{{Account: prev.NextConsensus, Scopes: transaction.Global}})
 	}
 	if verbose {
 		ic.VM.EnableInvocationTree()
@@ -2394,28 +3153,33 @@ func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contrac
 // result. The script is either a simple script in case of `application` trigger,
 // witness invocation script in case of `verification` trigger (it pushes `verify`
 // arguments on stack before verification). In case of contract verification
-// contractScriptHash should be specified.
-func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, verbose bool) (*result.Invoke, *neorpc.Error) {
-	ic, respErr := s.prepareInvocationContext(t, script, contractScriptHash, tx, nextH, verbose)
+// contractScriptHash should be specified. If b is not nil, it's used as the
+// script container instead of tx.
+func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, b *block.Block, hp *historicParams, verbose bool) (*result.Invoke, *neorpc.Error) {
+	ic, respErr := s.prepareInvocationContext(t, script, contractScriptHash, tx, b, hp, verbose)
 	if respErr != nil {
 		return nil, respErr
 	}
+	var prof *verificationProfiler
+	if verbose && t == trigger.Verification {
+		prof = newVerificationProfiler(ic.VM)
+	}
 	err := ic.VM.Run()
 	var faultException string
 	if err != nil {
-		faultException = err.Error()
+		faultException = s.chain.DescribeFault(ic.VM, err)
 	}
 	items := ic.VM.Estack().ToArray()
 	sess := s.postProcessExecStack(items)
 	var id uuid.UUID
 
 	if sess != nil {
-		// nextH == nil only when we're not using MPT-backed storage, therefore
+		// hp == nil only when we're not using MPT-backed storage, therefore
 		// the second attempt won't stop here.
-		if s.config.SessionBackedByMPT && nextH == nil {
+		if s.config.SessionBackedByMPT && hp == nil {
 			ic.Finalize()
 			// Rerun with MPT-backed storage.
-			return s.runScriptInVM(t, script, contractScriptHash, tx, &ic.Block.Index, verbose)
+			return s.runScriptInVM(t, script, contractScriptHash, tx, b, &historicParams{nextH: ic.Block.Index}, verbose)
 		}
 		id = uuid.New()
 		sessionID := id.String()
@@ -2449,9 +3213,18 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 	var diag *result.InvokeDiag
 	tree := ic.VM.GetInvocationTree()
 	if tree != nil {
+		stats := ic.VM.StackStats()
 		diag = &result.InvokeDiag{
 			Invocations: tree.Calls,
 			Changes:     storage.BatchToOperations(ic.DAO.GetBatch()),
+			StackUsage: &result.StackUsage{
+				InvocationDepth: stats.InvocationDepth,
+				EvaluationDepth: stats.EvaluationDepth,
+				Items:           stats.Items,
+			},
+		}
+		if prof != nil {
+			diag.Verification = prof.diag(s.chain.FeePerByte(), script)
 		}
 	}
 	notifications := ic.Notifications
@@ -2468,10 +3241,84 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 		Diagnostics:    diag,
 		Session:        id,
 	}
+	if s.config.StrictStackJSON {
+		res.StrictStackSize = strictStackJSONSize
+	}
 
 	return res, nil
 }
 
+// verificationProfiler collects per-contract GAS and instruction statistics
+// for the contract verification.
+type verificationProfiler struct {
+	v         *vm.VM
+	contracts []result.ContractUsage
+	indexes   map[util.Uint160]int
+	steps     uint64
+	// last is the last executed instruction and gas is the GAS consumed
+	// before it.
+	last *result.ExecutionPoint
+	gas  int64
+}
+
+// newVerificationProfiler creates a profiler and sets it as the OnExec hook of
+// the VM, so it must be called before the VM is started.
+func newVerificationProfiler(v *vm.VM) *verificationProfiler {
+	p := &verificationProfiler{
+		v:       v,
+		indexes: make(map[util.Uint160]int),
+		gas:     v.GasConsumed(),
+	}
+	v.SetOnExecHook(p.onExec)
+	return p
+}
+
+// onExec attributes the GAS consumed by the previous instruction to the
+// contract it belongs to.
+func (p *verificationProfiler) onExec(h util.Uint160, offset int, op opcode.Opcode) {
+	p.account()
+	i, ok := p.indexes[h]
+	if !ok {
+		i = len(p.contracts)
+		p.indexes[h] = i
+		p.contracts = append(p.contracts, result.ContractUsage{Hash: h})
+	}
+	p.contracts[i].Steps++
+	p.steps++
+	p.last = &result.ExecutionPoint{Hash: h, Offset: offset, Opcode: op.String()}
+}
+
+// account attributes the GAS consumed since the last call to the contract of
+// the last executed instruction.
+func (p *verificationProfiler) account() {
+	gas := p.v.GasConsumed()
+	if p.last != nil {
+		p.contracts[p.indexes[p.last.Hash]].GasConsumed += gas - p.gas
+	}
+	p.gas = gas
+}
+
+// diag returns verification diagnostics for the finished VM, the network fee
+// is calculated for the contract witness with the given invocation script.
+func (p *verificationProfiler) diag(feePerByte int64, invocationScript []byte) *result.VerificationDiag {
+	p.account()
+	w := transaction.Witness{InvocationScript: invocationScript, VerificationScript: []byte{}}
+	res := &result.VerificationDiag{
+		Steps:           p.steps,
+		Contracts:       p.contracts,
+		VerificationFee: p.v.GasConsumed(),
+		SizeFee:         int64(io.GetVarSize(&w)) * feePerByte,
+	}
+	if res.Contracts == nil {
+		res.Contracts = []result.ContractUsage{}
+	}
+	if p.v.HasFailed() {
+		res.Fault = p.last
+	}
+	res.NetworkFee = res.VerificationFee + res.SizeFee
+	return res
+}
+
 // postProcessExecStack changes iterator interop items according to the server configuration.
 // It does modifications in-place, but it returns a session if any iterator was registered.
 func (s *Server) postProcessExecStack(stack []stackitem.Item) *session {
@@ -2615,7 +3462,7 @@ func (s *Server) submitBlock(reqParams params.Params) (any, *neorpc.Error) {
 	if r.Err != nil {
 		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("can't decode block: %s", r.Err))
 	}
-	return getRelayResult(s.chain.AddBlock(b), b.Hash())
+	return getRelayResult(s.coreServer.RelayBlock(b), b.Hash())
 }
 
 // submitNotaryRequest broadcasts P2PNotaryRequest over the Neo network.
@@ -2642,6 +3489,8 @@ func getRelayResult(err error, hash util.Uint256) (any, *neorpc.Error) {
 		return result.RelayResult{
 			Hash: hash,
 		}, nil
+	case errors.Is(err, network.ErrSyncInProgress):
+		return nil, neorpc.NewInternalServerError(err.Error())
 	case errors.Is(err, core.ErrTxExpired):
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrExpiredTransaction, err.Error())
 	case errors.Is(err, core.ErrAlreadyExists) || errors.Is(err, core.ErrInvalidBlockIndex):
@@ -2725,7 +3574,7 @@ func (s *Server) subscribe(reqParams params.Params, sub *subscriber) (any, *neor
 		return nil, neorpc.ErrInvalidParams
 	}
 	event, err := neorpc.GetEventIDFromString(streamName)
-	if err != nil || event == neorpc.MissedEventID {
+	if err != nil || event == neorpc.MissedEventID || event == neorpc.StorageBatchEventID {
 		return nil, neorpc.ErrInvalidParams
 	}
 	if event == neorpc.NotaryRequestEventID && !s.chain.P2PSigExtensionsEnabled() {
@@ -2833,6 +3682,11 @@ func (s *Server) subscribeToChannel(event neorpc.EventID) {
 			s.chain.SubscribeForHeadersOfAddedBlocks(s.blockHeaderCh)
 		}
 		s.blockHeaderSubs++
+	case neorpc.BlockRejectedEventID:
+		if s.rejectedSubs == 0 {
+			s.chain.SubscribeForRejectedBlocks(s.rejectedCh)
+		}
+		s.rejectedSubs++
 	default:
 	}
 }
@@ -2894,6 +3748,11 @@ func (s *Server) unsubscribeFromChannel(event neorpc.EventID) {
 		if s.blockHeaderSubs == 0 {
 			s.chain.UnsubscribeFromHeadersOfAddedBlocks(s.blockHeaderCh)
 		}
+	case neorpc.BlockRejectedEventID:
+		s.rejectedSubs--
+		if s.rejectedSubs == 0 {
+			s.chain.UnsubscribeFromRejectedBlocks(s.rejectedCh)
+		}
 	default:
 	}
 }
@@ -2911,6 +3770,7 @@ func (s *Server) handleSubEvents() {
 		s.log.Error("fatal: failed to marshal overflow event", zap.Error(err))
 		return
 	}
+	overflowData := b
 	overflowMsg, err := websocket.NewPreparedMessage(websocket.TextMessage, b)
 	if err != nil {
 		s.log.Error("fatal: failed to prepare overflow message", zap.Error(err))
@@ -2947,8 +3807,13 @@ chloop:
 		case header := <-s.blockHeaderCh:
 			resp.Event = neorpc.HeaderOfAddedBlockEventID
 			resp.Payload[0] = header
+		case rb := <-s.rejectedCh:
+			resp.Event = neorpc.BlockRejectedEventID
+			resp.Payload[0] = rb
 		}
 		s.subsLock.RLock()
+		s.eventSeq++
+		var hist = sseEvent{id: s.eventSeq, ntf: &resp}
 	subloop:
 		for sub := range s.subscribers {
 			if sub.overflown.Load() {
@@ -2972,13 +3837,14 @@ chloop:
 							break subloop
 						}
 					}
+					hist.data = b
 					select {
-					case sub.writer <- intEvent{msg, &resp}:
+					case sub.writer <- intEvent{msg: msg, ntf: &resp, data: b, id: hist.id}:
 					default:
 						sub.overflown.Store(true)
 						// MissedEvent is to be delivered eventually.
 						go func(sub *subscriber) {
-							sub.writer <- intEvent{overflowMsg, &overflowEvent}
+							sub.writer <- intEvent{msg: overflowMsg, ntf: &overflowEvent, data: overflowData}
 							sub.overflown.Store(false)
 						}(sub)
 					}
@@ -2987,6 +3853,7 @@ chloop:
 				}
 			}
 		}
+		s.addSSEHistory(hist)
 		s.subsLock.RUnlock()
 	}
 	// It's important to do it with subsCounterLock held because no subscription routine
@@ -3000,6 +3867,7 @@ chloop:
 	s.chain.UnsubscribeFromNotifications(s.notificationCh)
 	s.chain.UnsubscribeFromExecutions(s.executionCh)
 	s.chain.UnsubscribeFromHeadersOfAddedBlocks(s.blockHeaderCh)
+	s.chain.UnsubscribeFromRejectedBlocks(s.rejectedCh)
 	if s.chain.P2PSigExtensionsEnabled() {
 		s.coreServer.UnsubscribeFromNotaryRequests(s.notaryRequestCh)
 	}
@@ -3013,6 +3881,7 @@ drainloop:
 		case <-s.transactionCh:
 		case <-s.notaryRequestCh:
 		case <-s.blockHeaderCh:
+		case <-s.rejectedCh:
 		default:
 			break drainloop
 		}
@@ -3025,6 +3894,7 @@ drainloop:
 	close(s.executionCh)
 	close(s.notaryRequestCh)
 	close(s.blockHeaderCh)
+	close(s.rejectedCh)
 	// notify Shutdown routine
 	close(s.subEventsToExitCh)
 }
@@ -3086,20 +3956,12 @@ func (s *Server) writeHTTPErrorResponse(r *params.In, w http.ResponseWriter, jso
 	s.writeHTTPServerResponse(&params.Request{In: r}, w, resp)
 }
 
-func setCORSOriginHeaders(h http.Header) {
-	h.Set("Access-Control-Allow-Origin", "*")
-	h.Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
-}
-
 func (s *Server) writeHTTPServerResponse(r *params.Request, w http.ResponseWriter, resp abstractResult) {
 	// Errors can happen in many places and we can only catch ALL of them here.
 	resp.RunForErrors(func(jsonErr *neorpc.Error) {
 		s.logRequestError(r, jsonErr)
 	})
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	if s.config.EnableCORSWorkaround {
-		setCORSOriginHeaders(w.Header())
-	}
 	if r.In != nil {
 		resp := resp.(abstract)
 		if resp.Error != nil {
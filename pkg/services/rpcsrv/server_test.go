@@ -36,6 +36,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dboper"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash/merkle"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
@@ -132,6 +133,14 @@ var rpcFunctionsWithUnsupportedStatesTestCases = map[string][]rpcTestCase{
 			errCode: neorpc.ErrUnsupportedStateCode,
 		},
 	},
+	"getrangeproof": {
+		{
+			name:    "unsupported state",
+			params:  `["` + block20StateRootLE + `", "0xabcdef"]`,
+			fail:    true,
+			errCode: neorpc.ErrUnsupportedStateCode,
+		},
+	},
 	"findstoragehistoric": {
 		{
 			name:    "unsupported state",
@@ -588,6 +597,12 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:    true,
 			errCode: neorpc.InvalidParamsCode,
 		},
+		{
+			name:    "invalid backwards flag",
+			params:  `["` + block20StateRootLE + `", "` + testContractHash + `", "QQ==", "", 1, "notabool"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
 		{
 			name:    "unknown contract/large count",
 			params:  `["` + block20StateRootLE + `", "0000000000000000000000000000000000000000", "QQ==", "QQ==", 101]`,
@@ -595,6 +610,70 @@ var rpcTestCases = map[string][]rpcTestCase{
 			errCode: neorpc.ErrUnknownContractCode,
 		},
 	},
+	"getrangeproof": {
+		{
+			name:    "no params",
+			params:  `[]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid root",
+			params:  `["0xabcdef"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid contract",
+			params:  `["` + block20StateRootLE + `", "0xabcdef"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid prefix",
+			params:  `["` + block20StateRootLE + `", "` + testContractHash + `", "notabase64%"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid from",
+			params:  `["` + block20StateRootLE + `", "` + testContractHash + `", "QQ==", "notabase64%"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "from doesn't match prefix",
+			params:  `["` + block20StateRootLE + `", "` + testContractHash + `", "QQ==", "Qg=="]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid count",
+			params:  `["` + block20StateRootLE + `", "` + testContractHash + `", "QQ==", "", 0]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "unknown contract",
+			params:  `["` + block20StateRootLE + `", "0000000000000000000000000000000000000000", "QQ=="]`,
+			fail:    true,
+			errCode: neorpc.ErrUnknownContractCode,
+		},
+	},
+	"verifyrangeproof": {
+		{
+			name:    "no params",
+			params:  `[]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid proof",
+			params:  `["` + block20StateRootLE + `", "notabase64%"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+	},
 	"getstateheight": {
 		{
 			name:   "positive",
@@ -663,6 +742,42 @@ var rpcTestCases = map[string][]rpcTestCase{
 			errCode: neorpc.InvalidParamsCode,
 		},
 	},
+	"getstoragebatch": {
+		{
+			name:   "positive",
+			params: fmt.Sprintf(`["%s", ["dGVzdGtleQ==", "dGU="]]`, testContractHash),
+			result: func(e *executor) any {
+				return &[]result.KeyValue{
+					{Key: []byte("testkey"), Value: []byte("newtestvalue")},
+					{Key: []byte("te")},
+				}
+			},
+		},
+		{
+			name:    "no params",
+			params:  `[]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "no keys",
+			params:  fmt.Sprintf(`["%s", []]`, testContractHash),
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "too many keys",
+			params:  fmt.Sprintf(`["%s", ["YQ==", "Yg==", "Yw=="]]`, testContractHash),
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid key",
+			params:  fmt.Sprintf(`["%s", ["notabase64$"]]`, testContractHash),
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+	},
 	"getstoragehistoric": {
 		{
 			name:   "positive",
@@ -1203,6 +1318,46 @@ var rpcTestCases = map[string][]rpcTestCase{
 				}
 			},
 		},
+		{
+			name:   "by height",
+			params: "[0]",
+			result: func(e *executor) any {
+				return new([]state.Contract)
+			},
+			check: func(t *testing.T, e *executor, res any) {
+				lst := res.(*[]state.Contract)
+				require.Equal(t, e.chain.GetNatives(), *lst)
+			},
+		},
+		{
+			name:   "by hardfork",
+			params: `["Aspidochelone"]`,
+			result: func(e *executor) any {
+				return new([]state.Contract)
+			},
+			check: func(t *testing.T, e *executor, res any) {
+				lst := res.(*[]state.Contract)
+				require.Equal(t, e.chain.GetNatives(), *lst)
+			},
+		},
+		{
+			name:    "disabled hardfork",
+			params:  `["Basilisk"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid parameter",
+			params:  `["first"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "unknown height",
+			params:  `[-2]`,
+			fail:    true,
+			errCode: neorpc.ErrUnknownHeightCode,
+		},
 	},
 	"getpeers": {
 		{
@@ -1269,6 +1424,45 @@ var rpcTestCases = map[string][]rpcTestCase{
 			errCode: neorpc.ErrUnknownTransactionCode,
 		},
 	},
+	"gettransactionproof": {
+		{
+			name:   "positive",
+			params: `["` + deploymentTxHash + `"]`,
+			result: func(e *executor) any { return new(result.TransactionProof) },
+			check: func(t *testing.T, e *executor, resp any) {
+				res, ok := resp.(*result.TransactionProof)
+				require.True(t, ok)
+				b, err := e.chain.GetBlock(e.chain.GetHeaderHash(2))
+				require.NoError(t, err)
+				require.Equal(t, b.Hash(), res.BlockHash)
+				require.Equal(t, uint32(2), res.BlockIndex)
+				require.Equal(t, b.MerkleRoot, res.MerkleRoot)
+				require.Equal(t, len(b.Transactions), res.TxCount)
+				h, err := util.Uint256DecodeStringLE(deploymentTxHash)
+				require.NoError(t, err)
+				require.Equal(t, h, b.Transactions[res.Index].Hash())
+				require.NoError(t, merkle.VerifyProof(b.MerkleRoot, h, res.Index, res.TxCount, res.Proof))
+			},
+		},
+		{
+			name:    "no params",
+			params:  `[]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid hash",
+			params:  `["notahex"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "missing hash",
+			params:  `["` + util.Uint256{}.String() + `"]`,
+			fail:    true,
+			errCode: neorpc.ErrUnknownTransactionCode,
+		},
+	},
 	"getunclaimedgas": {
 		{
 			name:    "no params",
@@ -1299,6 +1493,77 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getaccountstates": {
+		{
+			name:    "no params",
+			params:  "[]",
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "no accounts",
+			params:  "[[]]",
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "too many accounts",
+			params:  `[[` + strings.Repeat(`"`+testchain.MultisigAddress()+`", `, 100) + `"` + testchain.MultisigAddress() + `"]]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid address",
+			params:  `[["invalid"]]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "past height",
+			params:  `[["` + testchain.MultisigAddress() + `"], 1]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:   "positive",
+			params: `[["` + testchain.MultisigAddress() + `", "` + util.Uint160{1, 2, 3}.StringLE() + `"]]`,
+			result: func(*executor) any {
+				return &[]result.AccountState{}
+			},
+			check: func(t *testing.T, e *executor, resp any) {
+				actual, ok := resp.(*[]result.AccountState)
+				require.True(t, ok)
+				st, err := e.chain.GetNEOAccountState(testchain.MultisigScriptHash())
+				require.NoError(t, err)
+				expected := []result.AccountState{{
+					Address:       testchain.MultisigScriptHash(),
+					Balance:       st.Balance,
+					BalanceHeight: st.BalanceHeight,
+					VoteTo:        st.VoteTo,
+					Unclaimed:     *big.NewInt(11500),
+				}, {
+					Address: util.Uint160{1, 2, 3},
+				}}
+				require.Equal(t, expected, *actual)
+			},
+		},
+		{
+			name:   "projected",
+			params: `[["` + testchain.MultisigAddress() + `"], 1000]`,
+			result: func(*executor) any {
+				return &[]result.AccountState{}
+			},
+			check: func(t *testing.T, e *executor, resp any) {
+				actual, ok := resp.(*[]result.AccountState)
+				require.True(t, ok)
+				require.Equal(t, 1, len(*actual))
+				expected, err := e.chain.ProjectClaimable(testchain.MultisigScriptHash(), 1000)
+				require.NoError(t, err)
+				require.Equal(t, expected, &(*actual)[0].Unclaimed)
+				require.Equal(t, 1, expected.Cmp(big.NewInt(11500)))
+			},
+		},
+	},
 	"getcandidates": {
 		{
 			params: "[]",
@@ -1475,6 +1740,11 @@ var rpcTestCases = map[string][]rpcTestCase{
 								},
 							},
 						}},
+						StackUsage: &result.StackUsage{
+							InvocationDepth: 8,
+							EvaluationDepth: 6,
+							Items:           34,
+						},
 					},
 				}
 			},
@@ -1594,6 +1864,11 @@ var rpcTestCases = map[string][]rpcTestCase{
 								},
 							},
 						}},
+						StackUsage: &result.StackUsage{
+							InvocationDepth: 8,
+							EvaluationDepth: 6,
+							Items:           34,
+						},
 					},
 				}
 			},
@@ -1671,6 +1946,11 @@ var rpcTestCases = map[string][]rpcTestCase{
 						Invocations: []*invocations.Tree{{
 							Current: hash.Hash160(script),
 						}},
+						StackUsage: &result.StackUsage{
+							InvocationDepth: 1,
+							EvaluationDepth: 0,
+							Items:           0,
+						},
 					},
 				}
 			},
@@ -1768,6 +2048,18 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.NotEqual(t, 0, res.GasConsumed)
 			},
 		},
+		{
+			name:   "positive, by transaction",
+			params: `["` + deploymentTxHash + `","UcVrDUhlbGxvLCB3b3JsZCFoD05lby5SdW50aW1lLkxvZ2FsdWY="]`,
+			result: func(e *executor) any { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv any) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.NotEqual(t, "", res.Script)
+				assert.NotEqual(t, "", res.State)
+				assert.NotEqual(t, 0, res.GasConsumed)
+			},
+		},
 		{
 			name:   "positive,verbose",
 			params: `[20, "UcVrDUhlbGxvLCB3b3JsZCFoD05lby5SdW50aW1lLkxvZ2FsdWY=",[],true]`,
@@ -1785,6 +2077,11 @@ var rpcTestCases = map[string][]rpcTestCase{
 						Invocations: []*invocations.Tree{{
 							Current: hash.Hash160(script),
 						}},
+						StackUsage: &result.StackUsage{
+							InvocationDepth: 1,
+							EvaluationDepth: 0,
+							Items:           0,
+						},
 					},
 				}
 			},
@@ -1889,6 +2186,35 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.Equal(t, true, res.Stack[0].Value().(bool), fmt.Sprintf("check address in verification_contract.go: expected %s", testchain.PrivateKeyByID(0).Address()))
 			},
 		},
+		{
+			name:   "positive, verbose",
+			params: fmt.Sprintf(`["%s", [], [{"account":"%s"}], true]`, verifyContractHash, testchain.PrivateKeyByID(0).PublicKey().GetScriptHash().StringLE()),
+			result: func(e *executor) any { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv any) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "HALT", res.State)
+				assert.Equal(t, true, res.Stack[0].Value().(bool))
+				require.NotNil(t, res.Diagnostics)
+				diag := res.Diagnostics.Verification
+				require.NotNil(t, diag)
+				require.Len(t, diag.Contracts, 1)
+				assert.Equal(t, verifyContractHash, diag.Contracts[0].Hash.StringLE())
+				assert.Equal(t, res.GasConsumed, diag.Contracts[0].GasConsumed)
+				assert.Equal(t, diag.Steps, diag.Contracts[0].Steps)
+				assert.NotZero(t, diag.Steps)
+				assert.Nil(t, diag.Fault)
+				assert.Equal(t, res.GasConsumed, diag.VerificationFee)
+				assert.Equal(t, 2*e.chain.FeePerByte(), diag.SizeFee) // Empty invocation and verification scripts.
+				assert.Equal(t, diag.VerificationFee+diag.SizeFee, diag.NetworkFee)
+			},
+		},
+		{
+			name:    "invalid verbose flag",
+			params:  fmt.Sprintf(`["%s", [], [{"account":"%s"}], {}]`, verifyContractHash, testchain.PrivateKeyByID(0).PublicKey().GetScriptHash().StringLE()),
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
 		{
 			name:   "positive, no signers",
 			params: fmt.Sprintf(`["%s", []]`, verifyContractHash),
@@ -2242,6 +2568,60 @@ func TestRPC(t *testing.T) {
 	})
 }
 
+type testConsensus struct {
+	state *result.ConsensusState
+}
+
+func (c testConsensus) GetState() *result.ConsensusState { return c.state }
+
+func TestGetConsensusState(t *testing.T) {
+	req := `{"jsonrpc": "2.0", "id": 1, "method": "getconsensusstate", "params": []}`
+
+	t.Run("disabled in config", func(t *testing.T) {
+		_, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {})
+		rpcSrv.SetConsensusHandler(testConsensus{state: &result.ConsensusState{}})
+		body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+		checkErrGetResult(t, body, true, neorpc.ErrConsensusDisabledCode)
+	})
+
+	_, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.ConsensusStateEnabled = true
+	})
+	t.Run("no consensus", func(t *testing.T) {
+		body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+		checkErrGetResult(t, body, true, neorpc.ErrConsensusDisabledCode)
+	})
+	t.Run("not started", func(t *testing.T) {
+		rpcSrv.SetConsensusHandler(testConsensus{})
+		body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+		checkErrGetResult(t, body, true, neorpc.ErrConsensusDisabledCode)
+	})
+	t.Run("positive", func(t *testing.T) {
+		pub, err := keys.NewPublicKeyFromString("03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c")
+		require.NoError(t, err)
+		expected := &result.ConsensusState{
+			Height:        5,
+			View:          1,
+			Index:         -1,
+			NextPrimary:   3,
+			M:             1,
+			Preparations:  1,
+			TimerDeadline: 1700000000000,
+			Validators: []result.ConsensusValidator{{
+				PublicKey: pub,
+				Prepared:  true,
+				LastSeen:  &result.ConsensusHeightView{Height: 5, View: 1},
+			}},
+		}
+		rpcSrv.SetConsensusHandler(testConsensus{state: expected})
+		body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+		res := checkErrGetResult(t, body, false, 0)
+		actual := new(result.ConsensusState)
+		require.NoError(t, json.Unmarshal(res, actual))
+		require.Equal(t, expected, actual)
+	})
+}
+
 func TestSubmitOracle(t *testing.T) {
 	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "submitoracleresponse", "params": %s}`
 
@@ -2872,6 +3252,91 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 				Truncated: true,
 			})
 		})
+		t.Run("good: backwards, no key", func(t *testing.T) {
+			// pairs for this test where put to the contract storage at block #16
+			root, err := e.chain.GetStateModule().GetStateRoot(16)
+			require.NoError(t, err)
+			params := fmt.Sprintf(`"%s", "%s", "%s", "", 10, true`, root.Root.StringLE(), testContractHash, base64.StdEncoding.EncodeToString([]byte("aa")))
+			testFindStates(t, params, root.Root, result.FindStates{
+				Results: []result.KeyValue{
+					{Key: []byte("aa"), Value: []byte("v1")},
+					{Key: []byte("aa50"), Value: []byte("v3")},
+					{Key: []byte("aa10"), Value: []byte("v2")},
+				},
+				Truncated: false,
+			})
+		})
+		t.Run("good: backwards, with key, with limit", func(t *testing.T) {
+			// pairs for this test where put to the contract storage at block #16
+			root, err := e.chain.GetStateModule().GetStateRoot(16)
+			require.NoError(t, err)
+			params := fmt.Sprintf(`"%s", "%s", "%s", "%s", 1, true`, root.Root.StringLE(), testContractHash, base64.StdEncoding.EncodeToString([]byte("aa")), base64.StdEncoding.EncodeToString([]byte("aa")))
+			testFindStates(t, params, root.Root, result.FindStates{
+				Results: []result.KeyValue{
+					{Key: []byte("aa50"), Value: []byte("v3")},
+				},
+				Truncated: true,
+			})
+		})
+		t.Run("good: explicit forward", func(t *testing.T) {
+			// pairs for this test where put to the contract storage at block #16
+			root, err := e.chain.GetStateModule().GetStateRoot(16)
+			require.NoError(t, err)
+			params := fmt.Sprintf(`"%s", "%s", "%s", "%s", 2, false`, root.Root.StringLE(), testContractHash, base64.StdEncoding.EncodeToString([]byte("aa")), base64.StdEncoding.EncodeToString([]byte("aa10")))
+			testFindStates(t, params, root.Root, result.FindStates{
+				Results: []result.KeyValue{
+					{Key: []byte("aa50"), Value: []byte("v3")},
+				},
+				Truncated: false,
+			})
+		})
+	})
+	t.Run("getrangeproof", func(t *testing.T) {
+		// pairs for this test where put to the contract storage at block #16
+		root, err := e.chain.GetStateModule().GetStateRoot(16)
+		require.NoError(t, err)
+		testRangeProof := func(t *testing.T, p string, expected []result.KeyValue, truncated bool) {
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getrangeproof", "params": [%s]}`, p)
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			rawRes := checkErrGetResult(t, body, false, 0)
+
+			var actual result.GetRangeProof
+			require.NoError(t, json.Unmarshal(rawRes, &actual))
+			require.Equal(t, expected, actual.Results)
+			require.Equal(t, truncated, actual.Truncated)
+
+			rpc = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "verifyrangeproof", "params": ["%s", "%s"]}`,
+				root.Root.StringLE(), actual.Proof.String())
+			body = doRPCCall(rpc, httpSrv.URL, t)
+			rawRes = checkErrGetResult(t, body, false, 0)
+			var vp result.VerifyRangeProof
+			require.NoError(t, json.Unmarshal(rawRes, &vp))
+			require.Equal(t, expected, vp.Results)
+
+			rpc = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "verifyrangeproof", "params": ["%s", "%s"]}`,
+				util.Uint256{1, 2, 3}.StringLE(), actual.Proof.String())
+			body = doRPCCall(rpc, httpSrv.URL, t)
+			checkErrGetResult(t, body, true, neorpc.ErrInvalidProofCode)
+		}
+		t.Run("no from, no limit", func(t *testing.T) {
+			params := fmt.Sprintf(`"%s", "%s", "%s"`, root.Root.StringLE(), testContractHash, base64.StdEncoding.EncodeToString([]byte("aa")))
+			testRangeProof(t, params, []result.KeyValue{
+				{Key: []byte("aa"), Value: []byte("v1")},
+				{Key: []byte("aa10"), Value: []byte("v2")},
+				{Key: []byte("aa50"), Value: []byte("v3")},
+			}, false)
+		})
+		t.Run("with from, with limit", func(t *testing.T) {
+			params := fmt.Sprintf(`"%s", "%s", "%s", "%s", 1`, root.Root.StringLE(), testContractHash,
+				base64.StdEncoding.EncodeToString([]byte("aa")), base64.StdEncoding.EncodeToString([]byte("aa")))
+			testRangeProof(t, params, []result.KeyValue{
+				{Key: []byte("aa10"), Value: []byte("v2")},
+			}, true)
+		})
+		t.Run("absent", func(t *testing.T) {
+			params := fmt.Sprintf(`"%s", "%s", "%s"`, root.Root.StringLE(), testContractHash, base64.StdEncoding.EncodeToString([]byte("zz")))
+			testRangeProof(t, params, []result.KeyValue{}, false)
+		})
 	})
 
 	t.Run("getrawtransaction", func(t *testing.T) {
@@ -3243,6 +3708,25 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 			}
 			checkCalc(t, tx, 2315100) // Perfectly matches FeeIsMultiSigContract() C# test.
 		})
+		t.Run("secp256k1 signature tx", func(t *testing.T) {
+			priv, err := keys.NewSecp256k1PrivateKey()
+			require.NoError(t, err)
+			txScript, err := smartcontract.CreateCallWithAssertScript(chain.UtilityTokenHash(), "transfer",
+				priv.GetScriptHash(), priv.GetScriptHash(), 1, nil)
+			require.NoError(t, err)
+			tx := &transaction.Transaction{
+				Script:  txScript,
+				Signers: []transaction.Signer{{Account: priv.GetScriptHash(), Scopes: transaction.CalledByEntry}},
+				Scripts: []transaction.Witness{{
+					InvocationScript:   []byte{},
+					VerificationScript: priv.PublicKey().GetVerificationScript(),
+				}},
+			}
+			// Dummy signature is inferred, but Keccak256 is not yet
+			// enabled at the current test chain height.
+			body := calcReq(t, tx)
+			_ = checkErrGetResult(t, body, true, neorpc.ErrInvalidSignatureCode, "keccak hash")
+		})
 		checkContract := func(t *testing.T, verAcc util.Uint160, invoc []byte, fee int64) {
 			txScript, err := smartcontract.CreateCallWithAssertScript(chain.UtilityTokenHash(), "transfer",
 				verAcc, verAcc, 1, nil)
@@ -3384,6 +3868,97 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 			runTestCasesWithExecutor(t, e, rpc, method, cases, doRPCCall, checkErrGetResult)
 		}
 	})
+	t.Run("pruned application logs", func(t *testing.T) {
+		chain, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ApplicationConfiguration.Ledger.AppLogsMaxBlocks = 2
+			c.ApplicationConfiguration.Ledger.CompressAppLogs = true
+		})
+		for _, b := range getTestBlocks(t) {
+			require.NoError(t, chain.AddBlock(b))
+		}
+		b, err := chain.GetBlock(chain.GetHeaderHash(1))
+		require.NoError(t, err)
+		require.NotEmpty(t, b.Transactions)
+		txHash := b.Transactions[0].Hash()
+
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getapplicationlog", "params": ["%s"]}`
+		for _, h := range []util.Uint256{b.Hash(), txHash} {
+			body := doRPCCall(fmt.Sprintf(rpc, h.StringLE()), httpSrv.URL, t)
+			checkErrGetResult(t, body, true, neorpc.ErrAppLogPrunedCode)
+		}
+		body := doRPCCall(fmt.Sprintf(rpc, chain.CurrentBlockHash().StringLE()), httpSrv.URL, t)
+		checkErrGetResult(t, body, false, 0)
+
+		rpc = `{"jsonrpc": "2.0", "id": 1, "method": "getrawtransaction", "params": ["%s", 1]}`
+		body = doRPCCall(fmt.Sprintf(rpc, txHash.StringLE()), httpSrv.URL, t)
+		data := checkErrGetResult(t, body, false, 0)
+		var res result.TransactionOutputRaw
+		require.NoError(t, json.Unmarshal(data, &res))
+		require.Equal(t, txHash, res.Transaction.Hash())
+		require.Equal(t, b.Hash(), res.Blockhash)
+		require.Empty(t, res.VMState)
+	})
+	t.Run("getcontractstats", func(t *testing.T) {
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getcontractstats", "params": []}`
+		t.Run("disabled", func(t *testing.T) {
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			checkErrGetResult(t, body, true, neorpc.InternalServerErrorCode)
+		})
+		chain, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ApplicationConfiguration.Ledger.ContractStatsPeriod = 5
+		})
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		checkErrGetResult(t, body, true, neorpc.InternalServerErrorCode)
+
+		for _, b := range getTestBlocks(t) {
+			require.NoError(t, chain.AddBlock(b))
+		}
+		body = doRPCCall(rpc, httpSrv.URL, t)
+		data := checkErrGetResult(t, body, false, 0)
+		var res result.ContractStats
+		require.NoError(t, json.Unmarshal(data, &res))
+		require.Equal(t, uint32(0), (res.EndBlock+1)%5)
+		require.Equal(t, res.EndBlock-4, res.StartBlock)
+		require.NotEmpty(t, res.Contracts)
+		for i, c := range res.Contracts {
+			require.NotZero(t, c.Calls)
+			require.NotZero(t, c.Executions)
+			require.LessOrEqual(t, c.Faults, c.Executions)
+			if i > 0 {
+				require.GreaterOrEqual(t, res.Contracts[i-1].Calls, c.Calls)
+			}
+		}
+	})
+	t.Run("getvalidatorstats", func(t *testing.T) {
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getvalidatorstats", "params": []}`
+		t.Run("disabled", func(t *testing.T) {
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			checkErrGetResult(t, body, true, neorpc.InternalServerErrorCode)
+		})
+		chain, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ApplicationConfiguration.Ledger.ValidatorStatsWindow = 5
+		})
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		checkErrGetResult(t, body, true, neorpc.InternalServerErrorCode)
+
+		for _, b := range getTestBlocks(t) {
+			require.NoError(t, chain.AddBlock(b))
+		}
+		body = doRPCCall(rpc, httpSrv.URL, t)
+		data := checkErrGetResult(t, body, false, 0)
+		var res result.ValidatorStats
+		require.NoError(t, json.Unmarshal(data, &res))
+		require.Equal(t, chain.BlockHeight(), res.EndBlock)
+		require.Equal(t, res.EndBlock-4, res.StartBlock)
+		vals, err := chain.GetNextBlockValidators()
+		require.NoError(t, err)
+		var produced uint64
+		for _, v := range res.Validators {
+			require.True(t, slices.ContainsFunc(vals, v.PublicKey.Equal))
+			produced += v.Produced
+		}
+		require.Equal(t, uint64(5), produced)
+	})
 }
 
 func (e *executor) getHeader(s string) *block.Header {
@@ -0,0 +1,216 @@
+package rpcsrv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/rpcevent"
+	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
+	"go.uber.org/zap"
+)
+
+// sseEvent is a notification stored in the SSE history for Last-Event-ID
+// based resumption.
+type sseEvent struct {
+	id   uint64
+	ntf  *neorpc.Notification
+	data []byte
+}
+
+const (
+	// ssePath is the URL path of SSE notification endpoint.
+	ssePath = "/sse"
+
+	// sseSubscribeParam is the URL query parameter containing JSON-encoded
+	// `subscribe` method parameters, it can be repeated.
+	sseSubscribeParam = "subscribe"
+
+	// sseKeepAlivePeriod is the period of SSE comment messages used to keep
+	// connection alive through proxies.
+	sseKeepAlivePeriod = wsPingPeriod
+
+	// defaultSSEHistorySize is the default number of events kept for SSE
+	// clients resumption.
+	defaultSSEHistorySize = 1024
+)
+
+// handleSSE serves Server-Sent Events subscription request. Subscriptions are
+// taken from the request URL, each `subscribe` query parameter holds a JSON
+// array of `subscribe` method parameters. Events missed since the event ID
+// specified in Last-Event-ID header are replayed from the history if possible.
+func (s *Server) handleSSE(w http.ResponseWriter, httpRequest *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeHTTPErrorResponse(params.NewIn(), w, neorpc.NewInternalServerError("streaming is not supported"))
+		return
+	}
+	// See the comment on websocket limit check, the same applies here.
+	s.subsLock.RLock()
+	numOfSubs := len(s.subscribers)
+	s.subsLock.RUnlock()
+	if numOfSubs >= s.config.MaxWebSocketClients {
+		s.writeHTTPErrorResponse(params.NewIn(), w, neorpc.NewInternalServerError("subscribers limit reached"))
+		return
+	}
+	var lastID uint64
+	if h := httpRequest.Header.Get("Last-Event-ID"); h != "" {
+		id, err := strconv.ParseUint(h, 10, 64)
+		if err != nil {
+			s.writeHTTPErrorResponse(params.NewIn(), w, neorpc.NewInvalidRequestError(fmt.Sprintf("invalid Last-Event-ID: %s", err)))
+			return
+		}
+		lastID = id
+	}
+	subs := httpRequest.URL.Query()[sseSubscribeParam]
+	if len(subs) == 0 {
+		s.writeHTTPErrorResponse(params.NewIn(), w, neorpc.NewInvalidParamsError("no subscriptions specified"))
+		return
+	}
+	subChan := make(chan intEvent, notificationBufSize)
+	subscr := &subscriber{writer: subChan}
+	for _, sub := range subs {
+		var reqParams params.Params
+		err := json.Unmarshal([]byte(sub), &reqParams)
+		if err != nil {
+			s.dropSubscriber(subscr)
+			s.writeHTTPErrorResponse(params.NewIn(), w, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid subscription %q: %s", sub, err)))
+			return
+		}
+		_, jsonErr := s.subscribe(reqParams, subscr)
+		if jsonErr != nil {
+			s.dropSubscriber(subscr)
+			s.writeHTTPErrorResponse(params.NewIn(), w, jsonErr)
+			return
+		}
+	}
+
+	// No events can be dispatched while subsLock is taken, so the history
+	// snapshot and live events never overlap or leave a gap.
+	s.subsLock.Lock()
+	var replay []sseEvent
+	missed := lastID != 0 && lastID < s.eventSeq && (len(s.sseHistory) == 0 || s.sseHistory[0].id > lastID+1)
+	if lastID != 0 {
+		for _, ev := range s.sseHistory {
+			if ev.id > lastID {
+				replay = append(replay, ev)
+			}
+		}
+	}
+	s.subscribers[subscr] = true
+	s.subsLock.Unlock()
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	s.handleSSEWrites(w, flusher, httpRequest, subscr, subChan, missed, replay)
+}
+
+// handleSSEWrites writes events to SSE client until it disconnects or the
+// server is shut down.
+func (s *Server) handleSSEWrites(w http.ResponseWriter, flusher http.Flusher, httpRequest *http.Request,
+	subscr *subscriber, subChan <-chan intEvent, missed bool, replay []sseEvent) {
+	var err error
+	if missed {
+		var b []byte
+		b, err = json.Marshal(neorpc.Notification{
+			JSONRPC: neorpc.JSONRPCVersion,
+			Event:   neorpc.MissedEventID,
+			Payload: make([]any, 0),
+		})
+		if err == nil {
+			err = writeSSEEvent(w, 0, b)
+		}
+	}
+	for i := 0; err == nil && i < len(replay); i++ {
+		ev := replay[i]
+		if !subscr.matches(ev.ntf) {
+			continue
+		}
+		data := ev.data
+		if data == nil {
+			data, err = json.Marshal(ev.ntf)
+			if err != nil {
+				s.log.Error("failed to marshal notification", zap.Error(err), zap.Stringer("type", ev.ntf.Event))
+				break
+			}
+		}
+		err = writeSSEEvent(w, ev.id, data)
+	}
+	if err == nil {
+		flusher.Flush()
+	}
+	keepAlive := time.NewTicker(sseKeepAlivePeriod)
+eventloop:
+	for err == nil {
+		select {
+		case <-s.shutdown:
+			break eventloop
+		case <-httpRequest.Context().Done():
+			break eventloop
+		case event := <-subChan:
+			err = writeSSEEvent(w, event.id, event.data)
+		case <-keepAlive.C:
+			_, err = w.Write([]byte(":\n\n"))
+		}
+		if err == nil {
+			flusher.Flush()
+		}
+	}
+	keepAlive.Stop()
+	s.dropSubscriber(subscr)
+	// Drain notification channel as there might be some goroutines blocked
+	// on it.
+drainloop:
+	for {
+		select {
+		case <-subChan:
+		default:
+			break drainloop
+		}
+	}
+}
+
+// writeSSEEvent writes a single SSE message with the given ID (omitted if 0)
+// and JSON data.
+func writeSSEEvent(w http.ResponseWriter, id uint64, data []byte) error {
+	var msg = make([]byte, 0, len(data)+32)
+	if id != 0 {
+		msg = append(msg, "id: "...)
+		msg = strconv.AppendUint(msg, id, 10)
+		msg = append(msg, '\n')
+	}
+	msg = append(msg, "data: "...)
+	msg = append(msg, data...)
+	msg = append(msg, "\n\n"...)
+	_, err := w.Write(msg)
+	return err
+}
+
+// addSSEHistory stores the event in the SSE history evicting the oldest one
+// if needed. It must be called by handleSubEvents with subsLock read-locked.
+func (s *Server) addSSEHistory(ev sseEvent) {
+	if len(s.sseHistory) >= s.config.SSEHistorySize {
+		copy(s.sseHistory, s.sseHistory[1:])
+		s.sseHistory = s.sseHistory[:len(s.sseHistory)-1]
+	}
+	s.sseHistory = append(s.sseHistory, ev)
+}
+
+// matches checks whether the notification matches any of subscriber's feeds.
+func (sub *subscriber) matches(ntf *neorpc.Notification) bool {
+	for i := range sub.feeds {
+		if rpcevent.Matches(sub.feeds[i], ntf) {
+			return true
+		}
+	}
+	return false
+}
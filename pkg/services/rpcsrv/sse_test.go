@@ -0,0 +1,187 @@
+package rpcsrv
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/stretchr/testify/require"
+)
+
+type sseMessage struct {
+	id  uint64
+	ntf *neorpc.Notification
+}
+
+// sseConnect opens SSE stream with the given subscriptions and returns a
+// channel of received messages and a function closing the stream.
+func sseConnect(t *testing.T, baseURL string, lastID uint64, subs ...string) (<-chan sseMessage, func()) {
+	q := make(url.Values)
+	for _, s := range subs {
+		q.Add(sseSubscribeParam, s)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+ssePath+"?"+q.Encode(), nil)
+	require.NoError(t, err)
+	if lastID != 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatUint(lastID, 10))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	msgs := make(chan sseMessage, 16)
+	go func() {
+		defer close(msgs)
+		var (
+			sc  = bufio.NewScanner(resp.Body)
+			msg sseMessage
+		)
+		sc.Buffer(nil, 1024*1024)
+		for sc.Scan() {
+			line := sc.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				id, err := strconv.ParseUint(strings.TrimPrefix(line, "id: "), 10, 64)
+				if err != nil {
+					return
+				}
+				msg.id = id
+			case strings.HasPrefix(line, "data: "):
+				msg.ntf = new(neorpc.Notification)
+				if json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), msg.ntf) != nil {
+					return
+				}
+			case line == "" && msg.ntf != nil:
+				msgs <- msg
+				msg = sseMessage{}
+			}
+		}
+	}()
+	return msgs, func() {
+		cancel()
+		resp.Body.Close()
+	}
+}
+
+func getSSEMessage(t *testing.T, msgs <-chan sseMessage) sseMessage {
+	select {
+	case msg, ok := <-msgs:
+		require.True(t, ok, "stream is closed")
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+	return sseMessage{}
+}
+
+func TestSSESubscriptions(t *testing.T) {
+	chain, _, httpSrv := initClearServerWithInMemoryChain(t)
+
+	msgs, closeSSE := sseConnect(t, httpSrv.URL, 0, `["block_added", {"primary":3}]`)
+	defer closeSSE()
+	// Subscription is set up before the response is sent.
+	var expected []uint32
+	for i := range 8 {
+		primary := uint32(i % 4)
+		b := testchain.NewBlock(t, chain, 1, primary)
+		require.NoError(t, chain.AddBlock(b))
+		if primary == 3 {
+			expected = append(expected, b.Index)
+		}
+	}
+	var lastID uint64
+	for _, idx := range expected {
+		msg := getSSEMessage(t, msgs)
+		require.Equal(t, neorpc.BlockEventID, msg.ntf.Event)
+		require.Greater(t, msg.id, lastID)
+		lastID = msg.id
+		rmap := msg.ntf.Payload[0].(map[string]any)
+		require.Equal(t, idx, uint32(rmap["index"].(float64)))
+	}
+}
+
+func TestSSEResume(t *testing.T) {
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ApplicationConfiguration.RPC.SSEHistorySize = 2
+	})
+
+	// Keeps the server subscribed to blocks while the first client is away.
+	keeper, closeKeeper := sseConnect(t, httpSrv.URL, 0, `["block_added"]`)
+	defer closeKeeper()
+	msgs, closeSSE := sseConnect(t, httpSrv.URL, 0, `["block_added"]`)
+
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+	msg := getSSEMessage(t, msgs)
+	lastID := msg.id
+	require.Equal(t, lastID, getSSEMessage(t, keeper).id)
+	closeSSE()
+
+	var expected []uint64
+	for range 2 {
+		require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+		expected = append(expected, getSSEMessage(t, keeper).id)
+	}
+
+	msgs, closeSSE = sseConnect(t, httpSrv.URL, lastID, `["block_added"]`)
+	defer closeSSE()
+	for _, id := range expected {
+		msg = getSSEMessage(t, msgs)
+		require.Equal(t, id, msg.id)
+		require.Equal(t, neorpc.BlockEventID, msg.ntf.Event)
+	}
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+	msg = getSSEMessage(t, msgs)
+	require.Equal(t, getSSEMessage(t, keeper).id, msg.id)
+	require.Greater(t, msg.id, expected[len(expected)-1])
+
+	t.Run("missed", func(t *testing.T) {
+		// The first event is no longer in the history.
+		stale, closeStale := sseConnect(t, httpSrv.URL, 1, `["block_added"]`)
+		defer closeStale()
+		msg := getSSEMessage(t, stale)
+		require.Equal(t, neorpc.MissedEventID, msg.ntf.Event)
+		require.Equal(t, uint64(0), msg.id)
+		require.Equal(t, expected[1], getSSEMessage(t, stale).id)
+	})
+}
+
+func TestSSEBadRequests(t *testing.T) {
+	_, _, httpSrv := initClearServerWithInMemoryChain(t)
+
+	for name, query := range map[string]string{
+		"no subscriptions": "",
+		"bad JSON":         "?subscribe=" + url.QueryEscape(`["block_added"`),
+		"bad event":        "?subscribe=" + url.QueryEscape(`["unknown"]`),
+		"bad filter":       "?subscribe=" + url.QueryEscape(`["block_added", {"unknown":1}]`),
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp, err := http.Get(httpSrv.URL + ssePath + query)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.NotEqual(t, http.StatusOK, resp.StatusCode)
+			var r neorpc.Response
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&r))
+			require.NotNil(t, r.Error)
+		})
+	}
+	t.Run("bad Last-Event-ID", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, httpSrv.URL+ssePath+"?subscribe="+url.QueryEscape(`["block_added"]`), nil)
+		require.NoError(t, err)
+		req.Header.Set("Last-Event-ID", "abc")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.NotEqual(t, http.StatusOK, resp.StatusCode)
+	})
+}
@@ -10,10 +10,14 @@ import (
 type (
 	// intEvent is an internal event that has both a proper structure and
 	// a websocket-ready message. It's used to serve websocket-based clients
-	// as well as internal ones.
+	// as well as internal and SSE ones.
 	intEvent struct {
-		msg *websocket.PreparedMessage
-		ntf *neorpc.Notification
+		msg  *websocket.PreparedMessage
+		ntf  *neorpc.Notification
+		data []byte
+		// id is a sequential event number used by SSE clients, 0 for
+		// events that are not stored in the history.
+		id uint64
 	}
 	// subscriber is an event subscriber.
 	subscriber struct {
@@ -3,7 +3,6 @@ package stateroot
 import (
 	"errors"
 	"fmt"
-	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -17,6 +16,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/keystore"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"go.uber.org/zap"
 )
@@ -62,7 +62,7 @@ type (
 		accMtx    sync.RWMutex
 		accHeight uint32
 		myIndex   byte
-		wallet    *wallet.Wallet
+		keystore  *wallet.Keystore
 		acc       *wallet.Account
 
 		srMtx           sync.Mutex
@@ -108,18 +108,10 @@ func New(cfg config.StateRoot, sm *stateroot.Module, log *zap.Logger, bc Ledger,
 			return nil, errors.New("`StateRootInHeader` should be disabled when state service is enabled")
 		}
 		var err error
-		w := cfg.UnlockWallet
-		if s.wallet, err = wallet.NewWalletFromFile(w.Path); err != nil {
+		if s.keystore, err = keystore.Open(cfg.UnlockWallet); err != nil {
 			return nil, err
 		}
 
-		var haveAccount = slices.ContainsFunc(s.wallet.Accounts, func(acc *wallet.Account) bool {
-			return acc.Decrypt(w.Password, s.wallet.Scrypt) == nil
-		})
-		if !haveAccount {
-			return nil, errors.New("no wallet account could be unlocked")
-		}
-
 		keys, h, err := bc.GetDesignatedByRole(noderoles.StateValidator)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get designated StateValidators: %w", err)
@@ -172,14 +164,12 @@ func (s *service) updateValidators(height uint32, pubs keys.PublicKeys) {
 
 	s.acc = nil
 	for i := range pubs {
-		if acc := s.wallet.GetAccount(pubs[i].GetScriptHash()); acc != nil {
-			err := acc.Decrypt(s.MainCfg.UnlockWallet.Password, s.wallet.Scrypt)
-			if err == nil {
-				s.acc = acc
-				s.accHeight = height
-				s.myIndex = byte(i)
-				break
-			}
+		acc, err := s.keystore.GetAccount(pubs[i].GetScriptHash())
+		if err == nil && acc != nil {
+			s.acc = acc
+			s.accHeight = height
+			s.myIndex = byte(i)
+			break
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package stateroot
 
 import (
+	"errors"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -29,11 +30,11 @@ func (s *service) Start() {
 		return
 	}
 	s.log.Info("starting state validation service")
+	s.chain.SubscribeForBlocks(s.blockCh)
 	go s.run()
 }
 
 func (s *service) run() {
-	s.chain.SubscribeForBlocks(s.blockCh)
 runloop:
 	for {
 		select {
@@ -64,6 +65,14 @@ drainloop:
 	close(s.done)
 }
 
+// Health returns an error if the service is not running.
+func (s *service) Health() error {
+	if !s.started.Load() {
+		return errors.New("not started")
+	}
+	return nil
+}
+
 // Shutdown stops the service. It can only be called once, subsequent calls
 // to Shutdown on the same instance are no-op. The instance that was stopped can
 // not be started again by calling Start (use a new instance if needed).
@@ -74,8 +83,8 @@ func (s *service) Shutdown() {
 	s.log.Info("stopping state validation service")
 	close(s.stopCh)
 	<-s.done
-	if s.wallet != nil {
-		s.wallet.Close()
+	if s.keystore != nil {
+		s.keystore.Close()
 	}
 	_ = s.log.Sync()
 }
@@ -0,0 +1,354 @@
+/*
+Package webhook implements a service that delivers selected chain events to
+external HTTP endpoints.
+
+Events are sent as POST requests with JSON body in the same format that is
+used for WebSocket notifications by the RPC server (see neorpc.Notification).
+If the endpoint has a secret configured, the request contains SignatureHeader
+with hex-encoded HMAC-SHA256 of the body.
+*/
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+const (
+	// SignatureHeader is an HTTP header containing HMAC-SHA256 signature
+	// of the request body.
+	SignatureHeader = "X-Neo-Signature"
+	// EventHeader is an HTTP header containing event name.
+	EventHeader = "X-Neo-Event"
+
+	defaultTimeout       = 5 * time.Second
+	defaultRetryInterval = time.Second
+	defaultQueueSize     = 128
+)
+
+type (
+	// Ledger is an interface to Blockchain sufficient for Service.
+	Ledger interface {
+		SubscribeForBlocks(ch chan *block.Block)
+		SubscribeForNotifications(ch chan *state.ContainedNotificationEvent)
+		SubscribeForTransactions(ch chan *transaction.Transaction)
+		UnsubscribeFromBlocks(ch chan *block.Block)
+		UnsubscribeFromNotifications(ch chan *state.ContainedNotificationEvent)
+		UnsubscribeFromTransactions(ch chan *transaction.Transaction)
+	}
+
+	// Service is a webhook notification service.
+	Service struct {
+		cfg     config.Webhook
+		chain   Ledger
+		log     *zap.Logger
+		client  *http.Client
+		started atomic.Bool
+
+		endpoints []*endpoint
+
+		blockCh        chan *block.Block
+		notificationCh chan *state.ContainedNotificationEvent
+		transactionCh  chan *transaction.Transaction
+		stopCh         chan struct{}
+		done           chan struct{}
+		wg             sync.WaitGroup
+	}
+
+	endpoint struct {
+		url           string
+		secret        []byte
+		blocks        bool
+		contracts     []util.Uint160
+		notifications []string
+		addresses     []util.Uint160
+		queue         chan event
+	}
+
+	event struct {
+		id   neorpc.EventID
+		body []byte
+	}
+)
+
+// New creates a new webhook Service instance. It returns an error in case of
+// invalid configuration.
+func New(cfg config.Webhook, chain Ledger, log *zap.Logger) (*Service, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = defaultRetryInterval
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+	s := &Service{
+		cfg:            cfg,
+		chain:          chain,
+		log:            log.With(zap.String("service", "Webhook")),
+		client:         &http.Client{Timeout: cfg.Timeout},
+		blockCh:        make(chan *block.Block, 1),
+		notificationCh: make(chan *state.ContainedNotificationEvent, 1),
+		transactionCh:  make(chan *transaction.Transaction, 1),
+		stopCh:         make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	for _, e := range cfg.Endpoints {
+		ep := &endpoint{
+			url:           e.URL,
+			secret:        []byte(e.Secret),
+			blocks:        e.Blocks,
+			notifications: e.Notifications,
+			queue:         make(chan event, cfg.QueueSize),
+		}
+		for _, c := range e.Contracts {
+			h, _ := util.Uint160DecodeStringLE(c) // Already validated.
+			ep.contracts = append(ep.contracts, h)
+		}
+		for _, a := range e.Addresses {
+			h, _ := address.StringToUint160(a) // Already validated.
+			ep.addresses = append(ep.addresses, h)
+		}
+		s.endpoints = append(s.endpoints, ep)
+	}
+	return s, nil
+}
+
+// Name returns service name.
+func (s *Service) Name() string {
+	return "webhook"
+}
+
+// Start runs the service in a separate goroutine. The service only starts
+// once, subsequent calls to Start are no-op.
+func (s *Service) Start() {
+	if !s.cfg.Enabled || !s.started.CompareAndSwap(false, true) {
+		return
+	}
+	s.log.Info("starting webhook service", zap.Int("endpoints", len(s.endpoints)))
+	for _, ep := range s.endpoints {
+		s.wg.Add(1)
+		go s.deliveryLoop(ep)
+	}
+	go s.eventLoop()
+}
+
+// Health returns an error if the service is not running.
+func (s *Service) Health() error {
+	if !s.started.Load() {
+		return errors.New("not started")
+	}
+	return nil
+}
+
+// Shutdown stops the service. It can only be called once, subsequent calls
+// to Shutdown on the same instance are no-op. The instance that was stopped
+// can not be started again by calling Start (use a new instance if needed).
+// Events that were not delivered yet are dropped.
+func (s *Service) Shutdown() {
+	if !s.started.CompareAndSwap(true, false) {
+		return
+	}
+	s.log.Info("stopping webhook service")
+	close(s.stopCh)
+	<-s.done
+	s.wg.Wait()
+	_ = s.log.Sync()
+}
+
+func (s *Service) eventLoop() {
+	var wantBlocks, wantNotifications, wantTransactions bool
+	for _, ep := range s.endpoints {
+		wantBlocks = wantBlocks || ep.blocks
+		wantNotifications = wantNotifications || len(ep.contracts) != 0
+		wantTransactions = wantTransactions || len(ep.addresses) != 0
+	}
+	if wantBlocks {
+		s.chain.SubscribeForBlocks(s.blockCh)
+	}
+	if wantNotifications {
+		s.chain.SubscribeForNotifications(s.notificationCh)
+	}
+	if wantTransactions {
+		s.chain.SubscribeForTransactions(s.transactionCh)
+	}
+mainloop:
+	for {
+		select {
+		case <-s.stopCh:
+			break mainloop
+		case b := <-s.blockCh:
+			s.dispatch(neorpc.BlockEventID, b, func(ep *endpoint) bool {
+				return ep.blocks
+			})
+		case ntf := <-s.notificationCh:
+			s.dispatch(neorpc.NotificationEventID, ntf, func(ep *endpoint) bool {
+				return ep.matchesNotification(ntf)
+			})
+		case tx := <-s.transactionCh:
+			s.dispatch(neorpc.TransactionEventID, tx, func(ep *endpoint) bool {
+				return ep.matchesTransaction(tx)
+			})
+		}
+	}
+	s.chain.UnsubscribeFromBlocks(s.blockCh)
+	s.chain.UnsubscribeFromNotifications(s.notificationCh)
+	s.chain.UnsubscribeFromTransactions(s.transactionCh)
+drainloop:
+	for {
+		select {
+		case <-s.blockCh:
+		case <-s.notificationCh:
+		case <-s.transactionCh:
+		default:
+			break drainloop
+		}
+	}
+	close(s.blockCh)
+	close(s.notificationCh)
+	close(s.transactionCh)
+	close(s.done)
+}
+
+// dispatch marshals the event once and puts it into the queue of every
+// matching endpoint.
+func (s *Service) dispatch(id neorpc.EventID, data any, match func(*endpoint) bool) {
+	var body []byte
+	for _, ep := range s.endpoints {
+		if !match(ep) {
+			continue
+		}
+		if body == nil {
+			var err error
+			body, err = json.Marshal(neorpc.Notification{
+				JSONRPC: neorpc.JSONRPCVersion,
+				Event:   id,
+				Payload: []any{data},
+			})
+			if err != nil {
+				s.log.Error("failed to marshal event", zap.Stringer("type", id), zap.Error(err))
+				return
+			}
+		}
+		select {
+		case ep.queue <- event{id: id, body: body}:
+		default:
+			s.log.Warn("webhook queue is full, event dropped",
+				zap.String("url", ep.url), zap.Stringer("type", id))
+		}
+	}
+}
+
+func (s *Service) deliveryLoop(ep *endpoint) {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case e := <-ep.queue:
+			s.deliver(ep, e)
+		}
+	}
+}
+
+// deliver sends the body to the endpoint retrying with exponential backoff
+// in case of failure.
+func (s *Service) deliver(ep *endpoint, e event) {
+	var (
+		err      error
+		interval = s.cfg.RetryInterval
+	)
+	for i := 0; i <= s.cfg.MaxRetries; i++ {
+		if i != 0 {
+			select {
+			case <-s.stopCh:
+				return
+			case <-time.After(interval):
+			}
+			interval *= 2
+		}
+		err = s.post(ep, e)
+		if err == nil {
+			return
+		}
+		s.log.Debug("webhook delivery attempt failed",
+			zap.String("url", ep.url), zap.Int("attempt", i+1), zap.Error(err))
+	}
+	s.log.Warn("failed to deliver webhook event",
+		zap.String("url", ep.url), zap.Stringer("type", e.id), zap.Error(err))
+}
+
+func (s *Service) post(ep *endpoint, e event) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.url, bytes.NewReader(e.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, e.id.String())
+	if len(ep.secret) != 0 {
+		req.Header.Set(SignatureHeader, Sign(ep.secret, e.body))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (ep *endpoint) matchesNotification(ntf *state.ContainedNotificationEvent) bool {
+	if !slices.Contains(ep.contracts, ntf.ScriptHash) {
+		return false
+	}
+	return len(ep.notifications) == 0 || slices.Contains(ep.notifications, ntf.Name)
+}
+
+func (ep *endpoint) matchesTransaction(tx *transaction.Transaction) bool {
+	return slices.ContainsFunc(tx.Signers, func(s transaction.Signer) bool {
+		return slices.Contains(ep.addresses, s.Account)
+	})
+}
+
+// Sign returns hex-encoded HMAC-SHA256 signature of the data made with the
+// given secret. It can be used by webhook receivers to check SignatureHeader.
+func Sign(secret []byte, data []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,157 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type fakeLedger struct {
+	mtx            sync.Mutex
+	blockCh        chan *block.Block
+	notificationCh chan *state.ContainedNotificationEvent
+	transactionCh  chan *transaction.Transaction
+}
+
+func (l *fakeLedger) SubscribeForBlocks(ch chan *block.Block) {
+	l.mtx.Lock()
+	l.blockCh = ch
+	l.mtx.Unlock()
+}
+func (l *fakeLedger) SubscribeForNotifications(ch chan *state.ContainedNotificationEvent) {
+	l.mtx.Lock()
+	l.notificationCh = ch
+	l.mtx.Unlock()
+}
+func (l *fakeLedger) SubscribeForTransactions(ch chan *transaction.Transaction) {
+	l.mtx.Lock()
+	l.transactionCh = ch
+	l.mtx.Unlock()
+}
+func (l *fakeLedger) UnsubscribeFromBlocks(chan *block.Block)                             {}
+func (l *fakeLedger) UnsubscribeFromNotifications(chan *state.ContainedNotificationEvent) {}
+func (l *fakeLedger) UnsubscribeFromTransactions(chan *transaction.Transaction)           {}
+
+func (l *fakeLedger) subscribed() bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.blockCh != nil && l.notificationCh != nil && l.transactionCh != nil
+}
+
+type received struct {
+	event     string
+	signature string
+	body      []byte
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.NoError(t, (&config.Webhook{}).Validate())
+	require.Error(t, (&config.Webhook{Enabled: true}).Validate())
+	require.Error(t, (&config.Webhook{Enabled: true, Endpoints: []config.WebhookEndpoint{{URL: "ftp://localhost", Blocks: true}}}).Validate())
+	require.Error(t, (&config.Webhook{Enabled: true, Endpoints: []config.WebhookEndpoint{{URL: "http://localhost"}}}).Validate())
+	require.Error(t, (&config.Webhook{Enabled: true, Endpoints: []config.WebhookEndpoint{{URL: "http://localhost", Contracts: []string{"bad"}}}}).Validate())
+	require.Error(t, (&config.Webhook{Enabled: true, Endpoints: []config.WebhookEndpoint{{URL: "http://localhost", Addresses: []string{"bad"}}}}).Validate())
+	require.Error(t, (&config.Webhook{Enabled: true, Endpoints: []config.WebhookEndpoint{{URL: "http://localhost", Blocks: true, Notifications: []string{"Transfer"}}}}).Validate())
+	require.NoError(t, (&config.Webhook{Enabled: true, Endpoints: []config.WebhookEndpoint{{URL: "http://localhost", Blocks: true}}}).Validate())
+}
+
+func TestService(t *testing.T) {
+	var (
+		mtx      sync.Mutex
+		got      []received
+		failures atomic.Int32
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first request to check retries.
+		if failures.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		mtx.Lock()
+		got = append(got, received{
+			event:     r.Header.Get(EventHeader),
+			signature: r.Header.Get(SignatureHeader),
+			body:      b,
+		})
+		mtx.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+
+	var (
+		contract = util.Uint160{1, 2, 3}
+		acc      = util.Uint160{4, 5, 6}
+		secret   = "secret"
+		chain    = new(fakeLedger)
+	)
+	s, err := New(config.Webhook{
+		Enabled:       true,
+		MaxRetries:    2,
+		RetryInterval: time.Millisecond,
+		Endpoints: []config.WebhookEndpoint{{
+			URL:           srv.URL,
+			Secret:        secret,
+			Blocks:        true,
+			Contracts:     []string{contract.StringLE()},
+			Notifications: []string{"Transfer"},
+			Addresses:     []string{address.Uint160ToString(acc)},
+		}},
+	}, chain, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	require.Equal(t, "webhook", s.Name())
+
+	s.Start()
+	t.Cleanup(s.Shutdown)
+	require.Eventually(t, chain.subscribed, time.Second, 10*time.Millisecond)
+
+	chain.blockCh <- &block.Block{Header: block.Header{Index: 5}}
+	chain.notificationCh <- &state.ContainedNotificationEvent{
+		NotificationEvent: state.NotificationEvent{ScriptHash: contract, Name: "Approval", Item: stackitem.NewArray(nil)},
+	}
+	chain.notificationCh <- &state.ContainedNotificationEvent{
+		NotificationEvent: state.NotificationEvent{ScriptHash: util.Uint160{7}, Name: "Transfer", Item: stackitem.NewArray(nil)},
+	}
+	chain.notificationCh <- &state.ContainedNotificationEvent{
+		NotificationEvent: state.NotificationEvent{ScriptHash: contract, Name: "Transfer", Item: stackitem.NewArray(nil)},
+	}
+	chain.transactionCh <- &transaction.Transaction{Script: []byte{1}, Signers: []transaction.Signer{{Account: util.Uint160{8}}}}
+	chain.transactionCh <- &transaction.Transaction{Script: []byte{1}, Signers: []transaction.Signer{{Account: acc}}}
+
+	require.Eventually(t, func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(got) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	expected := []neorpc.EventID{neorpc.BlockEventID, neorpc.NotificationEventID, neorpc.TransactionEventID}
+	for i, r := range got {
+		require.Equal(t, expected[i].String(), r.event)
+		require.Equal(t, Sign([]byte(secret), r.body), r.signature)
+		var n struct {
+			Event   neorpc.EventID    `json:"method"`
+			Payload []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.Unmarshal(r.body, &n))
+		require.Equal(t, expected[i], n.Event)
+		require.Len(t, n.Payload, 1)
+	}
+}
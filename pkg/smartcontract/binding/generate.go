@@ -193,8 +193,18 @@ func scTypeToGo(name string, typ smartcontract.ParamType, cfg *Config) (string,
 	if over, ok := cfg.Overrides[name]; ok {
 		return over.TypeName, over.Package
 	}
+	et, ok := cfg.Types[name]
+	if !ok {
+		et = ExtendedType{Base: typ}
+	}
+	return extendedTypeToGo(et)
+}
 
-	switch typ {
+// extendedTypeToGo converts extended type data into contract-side Go type and
+// the package it needs. Structures are not defined by contract wrappers, so
+// named types are represented by generic arrays unless they're overridden.
+func extendedTypeToGo(et ExtendedType) (string, string) {
+	switch et.Base {
 	case smartcontract.AnyType:
 		return "any", ""
 	case smartcontract.BoolType:
@@ -214,10 +224,30 @@ func scTypeToGo(name string, typ smartcontract.ParamType, cfg *Config) (string,
 	case smartcontract.SignatureType:
 		return "interop.Signature", "github.com/nspcc-dev/neo-go/pkg/interop"
 	case smartcontract.ArrayType:
+		if len(et.Name) == 0 && et.Value != nil {
+			sub, pkg := extendedTypeToGo(*et.Value)
+			return "[]" + sub, pkg
+		}
 		return "[]any", ""
 	case smartcontract.MapType:
-		return "map[string]any", ""
+		// Byte-based interop types are not comparable in Go, but they're
+		// all byte strings for the VM, so string is used for them.
+		var kt = "string"
+		switch et.Key {
+		case smartcontract.BoolType:
+			kt = "bool"
+		case smartcontract.IntegerType:
+			kt = "int"
+		}
+		if et.Value != nil {
+			vt, pkg := extendedTypeToGo(*et.Value)
+			return "map[" + kt + "]" + vt, pkg
+		}
+		return "map[" + kt + "]any", ""
 	case smartcontract.InteropInterfaceType:
+		if et.Interface == "iterator" {
+			return "iterator.Iterator", "github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+		}
 		return "any", ""
 	case smartcontract.VoidType:
 		return "", ""
@@ -2,6 +2,7 @@ package context
 
 import (
 	"bytes"
+	"crypto/elliptic"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -118,30 +119,7 @@ func (c *ParameterContext) AddSignature(h util.Uint160, ctr *wallet.Contract, pu
 			return errors.New("public key is not present in script")
 		}
 		item.AddSignature(pub, sig)
-		if len(item.Signatures) >= len(ctr.Parameters) {
-			indexMap := map[string]int{}
-			for i := range pubs {
-				indexMap[hex.EncodeToString(pubs[i])] = i
-			}
-			sigs := make([]sigWithIndex, len(item.Parameters))
-			var i int
-			for pub, sig := range item.Signatures {
-				sigs[i] = sigWithIndex{index: indexMap[pub], sig: sig}
-				i++
-				if i == len(sigs) {
-					break
-				}
-			}
-			slices.SortFunc(sigs, func(a, b sigWithIndex) int {
-				return a.index - b.index
-			})
-			for i := range sigs {
-				item.Parameters[i] = smartcontract.Parameter{
-					Type:  smartcontract.SignatureType,
-					Value: sigs[i].sig,
-				}
-			}
-		}
+		fillMultisigParameters(item, pubs)
 		return nil
 	}
 
@@ -162,6 +140,110 @@ func (c *ParameterContext) AddSignature(h util.Uint160, ctr *wallet.Contract, pu
 	return nil
 }
 
+// IsExpired returns true if the context contains a transaction that can't be
+// accepted by the network with the given (current) chain height because its
+// ValidUntilBlock has already passed. Such a transaction can be refreshed with
+// Refresh.
+func (c *ParameterContext) IsExpired(height uint32) bool {
+	tx, ok := c.Verifiable.(*transaction.Transaction)
+	return ok && tx.ValidUntilBlock <= height
+}
+
+// Refresh replaces the transaction in the context with its copy having the
+// given ValidUntilBlock, system and network fees (witnesses are dropped). If
+// this changes the transaction hash, signatures that are not valid for the new
+// transaction are removed from the context along with signature parameters
+// that can't be checked or are not valid anymore, so they need to be added
+// again. The number of signatures removed is returned.
+func (c *ParameterContext) Refresh(vub uint32, sysFee, netFee int64) (int, error) {
+	tx, ok := c.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return 0, errors.New("verifiable item is not a transaction")
+	}
+	newTx := tx.Copy()
+	newTx.ValidUntilBlock = vub
+	newTx.SystemFee = sysFee
+	newTx.NetworkFee = netFee
+	newTx.Scripts = nil
+	c.Verifiable = newTx
+	if newTx.Hash().Equals(tx.Hash()) {
+		return 0, nil
+	}
+	var removed int
+	for _, item := range c.Items {
+		removed += c.dropInvalidSignatures(item, newTx)
+	}
+	return removed, nil
+}
+
+// dropInvalidSignatures removes signatures and signature parameters not valid
+// for the given transaction from the item, it returns the number of removed
+// signatures.
+func (c *ParameterContext) dropInvalidSignatures(item *Item, tx *transaction.Transaction) int {
+	var removed int
+	for pubStr, sig := range item.Signatures {
+		pub, err := keys.NewPublicKeyFromString(pubStr)
+		if err != nil || !pub.VerifyHashable(sig, uint32(c.Network), tx) {
+			delete(item.Signatures, pubStr)
+			removed++
+		}
+	}
+	if _, pubs, ok := vm.ParseMultiSigContract(item.Script); ok {
+		for i := range item.Parameters {
+			item.Parameters[i].Value = nil
+		}
+		fillMultisigParameters(item, pubs)
+		return removed
+	}
+	var pub *keys.PublicKey
+	if pubBytes, ok := vm.ParseSignatureContract(item.Script); ok {
+		pub, _ = keys.NewPublicKeyFromBytes(pubBytes, elliptic.P256())
+	}
+	for i := range item.Parameters {
+		p := &item.Parameters[i]
+		if p.Type != smartcontract.SignatureType || p.Value == nil {
+			continue
+		}
+		sig, ok := p.Value.([]byte)
+		if !ok || pub == nil || !pub.VerifyHashable(sig, uint32(c.Network), tx) {
+			p.Value = nil
+			removed++
+		}
+	}
+	return removed
+}
+
+// fillMultisigParameters fills item parameters with signatures ordered
+// according to the given multisignature contract keys if there are enough
+// signatures collected.
+func fillMultisigParameters(item *Item, pubs [][]byte) {
+	if len(item.Signatures) < len(item.Parameters) {
+		return
+	}
+	indexMap := map[string]int{}
+	for i := range pubs {
+		indexMap[hex.EncodeToString(pubs[i])] = i
+	}
+	sigs := make([]sigWithIndex, len(item.Parameters))
+	var i int
+	for pub, sig := range item.Signatures {
+		sigs[i] = sigWithIndex{index: indexMap[pub], sig: sig}
+		i++
+		if i == len(sigs) {
+			break
+		}
+	}
+	slices.SortFunc(sigs, func(a, b sigWithIndex) int {
+		return a.index - b.index
+	})
+	for i := range sigs {
+		item.Parameters[i] = smartcontract.Parameter{
+			Type:  smartcontract.SignatureType,
+			Value: sigs[i].sig,
+		}
+	}
+}
+
 func (c *ParameterContext) getItemForContract(h util.Uint160, ctr *wallet.Contract) *Item {
 	item, ok := c.Items[ctr.ScriptHash()]
 	if ok {
@@ -184,6 +266,49 @@ func (c *ParameterContext) getItemForContract(h util.Uint160, ctr *wallet.Contra
 	return item
 }
 
+// Merge adds signatures and parameters from src to the context, both contexts
+// must be made for the same verifiable item.
+func (c *ParameterContext) Merge(src *ParameterContext) error {
+	if c.Network != src.Network || c.Type != src.Type ||
+		!c.Verifiable.Hash().Equals(src.Verifiable.Hash()) {
+		return errors.New("contexts are made for different items")
+	}
+	for h, item := range src.Items {
+		dItem, ok := c.Items[h]
+		if !ok {
+			c.Items[h] = item
+			continue
+		}
+		_, pubs, isMultisig := vm.ParseMultiSigContract(dItem.Script)
+		for pubStr, sig := range item.Signatures {
+			pub, err := keys.NewPublicKeyFromString(pubStr)
+			if err != nil {
+				return fmt.Errorf("invalid public key %s: %w", pubStr, err)
+			}
+			pubBytes := pub.Bytes()
+			if isMultisig && !slices.ContainsFunc(pubs, func(p []byte) bool { return bytes.Equal(pubBytes, p) }) {
+				return fmt.Errorf("public key %s is not present in script", pubStr)
+			}
+			if dItem.GetSignature(pub) == nil {
+				dItem.AddSignature(pub, sig)
+			}
+		}
+		if isMultisig {
+			// Parameters are filled once there are enough signatures.
+			fillMultisigParameters(dItem, pubs)
+		}
+		if len(dItem.Parameters) != len(item.Parameters) {
+			return fmt.Errorf("parameters mismatch for %s", h.StringLE())
+		}
+		for i := range item.Parameters {
+			if dItem.Parameters[i].Value == nil {
+				dItem.Parameters[i] = item.Parameters[i]
+			}
+		}
+	}
+	return nil
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (c ParameterContext) MarshalJSON() ([]byte, error) {
 	verif, err := c.Verifiable.EncodeHashableFields()
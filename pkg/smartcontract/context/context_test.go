@@ -157,6 +157,152 @@ func TestParameterContext_AddSignatureMultisig(t *testing.T) {
 	})
 }
 
+func TestParameterContext_Refresh(t *testing.T) {
+	t.Run("not a transaction", func(t *testing.T) {
+		c := NewParameterContext("Neo.Network.P2P.Payloads.Block", netmode.UnitTestNet, verifStub{})
+		require.False(t, c.IsExpired(100500))
+		_, err := c.Refresh(1, 2, 3)
+		require.Error(t, err)
+	})
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+	tx := getContractTx(pub.GetScriptHash()).Copy() // Reset cached hash.
+	tx.ValidUntilBlock = 10
+	c := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+	ctr := &wallet.Contract{
+		Script:     pub.GetVerificationScript(),
+		Parameters: []wallet.ContractParam{newParam(smartcontract.SignatureType, "parameter0")},
+	}
+	require.NoError(t, c.AddSignature(ctr.ScriptHash(), ctr, pub, priv.SignHashable(uint32(c.Network), tx)))
+
+	require.False(t, c.IsExpired(9))
+	require.True(t, c.IsExpired(10))
+
+	t.Run("unchanged", func(t *testing.T) {
+		n, err := c.Refresh(tx.ValidUntilBlock, tx.SystemFee, tx.NetworkFee)
+		require.NoError(t, err)
+		require.Equal(t, 0, n)
+		require.Equal(t, tx.Hash(), c.Verifiable.Hash())
+		_, err = c.GetWitness(ctr.ScriptHash())
+		require.NoError(t, err)
+	})
+
+	n, err := c.Refresh(20, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.False(t, c.IsExpired(10))
+	require.Equal(t, uint32(10), tx.ValidUntilBlock) // The original one is not changed.
+	newTx := c.Verifiable.(*transaction.Transaction)
+	require.Equal(t, uint32(20), newTx.ValidUntilBlock)
+	require.Equal(t, int64(1), newTx.SystemFee)
+	require.Equal(t, int64(2), newTx.NetworkFee)
+	_, err = c.GetWitness(ctr.ScriptHash())
+	require.Error(t, err)
+
+	require.NoError(t, c.AddSignature(ctr.ScriptHash(), ctr, pub, priv.SignHashable(uint32(c.Network), newTx)))
+	w, err := c.GetWitness(ctr.ScriptHash())
+	require.NoError(t, err)
+	v := newTestVM(w, newTx)
+	require.NoError(t, v.Run())
+	require.Equal(t, true, v.Estack().Pop().Value())
+
+	t.Run("multisig", func(t *testing.T) {
+		privs, pubs := getPrivateKeys(t, 3)
+		script, err := smartcontract.CreateMultiSigRedeemScript(2, keys.PublicKeys(pubs).Copy())
+		require.NoError(t, err)
+		ctr := &wallet.Contract{
+			Script: script,
+			Parameters: []wallet.ContractParam{
+				newParam(smartcontract.SignatureType, "parameter0"),
+				newParam(smartcontract.SignatureType, "parameter1"),
+			},
+		}
+		tx := getContractTx(ctr.ScriptHash())
+		c := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+		for i := range 2 {
+			require.NoError(t, c.AddSignature(ctr.ScriptHash(), ctr, pubs[i], privs[i].SignHashable(uint32(c.Network), tx)))
+		}
+		_, err = c.GetWitness(ctr.ScriptHash())
+		require.NoError(t, err)
+
+		// Signature made for the refreshed transaction in advance is kept.
+		newTx := tx.Copy()
+		newTx.ValidUntilBlock = 42
+		newTx.Scripts = nil
+		item := c.Items[ctr.ScriptHash()]
+		item.AddSignature(pubs[2], privs[2].SignHashable(uint32(c.Network), newTx))
+
+		n, err := c.Refresh(42, tx.SystemFee, tx.NetworkFee)
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Equal(t, newTx.Hash(), c.Verifiable.Hash())
+		require.Len(t, item.Signatures, 1)
+		require.NotNil(t, item.GetSignature(pubs[2]))
+		_, err = c.GetWitness(ctr.ScriptHash())
+		require.Error(t, err)
+
+		require.NoError(t, c.AddSignature(ctr.ScriptHash(), ctr, pubs[0], privs[0].SignHashable(uint32(c.Network), newTx)))
+		w, err := c.GetWitness(ctr.ScriptHash())
+		require.NoError(t, err)
+		v := newTestVM(w, newTx)
+		require.NoError(t, v.Run())
+		require.Equal(t, true, v.Estack().Pop().Value())
+	})
+}
+
+func TestParameterContext_Merge(t *testing.T) {
+	privs, pubs := getPrivateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, keys.PublicKeys(pubs).Copy())
+	require.NoError(t, err)
+	ctr := &wallet.Contract{
+		Script: script,
+		Parameters: []wallet.ContractParam{
+			newParam(smartcontract.SignatureType, "parameter0"),
+			newParam(smartcontract.SignatureType, "parameter1"),
+		},
+	}
+	tx := getContractTx(ctr.ScriptHash())
+	newCtx := func(signers ...int) *ParameterContext {
+		c := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+		for _, i := range signers {
+			require.NoError(t, c.AddSignature(ctr.ScriptHash(), ctr, pubs[i], privs[i].SignHashable(uint32(c.Network), tx)))
+		}
+		return c
+	}
+
+	t.Run("different items", func(t *testing.T) {
+		other := NewParameterContext(TransactionType, netmode.UnitTestNet, getContractTx(util.Uint160{1, 2, 3}))
+		require.Error(t, newCtx(0).Merge(other))
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		src := newCtx(0)
+		src.Items[ctr.ScriptHash()].AddSignature(priv.PublicKey(), priv.SignHashable(uint32(src.Network), tx))
+		require.Error(t, newCtx(1).Merge(src))
+	})
+
+	dst := newCtx(0)
+	require.NoError(t, dst.Merge(NewParameterContext(TransactionType, netmode.UnitTestNet, tx)))
+	_, err = dst.GetWitness(ctr.ScriptHash())
+	require.Error(t, err)
+
+	require.NoError(t, dst.Merge(newCtx(2)))
+	w, err := dst.GetWitness(ctr.ScriptHash())
+	require.NoError(t, err)
+	v := newTestVM(w, tx)
+	require.NoError(t, v.Run())
+	require.Equal(t, true, v.Estack().Pop().Value())
+
+	// Missing items are added as is.
+	dst = NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+	require.NoError(t, dst.Merge(newCtx(0, 1)))
+	_, err = dst.GetWitness(ctr.ScriptHash())
+	require.NoError(t, err)
+}
+
 func newTestVM(w *transaction.Witness, tx *transaction.Transaction) *vm.VM {
 	ic := &interop.Context{Network: uint32(netmode.UnitTestNet), Container: tx, Functions: crypto.Interops}
 	v := ic.SpawnVM()
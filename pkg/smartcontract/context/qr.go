@@ -0,0 +1,136 @@
+package context
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// qrFramePrefix is the prefix of every QR frame text.
+const qrFramePrefix = "NEOQR"
+
+// DefaultQRFrameSize is the default size of data (in base64 characters) put
+// into a single QR frame, it's small enough to be reliably scanned from the
+// terminal screen.
+const DefaultQRFrameSize = 300
+
+// ErrNotQRFrame is returned by QRDecoder for strings that are not QR frames.
+var ErrNotQRFrame = errors.New("not a QR frame")
+
+// QRFrames splits the parameter context into a set of text frames to be shown
+// as QR codes one by one (animated QR). Context JSON is compressed and
+// base64-encoded, every frame has "NEOQR:<index>/<total>:<checksum>:<data>"
+// form where index is 1-based and checksum is CRC32 of the whole compressed
+// context identifying the frame set.
+func QRFrames(c *ParameterContext, size int) ([]string, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid frame size %d", size)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal context: %w", err)
+	}
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	var (
+		sum    = crc32.ChecksumIEEE(buf.Bytes())
+		enc    = base64.StdEncoding.EncodeToString(buf.Bytes())
+		total  = (len(enc) + size - 1) / size
+		frames = make([]string, 0, total)
+	)
+	for i := range total {
+		chunk := enc[i*size : min((i+1)*size, len(enc))]
+		frames = append(frames, fmt.Sprintf("%s:%d/%d:%08x:%s", qrFramePrefix, i+1, total, sum, chunk))
+	}
+	return frames, nil
+}
+
+// QRDecoder collects scanned QR frames (in any order, duplicates are allowed)
+// and restores the parameter context from them.
+type QRDecoder struct {
+	sum    string
+	chunks []string
+	left   int
+}
+
+// Add adds the frame to the decoder, it returns true when all frames are
+// collected. ErrNotQRFrame is returned for strings not looking like frames,
+// frames of some other set are rejected.
+func (d *QRDecoder) Add(frame string) (bool, error) {
+	parts := strings.SplitN(strings.TrimSpace(frame), ":", 4)
+	if len(parts) != 4 || parts[0] != qrFramePrefix {
+		return false, ErrNotQRFrame
+	}
+	idxStr, totalStr, ok := strings.Cut(parts[1], "/")
+	if !ok {
+		return false, fmt.Errorf("invalid frame number %q", parts[1])
+	}
+	idx, err := strconv.Atoi(idxStr)
+	if err != nil {
+		return false, fmt.Errorf("invalid frame index: %w", err)
+	}
+	total, err := strconv.Atoi(totalStr)
+	if err != nil || total <= 0 {
+		return false, fmt.Errorf("invalid frame count %q", totalStr)
+	}
+	if idx < 1 || idx > total {
+		return false, fmt.Errorf("frame index %d is out of range", idx)
+	}
+	if d.chunks == nil {
+		d.sum = parts[2]
+		d.chunks = make([]string, total)
+		d.left = total
+	} else if d.sum != parts[2] || len(d.chunks) != total {
+		return false, errors.New("frame belongs to a different context")
+	}
+	if d.chunks[idx-1] == "" {
+		d.chunks[idx-1] = parts[3]
+		d.left--
+	}
+	return d.left == 0, nil
+}
+
+// Progress returns the number of frames collected and the total number of
+// frames (zero if no frames were added yet).
+func (d *QRDecoder) Progress() (int, int) {
+	return len(d.chunks) - d.left, len(d.chunks)
+}
+
+// Context returns the parameter context restored from the collected frames.
+func (d *QRDecoder) Context() (*ParameterContext, error) {
+	if d.chunks == nil || d.left != 0 {
+		return nil, errors.New("not all frames are collected")
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.Join(d.chunks, ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid frame data: %w", err)
+	}
+	if fmt.Sprintf("%08x", crc32.ChecksumIEEE(data)) != d.sum {
+		return nil, errors.New("checksum mismatch")
+	}
+	data, err = io.ReadAll(flate.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return nil, fmt.Errorf("can't decompress context: %w", err)
+	}
+	c := new(ParameterContext)
+	if err = json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("can't parse context: %w", err)
+	}
+	return c, nil
+}
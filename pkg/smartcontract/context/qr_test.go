@@ -0,0 +1,95 @@
+package context
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRFrames(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+	tx := getContractTx(pub.GetScriptHash())
+	c := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+	ctr := &wallet.Contract{
+		Script:     pub.GetVerificationScript(),
+		Parameters: []wallet.ContractParam{newParam(smartcontract.SignatureType, "parameter0")},
+	}
+	require.NoError(t, c.AddSignature(ctr.ScriptHash(), ctr, pub, priv.SignHashable(uint32(c.Network), tx)))
+
+	_, err = QRFrames(c, 0)
+	require.Error(t, err)
+
+	single, err := QRFrames(c, 1000)
+	require.NoError(t, err)
+	require.Len(t, single, 1)
+
+	frames, err := QRFrames(c, 20)
+	require.NoError(t, err)
+	require.Greater(t, len(frames), 2)
+	for i := range frames {
+		require.True(t, strings.HasPrefix(frames[i], qrFramePrefix+":"))
+	}
+
+	t.Run("any order", func(t *testing.T) {
+		var d QRDecoder
+		_, err := d.Context()
+		require.Error(t, err)
+
+		_, err = d.Add("garbage")
+		require.ErrorIs(t, err, ErrNotQRFrame)
+		for i := len(frames) - 1; i > 0; i-- {
+			done, err := d.Add(frames[i])
+			require.NoError(t, err)
+			require.False(t, done)
+			done, err = d.Add(frames[i]) // Duplicate.
+			require.NoError(t, err)
+			require.False(t, done)
+		}
+		got, total := d.Progress()
+		require.Equal(t, len(frames)-1, got)
+		require.Equal(t, len(frames), total)
+		_, err = d.Add(single[0])
+		require.Error(t, err)
+		_, err = d.Context()
+		require.Error(t, err)
+
+		done, err := d.Add(frames[0])
+		require.NoError(t, err)
+		require.True(t, done)
+		res, err := d.Context()
+		require.NoError(t, err)
+		require.Equal(t, c.Verifiable.Hash(), res.Verifiable.Hash())
+		require.Equal(t, c.Items, res.Items)
+	})
+	t.Run("invalid frames", func(t *testing.T) {
+		var d QRDecoder
+		for _, f := range []string{
+			qrFramePrefix + ":1:0:data",
+			qrFramePrefix + ":x/2:0:data",
+			qrFramePrefix + ":1/x:0:data",
+			qrFramePrefix + ":3/2:0:data",
+			qrFramePrefix + ":0/2:0:data",
+		} {
+			_, err := d.Add(f)
+			require.Error(t, err, f)
+			require.NotErrorIs(t, err, ErrNotQRFrame, f)
+		}
+	})
+	t.Run("checksum mismatch", func(t *testing.T) {
+		var d QRDecoder
+		parts := strings.SplitN(single[0], ":", 4)
+		parts[2] = "00000000"
+		done, err := d.Add(strings.Join(parts, ":"))
+		require.NoError(t, err)
+		require.True(t, done)
+		_, err = d.Context()
+		require.Error(t, err)
+	})
+}
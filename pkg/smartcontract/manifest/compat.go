@@ -0,0 +1,71 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Various breaking changes returned from CheckCompatibility.
+var (
+	ErrMethodRemoved       = errors.New("method removed")
+	ErrMethodReturnChanged = errors.New("method return type changed")
+	ErrMethodParamsChanged = errors.New("method parameter types changed")
+	ErrMethodNotSafe       = errors.New("method is no longer safe")
+	ErrEventRemoved        = errors.New("event removed")
+	ErrEventParamsChanged  = errors.New("event parameters changed")
+)
+
+// CheckCompatibility compares ABIs of the old and new versions of the contract
+// manifest and returns the list of changes that break the existing users of the
+// contract: removed methods, changed method signatures, methods that are no
+// longer safe, removed events and changed event parameters. Methods starting
+// with underscore can't be called from outside, so they're not checked. Every
+// change is returned as one of the errors defined above wrapped with details,
+// an empty list means that the new ABI is compatible with the old one.
+func CheckCompatibility(oldM, newM *Manifest) []error {
+	var res []error
+	for i := range oldM.ABI.Methods {
+		om := &oldM.ABI.Methods[i]
+		if strings.HasPrefix(om.Name, "_") {
+			continue
+		}
+		nm := newM.ABI.GetMethod(om.Name, len(om.Parameters))
+		if nm == nil {
+			res = append(res, fmt.Errorf("%w: '%s' with %d parameters", ErrMethodRemoved, om.Name, len(om.Parameters)))
+			continue
+		}
+		if om.ReturnType != nm.ReturnType {
+			res = append(res, fmt.Errorf("%w: '%s' (%s -> %s)", ErrMethodReturnChanged, om.Name, om.ReturnType, nm.ReturnType))
+		}
+		for j := range om.Parameters {
+			if om.Parameters[j].Type != nm.Parameters[j].Type {
+				res = append(res, fmt.Errorf("%w: '%s'[%d] (%s -> %s)", ErrMethodParamsChanged, om.Name, j,
+					om.Parameters[j].Type, nm.Parameters[j].Type))
+			}
+		}
+		if om.Safe && !nm.Safe {
+			res = append(res, fmt.Errorf("%w: '%s'", ErrMethodNotSafe, om.Name))
+		}
+	}
+	for i := range oldM.ABI.Events {
+		oe := &oldM.ABI.Events[i]
+		ne := newM.ABI.GetEvent(oe.Name)
+		if ne == nil {
+			res = append(res, fmt.Errorf("%w: '%s'", ErrEventRemoved, oe.Name))
+			continue
+		}
+		if len(oe.Parameters) != len(ne.Parameters) {
+			res = append(res, fmt.Errorf("%w: '%s' (expected %d parameters, got %d)", ErrEventParamsChanged,
+				oe.Name, len(oe.Parameters), len(ne.Parameters)))
+			continue
+		}
+		for j := range oe.Parameters {
+			if oe.Parameters[j].Type != ne.Parameters[j].Type {
+				res = append(res, fmt.Errorf("%w: '%s'[%d] (%s -> %s)", ErrEventParamsChanged, oe.Name, j,
+					oe.Parameters[j].Type, ne.Parameters[j].Type))
+			}
+		}
+	}
+	return res
+}
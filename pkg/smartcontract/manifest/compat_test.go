@@ -0,0 +1,82 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	newManifest := func() *Manifest {
+		m := NewManifest("Test")
+		m.ABI.Methods = []Method{
+			{Name: "_deploy", Parameters: []Parameter{
+				NewParameter("data", smartcontract.AnyType),
+				NewParameter("isUpdate", smartcontract.BoolType),
+			}, ReturnType: smartcontract.VoidType},
+			{Name: "balanceOf", Parameters: []Parameter{
+				NewParameter("account", smartcontract.Hash160Type),
+			}, ReturnType: smartcontract.IntegerType, Safe: true},
+			{Name: "transfer", Parameters: []Parameter{
+				NewParameter("from", smartcontract.Hash160Type),
+				NewParameter("to", smartcontract.Hash160Type),
+				NewParameter("amount", smartcontract.IntegerType),
+			}, ReturnType: smartcontract.BoolType},
+		}
+		m.ABI.Events = []Event{
+			{Name: "Transfer", Parameters: []Parameter{
+				NewParameter("from", smartcontract.Hash160Type),
+				NewParameter("to", smartcontract.Hash160Type),
+				NewParameter("amount", smartcontract.IntegerType),
+			}},
+		}
+		return m
+	}
+	oldM := newManifest()
+
+	t.Run("compatible", func(t *testing.T) {
+		m := newManifest()
+		require.Empty(t, CheckCompatibility(oldM, m))
+
+		// Renames, new methods/events, internal methods and safe flag
+		// addition don't break anything.
+		m.ABI.Methods = m.ABI.Methods[1:]
+		m.ABI.Methods[0].Parameters[0].Name = "acc"
+		m.ABI.Methods[1].Safe = true
+		m.ABI.Methods = append(m.ABI.Methods, Method{Name: "transfer", ReturnType: smartcontract.BoolType})
+		m.ABI.Events = append(m.ABI.Events, Event{Name: "Burn"})
+		require.Empty(t, CheckCompatibility(oldM, m))
+	})
+	t.Run("methods", func(t *testing.T) {
+		m := newManifest()
+		m.ABI.Methods[1].ReturnType = smartcontract.StringType
+		m.ABI.Methods[1].Parameters[0].Type = smartcontract.ByteArrayType
+		m.ABI.Methods[1].Safe = false
+		m.ABI.Methods[2].Parameters = m.ABI.Methods[2].Parameters[:2]
+		errs := CheckCompatibility(oldM, m)
+		require.Len(t, errs, 4)
+		require.ErrorIs(t, errs[0], ErrMethodReturnChanged)
+		require.ErrorIs(t, errs[1], ErrMethodParamsChanged)
+		require.ErrorIs(t, errs[2], ErrMethodNotSafe)
+		require.ErrorIs(t, errs[3], ErrMethodRemoved)
+		require.ErrorContains(t, errs[3], "'transfer' with 3 parameters")
+	})
+	t.Run("events", func(t *testing.T) {
+		m := newManifest()
+		m.ABI.Events[0].Parameters[2].Type = smartcontract.StringType
+		errs := CheckCompatibility(oldM, m)
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], ErrEventParamsChanged)
+
+		m.ABI.Events[0].Parameters = m.ABI.Events[0].Parameters[:2]
+		errs = CheckCompatibility(oldM, m)
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], ErrEventParamsChanged)
+
+		m.ABI.Events[0].Name = "transfer"
+		errs = CheckCompatibility(oldM, m)
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], ErrEventRemoved)
+	})
+}
@@ -22,9 +22,17 @@ const (
 	NEP11StandardName = "NEP-11"
 	// NEP17StandardName represents the name of NEP-17 smartcontract standard.
 	NEP17StandardName = "NEP-17"
-	// NEP11Payable represents the name of contract interface which can receive NEP-11 tokens.
+	// NEP26StandardName represents the name of NEP-26 smartcontract standard
+	// (onNEP11Payment callback allowing to receive NEP-11 tokens).
+	NEP26StandardName = "NEP-26"
+	// NEP27StandardName represents the name of NEP-27 smartcontract standard
+	// (onNEP17Payment callback allowing to receive NEP-17 tokens).
+	NEP27StandardName = "NEP-27"
+	// NEP11Payable represents the name of contract interface which can receive
+	// NEP-11 tokens. It's a legacy name for NEP-26 standard.
 	NEP11Payable = "NEP-11-Payable"
-	// NEP17Payable represents the name of contract interface which can receive NEP-17 tokens.
+	// NEP17Payable represents the name of contract interface which can receive
+	// NEP-17 tokens. It's a legacy name for NEP-27 standard.
 	NEP17Payable = "NEP-17-Payable"
 
 	emptyFeatures = "{}"
@@ -145,6 +153,21 @@ func (m *Manifest) IsStandardSupported(standard string) bool {
 	return slices.Contains(m.SupportedStandards, standard)
 }
 
+// IsPayable returns true if the contract declares support of NEP-26 (for
+// NEP-11 tokens) or NEP-27 (for NEP-17 tokens) standard corresponding to the
+// given payment callback (MethodOnNEP11Payment or MethodOnNEP17Payment), legacy
+// NEP11Payable and NEP17Payable names are accepted as well.
+func (m *Manifest) IsPayable(callback string) bool {
+	switch callback {
+	case MethodOnNEP11Payment:
+		return m.IsStandardSupported(NEP26StandardName) || m.IsStandardSupported(NEP11Payable)
+	case MethodOnNEP17Payment:
+		return m.IsStandardSupported(NEP27StandardName) || m.IsStandardSupported(NEP17Payable)
+	default:
+		return false
+	}
+}
+
 // ToStackItem converts Manifest to stackitem.Item.
 func (m *Manifest) ToStackItem() (stackitem.Item, error) {
 	groups := make([]stackitem.Item, len(m.Groups))
@@ -504,3 +504,18 @@ func TestManifest_IsStandardSupported(t *testing.T) {
 	require.False(t, m.IsStandardSupported(""))
 	require.False(t, m.IsStandardSupported("unknown standard"))
 }
+
+func TestManifest_IsPayable(t *testing.T) {
+	m := &Manifest{SupportedStandards: []string{NEP17StandardName}}
+	require.False(t, m.IsPayable(MethodOnNEP11Payment))
+	require.False(t, m.IsPayable(MethodOnNEP17Payment))
+
+	m.SupportedStandards = []string{NEP26StandardName, NEP17Payable}
+	require.True(t, m.IsPayable(MethodOnNEP11Payment))
+	require.True(t, m.IsPayable(MethodOnNEP17Payment))
+	require.False(t, m.IsPayable(MethodVerify))
+
+	m.SupportedStandards = []string{NEP11Payable, NEP27StandardName}
+	require.True(t, m.IsPayable(MethodOnNEP11Payment))
+	require.True(t, m.IsPayable(MethodOnNEP17Payment))
+}
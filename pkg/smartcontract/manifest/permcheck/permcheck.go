@@ -0,0 +1,260 @@
+/*
+Package permcheck contains contract permission analysis. It finds contract
+calls made by the compiled contract script and compares them with permissions
+declared in the contract manifest, reporting calls that are not allowed and
+permissions that are wider than needed, it also can generate a minimal set of
+permissions allowing all the calls found.
+*/
+package permcheck
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// Various problems returned from Check.
+var (
+	ErrCallNotAllowed   = errors.New("call is not allowed")
+	ErrUnusedPermission = errors.New("permission is not used")
+	ErrWildcardContract = errors.New("permission allows any contract")
+	ErrWildcardMethods  = errors.New("permission allows any method")
+	ErrUnusedMethods    = errors.New("permission allows unused methods")
+)
+
+// Call is a contract call found in the script.
+type Call struct {
+	// Offset is the offset of the calling instruction in the script.
+	Offset int
+	// Contract is the hash of the called contract, it's zero if the hash
+	// can't be determined statically (it's computed or passed as an argument).
+	Contract util.Uint160
+	// Method is the name of the called method, it's empty if it can't be
+	// determined statically.
+	Method string
+}
+
+var callID = interopnames.ToID([]byte(interopnames.SystemContractCall))
+
+// IsDynamic returns true if either the called contract or the method are not
+// known statically.
+func (c Call) IsDynamic() bool {
+	return c.Contract.Equals(util.Uint160{}) || c.Method == ""
+}
+
+// String implements the fmt.Stringer interface.
+func (c Call) String() string {
+	var h, m = "unknown contract", "unknown method"
+	if !c.Contract.Equals(util.Uint160{}) {
+		h = "contract " + c.Contract.StringLE()
+	}
+	if c.Method != "" {
+		m = "'" + c.Method + "'"
+	}
+	return fmt.Sprintf("%s of %s at offset %d", m, h, c.Offset)
+}
+
+// FindCalls returns all contract calls made by the given NEF file script. These
+// include CALLT instructions (which refer to method tokens) and System.Contract.Call
+// syscalls. Hash and method of the latter are taken from the PUSHDATA instructions
+// preceding the syscall for both the standard sequence (used by the emit package)
+// and the one generated by the compiler, anything else is treated as a dynamic
+// call with unknown contract and/or method.
+func FindCalls(nefFile *nef.File) ([]Call, error) {
+	var (
+		calls  []Call
+		prev   []instr // A window of the preceding instructions.
+		script = nefFile.Script
+		ctx    = vm.NewContext(script)
+	)
+	for ctx.NextIP() < len(script) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case op == opcode.CALLT:
+			id := int(binary.LittleEndian.Uint16(param))
+			if id >= len(nefFile.Tokens) {
+				return nil, fmt.Errorf("CALLT at offset %d refers to missing method token %d", ctx.IP(), id)
+			}
+			calls = append(calls, Call{
+				Offset:   ctx.IP(),
+				Contract: nefFile.Tokens[id].Hash,
+				Method:   nefFile.Tokens[id].Method,
+			})
+		case op == opcode.SYSCALL && binary.LittleEndian.Uint32(param) == callID:
+			calls = append(calls, syscallTarget(ctx.IP(), prev))
+		}
+		prev = append(prev, instr{op: op, param: param})
+		if len(prev) > 5 {
+			prev = prev[1:]
+		}
+	}
+	return calls, nil
+}
+
+// instr is an instruction with its parameter.
+type instr struct {
+	op    opcode.Opcode
+	param []byte
+}
+
+// syscallTarget determines the contract and method of System.Contract.Call
+// syscall at the given offset from the preceding instructions.
+func syscallTarget(off int, prev []instr) Call {
+	var (
+		c = Call{Offset: off}
+		l = len(prev)
+
+		hash, method int // Indexes in prev.
+	)
+	switch {
+	case l >= 5 && prev[l-1].op == opcode.REVERSE4:
+		// Compiler: hash, method, flags, args, REVERSE4.
+		hash, method = l-5, l-4
+	case l >= 2:
+		// emit.AppCall: args, flags, method, hash.
+		hash, method = l-1, l-2
+	default:
+		return c
+	}
+	if isPushData(prev[hash].op) && len(prev[hash].param) == util.Uint160Size {
+		c.Contract, _ = util.Uint160DecodeBytesBE(prev[hash].param)
+	}
+	if isPushData(prev[method].op) {
+		c.Method = string(prev[method].param)
+	}
+	return c
+}
+
+func isPushData(op opcode.Opcode) bool {
+	return op == opcode.PUSHDATA1 || op == opcode.PUSHDATA2 || op == opcode.PUSHDATA4
+}
+
+// mayAllow checks if the given permission can allow the call. Group permissions
+// can't be checked without the called contract manifest, so they're assumed to
+// allow any contract. A call to unknown contract can be allowed by any permission
+// and a call to unknown method can only be allowed by the wildcard one.
+func mayAllow(p *manifest.Permission, c Call) bool {
+	if p.Contract.Type == manifest.PermissionHash && !c.Contract.Equals(util.Uint160{}) &&
+		!p.Contract.Hash().Equals(c.Contract) {
+		return false
+	}
+	if c.Method == "" {
+		return p.Methods.IsWildcard()
+	}
+	return p.Methods.Contains(c.Method)
+}
+
+// Check compares the given calls (see FindCalls) with the permissions declared
+// in the manifest and returns the list of problems found: calls that are not
+// allowed by any permission, permissions not allowing any of the calls, wildcard
+// contracts or methods where all matching calls are known statically and
+// permitted methods that are never called. Every problem is returned as one of
+// the errors defined above wrapped with details, an empty list means that
+// permissions match the calls exactly.
+func Check(calls []Call, ps []manifest.Permission) []error {
+	var (
+		res  []error
+		used = make([][]Call, len(ps))
+	)
+	for _, c := range calls {
+		var allowed bool
+		for i := range ps {
+			if mayAllow(&ps[i], c) {
+				used[i] = append(used[i], c)
+				allowed = true
+			}
+		}
+		if !allowed {
+			res = append(res, fmt.Errorf("%w: %s", ErrCallNotAllowed, c))
+		}
+	}
+	for i := range ps {
+		var (
+			p         = &ps[i]
+			contracts = make(map[util.Uint160]bool)
+			methods   = make(map[string]bool)
+		)
+		if len(used[i]) == 0 {
+			res = append(res, fmt.Errorf("%w: %s", ErrUnusedPermission, p.Contract))
+			continue
+		}
+		for _, c := range used[i] {
+			contracts[c.Contract] = true
+			methods[c.Method] = true
+		}
+		if p.Contract.Type == manifest.PermissionWildcard && !contracts[util.Uint160{}] {
+			res = append(res, fmt.Errorf("%w: only %d contract(s) are called", ErrWildcardContract, len(contracts)))
+		}
+		switch {
+		case p.Methods.IsWildcard() && !methods[""]:
+			res = append(res, fmt.Errorf("%w: %s, only %s are called", ErrWildcardMethods, p.Contract, strings.Join(sortedKeys(methods), ", ")))
+		case !p.Methods.IsWildcard():
+			var unused []string
+			for _, m := range p.Methods.Value {
+				if !methods[m] {
+					unused = append(unused, m)
+				}
+			}
+			if len(unused) != 0 {
+				res = append(res, fmt.Errorf("%w: %s, %s", ErrUnusedMethods, p.Contract, strings.Join(unused, ", ")))
+			}
+		}
+	}
+	return res
+}
+
+// Suggest returns the minimal set of permissions allowing all the given calls.
+// Statically known contracts get hash permissions with the list of methods
+// called (or any method if some of them are dynamic), calls to unknown
+// contracts are allowed by the wildcard contract permission. Group permissions
+// can't be derived from the script, so they're never suggested.
+func Suggest(calls []Call) []manifest.Permission {
+	var (
+		res     []manifest.Permission
+		methods = make(map[util.Uint160]map[string]bool)
+	)
+	for _, c := range calls {
+		if methods[c.Contract] == nil {
+			methods[c.Contract] = make(map[string]bool)
+		}
+		methods[c.Contract][c.Method] = true
+	}
+	for h, ms := range methods {
+		var p *manifest.Permission
+		if h.Equals(util.Uint160{}) {
+			p = manifest.NewPermission(manifest.PermissionWildcard)
+		} else {
+			p = manifest.NewPermission(manifest.PermissionHash, h)
+		}
+		if !ms[""] {
+			p.Methods.Restrict()
+			for _, m := range sortedKeys(ms) {
+				p.Methods.Add(m)
+			}
+		}
+		res = append(res, *p)
+	}
+	slices.SortFunc(res, func(a, b manifest.Permission) int { return a.Contract.Compare(b.Contract) })
+	return res
+}
+
+func sortedKeys(m map[string]bool) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	slices.Sort(res)
+	return res
+}
@@ -0,0 +1,142 @@
+package permcheck
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	h1 = util.Uint160{1, 2, 3}
+	h2 = util.Uint160{4, 5, 6}
+)
+
+// hashPerm returns a hash permission for the given methods (any if none).
+func hashPerm(h util.Uint160, methods ...string) manifest.Permission {
+	p := manifest.NewPermission(manifest.PermissionHash, h)
+	if len(methods) != 0 {
+		p.Methods.Restrict()
+		for _, m := range methods {
+			p.Methods.Add(m)
+		}
+	}
+	return *p
+}
+
+func testNEF(t *testing.T) *nef.File {
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, h1, "transfer", callflag.All, 1, 2)
+	// Compiler-generated sequence.
+	emit.Bytes(w.BinWriter, h2.BytesBE())
+	emit.String(w.BinWriter, "balanceOf")
+	emit.Int(w.BinWriter, int64(callflag.ReadStates))
+	emit.Opcodes(w.BinWriter, opcode.LDLOC0, opcode.REVERSE4)
+	emit.Syscall(w.BinWriter, "System.Contract.Call")
+	// Dynamic hash.
+	emit.Opcodes(w.BinWriter, opcode.PUSH0, opcode.PACK)
+	emit.Int(w.BinWriter, int64(callflag.All))
+	emit.String(w.BinWriter, "update")
+	emit.Opcodes(w.BinWriter, opcode.LDARG0)
+	emit.Syscall(w.BinWriter, "System.Contract.Call")
+	emit.Instruction(w.BinWriter, opcode.CALLT, []byte{0, 0})
+	emit.Opcodes(w.BinWriter, opcode.RET)
+	require.NoError(t, w.Err)
+	return &nef.File{
+		Tokens: []nef.MethodToken{{Hash: h1, Method: "symbol"}},
+		Script: w.Bytes(),
+	}
+}
+
+func TestFindCalls(t *testing.T) {
+	f := testNEF(t)
+	calls, err := FindCalls(f)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(calls))
+	for i, exp := range []Call{
+		{Contract: h1, Method: "transfer"},
+		{Contract: h2, Method: "balanceOf"},
+		{Method: "update"},
+		{Contract: h1, Method: "symbol"},
+	} {
+		require.Equal(t, exp.Contract, calls[i].Contract, i)
+		require.Equal(t, exp.Method, calls[i].Method, i)
+	}
+	require.False(t, calls[0].IsDynamic())
+	require.True(t, calls[2].IsDynamic())
+	require.Equal(t, opcode.CALLT, opcode.Opcode(f.Script[calls[3].Offset]))
+
+	f.Tokens = nil
+	_, err = FindCalls(f)
+	require.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	calls := []Call{
+		{Contract: h1, Method: "transfer"},
+		{Contract: h1, Method: "symbol"},
+		{Contract: h2, Method: "balanceOf"},
+	}
+	errorsAre := func(t *testing.T, errs []error, targets ...error) {
+		require.Equal(t, len(targets), len(errs), errs)
+		for i := range targets {
+			require.True(t, errors.Is(errs[i], targets[i]), errs[i])
+		}
+	}
+
+	t.Run("exact", func(t *testing.T) {
+		errorsAre(t, Check(calls, Suggest(calls)))
+	})
+	t.Run("wildcard", func(t *testing.T) {
+		ps := []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)}
+		errorsAre(t, Check(calls, ps), ErrWildcardContract, ErrWildcardMethods)
+
+		// Dynamic calls need it.
+		errorsAre(t, Check([]Call{{}}, ps))
+		errorsAre(t, Check(nil, ps), ErrUnusedPermission)
+	})
+	t.Run("wildcard methods", func(t *testing.T) {
+		ps := []manifest.Permission{
+			hashPerm(h1),
+			hashPerm(h2, "balanceOf", "decimals"),
+		}
+		errorsAre(t, Check(calls, ps), ErrWildcardMethods, ErrUnusedMethods)
+	})
+	t.Run("not allowed", func(t *testing.T) {
+		ps := []manifest.Permission{
+			hashPerm(h1, "transfer", "symbol"),
+			hashPerm(util.Uint160{7}),
+		}
+		errorsAre(t, Check(calls, ps), ErrCallNotAllowed, ErrUnusedPermission)
+		errorsAre(t, Check(append(calls[:2:2], Call{Contract: h1}), ps[:1]), ErrCallNotAllowed)
+	})
+}
+
+func TestSuggest(t *testing.T) {
+	require.Nil(t, Suggest(nil))
+
+	ps := Suggest([]Call{
+		{Contract: h2, Method: "transfer"},
+		{Contract: h1, Method: "transfer"},
+		{Contract: h1, Method: "balanceOf"},
+		{Contract: h1, Method: "transfer"},
+		{Contract: h2},
+		{Method: "onNEP17Payment"},
+	})
+	exp := []manifest.Permission{
+		*manifest.NewPermission(manifest.PermissionWildcard),
+		hashPerm(h1, "balanceOf", "transfer"),
+		hashPerm(h2),
+	}
+	exp[0].Methods.Restrict()
+	exp[0].Methods.Add("onNEP17Payment")
+	require.Equal(t, exp, ps)
+	require.NoError(t, manifest.Permissions(ps).AreValid())
+}
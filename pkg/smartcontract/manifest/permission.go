@@ -115,6 +115,18 @@ func (d *PermissionDesc) Equals(v PermissionDesc) bool {
 	return d.Compare(v) == 0
 }
 
+// String returns a human-readable description of the permission descriptor.
+func (d PermissionDesc) String() string {
+	switch d.Type {
+	case PermissionHash:
+		return "contract " + d.Hash().StringLE()
+	case PermissionGroup:
+		return "group " + d.Group().StringCompressed()
+	default:
+		return "any contract"
+	}
+}
+
 // IsValid checks if Permission is correct.
 func (p *Permission) IsValid() error {
 	if slices.Contains(p.Methods.Value, "") {
@@ -21,8 +21,10 @@ var (
 var checks = map[string][]*Standard{
 	manifest.NEP11StandardName: {Nep11NonDivisible, Nep11Divisible},
 	manifest.NEP17StandardName: {Nep17},
-	manifest.NEP11Payable:      {Nep11Payable},
-	manifest.NEP17Payable:      {Nep17Payable},
+	manifest.NEP26StandardName: {Nep26},
+	manifest.NEP27StandardName: {Nep27},
+	manifest.NEP11Payable:      {Nep26},
+	manifest.NEP17Payable:      {Nep27},
 }
 
 // Check checks if the manifest complies with all provided standards.
@@ -1,6 +1,7 @@
 package standard
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -176,3 +177,31 @@ func TestOptional(t *testing.T) {
 		require.NoError(t, Comply(&actual, &m))
 	})
 }
+
+func TestCheckPayable(t *testing.T) {
+	for _, tc := range []struct {
+		names []string
+		std   *Standard
+	}{
+		{[]string{manifest.NEP26StandardName, manifest.NEP11Payable}, Nep26},
+		{[]string{manifest.NEP27StandardName, manifest.NEP17Payable}, Nep27},
+	} {
+		callback := tc.std.ABI.Methods[0]
+		for _, name := range tc.names {
+			t.Run(name, func(t *testing.T) {
+				m := manifest.NewManifest("Test")
+				m.SupportedStandards = []string{name}
+				require.ErrorIs(t, Check(m, name), ErrMethodMissing)
+
+				md := callback
+				md.Parameters = slices.Clone(md.Parameters)
+				md.Parameters[0].Type = smartcontract.ByteArrayType
+				m.ABI.Methods = []manifest.Method{md}
+				require.ErrorIs(t, Check(m, name), ErrInvalidParameterType)
+
+				m.ABI.Methods = []manifest.Method{callback}
+				require.NoError(t, Check(m, name))
+			})
+		}
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 )
 
-// Nep11Payable contains NEP-11's onNEP11Payment method definition.
-var Nep11Payable = &Standard{
+// Nep26 contains NEP-26's onNEP11Payment method definition.
+var Nep26 = &Standard{
 	Manifest: manifest.Manifest{
 		ABI: manifest.ABI{
 			Methods: []manifest.Method{{
@@ -23,8 +23,8 @@ var Nep11Payable = &Standard{
 	},
 }
 
-// Nep17Payable contains NEP-17's onNEP17Payment method definition.
-var Nep17Payable = &Standard{
+// Nep27 contains NEP-27's onNEP17Payment method definition.
+var Nep27 = &Standard{
 	Manifest: manifest.Manifest{
 		ABI: manifest.ABI{
 			Methods: []manifest.Method{{
@@ -39,3 +39,9 @@ var Nep17Payable = &Standard{
 		},
 	},
 }
+
+// Nep11Payable is a legacy name of Nep26.
+var Nep11Payable = Nep26
+
+// Nep17Payable is a legacy name of Nep27.
+var Nep17Payable = Nep27
@@ -0,0 +1,424 @@
+package smartcontract
+
+import (
+	"cmp"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// StructTag is the struct field tag used by NewParameterFromValueDeep and
+// Parameter.ConvertTo. Tag value is either "-" to skip the field or the index
+// of the field in the resulting ArrayType parameter. Indexes are either
+// specified for all converted fields of the structure (forming 0..N-1
+// sequence) or not specified at all, in which case declaration order is used.
+const StructTag = "neo"
+
+var (
+	bigIntType    = reflect.TypeFor[big.Int]()
+	parameterType = reflect.TypeFor[Parameter]()
+	publicKeyType = reflect.TypeFor[keys.PublicKey]()
+	uint160Type   = reflect.TypeFor[util.Uint160]()
+	uint256Type   = reflect.TypeFor[util.Uint256]()
+)
+
+// NewParameterFromValueDeep is similar to NewParameterFromValue, but it also
+// converts arbitrary (nested) structures, slices, arrays, maps and pointers
+// using reflection. Structures are converted to ArrayType parameters
+// containing exported fields (see StructTag for field order), maps are
+// converted to MapType parameters (sorted by keys for integer, string and
+// boolean keys), byte slices and arrays are converted to ByteArrayType,
+// other slices and arrays are converted to ArrayType and nil pointers are
+// converted to AnyType. Types supported by NewParameterFromValue are converted
+// the same way. [errors.ErrUnsupported] is returned for types that can't be
+// converted (like floats, channels or functions).
+func NewParameterFromValueDeep(value any) (Parameter, error) {
+	p, err := NewParameterFromValue(value)
+	if err == nil || !errors.Is(err, errors.ErrUnsupported) {
+		return p, err
+	}
+	return newParameterFromReflect(reflect.ValueOf(value))
+}
+
+func newParameterFromReflect(v reflect.Value) (Parameter, error) {
+	if !v.IsValid() || (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return NewParameter(AnyType), nil
+	}
+	if c, ok := v.Interface().(Convertible); ok {
+		return NewParameterFromValue(c)
+	}
+	switch v.Type() {
+	case bigIntType:
+		i := v.Interface().(big.Int)
+		return NewParameterFromValue(&i)
+	case publicKeyType, uint160Type, uint256Type, parameterType,
+		reflect.PointerTo(bigIntType), reflect.PointerTo(publicKeyType),
+		reflect.PointerTo(uint160Type), reflect.PointerTo(uint256Type),
+		reflect.PointerTo(parameterType):
+		return NewParameterFromValue(v.Interface())
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return newParameterFromReflect(v.Elem())
+	case reflect.Bool:
+		return Parameter{Type: BoolType, Value: v.Bool()}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Parameter{Type: IntegerType, Value: big.NewInt(v.Int())}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Parameter{Type: IntegerType, Value: new(big.Int).SetUint64(v.Uint())}, nil
+	case reflect.String:
+		return Parameter{Type: StringType, Value: v.String()}, nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return Parameter{Type: ByteArrayType, Value: b}, nil
+		}
+		arr := make([]Parameter, v.Len())
+		for i := range arr {
+			var err error
+			arr[i], err = newParameterFromReflect(v.Index(i))
+			if err != nil {
+				return Parameter{}, fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return Parameter{Type: ArrayType, Value: arr}, nil
+	case reflect.Map:
+		mkeys := v.MapKeys()
+		slices.SortFunc(mkeys, compareMapKeys)
+		pairs := make([]ParameterPair, len(mkeys))
+		for i, k := range mkeys {
+			var err error
+			pairs[i].Key, err = newParameterFromReflect(k)
+			if err != nil {
+				return Parameter{}, fmt.Errorf("map key: %w", err)
+			}
+			pairs[i].Value, err = newParameterFromReflect(v.MapIndex(k))
+			if err != nil {
+				return Parameter{}, fmt.Errorf("map value: %w", err)
+			}
+		}
+		return Parameter{Type: MapType, Value: pairs}, nil
+	case reflect.Struct:
+		fields, err := structFields(v.Type())
+		if err != nil {
+			return Parameter{}, err
+		}
+		arr := make([]Parameter, len(fields))
+		for i, f := range fields {
+			arr[i], err = newParameterFromReflect(v.Field(f))
+			if err != nil {
+				return Parameter{}, fmt.Errorf("field %s: %w", v.Type().Field(f).Name, err)
+			}
+		}
+		return Parameter{Type: ArrayType, Value: arr}, nil
+	default:
+		return Parameter{}, fmt.Errorf("%w: %s type", errors.ErrUnsupported, v.Type())
+	}
+}
+
+// compareMapKeys orders integer, string and boolean map keys, other keys are
+// considered to be equal.
+func compareMapKeys(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case b.Bool():
+			return -1
+		default:
+			return 1
+		}
+	default:
+		return 0
+	}
+}
+
+// structFields returns indexes of the structure fields in the order they're
+// stored in ArrayType parameter.
+func structFields(t reflect.Type) ([]int, error) {
+	var (
+		fields  []int
+		indexes []int
+	)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup(StructTag)
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		if tagged {
+			idx, err := strconv.Atoi(tag)
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("field %s of %s: invalid %q tag value %q", f.Name, t, StructTag, tag)
+			}
+			indexes = append(indexes, idx)
+		}
+		fields = append(fields, i)
+	}
+	if len(indexes) == 0 {
+		return fields, nil
+	}
+	if len(indexes) != len(fields) {
+		return nil, fmt.Errorf("%s: %q tag should be specified for all fields or none of them", t, StructTag)
+	}
+	res := make([]int, len(fields))
+	filled := make([]bool, len(fields))
+	for i, idx := range indexes {
+		if idx >= len(res) || filled[idx] {
+			return nil, fmt.Errorf("%s: invalid or duplicate field index %d", t, idx)
+		}
+		res[idx] = fields[i]
+		filled[idx] = true
+	}
+	return res, nil
+}
+
+// ConvertTo converts the parameter into the value pointed to by v. It's the
+// reverse of NewParameterFromValueDeep: ArrayType parameters can be converted
+// to structures (see StructTag for field order), slices and arrays, MapType
+// parameters can be converted to maps, AnyType parameters with nil value set
+// the target to its zero value. Integers are checked for overflows. Values of
+// `any` type get parameter values as is except for ArrayType ones that are
+// converted to []any recursively.
+func (p *Parameter) ConvertTo(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("non-nil pointer expected, got %T", v)
+	}
+	return p.convertTo(rv.Elem())
+}
+
+func (p *Parameter) convertTo(v reflect.Value) error {
+	if p.Type == AnyType && p.Value == nil {
+		v.SetZero()
+		return nil
+	}
+	switch v.Type() {
+	case parameterType:
+		v.Set(reflect.ValueOf(*p))
+		return nil
+	case bigIntType:
+		i, err := p.getInteger()
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(*new(big.Int).Set(i)))
+		return nil
+	case uint160Type, uint256Type:
+		return p.convertToHash(v)
+	case publicKeyType:
+		b, err := p.getBytes(PublicKeyType, ByteArrayType)
+		if err != nil {
+			return err
+		}
+		pub, err := keys.NewPublicKeyFromBytes(b, elliptic.P256())
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(*pub))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := p.convertTo(elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return fmt.Errorf("%w: %s type", errors.ErrUnsupported, v.Type())
+		}
+		if p.Type != ArrayType {
+			if p.Value != nil {
+				v.Set(reflect.ValueOf(p.Value))
+			} else {
+				v.SetZero()
+			}
+			return nil
+		}
+		arr := make([]any, 0)
+		if err := p.convertTo(reflect.ValueOf(&arr).Elem()); err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(arr))
+	case reflect.Bool:
+		b, ok := p.Value.(bool)
+		if p.Type != BoolType || !ok {
+			return p.typeError(v.Type())
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := p.getInteger()
+		if err != nil {
+			return err
+		}
+		if !i.IsInt64() || v.OverflowInt(i.Int64()) {
+			return fmt.Errorf("integer %s overflows %s", i, v.Type())
+		}
+		v.SetInt(i.Int64())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, err := p.getInteger()
+		if err != nil {
+			return err
+		}
+		if !i.IsUint64() || v.OverflowUint(i.Uint64()) {
+			return fmt.Errorf("integer %s overflows %s", i, v.Type())
+		}
+		v.SetUint(i.Uint64())
+	case reflect.String:
+		switch val := p.Value.(type) {
+		case string:
+			v.SetString(val)
+		case []byte:
+			v.SetString(string(val))
+		default:
+			return p.typeError(v.Type())
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, err := p.getBytes(ByteArrayType, SignatureType, PublicKeyType, StringType)
+			if err != nil {
+				return err
+			}
+			if v.Kind() == reflect.Array {
+				if len(b) != v.Len() {
+					return fmt.Errorf("expected %d bytes for %s, got %d", v.Len(), v.Type(), len(b))
+				}
+			} else {
+				v.Set(reflect.MakeSlice(v.Type(), len(b), len(b)))
+			}
+			reflect.Copy(v, reflect.ValueOf(b))
+			return nil
+		}
+		arr, ok := p.Value.([]Parameter)
+		if p.Type != ArrayType || !ok {
+			return p.typeError(v.Type())
+		}
+		if v.Kind() == reflect.Array {
+			if len(arr) != v.Len() {
+				return fmt.Errorf("expected %d elements for %s, got %d", v.Len(), v.Type(), len(arr))
+			}
+		} else {
+			v.Set(reflect.MakeSlice(v.Type(), len(arr), len(arr)))
+		}
+		for i := range arr {
+			if err := arr[i].convertTo(v.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+	case reflect.Map:
+		pairs, ok := p.Value.([]ParameterPair)
+		if p.Type != MapType || !ok {
+			return p.typeError(v.Type())
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(pairs))
+		for i := range pairs {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := pairs[i].Key.convertTo(key); err != nil {
+				return fmt.Errorf("map key: %w", err)
+			}
+			if !key.Comparable() {
+				return fmt.Errorf("%w: %s map key", errors.ErrUnsupported, key.Type())
+			}
+			val := reflect.New(v.Type().Elem()).Elem()
+			if err := pairs[i].Value.convertTo(val); err != nil {
+				return fmt.Errorf("map value: %w", err)
+			}
+			m.SetMapIndex(key, val)
+		}
+		v.Set(m)
+	case reflect.Struct:
+		fields, err := structFields(v.Type())
+		if err != nil {
+			return err
+		}
+		arr, ok := p.Value.([]Parameter)
+		if p.Type != ArrayType || !ok {
+			return p.typeError(v.Type())
+		}
+		if len(arr) != len(fields) {
+			return fmt.Errorf("expected %d fields for %s, got %d", len(fields), v.Type(), len(arr))
+		}
+		for i, f := range fields {
+			if err := arr[i].convertTo(v.Field(f)); err != nil {
+				return fmt.Errorf("field %s: %w", v.Type().Field(f).Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %s type", errors.ErrUnsupported, v.Type())
+	}
+	return nil
+}
+
+// convertToHash sets util.Uint160 or util.Uint256 value from the Hash160Type,
+// Hash256Type or ByteArrayType parameter.
+func (p *Parameter) convertToHash(v reflect.Value) error {
+	switch val := p.Value.(type) {
+	case util.Uint160:
+		if v.Type() != uint160Type {
+			return p.typeError(v.Type())
+		}
+		v.Set(reflect.ValueOf(val))
+	case util.Uint256:
+		if v.Type() != uint256Type {
+			return p.typeError(v.Type())
+		}
+		v.Set(reflect.ValueOf(val))
+	case []byte:
+		if p.Type != ByteArrayType {
+			return p.typeError(v.Type())
+		}
+		if len(val) != v.Len() {
+			return fmt.Errorf("expected %d bytes for %s, got %d", v.Len(), v.Type(), len(val))
+		}
+		reflect.Copy(v, reflect.ValueOf(val))
+	default:
+		return p.typeError(v.Type())
+	}
+	return nil
+}
+
+// getInteger returns the value of IntegerType parameter.
+func (p *Parameter) getInteger() (*big.Int, error) {
+	i, ok := p.Value.(*big.Int)
+	if p.Type != IntegerType || !ok || i == nil {
+		return nil, p.typeError(bigIntType)
+	}
+	return i, nil
+}
+
+// getBytes returns the byte value of the parameter if it's of one of the
+// given types.
+func (p *Parameter) getBytes(types ...ParamType) ([]byte, error) {
+	if !slices.Contains(types, p.Type) {
+		return nil, p.typeError(reflect.TypeFor[[]byte]())
+	}
+	switch val := p.Value.(type) {
+	case []byte:
+		return val, nil
+	case string:
+		return []byte(val), nil
+	default:
+		return nil, p.typeError(reflect.TypeFor[[]byte]())
+	}
+}
+
+func (p *Parameter) typeError(t reflect.Type) error {
+	return fmt.Errorf("can't convert %s parameter to %s", p.Type, t)
+}
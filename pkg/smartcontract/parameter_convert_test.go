@@ -0,0 +1,205 @@
+package smartcontract
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	testOrder struct {
+		Owner  util.Uint160
+		Amount *big.Int
+		Items  []testItem
+		Meta   map[string]int64
+		Key    *keys.PublicKey
+		Note   *string
+		Hash   [4]byte
+		hidden int
+		Skip   bool `neo:"-"`
+	}
+	testItem struct {
+		Count uint16 `neo:"1"`
+		Name  string `neo:"0"`
+	}
+)
+
+func TestNewParameterFromValueDeep(t *testing.T) {
+	pk, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	order := testOrder{
+		Owner:  util.Uint160{1, 2, 3},
+		Amount: big.NewInt(100500),
+		Items:  []testItem{{Name: "a", Count: 1}, {Name: "b", Count: 2}},
+		Meta:   map[string]int64{"z": 26, "a": 1},
+		Key:    pk.PublicKey(),
+		Hash:   [4]byte{1, 2, 3, 4},
+		hidden: 42,
+		Skip:   true,
+	}
+	expected := Parameter{Type: ArrayType, Value: []Parameter{
+		{Type: Hash160Type, Value: util.Uint160{1, 2, 3}},
+		{Type: IntegerType, Value: big.NewInt(100500)},
+		{Type: ArrayType, Value: []Parameter{
+			{Type: ArrayType, Value: []Parameter{{Type: StringType, Value: "a"}, {Type: IntegerType, Value: big.NewInt(1)}}},
+			{Type: ArrayType, Value: []Parameter{{Type: StringType, Value: "b"}, {Type: IntegerType, Value: big.NewInt(2)}}},
+		}},
+		{Type: MapType, Value: []ParameterPair{
+			{Key: Parameter{Type: StringType, Value: "a"}, Value: Parameter{Type: IntegerType, Value: big.NewInt(1)}},
+			{Key: Parameter{Type: StringType, Value: "z"}, Value: Parameter{Type: IntegerType, Value: big.NewInt(26)}},
+		}},
+		{Type: PublicKeyType, Value: pk.PublicKey().Bytes()},
+		{Type: AnyType},
+		{Type: ByteArrayType, Value: []byte{1, 2, 3, 4}},
+	}}
+
+	for name, v := range map[string]any{"value": order, "pointer": &order} {
+		t.Run(name, func(t *testing.T) {
+			p, err := NewParameterFromValueDeep(v)
+			require.NoError(t, err)
+			require.Equal(t, expected, p)
+
+			var actual testOrder
+			require.NoError(t, p.ConvertTo(&actual))
+			order.hidden, order.Skip = 0, false
+			require.Equal(t, order, actual)
+			order.hidden, order.Skip = 42, true
+		})
+	}
+
+	t.Run("simple", func(t *testing.T) {
+		p, err := NewParameterFromValueDeep(42)
+		require.NoError(t, err)
+		require.Equal(t, Parameter{Type: IntegerType, Value: big.NewInt(42)}, p)
+
+		p, err = NewParameterFromValueDeep([]any{1, map[int]bool{2: true}, nil})
+		require.NoError(t, err)
+		require.Equal(t, Parameter{Type: ArrayType, Value: []Parameter{
+			{Type: IntegerType, Value: big.NewInt(1)},
+			{Type: MapType, Value: []ParameterPair{{Key: Parameter{Type: IntegerType, Value: big.NewInt(2)}, Value: Parameter{Type: BoolType, Value: true}}}},
+			{Type: AnyType},
+		}}, p)
+
+		p, err = NewParameterFromValueDeep([]testConvertible{{i: 1}})
+		require.NoError(t, err)
+		require.Equal(t, Parameter{Type: ArrayType, Value: []Parameter{{Type: IntegerType, Value: 1}}}, p)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := NewParameterFromValueDeep([]float64{1.5})
+		require.True(t, errors.Is(err, errors.ErrUnsupported))
+
+		_, err = NewParameterFromValueDeep(struct{ F func() }{})
+		require.True(t, errors.Is(err, errors.ErrUnsupported))
+	})
+
+	t.Run("bad tags", func(t *testing.T) {
+		_, err := NewParameterFromValueDeep(struct {
+			A int `neo:"0"`
+			B int
+		}{})
+		require.Error(t, err)
+
+		_, err = NewParameterFromValueDeep(struct {
+			A int `neo:"1"`
+			B int `neo:"1"`
+		}{})
+		require.Error(t, err)
+
+		_, err = NewParameterFromValueDeep(struct {
+			A int `neo:"first"`
+		}{})
+		require.Error(t, err)
+	})
+}
+
+func TestParameterConvertTo(t *testing.T) {
+	t.Run("not a pointer", func(t *testing.T) {
+		p := Parameter{Type: IntegerType, Value: big.NewInt(1)}
+		var i int
+		require.Error(t, p.ConvertTo(i))
+		require.Error(t, p.ConvertTo((*int)(nil)))
+	})
+	t.Run("integers", func(t *testing.T) {
+		p := Parameter{Type: IntegerType, Value: big.NewInt(300)}
+		var (
+			i   int
+			u8  uint8
+			i16 int16
+			b   big.Int
+		)
+		require.NoError(t, p.ConvertTo(&i))
+		require.Equal(t, 300, i)
+		require.Error(t, p.ConvertTo(&u8))
+		require.NoError(t, p.ConvertTo(&i16))
+		require.Equal(t, int16(300), i16)
+		require.NoError(t, p.ConvertTo(&b))
+		require.Equal(t, int64(300), b.Int64())
+
+		p.Value = new(big.Int).Lsh(big.NewInt(1), 64)
+		require.Error(t, p.ConvertTo(&i))
+
+		p.Value = big.NewInt(-1)
+		var u uint64
+		require.Error(t, p.ConvertTo(&u))
+		p.Value = big.NewInt(math.MaxInt64)
+		require.NoError(t, p.ConvertTo(&u))
+		require.Equal(t, uint64(math.MaxInt64), u)
+	})
+	t.Run("any", func(t *testing.T) {
+		p := Parameter{Type: ArrayType, Value: []Parameter{
+			{Type: StringType, Value: "s"},
+			{Type: ArrayType, Value: []Parameter{{Type: BoolType, Value: true}}},
+			{Type: AnyType},
+		}}
+		var v any
+		require.NoError(t, p.ConvertTo(&v))
+		require.Equal(t, []any{"s", []any{true}, nil}, v)
+	})
+	t.Run("hashes and bytes", func(t *testing.T) {
+		var (
+			h160 util.Uint160
+			h256 util.Uint256
+			s    string
+			b    []byte
+		)
+		p := Parameter{Type: ByteArrayType, Value: util.Uint160{1, 2, 3}.BytesBE()}
+		require.NoError(t, p.ConvertTo(&h160))
+		require.Equal(t, util.Uint160{1, 2, 3}, h160)
+		require.Error(t, p.ConvertTo(&h256))
+		require.NoError(t, p.ConvertTo(&s))
+		require.Equal(t, string(h160.BytesBE()), s)
+
+		p = Parameter{Type: Hash256Type, Value: util.Uint256{3, 2, 1}}
+		require.NoError(t, p.ConvertTo(&h256))
+		require.Equal(t, util.Uint256{3, 2, 1}, h256)
+		require.Error(t, p.ConvertTo(&h160))
+		require.Error(t, p.ConvertTo(&b))
+
+		p = Parameter{Type: StringType, Value: "abc"}
+		require.NoError(t, p.ConvertTo(&b))
+		require.Equal(t, []byte("abc"), b)
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		var (
+			item testItem
+			m    map[string]int
+			arr  [2]int
+		)
+		p := Parameter{Type: ArrayType, Value: []Parameter{{Type: StringType, Value: "a"}}}
+		require.Error(t, p.ConvertTo(&item))
+		require.Error(t, p.ConvertTo(&m))
+		require.Error(t, p.ConvertTo(&arr))
+
+		p = Parameter{Type: BoolType, Value: true}
+		require.Error(t, p.ConvertTo(&item))
+		var f float64
+		require.True(t, errors.Is(p.ConvertTo(&f), errors.ErrUnsupported))
+	})
+}
@@ -54,10 +54,45 @@ func (c *ContractReader) {{.Name}}({{range $index, $arg := .Arguments -}}
 // doesn't expand iterators. It creates a script that will get the specified
 // number of result items from the iterator right in the VM and return them to
 // you. It's only limited by VM stack and GAS available for RPC invocations.
-func (c *ContractReader) {{.Name}}Expanded({{range $index, $arg := .Arguments}}{{.Name}} {{.Type}}, {{end}}_numOfIteratorItems int) ([]stackitem.Item, error) {
+func (c *ContractReader) {{.Name}}Expanded({{range $index, $arg := .Arguments}}{{.Name}} {{.Type}}, {{end}}_numOfIteratorItems int) ([]{{if .IteratorValueType}}{{.IteratorValueType}}{{else}}stackitem.Item{{end}}, error) {
+	{{if .IteratorValueType -}}
+	items, err := unwrap.Array(c.invoker.CallAndExpandIterator(c.hash, "{{.NameABI}}", _numOfIteratorItems{{range $arg := .Arguments}}, {{.Name}}{{end}}))
+	if err != nil {
+		return nil, err
+	}
+	return itemsTo{{.Name}}Values(items)
+	{{- else -}}
 	return unwrap.Array(c.invoker.CallAndExpandIterator(c.hash, "{{.NameABI}}", _numOfIteratorItems{{range $arg := .Arguments}}, {{.Name}}{{end}}))
+	{{- end}}
+}
+{{ if .IteratorValueType }}
+// {{.Name}}Traverse returns the next num items of the iterator returned by
+// {{.Name}} converted to {{.IteratorValueType}}. The session isn't terminated
+// by this method, use TerminateSession of the Invoker when it's no longer needed.
+func (c *ContractReader) {{.Name}}Traverse(sessionID uuid.UUID, iter *result.Iterator, num int) ([]{{.IteratorValueType}}, error) {
+	items, err := c.invoker.TraverseIterator(sessionID, iter, num)
+	if err != nil {
+		return nil, err
+	}
+	return itemsTo{{.Name}}Values(items)
 }
-{{ end }}{{ end }}`
+
+// itemsTo{{.Name}}Values converts items of the iterator returned by {{.Name}}
+// to {{.IteratorValueType}}.
+func itemsTo{{.Name}}Values(items []stackitem.Item) ([]{{.IteratorValueType}}, error) {
+	var (
+		err error
+		res = make([]{{.IteratorValueType}}, len(items))
+	)
+	for i := range items {
+		res[i], err = {{addIndent (etTypeConverter .IteratorValue "items[i]") "\t\t"}}
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+{{ end }}{{ end }}{{ end }}`
 	methodDefinition = `{{ define "METHOD" }}{{ if eq .ReturnType "bool"}}
 func (c *Contract) scriptFor{{.Name}}({{range $index, $arg := .Arguments -}}
 	{{- if ne $index 0}}, {{end}}
@@ -363,6 +398,12 @@ type (
 		Unwrapper      string
 		ItemTo         string
 		ExtendedReturn binding.ExtendedType
+
+		// IteratorValue is the type of iterator items for methods returning
+		// iterators with known value type, IteratorValueType is its Go
+		// representation (empty for untyped iterators).
+		IteratorValue     binding.ExtendedType
+		IteratorValueType string
 	}
 
 	CustomEventTemplate struct {
@@ -405,7 +446,6 @@ func Generate(cfg binding.Config) error {
 	// Strip standard methods from NEP-XX packages.
 	for _, std := range cfg.Manifest.SupportedStandards {
 		if std == manifest.NEP11StandardName {
-			imports["github.com/nspcc-dev/neo-go/pkg/rpcclient/nep11"] = struct{}{}
 			if standard.ComplyABI(cfg.Manifest, standard.Nep11Divisible) == nil {
 				mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, standard.Nep11Divisible)
 				ctr.IsNep11D = true
@@ -413,6 +453,9 @@ func Generate(cfg binding.Config) error {
 				mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, standard.Nep11NonDivisible)
 				ctr.IsNep11ND = true
 			}
+			if ctr.IsNep11D || ctr.IsNep11ND {
+				imports["github.com/nspcc-dev/neo-go/pkg/rpcclient/nep11"] = struct{}{}
+			}
 			mfst.ABI.Events = dropStdEvents(mfst.ABI.Events, standard.Nep11Base)
 			break // Can't be NEP-17 at the same time.
 		}
@@ -426,11 +469,11 @@ func Generate(cfg binding.Config) error {
 	}
 
 	// OnNepXXPayment handlers normally can't be called directly.
-	if standard.ComplyABI(cfg.Manifest, standard.Nep11Payable) == nil {
-		mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, standard.Nep11Payable)
+	if standard.ComplyABI(cfg.Manifest, standard.Nep26) == nil {
+		mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, standard.Nep26)
 	}
-	if standard.ComplyABI(cfg.Manifest, standard.Nep17Payable) == nil {
-		mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, standard.Nep17Payable)
+	if standard.ComplyABI(cfg.Manifest, standard.Nep27) == nil {
+		mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, standard.Nep27)
 	}
 
 	ctr.ContractTmpl = binding.TemplateFromManifest(cfg, scTypeToGo)
@@ -786,6 +829,13 @@ func scTemplateToRPC(cfg binding.Config, ctr ContractTmpl, imports map[string]st
 				ctr.SafeMethods[i].ReturnType = "uuid.UUID, result.Iterator"
 				ctr.SafeMethods[i].Unwrapper = "SessionIterator"
 				ctr.HasIterator = true
+				et := ctr.SafeMethods[i].ExtendedReturn
+				if et.Interface == "iterator" && et.Value != nil {
+					ctr.SafeMethods[i].IteratorValue = *et.Value
+					ctr.SafeMethods[i].IteratorValueType, _ = extendedTypeToGo(*et.Value, cfg.NamedTypes)
+					addETImports(*et.Value, cfg.NamedTypes, imports)
+					imports["fmt"] = struct{}{}
+				}
 			} else {
 				imports["github.com/nspcc-dev/neo-go/pkg/vm/stackitem"] = struct{}{}
 				ctr.SafeMethods[i].ReturnType = "any"
@@ -0,0 +1,139 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// Checkpoint is a serializable snapshot of a paused VM. VM state can't be
+// serialized directly since it can contain interop items (like storage
+// iterators) and it depends on the storage state changed by the execution.
+// But the execution is deterministic, so a checkpoint only contains the number
+// of instructions executed along with the VM state digest. The VM is restored
+// by executing the same script in the same environment up to this point (see
+// Restore), the digest allows to ensure the restored state is the same.
+type Checkpoint struct {
+	// Steps is the number of instructions executed.
+	Steps uint64
+	// GasConsumed is the amount of GAS consumed by the execution.
+	GasConsumed int64
+	// Contexts contains the state of invocation stack elements starting from
+	// the entry one.
+	Contexts []CheckpointContext
+	// EStackSize is the size of the current evaluation stack.
+	EStackSize int
+}
+
+// CheckpointContext is the state of a single invocation stack element.
+type CheckpointContext struct {
+	// ScriptHash is the hash of the script executed.
+	ScriptHash util.Uint160
+	// NextIP is the position of the next instruction to be executed.
+	NextIP int
+}
+
+// ErrCheckpointMismatch is returned from Restore when the VM state after
+// execution doesn't match the checkpoint.
+var ErrCheckpointMismatch = errors.New("checkpoint mismatch")
+
+// Steps returns the number of instructions executed by the VM.
+func (v *VM) Steps() uint64 {
+	return v.steps
+}
+
+// Checkpoint returns the checkpoint for the current VM state. The VM must have
+// a program loaded and must not be stopped.
+func (v *VM) Checkpoint() (*Checkpoint, error) {
+	if !v.Ready() || v.HasStopped() {
+		return nil, errors.New("VM is not running")
+	}
+	c := &Checkpoint{
+		Steps:       v.steps,
+		GasConsumed: v.gasConsumed,
+		Contexts:    make([]CheckpointContext, len(v.istack)),
+		EStackSize:  v.estack.Len(),
+	}
+	for i, ctx := range v.istack {
+		c.Contexts[i] = CheckpointContext{
+			ScriptHash: ctx.ScriptHash(),
+			NextIP:     ctx.NextIP(),
+		}
+	}
+	return c, nil
+}
+
+// Restore brings the VM to the state described by the checkpoint executing the
+// loaded program up to the checkpoint. The program must be loaded in the same
+// environment (script container, block, storage state, etc.) the checkpoint
+// was made in, ErrCheckpointMismatch is returned if the resulting state
+// differs from the checkpoint. The VM is left in the Break state on success.
+func (v *VM) Restore(c *Checkpoint) error {
+	if v.steps > c.Steps {
+		return fmt.Errorf("%w: %d instructions executed already, checkpoint is at %d", ErrCheckpointMismatch, v.steps, c.Steps)
+	}
+	for v.steps < c.Steps {
+		if !v.Ready() || v.HasStopped() {
+			return fmt.Errorf("%w: execution stopped after %d instructions", ErrCheckpointMismatch, v.steps)
+		}
+		err := v.Step()
+		if err != nil {
+			return fmt.Errorf("failed to execute instruction %d: %w", v.steps, err)
+		}
+	}
+	actual, err := v.Checkpoint()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCheckpointMismatch, err)
+	}
+	if !actual.Equals(c) {
+		return ErrCheckpointMismatch
+	}
+	v.state = vmstate.Break
+	return nil
+}
+
+// Equals checks whether two checkpoints describe the same VM state.
+func (c *Checkpoint) Equals(o *Checkpoint) bool {
+	if c.Steps != o.Steps || c.GasConsumed != o.GasConsumed ||
+		c.EStackSize != o.EStackSize || len(c.Contexts) != len(o.Contexts) {
+		return false
+	}
+	for i := range c.Contexts {
+		if c.Contexts[i] != o.Contexts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// EncodeBinary implements the io.Serializable interface.
+func (c *Checkpoint) EncodeBinary(w *io.BinWriter) {
+	w.WriteU64LE(c.Steps)
+	w.WriteU64LE(uint64(c.GasConsumed))
+	w.WriteVarUint(uint64(len(c.Contexts)))
+	for i := range c.Contexts {
+		w.WriteBytes(c.Contexts[i].ScriptHash[:])
+		w.WriteVarUint(uint64(c.Contexts[i].NextIP))
+	}
+	w.WriteVarUint(uint64(c.EStackSize))
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (c *Checkpoint) DecodeBinary(r *io.BinReader) {
+	c.Steps = r.ReadU64LE()
+	c.GasConsumed = int64(r.ReadU64LE())
+	n := r.ReadVarUint()
+	if n > MaxInvocationStackSize {
+		r.Err = fmt.Errorf("too many contexts: %d", n)
+		return
+	}
+	c.Contexts = make([]CheckpointContext, n)
+	for i := range c.Contexts {
+		r.ReadBytes(c.Contexts[i].ScriptHash[:])
+		c.Contexts[i].NextIP = int(r.ReadVarUint())
+	}
+	c.EStackSize = int(r.ReadVarUint())
+}
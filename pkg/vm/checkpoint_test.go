@@ -0,0 +1,58 @@
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint(t *testing.T) {
+	prog := makeProgram(opcode.PUSH1, opcode.PUSH2, opcode.ADD, opcode.PUSH3, opcode.MUL)
+
+	v := load(prog)
+	for range 3 {
+		require.NoError(t, v.Step())
+	}
+	require.Equal(t, uint64(3), v.Steps())
+	c, err := v.Checkpoint()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), c.Steps)
+	require.Equal(t, 1, c.EStackSize)
+	require.Equal(t, []CheckpointContext{{ScriptHash: v.Context().ScriptHash(), NextIP: 3}}, c.Contexts)
+	testserdes.EncodeDecodeBinary(t, c, new(Checkpoint))
+
+	t.Run("restore", func(t *testing.T) {
+		r := load(prog)
+		require.NoError(t, r.Restore(c))
+		require.True(t, r.AtBreakpoint())
+		require.Equal(t, uint64(3), r.Steps())
+		require.Equal(t, big.NewInt(3), r.Estack().Peek(0).BigInt())
+
+		require.NoError(t, r.Run())
+		require.True(t, r.HasHalted())
+		require.Equal(t, big.NewInt(9), r.Estack().Pop().BigInt())
+	})
+	t.Run("different program", func(t *testing.T) {
+		r := load(makeProgram(opcode.PUSH1, opcode.PUSH2, opcode.DROP, opcode.PUSH3, opcode.MUL))
+		require.ErrorIs(t, r.Restore(c), ErrCheckpointMismatch)
+	})
+	t.Run("program ends earlier", func(t *testing.T) {
+		r := load(makeProgram(opcode.PUSH1))
+		require.ErrorIs(t, r.Restore(c), ErrCheckpointMismatch)
+	})
+	t.Run("executed already", func(t *testing.T) {
+		r := load(prog)
+		for range 4 {
+			require.NoError(t, r.Step())
+		}
+		require.ErrorIs(t, r.Restore(c), ErrCheckpointMismatch)
+	})
+	t.Run("stopped", func(t *testing.T) {
+		require.NoError(t, v.Run())
+		_, err := v.Checkpoint()
+		require.Error(t, err)
+	})
+}
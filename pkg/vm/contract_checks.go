@@ -1,13 +1,16 @@
 package vm
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util/bitfield"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
@@ -15,6 +18,9 @@ import (
 // MaxMultisigKeys is the maximum number of keys allowed for correct multisig contract.
 const MaxMultisigKeys = 1024
 
+// secp256k1SigContractLen is the length of Secp256k1 signature contract.
+const secp256k1SigContractLen = 110
+
 var (
 	verifyInteropID   = interopnames.ToID([]byte(interopnames.SystemCryptoCheckSig))
 	multisigInteropID = interopnames.ToID([]byte(interopnames.SystemCryptoCheckMultisig))
@@ -128,6 +134,31 @@ func ParseSignatureContract(script []byte) ([]byte, bool) {
 	return nil, false
 }
 
+// IsSecp256k1SignatureContract checks whether the passed script is a Secp256k1
+// signature check contract (see emit.CheckSecp256k1Sig).
+func IsSecp256k1SignatureContract(script []byte) bool {
+	_, ok := ParseSecp256k1SignatureContract(script)
+	return ok
+}
+
+// ParseSecp256k1SignatureContract parses a Secp256k1 signature contract created
+// by emit.CheckSecp256k1Sig and returns a public key.
+func ParseSecp256k1SignatureContract(script []byte) ([]byte, bool) {
+	if len(script) != secp256k1SigContractLen ||
+		script[3] != byte(opcode.PUSHDATA1) || script[4] != 33 {
+		return nil, false
+	}
+	var (
+		key = script[5:38]
+		buf = io.NewBufBinWriter()
+	)
+	emit.CheckSecp256k1Sig(buf.BinWriter, key)
+	if !bytes.Equal(script, buf.Bytes()) {
+		return nil, false
+	}
+	return key, true
+}
+
 // IsStandardContract checks whether the passed script is a signature or
 // multi-signature contract.
 func IsStandardContract(script []byte) bool {
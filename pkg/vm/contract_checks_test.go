@@ -34,6 +34,33 @@ func TestParseSignatureContract(t *testing.T) {
 	require.Equal(t, pub, actual)
 }
 
+func TestParseSecp256k1SignatureContract(t *testing.T) {
+	k, err := keys.NewSecp256k1PrivateKey()
+	require.NoError(t, err)
+	pub := k.PublicKey().Bytes()
+	buf := io.NewBufBinWriter()
+	emit.CheckSecp256k1Sig(buf.BinWriter, pub)
+	prog := buf.Bytes()
+
+	actual, ok := ParseSecp256k1SignatureContract(prog)
+	require.True(t, ok)
+	require.Equal(t, pub, actual)
+	require.True(t, IsSecp256k1SignatureContract(prog))
+
+	t.Run("invalid length", func(t *testing.T) {
+		require.False(t, IsSecp256k1SignatureContract(prog[:len(prog)-1]))
+		require.False(t, IsSecp256k1SignatureContract(append(prog, byte(opcode.RET))))
+	})
+	t.Run("invalid script", func(t *testing.T) {
+		bad := slices.Clone(prog)
+		bad[len(bad)-5] = byte(opcode.NOP)
+		require.False(t, IsSecp256k1SignatureContract(bad))
+	})
+	t.Run("signature contract", func(t *testing.T) {
+		require.False(t, IsSecp256k1SignatureContract(testSignatureContract()))
+	})
+}
+
 func TestIsSignatureContract(t *testing.T) {
 	t.Run("valid contract", func(t *testing.T) {
 		prog := testSignatureContract()
@@ -41,6 +41,34 @@ func TestVM_Debug(t *testing.T) {
 		require.Equal(t, 1, v.estack.Len())
 		require.Equal(t, big.NewInt(5), v.estack.Top().Value())
 	})
+	t.Run("BreakCondition", func(t *testing.T) {
+		var executed int
+		v := load(prog)
+		v.SetOnExecHook(func(_ util.Uint160, _ int, _ opcode.Opcode) { executed++ })
+		v.SetBreakCondition(func(ctx *Context) bool {
+			_, op := ctx.NextInstr()
+			return op == opcode.ADD
+		})
+		require.NoError(t, v.Run())
+		require.True(t, v.AtBreakpoint())
+		require.Equal(t, 5, v.Context().NextIP())
+		require.Equal(t, 3, executed)
+
+		v.SetBreakCondition(nil)
+		require.NoError(t, v.Run())
+		require.True(t, v.HasHalted())
+		require.Equal(t, big.NewInt(5), v.estack.Top().Value())
+	})
+	t.Run("BreakCondition, StepInto", func(t *testing.T) {
+		var executed int
+		v := load(prog)
+		v.SetOnExecHook(func(_ util.Uint160, _ int, _ opcode.Opcode) { executed++ })
+		v.SetBreakCondition(func(ctx *Context) bool { return ctx.NextIP() == 4 })
+		require.NoError(t, v.StepOut())
+		require.True(t, v.AtBreakpoint())
+		require.Equal(t, 4, v.Context().NextIP())
+		require.Equal(t, 2, executed)
+	})
 }
 
 func TestContext_BreakPoints(t *testing.T) {
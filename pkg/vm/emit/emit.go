@@ -8,6 +8,7 @@ import (
 	"math/bits"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
@@ -326,6 +327,30 @@ func CheckSig(w *io.BinWriter, key []byte) {
 	Syscall(w, interopnames.SystemCryptoCheckSig)
 }
 
+// secp256k1Keccak256 is the Secp256k1Keccak256 named curve hash identifier of
+// the native CryptoLib contract.
+const secp256k1Keccak256 = 122
+
+// CheckSecp256k1Sig emits a single-key verification script for the given
+// Secp256k1 public key. The script calls CryptoLib's verifyWithECDsa method
+// checking the signature of Keccak256 hash of the 4-byte network magic (LE)
+// concatenated with the script container hash. It does not check for key
+// correctness, so you can get an invalid script if the data passed is not
+// really a public key.
+func CheckSecp256k1Sig(w *io.BinWriter, key []byte) {
+	Int(w, secp256k1Keccak256)
+	Opcodes(w, opcode.SWAP)
+	Bytes(w, key)
+	// Network magic is converted to 4-byte LE representation, the number
+	// added is at least 5 bytes long and its first 4 bytes are the magic.
+	Syscall(w, interopnames.SystemRuntimeGetNetwork)
+	Int(w, 0x100000000)
+	Opcodes(w, opcode.ADD, opcode.PUSH4, opcode.LEFT)
+	Syscall(w, interopnames.SystemRuntimeGetScriptContainer)
+	Opcodes(w, opcode.PUSH0, opcode.PICKITEM, opcode.CAT, opcode.PUSH4, opcode.PACK)
+	AppCallNoArgs(w, nativehashes.CryptoLib, "verifyWithECDsa", callflag.NoneFlag)
+}
+
 func isInstructionJmp(op opcode.Opcode) bool {
 	return opcode.JMP <= op && op <= opcode.CALLL || op == opcode.ENDTRYL
 }
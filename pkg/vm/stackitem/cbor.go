@@ -0,0 +1,376 @@
+package stackitem
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	gio "io"
+	"math"
+	"math/big"
+	"slices"
+	"unicode/utf8"
+)
+
+// CBOR major types.
+const (
+	cborUint byte = iota << 5
+	cborNegInt
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+// CBOR additional information values and tags used.
+const (
+	cborFalse      = 20
+	cborTrue       = 21
+	cborNull       = 22
+	cborIndefinite = 31
+
+	cborTagPosBignum = 2
+	cborTagNegBignum = 3
+)
+
+// ErrInvalidCBOR is returned when CBOR data can't be decoded into Item. Only
+// the deterministic encoding (see ToCBOR) of the supported types is accepted.
+var ErrInvalidCBOR = errors.New("invalid CBOR")
+
+// cborDecoder is a CBOR decoder state.
+type cborDecoder struct {
+	data  []byte
+	limit int
+}
+
+// ToCBOR encodes Item to CBOR using the core deterministic encoding (RFC 8949,
+// section 4.2.1), so the same item always has the same encoding. It behaves as
+// following:
+//
+//	ByteString, Buffer -> byte string
+//	Integer -> unsigned/negative integer, bignum (tags 2/3) for values not fitting into 64 bits
+//	Bool -> true/false
+//	Null -> null
+//	Array, Struct -> array
+//	Map -> map with keys sorted by their encoding
+//
+// Interop and Pointer items can't be encoded, the resulting size is limited
+// by MaxSize.
+func ToCBOR(item Item) ([]byte, error) {
+	return toCBOR(nil, item, make(map[Item]sliceNoPointer, typicalNumOfItems))
+}
+
+func toCBOR(data []byte, item Item, seen map[Item]sliceNoPointer) ([]byte, error) {
+	if old, ok := seen[item]; ok {
+		if old.end == 0 {
+			// Compound item marshaling which has not yet finished.
+			return nil, ErrRecursive
+		}
+		if len(data)+old.end-old.start > MaxSize {
+			return nil, errTooBigSize
+		}
+		return append(data, data[old.start:old.end]...), nil
+	}
+
+	var start = len(data)
+
+	switch it := item.(type) {
+	case *ByteArray, *Buffer:
+		b := it.Value().([]byte)
+		data = appendCBORHead(data, cborBytes, uint64(len(b)))
+		data = append(data, b...)
+	case *BigInteger:
+		if err := CheckIntegerSize(it.Big()); err != nil {
+			return nil, err
+		}
+		data = appendCBORInt(data, it.Big())
+	case Bool:
+		if it {
+			data = append(data, cborSimple|cborTrue)
+		} else {
+			data = append(data, cborSimple|cborFalse)
+		}
+	case Null:
+		data = append(data, cborSimple|cborNull)
+	case *Array, *Struct:
+		var (
+			err error
+			arr = it.Value().([]Item)
+		)
+		seen[item] = sliceNoPointer{}
+		data = appendCBORHead(data, cborArray, uint64(len(arr)))
+		for _, elem := range arr {
+			data, err = toCBOR(data, elem, seen)
+			if err != nil {
+				return nil, err
+			}
+		}
+		seen[item] = sliceNoPointer{start: start, end: len(data)}
+	case *Map:
+		var (
+			err  error
+			keys = make([][]byte, len(it.value))
+			vals = make([]Item, len(it.value))
+			idx  = make([]int, len(it.value))
+		)
+		seen[item] = sliceNoPointer{}
+		for i := range it.value {
+			keys[i], err = toCBOR(nil, it.value[i].Key, seen)
+			if err != nil {
+				return nil, err
+			}
+			vals[i] = it.value[i].Value
+			idx[i] = i
+		}
+		slices.SortFunc(idx, func(a, b int) int {
+			return bytes.Compare(keys[a], keys[b])
+		})
+		data = appendCBORHead(data, cborMap, uint64(len(it.value)))
+		for _, i := range idx {
+			if len(data)+len(keys[i]) > MaxSize {
+				return nil, errTooBigSize
+			}
+			data = append(data, keys[i]...)
+			data, err = toCBOR(data, vals[i], seen)
+			if err != nil {
+				return nil, err
+			}
+		}
+		seen[item] = sliceNoPointer{start: start, end: len(data)}
+	default:
+		if item == nil {
+			return nil, fmt.Errorf("%w: nil", ErrUnserializable)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrUnserializable, item.Type())
+	}
+	if len(data) > MaxSize {
+		return nil, errTooBigSize
+	}
+	return data, nil
+}
+
+// appendCBORHead appends the shortest possible data item head with the given
+// major type and argument.
+func appendCBORHead(data []byte, major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return append(data, major|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(data, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(data, major|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(data, major|26), uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64(append(data, major|27), arg)
+	}
+}
+
+// appendCBORInt appends integer using bignum tags only for values that don't
+// fit into the integer major types.
+func appendCBORInt(data []byte, v *big.Int) []byte {
+	var (
+		major        = cborUint
+		tag   uint64 = cborTagPosBignum
+		n            = v
+	)
+	if v.Sign() < 0 {
+		major, tag = cborNegInt, cborTagNegBignum
+		n = new(big.Int).Not(v) // -1 - v
+	}
+	if n.IsUint64() {
+		return appendCBORHead(data, major, n.Uint64())
+	}
+	b := n.Bytes()
+	data = appendCBORHead(data, cborTag, tag)
+	data = appendCBORHead(data, cborBytes, uint64(len(b)))
+	return append(data, b...)
+}
+
+// FromCBOR decodes Item from CBOR data encoded with ToCBOR. Byte and (valid
+// UTF-8) text strings are decoded into ByteString, integers and bignums into
+// Integer, arrays into Array and maps into Map (with keys in their encoding
+// order). Non-shortest heads, indefinite lengths, unordered or duplicate map
+// keys, bignums fitting into 64 bits, floating point numbers, tags other than
+// bignums and simple values other than false, true and null are rejected.
+// limit restricts the maximum number of items decoded item can contain
+// (including itself), MaxDeserialized is used if non-positive limit is
+// specified.
+func FromCBOR(data []byte, limit int) (Item, error) {
+	d := cborDecoder{data: data, limit: MaxDeserialized}
+	if limit > 0 {
+		d.limit = limit
+	}
+	item, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	if len(d.data) != 0 {
+		return nil, fmt.Errorf("%w: unexpected trailing data", ErrInvalidCBOR)
+	}
+	return item, nil
+}
+
+// readHead reads data item head returning its major type and argument.
+func (d *cborDecoder) readHead() (byte, uint64, error) {
+	if len(d.data) == 0 {
+		return 0, 0, gio.ErrUnexpectedEOF
+	}
+	var (
+		major = d.data[0] & 0xe0
+		info  = d.data[0] & 0x1f
+		size  int
+		arg   uint64
+	)
+	switch {
+	case info < 24:
+		d.data = d.data[1:]
+		return major, uint64(info), nil
+	case info <= 27:
+		size = 1 << (info - 24)
+	case info == cborIndefinite:
+		return 0, 0, fmt.Errorf("%w: indefinite length", ErrInvalidCBOR)
+	default:
+		return 0, 0, fmt.Errorf("%w: reserved additional information %d", ErrInvalidCBOR, info)
+	}
+	if len(d.data) < 1+size {
+		return 0, 0, gio.ErrUnexpectedEOF
+	}
+	for _, b := range d.data[1 : 1+size] {
+		arg = arg<<8 | uint64(b)
+	}
+	d.data = d.data[1+size:]
+	// Simple values are encoded with one additional byte, but only those
+	// greater than 31 and they're not supported anyway.
+	if major != cborSimple && (arg < 24 || (size > 1 && arg < 1<<(4*size))) {
+		return 0, 0, fmt.Errorf("%w: non-shortest argument encoding", ErrInvalidCBOR)
+	}
+	return major, arg, nil
+}
+
+// readBytes reads byte or text string contents of the given length.
+func (d *cborDecoder) readBytes(n uint64) ([]byte, error) {
+	if n > MaxSize {
+		return nil, errTooBigSize
+	}
+	if uint64(len(d.data)) < n {
+		return nil, gio.ErrUnexpectedEOF
+	}
+	res := bytes.Clone(d.data[:n])
+	d.data = d.data[n:]
+	return res, nil
+}
+
+func (d *cborDecoder) decode() (Item, error) {
+	d.limit--
+	if d.limit < 0 {
+		return nil, errTooBigElements
+	}
+	var start = d.data
+	major, arg, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case cborUint:
+		return NewBigInteger(new(big.Int).SetUint64(arg)), nil
+	case cborNegInt:
+		n := new(big.Int).SetUint64(arg)
+		return NewBigInteger(n.Not(n)), nil
+	case cborBytes, cborText:
+		b, err := d.readBytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		if major == cborText && !utf8.Valid(b) {
+			return nil, fmt.Errorf("%w: invalid UTF-8 text string", ErrInvalidCBOR)
+		}
+		return NewByteArray(b), nil
+	case cborArray:
+		if arg > uint64(d.limit) {
+			return nil, errTooBigElements
+		}
+		arr := make([]Item, arg)
+		for i := range arr {
+			arr[i], err = d.decode()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return NewArray(arr), nil
+	case cborMap:
+		if arg > uint64(d.limit/2) {
+			return nil, errTooBigElements
+		}
+		var (
+			m       = NewMap()
+			lastKey []byte
+		)
+		for range arg {
+			keyStart := d.data
+			key, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			enc := keyStart[:len(keyStart)-len(d.data)]
+			if lastKey != nil && bytes.Compare(lastKey, enc) >= 0 {
+				return nil, fmt.Errorf("%w: unordered or duplicate map key", ErrInvalidCBOR)
+			}
+			lastKey = enc
+			if err = IsValidMapKey(key); err != nil {
+				return nil, err
+			}
+			if m.Index(key) >= 0 {
+				return nil, fmt.Errorf("%w: duplicate map key", ErrInvalidCBOR)
+			}
+			val, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			m.Add(key, val)
+		}
+		return m, nil
+	case cborTag:
+		if arg != cborTagPosBignum && arg != cborTagNegBignum {
+			return nil, fmt.Errorf("%w: unsupported tag %d", ErrInvalidCBOR, arg)
+		}
+		major, n, err := d.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if major != cborBytes {
+			return nil, fmt.Errorf("%w: bignum is not a byte string", ErrInvalidCBOR)
+		}
+		b, err := d.readBytes(n)
+		if err != nil {
+			return nil, err
+		}
+		v := new(big.Int).SetBytes(b)
+		if len(b) == 0 || b[0] == 0 || v.IsUint64() {
+			return nil, fmt.Errorf("%w: non-canonical bignum", ErrInvalidCBOR)
+		}
+		if arg == cborTagNegBignum {
+			v.Not(v)
+		}
+		if err = CheckIntegerSize(v); err != nil {
+			return nil, err
+		}
+		return NewBigInteger(v), nil
+	default: // cborSimple
+		if len(start)-len(d.data) != 1 {
+			return nil, fmt.Errorf("%w: unsupported simple value or float", ErrInvalidCBOR)
+		}
+		switch arg {
+		case cborFalse:
+			return NewBool(false), nil
+		case cborTrue:
+			return NewBool(true), nil
+		case cborNull:
+			return Null{}, nil
+		default:
+			return nil, fmt.Errorf("%w: unsupported simple value %d", ErrInvalidCBOR, arg)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package stackitem
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToFromCBOR(t *testing.T) {
+	bigPos, _ := new(big.Int).SetString("18446744073709551616", 10) // 2^64
+	bigNeg := new(big.Int).Neg(bigPos)
+	bigNeg.Sub(bigNeg, big.NewInt(1)) // -2^64 - 1
+
+	// Encodings are taken from RFC 8949, Appendix A.
+	testCases := []struct {
+		name string
+		item Item
+		enc  string
+	}{
+		{"zero", Make(0), "00"},
+		{"23", Make(23), "17"},
+		{"24", Make(24), "1818"},
+		{"1000", Make(1000), "1903e8"},
+		{"1000000", Make(1000000), "1a000f4240"},
+		{"1000000000000", Make(1000000000000), "1b000000e8d4a51000"},
+		{"-1", Make(-1), "20"},
+		{"-1000", Make(-1000), "3903e7"},
+		{"2^64", NewBigInteger(bigPos), "c249010000000000000000"},
+		{"-2^64-1", NewBigInteger(bigNeg), "c349010000000000000000"},
+		{"false", Make(false), "f4"},
+		{"true", Make(true), "f5"},
+		{"null", Null{}, "f6"},
+		{"empty bytes", Make([]byte{}), "40"},
+		{"bytes", Make([]byte{1, 2, 3, 4}), "4401020304"},
+		{"empty array", Make([]Item{}), "80"},
+		{"array", Make([]Item{Make(1), Make([]Item{Make(2), Make(3)})}), "8201820203"},
+		{"empty map", NewMap(), "a0"},
+		{"map", NewMapWithValue([]MapElement{
+			{Key: Make(1), Value: Make(2)},
+			{Key: Make(3), Value: Make(4)},
+		}), "a201020304"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := ToCBOR(tc.item)
+			require.NoError(t, err)
+			require.Equal(t, tc.enc, hex.EncodeToString(data))
+
+			actual, err := FromCBOR(data, 0)
+			require.NoError(t, err)
+			require.Equal(t, tc.item, actual)
+		})
+	}
+
+	t.Run("deterministic map", func(t *testing.T) {
+		m := NewMapWithValue([]MapElement{
+			{Key: Make("b"), Value: Make(1)},
+			{Key: Make(true), Value: Make(2)},
+			{Key: Make(100), Value: Make(3)},
+			{Key: Make("a"), Value: Make(4)},
+			{Key: Make(-1), Value: Make(5)},
+		})
+		data, err := ToCBOR(m)
+		require.NoError(t, err)
+		require.Equal(t, "a5"+"1864"+"03"+"20"+"05"+"4161"+"04"+"4162"+"01"+"f5"+"02", hex.EncodeToString(data))
+	})
+	t.Run("struct and buffer", func(t *testing.T) {
+		data, err := ToCBOR(NewStruct([]Item{NewBuffer([]byte{1}), Make([]byte{1})}))
+		require.NoError(t, err)
+		require.Equal(t, "82"+"4101"+"4101", hex.EncodeToString(data))
+	})
+	t.Run("shared item", func(t *testing.T) {
+		shared := Make([]Item{Make(1)})
+		data, err := ToCBOR(Make([]Item{shared, shared}))
+		require.NoError(t, err)
+		require.Equal(t, "82"+"8101"+"8101", hex.EncodeToString(data))
+	})
+	t.Run("text string", func(t *testing.T) {
+		item, err := FromCBOR([]byte{0x62, 'h', 'i'}, 0)
+		require.NoError(t, err)
+		require.Equal(t, Make("hi"), item)
+	})
+}
+
+func TestToCBORBadCases(t *testing.T) {
+	t.Run("recursive", func(t *testing.T) {
+		arr := NewArray(nil)
+		arr.value = []Item{arr}
+		_, err := ToCBOR(arr)
+		require.ErrorIs(t, err, ErrRecursive)
+
+		m := NewMap()
+		m.Add(Make(1), m)
+		_, err = ToCBOR(m)
+		require.ErrorIs(t, err, ErrRecursive)
+	})
+	t.Run("unserializable", func(t *testing.T) {
+		for _, item := range []Item{nil, NewInterop(nil), NewPointer(0, nil)} {
+			_, err := ToCBOR(Make([]Item{item}))
+			require.ErrorIs(t, err, ErrUnserializable)
+		}
+	})
+	t.Run("big integer", func(t *testing.T) {
+		_, err := ToCBOR((*BigInteger)(new(big.Int).Lsh(big.NewInt(1), MaxBigIntegerSizeBits)))
+		require.ErrorIs(t, err, ErrTooBig)
+	})
+	t.Run("too big", func(t *testing.T) {
+		b := NewBuffer(make([]byte, MaxSize/2))
+		_, err := ToCBOR(Make([]Item{b, b}))
+		require.ErrorIs(t, err, errTooBigSize)
+	})
+}
+
+func TestFromCBORBadCases(t *testing.T) {
+	testCases := map[string]string{
+		"empty":              "",
+		"trailing data":      "0000",
+		"non-shortest int":   "1817",
+		"non-shortest len":   "590001" + "00",
+		"indefinite array":   "9fff",
+		"reserved info":      "1c",
+		"truncated head":     "19",
+		"truncated bytes":    "4301",
+		"truncated array":    "8201",
+		"invalid text":       "61ff",
+		"float":              "f93c00",
+		"undefined":          "f7",
+		"simple value":       "f820",
+		"unknown tag":        "c000",
+		"bignum not bytes":   "c200",
+		"short bignum":       "c24101",
+		"bignum zero prefix": "c249000100000000000000",
+		"unordered map":      "a2030401f5",
+		"duplicate map key":  "a201020103",
+		"text and bytes key": "a2416101616102",
+		"invalid map key":    "a180f5",
+		"too big integer":    "c25821" + "01" + "0000000000000000000000000000000000000000000000000000000000000000",
+	}
+	for name, enc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data, err := hex.DecodeString(enc)
+			require.NoError(t, err)
+			_, err = FromCBOR(data, 0)
+			require.Error(t, err)
+		})
+	}
+	t.Run("limit", func(t *testing.T) {
+		data, err := ToCBOR(Make([]Item{Make(1), Make(2)}))
+		require.NoError(t, err)
+		_, err = FromCBOR(data, 3)
+		require.NoError(t, err)
+		_, err = FromCBOR(data, 2)
+		require.ErrorIs(t, err, errTooBigElements)
+	})
+}
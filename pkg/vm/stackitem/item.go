@@ -20,6 +20,11 @@ import (
 const (
 	// MaxBigIntegerSizeBits is the maximum size of a BigInt item in bits.
 	MaxBigIntegerSizeBits = 32 * 8
+	// MaxExtendedIntegerSizeBits is the maximum size of a BigInt item in bits
+	// that can be produced by VM with extended integer size limit (see
+	// vm.VM.SetMaxIntegerSize). Such items can only be a part of execution
+	// results, they're rejected by regular serialization.
+	MaxExtendedIntegerSizeBits = 128 * 8
 	// MaxSize is the maximum item size allowed in the VM.
 	MaxSize = math.MaxUint16 * 2
 	// MaxComparableNumOfItems is the maximum number of items that can be compared for structs.
@@ -449,19 +454,25 @@ func NewBigInteger(value *big.Int) *BigInteger {
 
 // CheckIntegerSize checks that the value size doesn't exceed the VM limit for Interer.
 func CheckIntegerSize(value *big.Int) error {
+	return CheckIntegerSizeLimit(value, MaxBigIntegerSizeBits)
+}
+
+// CheckIntegerSizeLimit checks that the value size doesn't exceed the given
+// limit (in bits) for Integer.
+func CheckIntegerSizeLimit(value *big.Int, maxBits int) error {
 	// There are 2 cases when `BitLen` differs from the actual size:
 	// 1. Positive integer with the highest bit on byte boundary = 1.
 	// 2. Negative integer with the highest bit on byte boundary = 1
 	//    minus some value. (-0x80 -> 0x80, -0x7F -> 0x81, -0x81 -> 0x7FFF).
 	sz := value.BitLen()
 	// This check is not required, just an optimization for the common case.
-	if sz < MaxBigIntegerSizeBits {
+	if sz < maxBits {
 		return nil
 	}
-	if sz > MaxBigIntegerSizeBits {
+	if sz > maxBits {
 		return errTooBigInteger
 	}
-	if value.Sign() == 1 || value.TrailingZeroBits() != MaxBigIntegerSizeBits-1 {
+	if value.Sign() == 1 || value.TrailingZeroBits() != uint(maxBits-1) {
 		return errTooBigInteger
 	}
 	return nil
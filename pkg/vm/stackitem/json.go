@@ -320,10 +320,21 @@ func (d *decoder) decodeMap() (*Map, error) {
 
 // ToJSONWithTypes serializes any stackitem to JSON in a lossless way.
 func ToJSONWithTypes(item Item) ([]byte, error) {
-	return toJSONWithTypes(nil, item, make(map[Item]sliceNoPointer, typicalNumOfItems))
+	return toJSONWithTypes(nil, item, make(map[Item]sliceNoPointer, typicalNumOfItems), false, MaxSize)
 }
 
-func toJSONWithTypes(data []byte, item Item, seen map[Item]sliceNoPointer) ([]byte, error) {
+// ToJSONWithTypesStrict serializes stackitem to JSON the same way as
+// ToJSONWithTypes does, but it also applies all restrictions of the C#
+// implementation, so that the result (if any) is exactly the same as the one
+// produced by it. Compared to ToJSONWithTypes, it fails if the resulting JSON
+// is longer than maxSize, if an Array, Struct or Map is referenced more than
+// once (even if it's not a recursive reference) and for integers that don't
+// fit into MaxBigIntegerSizeBits.
+func ToJSONWithTypesStrict(item Item, maxSize int) ([]byte, error) {
+	return toJSONWithTypes(nil, item, make(map[Item]sliceNoPointer, typicalNumOfItems), true, maxSize)
+}
+
+func toJSONWithTypes(data []byte, item Item, seen map[Item]sliceNoPointer, strict bool, maxSize int) ([]byte, error) {
 	if item == nil {
 		return nil, fmt.Errorf("%w: nil", ErrUnserializable)
 	}
@@ -332,7 +343,10 @@ func toJSONWithTypes(data []byte, item Item, seen map[Item]sliceNoPointer) ([]by
 			// Compound item marshaling which has not yet finished.
 			return nil, ErrRecursive
 		}
-		if len(data)+old.end-old.start > MaxSize {
+		if _, isBuf := item.(*Buffer); strict && !isBuf {
+			return nil, fmt.Errorf("%w: %s is referenced more than once", ErrUnserializable, item.Type())
+		}
+		if len(data)+old.end-old.start > maxSize {
 			return nil, errTooBigSize
 		}
 		return append(data, data[old.start:old.end]...), nil
@@ -350,7 +364,7 @@ func toJSONWithTypes(data []byte, item Item, seen map[Item]sliceNoPointer) ([]by
 		hasValue = true
 	}
 
-	if len(data)+len(val) > MaxSize {
+	if len(data)+len(val) > maxSize {
 		return nil, errTooBigSize
 	}
 
@@ -375,7 +389,7 @@ func toJSONWithTypes(data []byte, item Item, seen map[Item]sliceNoPointer) ([]by
 			if i != 0 {
 				data = append(data, ',')
 			}
-			data, err = toJSONWithTypes(data, elem, seen)
+			data, err = toJSONWithTypes(data, elem, seen, strict, maxSize)
 			if err != nil {
 				return nil, err
 			}
@@ -392,6 +406,11 @@ func toJSONWithTypes(data []byte, item Item, seen map[Item]sliceNoPointer) ([]by
 		isBuffer = true
 		primitive = `"` + base64.StdEncoding.EncodeToString(it.Value().([]byte)) + `"`
 	case *BigInteger:
+		if strict {
+			if err := CheckIntegerSizeLimit(it.Big(), MaxBigIntegerSizeBits); err != nil {
+				return nil, err
+			}
+		}
 		primitive = `"` + it.Big().String() + `"`
 	case *Map:
 		seen[item] = sliceNoPointer{}
@@ -401,12 +420,12 @@ func toJSONWithTypes(data []byte, item Item, seen map[Item]sliceNoPointer) ([]by
 				data = append(data, ',')
 			}
 			data = append(data, `{"key":`...)
-			data, err = toJSONWithTypes(data, it.value[i].Key, seen)
+			data, err = toJSONWithTypes(data, it.value[i].Key, seen, strict, maxSize)
 			if err != nil {
 				return nil, err
 			}
 			data = append(data, `,"value":`...)
-			data, err = toJSONWithTypes(data, it.value[i].Value, seen)
+			data, err = toJSONWithTypes(data, it.value[i].Value, seen, strict, maxSize)
 			if err != nil {
 				return nil, err
 			}
@@ -416,7 +435,7 @@ func toJSONWithTypes(data []byte, item Item, seen map[Item]sliceNoPointer) ([]by
 		primitive = strconv.Itoa(it.pos)
 	}
 	if len(primitive) != 0 {
-		if len(data)+len(primitive)+1 > MaxSize {
+		if len(data)+len(primitive)+1 > maxSize {
 			return nil, errTooBigSize
 		}
 		data = append(data, primitive...)
@@ -426,7 +445,7 @@ func toJSONWithTypes(data []byte, item Item, seen map[Item]sliceNoPointer) ([]by
 			seen[item] = sliceNoPointer{start: start, end: len(data)}
 		}
 	} else {
-		if len(data)+2 > MaxSize { // also take care of '}'
+		if len(data)+2 > maxSize { // also take care of '}'
 			return nil, errTooBigSize
 		}
 		data = append(data, ']', '}')
@@ -486,7 +505,12 @@ func FromJSONWithTypes(data []byte) (Item, error) {
 		if !ok {
 			return nil, mkErrValue(errors.New("not an integer"))
 		}
-		return NewBigInteger(val), nil
+		// Execution results can contain integers produced by VM with
+		// extended integer size limit.
+		if err := CheckIntegerSizeLimit(val, MaxExtendedIntegerSizeBits); err != nil {
+			return nil, mkErrValue(err)
+		}
+		return (*BigInteger)(val), nil
 	case ByteArrayT, BufferT:
 		var s string
 		if err := json.Unmarshal(raw.Value, &s); err != nil {
@@ -436,6 +436,47 @@ func TestToJSONWithTypesBadCases(t *testing.T) {
 	})
 }
 
+func TestToJSONWithTypesStrict(t *testing.T) {
+	t.Run("same as lossless", func(t *testing.T) {
+		m := NewMapWithValue([]MapElement{{Key: NewBigInteger(big.NewInt(42)), Value: NewBool(false)}})
+		buf := NewBuffer([]byte{1, 2, 3})
+		item := NewArray([]Item{Null{}, Make(-7), Make("str"), buf, buf, m, NewStruct([]Item{Make(true)}), NewInterop(nil)})
+		expected, err := ToJSONWithTypes(item)
+		require.NoError(t, err)
+		actual, err := ToJSONWithTypesStrict(item, MaxSize)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+	t.Run("shared compound", func(t *testing.T) {
+		for _, shared := range []Item{NewArray(nil), NewStruct(nil), NewMap()} {
+			_, err := ToJSONWithTypesStrict(NewArray([]Item{shared, shared}), MaxSize)
+			require.ErrorIs(t, err, ErrUnserializable, shared.Type())
+		}
+	})
+	t.Run("recursive", func(t *testing.T) {
+		arr := NewArray(nil)
+		arr.value = []Item{arr}
+		_, err := ToJSONWithTypesStrict(arr, MaxSize)
+		require.ErrorIs(t, err, ErrRecursive)
+	})
+	t.Run("big integer", func(t *testing.T) {
+		// Can't be created with NewBigInteger, but nothing prevents direct
+		// conversion.
+		bi := (*BigInteger)(new(big.Int).Lsh(big.NewInt(1), MaxBigIntegerSizeBits))
+		_, err := ToJSONWithTypes(bi)
+		require.NoError(t, err)
+		_, err = ToJSONWithTypesStrict(bi, MaxSize)
+		require.ErrorIs(t, err, ErrTooBig)
+	})
+	t.Run("size limit", func(t *testing.T) {
+		item := Make([]byte{1, 2, 3})
+		data, err := ToJSONWithTypesStrict(item, 40)
+		require.NoError(t, err)
+		_, err = ToJSONWithTypesStrict(item, len(data)-1)
+		require.ErrorIs(t, err, errTooBigSize)
+	})
+}
+
 func TestFromJSONWithTypes(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -446,6 +487,8 @@ func TestFromJSONWithTypes(t *testing.T) {
 		{"Interop", `{"type":"InteropInterface"}`, NewInterop(nil)},
 		{"Null", `{"type":"Any"}`, Null{}},
 		{"Array", `{"type":"Array","value":[{"type":"Any"}]}`, NewArray([]Item{Null{}})},
+		{"ExtendedInteger", `{"type":"Integer","value":"` + new(big.Int).Lsh(big.NewInt(1), MaxBigIntegerSizeBits).String() + `"}`,
+			(*BigInteger)(new(big.Int).Lsh(big.NewInt(1), MaxBigIntegerSizeBits))},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -464,6 +507,7 @@ func TestFromJSONWithTypes(t *testing.T) {
 			{"UnexpectedType", `{"type":"int","value":"4"}`},
 			{"IntegerValue1", `{"type":"Integer","value": 4}`},
 			{"IntegerValue2", `{"type":"Integer","value": "a"}`},
+			{"IntegerValue3", `{"type":"Integer","value":"` + new(big.Int).Lsh(big.NewInt(1), MaxExtendedIntegerSizeBits).String() + `"}`},
 			{"BoolValue", `{"type":"Boolean","value": "str"}`},
 			{"PointerValue", `{"type":"Pointer","value": "str"}`},
 			{"BufferValue1", `{"type":"Buffer","value":"not a base 64"}`},
@@ -182,6 +182,9 @@ func (w *SerializationContext) serialize(item Item) error {
 			w.data = append(w.data, 0)
 		}
 	case *BigInteger:
+		if !w.allowInvalid && CheckIntegerSize((*big.Int)(t)) != nil {
+			return errTooBigInteger
+		}
 		w.data = append(w.data, byte(IntegerT))
 		ln := len(w.data)
 		w.data = append(w.data, 0)
@@ -325,12 +328,20 @@ func (r *deserContext) decodeBinary() Item {
 		var b = r.ReadBool()
 		return NewBool(b)
 	case IntegerT:
-		data := r.ReadVarBytes(bigint.MaxBytesLen)
-		num := bigint.FromBytes(data)
-		return NewBigInteger(num)
+		maxLen := bigint.MaxBytesLen
+		if r.allowInvalid {
+			// Execution results can contain integers produced by VM with
+			// extended integer size limit.
+			maxLen = MaxExtendedIntegerSizeBits / 8
+		}
+		data := r.ReadVarBytes(maxLen)
+		if r.Err != nil {
+			return nil
+		}
+		return (*BigInteger)(bigint.FromBytes(data))
 	case ArrayT, StructT:
-		size := int(r.ReadVarUint())
-		if size > r.limit {
+		size := r.ReadVarUint()
+		if size > uint64(r.limit) {
 			r.Err = errTooBigElements
 			return nil
 		}
@@ -344,8 +355,8 @@ func (r *deserContext) decodeBinary() Item {
 		}
 		return NewStruct(arr)
 	case MapT:
-		size := int(r.ReadVarUint())
-		if size > r.limit/2 {
+		size := r.ReadVarUint()
+		if size > uint64(r.limit/2) {
 			r.Err = errTooBigElements
 			return nil
 		}
@@ -356,6 +367,10 @@ func (r *deserContext) decodeBinary() Item {
 			if r.Err != nil {
 				break
 			}
+			if err := IsValidMapKey(key); err != nil {
+				r.Err = err
+				break
+			}
 			m.Add(key, value)
 		}
 		return m
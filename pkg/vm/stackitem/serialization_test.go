@@ -1,6 +1,7 @@
 package stackitem
 
 import (
+	"math/big"
 	"strconv"
 	"testing"
 
@@ -244,6 +245,26 @@ func TestSerializeLimited(t *testing.T) {
 	})
 }
 
+func TestSerializeExtendedInteger(t *testing.T) {
+	item := (*BigInteger)(new(big.Int).Lsh(big.NewInt(1), MaxBigIntegerSizeBits))
+
+	_, err := Serialize(item)
+	require.ErrorIs(t, err, errTooBigInteger)
+
+	w := io.NewBufBinWriter()
+	EncodeBinaryProtected(item, w.BinWriter)
+	require.NoError(t, w.Err)
+	data := w.Bytes()
+
+	_, err = Deserialize(data)
+	require.Error(t, err)
+
+	r := io.NewBinReaderFromBuf(data)
+	actual := DecodeBinaryProtected(r)
+	require.NoError(t, r.Err)
+	require.Equal(t, item, actual)
+}
+
 func TestEmptyDeserialization(t *testing.T) {
 	empty := []byte{}
 	_, err := Deserialize(empty)
@@ -261,6 +282,11 @@ func TestMapDeserializationError(t *testing.T) {
 	require.NoError(t, w.Err)
 	_, err := Deserialize(w.Bytes())
 	require.ErrorIs(t, err, ErrInvalidType)
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := Deserialize([]byte{byte(MapT), 1, byte(AnyT), byte(AnyT)})
+		require.Error(t, err)
+	})
 }
 
 func TestDeserializeTooManyElements(t *testing.T) {
@@ -278,6 +304,14 @@ func TestDeserializeTooManyElements(t *testing.T) {
 	require.NoError(t, err)
 	_, err = Deserialize(data)
 	require.ErrorIs(t, err, ErrTooBig)
+
+	t.Run("huge size", func(t *testing.T) {
+		for _, typ := range []Type{ArrayT, StructT, MapT} {
+			data := append([]byte{byte(typ), 0xff}, 0, 0, 0, 0, 0, 0, 0, 0x80)
+			_, err := Deserialize(data)
+			require.ErrorIs(t, err, ErrTooBig, typ)
+		}
+	})
 }
 
 func TestDeserializeLimited(t *testing.T) {
@@ -57,8 +57,6 @@ const (
 	// MaxStackSize is the maximum number of items allowed to be
 	// on all stacks at once.
 	MaxStackSize = 2 * 1024
-
-	maxSHLArg = stackitem.MaxBigIntegerSizeBits
 )
 
 // SyscallHandler is a type for syscall handler.
@@ -68,9 +66,29 @@ type SyscallHandler = func(*VM, uint32) error
 // before each instruction is executed.
 type OnExecHook = func(scriptHash util.Uint160, offset int, opcode opcode.Opcode)
 
+// BreakCondition is a type for a callback that is invoked with the current
+// context after each instruction executed by Run or StepInto (and thus
+// StepOut and StepOver), VM stops in BREAK state if it returns true.
+type BreakCondition = func(ctx *Context) bool
+
 // A struct that contains all VM hooks.
 type hooks struct {
-	onExec OnExecHook
+	onExec    OnExecHook
+	breakCond BreakCondition
+}
+
+// StackStats contains the peak stack usage of the VM during execution, all
+// values are the maximums reached after any of the executed instructions.
+type StackStats struct {
+	// InvocationDepth is the number of contexts on the invocation stack, it's
+	// limited by MaxInvocationStackSize.
+	InvocationDepth int
+	// EvaluationDepth is the number of items on the evaluation stack of the
+	// current context.
+	EvaluationDepth int
+	// Items is the number of stack items referenced from all stacks and slots
+	// (including compound item elements), it's limited by MaxStackSize.
+	Items int
 }
 
 // VM represents the virtual machine.
@@ -90,6 +108,12 @@ type VM struct {
 	gasConsumed int64
 	GasLimit    int64
 
+	// steps is the number of instructions executed.
+	steps uint64
+
+	// stackStats is the peak stack usage.
+	stackStats StackStats
+
 	// SyscallHandler handles SYSCALL opcode.
 	SyscallHandler func(v *VM, id uint32) error
 
@@ -101,6 +125,10 @@ type VM struct {
 	// invTree is a top-level invocation tree (if enabled).
 	invTree *invocations.Tree
 
+	// maxIntegerSizeBits is the maximum size of Integer produced by
+	// arithmetic instructions.
+	maxIntegerSizeBits int
+
 	// All registered hooks.
 	hooks hooks
 }
@@ -120,8 +148,9 @@ func New() *VM {
 // NewWithTrigger returns a new VM for executions triggered by t.
 func NewWithTrigger(t trigger.Type) *VM {
 	vm := &VM{
-		state:   vmstate.None,
-		trigger: t,
+		state:              vmstate.None,
+		trigger:            t,
+		maxIntegerSizeBits: stackitem.MaxBigIntegerSizeBits,
 	}
 
 	vm.istack = make([]*Context, 0, 8) // Most of invocations use one-two contracts, but they're likely to have internal calls.
@@ -139,6 +168,31 @@ func (v *VM) SetOnExecHook(hook OnExecHook) {
 	v.hooks.onExec = hook
 }
 
+// SetBreakCondition sets the BreakCondition which is checked along with
+// the breakpoints of the current context. Unlike other hooks, it can be
+// changed at any time, which allows debuggers to manage conditional
+// breakpoints between executions.
+func (v *VM) SetBreakCondition(cond BreakCondition) {
+	v.hooks.breakCond = cond
+}
+
+// SetMaxIntegerSize sets the maximum size (in bits) of Integer values produced
+// by arithmetic instructions, the default is stackitem.MaxBigIntegerSizeBits.
+// It also limits SHL/SHR shifts and POW exponents. Conversions from ByteString
+// and Buffer as well as serialization are not affected by this setting. It's
+// intended for private networks only, the limit must be between the default
+// one and stackitem.MaxExtendedIntegerSizeBits. This function panics if the VM
+// has been started.
+func (v *VM) SetMaxIntegerSize(bits int) {
+	if v.state != vmstate.None {
+		panic("Cannot set max integer size of a started VM")
+	}
+	if bits < stackitem.MaxBigIntegerSizeBits || bits > stackitem.MaxExtendedIntegerSizeBits {
+		panic(fmt.Sprintf("max integer size must be between %d and %d bits", stackitem.MaxBigIntegerSizeBits, stackitem.MaxExtendedIntegerSizeBits))
+	}
+	v.maxIntegerSizeBits = bits
+}
+
 // SetPriceGetter registers the given PriceGetterFunc in v.
 // f accepts vm's Context, current instruction and instruction parameter.
 func (v *VM) SetPriceGetter(f func(opcode.Opcode, []byte) int64) {
@@ -157,10 +211,22 @@ func (v *VM) Reset(t trigger.Type) {
 	v.refs = 0
 	v.gasConsumed = 0
 	v.GasLimit = 0
+	v.steps = 0
+	v.stackStats = StackStats{}
 	v.SyscallHandler = nil
 	v.LoadToken = nil
 	v.trigger = t
 	v.invTree = nil
+	v.maxIntegerSizeBits = stackitem.MaxBigIntegerSizeBits
+}
+
+// newBigInteger returns Integer stack item for the given value checking it
+// against the VM integer size limit. It panics if the limit is exceeded.
+func (v *VM) newBigInteger(n *big.Int) *stackitem.BigInteger {
+	if err := stackitem.CheckIntegerSizeLimit(n, v.maxIntegerSizeBits); err != nil {
+		panic(err)
+	}
+	return (*stackitem.BigInteger)(n)
 }
 
 // GasConsumed returns the amount of GAS consumed during execution.
@@ -318,6 +384,7 @@ func (v *VM) LoadWithFlags(prog []byte, f callflag.CallFlag) {
 	v.estack.Clear()
 	v.state = vmstate.None
 	v.gasConsumed = 0
+	v.stackStats = StackStats{}
 	v.invTree = nil
 	v.LoadScriptWithFlags(prog, f)
 }
@@ -482,7 +549,7 @@ func (v *VM) Run() error {
 		}
 		// check for breakpoint before executing the next instruction
 		ctx = v.Context()
-		if ctx != nil && ctx.atBreakPoint() {
+		if v.atBreakPoint(ctx) {
 			v.state = vmstate.Break
 		}
 	}
@@ -506,6 +573,7 @@ func (v *VM) step(ctx *Context) error {
 		v.state = vmstate.Fault
 		return newError(ctx.ip, op, err)
 	}
+	v.steps++
 	return v.execute(ctx, op, param)
 }
 
@@ -523,24 +591,29 @@ func (v *VM) StepInto() error {
 	}
 
 	if ctx != nil && ctx.sc.prog != nil {
-		op, param, err := ctx.Next()
+		err := v.step(ctx)
 		if err != nil {
-			v.state = vmstate.Fault
-			return newError(ctx.ip, op, err)
-		}
-		vErr := v.execute(ctx, op, param)
-		if vErr != nil {
-			return vErr
+			return err
 		}
 	}
 
-	cctx := v.Context()
-	if cctx != nil && cctx.atBreakPoint() {
+	if v.atBreakPoint(v.Context()) {
 		v.state = vmstate.Break
 	}
 	return nil
 }
 
+// atBreakPoint checks whether the given context is at breakpoint or the break
+// condition is met.
+func (v *VM) atBreakPoint(ctx *Context) bool {
+	if ctx == nil {
+		return false
+	}
+	// Break condition is always checked, it can have side effects.
+	cond := v.hooks.breakCond != nil && v.hooks.breakCond(ctx)
+	return ctx.atBreakPoint() || cond
+}
+
 // StepOut takes the debugger to the line where the current function was called.
 func (v *VM) StepOut() error {
 	var err error
@@ -606,6 +679,18 @@ func (v *VM) AtBreakpoint() bool {
 	return v.state.HasFlag(vmstate.Break)
 }
 
+// StackStats returns the peak stack usage since the program was loaded.
+func (v *VM) StackStats() StackStats {
+	return v.stackStats
+}
+
+// updateStackStats updates the peak stack usage with the current one.
+func (v *VM) updateStackStats() {
+	v.stackStats.InvocationDepth = max(v.stackStats.InvocationDepth, len(v.istack))
+	v.stackStats.EvaluationDepth = max(v.stackStats.EvaluationDepth, v.estack.Len())
+	v.stackStats.Items = max(v.stackStats.Items, int(v.refs))
+}
+
 // GetInteropID converts instruction parameter to an interop ID.
 func GetInteropID(parameter []byte) uint32 {
 	return binary.LittleEndian.Uint32(parameter)
@@ -616,6 +701,7 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 	// Instead of polluting the whole VM logic with error handling, we will recover
 	// each panic at a central point, putting the VM in a fault state and setting error.
 	defer func() {
+		v.updateStackStats()
 		if errRecover := recover(); errRecover != nil {
 			v.state = vmstate.Fault
 			err = newError(ctx.ip, op, errRecover)
@@ -923,22 +1009,22 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 	// Bit operations.
 	case opcode.INVERT:
 		i := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Not(i)))
+		v.estack.PushItem(v.newBigInteger(new(big.Int).Not(i)))
 
 	case opcode.AND:
 		b := v.estack.Pop().BigInt()
 		a := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).And(b, a)))
+		v.estack.PushItem(v.newBigInteger(new(big.Int).And(b, a)))
 
 	case opcode.OR:
 		b := v.estack.Pop().BigInt()
 		a := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Or(b, a)))
+		v.estack.PushItem(v.newBigInteger(new(big.Int).Or(b, a)))
 
 	case opcode.XOR:
 		b := v.estack.Pop().BigInt()
 		a := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Xor(b, a)))
+		v.estack.PushItem(v.newBigInteger(new(big.Int).Xor(b, a)))
 
 	case opcode.EQUAL, opcode.NOTEQUAL:
 		if v.estack.Len() < 2 {
@@ -952,66 +1038,66 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 	// Numeric operations.
 	case opcode.SIGN:
 		x := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(big.NewInt(int64(x.Sign()))))
+		v.estack.PushItem(v.newBigInteger(big.NewInt(int64(x.Sign()))))
 
 	case opcode.ABS:
 		x := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Abs(x)))
+		v.estack.PushItem(v.newBigInteger(new(big.Int).Abs(x)))
 
 	case opcode.NEGATE:
 		x := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Neg(x)))
+		v.estack.PushItem(v.newBigInteger(new(big.Int).Neg(x)))
 
 	case opcode.INC:
 		x := v.estack.Pop().BigInt()
 		a := new(big.Int).Add(x, bigOne)
-		v.estack.PushItem(stackitem.NewBigInteger(a))
+		v.estack.PushItem(v.newBigInteger(a))
 
 	case opcode.DEC:
 		x := v.estack.Pop().BigInt()
 		a := new(big.Int).Sub(x, bigOne)
-		v.estack.PushItem(stackitem.NewBigInteger(a))
+		v.estack.PushItem(v.newBigInteger(a))
 
 	case opcode.ADD:
 		a := v.estack.Pop().BigInt()
 		b := v.estack.Pop().BigInt()
 
 		c := new(big.Int).Add(a, b)
-		v.estack.PushItem(stackitem.NewBigInteger(c))
+		v.estack.PushItem(v.newBigInteger(c))
 
 	case opcode.SUB:
 		b := v.estack.Pop().BigInt()
 		a := v.estack.Pop().BigInt()
 
 		c := new(big.Int).Sub(a, b)
-		v.estack.PushItem(stackitem.NewBigInteger(c))
+		v.estack.PushItem(v.newBigInteger(c))
 
 	case opcode.MUL:
 		a := v.estack.Pop().BigInt()
 		b := v.estack.Pop().BigInt()
 
 		c := new(big.Int).Mul(a, b)
-		v.estack.PushItem(stackitem.NewBigInteger(c))
+		v.estack.PushItem(v.newBigInteger(c))
 
 	case opcode.DIV:
 		b := v.estack.Pop().BigInt()
 		a := v.estack.Pop().BigInt()
 
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Quo(a, b)))
+		v.estack.PushItem(v.newBigInteger(new(big.Int).Quo(a, b)))
 
 	case opcode.MOD:
 		b := v.estack.Pop().BigInt()
 		a := v.estack.Pop().BigInt()
 
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Rem(a, b)))
+		v.estack.PushItem(v.newBigInteger(new(big.Int).Rem(a, b)))
 
 	case opcode.POW:
 		exp := v.estack.Pop().BigInt()
 		a := v.estack.Pop().BigInt()
-		if ei := exp.Uint64(); !exp.IsUint64() || ei > maxSHLArg {
+		if ei := exp.Uint64(); !exp.IsUint64() || ei > uint64(v.maxIntegerSizeBits) {
 			panic("invalid exponent")
 		}
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Exp(a, exp, nil)))
+		v.estack.PushItem(v.newBigInteger(new(big.Int).Exp(a, exp, nil)))
 
 	case opcode.SQRT:
 		a := v.estack.Pop().BigInt()
@@ -1019,7 +1105,7 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 			panic("negative value")
 		}
 
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Sqrt(a)))
+		v.estack.PushItem(v.newBigInteger(new(big.Int).Sqrt(a)))
 
 	case opcode.MODMUL:
 		modulus := v.estack.Pop().BigInt()
@@ -1030,7 +1116,7 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		x1 := v.estack.Pop().BigInt()
 
 		res := new(big.Int).Mul(x1, x2)
-		v.estack.PushItem(stackitem.NewBigInteger(res.Rem(res, modulus)))
+		v.estack.PushItem(v.newBigInteger(res.Rem(res, modulus)))
 
 	case opcode.MODPOW:
 		modulus := v.estack.Pop().BigInt()
@@ -1063,14 +1149,14 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 			}
 		}
 
-		v.estack.PushItem(stackitem.NewBigInteger(res))
+		v.estack.PushItem(v.newBigInteger(res))
 
 	case opcode.SHL, opcode.SHR:
 		b := toInt(v.estack.Pop().BigInt())
 		if b == 0 {
 			return
-		} else if b < 0 || b > maxSHLArg {
-			panic(fmt.Sprintf("operand must be between %d and %d", 0, maxSHLArg))
+		} else if b < 0 || b > v.maxIntegerSizeBits {
+			panic(fmt.Sprintf("operand must be between %d and %d", 0, v.maxIntegerSizeBits))
 		}
 		a := v.estack.Pop().BigInt()
 
@@ -1081,7 +1167,7 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 			item.Rsh(a, uint(b))
 		}
 
-		v.estack.PushItem(stackitem.NewBigInteger(&item))
+		v.estack.PushItem(v.newBigInteger(&item))
 
 	case opcode.NOT:
 		x := v.estack.Pop().Bool()
@@ -1141,7 +1227,7 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		if a.Cmp(b) == 1 {
 			val = b
 		}
-		v.estack.PushItem(stackitem.NewBigInteger(val))
+		v.estack.PushItem(v.newBigInteger(val))
 
 	case opcode.MAX:
 		b := v.estack.Pop().BigInt()
@@ -1150,7 +1236,7 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		if a.Cmp(b) == -1 {
 			val = b
 		}
-		v.estack.PushItem(stackitem.NewBigInteger(val))
+		v.estack.PushItem(v.newBigInteger(val))
 
 	case opcode.WITHIN:
 		b := v.estack.Pop().BigInt()
@@ -721,7 +721,7 @@ func TestPOW(t *testing.T) {
 	t.Run("good, negative, odd", getTestFuncForVM(prog, -8, -2, 3))
 	t.Run("zero", getTestFuncForVM(prog, 1, 3, 0))
 	t.Run("negative exponent", getTestFuncForVM(prog, nil, 3, -1))
-	t.Run("too big exponent", getTestFuncForVM(prog, nil, 1, maxSHLArg+1))
+	t.Run("too big exponent", getTestFuncForVM(prog, nil, 1, stackitem.MaxBigIntegerSizeBits+1))
 }
 
 func TestSQRT(t *testing.T) {
@@ -777,11 +777,64 @@ func TestSHL(t *testing.T) {
 	prog := makeProgram(opcode.SHL)
 	t.Run("Good", getTestFuncForVM(prog, 16, 4, 2))
 	t.Run("Zero", getTestFuncForVM(prog, []byte{0, 1}, []byte{0, 1}, 0))
-	t.Run("BigShift", getTestFuncForVM(prog, nil, 5, maxSHLArg+1))
+	t.Run("BigShift", getTestFuncForVM(prog, nil, 5, stackitem.MaxBigIntegerSizeBits+1))
 	t.Run("BigResult", getTestFuncForVM(prog, nil, getBigInt(stackitem.MaxBigIntegerSizeBits/2, 0), stackitem.MaxBigIntegerSizeBits/2))
 	t.Run("very big shift", getTestFuncForVM(prog, nil, 5, maxu64Plus(1)))
 }
 
+func TestSetMaxIntegerSize(t *testing.T) {
+	const maxBits = 2 * stackitem.MaxBigIntegerSizeBits
+
+	newVM := func(prog []byte, args ...any) *VM {
+		v := newTestVM()
+		v.SetMaxIntegerSize(maxBits)
+		v.LoadScript(prog)
+		for i := range args {
+			v.estack.PushVal(args[i])
+		}
+		return v
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		v := newTestVM()
+		require.Panics(t, func() { v.SetMaxIntegerSize(stackitem.MaxBigIntegerSizeBits - 8) })
+		require.Panics(t, func() { v.SetMaxIntegerSize(stackitem.MaxExtendedIntegerSizeBits + 8) })
+		v.LoadScript(makeProgram(opcode.PUSH1))
+		require.NoError(t, v.Run())
+		require.Panics(t, func() { v.SetMaxIntegerSize(maxBits) })
+	})
+	t.Run("SHL", func(t *testing.T) {
+		v := newVM(makeProgram(opcode.SHL), 1, maxBits-2)
+		require.NoError(t, v.Run())
+		require.Equal(t, getBigInt(maxBits-2, 0), v.estack.Pop().BigInt())
+
+		v = newVM(makeProgram(opcode.SHL), 1, maxBits-1)
+		checkVMFailed(t, v)
+	})
+	t.Run("MUL", func(t *testing.T) {
+		x := getBigInt(stackitem.MaxBigIntegerSizeBits-2, 0)
+		v := newVM(makeProgram(opcode.MUL), x, x)
+		require.NoError(t, v.Run())
+		require.Equal(t, getBigInt(2*stackitem.MaxBigIntegerSizeBits-4, 0), v.estack.Pop().BigInt())
+
+		// The same operation fails with the default limit.
+		runWithArgs(t, makeProgram(opcode.MUL), nil, x, x)
+	})
+	t.Run("POW", func(t *testing.T) {
+		v := newVM(makeProgram(opcode.POW), 1, stackitem.MaxBigIntegerSizeBits+1)
+		require.NoError(t, v.Run())
+		require.Equal(t, big.NewInt(1), v.estack.Pop().BigInt())
+	})
+	t.Run("reset", func(t *testing.T) {
+		v := newVM(makeProgram(opcode.SHL), 1, maxBits-2)
+		v.Reset(trigger.Application)
+		v.LoadScript(makeProgram(opcode.SHL))
+		v.estack.PushVal(1)
+		v.estack.PushVal(maxBits - 2)
+		checkVMFailed(t, v)
+	})
+}
+
 func TestArithNullArg(t *testing.T) {
 	for _, op := range []opcode.Opcode{opcode.LT, opcode.LE, opcode.GT, opcode.GE} {
 		prog := makeProgram(op)
@@ -2766,6 +2819,33 @@ func TestRemoveReferrer(t *testing.T) {
 	assert.Equal(t, 0, int(vm.refs))
 }
 
+func TestStackStats(t *testing.T) {
+	prog := []byte{
+		byte(opcode.PUSH1), byte(opcode.PUSH2), byte(opcode.PUSH3), // 0
+		byte(opcode.PUSH3), byte(opcode.PACK), byte(opcode.DROP), // 3
+		byte(opcode.CALL), 3, // 6
+		byte(opcode.RET),   // 8
+		byte(opcode.PUSH0), // 9
+		byte(opcode.RET),
+	}
+	v := load(prog)
+	require.Equal(t, StackStats{}, v.StackStats())
+	runVM(t, v)
+	require.Equal(t, StackStats{InvocationDepth: 2, EvaluationDepth: 4, Items: 4}, v.StackStats())
+
+	v.Load(makeProgram(opcode.PUSH1))
+	runVM(t, v)
+	require.Equal(t, StackStats{InvocationDepth: 1, EvaluationDepth: 1, Items: 1}, v.StackStats())
+
+	v.Reset(trigger.Application)
+	require.Equal(t, StackStats{}, v.StackStats())
+
+	// Peak usage is recorded for failed instructions too.
+	v = load(makeProgram(opcode.PUSH1, opcode.NEWARRAY, opcode.THROW))
+	checkVMFailed(t, v)
+	require.Equal(t, StackStats{InvocationDepth: 1, EvaluationDepth: 1, Items: 2}, v.StackStats())
+}
+
 func TestUninitializedSyscallHandler(t *testing.T) {
 	v := newTestVM()
 	v.Reset(trigger.Application) // Reset SyscallHandler.
@@ -23,6 +23,9 @@ type Account struct {
 	// NEO private key.
 	privateKey *keys.PrivateKey
 
+	// External signer holding the private key (if any).
+	signer Signer
+
 	// Script hash corresponding to the Address.
 	scriptHash util.Uint160
 
@@ -45,6 +48,10 @@ type Account struct {
 
 	// Indicates whether the account is the default change account.
 	Default bool `json:"isDefault"`
+
+	// Extra contains additional account data (like HD derivation path),
+	// it can be nil.
+	Extra *AccountExtra `json:"extra,omitempty"`
 }
 
 // Contract represents a subset of the smartcontract to embed in the
@@ -140,7 +147,17 @@ func (a *Account) SignTx(net netmode.Magic, t *transaction.Transaction) error {
 	if len(a.Contract.Parameters) == 0 {
 		return nil
 	}
-	if a.privateKey == nil {
+	var sig []byte
+	switch {
+	case a.privateKey != nil:
+		sig = a.privateKey.SignHashable(uint32(net), t)
+	case a.signer != nil:
+		var err error
+		sig, err = a.signer.SignHashable(net, t)
+		if err != nil {
+			return fmt.Errorf("external signer: %w", err)
+		}
+	default:
 		return errors.New("account key is not available (need to decrypt?)")
 	}
 
@@ -148,24 +165,41 @@ func (a *Account) SignTx(net netmode.Magic, t *transaction.Transaction) error {
 		t.Scripts[pos].InvocationScript = t.Scripts[pos].InvocationScript[:0]
 	}
 	t.Scripts[pos].InvocationScript = append(t.Scripts[pos].InvocationScript, byte(opcode.PUSHDATA1), keys.SignatureLen)
-	t.Scripts[pos].InvocationScript = append(t.Scripts[pos].InvocationScript, a.privateKey.SignHashable(uint32(net), t)...)
+	t.Scripts[pos].InvocationScript = append(t.Scripts[pos].InvocationScript, sig...)
 
 	return nil
 }
 
 // SignHashable signs the given Hashable item and returns the signature. If this
-// account can't sign (CanSign() returns false) nil is returned.
+// account can't sign (CanSign() returns false) or its external signer fails
+// nil is returned.
 func (a *Account) SignHashable(net netmode.Magic, item hash.Hashable) []byte {
 	if !a.CanSign() {
 		return nil
 	}
+	if a.privateKey == nil {
+		sig, err := a.signer.SignHashable(net, item)
+		if err != nil {
+			return nil
+		}
+		return sig
+	}
 	return a.privateKey.SignHashable(uint32(net), item)
 }
 
 // CanSign returns true when account is not locked and has a decrypted private
-// key inside, so it's ready to create real signatures.
+// key inside or an external signer attached, so it's ready to create real
+// signatures.
 func (a *Account) CanSign() bool {
-	return !a.Locked && a.privateKey != nil
+	return !a.Locked && (a.privateKey != nil || a.signer != nil)
+}
+
+// SetSigner attaches an external signer to the account, it's used to sign
+// things when there is no decrypted private key in the account. The signer
+// key must be the one used by the account (or one of multisig account keys),
+// it's not checked by this method. Passing nil detaches the signer.
+func (a *Account) SetSigner(s Signer) {
+	a.signer = s
 }
 
 // GetVerificationScript returns account's verification script.
@@ -181,15 +215,18 @@ func (a *Account) GetVerificationScript() []byte {
 // things unless it's locked. Don't decrypt the key unless you want to sign
 // something and don't forget to call Close after use for maximum safety.
 func (a *Account) Decrypt(passphrase string, scrypt keys.ScryptParams) error {
-	var err error
-
 	if a.EncryptedWIF == "" {
 		return errors.New("no encrypted wif in the account")
 	}
-	a.privateKey, err = keys.NEP2Decrypt(a.EncryptedWIF, passphrase, scrypt)
+	priv, err := keys.NEP2Decrypt(a.EncryptedWIF, passphrase, scrypt)
 	if err != nil {
 		return err
 	}
+	// Previously decrypted key (if any) is not needed anymore.
+	if a.privateKey != nil {
+		a.privateKey.Destroy()
+	}
+	a.privateKey = priv
 
 	return nil
 }
@@ -220,6 +257,9 @@ func (a *Account) PublicKey() *keys.PublicKey {
 	if !a.CanSign() {
 		return nil
 	}
+	if a.privateKey == nil {
+		return a.signer.PublicKey()
+	}
 	return a.privateKey.PublicKey()
 }
 
@@ -233,10 +273,11 @@ func (a *Account) ScriptHash() util.Uint160 {
 	return a.scriptHash
 }
 
-// Close cleans up the private key used by Account and disassociates it from
-// Account. The Account can no longer sign anything after this call, but Decrypt
-// can make it usable again.
+// Close cleans up the private key used by Account and disassociates it (and
+// external signer if any) from Account. The Account can no longer sign anything
+// after this call, but Decrypt can make it usable again.
 func (a *Account) Close() {
+	a.signer = nil
 	if a.privateKey == nil {
 		return
 	}
@@ -253,6 +294,24 @@ func NewAccountFromWIF(wif string) (*Account, error) {
 	return NewAccountFromPrivateKey(privKey), nil
 }
 
+// NewSecp256k1AccountFromWIF creates a new Account from the given WIF treating
+// it as a Secp256k1 key (as used by Bitcoin), such an account has a CryptoLib
+// based verification script (see keys.PublicKey.GetVerificationScript).
+func NewSecp256k1AccountFromWIF(wif string) (*Account, error) {
+	w, err := keys.WIFDecode(wif, keys.WIFVersion)
+	if err != nil {
+		return nil, err
+	}
+	b := w.PrivateKey.Bytes()
+	defer clear(b)
+	w.PrivateKey.Destroy()
+	privKey, err := keys.NewSecp256k1PrivateKeyFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccountFromPrivateKey(privKey), nil
+}
+
 // NewAccountFromEncryptedWIF creates a new Account from the given encrypted WIF.
 func NewAccountFromEncryptedWIF(wif string, pass string, scrypt keys.ScryptParams) (*Account, error) {
 	priv, err := keys.NEP2Decrypt(wif, pass, scrypt)
@@ -301,6 +360,22 @@ func (a *Account) ConvertMultisigEncrypted(accKey *keys.PublicKey, m int, pubs [
 	return nil
 }
 
+// NewAccountFromSigner creates a standard signature account for the given
+// external signer key, this account doesn't have any private key inside.
+func NewAccountFromSigner(s Signer) *Account {
+	pubKey := s.PublicKey()
+
+	return &Account{
+		signer:     s,
+		scriptHash: pubKey.GetScriptHash(),
+		Address:    pubKey.Address(),
+		Contract: &Contract{
+			Script:     pubKey.GetVerificationScript(),
+			Parameters: getContractParams(1),
+		},
+	}
+}
+
 // NewAccountFromPrivateKey creates a wallet from the given PrivateKey.
 func NewAccountFromPrivateKey(p *keys.PrivateKey) *Account {
 	pubKey := p.PublicKey()
@@ -2,9 +2,11 @@ package wallet
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/keytestcases"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -38,6 +40,18 @@ func TestDecryptAccount(t *testing.T) {
 	// No encrypted key.
 	acc := &Account{}
 	require.Error(t, acc.Decrypt("qwerty", keys.NEP2ScryptParams()))
+
+	// Previously decrypted key is destroyed.
+	tc := keytestcases.Arr[0]
+	acc = &Account{EncryptedWIF: tc.EncryptedWif}
+	require.NoError(t, acc.Decrypt(tc.Passphrase, keys.NEP2ScryptParams()))
+	old := acc.PrivateKey()
+	require.NoError(t, acc.Decrypt(tc.Passphrase, keys.NEP2ScryptParams()))
+	require.True(t, old.IsDestroyed())
+	cur := acc.PrivateKey()
+	require.False(t, cur.IsDestroyed())
+	acc.Close()
+	require.True(t, cur.IsDestroyed())
 }
 
 func TestNewFromWif(t *testing.T) {
@@ -53,6 +67,19 @@ func TestNewFromWif(t *testing.T) {
 	}
 }
 
+func TestNewSecp256k1AccountFromWIF(t *testing.T) {
+	priv, err := keys.NewSecp256k1PrivateKey()
+	require.NoError(t, err)
+	acc, err := NewSecp256k1AccountFromWIF(priv.WIF())
+	require.NoError(t, err)
+	require.Equal(t, priv.Bytes(), acc.PrivateKey().Bytes())
+	require.Equal(t, priv.Address(), acc.Address)
+	require.Equal(t, priv.PublicKey().GetVerificationScript(), acc.Contract.Script)
+
+	_, err = NewSecp256k1AccountFromWIF("not a wif")
+	require.Error(t, err)
+}
+
 func TestNewAccountFromEncryptedWIF(t *testing.T) {
 	for _, tc := range keytestcases.Arr {
 		acc, err := NewAccountFromEncryptedWIF(tc.EncryptedWif, tc.Passphrase, keys.NEP2ScryptParams())
@@ -111,7 +138,10 @@ func TestContractSignTx(t *testing.T) {
 	require.NoError(t, err)
 	multiAcc := NewAccountFromPrivateKey(acc.privateKey)
 	require.NoError(t, multiAcc.ConvertMultisig(2, pubs))
-	multiAcc2 := NewAccountFromPrivateKey(acc2.privateKey)
+	// acc2 is closed below, so multiAcc2 needs its own copy of the key.
+	priv2, err := keys.NewPrivateKeyFromBytes(acc2.privateKey.Bytes())
+	require.NoError(t, err)
+	multiAcc2 := NewAccountFromPrivateKey(priv2)
 	require.NoError(t, multiAcc2.ConvertMultisig(2, pubs))
 
 	tx = &transaction.Transaction{
@@ -166,6 +196,74 @@ func TestContractSignTx(t *testing.T) {
 	require.Equal(t, 132, len(tx.Scripts[2].InvocationScript))
 }
 
+type keySigner struct {
+	priv *keys.PrivateKey
+	err  error
+}
+
+func (s *keySigner) PublicKey() *keys.PublicKey {
+	return s.priv.PublicKey()
+}
+
+func (s *keySigner) SignHashable(net netmode.Magic, item hash.Hashable) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.priv.SignHashable(uint32(net), item), nil
+}
+
+func TestAccount_Signer(t *testing.T) {
+	priv1, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	priv2, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	s := &keySigner{priv: priv1}
+
+	acc := NewAccountFromSigner(s)
+	require.Equal(t, priv1.Address(), acc.Address)
+	require.Equal(t, priv1.PublicKey().GetVerificationScript(), acc.Contract.Script)
+	require.True(t, acc.CanSign())
+	require.Equal(t, priv1.PublicKey(), acc.PublicKey())
+	require.Nil(t, acc.PrivateKey())
+
+	pubs := keys.PublicKeys{priv1.PublicKey(), priv2.PublicKey()}
+	multiAcc := NewAccountFromPrivateKey(priv2)
+	require.NoError(t, multiAcc.ConvertMultisig(2, pubs))
+	multiAcc2 := &Account{Address: multiAcc.Address, Contract: multiAcc.Contract}
+	require.False(t, multiAcc2.CanSign())
+	multiAcc2.SetSigner(s)
+	require.True(t, multiAcc2.CanSign())
+
+	tx := &transaction.Transaction{
+		Script: []byte{1, 2, 3},
+		Signers: []transaction.Signer{{
+			Account: acc.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		}, {
+			Account: multiAcc.ScriptHash(),
+			Scopes:  transaction.None,
+		}},
+	}
+	require.NoError(t, acc.SignTx(netmode.UnitTestNet, tx))
+	require.True(t, priv1.PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], uint32(netmode.UnitTestNet), tx))
+	require.True(t, priv1.PublicKey().VerifyHashable(acc.SignHashable(netmode.UnitTestNet, tx), uint32(netmode.UnitTestNet), tx))
+	require.NoError(t, multiAcc.SignTx(netmode.UnitTestNet, tx))
+	require.NoError(t, multiAcc2.SignTx(netmode.UnitTestNet, tx))
+	require.Equal(t, 132, len(tx.Scripts[1].InvocationScript))
+
+	s.err = errors.New("denied")
+	require.ErrorIs(t, acc.SignTx(netmode.UnitTestNet, tx), s.err)
+	require.Nil(t, acc.SignHashable(netmode.UnitTestNet, tx))
+
+	acc.Locked = true
+	require.False(t, acc.CanSign())
+	require.Nil(t, acc.PublicKey())
+
+	acc.Locked = false
+	acc.Close()
+	require.False(t, acc.CanSign())
+}
+
 func TestContract_ScriptHash(t *testing.T) {
 	script := []byte{0, 1, 2, 3}
 	c := &Contract{Script: script}
@@ -0,0 +1,179 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/hd"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+// hdSaltLen is the length of the salt used for mnemonic encryption.
+const hdSaltLen = 16
+
+var (
+	// ErrNotHD is returned when HD-specific operation is performed on a
+	// wallet without HD parameters.
+	ErrNotHD = errors.New("wallet is not hierarchical deterministic")
+	// ErrAlreadyHD is returned on attempt to initialize HD parameters of the
+	// wallet that already has them.
+	ErrAlreadyHD = errors.New("wallet is already hierarchical deterministic")
+)
+
+// HDParams contains hierarchical deterministic account derivation parameters
+// of the wallet. It's stored in the extra section of NEP-6 wallet.
+type HDParams struct {
+	// Mnemonic is BIP-39 mnemonic encrypted with the wallet passphrase
+	// (AES-256-GCM with scrypt-derived key), base64-encoded.
+	Mnemonic string `json:"mnemonic"`
+	// Path is the derivation path of wallet accounts without the last
+	// (account index) element, like "m/44'/888'/0'/0".
+	Path string `json:"path"`
+	// Next is the index of the next account to be derived.
+	Next uint32 `json:"next"`
+}
+
+// AccountExtra contains additional NEP-6 account data.
+type AccountExtra struct {
+	// Path is the full derivation path of HD wallet account.
+	Path string `json:"path,omitempty"`
+}
+
+// InitHD makes the wallet hierarchical deterministic using the given BIP-39
+// mnemonic and derivation path (hd.DefaultAccountPath is used if it's empty).
+// The mnemonic is stored encrypted with the given passphrase, it's also used
+// to encrypt accounts derived via DeriveAccount. No accounts are created by
+// this method and the wallet is not saved.
+func (w *Wallet) InitHD(mnemonic, path, passphrase string) error {
+	if w.Extra.HD != nil {
+		return ErrAlreadyHD
+	}
+	if err := hd.ValidateMnemonic(mnemonic); err != nil {
+		return err
+	}
+	if path == "" {
+		path = hd.DefaultAccountPath
+	}
+	p, err := hd.ParsePath(path)
+	if err != nil {
+		return err
+	}
+	enc, err := encryptMnemonic(mnemonic, passphrase, w.Scrypt)
+	if err != nil {
+		return err
+	}
+	w.Extra.HD = &HDParams{
+		Mnemonic: enc,
+		Path:     p.String(),
+	}
+	return nil
+}
+
+// Mnemonic decrypts and returns the BIP-39 mnemonic of HD wallet.
+func (w *Wallet) Mnemonic(passphrase string) (string, error) {
+	if w.Extra.HD == nil {
+		return "", ErrNotHD
+	}
+	return decryptMnemonic(w.Extra.HD.Mnemonic, passphrase, w.Scrypt)
+}
+
+// DeriveAccount derives the next account of HD wallet, encrypts it with the
+// given passphrase and adds it to the wallet. The account is returned in the
+// decrypted state, the wallet is not saved.
+func (w *Wallet) DeriveAccount(passphrase string) (*Account, error) {
+	mnemonic, err := w.Mnemonic(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	p, err := hd.ParsePath(w.Extra.HD.Path)
+	if err != nil {
+		return nil, err
+	}
+	master, err := hd.NewMasterKey(hd.MnemonicToSeed(mnemonic, ""))
+	if err != nil {
+		return nil, err
+	}
+	p = p.Child(w.Extra.HD.Next)
+	k, err := master.Derive(p)
+	if err != nil {
+		return nil, err
+	}
+	priv, err := k.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	acc := NewAccountFromPrivateKey(priv)
+	if err := acc.Encrypt(passphrase, w.Scrypt); err != nil {
+		return nil, err
+	}
+	acc.Extra = &AccountExtra{Path: p.String()}
+	w.AddAccount(acc)
+	w.Extra.HD.Next++
+	return acc, nil
+}
+
+func mnemonicKey(passphrase string, salt []byte, params keys.ScryptParams) ([]byte, error) {
+	return scrypt.Key(norm.NFC.Bytes([]byte(passphrase)), salt, params.N, params.R, params.P, 32)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encryptMnemonic(mnemonic, passphrase string, params keys.ScryptParams) (string, error) {
+	var salt = make([]byte, hdSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := mnemonicKey(passphrase, salt, params)
+	if err != nil {
+		return "", err
+	}
+	defer clear(key)
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	var nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	res := append(salt, nonce...)
+	res = gcm.Seal(res, nonce, []byte(mnemonic), nil)
+	return base64.StdEncoding.EncodeToString(res), nil
+}
+
+func decryptMnemonic(enc, passphrase string, params keys.ScryptParams) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(enc)
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted mnemonic: %w", err)
+	}
+	key, err := mnemonicKey(passphrase, data[:min(len(data), hdSaltLen)], params)
+	if err != nil {
+		return "", err
+	}
+	defer clear(key)
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < hdSaltLen+gcm.NonceSize() {
+		return "", errors.New("invalid encrypted mnemonic: too short")
+	}
+	data = data[hdSaltLen:]
+	res, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", errors.New("failed to decrypt mnemonic (wrong passphrase?)")
+	}
+	return string(res), nil
+}
@@ -0,0 +1,9 @@
+/*
+Package hd implements hierarchical deterministic key derivation for NEO
+wallets. It provides BIP-39 mnemonic handling (English wordlist) and SLIP-10
+private key derivation for the NIST P-256 (secp256r1) curve used by NEO.
+Derivation paths follow BIP-32/BIP-44 notation with NEO coin type 888 (see
+SLIP-44), so keys derived with [DefaultAccountPath] are compatible with other
+NEO wallets using the same scheme.
+*/
+package hd
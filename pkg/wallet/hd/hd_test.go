@@ -0,0 +1,161 @@
+package hd
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMnemonicVectors(t *testing.T) {
+	// BIP-39 reference test vectors (passphrase is "TREZOR").
+	var vectors = []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			"00000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			"80808080808080808080808080808080",
+			"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+			"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+		},
+		{
+			"ffffffffffffffffffffffffffffffff",
+			"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			"bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+		},
+		{
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+			"dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+		},
+	}
+	for _, v := range vectors {
+		entropy, err := hex.DecodeString(v.entropy)
+		require.NoError(t, err)
+
+		m, err := MnemonicFromEntropy(entropy)
+		require.NoError(t, err)
+		require.Equal(t, v.mnemonic, m)
+
+		actual, err := MnemonicToEntropy(m)
+		require.NoError(t, err)
+		require.Equal(t, entropy, actual)
+
+		require.Equal(t, v.seed, hex.EncodeToString(MnemonicToSeed(m, "TREZOR")))
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		m, err := NewMnemonic(bits)
+		require.NoError(t, err)
+		require.Equal(t, (bits+bits/32)/11, len(strings.Fields(m)))
+		require.NoError(t, ValidateMnemonic(m))
+	}
+	for _, bits := range []int{0, 96, 130, 288} {
+		_, err := NewMnemonic(bits)
+		require.ErrorIs(t, err, ErrInvalidEntropy)
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	for _, m := range []string{
+		"",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon neogo",
+	} {
+		require.ErrorIs(t, ValidateMnemonic(m), ErrInvalidMnemonic, m)
+	}
+}
+
+func TestPath(t *testing.T) {
+	p, err := ParsePath(DefaultAccountPath)
+	require.NoError(t, err)
+	require.Equal(t, Path{44 + HardenedOffset, CoinType + HardenedOffset, HardenedOffset, 0}, p)
+	require.Equal(t, DefaultAccountPath, p.String())
+	require.Equal(t, DefaultAccountPath+"/5", p.Child(5).String())
+	require.Equal(t, 4, len(p))
+
+	p, err = ParsePath("m/0h/1/2H")
+	require.NoError(t, err)
+	require.Equal(t, "m/0'/1/2'", p.String())
+
+	p, err = ParsePath("m")
+	require.NoError(t, err)
+	require.Empty(t, p)
+
+	for _, s := range []string{"", "44'/0", "m/", "m/a", "m/-1", "m/2147483648", "m/1''"} {
+		_, err = ParsePath(s)
+		require.ErrorIs(t, err, ErrInvalidPath, s)
+	}
+}
+
+func TestDerive(t *testing.T) {
+	// SLIP-10 test vector 1 for nist256p1.
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	var vectors = []struct {
+		path      string
+		chainCode string
+		priv      string
+		pub       string
+	}{
+		{"m",
+			"beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea",
+			"612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2",
+			"0266874dc6ade47b3ecd096745ca09bcd29638dd52c2c12117b11ed3e458cfa9e8"},
+		{"m/0'",
+			"3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11",
+			"6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c",
+			"0384610f5ecffe8fda089363a41f56a5c7ffc1d81b59a612d0d649b2d22355590c"},
+		{"m/0'/1",
+			"4187afff1aafa8445010097fb99d23aee9f599450c7bd140b6826ac22ba21d0c",
+			"284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129",
+			"03526c63f8d0b4bbbf9c80df553fe66742df4676b241dabefdef67733e070f6844"},
+		{"m/0'/1/2'",
+			"98c7514f562e64e74170cc3cf304ee1ce54d6b6da4f880f313e8204c2a185318",
+			"694596e8a54f252c960eb771a3c41e7e32496d03b954aeb90f61635b8e092aa7",
+			"0359cf160040778a4b14c5f4d7b76e327ccc8c4a6086dd9451b7482b5a4972dda0"},
+		{"m/0'/1/2'/2",
+			"ba96f776a5c3907d7fd48bde5620ee374d4acfd540378476019eab70790c63a0",
+			"5996c37fd3dd2679039b23ed6f70b506c6b56b3cb5e424681fb0fa64caf82aaa",
+			"029f871f4cb9e1c97f9f4de9ccd0d4a2f2a171110c61178f84430062230833ff20"},
+		{"m/0'/1/2'/2/1000000000",
+			"b9b7b82d326bb9cb5b5b121066feea4eb93d5241103c9e7a18aad40f1dde8059",
+			"21c4f269ef0a5fd1badf47eeacebeeaa3de22eb8e5b0adcd0f27dd99d34d0119",
+			"02216cd26d31147f72427a453c443ed2cde8a1e53c9cc44e5ddf739725413fe3f4"},
+	}
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+	for _, v := range vectors {
+		p, err := ParsePath(v.path)
+		require.NoError(t, err)
+		k, err := master.Derive(p)
+		require.NoError(t, err)
+		require.Equal(t, v.chainCode, hex.EncodeToString(k.ChainCode()), v.path)
+		priv, err := k.PrivateKey()
+		require.NoError(t, err)
+		require.Equal(t, v.priv, hex.EncodeToString(priv.Bytes()), v.path)
+		require.Equal(t, v.pub, hex.EncodeToString(priv.PublicKey().Bytes()), v.path)
+	}
+
+	_, err = NewMasterKey(seed[:15])
+	require.Error(t, err)
+}
@@ -0,0 +1,144 @@
+package hd
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MinEntropyBits is the minimum allowed mnemonic entropy size in bits.
+	MinEntropyBits = 128
+	// MaxEntropyBits is the maximum allowed mnemonic entropy size in bits.
+	MaxEntropyBits = 256
+	// DefaultEntropyBits is the entropy size used by NewMnemonic by default
+	// (it results in 24-word mnemonic).
+	DefaultEntropyBits = 256
+
+	// seedIterations is the number of PBKDF2 iterations used to derive seed.
+	seedIterations = 2048
+	// seedLen is the length of the seed in bytes.
+	seedLen = 64
+)
+
+var (
+	// ErrInvalidEntropy is returned when the entropy size is not a multiple of
+	// 32 bits in [MinEntropyBits, MaxEntropyBits] range.
+	ErrInvalidEntropy = errors.New("invalid entropy size")
+	// ErrInvalidMnemonic is returned for mnemonics with invalid length,
+	// unknown words or wrong checksum.
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+)
+
+//go:embed english.txt
+var englishList string
+
+var (
+	wordList  = strings.Fields(englishList)
+	wordIndex = func() map[string]int {
+		var m = make(map[string]int, len(wordList))
+		for i, w := range wordList {
+			m[w] = i
+		}
+		return m
+	}()
+)
+
+// NewMnemonic generates a new random BIP-39 mnemonic with the given entropy
+// size in bits (which must be a multiple of 32 in [MinEntropyBits,
+// MaxEntropyBits] range).
+func NewMnemonic(bits int) (string, error) {
+	if err := checkEntropyBits(bits); err != nil {
+		return "", err
+	}
+	var entropy = make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return MnemonicFromEntropy(entropy)
+}
+
+// MnemonicFromEntropy returns the BIP-39 mnemonic for the given entropy.
+func MnemonicFromEntropy(entropy []byte) (string, error) {
+	if err := checkEntropyBits(len(entropy) * 8); err != nil {
+		return "", err
+	}
+	var (
+		csBits = len(entropy) * 8 / 32
+		h      = sha256.Sum256(entropy)
+		n      = new(big.Int).SetBytes(entropy)
+		words  = make([]string, (len(entropy)*8+csBits)/11)
+		idx    = new(big.Int)
+		mask   = big.NewInt(2047)
+	)
+	n.Lsh(n, uint(csBits))
+	n.Or(n, big.NewInt(int64(h[0]>>(8-csBits))))
+	for i := len(words) - 1; i >= 0; i-- {
+		idx.And(n, mask)
+		words[i] = wordList[idx.Int64()]
+		n.Rsh(n, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy validates the given BIP-39 mnemonic and returns the
+// entropy it encodes.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	var words = strings.Fields(mnemonic)
+	if len(words)%3 != 0 || len(words)*11*32/33 < MinEntropyBits || len(words)*11*32/33 > MaxEntropyBits {
+		return nil, fmt.Errorf("%w: bad number of words %d", ErrInvalidMnemonic, len(words))
+	}
+	var n = new(big.Int)
+	for _, w := range words {
+		i, ok := wordIndex[w]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, w)
+		}
+		n.Lsh(n, 11)
+		n.Or(n, big.NewInt(int64(i)))
+	}
+	var (
+		csBits  = len(words) * 11 / 33
+		cs      = new(big.Int).And(n, big.NewInt(1<<csBits-1)).Int64()
+		entropy = make([]byte, (len(words)*11-csBits)/8)
+	)
+	n.Rsh(n, uint(csBits)).FillBytes(entropy)
+	h := sha256.Sum256(entropy)
+	if int64(h[0]>>(8-csBits)) != cs {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic checks whether the given string is a valid BIP-39
+// mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err
+}
+
+// MnemonicToSeed returns the BIP-39 seed for the given mnemonic and (possibly
+// empty) passphrase. It doesn't validate the mnemonic, use ValidateMnemonic
+// for that.
+func MnemonicToSeed(mnemonic, passphrase string) []byte {
+	var (
+		m    = norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+		salt = norm.NFKD.String("mnemonic" + passphrase)
+	)
+	return pbkdf2.Key([]byte(m), []byte(salt), seedIterations, seedLen, sha512.New)
+}
+
+func checkEntropyBits(bits int) error {
+	if bits < MinEntropyBits || bits > MaxEntropyBits || bits%32 != 0 {
+		return fmt.Errorf("%w: %d bits", ErrInvalidEntropy, bits)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package hd
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+const (
+	// HardenedOffset is the first hardened child index.
+	HardenedOffset uint32 = 0x80000000
+
+	// CoinType is the NEO coin type registered in SLIP-44.
+	CoinType = 888
+
+	// DefaultAccountPath is the default BIP-44 derivation path of NEO
+	// accounts with the account index (the last path element) omitted.
+	DefaultAccountPath = "m/44'/888'/0'/0"
+
+	// curveSeed is the SLIP-10 HMAC key used for NIST P-256 master key generation.
+	curveSeed = "Nist256p1 seed"
+)
+
+// ErrInvalidPath is returned for malformed derivation paths.
+var ErrInvalidPath = errors.New("invalid derivation path")
+
+// Path is a parsed BIP-32 derivation path, hardened indexes have
+// HardenedOffset added.
+type Path []uint32
+
+// ExtendedKey is a SLIP-10 extended private key for NIST P-256 curve.
+type ExtendedKey struct {
+	key       []byte
+	chainCode []byte
+}
+
+// ParsePath parses BIP-32 derivation path in "m/44'/888'/0'/0/0" notation
+// (both ' and h suffixes can be used for hardened indexes).
+func ParsePath(s string) (Path, error) {
+	var parts = strings.Split(strings.TrimSpace(s), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("%w: must start with \"m\"", ErrInvalidPath)
+	}
+	var res = make(Path, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		var offset uint32
+		if strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h") || strings.HasSuffix(p, "H") {
+			offset = HardenedOffset
+			p = p[:len(p)-1]
+		}
+		i, err := strconv.ParseUint(p, 10, 32)
+		if err != nil || uint32(i) >= HardenedOffset {
+			return nil, fmt.Errorf("%w: bad element %q", ErrInvalidPath, p)
+		}
+		res = append(res, uint32(i)+offset)
+	}
+	return res, nil
+}
+
+// String implements the fmt.Stringer interface, it returns the path in
+// "m/44'/888'/0'/0/0" notation.
+func (p Path) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, i := range p {
+		b.WriteByte('/')
+		if i >= HardenedOffset {
+			b.WriteString(strconv.FormatUint(uint64(i-HardenedOffset), 10))
+			b.WriteByte('\'')
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(i), 10))
+		}
+	}
+	return b.String()
+}
+
+// Child returns a copy of the path with the given index appended to it.
+func (p Path) Child(i uint32) Path {
+	return append(p[:len(p):len(p)], i)
+}
+
+// NewMasterKey creates SLIP-10 master key from the given seed (normally
+// obtained via MnemonicToSeed).
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed length %d", len(seed))
+	}
+	var n = elliptic.P256().Params().N
+	for {
+		mac := hmac.New(sha512.New, []byte(curveSeed))
+		mac.Write(seed)
+		i := mac.Sum(nil)
+		il := new(big.Int).SetBytes(i[:32])
+		if il.Sign() != 0 && il.Cmp(n) < 0 {
+			return &ExtendedKey{key: i[:32], chainCode: i[32:]}, nil
+		}
+		seed = i
+	}
+}
+
+// Child derives a child key with the given index (use HardenedOffset for
+// hardened keys).
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	var (
+		n    = elliptic.P256().Params().N
+		data = make([]byte, 0, 37)
+	)
+	if index >= HardenedOffset {
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		priv, err := k.PrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, priv.PublicKey().Bytes()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	for {
+		mac := hmac.New(sha512.New, k.chainCode)
+		mac.Write(data)
+		i := mac.Sum(nil)
+		il := new(big.Int).SetBytes(i[:32])
+		if il.Cmp(n) < 0 {
+			il.Add(il, new(big.Int).SetBytes(k.key))
+			il.Mod(il, n)
+			if il.Sign() != 0 {
+				return &ExtendedKey{key: il.FillBytes(make([]byte, 32)), chainCode: i[32:]}, nil
+			}
+		}
+		data = append([]byte{1}, i[32:]...)
+		data = binary.BigEndian.AppendUint32(data, index)
+	}
+}
+
+// Derive derives a key for the given path relative to k (which is expected
+// to be the master key for absolute paths).
+func (k *ExtendedKey) Derive(path Path) (*ExtendedKey, error) {
+	var (
+		res = k
+		err error
+	)
+	for _, i := range path {
+		res, err = res.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// PrivateKey returns the private key corresponding to the extended key.
+func (k *ExtendedKey) PrivateKey() (*keys.PrivateKey, error) {
+	return keys.NewPrivateKeyFromBytes(k.key)
+}
+
+// ChainCode returns the chain code of the extended key.
+func (k *ExtendedKey) ChainCode() []byte {
+	return k.chainCode
+}
@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/hd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWallet_HD(t *testing.T) {
+	const (
+		mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+		pass     = "pass"
+	)
+	w := NewInMemoryWallet()
+	w.Scrypt = keys.ScryptParams{N: 2, R: 1, P: 1}
+
+	_, err := w.Mnemonic(pass)
+	require.ErrorIs(t, err, ErrNotHD)
+	_, err = w.DeriveAccount(pass)
+	require.ErrorIs(t, err, ErrNotHD)
+
+	require.ErrorIs(t, w.InitHD("abandon about", "", pass), hd.ErrInvalidMnemonic)
+	require.ErrorIs(t, w.InitHD(mnemonic, "44'/888'", pass), hd.ErrInvalidPath)
+	require.Nil(t, w.Extra.HD)
+
+	require.NoError(t, w.InitHD(mnemonic, "", pass))
+	require.ErrorIs(t, w.InitHD(mnemonic, "", pass), ErrAlreadyHD)
+	require.Equal(t, hd.DefaultAccountPath, w.Extra.HD.Path)
+	require.Empty(t, w.Accounts)
+
+	m, err := w.Mnemonic(pass)
+	require.NoError(t, err)
+	require.Equal(t, mnemonic, m)
+	_, err = w.Mnemonic("wrong")
+	require.Error(t, err)
+	_, err = w.DeriveAccount("wrong")
+	require.Error(t, err)
+
+	master, err := hd.NewMasterKey(hd.MnemonicToSeed(mnemonic, ""))
+	require.NoError(t, err)
+	basePath, err := hd.ParsePath(hd.DefaultAccountPath)
+	require.NoError(t, err)
+	for i := range uint32(3) {
+		acc, err := w.DeriveAccount(pass)
+		require.NoError(t, err)
+		require.Equal(t, i+1, w.Extra.HD.Next)
+		require.Equal(t, basePath.Child(i).String(), acc.Extra.Path)
+
+		k, err := master.Derive(basePath.Child(i))
+		require.NoError(t, err)
+		priv, err := k.PrivateKey()
+		require.NoError(t, err)
+		require.Equal(t, priv.Address(), acc.Address)
+
+		require.NoError(t, acc.Decrypt(pass, w.Scrypt))
+		require.Equal(t, priv.Bytes(), acc.PrivateKey().Bytes())
+	}
+	require.Len(t, w.Accounts, 3)
+
+	t.Run("persist", func(t *testing.T) {
+		w.SetPath(filepath.Join(t.TempDir(), "wallet.json"))
+		require.NoError(t, w.Save())
+
+		w2, err := NewWalletFromFile(w.Path())
+		require.NoError(t, err)
+		require.Equal(t, w.Extra.HD, w2.Extra.HD)
+		for i := range w.Accounts {
+			require.Equal(t, w.Accounts[i].Extra, w2.Accounts[i].Extra)
+		}
+
+		acc, err := w2.DeriveAccount(pass)
+		require.NoError(t, err)
+		require.Equal(t, hd.DefaultAccountPath+"/3", acc.Extra.Path)
+	})
+
+	t.Run("custom path", func(t *testing.T) {
+		var defAddr = w.Accounts[0].Address
+		w := NewInMemoryWallet()
+		w.Scrypt = keys.ScryptParams{N: 2, R: 1, P: 1}
+		require.NoError(t, w.InitHD(mnemonic, "m/44h/888h/1h/0", pass))
+		require.Equal(t, "m/44'/888'/1'/0", w.Extra.HD.Path)
+		acc, err := w.DeriveAccount(pass)
+		require.NoError(t, err)
+		require.Equal(t, "m/44'/888'/1'/0/0", acc.Extra.Path)
+		require.NotEqual(t, defAddr, acc.Address)
+	})
+
+	t.Run("regular wallet JSON", func(t *testing.T) {
+		w := NewInMemoryWallet()
+		acc, err := NewAccount()
+		require.NoError(t, err)
+		w.AddAccount(acc)
+		data, err := json.Marshal(w)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), `"HD"`)
+		require.NotContains(t, string(data), `"extra":{"path"`)
+	})
+}
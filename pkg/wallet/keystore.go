@@ -0,0 +1,147 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// PasswordGetter returns the password for the account with the given address
+// and a flag showing whether the password is known.
+type PasswordGetter func(addr string) (string, bool)
+
+// Keystore is a set of NEP-6 wallets used by services to retrieve accounts.
+// Wallet files are read on the first keystore access and accounts are
+// decrypted on demand using passwords provided by PasswordGetter. It's safe
+// for concurrent use.
+type Keystore struct {
+	files    []string
+	password PasswordGetter
+
+	lock    sync.Mutex
+	loaded  bool
+	wallets []*Wallet
+}
+
+// NewKeystore creates a keystore from the given wallet files. Files are not
+// read until the keystore is used.
+func NewKeystore(password PasswordGetter, files ...string) *Keystore {
+	return &Keystore{
+		files:    files,
+		password: password,
+	}
+}
+
+// NewKeystoreFromDir creates a keystore from all JSON files of the given
+// directory (subdirectories are not traversed).
+func NewKeystoreFromDir(dir string, password PasswordGetter) (*Keystore, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+	if !fi.IsDir() {
+		return nil, fmt.Errorf("keystore: %s is not a directory", dir)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("keystore: no wallet files found in %s", dir)
+	}
+	return NewKeystore(password, files...), nil
+}
+
+// load reads all keystore wallet files if they're not yet read. It must be
+// called with the lock held.
+func (k *Keystore) load() error {
+	if k.loaded {
+		return nil
+	}
+	wallets := make([]*Wallet, 0, len(k.files))
+	for _, path := range k.files {
+		w, err := NewWalletFromFile(path)
+		if err != nil {
+			for _, w := range wallets {
+				w.Close()
+			}
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		wallets = append(wallets, w)
+	}
+	k.wallets = wallets
+	k.loaded = true
+	return nil
+}
+
+// unlock decrypts the given account of the given wallet if needed.
+func (k *Keystore) unlock(w *Wallet, acc *Account) error {
+	if acc.CanSign() {
+		return nil
+	}
+	pass, ok := k.password(acc.Address)
+	if !ok {
+		return fmt.Errorf("no password for account %s", acc.Address)
+	}
+	return acc.Decrypt(pass, w.Scrypt)
+}
+
+// GetAccount returns an unlocked account corresponding to the provided script
+// hash. It returns nil and no error if there is no such account in the
+// keystore.
+func (k *Keystore) GetAccount(h util.Uint160) (*Account, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if err := k.load(); err != nil {
+		return nil, err
+	}
+	for _, w := range k.wallets {
+		if acc := w.GetAccount(h); acc != nil {
+			if err := k.unlock(w, acc); err != nil {
+				return nil, err
+			}
+			return acc, nil
+		}
+	}
+	return nil, nil
+}
+
+// Unlock decrypts all keystore accounts that can be decrypted with known
+// passwords. It returns an error if wallets can't be read or if none of the
+// accounts can be unlocked.
+func (k *Keystore) Unlock() error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if err := k.load(); err != nil {
+		return err
+	}
+	var unlocked bool
+	for _, w := range k.wallets {
+		if slices.ContainsFunc(w.Accounts, func(acc *Account) bool {
+			return k.unlock(w, acc) == nil
+		}) {
+			unlocked = true
+		}
+	}
+	if !unlocked {
+		return errors.New("no wallet account could be unlocked")
+	}
+	return nil
+}
+
+// Close closes all loaded keystore wallets, see [Wallet.Close].
+func (k *Keystore) Close() {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	for _, w := range k.wallets {
+		w.Close()
+	}
+}
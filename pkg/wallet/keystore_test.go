@@ -0,0 +1,90 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newKeystoreWallet(t *testing.T, path string, passwords ...string) []*Account {
+	w, err := NewWallet(path)
+	require.NoError(t, err)
+	w.Scrypt = keys.ScryptParams{N: 2, R: 1, P: 1}
+	accs := make([]*Account, 0, len(passwords))
+	for _, pass := range passwords {
+		acc, err := NewAccount()
+		require.NoError(t, err)
+		require.NoError(t, acc.Encrypt(pass, w.Scrypt))
+		w.AddAccount(acc)
+		accs = append(accs, acc)
+	}
+	require.NoError(t, w.Save())
+	w.Close()
+	return accs
+}
+
+func TestKeystore(t *testing.T) {
+	dir := t.TempDir()
+	accs := newKeystoreWallet(t, filepath.Join(dir, "one.json"), "one", "two")
+	accs = append(accs, newKeystoreWallet(t, filepath.Join(dir, "two.json"), "three")...)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("not a wallet"), 0o644))
+
+	passwords := map[string]string{
+		accs[0].Address: "one",
+		accs[1].Address: "wrong",
+		accs[2].Address: "three",
+	}
+	getPass := func(addr string) (string, bool) {
+		pass, ok := passwords[addr]
+		return pass, ok
+	}
+
+	t.Run("bad dir", func(t *testing.T) {
+		_, err := NewKeystoreFromDir(filepath.Join(dir, "missing"), getPass)
+		require.Error(t, err)
+		_, err = NewKeystoreFromDir(filepath.Join(dir, "one.json"), getPass)
+		require.Error(t, err)
+		_, err = NewKeystoreFromDir(t.TempDir(), getPass)
+		require.Error(t, err)
+	})
+
+	ks, err := NewKeystoreFromDir(dir, getPass)
+	require.NoError(t, err)
+	t.Cleanup(ks.Close)
+
+	acc, err := ks.GetAccount(accs[0].ScriptHash())
+	require.NoError(t, err)
+	require.True(t, acc.CanSign())
+	require.Equal(t, accs[0].Address, acc.Address)
+
+	_, err = ks.GetAccount(accs[1].ScriptHash())
+	require.Error(t, err)
+
+	acc, err = ks.GetAccount(accs[2].ScriptHash())
+	require.NoError(t, err)
+	require.True(t, acc.CanSign())
+
+	acc, err = ks.GetAccount(util.Uint160{1, 2, 3})
+	require.NoError(t, err)
+	require.Nil(t, acc)
+
+	require.NoError(t, ks.Unlock())
+
+	t.Run("no password", func(t *testing.T) {
+		ks := NewKeystore(func(string) (string, bool) { return "", false }, filepath.Join(dir, "two.json"))
+		_, err := ks.GetAccount(accs[2].ScriptHash())
+		require.ErrorContains(t, err, "no password")
+		require.Error(t, ks.Unlock())
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		ks := NewKeystore(getPass, filepath.Join(dir, "missing.json"))
+		_, err := ks.GetAccount(accs[0].ScriptHash())
+		require.Error(t, err)
+		require.Error(t, ks.Unlock())
+	})
+}
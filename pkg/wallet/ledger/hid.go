@@ -0,0 +1,102 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// VendorID is the USB vendor ID of Ledger devices.
+	VendorID = 0x2c97
+
+	hidPacketSize = 64
+	hidChannel    = 0x0101
+	hidTagAPDU    = 0x05
+	// hidHeaderSize is channel (2 bytes), tag (1 byte) and sequence number
+	// (2 bytes) header of every HID packet.
+	hidHeaderSize = 5
+)
+
+// ErrNoDevice is returned when no Ledger device is found.
+var ErrNoDevice = errors.New("no Ledger device found")
+
+// hidTransport is a Transport implementation for Ledger HID framing protocol
+// over some raw HID device.
+type hidTransport struct {
+	dev io.ReadWriteCloser
+	// reportID denotes whether written packets should be prefixed with HID
+	// report ID (zero for Ledger devices).
+	reportID bool
+}
+
+// wrapAPDU splits the given command APDU into HID packets.
+func wrapAPDU(apdu []byte) [][]byte {
+	var (
+		data    = binary.BigEndian.AppendUint16(nil, uint16(len(apdu)))
+		packets [][]byte
+	)
+	data = append(data, apdu...)
+	for seq := 0; len(data) != 0; seq++ {
+		var p = make([]byte, hidPacketSize)
+		binary.BigEndian.PutUint16(p, hidChannel)
+		p[2] = hidTagAPDU
+		binary.BigEndian.PutUint16(p[3:], uint16(seq))
+		n := copy(p[hidHeaderSize:], data)
+		data = data[n:]
+		packets = append(packets, p)
+	}
+	return packets
+}
+
+// unwrapAPDU reads HID packets from the given reader and returns the response
+// APDU (including status word) assembled from them.
+func unwrapAPDU(r io.Reader) ([]byte, error) {
+	var (
+		p    = make([]byte, hidPacketSize)
+		resp []byte
+		size int
+	)
+	for seq := 0; seq == 0 || len(resp) < size; seq++ {
+		if _, err := io.ReadFull(r, p); err != nil {
+			return nil, err
+		}
+		if binary.BigEndian.Uint16(p) != hidChannel || p[2] != hidTagAPDU {
+			return nil, errors.New("invalid HID packet header")
+		}
+		if s := binary.BigEndian.Uint16(p[3:]); int(s) != seq {
+			return nil, fmt.Errorf("invalid HID packet sequence %d (expected %d)", s, seq)
+		}
+		data := p[hidHeaderSize:]
+		if seq == 0 {
+			size = int(binary.BigEndian.Uint16(data))
+			data = data[2:]
+			resp = make([]byte, 0, size)
+		}
+		resp = append(resp, data[:min(len(data), size-len(resp))]...)
+	}
+	return resp, nil
+}
+
+// Exchange implements Transport interface.
+func (t *hidTransport) Exchange(apdu []byte) ([]byte, error) {
+	for _, p := range wrapAPDU(apdu) {
+		if t.reportID {
+			p = append([]byte{0}, p...)
+		}
+		if _, err := t.dev.Write(p); err != nil {
+			return nil, fmt.Errorf("HID write: %w", err)
+		}
+	}
+	resp, err := unwrapAPDU(t.dev)
+	if err != nil {
+		return nil, fmt.Errorf("HID read: %w", err)
+	}
+	return resp, nil
+}
+
+// Close implements Transport interface.
+func (t *hidTransport) Close() error {
+	return t.dev.Close()
+}
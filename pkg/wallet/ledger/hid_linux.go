@@ -0,0 +1,61 @@
+//go:build linux
+
+package ledger
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// hidrawSysfs is a sysfs directory with hidraw devices.
+const hidrawSysfs = "/sys/class/hidraw"
+
+// OpenHID opens the first Ledger device found via Linux hidraw interface.
+// The user should have read/write permissions for the device file which
+// usually requires installing Ledger udev rules.
+func OpenHID() (Transport, error) {
+	devs, err := os.ReadDir(hidrawSysfs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNoDevice, err)
+	}
+	for _, d := range devs {
+		if !isLedger(filepath.Join(hidrawSysfs, d.Name(), "device", "uevent")) {
+			continue
+		}
+		f, err := os.OpenFile(filepath.Join("/dev", d.Name()), os.O_RDWR, 0)
+		if err != nil {
+			return nil, fmt.Errorf("can't open Ledger device: %w", err)
+		}
+		return &hidTransport{dev: f, reportID: true}, nil
+	}
+	return nil, ErrNoDevice
+}
+
+// isLedger checks HID_ID of the device described by the given uevent file
+// (like "HID_ID=0003:00002C97:00005011") against Ledger vendor ID.
+func isLedger(uevent string) bool {
+	f, err := os.Open(uevent)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		id, ok := strings.CutPrefix(s.Text(), "HID_ID=")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(id, ":")
+		if len(parts) != 3 {
+			return false
+		}
+		vendor, err := strconv.ParseUint(parts[1], 16, 32)
+		return err == nil && vendor == VendorID
+	}
+	return false
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package ledger
+
+import "fmt"
+
+// OpenHID opens the first Ledger device found. HID devices are only supported
+// on Linux for now, so it always returns an error on other platforms.
+func OpenHID() (Transport, error) {
+	return nil, fmt.Errorf("%w: HID is not supported on this platform", ErrNoDevice)
+}
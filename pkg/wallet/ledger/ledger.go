@@ -0,0 +1,268 @@
+/*
+Package ledger implements a Ledger hardware wallet signer for the Neo N3
+Ledger application. The device keeps private keys derived with BIP-44 paths
+(see [hd.DefaultAccountPath]) and signs transactions after the user confirms
+them on the device screen. [Signer] implements [wallet.Signer] interface, so
+it can be attached to wallet accounts (including multisignature ones) with
+[wallet.Account.SetSigner].
+*/
+package ledger
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/hd"
+)
+
+// Transport is a low-level Ledger device connection exchanging APDUs.
+type Transport interface {
+	// Exchange sends the given command APDU to the device and returns the
+	// response APDU including the status word.
+	Exchange(apdu []byte) ([]byte, error)
+	// Close closes the connection.
+	Close() error
+}
+
+// Neo N3 application APDU constants.
+const (
+	cla = 0x80
+
+	insGetAppName   = 0x00
+	insGetVersion   = 0x01
+	insSignTx       = 0x02
+	insGetPublicKey = 0x04
+
+	p2LastChunk = 0x00
+	p2MoreChunk = 0x80
+
+	// maxChunkSize is the maximum APDU data size.
+	maxChunkSize = 255
+	// pathLen is the number of BIP-44 path elements accepted by the
+	// application.
+	pathLen = 5
+
+	swOK     = 0x9000
+	swDenied = 0x6985
+)
+
+var (
+	// ErrDenied is returned when the user rejects the operation on the device.
+	ErrDenied = errors.New("operation denied by user")
+	// ErrUnsupported is returned by [Signer.SignHashable] for anything except
+	// transactions.
+	ErrUnsupported = errors.New("only transactions can be signed with Ledger")
+)
+
+// Device is a Ledger device running the Neo N3 application.
+type Device struct {
+	t Transport
+}
+
+// Signer is a [wallet.Signer] implementation using the key derived with the
+// specific BIP-44 path on the Ledger device.
+type Signer struct {
+	dev  *Device
+	path hd.Path
+	pub  *keys.PublicKey
+}
+
+var _ wallet.Signer = (*Signer)(nil)
+
+// New creates a Device using the given transport.
+func New(t Transport) *Device {
+	return &Device{t: t}
+}
+
+// Open connects to the first Ledger device found via HID, see [OpenHID].
+func Open() (*Device, error) {
+	t, err := OpenHID()
+	if err != nil {
+		return nil, err
+	}
+	return New(t), nil
+}
+
+// AccountPath returns the BIP-44 path of the NEO account with the given index,
+// that is [hd.DefaultAccountPath] with the index appended.
+func AccountPath(index uint32) hd.Path {
+	p, _ := hd.ParsePath(hd.DefaultAccountPath)
+	return p.Child(index)
+}
+
+// Close closes the device connection.
+func (d *Device) Close() error {
+	return d.t.Close()
+}
+
+// exchange sends a single command to the device and returns the response data
+// if the command succeeds.
+func (d *Device) exchange(ins, p1, p2 byte, data []byte) ([]byte, error) {
+	var apdu = append([]byte{cla, ins, p1, p2, byte(len(data))}, data...)
+	resp, err := d.t.Exchange(apdu)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) < 2 {
+		return nil, errors.New("response is too short")
+	}
+	var (
+		sw   = binary.BigEndian.Uint16(resp[len(resp)-2:])
+		body = resp[:len(resp)-2]
+	)
+	switch sw {
+	case swOK:
+		return body, nil
+	case swDenied:
+		return nil, ErrDenied
+	default:
+		return nil, fmt.Errorf("device error: status %04x", sw)
+	}
+}
+
+// AppName returns the name of the application running on the device.
+func (d *Device) AppName() (string, error) {
+	resp, err := d.exchange(insGetAppName, 0, 0, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(resp), nil
+}
+
+// Version returns the version of the application running on the device.
+func (d *Device) Version() (string, error) {
+	resp, err := d.exchange(insGetVersion, 0, 0, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(resp) != 3 {
+		return "", fmt.Errorf("invalid version response length %d", len(resp))
+	}
+	return fmt.Sprintf("%d.%d.%d", resp[0], resp[1], resp[2]), nil
+}
+
+// encodePath serializes BIP-44 path in the application format.
+func encodePath(path hd.Path) ([]byte, error) {
+	if len(path) != pathLen {
+		return nil, fmt.Errorf("%w: %d elements are expected", hd.ErrInvalidPath, pathLen)
+	}
+	var res = make([]byte, 0, 4*pathLen)
+	for _, p := range path {
+		res = binary.BigEndian.AppendUint32(res, p)
+	}
+	return res, nil
+}
+
+// PublicKey returns the public key derived with the given BIP-44 path.
+func (d *Device) PublicKey(path hd.Path) (*keys.PublicKey, error) {
+	data, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.exchange(insGetPublicKey, 0, 0, data)
+	if err != nil {
+		return nil, err
+	}
+	return keys.NewPublicKeyFromBytes(resp, elliptic.P256())
+}
+
+// SignTx shows the given transaction on the device and returns its 64-byte
+// signature made with the key derived with the given BIP-44 path for the given
+// network if the user confirms it.
+func (d *Device) SignTx(path hd.Path, net netmode.Magic, tx *transaction.Transaction) ([]byte, error) {
+	p, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+	txData, err := tx.EncodeHashableFields()
+	if err != nil {
+		return nil, err
+	}
+	var chunks = [][]byte{p, binary.LittleEndian.AppendUint32(nil, uint32(net))}
+	for len(txData) > maxChunkSize {
+		chunks = append(chunks, txData[:maxChunkSize])
+		txData = txData[maxChunkSize:]
+	}
+	chunks = append(chunks, txData)
+
+	var resp []byte
+	for i, c := range chunks {
+		var p2 byte = p2MoreChunk
+		if i == len(chunks)-1 {
+			p2 = p2LastChunk
+		}
+		resp, err = d.exchange(insSignTx, byte(i), p2, c)
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d: %w", i, err)
+		}
+	}
+	return decodeSignature(resp)
+}
+
+// decodeSignature converts DER-encoded ECDSA signature (optionally prefixed
+// with its length) to 64-byte r||s form.
+func decodeSignature(der []byte) ([]byte, error) {
+	if len(der) > 0 && int(der[0]) == len(der)-1 {
+		der = der[1:]
+	}
+	var sig struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("invalid signature: trailing data")
+	}
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.BitLen() > 256 || sig.S.BitLen() > 256 {
+		return nil, errors.New("invalid signature: bad R or S")
+	}
+	var res = make([]byte, keys.SignatureLen)
+	sig.R.FillBytes(res[:32])
+	sig.S.FillBytes(res[32:])
+	return res, nil
+}
+
+// NewSigner creates a signer for the key derived with the given BIP-44 path on
+// the device.
+func NewSigner(d *Device, path hd.Path) (*Signer, error) {
+	pub, err := d.PublicKey(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		dev:  d,
+		path: path,
+		pub:  pub,
+	}, nil
+}
+
+// PublicKey implements [wallet.Signer] interface.
+func (s *Signer) PublicKey() *keys.PublicKey {
+	return s.pub
+}
+
+// Path returns the BIP-44 path of the signer key.
+func (s *Signer) Path() hd.Path {
+	return s.path
+}
+
+// SignHashable implements [wallet.Signer] interface, only transactions are
+// supported.
+func (s *Signer) SignHashable(net netmode.Magic, item hash.Hashable) ([]byte, error) {
+	tx, ok := item.(*transaction.Transaction)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	return s.dev.SignTx(s.path, net, tx)
+}
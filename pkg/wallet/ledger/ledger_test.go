@@ -0,0 +1,175 @@
+package ledger
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/hd"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeApp emulates Neo N3 Ledger application.
+type fakeApp struct {
+	t      *testing.T
+	priv   *keys.PrivateKey
+	path   []byte
+	deny   bool
+	chunks [][]byte
+}
+
+func (a *fakeApp) Exchange(apdu []byte) ([]byte, error) {
+	require.True(a.t, len(apdu) >= 5)
+	require.Equal(a.t, byte(cla), apdu[0])
+	require.Equal(a.t, int(apdu[4]), len(apdu)-5)
+	var (
+		ins, p1, p2 = apdu[1], apdu[2], apdu[3]
+		data        = apdu[5:]
+		ok          = []byte{0x90, 0x00}
+	)
+	switch ins {
+	case insGetAppName:
+		return append([]byte("NEO N3"), ok...), nil
+	case insGetVersion:
+		return append([]byte{1, 2, 3}, ok...), nil
+	case insGetPublicKey:
+		require.Equal(a.t, a.path, data)
+		return append(a.priv.PublicKey().UncompressedBytes(), ok...), nil
+	case insSignTx:
+		require.Equal(a.t, len(a.chunks), int(p1))
+		a.chunks = append(a.chunks, bytes.Clone(data))
+		if p2 == p2MoreChunk {
+			return ok, nil
+		}
+		if a.deny {
+			return []byte{0x69, 0x85}, nil
+		}
+		require.Equal(a.t, a.path, a.chunks[0])
+		tx := new(transaction.Transaction)
+		require.NoError(a.t, tx.DecodeHashableFields(bytes.Join(a.chunks[2:], nil)))
+		sig := a.priv.SignHashable(binary.LittleEndian.Uint32(a.chunks[1]), tx)
+		der, err := asn1.Marshal(struct{ R, S *big.Int }{
+			R: new(big.Int).SetBytes(sig[:32]),
+			S: new(big.Int).SetBytes(sig[32:]),
+		})
+		require.NoError(a.t, err)
+		a.chunks = nil
+		return append(append([]byte{byte(len(der))}, der...), ok...), nil
+	}
+	return []byte{0x6d, 0x00}, nil
+}
+
+func (a *fakeApp) Close() error { return nil }
+
+func TestHIDFraming(t *testing.T) {
+	for _, n := range []int{0, 10, 57, 58, 59, 300} {
+		apdu := make([]byte, n)
+		for i := range apdu {
+			apdu[i] = byte(i)
+		}
+		packets := wrapAPDU(apdu)
+		for _, p := range packets {
+			require.Len(t, p, hidPacketSize)
+		}
+		actual, err := unwrapAPDU(bytes.NewReader(bytes.Join(packets, nil)))
+		require.NoError(t, err)
+		require.Equal(t, apdu, actual)
+	}
+
+	packets := wrapAPDU(make([]byte, 100))
+	packets[1][4] = 5
+	_, err := unwrapAPDU(bytes.NewReader(bytes.Join(packets, nil)))
+	require.Error(t, err)
+	_, err = unwrapAPDU(bytes.NewReader(packets[0]))
+	require.Error(t, err)
+}
+
+func TestDevice(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	path := AccountPath(1)
+	require.Equal(t, "m/44'/888'/0'/0/1", path.String())
+	app := &fakeApp{
+		t:    t,
+		priv: priv,
+		path: []byte{
+			0x80, 0, 0, 44,
+			0x80, 0, 0x03, 0x78,
+			0x80, 0, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 0, 1,
+		},
+	}
+	d := New(app)
+
+	name, err := d.AppName()
+	require.NoError(t, err)
+	require.Equal(t, "NEO N3", name)
+	ver, err := d.Version()
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3", ver)
+
+	_, err = d.PublicKey(path[:4])
+	require.ErrorIs(t, err, hd.ErrInvalidPath)
+
+	s, err := NewSigner(d, path)
+	require.NoError(t, err)
+	require.Equal(t, priv.PublicKey(), s.PublicKey())
+	require.Equal(t, path, s.Path())
+
+	tx := transaction.New(make([]byte, 600), 123)
+	tx.ValidUntilBlock = 10
+	tx.Signers = []transaction.Signer{{Account: priv.GetScriptHash()}}
+
+	sig, err := s.SignHashable(netmode.TestNet, tx)
+	require.NoError(t, err)
+	require.True(t, priv.PublicKey().VerifyHashable(sig, uint32(netmode.TestNet), tx))
+	require.Len(t, app.chunks, 0)
+
+	_, err = s.SignHashable(netmode.TestNet, &block.Header{})
+	require.ErrorIs(t, err, ErrUnsupported)
+
+	t.Run("account", func(t *testing.T) {
+		acc := wallet.NewAccountFromSigner(s)
+		require.Equal(t, priv.Address(), acc.Address)
+		require.True(t, acc.CanSign())
+		require.Equal(t, priv.PublicKey(), acc.PublicKey())
+		require.NoError(t, acc.SignTx(netmode.TestNet, tx))
+		require.Len(t, tx.Scripts, 1)
+		require.True(t, priv.PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], uint32(netmode.TestNet), tx))
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		app.deny = true
+		t.Cleanup(func() { app.deny = false; app.chunks = nil })
+		_, err := s.SignHashable(netmode.TestNet, tx)
+		require.ErrorIs(t, err, ErrDenied)
+	})
+}
+
+func TestDecodeSignature(t *testing.T) {
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{big.NewInt(1), big.NewInt(2)})
+	require.NoError(t, err)
+	sig, err := decodeSignature(der)
+	require.NoError(t, err)
+	expected := make([]byte, 64)
+	expected[31], expected[63] = 1, 2
+	require.Equal(t, expected, sig)
+
+	_, err = decodeSignature(append(der, 0))
+	require.Error(t, err)
+	_, err = decodeSignature([]byte{1, 2, 3})
+	require.Error(t, err)
+
+	der, err = asn1.Marshal(struct{ R, S *big.Int }{big.NewInt(0), big.NewInt(2)})
+	require.NoError(t, err)
+	_, err = decodeSignature(der)
+	require.Error(t, err)
+}
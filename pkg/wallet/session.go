@@ -0,0 +1,133 @@
+package wallet
+
+import (
+	"sync"
+	"time"
+)
+
+// Session keeps wallet accounts decrypted for a limited time. Accounts
+// unlocked via session are relocked (see [Account.Close]) once their TTL
+// expires, so private keys don't stay in memory longer than needed while
+// passwords don't need to be entered for every operation. It's safe for
+// concurrent use.
+type Session struct {
+	wallet *Wallet
+	ttl    time.Duration
+
+	// use is held for reading by Run callers, relocking needs it to be
+	// held for writing, so accounts can't be relocked in the middle of
+	// signing.
+	use sync.RWMutex
+
+	lock     sync.Mutex
+	unlocked map[*Account]*sessionEntry
+}
+
+// sessionEntry is an account unlocked via session.
+type sessionEntry struct {
+	// timer is nil if TTL is not limited.
+	timer   *time.Timer
+	expires time.Time
+}
+
+// NewSession creates a session for the given wallet. Accounts unlocked via
+// this session are relocked after ttl, non-positive ttl means they're kept
+// unlocked until the session is closed.
+func NewSession(w *Wallet, ttl time.Duration) *Session {
+	return &Session{
+		wallet:   w,
+		ttl:      ttl,
+		unlocked: make(map[*Account]*sessionEntry),
+	}
+}
+
+// Unlock decrypts the given wallet account with the given password and
+// (re)starts its relocking timer. The account state doesn't change if
+// decryption fails.
+func (s *Session) Unlock(acc *Account, pass string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := acc.Decrypt(pass, s.wallet.Scrypt); err != nil {
+		return err
+	}
+	if e, ok := s.unlocked[acc]; ok && e.timer != nil {
+		e.timer.Stop()
+	}
+	e := new(sessionEntry)
+	if s.ttl > 0 {
+		e.expires = time.Now().Add(s.ttl)
+		e.timer = time.AfterFunc(s.ttl, func() { s.expire(acc, e) })
+	}
+	s.unlocked[acc] = e
+	return nil
+}
+
+// expire relocks the account if e is still its current session entry.
+func (s *Session) expire(acc *Account, e *sessionEntry) {
+	s.use.Lock()
+	defer s.use.Unlock()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if cur, ok := s.unlocked[acc]; !ok || cur != e {
+		return // Relocked or unlocked again.
+	}
+	delete(s.unlocked, acc)
+	acc.Close()
+}
+
+// IsUnlocked returns true if the account is unlocked via this session and
+// its TTL hasn't yet expired (even if it's not yet relocked because of Run).
+func (s *Session) IsUnlocked(acc *Account) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.unlocked[acc]
+	return ok && (e.timer == nil || time.Now().Before(e.expires)) && acc.CanSign()
+}
+
+// Relock relocks the account unlocked via this session immediately.
+func (s *Session) Relock(acc *Account) {
+	s.use.Lock()
+	defer s.use.Unlock()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.relock(acc)
+}
+
+// relock closes the account and stops its timer, it must be called with both
+// locks held.
+func (s *Session) relock(acc *Account) {
+	e, ok := s.unlocked[acc]
+	if !ok {
+		return
+	}
+	if e.timer != nil {
+		e.timer.Stop()
+	}
+	delete(s.unlocked, acc)
+	acc.Close()
+}
+
+// Run calls f ensuring that no account is relocked by the session while f is
+// running, expired accounts are relocked after f returns. f can unlock
+// accounts, but it must not call Relock or Close.
+func (s *Session) Run(f func() error) error {
+	s.use.RLock()
+	defer s.use.RUnlock()
+	return f()
+}
+
+// Close relocks all accounts unlocked via this session.
+func (s *Session) Close() {
+	s.use.Lock()
+	defer s.use.Unlock()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for acc := range s.unlocked {
+		s.relock(acc)
+	}
+}
@@ -0,0 +1,86 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+)
+
+func newSessionAccount(t *testing.T, w *Wallet, pass string) *Account {
+	acc, err := NewAccount()
+	require.NoError(t, err)
+	require.NoError(t, acc.Encrypt(pass, w.Scrypt))
+	acc.Close()
+	w.AddAccount(acc)
+	return acc
+}
+
+// isRelocked checks whether the account was relocked by the session (not just
+// expired).
+func isRelocked(s *Session, acc *Account) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, ok := s.unlocked[acc]
+	return !ok
+}
+
+func TestSession(t *testing.T) {
+	w := NewInMemoryWallet()
+	w.Scrypt = keys.ScryptParams{N: 2, R: 1, P: 1}
+	acc := newSessionAccount(t, w, "one")
+
+	t.Run("expiration", func(t *testing.T) {
+		s := NewSession(w, 50*time.Millisecond)
+		defer s.Close()
+
+		require.Error(t, s.Unlock(acc, "wrong"))
+		require.False(t, acc.CanSign())
+		require.False(t, s.IsUnlocked(acc))
+
+		require.NoError(t, s.Unlock(acc, "one"))
+		require.True(t, s.IsUnlocked(acc))
+		priv := acc.PrivateKey()
+		require.Eventually(t, func() bool { return isRelocked(s, acc) }, time.Second, 10*time.Millisecond)
+		require.False(t, acc.CanSign())
+		require.True(t, priv.IsDestroyed())
+	})
+	t.Run("run", func(t *testing.T) {
+		s := NewSession(w, 10*time.Millisecond)
+		defer s.Close()
+
+		require.NoError(t, s.Run(func() error {
+			if err := s.Unlock(acc, "one"); err != nil {
+				return err
+			}
+			time.Sleep(50 * time.Millisecond)
+			require.True(t, acc.CanSign()) // Not relocked while running.
+			return nil
+		}))
+		require.Eventually(t, func() bool { return isRelocked(s, acc) }, time.Second, 10*time.Millisecond)
+		require.False(t, acc.CanSign())
+	})
+	t.Run("relock", func(t *testing.T) {
+		s := NewSession(w, 0)
+		defer s.Close()
+
+		require.NoError(t, s.Unlock(acc, "one"))
+		require.NoError(t, s.Unlock(acc, "one")) // Unlock again.
+		require.True(t, s.IsUnlocked(acc))
+		s.Relock(acc)
+		require.False(t, s.IsUnlocked(acc))
+		require.False(t, acc.CanSign())
+		s.Relock(acc) // No-op.
+	})
+	t.Run("close", func(t *testing.T) {
+		acc2 := newSessionAccount(t, w, "two")
+		s := NewSession(w, time.Hour)
+
+		require.NoError(t, s.Unlock(acc, "one"))
+		require.NoError(t, s.Unlock(acc2, "two"))
+		s.Close()
+		require.False(t, acc.CanSign())
+		require.False(t, acc2.CanSign())
+	})
+}
@@ -0,0 +1,21 @@
+package wallet
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// Signer is an external signing backend (like a hardware wallet) that keeps
+// the private key and can produce signatures for it. It can be attached to
+// an [Account] with [Account.SetSigner] or used to create a new one with
+// [NewAccountFromSigner].
+type Signer interface {
+	// PublicKey returns the public key corresponding to the signer's
+	// private key.
+	PublicKey() *keys.PublicKey
+	// SignHashable returns a 64-byte signature for the given item in the
+	// given network (see [keys.PrivateKey.SignHashable]). Signers can
+	// support only some specific hashables (like transactions).
+	SignHashable(net netmode.Magic, item hash.Hashable) ([]byte, error)
+}
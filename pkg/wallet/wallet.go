@@ -45,10 +45,13 @@ type Wallet struct {
 	path string
 }
 
-// Extra stores imported token contracts.
+// Extra stores imported token contracts and HD wallet parameters.
 type Extra struct {
 	// Tokens is a list of imported token contracts.
 	Tokens []*Token
+	// HD contains hierarchical deterministic account derivation parameters,
+	// it's nil for regular wallets.
+	HD *HDParams `json:",omitempty"`
 }
 
 // NewWallet creates a new NEO wallet at the given location.
@@ -237,7 +240,8 @@ func (w *Wallet) GetChangeAddress() util.Uint160 {
 
 	for i := range w.Accounts {
 		if acc == nil || w.Accounts[i].Default {
-			if w.Accounts[i].Contract != nil && vm.IsSignatureContract(w.Accounts[i].Contract.Script) {
+			if w.Accounts[i].Contract != nil && (vm.IsSignatureContract(w.Accounts[i].Contract.Script) ||
+				vm.IsSecp256k1SignatureContract(w.Accounts[i].Contract.Script)) {
 				acc = w.Accounts[i]
 				if w.Accounts[i].Default {
 					break